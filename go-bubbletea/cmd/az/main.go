@@ -3,11 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/riordanpawley/azedarach/internal/app"
 	"github.com/riordanpawley/azedarach/internal/cli"
 	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/riordanpawley/azedarach/internal/services/applog"
 )
 
 func main() {
@@ -17,9 +19,15 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
-
 	// Parse command-line arguments
-	args := os.Args[1:]
+	args, debug := stripFlag(os.Args[1:], "--debug")
+
+	// The TUI owns the terminal, so logs go to a file
+	if closer, err := applog.Install(cfg.Session.LogDir, cfg.UI.LogLevel, debug); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: logging disabled: %v\n", err)
+	} else {
+		defer closer.Close()
+	}
 
 	// If no arguments, run the TUI
 	if len(args) == 0 {
@@ -83,6 +91,106 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "keys":
+		path := ""
+		if len(commandArgs) == 1 {
+			path = commandArgs[0]
+		} else if len(commandArgs) > 1 {
+			fmt.Fprintf(os.Stderr, "Usage: az keys [file]\n")
+			os.Exit(1)
+		}
+		if err := cli.KeysCommand(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "init":
+		opts := cli.InitOptions{}
+		var positional []string
+		for _, arg := range commandArgs {
+			if arg == "--beads" {
+				opts.InitBeads = true
+				continue
+			}
+			positional = append(positional, arg)
+		}
+		if len(positional) < 1 || len(positional) > 2 {
+			fmt.Fprintf(os.Stderr, "Usage: az init <git-url> [dir] [--beads]\n")
+			os.Exit(1)
+		}
+		opts.URL = positional[0]
+		if len(positional) == 2 {
+			opts.Dir = positional[1]
+		}
+		if err := cli.InitCommand(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "serve":
+		addr := cli.DefaultServeAddr
+		if len(commandArgs) == 1 {
+			addr = commandArgs[0]
+		} else if len(commandArgs) > 1 {
+			fmt.Fprintf(os.Stderr, "Usage: az serve [addr]\n")
+			os.Exit(1)
+		}
+		if err := runCommand(cfg, func(deps *cli.Dependencies) error {
+			return cli.ServeCommand(deps, addr)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "plan":
+		opts := cli.PlanOptions{}
+		var positional []string
+		for _, arg := range commandArgs {
+			if arg == "--dry-run" {
+				opts.DryRun = true
+				continue
+			}
+			positional = append(positional, arg)
+		}
+		if len(positional) != 1 || strings.TrimSpace(positional[0]) == "" {
+			fmt.Fprintf(os.Stderr, "Usage: az plan \"<feature description>\" [--dry-run]\n")
+			os.Exit(1)
+		}
+		opts.Description = positional[0]
+		if err := runCommand(cfg, func(deps *cli.Dependencies) error {
+			return cli.PlanCommand(deps, opts)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "export":
+		opts, err := parseExportArgs(commandArgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\nUsage: az export [--format md|json] [--status s] [--priority p] [--type t] [--search q]\n", err)
+			os.Exit(1)
+		}
+		if err := runCommand(cfg, func(deps *cli.Dependencies) error {
+			return cli.ExportCommand(deps, opts)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "import":
+		paths, dryRun := stripFlag(commandArgs, "--dry-run")
+		if len(paths) != 1 {
+			fmt.Fprintf(os.Stderr, "Usage: az import <file> [--dry-run]\n")
+			os.Exit(1)
+		}
+		opts := cli.ImportOptions{Path: paths[0], DryRun: dryRun}
+		if err := runCommand(cfg, func(deps *cli.Dependencies) error {
+			return cli.ImportCommand(deps, opts)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "help", "-h", "--help":
 		cli.PrintUsage()
 
@@ -93,8 +201,22 @@ func main() {
 	}
 }
 
+// validateConfig exits listing the config's problems, if any. Only the TUI
+// and the commands that use the config check it, so that az keys and az
+// init still run with a broken one.
+func validateConfig(cfg *config.Config) {
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid config:\n")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(os.Stderr, "  - %s\n", line)
+		}
+		os.Exit(1)
+	}
+}
+
 // runTUI starts the terminal user interface
 func runTUI(cfg *config.Config) {
+	validateConfig(cfg)
 	model := app.New(cfg)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
@@ -106,9 +228,57 @@ func runTUI(cfg *config.Config) {
 
 // runCommand executes a CLI command with dependency injection
 func runCommand(cfg *config.Config, fn func(*cli.Dependencies) error) error {
+	validateConfig(cfg)
 	deps, err := cli.NewDependencies(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize dependencies: %w", err)
 	}
 	return fn(deps)
 }
+
+// stripFlag removes every occurrence of flag from args, reporting whether
+// it was present
+func stripFlag(args []string, flag string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// parseExportArgs reads the flags of `az export`, each followed by its
+// value. --status, --priority and --type may be repeated.
+func parseExportArgs(args []string) (cli.ExportOptions, error) {
+	var opts cli.ExportOptions
+	for i := 0; i < len(args); i += 2 {
+		flag := args[i]
+		switch flag {
+		case "--format", "--status", "--priority", "--type", "--search":
+		default:
+			return opts, fmt.Errorf("unknown flag %s", flag)
+		}
+		if i+1 == len(args) {
+			return opts, fmt.Errorf("%s needs a value", flag)
+		}
+		value := args[i+1]
+
+		switch flag {
+		case "--format":
+			opts.Format = value
+		case "--status":
+			opts.Statuses = append(opts.Statuses, value)
+		case "--priority":
+			opts.Priorities = append(opts.Priorities, value)
+		case "--type":
+			opts.Types = append(opts.Types, value)
+		case "--search":
+			opts.Search = value
+		}
+	}
+	return opts, nil
+}
@@ -6,7 +6,7 @@
 //
 // Usage:
 //
-//	azedarach [options]
+//	azedarach [--debug]
 //
 // For more information, see the PLAN.md file in this directory.
 package main
@@ -14,10 +14,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/riordanpawley/azedarach/internal/app"
 	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/riordanpawley/azedarach/internal/services/applog"
 )
 
 func main() {
@@ -28,6 +30,27 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid config:\n")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(os.Stderr, "  - %s\n", line)
+		}
+		os.Exit(1)
+	}
+
+	debug := false
+	for _, arg := range os.Args[1:] {
+		if arg == "--debug" {
+			debug = true
+		}
+	}
+
+	// The TUI owns the terminal, so logs go to a file
+	if closer, err := applog.Install(cfg.Session.LogDir, cfg.UI.LogLevel, debug); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: logging disabled: %v\n", err)
+	} else {
+		defer closer.Close()
+	}
 
 	model := app.New(cfg)
 	program := tea.NewProgram(
@@ -35,6 +58,7 @@ func main() {
 		tea.WithAltScreen(),       // Use alternate screen buffer
 		tea.WithMouseCellMotion(), // Enable mouse support
 	)
+	model.SetProgram(program)
 
 	if _, err := program.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// aheadBehindInterval is how often session branches are compared with the
+// base branch. Slower than the beads refresh, since it runs git in every
+// session's worktree.
+const aheadBehindInterval = time.Minute
+
+// branchCounts is how far a session's branch is from the base branch
+type branchCounts struct {
+	ahead  int
+	behind int
+}
+
+// aheadBehindMsg reports the ahead/behind counts of session branches, by
+// bead ID. Sessions whose worktree couldn't be compared are left out.
+type aheadBehindMsg struct {
+	counts map[string]branchCounts
+}
+
+// aheadBehindCmd compares each session's branch with the base branch, if
+// aheadBehindInterval has passed since the last comparison
+func (m *Model) aheadBehindCmd(now time.Time) tea.Cmd {
+	if m.gitClient == nil || now.Sub(m.aheadBehindAt) < aheadBehindInterval {
+		return nil
+	}
+
+	worktrees := make(map[string]string)
+	for beadID, session := range m.sessions {
+		if session.Worktree != "" {
+			worktrees[beadID] = session.Worktree
+		}
+	}
+	if len(worktrees) == 0 {
+		return nil
+	}
+	m.aheadBehindAt = now
+
+	client := m.gitClient
+	logger := m.logger
+	base := m.baseBranch()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		counts := make(map[string]branchCounts, len(worktrees))
+		for beadID, worktree := range worktrees {
+			ahead, behind, err := client.AheadBehind(ctx, worktree, base)
+			if err != nil {
+				logger.Debug("failed to compare session branch", "bead", beadID, "error", err)
+				continue
+			}
+			counts[beadID] = branchCounts{ahead: ahead, behind: behind}
+		}
+		return aheadBehindMsg{counts: counts}
+	}
+}
+
+// handleAheadBehind records the counts on the sessions still running
+func (m *Model) handleAheadBehind(msg aheadBehindMsg) {
+	for beadID, counts := range msg.counts {
+		if session, ok := m.sessions[beadID]; ok {
+			session.Ahead = counts.ahead
+			session.Behind = counts.behind
+		}
+	}
+}
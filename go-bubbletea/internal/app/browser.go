@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// browserOpenedMsg reports how opening a URL in the browser went
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+// browserCommand returns the command that opens url in the default browser
+// on goos
+func browserCommand(goos, url string) (name string, args []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// openURLCmd opens url in the default browser. A missing opener comes back
+// as a browserOpenedMsg error instead of a failed exec.
+func openURLCmd(url string) tea.Cmd {
+	name, args := browserCommand(runtime.GOOS, url)
+	if _, err := exec.LookPath(name); err != nil {
+		return func() tea.Msg {
+			return browserOpenedMsg{url: url, err: fmt.Errorf("no browser opener found: %s is not installed", name)}
+		}
+	}
+
+	return tea.ExecProcess(exec.Command(name, args...), func(err error) tea.Msg {
+		return browserOpenedMsg{url: url, err: err}
+	})
+}
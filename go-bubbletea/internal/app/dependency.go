@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/core/phases"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/ui/overlay"
+)
+
+// dependencyResultMsg reports adding or removing a dependency of taskID
+type dependencyResultMsg struct {
+	taskID  string
+	message string // Success toast
+	err     error
+}
+
+// dependencyEdge is a dependency as bd stores it: childID depends on
+// parentID with depType
+type dependencyEdge struct {
+	childID  string
+	parentID string
+	depType  string
+}
+
+// dependencyEdgeFor turns a link picked from taskID's side into the
+// dependency bd stores. "blocks" from taskID is stored on the other task.
+func dependencyEdgeFor(msg overlay.DependencyAddMsg) dependencyEdge {
+	switch msg.Type {
+	case domain.DependencyBlocks:
+		return dependencyEdge{childID: msg.OtherID, parentID: msg.TaskID, depType: "blocks"}
+	case domain.DependencyRelatedTo:
+		return dependencyEdge{childID: msg.TaskID, parentID: msg.OtherID, depType: "related"}
+	default:
+		return dependencyEdge{childID: msg.TaskID, parentID: msg.OtherID, depType: "blocks"}
+	}
+}
+
+// addDependency checks a picked link for cycles and adds it. A blocking
+// link that would close a cycle is refused with a warning.
+func (m *Model) addDependency(msg overlay.DependencyAddMsg) tea.Cmd {
+	edge := dependencyEdgeFor(msg)
+
+	if edge.depType == "blocks" {
+		tasks := make(map[string]domain.Task, len(m.tasks))
+		for _, task := range m.tasks {
+			tasks[task.ID] = task
+		}
+		if phases.WouldCreateCycle(tasks, edge.childID, edge.parentID) {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: fmt.Sprintf("%s already depends on %s; that link would make a cycle", edge.parentID, edge.childID),
+			})
+			return nil
+		}
+	}
+
+	client := m.beadsClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := client.AddDependency(ctx, edge.childID, edge.parentID, edge.depType)
+		return dependencyResultMsg{
+			taskID:  msg.TaskID,
+			message: fmt.Sprintf("Linked %s: %s %s", msg.TaskID, msg.Type, msg.OtherID),
+			err:     err,
+		}
+	}
+}
+
+// removeDependencyCmd removes one of taskID's dependencies
+func (m Model) removeDependencyCmd(msg overlay.DependencyRemoveMsg) tea.Cmd {
+	client := m.beadsClient
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := client.RemoveDependency(ctx, msg.TaskID, msg.Dependency.ID)
+		return dependencyResultMsg{
+			taskID:  msg.TaskID,
+			message: fmt.Sprintf("Removed %s's dependency on %s", msg.TaskID, msg.Dependency.ID),
+			err:     err,
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/services/attachment"
+	"github.com/riordanpawley/azedarach/internal/ui/board"
+)
+
+// boardExportFile is where a board export is saved when there's no
+// clipboard to copy it to, in the project directory
+const boardExportFile = "azedarach-board.md"
+
+// boardExportedMsg reports a board export finishing: copied to the
+// clipboard, or else saved to path
+type boardExportedMsg struct {
+	copied bool
+	path   string
+	err    error
+}
+
+// exportColumns returns the board's columns as filtered and sorted, with
+// collapsed columns expanded: collapsing is how Done is drawn, not a filter
+func (m Model) exportColumns() []board.Column {
+	columns := m.buildColumns()
+	filtered := m.editor.ApplyFilter(m.tasksWithSessions())
+	for i := range columns {
+		if columns[i].Collapsed {
+			columns[i].Tasks = m.sortTasksInColumn(filtered, columns[i].Status)
+			columns[i].Collapsed = false
+			columns[i].Hidden = 0
+		}
+	}
+	return columns
+}
+
+// exportBoardCmd renders the board, as filtered, as markdown and copies it
+// to the clipboard, saving it in the project when there's no clipboard
+func (m Model) exportBoardCmd() tea.Cmd {
+	doc, err := board.Export(m.exportColumns(), board.ExportMarkdown)
+	if err != nil {
+		return func() tea.Msg { return boardExportedMsg{err: err} }
+	}
+	path := filepath.Join(filepath.Dir(m.configPath()), boardExportFile)
+
+	return func() tea.Msg {
+		err := attachment.WriteTextToClipboard(context.Background(), doc)
+		if err == nil {
+			return boardExportedMsg{copied: true}
+		}
+		m.logger.Debug("failed to copy board export", "error", err)
+
+		if err := os.WriteFile(path, []byte(doc), 0644); err != nil {
+			return boardExportedMsg{err: fmt.Errorf("failed to write %s: %w", path, err)}
+		}
+		return boardExportedMsg{path: path}
+	}
+}
+
+// handleBoardExported tells the user where the board export went
+func (m Model) handleBoardExported(msg boardExportedMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.addToast(Toast{
+			Level:   ToastError,
+			Message: fmt.Sprintf("Failed to export board: %v", msg.err),
+		})
+	case msg.copied:
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: "Board copied to clipboard as markdown",
+		})
+	default:
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Board exported to %s", msg.path),
+		})
+	}
+	return m, nil
+}
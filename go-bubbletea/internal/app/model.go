@@ -3,10 +3,13 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,9 +26,12 @@ import (
 	"github.com/riordanpawley/azedarach/internal/services/editor"
 	"github.com/riordanpawley/azedarach/internal/services/git"
 	"github.com/riordanpawley/azedarach/internal/services/monitor"
+	"github.com/riordanpawley/azedarach/internal/services/multiplexer"
 	"github.com/riordanpawley/azedarach/internal/services/navigation"
 	"github.com/riordanpawley/azedarach/internal/services/network"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
 	"github.com/riordanpawley/azedarach/internal/services/pr"
+	"github.com/riordanpawley/azedarach/internal/services/sessionlog"
 	"github.com/riordanpawley/azedarach/internal/services/tmux"
 	"github.com/riordanpawley/azedarach/internal/types"
 	"github.com/riordanpawley/azedarach/internal/ui/board"
@@ -59,14 +65,18 @@ const (
 	ToastError   = types.ToastError
 )
 
-// tmuxAdapter adapts tmux.Client to satisfy monitor.TmuxClient interface
+// tmuxAdapter adapts the session multiplexer to satisfy monitor.TmuxClient interface
 type tmuxAdapter struct {
-	client *tmux.Client
+	client multiplexer.Multiplexer
+	lines  int // config.Monitor.CaptureLines
 }
 
 func (a *tmuxAdapter) CapturePane(ctx context.Context, sessionName string) (string, error) {
-	// Capture last 100 lines by default
-	return a.client.CapturePane(ctx, sessionName, 100)
+	lines := a.lines
+	if lines <= 0 {
+		lines = monitor.DefaultCaptureLines
+	}
+	return a.client.CapturePane(ctx, sessionName, lines)
 }
 
 // Re-export navigation types for compatibility
@@ -101,7 +111,36 @@ type Model struct {
 	projects       []domain.Project
 
 	// Toasts
-	toasts []Toast
+	toasts       []Toast
+	toastHistory []Toast // Every toast raised, oldest first, for the notification center
+
+	// Whether the Done column shows its cards or is collapsed to a count
+	showDone bool
+
+	// Focus mode: the cursor's column takes the board's width and the
+	// others are collapsed to their headers, for narrow terminals
+	focusMode bool
+
+	// Recently moved tasks: beadID -> highlight expiry
+	movedTasks map[string]time.Time
+
+	// Recent status changes and deletes, reversed by ctrl+z
+	undoHistory undoHistory
+
+	// Bead awaiting confirmation to start a session on a done task
+	pendingStartBeadID string
+
+	// Bead whose ended session awaits confirmation to restart in its
+	// existing worktree
+	pendingRestartBeadID string
+
+	// Existing PR awaiting confirmation to open instead of creating one
+	pendingOpenURL string
+
+	// Stale worktrees awaiting confirmation to remove, found by the sweep
+	// that runs once after the first load
+	pendingStaleWorktrees []git.Worktree
+	worktreeSweepDone     bool
 
 	// Terminal size
 	width  int
@@ -117,16 +156,27 @@ type Model struct {
 	loading        bool
 	spinner        spinner.Model
 	lastRefresh    time.Time
+	aheadBehindAt  time.Time // When session branches were last compared with base
 	hasRefreshLoop bool
+	beadsFailures  int  // Consecutive failed beads loads, drives retry backoff
+	beadsMissing   bool // bd isn't installed and the user has been told
+	refreshPaused  bool // Refresh loop stopped while the missing-bd prompt is up
 
 	// Beads client
-	beadsClient *beads.Client
+	beadsClient   *beads.Client
+	beadsRevision string // Revision of the bd list m.tasks was loaded from
 
 	// Session management services
-	tmuxClient      *tmux.Client
+	tmuxClient      multiplexer.Multiplexer
 	worktreeManager *git.WorktreeManager
 	sessionMonitor  *monitor.SessionMonitor
+	program         *programRef // Where the monitor sends session state changes
 	portAllocator   *devserver.PortAllocator
+	sessionLog      *sessionlog.Service
+	sessionLimiter  *sessionLimiter
+	startQueue      []string                 // Beads waiting for a session slot, oldest first
+	idleFlagged     map[string]bool          // Sessions already warned about under session.idleTimeoutMinutes
+	parkedProjects  map[string]parkedProject // By project directory; see switchProjectSessions
 
 	// Git services
 	gitClient      *git.Client
@@ -139,9 +189,22 @@ type Model struct {
 
 	// Image attachment service
 	attachmentService *attachment.Service
+	beadsPath         string // Current project's .beads directory
 
 	// PR workflow service
-	prWorkflow *pr.PRWorkflow
+	prWorkflow    pr.Provider
+	prProvider    string                    // pr.ProviderGitHub or pr.ProviderGitLab
+	prStates      map[string]domain.PRState // beadID -> last polled PR state
+	prMapping     pr.Mapping                // beadID -> URL of the PR created for it
+	prMappingPath string
+
+	// AI planning, which needs ANTHROPIC_API_KEY
+	planningAvailable bool
+	planner           *planning.Service // Service of the run awaiting approval
+
+	// Beads changes made while offline, replayed when back online
+	pendingChanges   *beads.PendingQueue
+	replayingChanges bool // A replay is in flight
 
 	// Dev server manager
 	devServerManager *devserver.Manager
@@ -170,9 +233,12 @@ func New(cfg *config.Config) Model {
 	beadsRunner := &beads.ExecRunner{}
 	beadsClient := beads.NewClient(beadsRunner, logger)
 
-	// Initialize tmux client
-	tmuxRunner := &tmux.ExecRunner{}
-	tmuxClient := tmux.NewClient(tmuxRunner, logger)
+	// Initialize the session multiplexer (tmux unless configured otherwise)
+	tmuxClient, err := multiplexer.New(cfg.Session.Multiplexer, logger)
+	if err != nil {
+		logger.Error("falling back to tmux", "error", err)
+		tmuxClient = tmux.NewClient(&tmux.ExecRunner{}, logger)
+	}
 
 	// Initialize git worktree manager
 	// Get current working directory as repo directory
@@ -183,16 +249,29 @@ func New(cfg *config.Config) Model {
 	}
 	gitRunner := git.NewExecRunner(repoDir)
 	worktreeManager := git.NewWorktreeManager(gitRunner, repoDir, logger)
+	worktreeManager.SetFullExistsCheck(cfg.Worktree.FullExistsCheck)
+	worktreeManager.SetLayout(cfg.Worktree.BasePath, cfg.Worktree.NameFormat)
 
 	// Initialize session monitor with tmux adapter
-	adapter := &tmuxAdapter{client: tmuxClient}
+	adapter := &tmuxAdapter{client: tmuxClient, lines: cfg.Monitor.CaptureLines}
 	sessionMonitor := monitor.NewSessionMonitor(adapter)
+	sessionMonitor.SetMinConfidence(cfg.Monitor.MinConfidence)
+	sessionMonitor.SetHistoryLines(cfg.Monitor.HistoryLines)
+	sessionMonitor.SetCaptureLines(cfg.Monitor.CaptureLines)
+	sessionMonitor.SetStuckTimeout(time.Duration(cfg.Session.StuckTimeoutMs) * time.Millisecond)
+
+	// Initialize session log persistence
+	sessionLog := sessionlog.NewService(cfg.Session.LogDir, sessionLogMaxBytes(cfg), logger)
 
-	// Initialize port allocator (base port 3000)
-	portAllocator := devserver.NewPortAllocator(3000)
+	// Initialize port allocator
+	portAllocator := devserver.NewPortAllocator(cfg.DevServer.BasePort)
 
 	// Initialize network checker
-	networkChecker := network.NewStatusChecker()
+	networkChecker, err := network.NewStatusCheckerFor(cfg.Network.CheckMethod, cfg.Network.CheckURL)
+	if err != nil {
+		logger.Error("falling back to the default network check", "error", err)
+		networkChecker = network.NewStatusChecker()
+	}
 
 	// Initialize git client (uses same runner as worktree manager)
 	gitClient := git.NewClient(gitRunner, logger)
@@ -209,6 +288,7 @@ func New(cfg *config.Config) Model {
 			DefaultProject: "",
 		}
 	}
+	gitSyncService.SetBaseBranch(config.ResolveBaseBranch(cfg, registry.FindByPath(repoDir)))
 
 	// Initialize attachment service
 	beadsPath := filepath.Join(repoDir, ".beads")
@@ -216,23 +296,40 @@ func New(cfg *config.Config) Model {
 
 	// Initialize PR workflow
 	prRunner := &pr.ExecRunner{}
-	prWorkflow := pr.NewPRWorkflow(prRunner, logger)
+	prProvider := prProviderName(cfg)
+	prWorkflow := pr.NewProvider(prProvider, prRunner, logger)
+	prMappingPath := pr.DefaultMappingPath(repoDir)
+	prMapping, err := pr.LoadMapping(prMappingPath)
+	if err != nil {
+		logger.Error("failed to load PR mapping", "error", err)
+		prMapping = pr.Mapping{}
+	}
+	pendingChanges, err := beads.OpenPendingQueue(beads.DefaultQueuePath(repoDir))
+	if err != nil {
+		logger.Error("failed to load offline queue", "error", err)
+	}
 
 	// Initialize dev server manager
 	devServerMgr := devserver.NewManager(portAllocator, logger)
 
 	// Initialize diagnostics service
-	diagService := diagnostics.NewService(tmuxClient, portAllocator, networkChecker)
+	diagService := diagnostics.NewService(tmuxClient, portAllocator, networkChecker, cfg.Diagnostics)
 
-	return Model{
+	uiStyles := styles.New()
+	uiStyles.SetCardStatusColors(cfg.Board.StatusColors)
+
+	m := Model{
 		tasks:              []domain.Task{},
 		sessions:           make(map[string]*domain.Session),
+		parkedProjects:     make(map[string]parkedProject),
 		nav:                navigation.NewService(),
 		editor:             editor.NewService(),
 		overlayStack:       overlay.NewStack(),
 		viewMode:           ViewModeBoard, // Start with board view
+		showDone:           true,
 		toasts:             []Toast{},
-		styles:             styles.New(),
+		movedTasks:         make(map[string]time.Time),
+		styles:             uiStyles,
 		config:             cfg,
 		loading:            true, // Start with loading state
 		spinner:            s,
@@ -240,6 +337,10 @@ func New(cfg *config.Config) Model {
 		tmuxClient:         tmuxClient,
 		worktreeManager:    worktreeManager,
 		sessionMonitor:     sessionMonitor,
+		program:            &programRef{},
+		sessionLimiter:     newSessionLimiter(cfg.Session.MaxConcurrent),
+		idleFlagged:        make(map[string]bool),
+		sessionLog:         sessionLog,
 		portAllocator:      portAllocator,
 		gitClient:          gitClient,
 		gitSyncService:     gitSyncService,
@@ -247,12 +348,187 @@ func New(cfg *config.Config) Model {
 		projectRegistry:    registry,
 		isOnline:           true, // Optimistically assume online
 		attachmentService:  attachmentSvc,
+		beadsPath:          beadsPath,
 		prWorkflow:         prWorkflow,
+		prProvider:         prProvider,
+		prStates:           make(map[string]domain.PRState),
+		prMapping:          prMapping,
+		prMappingPath:      prMappingPath,
+		planningAvailable:  planning.Available(),
+		pendingChanges:     pendingChanges,
 		devServerManager:   devServerMgr,
 		diagnosticsService: diagService,
 		logger:             logger,
 		usePlaceholder:     false, // Use real data from beads
 	}
+	m.beadsClient.SetCustomStatuses(m.customStatuses())
+	return m
+}
+
+// useProject points the services that read or change a repository at the
+// project in dir, so that the board, worktrees and attachments are that
+// project's
+func (m *Model) useProject(dir string) {
+	m.beadsClient = m.beadsClient.InDir(dir)
+	m.beadsRevision = ""
+
+	gitRunner := git.NewExecRunner(dir)
+	m.gitClient = git.NewClient(gitRunner, m.logger)
+	m.worktreeManager = git.NewWorktreeManager(gitRunner, dir, m.logger)
+	m.worktreeManager.SetFullExistsCheck(m.config.Worktree.FullExistsCheck)
+	m.worktreeManager.SetLayout(m.config.Worktree.BasePath, m.config.Worktree.NameFormat)
+	m.gitSyncService = git.NewGitSyncService(m.gitClient, m.networkChecker, m.config, dir, m.logger)
+	m.gitSyncService.SetBaseBranch(config.ResolveBaseBranch(m.config, m.activeProject()))
+
+	m.beadsPath = filepath.Join(dir, ".beads")
+	m.attachmentService = attachment.NewService(m.beadsPath, m.logger)
+
+	m.prProvider = prProviderName(m.config)
+	m.prWorkflow = pr.NewProvider(m.prProvider, &pr.ExecRunner{}, m.logger)
+	m.prMappingPath = pr.DefaultMappingPath(dir)
+	mapping, err := pr.LoadMapping(m.prMappingPath)
+	if err != nil {
+		m.logger.Error("failed to load PR mapping", "error", err)
+		mapping = pr.Mapping{}
+	}
+	m.prMapping = mapping
+
+	pending, err := beads.OpenPendingQueue(beads.DefaultQueuePath(dir))
+	if err != nil {
+		m.logger.Error("failed to load offline queue", "error", err)
+	}
+	m.pendingChanges = pending
+}
+
+// parkedProject holds the sessions of a project switched away from, which
+// keep running, and the session starts it had queued
+type parkedProject struct {
+	sessions   map[string]*domain.Session
+	startQueue []string
+}
+
+// switchProjectSessions parks the sessions and queued starts of the project
+// in prevDir and brings back those of the project in dir, so that each
+// board shows only its own sessions
+func (m *Model) switchProjectSessions(prevDir, dir string) {
+	if filepath.Clean(prevDir) == filepath.Clean(dir) {
+		return
+	}
+	m.parkedProjects[filepath.Clean(prevDir)] = parkedProject{sessions: m.sessions, startQueue: m.startQueue}
+
+	parked, ok := m.parkedProjects[filepath.Clean(dir)]
+	delete(m.parkedProjects, filepath.Clean(dir))
+	if !ok {
+		parked.sessions = make(map[string]*domain.Session)
+	}
+	m.sessions = parked.sessions
+	m.startQueue = parked.startQueue
+}
+
+// parkedSession returns the session for beadID of a project switched away
+// from, or nil
+func (m Model) parkedSession(beadID string) *domain.Session {
+	for _, parked := range m.parkedProjects {
+		if session, ok := parked.sessions[beadID]; ok {
+			return session
+		}
+	}
+	return nil
+}
+
+// useProjectConfig makes the config in dir's .azedarach.json or package.json,
+// over the defaults, the effective config, unless it is invalid. See
+// applyConfig for what takes effect; useProject picks up the worktree
+// layout and PR provider.
+func (m *Model) useProjectConfig(dir string) (tea.Cmd, error) {
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	*m.config = *cfg
+	return m.applyConfig(), nil
+}
+
+// applyConfig pushes m.config to the services and styles that keep their
+// own copy of a setting; the rest read the shared m.config as they go. The
+// multiplexer is chosen at startup and only changes on a restart. A raised
+// session limit starts queued sessions that now fit.
+func (m *Model) applyConfig() tea.Cmd {
+	cfg := m.config
+	m.styles.SetCardStatusColors(cfg.Board.StatusColors)
+	m.beadsClient.SetCustomStatuses(m.customStatuses())
+	m.gitSyncService.SetBaseBranch(config.ResolveBaseBranch(cfg, m.activeProject()))
+	if m.portAllocator != nil {
+		m.portAllocator.SetBasePort(cfg.DevServer.BasePort)
+	}
+	if m.diagnosticsService != nil {
+		m.diagnosticsService.SetThresholds(cfg.Diagnostics)
+	}
+	if m.networkChecker != nil {
+		if err := m.networkChecker.Reconfigure(cfg.Network.CheckMethod, cfg.Network.CheckURL); err != nil {
+			m.logger.Warn("keeping the previous network check", "error", err)
+		}
+	}
+	if m.sessionLog != nil {
+		m.sessionLog.SetDir(cfg.Session.LogDir)
+		m.sessionLog.SetMaxBytes(sessionLogMaxBytes(cfg))
+	}
+	m.sessionMonitor.SetMinConfidence(cfg.Monitor.MinConfidence)
+	m.sessionMonitor.SetHistoryLines(cfg.Monitor.HistoryLines)
+	m.sessionMonitor.SetCaptureLines(cfg.Monitor.CaptureLines)
+	m.sessionMonitor.SetStuckTimeout(m.stuckTimeout())
+
+	m.sessionLimiter.SetLimit(cfg.Session.MaxConcurrent)
+	return m.drainStartQueue()
+}
+
+// prProviderName returns the code host PRs are made on as configured, or
+// GitHub until detectPRProviderCmd has looked at the origin remote
+func prProviderName(cfg *config.Config) string {
+	if cfg.PR.Provider != "" {
+		return cfg.PR.Provider
+	}
+	return pr.ProviderGitHub
+}
+
+// prProviderDetectedMsg carries the code host dir's origin remote is on
+type prProviderDetectedMsg struct {
+	dir      string
+	provider string
+}
+
+// detectPRProviderCmd detects the code host PRs are made on from dir's
+// origin remote, unless one is configured
+func (m Model) detectPRProviderCmd(dir string) tea.Cmd {
+	if m.config.PR.Provider != "" {
+		return nil
+	}
+	gitClient, logger := m.gitClient, m.logger
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		url, err := gitClient.RemoteURL(ctx, "origin")
+		if err != nil {
+			logger.Debug("no origin remote to detect the PR provider from", "error", err)
+			return nil
+		}
+		return prProviderDetectedMsg{dir: dir, provider: pr.DetectProvider(url)}
+	}
+}
+
+// programRef holds the program running the model, shared by the model's
+// copies since the program only exists once the model has been built
+type programRef struct {
+	sender monitor.ProgramSender
+}
+
+// SetProgram gives the model the program running it, which the session
+// monitor sends state changes to. Call it before the program runs.
+func (m Model) SetProgram(program monitor.ProgramSender) {
+	m.program.sender = program
 }
 
 // Init returns the initial command for the application
@@ -260,7 +536,10 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
 		m.loadBeadsCmd(),
+		m.detectBaseBranchCmd(),
+		m.detectPRProviderCmd(m.projectDir()),
 		m.gitSyncService.FetchAndCheck(),
+		prPollEvery(prPollInterval),
 	)
 }
 
@@ -270,144 +549,472 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		return m, nil
+		m.scrollBoard()
+		// Let an open overlay reflow, e.g. the diff viewer
+		return m, m.overlayStack.Update(msg)
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
-		return m, cmd
+		// Overlays may run spinners of their own
+		return m, tea.Batch(cmd, m.overlayStack.Update(msg))
 
 	case tea.KeyMsg:
+		var result tea.Model
+		var cmd tea.Cmd
 		// If overlay is open, route to overlay stack
 		if !m.overlayStack.IsEmpty() {
-			return m.handleOverlayKey(msg)
+			result, cmd = m.handleOverlayKey(msg)
+		} else {
+			result, cmd = m.handleKey(msg)
 		}
-		return m.handleKey(msg)
+		// Keys move the cursor and change what the board shows
+		if next, ok := result.(Model); ok {
+			next.scrollBoard()
+		}
+		return result, cmd
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
+	case Toast:
+		// Commands that only have a hint to show return it as a toast
+		m.addToast(msg)
+		return m, nil
 
 	// Overlay messages
 	case overlay.CloseOverlayMsg:
 		m.overlayStack.Pop()
+		// The filter and sort menus change the board as they go
+		m.resolveCursor()
 		return m, nil
 
+	case overlay.BulkActionMsg:
+		m.overlayStack.Pop()
+		return m.handleBulkAction(msg)
+
 	case overlay.SelectionMsg:
 		if msg.Key == "git_pull" {
 			m.overlayStack.Pop()
+			if !m.requireOnline(diagnostics.FeatureGitPushPull) {
+				return m, nil
+			}
 			return m, m.gitSyncService.Pull()
 		}
 		if msg.Key == "merge_attach" {
 			m.overlayStack.Pop()
 			beadID := msg.Value.(string)
 			session := m.sessions[beadID]
+			attachHint := func() tea.Msg {
+				return commandToast("Run: " + m.tmuxClient.AttachCommand(beadID))
+			}
+			// Offline: skip the update but still attach
+			if !m.requireOnline(diagnostics.FeatureGitPushPull) {
+				return m, attachHint
+			}
 			return m, tea.Batch(
-				m.fetchAndMergeCmd(session.Worktree, m.config.Git.BaseBranch),
-				func() tea.Msg {
-					return Toast{
-						Level:   ToastInfo,
-						Message: fmt.Sprintf("Run: tmux attach-session -t %s", beadID),
-						Expires: time.Now().Add(5 * time.Second),
-					}
-				},
+				m.fetchAndMergeCmd(session.Worktree, m.baseBranch()),
+				attachHint,
 			)
 		}
+		if msg.Key == "quit_leave" {
+			m.overlayStack.Pop()
+			m.sessionMonitor.StopAll()
+			return m, tea.Quit
+		}
+		if msg.Key == "quit_stop" {
+			m.overlayStack.Pop()
+			m.sessionMonitor.StopAll()
+			return m, tea.Sequence(m.stopAllSessionsCmd(), tea.Quit)
+		}
 		if msg.Key == "skip_attach" {
 			m.overlayStack.Pop()
 			beadID := msg.Value.(string)
-			m.toasts = append(m.toasts, Toast{
-				Level:   ToastInfo,
-				Message: fmt.Sprintf("Run: tmux attach-session -t %s", beadID),
-				Expires: time.Now().Add(5 * time.Second),
-			})
+			m.addToast(commandToast("Run: " + m.tmuxClient.AttachCommand(beadID)))
 			return m, nil
 		}
 		return m.handleSelection(msg)
 
+	case overlay.DetailLoadedMsg:
+		return m, m.overlayStack.Update(msg)
+
+	case overlay.DiagnosticsRefreshMsg, overlay.DiagnosticsTickMsg:
+		// A tick reaching another overlay is dropped, ending its ticker
+		return m, m.overlayStack.Update(msg)
+
+	case staleWorktreesMsg:
+		return m, m.handleStaleWorktrees(msg)
+
+	case worktreesRemovedMsg:
+		m.handleWorktreesRemoved(msg)
+		return m, nil
+
+	case overlay.DependencyPickMsg:
+		task := m.findTask(msg.TaskID)
+		if task == nil {
+			return m, nil
+		}
+		return m, m.overlayStack.Push(overlay.NewDependencyPicker(*task, m.tasks))
+
+	case overlay.DependencyAddMsg:
+		cmd := m.addDependency(msg)
+		if cmd == nil {
+			return m, nil
+		}
+		if _, ok := m.overlayStack.Current().(*overlay.DependencyPicker); ok {
+			m.overlayStack.Pop()
+		}
+		return m, cmd
+
+	case overlay.DependencyRemoveMsg:
+		return m, m.removeDependencyCmd(msg)
+
+	case overlay.SettingsSavedMsg:
+		return m.handleSettingsSaved(msg)
+
+	case overlay.PlanningStartMsg:
+		return m, m.startPlanningCmd(msg.Description)
+
+	case overlay.PlanningConfirmMsg:
+		return m, m.confirmPlanningCmd(msg.Plan)
+
+	case overlay.PlanningDiscardMsg:
+		return m, m.discardPlanningCmd()
+
+	case planningResultMsg:
+		return m.handlePlanningResult(msg)
+
+	case planningDraftMsg:
+		return m.handlePlanningDraft(msg)
+
+	case boardExportedMsg:
+		return m.handleBoardExported(msg)
+
+	case dependencyResultMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to update dependencies of %s: %v", msg.taskID, msg.err),
+			})
+			return m, nil
+		}
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: msg.message,
+		})
+		cmds := []tea.Cmd{m.loadBeadsCmd()}
+		if detail, ok := m.overlayStack.Current().(*overlay.DetailPanel); ok {
+			cmds = append(cmds, detail.Refresh())
+		}
+		return m, tea.Batch(cmds...)
+
 	case overlay.SearchMsg:
 		m.editor.SetSearchQuery(msg.Query)
+		m.editor.SetSearchScope(msg.Scope)
+		m.resolveCursor()
 		return m, nil
 
 	case beadsLoadedMsg:
 		wasLoading := m.loading
+		m.markMovedTasks(m.tasks, msg.tasks)
 		m.tasks = msg.tasks
+		m.beadsRevision = msg.revision
+		m.applyPRStates()
+		m.applyBlockers()
+		m.applyPendingChanges()
+		// Keep the cursor on its task wherever the refresh moved it
+		m.resolveCursor()
+		if detail, ok := m.overlayStack.Current().(*overlay.DetailPanel); ok {
+			detail.SetBlockers(m.openBlockers(detail.TaskID()))
+		}
 		m.loading = false
 		m.lastRefresh = time.Now()
+		m.beadsFailures = 0
+		m.beadsMissing = false
 		// Show success toast on first load
 		if wasLoading {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastSuccess,
 				Message: "Beads loaded",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 		}
+		var cmds []tea.Cmd
+		if !m.worktreeSweepDone {
+			m.worktreeSweepDone = true
+			cmds = append(cmds, m.findStaleWorktreesCmd())
+		}
 		// Start periodic refresh only if not already running
 		if !m.hasRefreshLoop {
 			m.hasRefreshLoop = true
-			return m, tickEvery(2 * time.Second)
+			cmds = append(cmds, tickEvery(m.refreshDelay()))
 		}
-		return m, nil
+		return m, tea.Batch(cmds...)
 
 	case beadsErrorMsg:
-		m.toasts = append(m.toasts, Toast{
+		if errors.Is(msg.err, beads.ErrNotInstalled) {
+			return m, m.beadsNotInstalled(msg.err)
+		}
+		m.addToast(Toast{
 			Level:   ToastError,
 			Message: msg.err.Error(),
-			Expires: time.Now().Add(8 * time.Second),
 		})
 		m.loading = false
-		// Still schedule a refresh to retry
-		return m, tickEvery(5 * time.Second)
+		m.beadsFailures++
+		// A failing load may mean we've gone offline
+		cmds := []tea.Cmd{m.networkChecker.CheckCmd()}
+		// Still start the refresh loop so it can retry with backoff
+		if !m.hasRefreshLoop {
+			m.hasRefreshLoop = true
+			cmds = append(cmds, tickEvery(m.refreshDelay()))
+		}
+		return m, tea.Batch(cmds...)
 
 	case tickMsg:
-		// Expire old toasts and refresh beads
+		if m.refreshPaused {
+			// Let the loop lapse; retrying or dismissing the prompt restarts it
+			m.hasRefreshLoop = false
+			return m, nil
+		}
+		// Expire old toasts and highlights, refresh beads and persist session output
 		m.expireToasts()
+		m.expireMovedTasks()
+		m.flagIdleSessions(time.Time(msg))
+		aheadBehind := m.aheadBehindCmd(time.Time(msg))
+		next := tickEvery(m.refreshDelay())
+		if !m.isOnline {
+			// Don't hit the beads CLI while offline; re-check connectivity instead
+			return m, tea.Batch(
+				m.networkChecker.CheckCmd(),
+				m.persistSessionLogsCmd(),
+				m.autoPauseIdleSessionsCmd(time.Time(msg)),
+				aheadBehind,
+				next,
+			)
+		}
 		return m, tea.Batch(
 			m.loadBeadsCmd(),
 			m.gitSyncService.FetchAndCheck(),
+			m.persistSessionLogsCmd(),
+			m.autoPauseIdleSessionsCmd(time.Time(msg)),
+			m.drainStartQueue(),
+			aheadBehind,
+			next,
+		)
+
+	case prPollTickMsg:
+		if !m.isOnline {
+			return m, prPollEvery(prPollInterval)
+		}
+		return m, tea.Batch(
+			m.pollPRStatusCmd(m.prPollTargets()),
+			prPollEvery(prPollInterval),
 		)
 
+	case aheadBehindMsg:
+		m.handleAheadBehind(msg)
+		return m, nil
+
+	case prProviderDetectedMsg:
+		// A detection for a project since switched away from is stale
+		if msg.dir != m.projectDir() || msg.provider == m.prProvider {
+			return m, nil
+		}
+		m.prProvider = msg.provider
+		m.prWorkflow = pr.NewProvider(msg.provider, &pr.ExecRunner{}, m.logger)
+		return m, nil
+
+	case prStatusMsg:
+		for beadID, state := range msg.states {
+			if state == domain.PRNone {
+				delete(m.prStates, beadID)
+				continue
+			}
+			m.prStates[beadID] = state
+		}
+		m.applyPRStates()
+		return m, nil
+
 	case monitor.SessionStateMsg:
 		if session, ok := m.sessions[msg.BeadID]; ok {
 			oldState := session.State
-			session.State = msg.State
+			session.SetState(msg.State, time.Now())
+			session.BusyReason = msg.Reason
+			wasStalled := session.Stalled
+			session.Stalled = msg.Stalled
 			m.logger.Debug("session state updated", "beadID", msg.BeadID, "state", msg.State)
 
 			if oldState != msg.State && msg.State == domain.SessionWaiting {
 				fmt.Print("\a")
-				m.toasts = append(m.toasts, Toast{
+				m.addToast(Toast{
 					Level:   ToastWarning,
 					Message: fmt.Sprintf("Session %s is waiting for input", msg.BeadID),
-					Expires: time.Now().Add(10 * time.Second),
 				})
 			}
+
+			if msg.Stalled && !wasStalled {
+				m.addToast(Toast{
+					Level:   ToastWarning,
+					Message: fmt.Sprintf("Session %s has shown no new output for %s; it may be stuck", msg.BeadID, m.stuckTimeout()),
+				})
+			}
+
+			// A settled session no longer counts against session.maxConcurrent
+			var dequeue tea.Cmd
+			if isSettledState(msg.State) {
+				dequeue = m.releaseSessionSlot(msg.BeadID)
+			}
+
+			if oldState != msg.State && msg.State == domain.SessionDone && m.config.Session.ArchiveOnDone {
+				return m, tea.Batch(m.archiveSessionCmd(msg.BeadID), dequeue)
+			}
+			return m, dequeue
+		}
+		// A parked project's session keeps its state for when the project
+		// is switched back to, and still frees its slot when it settles
+		if session := m.parkedSession(msg.BeadID); session != nil {
+			session.SetState(msg.State, time.Now())
+			session.BusyReason = msg.Reason
+			session.Stalled = msg.Stalled
+			if isSettledState(msg.State) {
+				return m, m.releaseSessionSlot(msg.BeadID)
+			}
+		}
+		return m, nil
+
+	case sessionArchivedMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to archive session %s: %v", msg.beadID, msg.err),
+			})
+			return m, nil
 		}
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: fmt.Sprintf("Session %s archived to %s", msg.beadID, msg.logPath),
+		})
 		return m, nil
 
 	case sessionStartedMsg:
-		m.toasts = append(m.toasts, Toast{
+		now := time.Now()
+		m.sessions[msg.beadID] = &domain.Session{
+			BeadID:    msg.beadID,
+			State:     domain.SessionBusy,
+			StartedAt: &now,
+			Worktree:  msg.worktreePath,
+		}
+		m.sessionMonitor.Start(context.Background(), msg.beadID, m.program.sender)
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Session started: %s", msg.beadID),
-			Expires: time.Now().Add(3 * time.Second),
+		})
+		m.warnLayoutFailed(msg.layoutErr)
+		m.warnInitFailed(msg.initErr)
+		// The cursor tracks tasks by ID, so it keeps following the task
+		// as status updates move it between columns
+		if m.config.UI.FollowNewSession {
+			m.nav.JumpToTaskByID(m.buildColumns(), msg.beadID)
+		}
+		return m, nil
+
+	case sessionRestartedMsg:
+		if session, ok := m.sessions[msg.beadID]; ok {
+			session.SetState(domain.SessionBusy, time.Now())
+		}
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Session restarted: %s", msg.beadID),
+		})
+		return m, nil
+
+	case overlay.SendTextMsg:
+		m.overlayStack.Pop()
+		return m, m.sendTextCmd(msg.BeadID, msg.Text)
+
+	case sessionTextSentMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to send to %s: %v", msg.beadID, msg.err),
+			})
+			return m, nil
+		}
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Sent to %s: %s", msg.beadID, msg.text),
+		})
+		return m, nil
+
+	case sessionPausedMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to pause session %s: %v", msg.beadID, msg.err),
+			})
+			return m, nil
+		}
+		if session, ok := m.sessions[msg.beadID]; ok {
+			session.SetState(domain.SessionPaused, time.Now())
+		}
+		message := fmt.Sprintf("Session paused: %s", msg.beadID)
+		if msg.auto {
+			message = fmt.Sprintf("Session %s auto-paused after %dm idle", msg.beadID, m.config.Session.AutoPauseIdleMinutes)
+		}
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: message,
 		})
 		return m, nil
 
+	case sessionResumedMsg:
+		if session, ok := m.sessions[msg.beadID]; ok {
+			session.SetState(domain.SessionBusy, time.Now())
+		}
+		// Pausing stopped the monitor along with the pane
+		m.sessionMonitor.Start(context.Background(), msg.beadID, m.program.sender)
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Session resumed: %s", msg.beadID),
+		})
+		m.warnLayoutFailed(msg.layoutErr)
+		m.warnInitFailed(msg.initErr)
+		return m, nil
+
 	case sessionErrorMsg:
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastError,
 			Message: fmt.Sprintf("Session error: %s - %v", msg.beadID, msg.err),
-			Expires: time.Now().Add(5 * time.Second),
 		})
-		return m, nil
+		return m, m.releaseSessionSlot(msg.beadID)
 
 	case network.StatusMsg:
 		// Update online status
+		wasOnline := m.isOnline
 		m.isOnline = msg.Online
 		m.logger.Debug("network status updated", "online", msg.Online)
+		// Back online: retry right away instead of waiting out the backoff,
+		// and make the changes queued while offline
+		if !wasOnline && msg.Online {
+			m.beadsFailures = 0
+			if m.pendingChanges.Len() > 0 {
+				return m, m.replayChangesCmd()
+			}
+			return m, m.loadBeadsCmd()
+		}
 		return m, nil
 
+	case queueChangeMsg:
+		m.queueChange(msg.change)
+		return m, nil
+
+	case changesReplayedMsg:
+		return m, m.handleChangesReplayed(msg)
+
 	case git.GitSyncMsg:
 		if msg.Err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Git sync failed: %v", msg.Err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
@@ -418,116 +1025,113 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case mergeResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Merge failed: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
 
 		if msg.result.HasConflicts {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: fmt.Sprintf("Merge conflicts: %s", strings.Join(msg.result.ConflictFiles, ", ")),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, m.overlayStack.Push(overlay.NewConflictDialog(msg.result.ConflictFiles))
 		}
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Successfully merged %s into %s", msg.sourceID, msg.targetID),
-			Expires: time.Now().Add(3 * time.Second),
 		})
 		return m, m.loadBeadsCmd()
 
 	case fetchAndMergeResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			message := fmt.Sprintf("Merge failed: %v", msg.err)
+			if msg.stashed && msg.popResult == nil {
+				message += "; your changes are in git stash"
+			}
+			m.addToast(Toast{
 				Level:   ToastError,
-				Message: fmt.Sprintf("Merge failed: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
+				Message: message,
 			})
 			return m, nil
 		}
 
 		if msg.result.HasConflicts {
 			// Show conflict dialog
-			m.toasts = append(m.toasts, Toast{
+			message := fmt.Sprintf("Merge conflicts in %d files", len(msg.result.ConflictFiles))
+			if msg.stashed {
+				message += "; run git stash pop after resolving them"
+			}
+			m.addToast(Toast{
 				Level:   ToastWarning,
-				Message: fmt.Sprintf("Merge conflicts in %d files", len(msg.result.ConflictFiles)),
-				Expires: time.Now().Add(3 * time.Second),
+				Message: message,
 			})
 			return m, m.overlayStack.Push(overlay.NewConflictDialog(msg.result.ConflictFiles))
 		}
 
+		if msg.popResult != nil && msg.popResult.HasConflicts {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: fmt.Sprintf("Updated from %s, but restoring stashed changes conflicts in %d files", msg.branch, len(msg.popResult.ConflictFiles)),
+			})
+			return m, m.overlayStack.Push(overlay.NewConflictDialog(msg.popResult.ConflictFiles))
+		}
+
 		// Successful merge
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
-			Message: "Updated from main successfully",
-			Expires: time.Now().Add(3 * time.Second),
+			Message: fmt.Sprintf("Updated from %s successfully", msg.branch),
 		})
+		m.aheadBehindAt = time.Time{} // Recount on the next tick
 		return m, nil
 
 	case createPRResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to get branch info: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
+			})
+			return m, nil
+		}
+
+		if msg.copied {
+			m.addToast(Toast{
+				Level:   ToastSuccess,
+				Message: fmt.Sprintf("PR command copied: %s", msg.cmd),
 			})
 			return m, nil
 		}
 
 		// Show PR command in toast
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: fmt.Sprintf("Run: %s", msg.cmd),
-			Expires: time.Now().Add(10 * time.Second),
-		})
+		m.addToast(commandToast(fmt.Sprintf("Run: %s", msg.cmd)))
 		return m, nil
 
 	case showDiffResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to get diff: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
 
-		// Show abbreviated diff in toast
-		diff := msg.diff
-		if len(diff) > 200 {
-			diff = diff[:200] + "..."
-		}
-		if diff == "" {
-			diff = "No changes"
-		}
-
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: diff,
-			Expires: time.Now().Add(8 * time.Second),
-		})
-		return m, nil
+		return m, m.overlayStack.Push(overlay.NewDiffSummaryOverlay(msg.beadID, msg.base, msg.summary))
 
 	case abortMergeResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to abort merge: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: "Merge aborted successfully",
-			Expires: time.Now().Add(3 * time.Second),
 		})
 		return m, nil
 
@@ -546,15 +1150,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.overlayStack.Pop()
 
 		// Switch to selected project
+		prevDir := m.projectDir()
 		m.currentProject = msg.Project.Name
-		m.toasts = append(m.toasts, Toast{
+		var cmds []tea.Cmd
+		if msg.Project.Path != "" {
+			m.switchProjectSessions(prevDir, msg.Project.Path)
+			cmd, err := m.useProjectConfig(msg.Project.Path)
+			if err != nil {
+				m.addToast(Toast{
+					Level:   ToastWarning,
+					Message: fmt.Sprintf("Keeping current config: %v", err),
+				})
+			}
+			m.useProject(msg.Project.Path)
+			cmds = append(cmds, cmd, m.detectBaseBranchCmd(), m.detectPRProviderCmd(msg.Project.Path))
+		}
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Switched to project: %s", msg.Project.Name),
-			Expires: time.Now().Add(3 * time.Second),
 		})
 
 		// Reload beads for new project
-		return m, m.loadBeadsCmd()
+		return m, tea.Batch(append(cmds, m.loadBeadsCmd())...)
 
 	case overlay.TaskCreatedMsg:
 		m.overlayStack.Pop()
@@ -562,18 +1179,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case taskCreatedResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to create task: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Task created: %s", msg.taskID),
-			Expires: time.Now().Add(3 * time.Second),
 		})
 
 		// Reload beads to show new task
@@ -582,22 +1197,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// PR creation overlay messages
 	case overlay.PRCreatedMsg:
 		m.overlayStack.Pop()
+		if !m.requireOnline(diagnostics.FeaturePRCreation) {
+			return m, nil
+		}
 		return m, m.createPRWithOverlayCmd(msg)
 
 	case prCreatedResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to create PR: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("PR created: %s", msg.url),
-			Expires: time.Now().Add(5 * time.Second),
 		})
+		m.recordPR(msg.beadID, msg.url)
+		if m.config.PR.OpenInBrowser && msg.url != "" {
+			return m, openURLCmd(msg.url)
+		}
+		return m, nil
+
+	case browserOpenedMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: fmt.Sprintf("Couldn't open browser (%v); PR is at %s", msg.err, msg.url),
+			})
+		}
 		return m, nil
 
 	// Diff viewer messages
@@ -619,10 +1248,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Image attachment messages
 	case overlay.AttachmentActionMsg:
 		if msg.Action == "attached" {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastSuccess,
 				Message: fmt.Sprintf("Image attached: %s", msg.Attachment.Filename),
-				Expires: time.Now().Add(3 * time.Second),
 			})
 		}
 		return m, nil
@@ -631,10 +1259,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case overlay.CleanupExecutedMsg:
 		m.overlayStack.Pop()
 		if msg.Error != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Cleanup failed: %v", msg.Error),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
@@ -660,84 +1287,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			message = fmt.Sprintf("Cleanup: %s", strings.Join(operations, ", "))
 		}
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: message,
-			Expires: time.Now().Add(5 * time.Second),
 		})
 
 		// Reload beads to reflect changes
 		return m, m.loadBeadsCmd()
 
+	case sessionGoneMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to check session: %v", msg.err),
+			})
+			return m, nil
+		}
+		// Offer a restart rather than an attach command that would fail. The
+		// worktree is still there, so restart in it rather than creating one.
+		if session, ok := m.sessions[msg.beadID]; ok && session.Worktree != "" {
+			m.pendingRestartBeadID = msg.beadID
+		} else {
+			m.pendingStartBeadID = msg.beadID
+		}
+		return m, m.overlayStack.Push(overlay.NewConfirmDialog(
+			"Session Ended",
+			fmt.Sprintf("%s's session is no longer running.\nRestart it?", msg.beadID),
+		))
+
 	case branchBehindMsg:
 		if msg.err != nil {
 			m.logger.Warn("failed to check branch distance", "beadID", msg.beadID, "error", msg.err)
 			// Proceed to attach anyway if check fails
-			m.toasts = append(m.toasts, Toast{
-				Level:   ToastInfo,
-				Message: fmt.Sprintf("Run: tmux attach-session -t %s", msg.beadID),
-				Expires: time.Now().Add(5 * time.Second),
-			})
+			m.addToast(commandToast("Run: " + m.tmuxClient.AttachCommand(msg.beadID)))
 			return m, nil
 		}
 
 		if msg.commitsBehind > 0 {
 			// Show merge choice overlay
-			m.overlayStack.Push(overlay.NewMergeChoiceOverlay(msg.beadID, msg.commitsBehind, m.config.Git.BaseBranch))
+			m.overlayStack.Push(overlay.NewMergeChoiceOverlay(msg.beadID, msg.commitsBehind, m.baseBranch()))
 			return m, nil
 		}
 
 		// Not behind, attach directly
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: fmt.Sprintf("Run: tmux attach-session -t %s", msg.beadID),
-			Expires: time.Now().Add(5 * time.Second),
-		})
+		m.addToast(commandToast("Run: " + m.tmuxClient.AttachCommand(msg.beadID)))
 		return m, nil
 
 	case openPROverlayResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to get branch info: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
-		return m, m.overlayStack.Push(overlay.NewPRCreateOverlay(msg.branch, m.config.Git.BaseBranch, msg.beadID))
+		if msg.existing != nil {
+			// Offer the PR already on the branch instead of a duplicate
+			m.recordPR(msg.beadID, msg.existing.URL)
+			m.pendingOpenURL = msg.existing.URL
+			return m, m.overlayStack.Push(overlay.NewConfirmDialog(
+				"PR Exists",
+				fmt.Sprintf("%s already has PR #%d.\nOpen it in the browser?", msg.branch, msg.existing.Number),
+			))
+		}
+		prOverlay := overlay.NewPRCreateOverlay(msg.branch, m.baseBranch(), msg.beadID)
+		if task := m.findTask(msg.beadID); task != nil {
+			prOverlay.SetDefaults(m.prDefaults(*task))
+		}
+		return m, m.overlayStack.Push(prOverlay)
 
 	case taskDeletedResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to delete task: %v", msg.err),
-				Expires: time.Now().Add(3 * time.Second),
 			})
 			return m, nil
 		}
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
-			Message: fmt.Sprintf("Task %s deleted", msg.taskID),
-			Expires: time.Now().Add(2 * time.Second),
+			Message: fmt.Sprintf("Task %s deleted (ctrl+z to undo)", msg.taskID),
 		})
+		// Keep a copy while the board still has it, to recreate on undo
+		if task := m.findTask(msg.taskID); task != nil {
+			m.undoHistory = m.undoHistory.push(undoAction{kind: undoDelete, taskID: task.ID, task: *task})
+		}
 		return m, m.loadBeadsCmd()
 
 	case taskStatusResultMsg:
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Failed to update task: %v", msg.err),
-				Expires: time.Now().Add(3 * time.Second),
 			})
 			return m, nil
 		}
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Task moved to %s", msg.newStatus),
-			Expires: time.Now().Add(2 * time.Second),
 		})
+		m.undoHistory = m.undoHistory.push(undoAction{kind: undoStatus, taskID: msg.taskID, oldStatus: msg.oldStatus})
+		// The board still shows the task in its old column until beads reload
+		if count, limit, exceeded := m.wipLimitExceeded(msg.newStatus); exceeded {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: fmt.Sprintf("%s is over its WIP limit (%d/%d)", msg.newStatus, count, limit),
+			})
+		}
 
-		if msg.newStatus == domain.StatusDone {
+		if msg.newStatus.Base(m.customStatuses()) == domain.StatusDone && m.isOnline {
 			if session, ok := m.sessions[msg.taskID]; ok {
 				return m, tea.Batch(
 					m.loadBeadsCmd(),
@@ -748,21 +1406,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, m.loadBeadsCmd()
 
+	case undoResultMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Undo failed: %v", msg.err),
+			})
+			// A recreated task exists even if restoring its status failed
+			if msg.newID == "" {
+				return m, nil
+			}
+			return m, m.loadBeadsCmd()
+		}
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: undoMessage(msg),
+		})
+		return m, m.loadBeadsCmd()
+
+	case taskReopenedMsg:
+		if msg.err != nil {
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: fmt.Sprintf("Failed to reopen %s: %v", msg.taskID, msg.err),
+			})
+			return m, nil
+		}
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Reopened %s as %s", msg.taskID, msg.status),
+		})
+
+		if msg.startSession {
+			if _, running := m.sessions[msg.taskID]; !running {
+				return m, tea.Batch(m.loadBeadsCmd(), m.requestSessionStart(msg.taskID))
+			}
+		}
+		return m, m.loadBeadsCmd()
+
 	case bulkStatusResultMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Bulk action failed: %v", msg.err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 			return m, nil
 		}
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Bulk action completed: %d updated, %d failed", msg.updated, msg.failed),
-			Expires: time.Now().Add(3 * time.Second),
 		})
 		m.editor.ClearSelection()
 		m.editor.EnterNormal()
@@ -789,8 +1483,12 @@ func (m Model) View() string {
 		mainView = m.renderBoardView()
 	}
 
-	sb := statusbar.New(m.editor.GetMode(), m.width, m.styles)
-	statusBarView := sb.Render()
+	// The status bar always gets its rows, however tall the board is
+	statusBarView := m.renderStatusBar()
+	mainView = lipgloss.NewStyle().
+		MaxWidth(m.width).
+		MaxHeight(max(m.height-lipgloss.Height(statusBarView), 0)).
+		Render(mainView)
 
 	view := lipgloss.JoinVertical(lipgloss.Left, mainView, statusBarView)
 
@@ -798,40 +1496,24 @@ func (m Model) View() string {
 		current := m.overlayStack.Current()
 		overlayView := current.View()
 
-		overlayWidth, overlayHeight := current.Size()
-
-		if overlayWidth == 0 {
-			viewHeight := lipgloss.Height(view)
-			overlayHeight := lipgloss.Height(overlayView)
-			if viewHeight+overlayHeight > m.height {
-				view = lipgloss.NewStyle().MaxHeight(m.height - overlayHeight).Render(view)
-			}
-			view = lipgloss.JoinVertical(lipgloss.Left, view, overlayView)
+		if overlayWidth, _ := current.Size(); overlayWidth == 0 {
+			// Bars such as search sit along the bottom of the screen
+			barHeight := lipgloss.Height(overlayView)
+			view = overlay.Place(view, overlayView, 0, m.height-barHeight, m.width, m.height)
 		} else {
-			title := current.Title()
-			if title != "" {
-				titleView := m.styles.OverlayTitle.Render(title)
-				overlayView = lipgloss.JoinVertical(lipgloss.Left, titleView, overlayView)
-			}
-			overlayView = m.styles.Overlay.
-				Width(overlayWidth).
-				Height(overlayHeight).
-				Render(overlayView)
+			overlayView = m.overlayBox(current)
 
-			centeredOverlay := lipgloss.Place(
+			// Modals are centred over the dimmed board, which stays
+			// visible around them, and keep clear of the status bar
+			boxWidth, boxHeight := lipgloss.Size(overlayView)
+			return overlay.Place(
+				overlay.Dim(view),
+				overlayView,
+				(m.width-boxWidth)/2,
+				max((m.height-lipgloss.Height(m.renderStatusBar())-boxHeight)/2, 0),
 				m.width,
 				m.height,
-				lipgloss.Center,
-				lipgloss.Center,
-				overlayView,
 			)
-
-			view = lipgloss.NewStyle().
-				MaxWidth(m.width).
-				MaxHeight(m.height).
-				Render(view)
-
-			return m.layer(view, centeredOverlay)
 		}
 	}
 
@@ -839,42 +1521,85 @@ func (m Model) View() string {
 		toastRenderer := toast.New(m.styles)
 		toastView := toastRenderer.Render(m.toasts, m.width)
 		if toastView != "" {
-			toastOverlay := lipgloss.Place(
-				m.width,
-				m.height,
-				lipgloss.Right,
-				lipgloss.Bottom,
-				toastView,
-			)
-			return m.layer(view, toastOverlay)
+			toastWidth, toastHeight := lipgloss.Size(toastView)
+			return overlay.Place(view, toastView, m.width-toastWidth, m.height-toastHeight, m.width, m.height)
 		}
 	}
 
 	return view
 }
 
-func (m Model) layer(bottom, top string) string {
-	bLines := strings.Split(lipgloss.NewStyle().Height(m.height).MaxHeight(m.height).Render(bottom), "\n")
-	tLines := strings.Split(lipgloss.NewStyle().Height(m.height).MaxHeight(m.height).Render(top), "\n")
+// renderStatusBar renders the status bar for the current mode
+func (m Model) renderStatusBar() string {
+	bar := statusbar.New(m.editor.GetMode(), m.width, m.styles)
+	if n := m.pendingChanges.Len(); n > 0 {
+		bar = bar.WithInfo(fmt.Sprintf("%d pending changes", n))
+	}
+	if !m.lastRefresh.IsZero() {
+		bar = bar.WithRefreshAge(time.Since(m.lastRefresh))
+	}
+	return bar.Render()
+}
 
-	res := make([]string, m.height)
-	for i := 0; i < m.height; i++ {
-		var b, t string
-		if i < len(bLines) {
-			b = bLines[i]
-		}
-		if i < len(tLines) {
-			t = tLines[i]
-		}
+// A modal's Size includes its padding; the border adds a cell each side
+const (
+	overlayFrameWidth  = 2
+	overlayFrameHeight = 2
+)
 
-		if strings.TrimSpace(t) == "" {
-			res[i] = b
-		} else {
-			res[i] = t
-		}
+// overlayLayout fits a modal to the screen. Size is a maximum: the box
+// shrinks to fit the screen above the status bar. It returns the box's inner size and
+// the modal's content wrapped to that width, one line per element.
+func (m Model) overlayLayout(current overlay.Overlay) (width, height int, lines []string) {
+	width, height = current.Size()
+	width = max(min(width, m.width-overlayFrameWidth), 1)
+	height = max(min(height, m.height-lipgloss.Height(m.renderStatusBar())-overlayFrameHeight), 1)
+
+	content := current.View()
+	if title := current.Title(); title != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, m.styles.OverlayTitle.Render(title), content)
 	}
 
-	return strings.Join(res, "\n")
+	// Padding takes two columns and a row on each side
+	wrapped := lipgloss.NewStyle().Width(max(width-4, 1)).Render(content)
+	return width, height, strings.Split(wrapped, "\n")
+}
+
+// overlayScrollLimit is how far the current modal can scroll: the lines of
+// content that don't fit its box
+func (m Model) overlayScrollLimit() int {
+	current := m.overlayStack.Current()
+	if width, _ := current.Size(); width == 0 {
+		// Bars aren't boxed
+		return 0
+	}
+
+	_, height, lines := m.overlayLayout(current)
+	if visible := max(height-2, 1); len(lines) > visible {
+		// The last visible line is taken by the scroll hint
+		return len(lines) - (visible - 1)
+	}
+	return 0
+}
+
+// overlayBox renders the current modal in its box. Content taller than the
+// box is shown from the stack's scroll offset, with a hint to scroll.
+func (m Model) overlayBox(current overlay.Overlay) string {
+	width, height, lines := m.overlayLayout(current)
+
+	if visible := max(height-2, 1); len(lines) > visible {
+		window := max(visible-1, 1)
+		offset := min(m.overlayStack.ScrollOffset(), len(lines)-window)
+		end := min(offset+window, len(lines))
+		hint := m.styles.MenuItemDisabled.Render(fmt.Sprintf("PgUp/PgDn: scroll (%d-%d of %d)", offset+1, end, len(lines)))
+		lines = append(lines[offset:end:end], hint)
+	}
+
+	return m.styles.Overlay.
+		Width(width).
+		Height(height).
+		MaxHeight(height + overlayFrameHeight).
+		Render(strings.Join(lines, "\n"))
 }
 
 // buildColumns converts tasks into board columns, applying filter and sort
@@ -885,15 +1610,128 @@ func (m Model) buildColumns() []board.Column {
 	}
 
 	// Apply filter to tasks
-	filteredTasks := m.editor.ApplyFilter(m.tasks)
+	filteredTasks := m.editor.ApplyFilter(m.tasksWithSessions())
 
 	// Build columns from filtered tasks
-	return []board.Column{
-		{Title: "Open", Tasks: m.sortTasksInColumn(filteredTasks, domain.StatusOpen)},
-		{Title: "In Progress", Tasks: m.sortTasksInColumn(filteredTasks, domain.StatusInProgress)},
-		{Title: "Blocked", Tasks: m.sortTasksInColumn(filteredTasks, domain.StatusBlocked)},
-		{Title: "Done", Tasks: m.sortTasksInColumn(filteredTasks, domain.StatusDone)},
+	limits := m.config.Board.WIPLimits
+	var columns []board.Column
+	for _, cfg := range m.boardColumns() {
+		status := domain.Status(cfg.Status)
+		col := board.Column{Title: cfg.Title, Status: status, Tasks: m.sortTasksInColumn(filteredTasks, status), Limit: limits[cfg.Status]}
+		if status == domain.StatusDone && !m.showDone {
+			// Collapsed: keep the count, drop the cards
+			col.Collapsed = true
+			col.Hidden = len(col.Tasks)
+			col.Tasks = nil
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
+
+// tasksWithSessions returns the tasks with their running session attached,
+// so the session state filter can match them
+func (m Model) tasksWithSessions() []domain.Task {
+	if len(m.sessions) == 0 && len(m.startQueue) == 0 {
+		return m.tasks
+	}
+	tasks := make([]domain.Task, len(m.tasks))
+	for i, task := range m.tasks {
+		if session, ok := m.sessions[task.ID]; ok {
+			task.Session = session
+		} else if m.isQueued(task.ID) {
+			task.Session = &domain.Session{BeadID: task.ID, State: domain.SessionQueued}
+		}
+		tasks[i] = task
+	}
+	return tasks
+}
+
+// attentionStates are the session states the ! preset filters to: sessions
+// waiting on input or stopped on an error
+var attentionStates = []domain.SessionState{domain.SessionWaiting, domain.SessionError}
+
+// toggleAttentionFilter filters the board to sessions that need attention
+// and jumps to the first, or turns the preset back off if it's already set
+func (m Model) toggleAttentionFilter() (tea.Model, tea.Cmd) {
+	filter := m.editor.GetFilter()
+	if isAttentionFilter(filter.SessionState) {
+		filter.SessionState = make(map[domain.SessionState]bool)
+		m.resolveCursor()
+		m.addToast(Toast{Level: ToastInfo, Message: "Showing all sessions"})
+		return m, nil
 	}
+
+	filter.SessionState = make(map[domain.SessionState]bool, len(attentionStates))
+	for _, state := range attentionStates {
+		filter.SessionState[state] = true
+	}
+	columns := m.buildColumns()
+	for _, col := range columns {
+		if len(col.Tasks) > 0 {
+			m.nav.JumpToTaskByID(columns, col.Tasks[0].ID)
+			return m, nil
+		}
+	}
+	m.addToast(Toast{Level: ToastInfo, Message: "No sessions waiting or errored"})
+	return m, nil
+}
+
+// isAttentionFilter reports whether states is exactly the ! preset
+func isAttentionFilter(states map[domain.SessionState]bool) bool {
+	if len(states) != len(attentionStates) {
+		return false
+	}
+	for _, state := range attentionStates {
+		if !states[state] {
+			return false
+		}
+	}
+	return true
+}
+
+// boardColumns returns the configured board columns, left to right
+func (m Model) boardColumns() []config.ColumnConfig {
+	return m.config.Board.ColumnsOrDefault()
+}
+
+// statusOrder returns the statuses of the board's columns, left to right,
+// which moving a task steps through
+func (m Model) statusOrder() []domain.Status {
+	columns := m.boardColumns()
+	order := make([]domain.Status, len(columns))
+	for i, col := range columns {
+		order[i] = domain.Status(col.Status)
+	}
+	return order
+}
+
+// customStatuses maps the custom statuses of the board's columns to the bd
+// status each behaves as
+func (m Model) customStatuses() domain.CustomStatuses {
+	custom := make(domain.CustomStatuses)
+	for _, col := range m.boardColumns() {
+		if col.Base != "" {
+			custom[domain.Status(col.Status)] = domain.Status(col.Base)
+		}
+	}
+	return custom
+}
+
+// wipLimitExceeded reports whether moving one more task into status takes
+// its column past the configured WIP limit, with the count after the move
+func (m Model) wipLimitExceeded(status domain.Status) (count, limit int, exceeded bool) {
+	limit = m.config.Board.WIPLimits[string(status)]
+	if limit <= 0 {
+		return 0, 0, false
+	}
+	for _, task := range m.tasks {
+		if task.Status == status {
+			count++
+		}
+	}
+	count++
+	return count, limit, count > limit
 }
 
 // handleKey processes keyboard input based on current mode
@@ -901,12 +1739,13 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Global keys (work in any mode)
 	switch msg.String() {
 	case "ctrl+c":
-		// Cleanup before quitting
-		m.sessionMonitor.StopAll()
-		return m, tea.Quit
+		return m, m.quit()
 	case "ctrl+l":
 		// Force redraw
 		return m, tea.ClearScreen
+	case "ctrl+r":
+		// Reload beads now rather than at the next tick
+		return m, m.loadBeadsCmd()
 	}
 
 	// Escape closes overlay or exits non-normal modes
@@ -944,9 +1783,7 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "q":
-		// Cleanup before quitting
-		m.sessionMonitor.StopAll()
-		return m, tea.Quit
+		return m, m.quit()
 
 	// Vertical navigation
 	case "j", "down":
@@ -966,6 +1803,15 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.nav.MoveRight(columns)
 		return m, nil
 
+	// Column movement skipping empty columns, for filtered boards
+	case "H":
+		m.nav.MoveLeftSkipEmpty(columns)
+		return m, nil
+
+	case "L":
+		m.nav.MoveRightSkipEmpty(columns)
+		return m, nil
+
 	// Half-page scroll
 	case "ctrl+d":
 		m.nav.HalfPageDown(columns, m.halfPage())
@@ -975,6 +1821,9 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.nav.HalfPageUp(columns, m.halfPage())
 		return m, nil
 
+	case "ctrl+z": // Undo the last status change or delete
+		return m, m.undoLast()
+
 	// Mode switches
 	case "g":
 		m.editor.EnterGoto()
@@ -983,15 +1832,20 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case " ": // Space - open action menu
 		task, session := m.getCurrentTaskAndSession()
 		if task != nil {
-			return m, m.overlayStack.Push(overlay.NewActionMenu(*task, session))
+			return m, m.overlayStack.Push(m.newActionMenu(*task, session))
 		}
 		return m, nil
 
 	case "/": // Search
-		return m, m.overlayStack.Push(overlay.NewSearchOverlay())
+		return m, m.overlayStack.Push(overlay.NewSearchOverlayWithScope(m.editor.GetFilter().SearchScope))
 
 	case "f": // Filter menu
-		return m, m.overlayStack.Push(overlay.NewFilterMenu(m.editor.GetFilter()))
+		menu := overlay.NewFilterMenuWithTasks(m.editor.GetFilter(), m.tasks)
+		menu.SetStatusColumns(m.boardColumns())
+		return m, m.overlayStack.Push(menu)
+
+	case "!": // Quick filter: sessions waiting on me
+		return m.toggleAttentionFilter()
 
 	case ",": // Sort menu
 		return m, m.overlayStack.Push(overlay.NewSortMenu(m.editor.GetSort()))
@@ -1009,10 +1863,15 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.isCurrentTaskEpic() {
 				// Epic drill-down
 				children := m.getEpicChildren(task.ID)
-				return m, m.overlayStack.Push(overlay.NewEpicDrillDown(*task, children))
+				drillDown := overlay.NewEpicDrillDown(*task, children)
+				drillDown.SetMarkdown(m.config.UI.MarkdownRender)
+				drillDown.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+				return m, m.overlayStack.Push(drillDown)
 			} else {
 				// Regular task detail panel
-				return m, m.overlayStack.Push(overlay.NewDetailPanel(*task, session))
+				detail := m.detailPanel(*task, session)
+				detail.SetBlockers(m.openBlockers(task.ID))
+				return m, m.overlayStack.Push(detail)
 			}
 		}
 		return m, nil
@@ -1026,30 +1885,69 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, m.overlayStack.Push(overlay.NewCreateTaskOverlayWithParent(parentID))
 
 	case "s": // Settings
-		return m, m.overlayStack.Push(overlay.NewSettingsOverlayWithEditor(m.editor))
+		return m, m.overlayStack.Push(overlay.NewConfigSettingsOverlay(m.editor, m.config, m.configPath()))
 
 	case "D": // Diagnostics (Shift+D)
-		diagPanel := overlay.NewDiagnosticsPanel(m.diagnosticsService, m.sessions)
+		refresh := time.Duration(m.config.Diagnostics.RefreshInterval) * time.Second
+		diagPanel := overlay.NewDiagnosticsPanel(m.diagnosticsService, m.sessions, m.beadsPath, refresh)
 		return m, tea.Batch(m.overlayStack.Push(diagPanel), diagPanel.Init())
 
 	case "tab": // Toggle view mode
 		if m.viewMode == ViewModeBoard {
 			m.viewMode = ViewModeCompact
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastInfo,
 				Message: "Switched to compact view",
-				Expires: time.Now().Add(2 * time.Second),
 			})
 		} else {
 			m.viewMode = ViewModeBoard
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastInfo,
 				Message: "Switched to board view",
-				Expires: time.Now().Add(2 * time.Second),
 			})
 		}
 		return m, nil
 
+	case "z": // Collapse or expand the Done column
+		m.showDone = !m.showDone
+		message := "Done column collapsed"
+		if m.showDone {
+			message = "Done column expanded"
+		}
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: message,
+		})
+		return m, nil
+
+	case "Z": // Focus mode: only the cursor's column, full width (Shift+Z)
+		m.focusMode = !m.focusMode
+		message := "Focus mode off"
+		if m.focusMode {
+			message = "Focus mode: h/l switches column"
+		}
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: message,
+		})
+		return m, nil
+
+	case "A": // Archive of completed tasks (Shift+A)
+		return m, m.overlayStack.Push(overlay.NewArchiveOverlay(m.tasks))
+
+	case "P": // Plan a new feature with AI (Shift+P)
+		return m, m.openPlanning()
+
+	case "E": // Export the board, as filtered, as markdown (Shift+E)
+		return m, m.exportBoardCmd()
+
+	case "x": // Dismiss the newest toast, persistent errors included
+		m.dismissToast()
+		return m, nil
+
+	case "N": // Notification center: recent toasts, expired ones included (Shift+N)
+		return m, m.overlayStack.Push(overlay.NewNotificationsOverlay(m.toastHistory))
+
 	case "O": // Orchestration overlay
 		return m, m.openOrchestrationOverlay()
 
@@ -1211,6 +2109,18 @@ func (m Model) handleSelectMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleOverlayKey routes keyboard messages to the overlay stack
 func (m Model) handleOverlayKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Scroll modals taller than the screen
+	if limit := m.overlayScrollLimit(); limit > 0 {
+		switch msg.String() {
+		case "pgdown":
+			m.overlayStack.Scroll(m.halfPage(), limit)
+			return m, nil
+		case "pgup":
+			m.overlayStack.Scroll(-m.halfPage(), limit)
+			return m, nil
+		}
+	}
+
 	cmd := m.overlayStack.Update(msg)
 	return m, cmd
 }
@@ -1218,7 +2128,8 @@ func (m Model) handleOverlayKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // Message types for async operations
 
 type beadsLoadedMsg struct {
-	tasks []domain.Task
+	tasks    []domain.Task
+	revision string // Revision of the bd list the tasks are from
 }
 
 type beadsErrorMsg struct {
@@ -1227,9 +2138,19 @@ type beadsErrorMsg struct {
 
 type tickMsg time.Time
 
+// prPollTickMsg triggers a PR status poll
+type prPollTickMsg time.Time
+
+// prStatusMsg carries the PR states polled for a set of beads
+type prStatusMsg struct {
+	states map[string]domain.PRState
+}
+
 type sessionStartedMsg struct {
 	beadID       string
 	worktreePath string
+	layoutErr    error // The session runs, but without its layout's panes
+	initErr      error // Init commands that failed to send; the session runs anyway
 }
 
 type sessionErrorMsg struct {
@@ -1237,20 +2158,126 @@ type sessionErrorMsg struct {
 	err    error
 }
 
+type sessionRestartedMsg struct {
+	beadID string
+}
+
+type sessionPausedMsg struct {
+	beadID string
+	auto   bool
+	err    error
+}
+
+type sessionResumedMsg struct {
+	beadID    string
+	layoutErr error // The session runs, but without its layout's panes
+	initErr   error // Init commands that failed to send; the session runs anyway
+}
+
+type sessionTextSentMsg struct {
+	beadID string
+	text   string
+	err    error
+}
+
+type sessionArchivedMsg struct {
+	beadID  string
+	logPath string
+	err     error
+}
+
 // Commands
 
 // loadBeadsCmd returns a command that fetches beads from the CLI
 func (m Model) loadBeadsCmd() tea.Cmd {
+	since, current := m.beadsRevision, slices.Clone(m.tasks)
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		tasks, err := m.beadsClient.List(ctx)
+		tasks, revision, err := m.beadsClient.ListSince(ctx, since)
 		if err != nil {
 			return beadsErrorMsg{err: err}
 		}
-		return beadsLoadedMsg{tasks: tasks}
+		if revision == since {
+			// Nothing changed in bd, so nothing was parsed; the tasks stay
+			// as they are
+			tasks = current
+		}
+		// Attachments live outside bd, so are counted either way
+		m.countAttachments(tasks)
+		return beadsLoadedMsg{tasks: tasks, revision: revision}
+	}
+}
+
+// countAttachments fills in each task's attachment count. A bead whose
+// attachments can't be read just shows none.
+func (m Model) countAttachments(tasks []domain.Task) {
+	if m.attachmentService == nil {
+		return
+	}
+	for i := range tasks {
+		count, err := m.attachmentService.Count(tasks[i].ID)
+		if err != nil {
+			m.logger.Debug("failed to count attachments", "beadID", tasks[i].ID, "error", err)
+			continue
+		}
+		tasks[i].Attachments = count
+	}
+}
+
+// beadsNotInstalled handles a load failing because bd isn't installed. The
+// first time, refreshing pauses behind a prompt with install instructions,
+// instead of repeating the error on every refresh. After the prompt is
+// dismissed, later failures keep retrying quietly with backoff.
+func (m *Model) beadsNotInstalled(err error) tea.Cmd {
+	m.loading = false
+	m.beadsFailures++
+	if m.beadsMissing {
+		if !m.hasRefreshLoop && !m.refreshPaused {
+			m.hasRefreshLoop = true
+			return tickEvery(m.refreshDelay())
+		}
+		return nil
+	}
+
+	m.logger.Warn("beads CLI not found", "error", err)
+	m.beadsMissing = true
+	m.refreshPaused = true
+	return m.overlayStack.Push(overlay.NewBeadsMissingOverlay(err))
+}
+
+// beadsRefreshInterval is the normal cadence of the refresh loop
+const beadsRefreshInterval = 2 * time.Second
+
+// beadsRetryBase is the refresh delay after the first failed beads load
+const beadsRetryBase = 5 * time.Second
+
+// beadsRetryDelay returns the delay before the next refresh after the given
+// number of consecutive failed loads: the normal interval when healthy, then
+// doubling from beadsRetryBase up to maxDelay
+func beadsRetryDelay(failures int, maxDelay time.Duration) time.Duration {
+	if failures <= 0 {
+		return beadsRefreshInterval
 	}
+	delay := beadsRetryBase
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// refreshDelay returns the delay before the next refresh tick, capped at
+// config.Network.OfflineTimeout while loads keep failing
+func (m Model) refreshDelay() time.Duration {
+	maxDelay := time.Duration(m.config.Network.OfflineTimeout) * time.Second
+	if maxDelay < beadsRetryBase {
+		maxDelay = beadsRetryBase
+	}
+	return beadsRetryDelay(m.beadsFailures, maxDelay)
 }
 
 func tickEvery(d time.Duration) tea.Cmd {
@@ -1259,13 +2286,163 @@ func tickEvery(d time.Duration) tea.Cmd {
 	})
 }
 
+// prPollInterval is how often open PRs are polled via gh. Kept well above the
+// beads refresh interval to stay clear of GitHub API rate limits.
+const prPollInterval = 30 * time.Second
+
+func prPollEvery(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return prPollTickMsg(t)
+	})
+}
+
+// prPollTargets returns the bead IDs whose branches may have a PR: tasks
+// that are in progress or blocked, tasks with a session, and tasks that
+// already had a PR on the last poll.
+func (m Model) prPollTargets() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	custom := m.customStatuses()
+	for _, task := range m.tasks {
+		if base := task.Status.Base(custom); base == domain.StatusInProgress || base == domain.StatusBlocked {
+			add(task.ID)
+		}
+	}
+	for beadID := range m.sessions {
+		add(beadID)
+	}
+	for beadID := range m.prStates {
+		add(beadID)
+	}
+	for beadID := range m.prMapping {
+		add(beadID)
+	}
+	return ids
+}
+
+// pollPRStatusCmd fetches the PR status for each bead's worktree branch
+func (m Model) pollPRStatusCmd(beadIDs []string) tea.Cmd {
+	if len(beadIDs) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		states := make(map[string]domain.PRState, len(beadIDs))
+		for _, beadID := range beadIDs {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			status, err := m.prWorkflow.Status(ctx, "az/"+beadID)
+			cancel()
+			if err != nil {
+				// Leave the previous state in place on transient failures
+				m.logger.Debug("PR status poll failed", "beadID", beadID, "error", err)
+				continue
+			}
+			states[beadID] = status.Summary()
+		}
+		return prStatusMsg{states: states}
+	}
+}
+
+// applyPRStates copies polled PR states and the PRs created for each bead
+// onto the loaded tasks
+func (m *Model) applyPRStates() {
+	for i := range m.tasks {
+		m.tasks[i].PRState = m.prStates[m.tasks[i].ID]
+		m.tasks[i].PRURL = m.prMapping[m.tasks[i].ID]
+		m.tasks[i].PRNumber = pr.NumberFromURL(m.tasks[i].PRURL)
+	}
+}
+
+// applyBlockers marks each task with its blockers that aren't done yet
+func (m *Model) applyBlockers() {
+	taskMap := make(map[string]domain.Task, len(m.tasks))
+	for _, task := range m.tasks {
+		taskMap[task.ID] = task
+	}
+
+	blocked := phases.ComputeOpenBlockers(taskMap)
+	for i := range m.tasks {
+		m.tasks[i].BlockedBy = blocked[m.tasks[i].ID]
+	}
+}
+
+// detailPanel creates the detail panel for a task, laid out for the screen
+func (m Model) detailPanel(task domain.Task, session *domain.Session) *overlay.DetailPanel {
+	detail := overlay.NewDetailPanel(task, session, m.beadsClient)
+	detail.SetMarkdown(m.config.UI.MarkdownRender)
+	detail.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	return detail
+}
+
+// openBlockers returns the loaded tasks blocking taskID that aren't done yet
+func (m Model) openBlockers(taskID string) []domain.Task {
+	task := m.findTask(taskID)
+	if task == nil {
+		return nil
+	}
+
+	var blockers []domain.Task
+	for _, id := range task.BlockedBy {
+		if blocker := m.findTask(id); blocker != nil {
+			blockers = append(blockers, *blocker)
+		}
+	}
+	return blockers
+}
+
+// recordPR remembers the PR created for beadID, persisting the mapping so
+// the task keeps its PR across restarts
+func (m *Model) recordPR(beadID, url string) {
+	if beadID == "" || url == "" {
+		return
+	}
+	m.prMapping[beadID] = url
+	m.applyPRStates()
+	if err := pr.SaveMapping(m.prMappingPath, m.prMapping); err != nil {
+		m.logger.Warn("failed to save PR mapping", "beadID", beadID, "error", err)
+	}
+}
+
+// baseBranch returns the base branch for the current project, as configured
+// for it or as detected by detectBaseBranchCmd, else the fallback. It doesn't
+// run git.
+func (m Model) baseBranch() string {
+	return m.gitSyncService.KnownBaseBranch()
+}
+
+// newActionMenu creates the action menu for task, naming the project's base
+// branch in its git actions
+func (m Model) newActionMenu(task domain.Task, session *domain.Session) *overlay.ActionMenu {
+	menu := overlay.NewActionMenu(task, session)
+	menu.SetBaseBranch(m.baseBranch())
+	return menu
+}
+
+// detectBaseBranchCmd detects the current project's base branch in the
+// background; the sync service caches it for baseBranch
+func (m Model) detectBaseBranchCmd() tea.Cmd {
+	syncService := m.gitSyncService
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		syncService.BaseBranch(ctx)
+		return nil
+	}
+}
+
 // startSessionCmd creates a worktree, tmux session, and starts monitoring
 func (m Model) startSessionCmd(beadID string) tea.Cmd {
+	cliTool := m.cliTool()
 	return func() tea.Msg {
 		ctx := context.Background()
 
 		// Create worktree for the task
-		baseBranch := "main" // TODO: Make configurable
+		baseBranch := m.gitSyncService.BaseBranch(ctx)
 		worktree, err := m.worktreeManager.Create(ctx, beadID, baseBranch)
 		if err != nil {
 			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to create worktree: %w", err)}
@@ -1276,30 +2453,451 @@ func (m Model) startSessionCmd(beadID string) tea.Cmd {
 		if err != nil {
 			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to create tmux session: %w", err)}
 		}
+		layoutErr := m.applySessionLayout(ctx, beadID, worktree.Path)
+		initErr := m.runInitCommands(ctx, beadID)
 
-		// Send Claude command to session
-		claudeCmd := "claude" // TODO: Make configurable or add more context
-		err = m.tmuxClient.SendKeys(ctx, beadID, claudeCmd)
+		// Start the project's CLI tool in the session
+		err = m.tmuxClient.SendKeys(ctx, beadID, cliTool)
 		if err != nil {
 			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to send keys: %w", err)}
 		}
 
-		// Create session record
-		now := time.Now()
-		session := &domain.Session{
-			BeadID:    beadID,
-			State:     domain.SessionBusy,
-			StartedAt: &now,
-			Worktree:  worktree.Path,
+		return sessionStartedMsg{beadID: beadID, worktreePath: worktree.Path, layoutErr: layoutErr, initErr: initErr}
+	}
+}
+
+// warnLayoutFailed tells the user a new session's layout couldn't be applied
+func (m *Model) warnLayoutFailed(err error) {
+	if err == nil {
+		return
+	}
+	m.addToast(Toast{
+		Level:   ToastWarning,
+		Message: fmt.Sprintf("Session layout not applied: %v", err),
+	})
+}
+
+// warnInitFailed tells the user some of a new session's init commands
+// couldn't be sent
+func (m *Model) warnInitFailed(err error) {
+	if err == nil {
+		return
+	}
+	m.addToast(Toast{
+		Level:   ToastWarning,
+		Message: fmt.Sprintf("Init commands failed: %v", err),
+	})
+}
+
+// initCommandSettle is how long the shell gets after each init command,
+// so the CLI tool isn't typed in while, say, nvm is still loading
+const initCommandSettle = 200 * time.Millisecond
+
+// runInitCommands types config.Session.InitCommands into a new session's
+// pane, in order, ahead of the CLI tool. A command that can't be sent is
+// logged and skipped; the failures are returned joined, for a warning.
+func (m Model) runInitCommands(ctx context.Context, beadID string) error {
+	var errs []error
+	for _, command := range m.config.Session.InitCommands {
+		if err := m.tmuxClient.SendKeys(ctx, beadID, command); err != nil {
+			m.logger.Warn("failed to run init command", "beadID", beadID, "command", command, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", command, err))
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(initCommandSettle):
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sessionLayout returns config.Session.Layout as a tmux layout
+func (m Model) sessionLayout() tmux.Layout {
+	var layout tmux.Layout
+	for _, pane := range m.config.Session.Layout.Panes {
+		layout.Panes = append(layout.Panes, tmux.Pane{Split: pane.Split, Size: pane.Size, Command: pane.Command})
+	}
+	return layout
+}
+
+// applySessionLayout opens the configured panes in a new session. A layout
+// that fails is logged and returned for a warning, but the session carries
+// on in its first pane.
+func (m Model) applySessionLayout(ctx context.Context, beadID, workdir string) error {
+	layout := m.sessionLayout()
+	applier, ok := m.tmuxClient.(multiplexer.LayoutApplier)
+	if !ok || len(layout.Panes) == 0 {
+		return nil
+	}
+
+	err := applier.ApplyLayout(ctx, beadID, workdir, layout, m.config.Session.InitCommands)
+	if err != nil {
+		m.logger.Warn("failed to apply session layout", "beadID", beadID, "error", err)
+	}
+	return err
+}
+
+// sessionLogCaptureLines is how much scrollback is captured per log flush.
+// It must cover the output a session can produce between refresh ticks.
+const sessionLogCaptureLines = 500
+
+// sessionLogMaxBytes is the size cfg rotates session logs at
+func sessionLogMaxBytes(cfg *config.Config) int64 {
+	return int64(cfg.Session.LogMaxMB) * 1024 * 1024
+}
+
+// persistSessionLogsCmd appends new pane output of the running sessions
+// whose output has changed to their log files under config.Session.LogDir
+func (m Model) persistSessionLogsCmd() tea.Cmd {
+	beadIDs := make([]string, 0, len(m.sessions))
+	for beadID, session := range m.sessions {
+		if session.State == domain.SessionPaused {
+			continue // No pane to capture
+		}
+		if !m.sessionMonitor.TakeOutputChange(beadID) {
+			continue // Nothing new to log
+		}
+		beadIDs = append(beadIDs, beadID)
+	}
+	if len(beadIDs) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		for _, beadID := range beadIDs {
+			m.persistSessionLog(ctx, beadID)
+		}
+		return nil
+	}
+}
+
+// persistSessionLog captures a session's pane and appends unseen lines to its log
+func (m Model) persistSessionLog(ctx context.Context, beadID string) {
+	output, err := m.tmuxClient.CapturePane(ctx, beadID, sessionLogCaptureLines)
+	if err != nil {
+		m.logger.Debug("session log capture failed", "beadID", beadID, "error", err)
+		return
+	}
+	if err := m.sessionLog.Append(beadID, output); err != nil {
+		m.logger.Warn("failed to persist session log", "beadID", beadID, "error", err)
+	}
+}
+
+// archiveCaptureLines is effectively the whole scrollback of a finished session
+const archiveCaptureLines = 100000
+
+// archiveSummaryLines is how much of the final output is attached to the bead
+const archiveSummaryLines = 20
+
+// archiveSessionCmd saves a finished session's complete output to its log and
+// comments a summary of the final output on the bead
+func (m Model) archiveSessionCmd(beadID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		output, err := m.tmuxClient.CapturePane(ctx, beadID, archiveCaptureLines)
+		if err != nil {
+			return sessionArchivedMsg{beadID: beadID, err: err}
+		}
+
+		if err := m.sessionLog.Append(beadID, output); err != nil {
+			return sessionArchivedMsg{beadID: beadID, err: err}
+		}
+
+		logPath := m.sessionLog.Path(beadID)
+		comment := fmt.Sprintf("Session completed. Final output:\n\n```\n%s\n```\n\nFull transcript: %s",
+			sessionlog.Summary(output, archiveSummaryLines), logPath)
+		if err := m.beadsClient.Comment(ctx, beadID, comment); err != nil {
+			return sessionArchivedMsg{beadID: beadID, err: err}
+		}
+
+		return sessionArchivedMsg{beadID: beadID, logPath: logPath}
+	}
+}
+
+// restartErrorContextLines is how much of the failed output is quoted in the
+// follow-up prompt of a restarted session
+const restartErrorContextLines = 5
+
+// restartSessionCmd restarts the CLI tool inside the existing tmux session and
+// worktree of a failed session, preserving work in progress. The tail of the
+// failed output is passed along as a follow-up prompt.
+func (m Model) restartSessionCmd(beadID, worktree string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		errorContext := ""
+		if output, err := m.tmuxClient.CapturePane(ctx, beadID, 50); err == nil {
+			errorContext = sessionlog.Summary(output, restartErrorContextLines)
+		}
+
+		// Interrupt whatever is left of the failed process
+		if err := m.tmuxClient.SendKey(ctx, beadID, "C-c"); err != nil {
+			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to interrupt session: %w", err)}
 		}
-		m.sessions[beadID] = session
 
-		// Start monitoring the session
-		// Note: We need a way to pass the tea.Program to the monitor
-		// For now, we'll skip this and implement it properly later
-		// m.sessionMonitor.Start(ctx, beadID, program)
+		if err := m.tmuxClient.SendKeys(ctx, beadID, "cd "+shellQuote(worktree)); err != nil {
+			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to enter worktree: %w", err)}
+		}
+
+		cmd := buildRestartCommand(m.cliTool(), errorContext)
+		if err := m.tmuxClient.SendKeys(ctx, beadID, cmd); err != nil {
+			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to restart session: %w", err)}
+		}
+
+		return sessionRestartedMsg{beadID: beadID}
+	}
+}
+
+// autoPauseIdleSessionsCmd pauses sessions the monitor has seen done or idle
+// for longer than config.Session.AutoPauseIdleMinutes as of now
+func (m Model) autoPauseIdleSessionsCmd(now time.Time) tea.Cmd {
+	if m.config == nil || m.config.Session.AutoPauseIdleMinutes <= 0 {
+		return nil
+	}
+
+	threshold := time.Duration(m.config.Session.AutoPauseIdleMinutes) * time.Minute
+	var cmds []tea.Cmd
+	for _, beadID := range m.sessionMonitor.IdleSessions(now, threshold) {
+		session, ok := m.sessions[beadID]
+		if !ok || session.State == domain.SessionPaused {
+			continue
+		}
+		cmds = append(cmds, m.pauseSessionCmd(beadID, true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// flagIdleSessions warns once about each session that has been idle or done
+// for longer than config.Session.IdleTimeoutMinutes, since it still holds a
+// worktree and possibly a dev server port. A session is flagged again only
+// after it has worked in between.
+func (m *Model) flagIdleSessions(now time.Time) {
+	if m.config == nil || m.config.Session.IdleTimeoutMinutes <= 0 {
+		return
+	}
+	timeout := time.Duration(m.config.Session.IdleTimeoutMinutes) * time.Minute
+
+	for beadID := range m.idleFlagged {
+		if _, ok := m.sessions[beadID]; !ok {
+			delete(m.idleFlagged, beadID)
+		}
+	}
+
+	beadIDs := make([]string, 0, len(m.sessions))
+	for beadID := range m.sessions {
+		beadIDs = append(beadIDs, beadID)
+	}
+	sort.Strings(beadIDs)
+
+	for _, beadID := range beadIDs {
+		idle := m.sessions[beadID].IdleFor(now)
+		if idle <= timeout {
+			delete(m.idleFlagged, beadID)
+			continue
+		}
+		if m.idleFlagged[beadID] {
+			continue
+		}
+		m.idleFlagged[beadID] = true
+		m.addToast(Toast{
+			Level:   ToastWarning,
+			Message: fmt.Sprintf("Session %s idle for %dm; pause or clean it up to free its worktree", beadID, int(idle.Minutes())),
+		})
+	}
+}
+
+// pauseSessionCmd frees a session's tmux pane while keeping its worktree and
+// session record so it can be resumed later
+func (m Model) pauseSessionCmd(beadID string, auto bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		m.sessionMonitor.Stop(beadID)
+
+		// Flush the final output to the session log before the pane is gone
+		m.persistSessionLog(ctx, beadID)
+
+		if err := m.tmuxClient.KillSession(ctx, beadID); err != nil {
+			return sessionPausedMsg{beadID: beadID, auto: auto, err: fmt.Errorf("failed to kill tmux session: %w", err)}
+		}
+
+		return sessionPausedMsg{beadID: beadID, auto: auto}
+	}
+}
+
+// resumeSessionCmd starts a fresh tmux session for a paused session in its
+// existing worktree
+func (m Model) resumeSessionCmd(beadID, worktree string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if err := m.tmuxClient.NewSession(ctx, beadID, worktree); err != nil {
+			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to create tmux session: %w", err)}
+		}
+		layoutErr := m.applySessionLayout(ctx, beadID, worktree)
+		initErr := m.runInitCommands(ctx, beadID)
+
+		if err := m.tmuxClient.SendKeys(ctx, beadID, buildRestartCommand(m.cliTool(), "")); err != nil {
+			return sessionErrorMsg{beadID: beadID, err: fmt.Errorf("failed to send keys: %w", err)}
+		}
+
+		return sessionResumedMsg{beadID: beadID, layoutErr: layoutErr, initErr: initErr}
+	}
+}
+
+// sendTextCmd types a line into a session's pane and presses Enter
+func (m Model) sendTextCmd(beadID, text string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := m.tmuxClient.SendKeys(ctx, beadID, text)
+		return sessionTextSentMsg{beadID: beadID, text: text, err: err}
+	}
+}
+
+// stuckTimeout is how long a busy session may go without new output before
+// it is flagged as stalled
+func (m Model) stuckTimeout() time.Duration {
+	return time.Duration(m.config.Session.StuckTimeoutMs) * time.Millisecond
+}
+
+// cliTool returns the CLI tool to start in sessions: the active project's
+// registry entry wins, then m.config, which already holds the project's own
+// config, then config.DefaultCLITool. Unlike config.ResolveCLITool it reads
+// nothing from disk, so Update can call it.
+func (m Model) cliTool() string {
+	if project := m.activeProject(); project != nil && project.CLITool != "" {
+		return project.CLITool
+	}
+	if m.config.CLITool != "" {
+		return m.config.CLITool
+	}
+	return config.DefaultCLITool
+}
+
+// activeProject returns the registry entry of the selected project, or of
+// the project containing the working directory when none is selected
+func (m Model) activeProject() *config.Project {
+	if m.projectRegistry == nil {
+		return nil
+	}
+	if m.currentProject != "" {
+		if project, err := m.projectRegistry.Get(m.currentProject); err == nil {
+			return project
+		}
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		return m.projectRegistry.FindByPath(cwd)
+	}
+	return nil
+}
+
+// projectDir is the directory of the active project, or else the working
+// directory
+func (m Model) projectDir() string {
+	if project := m.activeProject(); project != nil {
+		return project.Path
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return "."
+}
+
+// configPath is the .azedarach.json the settings overlay saves to: the
+// active project's, or else the working directory's
+func (m Model) configPath() string {
+	return filepath.Join(m.projectDir(), ".azedarach.json")
+}
+
+// handleSettingsSaved applies config the settings overlay saved, at once
+// (see applyConfig)
+func (m Model) handleSettingsSaved(msg overlay.SettingsSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		if settings, ok := m.overlayStack.Current().(*overlay.SettingsOverlay); ok {
+			settings.SetErrors(strings.Split(msg.Err.Error(), "\n"))
+			return m, nil
+		}
+		m.addToast(Toast{
+			Level:   ToastError,
+			Message: fmt.Sprintf("Failed to save settings: %v", msg.Err),
+		})
+		return m, nil
+	}
+
+	*m.config = *msg.Config
+	cmd := m.applyConfig()
+
+	if _, ok := m.overlayStack.Current().(*overlay.SettingsOverlay); ok {
+		m.overlayStack.Pop()
+	}
+	m.addToast(Toast{
+		Level:   ToastSuccess,
+		Message: fmt.Sprintf("Settings saved to %s", msg.Path),
+	})
+	return m, cmd
+}
+
+// buildRestartCommand builds the CLI invocation for a restarted session,
+// with a follow-up prompt describing the previous failure when available
+func buildRestartCommand(cliTool, errorContext string) string {
+	if cliTool == "" {
+		cliTool = config.DefaultCLITool
+	}
+	if errorContext == "" {
+		return cliTool
+	}
+
+	// Keep the prompt on one line so send-keys doesn't submit it early
+	summary := strings.Join(strings.Split(errorContext, "\n"), " | ")
+	prompt := fmt.Sprintf("The previous session failed with: %s. Continue the task from the current state of the worktree.", summary)
+	return cliTool + " " + shellQuote(prompt)
+}
+
+// shellQuote single-quotes s for safe use in a shell command line
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quit exits, first asking what to do with any running sessions. Quitting
+// otherwise leaves them running with nothing watching them.
+func (m *Model) quit() tea.Cmd {
+	if len(m.sessions) > 0 {
+		return m.overlayStack.Push(overlay.NewQuitConfirmOverlay(m.sessions))
+	}
+
+	// Cleanup before quitting
+	m.sessionMonitor.StopAll()
+	return tea.Quit
+}
+
+// stopAllSessionsCmd kills every session's tmux session before quitting,
+// saving their final output first. Worktrees are kept.
+func (m Model) stopAllSessionsCmd() tea.Cmd {
+	beadIDs := make([]string, 0, len(m.sessions))
+	for beadID := range m.sessions {
+		beadIDs = append(beadIDs, beadID)
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		return sessionStartedMsg{beadID: beadID, worktreePath: worktree.Path}
+		for _, beadID := range beadIDs {
+			m.persistSessionLog(ctx, beadID)
+			if err := m.tmuxClient.KillSession(ctx, beadID); err != nil {
+				m.logger.Error("failed to stop session on quit", "beadID", beadID, "error", err)
+			}
+		}
+		return nil
 	}
 }
 
@@ -1308,8 +2906,13 @@ func (m Model) stopSessionCmd(beadID string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		// Stop monitoring
+		// Stop monitoring and free the start slot; queued sessions pick it
+		// up on the next refresh tick
 		m.sessionMonitor.Stop(beadID)
+		m.sessionLimiter.Release(beadID)
+
+		// Flush the final output to the session log before the pane is gone
+		m.persistSessionLog(ctx, beadID)
 
 		// Kill tmux session
 		err := m.tmuxClient.KillSession(ctx, beadID)
@@ -1319,6 +2922,7 @@ func (m Model) stopSessionCmd(beadID string) tea.Cmd {
 
 		// Remove session record
 		delete(m.sessions, beadID)
+		m.sessionLog.Forget(beadID)
 
 		// Release port if allocated
 		m.portAllocator.Release(beadID)
@@ -1326,10 +2930,9 @@ func (m Model) stopSessionCmd(beadID string) tea.Cmd {
 		// TODO: Optionally delete worktree (should probably ask user first)
 		// err = m.worktreeManager.Delete(ctx, beadID)
 
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastSuccess,
 			Message: fmt.Sprintf("Session stopped: %s", beadID),
-			Expires: time.Now().Add(3 * time.Second),
 		})
 
 		return nil
@@ -1404,6 +3007,12 @@ func (m Model) handleBulkAction(msg overlay.BulkActionMsg) (tea.Model, tea.Cmd)
 	case "D": // Set to Done
 		return m, m.bulkSetStatusCmd(msg.SelectedIDs, domain.StatusDone)
 
+	case "s": // Start sessions
+		return m.bulkStartSessions(msg.SelectedIDs)
+
+	case "c": // Close selected
+		return m, m.bulkCloseCmd(msg.SelectedIDs)
+
 	case "d": // Delete selected
 		return m, m.bulkDeleteCmd(msg.SelectedIDs)
 
@@ -1413,10 +3022,9 @@ func (m Model) handleBulkAction(msg overlay.BulkActionMsg) (tea.Model, tea.Cmd)
 	case "x": // Clear selection
 		m.editor.ClearSelection()
 		m.editor.EnterNormal()
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastInfo,
 			Message: "Selection cleared",
-			Expires: time.Now().Add(2 * time.Second),
 		})
 	}
 
@@ -1425,6 +3033,13 @@ func (m Model) handleBulkAction(msg overlay.BulkActionMsg) (tea.Model, tea.Cmd)
 
 // handleSelection handles overlay selection messages
 func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
+	// Sort menu keys overlap with action keys; the sort state is already
+	// updated through the shared pointer, so just close the menu
+	if _, ok := msg.Value.(*domain.Sort); ok {
+		m.overlayStack.Pop()
+		return m, nil
+	}
+
 	// Handle special overlay-specific messages first (before popping overlay)
 	switch msg.Key {
 	case "abort", "claude", "manual":
@@ -1432,6 +3047,28 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 		if resolution, ok := msg.Value.(overlay.ConflictResolutionMsg); ok {
 			return m.handleConflictResolution(resolution)
 		}
+	case "yes", "no":
+		// Confirmation dialog result
+		if result, ok := msg.Value.(overlay.ConfirmResult); ok {
+			m.overlayStack.Pop()
+			beadID, url, stale := m.pendingStartBeadID, m.pendingOpenURL, m.pendingStaleWorktrees
+			restartID := m.pendingRestartBeadID
+			m.pendingStartBeadID, m.pendingOpenURL, m.pendingStaleWorktrees = "", "", nil
+			m.pendingRestartBeadID = ""
+			if result.Confirmed && url != "" {
+				return m, openURLCmd(url)
+			}
+			if result.Confirmed && len(stale) > 0 {
+				return m, m.removeWorktreesCmd(stale)
+			}
+			if session, ok := m.sessions[restartID]; result.Confirmed && ok {
+				return m, m.resumeSessionCmd(restartID, session.Worktree)
+			}
+			if result.Confirmed && beadID != "" {
+				return m, m.requestSessionStart(beadID)
+			}
+			return m, nil
+		}
 	case "merge":
 		// Merge target selection message
 		if mergeMsg, ok := msg.Value.(overlay.MergeTargetSelectedMsg); ok {
@@ -1445,10 +3082,9 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 		// Editor open error
 		m.overlayStack.Pop()
 		if err, ok := msg.Value.(error); ok {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Editor error: %v", err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 		}
 		return m, nil
@@ -1456,6 +3092,31 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 		// Editor closed successfully
 		m.overlayStack.Pop()
 		return m, nil
+	case "beads_retry":
+		m.overlayStack.Pop()
+		m.beadsMissing = false
+		m.refreshPaused = false
+		m.loading = true
+		return m, m.loadBeadsCmd()
+	case "beads_dismiss":
+		m.overlayStack.Pop()
+		m.refreshPaused = false
+		if !m.hasRefreshLoop {
+			m.hasRefreshLoop = true
+			return m, tickEvery(m.refreshDelay())
+		}
+		return m, nil
+	case "clear_notifications":
+		// Notification center: forget every toast raised so far
+		m.toastHistory = nil
+		return m, nil
+	case "archive_detail":
+		// Archive: show the completed task's details
+		m.overlayStack.Pop()
+		if task, ok := msg.Value.(domain.Task); ok {
+			return m, m.overlayStack.Push(m.detailPanel(task, m.sessions[task.ID]))
+		}
+		return m, nil
 	case "select_child":
 		// Epic drill-down: child task selected
 		m.overlayStack.Pop()
@@ -1465,23 +3126,21 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 			m.nav.JumpToTaskByID(columns, childID)
 		}
 		return m, nil
-	case "set-default-success", "remove-success", "detect-success":
+	case "set-default-success", "remove-success", "detect-success", "scan-success":
 		// Project registry actions succeeded - just show success toast
 		if name, ok := msg.Value.(string); ok {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastSuccess,
 				Message: fmt.Sprintf("Project %s: %s", msg.Key[:len(msg.Key)-8], name), // Remove "-success"
-				Expires: time.Now().Add(3 * time.Second),
 			})
 		}
 		return m, nil
-	case "set-default-error", "remove-error", "add-error", "save-error", "detect-error":
+	case "set-default-error", "remove-error", "add-error", "save-error", "detect-error", "scan-error":
 		// Project registry actions failed
 		if err, ok := msg.Value.(error); ok {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastError,
 				Message: fmt.Sprintf("Error: %v", err),
-				Expires: time.Now().Add(5 * time.Second),
 			})
 		}
 		return m, nil
@@ -1494,107 +3153,162 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 	if task == nil {
 		return m, nil
 	}
+	// A queued session has no pane or worktree to act on yet
+	if session != nil && session.State == domain.SessionQueued {
+		session = nil
+	}
 
 	// Handle the selection based on key
 	switch msg.Key {
 	// Session actions
 	case "s":
-		// Start session
-		return m, m.startSessionCmd(task.ID)
+		// Start session, confirming first if the task is already done
+		if task.Status.Base(m.customStatuses()).IsTerminal() && !m.config.Session.SkipDoneConfirm {
+			m.pendingStartBeadID = task.ID
+			return m, m.overlayStack.Push(overlay.NewConfirmDialog(
+				"Start Session",
+				fmt.Sprintf("%s is already done.\nStart a new session anyway?", task.ID),
+			))
+		}
+		return m, m.requestSessionStart(task.ID)
 	case "S":
 		// TODO: Start session + work
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastInfo,
 			Message: "Start session + work (TODO)",
-			Expires: time.Now().Add(3 * time.Second),
 		})
 	case "a":
 		// Attach to session
 		if session != nil {
-			// Check if branch is behind main
-			return m, m.checkBranchBehindCmd(session.Worktree, task.ID)
+			// Check the session is alive and whether its branch is behind main
+			return m, m.checkAttachCmd(session.Worktree, task.ID)
 		} else {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: "No active session for this task",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 		}
+	case "t":
+		// Send a line to the session
+		if session != nil {
+			return m, m.overlayStack.Push(overlay.NewSendTextOverlay(task.ID))
+		}
 	case "p":
-		// TODO: Pause session
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: "Pause session (TODO)",
-			Expires: time.Now().Add(3 * time.Second),
-		})
+		// Pause session
+		if session != nil {
+			return m, m.pauseSessionCmd(task.ID, false)
+		}
 	case "x":
-		// Stop session
+		// Stop session, or just drop it from the start queue
+		if m.dequeueSession(task.ID) {
+			m.addToast(Toast{
+				Level:   ToastInfo,
+				Message: fmt.Sprintf("Removed %s from the session queue", task.ID),
+			})
+			return m, nil
+		}
 		if session != nil {
 			return m, m.stopSessionCmd(task.ID)
 		} else {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: "No active session for this task",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 		}
-	case "R":
-		// TODO: Resume session
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: "Resume session (TODO)",
-			Expires: time.Now().Add(3 * time.Second),
+	case "T":
+		// Retry failed session in its existing worktree
+		if session != nil {
+			return m, m.restartSessionCmd(task.ID, session.Worktree)
+		}
+		m.addToast(Toast{
+			Level:   ToastWarning,
+			Message: "No active session for this task",
 		})
+	case "R":
+		// Resume paused session
+		if session != nil && session.State == domain.SessionPaused {
+			return m, m.resumeSessionCmd(task.ID, session.Worktree)
+		}
 
 	// Git actions
 	case "u":
-		// Update from main
+		// Update from the base branch
 		if session == nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: "No active session - start session first",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 			return m, nil
 		}
-		return m, m.fetchAndMergeCmd(session.Worktree, "main")
+		if !m.requireOnline(diagnostics.FeatureGitPushPull) {
+			return m, nil
+		}
+		return m, m.fetchAndMergeCmd(session.Worktree, m.baseBranch())
 
 	case "m":
 		// TODO: Merge to main (Phase 6)
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastInfo,
 			Message: "Merge to main (TODO - Phase 6)",
-			Expires: time.Now().Add(3 * time.Second),
 		})
 
 	case "P":
+		// A bead gets one PR; open the existing one rather than a duplicate
+		if task.PRURL != "" {
+			return m, openURLCmd(task.PRURL)
+		}
+
 		// Create PR (with overlay)
 		if session == nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: "No active session - start session first",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 			return m, nil
 		}
+		if !m.requireOnline(diagnostics.FeaturePRCreation) {
+			return m, nil
+		}
 		// Get current branch name and open PR creation overlay
 		return m, m.openPROverlayCmd(session.Worktree, task.ID)
 
+	case "y":
+		// Copy a gh pr create (or glab mr create) command to the clipboard
+		if session == nil {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: "No active session - start session first",
+			})
+			return m, nil
+		}
+		return m, m.createPRCmd(session.Worktree, *task)
+
 	case "f":
 		// Show diff viewer
 		if session == nil {
-			m.toasts = append(m.toasts, Toast{
+			m.addToast(Toast{
 				Level:   ToastWarning,
 				Message: "No active session - start session first",
-				Expires: time.Now().Add(3 * time.Second),
 			})
 			return m, nil
 		}
 		// Open diff viewer overlay
 		viewer := diff.NewDiffViewer(session.Worktree)
+		viewer.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 		cmd := m.overlayStack.Push(viewer)
 		return m, tea.Batch(cmd, viewer.LoadDiff(context.Background(), m.gitClient))
 
+	case "F":
+		// Summarize the session's commits and change size
+		if session == nil {
+			m.addToast(Toast{
+				Level:   ToastWarning,
+				Message: "No active session - start session first",
+			})
+			return m, nil
+		}
+		return m, m.showDiffCmd(task.ID, session.Worktree)
+
 	case "i":
 		// Image attachments
 		attachOverlay := overlay.NewImageAttachOverlay(task.ID, m.attachmentService)
@@ -1637,11 +3351,10 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 	// Task actions
 	case "h":
 		// Move task left (to previous status)
-		if task.Status == domain.StatusOpen {
-			m.toasts = append(m.toasts, Toast{
+		if columns := m.boardColumns(); task.Status == domain.Status(columns[0].Status) {
+			m.addToast(Toast{
 				Level:   ToastWarning,
-				Message: "Task is already in Open status",
-				Expires: time.Now().Add(2 * time.Second),
+				Message: fmt.Sprintf("Task is already in %s status", columns[0].Title),
 			})
 			return m, nil
 		}
@@ -1649,11 +3362,10 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 
 	case "l":
 		// Move task right (to next status)
-		if task.Status == domain.StatusDone {
-			m.toasts = append(m.toasts, Toast{
+		if columns := m.boardColumns(); task.Status == domain.Status(columns[len(columns)-1].Status) {
+			m.addToast(Toast{
 				Level:   ToastWarning,
-				Message: "Task is already in Done status",
-				Expires: time.Now().Add(2 * time.Second),
+				Message: fmt.Sprintf("Task is already in %s status", columns[len(columns)-1].Title),
 			})
 			return m, nil
 		}
@@ -1662,6 +3374,12 @@ func (m Model) handleSelection(msg overlay.SelectionMsg) (tea.Model, tea.Cmd) {
 		return m, m.overlayStack.Push(overlay.NewEditTaskOverlay(*task))
 	case "d":
 		return m, m.deleteTaskCmd(task.ID)
+	case "o":
+		// Reopen a done task for rework
+		return m, m.reopenTaskCmd(task.ID, domain.StatusOpen, false)
+	case "O":
+		// Reopen a done task and start working on it right away
+		return m, m.reopenTaskCmd(task.ID, domain.StatusInProgress, true)
 	}
 
 	return m, nil
@@ -1686,19 +3404,10 @@ func (m Model) deleteTaskCmd(taskID string) tea.Cmd {
 // The ID-based Cursor now handles bounds clamping internally via
 // MoveVertical, MoveHorizontal, and FindPosition methods.
 
-// halfPage calculates half-page scroll distance based on terminal height
+// halfPage calculates half-page scroll distance: half the cards a column
+// shows on the board, which takes the height above the status bar
 func (m Model) halfPage() int {
-	// Approximate: subtract status bar (1) and header (2), divide by card height (~4 lines)
-	visibleRows := m.height - 3
-	if visibleRows < 4 {
-		return 1
-	}
-	cardsPerColumn := visibleRows / 4
-	half := cardsPerColumn / 2
-	if half < 1 {
-		return 1
-	}
-	return half
+	return max(board.PageCards(m.height-1)/2, 1)
 }
 
 // renderLoading renders a centered loading spinner with message
@@ -1718,18 +3427,112 @@ func (m Model) renderLoading() string {
 	)
 }
 
-// addToast adds a toast notification to the list
+// toastHistoryLimit is how many toasts the notification center keeps
+const toastHistoryLimit = 100
+
+// defaultToastDuration is how long a toast shows when config.UI.ToastDurations
+// has no positive duration for its level
+const defaultToastDuration = 3 * time.Second
+
+// commandToastDuration is how long a toast giving a command to run shows,
+// long enough to read and copy the command
+const commandToastDuration = 10 * time.Second
+
+// commandToast is an info toast telling the user to run message's command,
+// shown for commandToastDuration whatever the info level's duration
+func commandToast(message string) Toast {
+	return Toast{
+		Level:   ToastInfo,
+		Message: message,
+		Expires: time.Now().Add(commandToastDuration),
+	}
+}
+
+// addToast adds a toast notification to the list and the notification
+// center's history. Unless the toast sets its own expiry, it shows for its
+// level's config.UI.ToastDurations; with config.UI.PersistErrors, errors
+// show until dismissed.
+// An identical (level and message) toast that is still visible is refreshed
+// instead of stacking a duplicate: its expiry is extended and its count bumped.
 func (m *Model) addToast(toast Toast) {
+	now := time.Now()
+	toast.Raised = now
+	if toast.Expires.IsZero() {
+		toast.Expires = now.Add(m.toastDuration(toast.Level))
+	}
+	if toast.Level == ToastError && m.config.UI.PersistErrors {
+		toast.Persist = true
+	}
+	for i, existing := range m.toasts {
+		if existing.Level != toast.Level || existing.Message != toast.Message || !existing.Active(now) {
+			continue
+		}
+		if toast.Expires.After(existing.Expires) {
+			m.toasts[i].Expires = toast.Expires
+		}
+		m.toasts[i].Count = max(existing.Count, 1) + 1
+		m.toasts[i].Raised = now
+		m.recordToast(m.toasts[i])
+		return
+	}
 	m.toasts = append(m.toasts, toast)
+	m.recordToast(toast)
+}
+
+// toastDuration returns how long a toast of level shows
+func (m Model) toastDuration(level ToastLevel) time.Duration {
+	if ms := m.config.UI.ToastDurations[level.String()]; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultToastDuration
+}
+
+// dismissToast removes the newest toast on screen, the only way persistent
+// error toasts go
+func (m *Model) dismissToast() {
+	if len(m.toasts) > 0 {
+		m.toasts = m.toasts[:len(m.toasts)-1]
+	}
+}
+
+// recordToast adds toast to the history, replacing the entry of the same
+// visible toast raised again, and drops the oldest past toastHistoryLimit
+func (m *Model) recordToast(toast Toast) {
+	if n := len(m.toastHistory); n > 0 && toast.Count > 1 {
+		for i := n - 1; i >= 0; i-- {
+			if last := m.toastHistory[i]; last.Level == toast.Level && last.Message == toast.Message {
+				m.toastHistory = append(m.toastHistory[:i], m.toastHistory[i+1:]...)
+				break
+			}
+		}
+	}
+	m.toastHistory = append(m.toastHistory, toast)
+	if over := len(m.toastHistory) - toastHistoryLimit; over > 0 {
+		m.toastHistory = m.toastHistory[over:]
+	}
+}
+
+// requireOnline reports whether a network-dependent feature can run now,
+// showing an offline warning instead when it can't
+func (m *Model) requireOnline(feature string) bool {
+	if m.isOnline || !diagnostics.RequiresNetwork(feature) {
+		return true
+	}
+	m.addToast(Toast{
+		Level:   ToastWarning,
+		Message: fmt.Sprintf("You're offline - %s needs a network connection", feature),
+	})
+	return false
 }
 
-// expireToasts removes expired toasts from the list
+// expireToasts removes expired toasts from the list. Persistent toasts
+// stay until dismissed.
 func (m *Model) expireToasts() {
 	now := time.Now()
 	filtered := make([]Toast, 0, len(m.toasts))
 
 	for _, toast := range m.toasts {
-		if toast.Expires.After(now) {
+		if toast.Active(now) {
 			filtered = append(filtered, toast)
 		}
 	}
@@ -1737,23 +3540,70 @@ func (m *Model) expireToasts() {
 	m.toasts = filtered
 }
 
+// movedHighlightDuration is how long a card stays highlighted after a
+// refresh moves it to another column
+const movedHighlightDuration = 5 * time.Second
+
+// markMovedTasks highlights tasks whose status changed between two loads
+func (m *Model) markMovedTasks(previous, current []domain.Task) {
+	oldStatus := make(map[string]domain.Status, len(previous))
+	for _, task := range previous {
+		oldStatus[task.ID] = task.Status
+	}
+
+	expires := time.Now().Add(movedHighlightDuration)
+	for _, task := range current {
+		if status, ok := oldStatus[task.ID]; ok && status != task.Status {
+			m.movedTasks[task.ID] = expires
+		}
+	}
+}
+
+// expireMovedTasks removes highlights that have run their course
+func (m *Model) expireMovedTasks() {
+	now := time.Now()
+	for beadID, expires := range m.movedTasks {
+		if !expires.After(now) {
+			delete(m.movedTasks, beadID)
+		}
+	}
+}
+
+// highlightedTasks returns the IDs of tasks whose move highlight is still active
+func (m Model) highlightedTasks() map[string]bool {
+	now := time.Now()
+	highlighted := make(map[string]bool, len(m.movedTasks))
+	for beadID, expires := range m.movedTasks {
+		if expires.After(now) {
+			highlighted[beadID] = true
+		}
+	}
+	return highlighted
+}
+
 // Git operation commands
 
 type fetchAndMergeResultMsg struct {
-	worktree string
-	result   *git.MergeResult
-	err      error
+	worktree  string
+	branch    string // Base branch merged from
+	result    *git.MergeResult
+	err       error
+	stashed   bool             // Dirty changes were auto-stashed before the merge
+	popResult *git.MergeResult // Restoring the stash, when it was popped
 }
 
 type createPRResultMsg struct {
 	beadID string
 	cmd    string
+	copied bool // cmd is on the clipboard
 	err    error
 }
 
 type showDiffResultMsg struct {
-	diff string
-	err  error
+	beadID  string
+	base    string
+	summary git.ChangeSummary
+	err     error
 }
 
 // fetchAndMergeCmd fetches and merges from the specified branch
@@ -1761,26 +3611,87 @@ func (m Model) fetchAndMergeCmd(worktree, branch string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
+		// With git.autoStash, set aside uncommitted work that would block
+		// the merge
+		stashed := false
+		if m.config.Git.AutoStash {
+			status, err := m.gitClient.Status(ctx, worktree)
+			if err != nil {
+				return fetchAndMergeResultMsg{
+					worktree: worktree,
+					branch:   branch,
+					err:      fmt.Errorf("status failed: %w", err),
+				}
+			}
+			if status.HasChanges {
+				if err := m.gitClient.Stash(ctx, worktree); err != nil {
+					return fetchAndMergeResultMsg{
+						worktree: worktree,
+						branch:   branch,
+						err:      fmt.Errorf("stash failed: %w", err),
+					}
+				}
+				stashed = true
+			}
+		}
+
 		// Fetch from origin
 		if err := m.gitClient.Fetch(ctx, worktree, "origin"); err != nil {
-			return fetchAndMergeResultMsg{
+			msg := fetchAndMergeResultMsg{
 				worktree: worktree,
+				branch:   branch,
 				err:      fmt.Errorf("fetch failed: %w", err),
+				stashed:  stashed,
 			}
+			if stashed {
+				msg.popResult, _ = m.gitClient.StashPop(ctx, worktree)
+			}
+			return msg
 		}
 
 		// Merge origin/branch
 		result, err := m.gitClient.Merge(ctx, worktree, "origin/"+branch)
-		return fetchAndMergeResultMsg{
+		msg := fetchAndMergeResultMsg{
 			worktree: worktree,
+			branch:   branch,
 			result:   result,
 			err:      err,
+			stashed:  stashed,
+		}
+
+		// Popping onto a failed or conflicted merge would fail too; the
+		// stash is kept for after the conflicts are resolved
+		if stashed && err == nil && !result.HasConflicts {
+			popResult, popErr := m.gitClient.StashPop(ctx, worktree)
+			if popErr != nil {
+				msg.err = fmt.Errorf("updated, but restoring stashed changes failed: %w", popErr)
+			}
+			msg.popResult = popResult
 		}
+		return msg
 	}
 }
 
-// createPRCmd generates the gh pr create command
-func (m Model) createPRCmd(worktree, beadID string) tea.Cmd {
+// prCreateCommand returns the command creating a PR for a bead's branch: gh
+// pr create, or glab mr create on GitLab
+func prCreateCommand(provider, branch, title, body string) string {
+	if provider == pr.ProviderGitLab {
+		return fmt.Sprintf("glab mr create --source-branch %s --title %s --description %s", branch, shellQuote(title), shellQuote(body))
+	}
+	return fmt.Sprintf("gh pr create --head %s --title %s --body %s", branch, shellQuote(title), shellQuote(body))
+}
+
+// prDefaults returns the title and body a task's PR starts with
+func (m Model) prDefaults(task domain.Task) (title, body string) {
+	return pr.DefaultTitle(task), pr.DefaultBody(task, m.config.PR.AutoLink, m.config.PR.LinkKeyword)
+}
+
+// createPRCmd generates the PR create command and copies it to the
+// clipboard when there is one
+func (m Model) createPRCmd(worktree string, task domain.Task) tea.Cmd {
+	beadID := task.ID
+	title, body := m.prDefaults(task)
+	provider := m.prProvider
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -1793,32 +3704,49 @@ func (m Model) createPRCmd(worktree, beadID string) tea.Cmd {
 			}
 		}
 
-		// Generate gh pr create command
-		cmd := fmt.Sprintf("gh pr create --head %s --title \"[%s] ...\" --body \"...\"", branch, beadID)
+		// Generate the PR create command
+		cmd := prCreateCommand(provider, branch, title, body)
+
+		// Without a clipboard the command is still shown in a toast
+		copied := true
+		if err := attachment.WriteTextToClipboard(ctx, cmd); err != nil {
+			m.logger.Debug("failed to copy PR command", "error", err)
+			copied = false
+		}
 
 		return createPRResultMsg{
 			beadID: beadID,
 			cmd:    cmd,
+			copied: copied,
 			err:    nil,
 		}
 	}
 }
 
 // showDiffCmd gets the diff stat for the worktree
-func (m Model) showDiffCmd(worktree string) tea.Cmd {
+func (m Model) showDiffCmd(beadID, worktree string) tea.Cmd {
+	base := m.baseBranch()
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		diff, err := m.gitClient.DiffStat(ctx, worktree)
+		stat, err := m.gitClient.BranchNumstat(ctx, worktree, base)
 		if err != nil {
 			return showDiffResultMsg{
 				err: fmt.Errorf("failed to get diff: %w", err),
 			}
 		}
 
+		log, err := m.gitClient.Log(ctx, worktree, base+"..HEAD")
+		if err != nil {
+			return showDiffResultMsg{
+				err: fmt.Errorf("failed to get commits: %w", err),
+			}
+		}
+
 		return showDiffResultMsg{
-			diff: diff,
-			err:  nil,
+			beadID:  beadID,
+			base:    base,
+			summary: git.ParseChangeSummary(stat, log),
 		}
 	}
 }
@@ -1840,20 +3768,15 @@ func (m Model) handleConflictResolution(resolution overlay.ConflictResolutionMsg
 
 	case resolution.OpenManually:
 		// Show instructions to open in editor
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastInfo,
 			Message: fmt.Sprintf("Open conflicted files in your editor at: %s", session.Worktree),
-			Expires: time.Now().Add(8 * time.Second),
 		})
 		return m, nil
 
 	case resolution.ResolveWithClaude:
 		// Attach to tmux session for Claude to resolve
-		m.toasts = append(m.toasts, Toast{
-			Level:   ToastInfo,
-			Message: fmt.Sprintf("Run: tmux attach-session -t %s (Claude can help resolve)", task.ID),
-			Expires: time.Now().Add(8 * time.Second),
-		})
+		m.addToast(commandToast(fmt.Sprintf("Run: %s (Claude can help resolve)", m.tmuxClient.AttachCommand(task.ID))))
 		return m, nil
 
 	default:
@@ -1867,24 +3790,26 @@ func (m Model) handleMergeTargetSelection(msg overlay.MergeTargetSelectedMsg) (t
 
 	sourceSession, hasSource := m.sessions[msg.SourceID]
 	if !hasSource {
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastError,
 			Message: "Source session not found",
-			Expires: time.Now().Add(3 * time.Second),
 		})
 		return m, nil
 	}
 
-	if msg.TargetID == "main" {
+	if msg.TargetID == overlay.BaseMergeTarget {
+		// Merging to the base branch fetches origin first
+		if !m.requireOnline(diagnostics.FeatureGitPushPull) {
+			return m, nil
+		}
 		return m, m.mergeToMainCmd(sourceSession.Worktree, msg.SourceID)
 	}
 
 	targetSession, hasTarget := m.sessions[msg.TargetID]
 	if !hasTarget {
-		m.toasts = append(m.toasts, Toast{
+		m.addToast(Toast{
 			Level:   ToastError,
 			Message: "Target session not found",
-			Expires: time.Now().Add(3 * time.Second),
 		})
 		return m, nil
 	}
@@ -1894,7 +3819,7 @@ func (m Model) handleMergeTargetSelection(msg overlay.MergeTargetSelectedMsg) (t
 
 type mergeResultMsg struct {
 	sourceID string
-	targetID string
+	targetID string // Task merged into, or the base branch's name
 	result   *git.MergeResult
 	err      error
 }
@@ -1902,23 +3827,23 @@ type mergeResultMsg struct {
 func (m Model) mergeToMainCmd(sourceWorktree, sourceID string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		baseBranch := m.config.Git.BaseBranch
+		baseBranch := m.gitSyncService.BaseBranch(ctx)
 
 		branch, err := m.gitClient.CurrentBranch(ctx, sourceWorktree)
 		if err != nil {
-			return mergeResultMsg{sourceID: sourceID, targetID: "main", err: err}
+			return mergeResultMsg{sourceID: sourceID, targetID: baseBranch, err: err}
 		}
 
 		if err := m.gitClient.Fetch(ctx, ".", "origin"); err != nil {
-			return mergeResultMsg{sourceID: sourceID, targetID: "main", err: err}
+			return mergeResultMsg{sourceID: sourceID, targetID: baseBranch, err: err}
 		}
 
 		if err := m.gitClient.Checkout(ctx, ".", baseBranch); err != nil {
-			return mergeResultMsg{sourceID: sourceID, targetID: "main", err: err}
+			return mergeResultMsg{sourceID: sourceID, targetID: baseBranch, err: err}
 		}
 
 		result, err := m.gitClient.Merge(ctx, ".", branch)
-		return mergeResultMsg{sourceID: sourceID, targetID: "main", result: result, err: err}
+		return mergeResultMsg{sourceID: sourceID, targetID: baseBranch, result: result, err: err}
 	}
 }
 
@@ -1968,12 +3893,7 @@ func (m Model) bulkMoveStatusCmd(taskIDs []string, delta int) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		statusOrder := []domain.Status{
-			domain.StatusOpen,
-			domain.StatusInProgress,
-			domain.StatusBlocked,
-			domain.StatusDone,
-		}
+		statusOrder := m.statusOrder()
 
 		updated := 0
 		failed := 0
@@ -2030,6 +3950,73 @@ func (m Model) bulkMoveStatusCmd(taskIDs []string, delta int) tea.Cmd {
 	}
 }
 
+// bulkStartSessions starts a session for every selected task that doesn't
+// already have one. Done tasks are skipped unless session.skipDoneConfirm is
+// set, since there is no per-task confirmation in bulk.
+func (m Model) bulkStartSessions(taskIDs []string) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	skipped, queued := 0, 0
+
+	for _, taskID := range taskIDs {
+		task := m.findTask(taskID)
+		if task == nil || m.sessions[taskID] != nil {
+			skipped++
+			continue
+		}
+		if task.Status.Base(m.customStatuses()).IsTerminal() && !m.config.Session.SkipDoneConfirm {
+			skipped++
+			continue
+		}
+		switch cmd, wait := m.startOrQueueSession(taskID); {
+		case cmd != nil:
+			cmds = append(cmds, cmd)
+		case wait:
+			queued++
+		default:
+			// Already queued or starting
+			skipped++
+		}
+	}
+
+	message := fmt.Sprintf("Starting %d sessions", len(cmds))
+	if queued > 0 {
+		message += fmt.Sprintf(", %d queued", queued)
+	}
+	if skipped > 0 {
+		message += fmt.Sprintf(" (%d skipped)", skipped)
+	}
+	m.addToast(Toast{
+		Level:   ToastInfo,
+		Message: message,
+	})
+	m.editor.ClearSelection()
+	m.editor.EnterNormal()
+
+	return m, tea.Batch(cmds...)
+}
+
+// bulkCloseCmd closes all selected tasks in beads
+func (m Model) bulkCloseCmd(taskIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		updated := 0
+		failed := 0
+
+		for _, taskID := range taskIDs {
+			err := m.beadsClient.Close(ctx, taskID, "")
+			if err != nil {
+				failed++
+				continue
+			}
+			updated++
+		}
+
+		return bulkStatusResultMsg{updated: updated, failed: failed}
+	}
+}
+
 func (m Model) bulkDeleteCmd(taskIDs []string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -2109,13 +4096,22 @@ func (m Model) saveTaskCmd(msg overlay.TaskCreatedMsg) tea.Cmd {
 			return taskCreatedResultMsg{taskID: msg.ID, err: err, isUpdate: true}
 		}
 
-		taskID, err := m.beadsClient.Create(ctx, beads.CreateTaskParams{
+		params := beads.CreateTaskParams{
 			Title:       msg.Title,
 			Description: msg.Description,
 			Type:        msg.Type,
 			Priority:    msg.Priority,
 			ParentID:    msg.ParentID,
-		})
+		}
+		if !m.isOnline {
+			return queueChangeMsg{change: beads.PendingChange{
+				Kind:     beads.PendingCreate,
+				Create:   &params,
+				QueuedAt: time.Now(),
+			}}
+		}
+
+		taskID, err := m.beadsClient.Create(ctx, params)
 		return taskCreatedResultMsg{taskID: taskID, err: err, isUpdate: false}
 	}
 }
@@ -2123,6 +4119,7 @@ func (m Model) saveTaskCmd(msg overlay.TaskCreatedMsg) tea.Cmd {
 // Single task status result
 type taskStatusResultMsg struct {
 	taskID    string
+	oldStatus domain.Status
 	newStatus domain.Status
 	err       error
 }
@@ -2133,12 +4130,7 @@ func (m Model) moveTaskStatusCmd(taskID string, delta int) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		statusOrder := []domain.Status{
-			domain.StatusOpen,
-			domain.StatusInProgress,
-			domain.StatusBlocked,
-			domain.StatusDone,
-		}
+		statusOrder := m.statusOrder()
 
 		// Find the task to get current status
 		var currentTask *domain.Task
@@ -2174,13 +4166,43 @@ func (m Model) moveTaskStatusCmd(taskID string, delta int) tea.Cmd {
 
 		newStatus := statusOrder[newIdx]
 
+		if !m.isOnline {
+			return queueChangeMsg{change: beads.PendingChange{
+				Kind:     beads.PendingStatus,
+				TaskID:   taskID,
+				From:     currentTask.Status,
+				To:       newStatus,
+				QueuedAt: time.Now(),
+			}}
+		}
+
 		// Update via beads client
 		err := m.beadsClient.Update(ctx, taskID, newStatus)
 		if err != nil {
 			return taskStatusResultMsg{taskID: taskID, err: err}
 		}
 
-		return taskStatusResultMsg{taskID: taskID, newStatus: newStatus}
+		return taskStatusResultMsg{taskID: taskID, oldStatus: currentTask.Status, newStatus: newStatus}
+	}
+}
+
+// taskReopenedMsg reports the result of moving a done task back onto the board
+type taskReopenedMsg struct {
+	taskID       string
+	status       domain.Status
+	startSession bool
+	err          error
+}
+
+// reopenTaskCmd moves a task back to status, optionally starting a fresh
+// session once the status has been updated
+func (m Model) reopenTaskCmd(taskID string, status domain.Status, startSession bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := m.beadsClient.Update(ctx, taskID, status)
+		return taskReopenedMsg{taskID: taskID, status: status, startSession: startSession, err: err}
 	}
 }
 
@@ -2195,6 +4217,16 @@ func (m Model) isCurrentTaskEpic() bool {
 	return task.Type == domain.TypeEpic
 }
 
+// findTask returns the loaded task with the given ID, or nil
+func (m Model) findTask(taskID string) *domain.Task {
+	for i := range m.tasks {
+		if m.tasks[i].ID == taskID {
+			return &m.tasks[i]
+		}
+	}
+	return nil
+}
+
 // getEpicChildren returns all tasks that are children of the given epic
 func (m Model) getEpicChildren(epicID string) []domain.Task {
 	var children []domain.Task
@@ -2206,6 +4238,26 @@ func (m Model) getEpicChildren(epicID string) []domain.Task {
 	return children
 }
 
+// computeEpicProgress counts completed children for every epic on the board
+func (m Model) computeEpicProgress() map[string]board.EpicProgress {
+	progress := make(map[string]board.EpicProgress)
+	custom := m.customStatuses()
+	for _, task := range m.tasks {
+		if task.Type != domain.TypeEpic {
+			continue
+		}
+		var p board.EpicProgress
+		for _, child := range m.getEpicChildren(task.ID) {
+			p.Total++
+			if child.Status.Base(custom) == domain.StatusDone {
+				p.Done++
+			}
+		}
+		progress[task.ID] = p
+	}
+	return progress
+}
+
 type taskCreatedResultMsg struct {
 	taskID   string
 	err      error
@@ -2219,17 +4271,20 @@ func (m Model) createTaskCmd(msg overlay.TaskCreatedMsg) tea.Cmd {
 // PR creation with overlay
 
 type prCreatedResultMsg struct {
-	url string
-	err error
+	beadID string
+	url    string
+	err    error
 }
 
 type openPROverlayResultMsg struct {
-	branch string
-	beadID string
-	err    error
+	branch   string
+	beadID   string
+	existing *pr.PRInfo // Open PR already on the branch, if any
+	err      error
 }
 
-// openPROverlayCmd gets the current branch and opens the PR creation overlay
+// openPROverlayCmd gets the current branch and opens the PR creation
+// overlay, unless the branch already has an open PR
 func (m Model) openPROverlayCmd(worktree, beadID string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
@@ -2237,7 +4292,13 @@ func (m Model) openPROverlayCmd(worktree, beadID string) tea.Cmd {
 		if err != nil {
 			return openPROverlayResultMsg{err: err}
 		}
-		return openPROverlayResultMsg{branch: branch, beadID: beadID}
+
+		// Not knowing shouldn't stop PR creation; gh will refuse a duplicate
+		existing, err := m.prWorkflow.Existing(ctx, branch)
+		if err != nil {
+			m.logger.Warn("failed to check for existing PR", "branch", branch, "error", err)
+		}
+		return openPROverlayResultMsg{branch: branch, beadID: beadID, existing: existing}
 	}
 }
 
@@ -2246,19 +4307,47 @@ func (m Model) createPRWithOverlayCmd(msg overlay.PRCreatedMsg) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
+		body := msg.Body
+		if m.config.PR.AutoLink {
+			body = pr.AppendLink(body, m.config.PR.LinkKeyword, msg.BeadID)
+		}
+
 		result, err := m.prWorkflow.Create(ctx, pr.CreatePRParams{
 			Title:      msg.Title,
-			Body:       msg.Body,
+			Body:       body,
 			Branch:     msg.Branch,
 			BaseBranch: msg.BaseBranch,
 			Draft:      msg.Draft,
 			BeadID:     msg.BeadID,
 		})
 		if err != nil {
-			return prCreatedResultMsg{err: err}
+			return prCreatedResultMsg{beadID: msg.BeadID, err: err}
 		}
 
-		return prCreatedResultMsg{url: result.URL}
+		return prCreatedResultMsg{beadID: msg.BeadID, url: result.URL}
+	}
+}
+
+// sessionGoneMsg reports that a session about to be attached to is no
+// longer running, or that checking failed with err
+type sessionGoneMsg struct {
+	beadID string
+	err    error
+}
+
+// checkAttachCmd makes sure beadID's session is still running before the
+// branch check that leads to attaching, so a dead session offers a restart
+// instead of an attach command that fails
+func (m Model) checkAttachCmd(worktree, beadID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		exists, err := m.tmuxClient.SessionExists(ctx, beadID)
+		if err != nil || !exists {
+			return sessionGoneMsg{beadID: beadID, err: err}
+		}
+		return m.checkBranchBehindCmd(worktree, beadID)()
 	}
 }
 
@@ -2274,7 +4363,7 @@ func (m Model) checkBranchBehindCmd(worktree, beadID string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		baseBranch := m.config.Git.BaseBranch
+		baseBranch := m.gitSyncService.BaseBranch(ctx)
 		remote := "origin"
 
 		if err := m.gitClient.Fetch(ctx, worktree, remote); err != nil {
@@ -2327,11 +4416,7 @@ func (m Model) toggleDevServer(serverID string) tea.Cmd {
 func (m Model) viewDevServer(serverID string) tea.Cmd {
 	return func() tea.Msg {
 		// For now, show a toast with instructions
-		return Toast{
-			Level:   ToastInfo,
-			Message: fmt.Sprintf("Run: tmux attach-session -t devserver-%s", serverID),
-			Expires: time.Now().Add(5 * time.Second),
-		}
+		return commandToast(fmt.Sprintf("Run: tmux attach-session -t devserver-%s", serverID))
 	}
 }
 
@@ -2350,8 +4435,8 @@ func (m Model) restartDevServer(serverID string) tea.Cmd {
 func (m Model) getMergeCandidates(source *domain.Task) []overlay.MergeTarget {
 	candidates := []overlay.MergeTarget{
 		{
-			ID:     "main",
-			Label:  "main branch",
+			ID:     overlay.BaseMergeTarget,
+			Label:  m.baseBranch(),
 			IsMain: true,
 		},
 	}
@@ -2377,13 +4462,40 @@ func (m Model) getMergeCandidates(source *domain.Task) []overlay.MergeTarget {
 	return candidates
 }
 
+// resolveCursor keeps the cursor on its task after the board was rebuilt,
+// e.g. by a refresh or a filter change, and scrolls it into view
+func (m *Model) resolveCursor() {
+	columns := m.buildColumns()
+	m.nav.Resolve(columns)
+	m.nav.ScrollIntoView(columns, m.height-1)
+}
+
+// scrollBoard scrolls the board's columns to keep the cursor card in view,
+// once the cursor moved or the window was resized
+func (m *Model) scrollBoard() {
+	m.nav.ScrollIntoView(m.buildColumns(), m.height-1)
+}
+
 // View rendering helpers
 
+// focusedColumn returns the column focus mode gives the board to, the
+// cursor's, or board.NoFocus outside focus mode
+func (m Model) focusedColumn(cursor board.Cursor) int {
+	if !m.focusMode {
+		return board.NoFocus
+	}
+	return cursor.Column
+}
+
 // renderBoardView renders the kanban board view
 func (m Model) renderBoardView() string {
 	// Build columns for the board
 	columns := m.buildColumns()
 
+	// Draw each column from the scroll Update left it at; only cards in
+	// view are rendered
+	m.nav.ApplyOffsets(columns)
+
 	// Create cursor for board package using computed position
 	pos := m.nav.GetPosition(columns)
 	cursor := board.Cursor{
@@ -2401,9 +4513,16 @@ func (m Model) renderBoardView() string {
 	return board.Render(
 		columns,
 		cursor,
+		m.focusedColumn(cursor),
 		m.editor.GetSelectedTasks(),
+		m.highlightedTasks(),
 		phaseData,
 		m.editor.GetShowPhases(),
+		m.computeEpicProgress(),
+		board.AgeOptions{
+			Show:       m.config.UI.ShowAge,
+			StaleAfter: time.Duration(m.config.Worktree.KeepDays) * 24 * time.Hour,
+		},
 		m.styles,
 		m.width,
 		m.height-1,
@@ -2446,10 +4565,13 @@ func (m Model) getFlatIndexFromPosition(pos navigation.Position, columns []board
 
 // openOrchestrationOverlay creates and opens the orchestration overlay
 func (m Model) openOrchestrationOverlay() tea.Cmd {
+	// Sessions live in m.sessions, not on the loaded tasks
+	tasks := m.tasksWithSessions()
+
 	// Gather session information
 	var sessions []overlay.SessionInfo
-	for _, task := range m.tasks {
-		if task.Session != nil {
+	for _, task := range tasks {
+		if _, ok := m.sessions[task.ID]; ok {
 			sessions = append(sessions, overlay.SessionInfo{
 				BeadID:       task.ID,
 				TaskTitle:    task.Title,
@@ -2468,11 +4590,7 @@ func (m Model) openOrchestrationOverlay() tea.Cmd {
 		func(beadID string) tea.Cmd {
 			return func() tea.Msg {
 				// Show attach instructions
-				return Toast{
-					Level:   ToastInfo,
-					Message: fmt.Sprintf("Run: tmux attach-session -t %s", beadID),
-					Expires: time.Now().Add(5 * time.Second),
-				}
+				return commandToast("Run: " + m.tmuxClient.AttachCommand(beadID))
 			}
 		},
 		// onKill
@@ -2484,6 +4602,7 @@ func (m Model) openOrchestrationOverlay() tea.Cmd {
 			return m.loadBeadsCmd()
 		},
 	)
+	orchOverlay.SetParallelism(phases.ComputeParallelism(tasks))
 
 	return m.overlayStack.Push(orchOverlay)
 }
@@ -1,11 +1,33 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+	"github.com/riordanpawley/azedarach/internal/services/diagnostics"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+	"github.com/riordanpawley/azedarach/internal/services/monitor"
+	"github.com/riordanpawley/azedarach/internal/services/network"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
+	"github.com/riordanpawley/azedarach/internal/services/pr"
+	"github.com/riordanpawley/azedarach/internal/services/sessionlog"
+	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/ui/overlay"
 )
 
 // Helper to create a test model with tasks
@@ -110,9 +132,9 @@ func TestHelperMethods(t *testing.T) {
 	t.Run("halfPage", func(t *testing.T) {
 		m.height = 24
 		half := m.halfPage()
-		// (24 - 3) / 4 = 5 cards, half = 2
-		if half < 1 {
-			t.Errorf("Expected at least 1, got %d", half)
+		// (24 - 1 - 2) / 5 = 4 cards, half = 2
+		if half != 2 {
+			t.Errorf("Expected 2, got %d", half)
 		}
 
 		m.height = 4
@@ -227,6 +249,34 @@ func TestNormalModeNavigation(t *testing.T) {
 	})
 }
 
+func TestSkipEmptyColumns(t *testing.T) {
+	m := newTestModel()
+	// Only the open and done tasks pass the filter
+	m.editor.ToggleStatusFilter(domain.StatusOpen)
+	m.editor.ToggleStatusFilter(domain.StatusDone)
+	m.showDone = true
+	m.nav.SelectTask("az-1", 0)
+
+	result, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	m = result.(Model)
+	if id := m.nav.GetCursor().TaskID; id != "az-5" {
+		t.Errorf("Expected L to skip to az-5, got %q", id)
+	}
+
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = result.(Model)
+	if id := m.nav.GetCursor().TaskID; id != "az-1" {
+		t.Errorf("Expected H to skip back to az-1, got %q", id)
+	}
+
+	// l still stops on the empty In Progress column
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = result.(Model)
+	if pos := getCursorPosition(m); pos.Column != 1 {
+		t.Errorf("Expected l to stop on column 1, got %d", pos.Column)
+	}
+}
+
 func TestHalfPageScroll(t *testing.T) {
 	m := newTestModel()
 
@@ -365,6 +415,42 @@ func TestGotoMode(t *testing.T) {
 	})
 }
 
+func TestAttentionFilterPreset(t *testing.T) {
+	m := newTestModel()
+	m.sessions["az-2"] = &domain.Session{BeadID: "az-2", State: domain.SessionBusy}
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionWaiting}
+	m.sessions["az-4"] = &domain.Session{BeadID: "az-4", State: domain.SessionError}
+	m.nav.SelectTask("az-1", 0)
+	bang := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}}
+
+	result, _ := m.handleNormalMode(bang)
+	m = result.(Model)
+
+	states := m.editor.GetFilter().SessionState
+	if len(states) != 2 || !states[domain.SessionWaiting] || !states[domain.SessionError] {
+		t.Errorf("Expected session filter {waiting, error}, got %v", states)
+	}
+	if id := m.nav.GetCursor().TaskID; id != "az-3" {
+		t.Errorf("Expected cursor on the first waiting task az-3, got %s", id)
+	}
+	var visible []string
+	for _, col := range m.buildColumns() {
+		for _, task := range col.Tasks {
+			visible = append(visible, task.ID)
+		}
+	}
+	if strings.Join(visible, ",") != "az-3,az-4" {
+		t.Errorf("Expected only az-3 and az-4 on the board, got %v", visible)
+	}
+
+	// Pressing it again turns the preset off
+	result, _ = m.handleNormalMode(bang)
+	m = result.(Model)
+	if states := m.editor.GetFilter().SessionState; len(states) != 0 {
+		t.Errorf("Expected session filter cleared, got %v", states)
+	}
+}
+
 func TestModeTransitions(t *testing.T) {
 	m := newTestModel()
 
@@ -419,3 +505,3404 @@ func TestModeStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestAddToast_Dedup(t *testing.T) {
+	m := newTestModel()
+	m.toasts = nil
+
+	first := time.Now().Add(2 * time.Second)
+	m.addToast(Toast{Level: ToastError, Message: "bd: connection refused", Expires: first})
+
+	second := time.Now().Add(8 * time.Second)
+	m.addToast(Toast{Level: ToastError, Message: "bd: connection refused", Expires: second})
+
+	if len(m.toasts) != 1 {
+		t.Fatalf("Expected 1 toast after duplicate add, got %d", len(m.toasts))
+	}
+	if !m.toasts[0].Expires.Equal(second) {
+		t.Errorf("Expected expiry to be extended to %v, got %v", second, m.toasts[0].Expires)
+	}
+	if m.toasts[0].Count != 2 {
+		t.Errorf("Expected count 2, got %d", m.toasts[0].Count)
+	}
+
+	// Different level or message still stacks
+	m.addToast(Toast{Level: ToastWarning, Message: "bd: connection refused", Expires: second})
+	m.addToast(Toast{Level: ToastError, Message: "other error", Expires: second})
+	if len(m.toasts) != 3 {
+		t.Errorf("Expected 3 toasts, got %d", len(m.toasts))
+	}
+}
+
+func TestAddToast_ExpiredNotReused(t *testing.T) {
+	m := newTestModel()
+	m.toasts = []Toast{{Level: ToastError, Message: "failed", Expires: time.Now().Add(-time.Second)}}
+
+	m.addToast(Toast{Level: ToastError, Message: "failed", Expires: time.Now().Add(5 * time.Second)})
+
+	if len(m.toasts) != 2 {
+		t.Errorf("Expected expired toast not to be refreshed, got %d toasts", len(m.toasts))
+	}
+}
+
+func TestAddToast_DurationByLevel(t *testing.T) {
+	m := newTestModel()
+	m.toasts = nil
+	m.config.UI.ToastDurations = map[string]int{"info": 1000, "error": 20000}
+
+	before := time.Now()
+	m.addToast(Toast{Level: ToastInfo, Message: "saved"})
+	m.addToast(Toast{Level: ToastError, Message: "failed"})
+	m.addToast(Toast{Level: ToastWarning, Message: "no duration configured"})
+
+	for i, want := range []time.Duration{time.Second, 20 * time.Second, defaultToastDuration} {
+		if got := m.toasts[i].Expires.Sub(before); got < want || got > want+time.Second {
+			t.Errorf("Toast %q shows for %v, want %v", m.toasts[i].Message, got, want)
+		}
+	}
+}
+
+func TestExpireToasts_PersistErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		persistErrors bool
+		want          []string
+	}{
+		{"errors persist", true, []string{"push failed"}},
+		{"errors expire", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.toasts = nil
+			m.config.UI.PersistErrors = tt.persistErrors
+
+			past := time.Now().Add(-time.Second)
+			m.addToast(Toast{Level: ToastError, Message: "push failed", Expires: past})
+			m.addToast(Toast{Level: ToastWarning, Message: "offline", Expires: past})
+			m.expireToasts()
+
+			var got []string
+			for _, toast := range m.toasts {
+				got = append(got, toast.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected toasts %v after expiry, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDismissToast(t *testing.T) {
+	m := newTestModel()
+	m.toasts = nil
+	m.config.UI.PersistErrors = true
+	m.addToast(Toast{Level: ToastError, Message: "first"})
+	m.addToast(Toast{Level: ToastError, Message: "second"})
+
+	result, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = result.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].Message != "first" {
+		t.Fatalf("Expected x to dismiss the newest toast, got %+v", m.toasts)
+	}
+
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = result.(Model)
+	if len(m.toasts) != 0 {
+		t.Errorf("Expected no toasts left, got %+v", m.toasts)
+	}
+	// Dismissed toasts stay in the notification center
+	if len(m.toastHistory) != 2 {
+		t.Errorf("Expected both toasts in history, got %+v", m.toastHistory)
+	}
+}
+
+func TestToastHistory_KeepsExpiredUntilCleared(t *testing.T) {
+	m := newTestModel()
+	m.toasts = nil
+
+	m.addToast(Toast{Level: ToastError, Message: "push failed", Expires: time.Now().Add(-time.Second)})
+	m.addToast(Toast{Level: ToastInfo, Message: "Board refreshed", Expires: time.Now().Add(5 * time.Second)})
+	m.addToast(Toast{Level: ToastInfo, Message: "Board refreshed", Expires: time.Now().Add(5 * time.Second)})
+	m.expireToasts()
+
+	if len(m.toasts) != 1 {
+		t.Fatalf("Expected the expired toast to be gone from the screen, got %+v", m.toasts)
+	}
+	// The repeat is one entry, counted, rather than two
+	if len(m.toastHistory) != 2 || m.toastHistory[0].Message != "push failed" || m.toastHistory[1].Count != 2 {
+		t.Fatalf("Expected the expired error and the counted repeat in history, got %+v", m.toastHistory)
+	}
+
+	// The notification center lists them newest first
+	result, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = result.(Model)
+	center, ok := m.overlayStack.Current().(*overlay.NotificationsOverlay)
+	if !ok {
+		t.Fatalf("Expected N to open the notification center, got %T", m.overlayStack.Current())
+	}
+	if got := center.Toasts(); len(got) != 2 || got[1].Message != "push failed" {
+		t.Errorf("Expected the expired error listed last, got %+v", got)
+	}
+
+	// Clearing empties the history
+	_, cmd := center.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if len(m.toastHistory) != 0 {
+		t.Errorf("Expected c to clear the history, got %+v", m.toastHistory)
+	}
+}
+
+func TestToastHistory_Capped(t *testing.T) {
+	m := newTestModel()
+	for i := 0; i < toastHistoryLimit+5; i++ {
+		m.addToast(Toast{Level: ToastInfo, Message: fmt.Sprintf("toast %d", i), Expires: time.Now().Add(time.Second)})
+	}
+
+	if len(m.toastHistory) != toastHistoryLimit {
+		t.Fatalf("Expected %d toasts kept, got %d", toastHistoryLimit, len(m.toastHistory))
+	}
+	if m.toastHistory[0].Message != "toast 5" {
+		t.Errorf("Expected the 5 oldest to be dropped, oldest is %q", m.toastHistory[0].Message)
+	}
+}
+
+func TestDiagnosticsRefresh_ReachesPanel(t *testing.T) {
+	m := newTestModel()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = updated.(Model)
+	panel, ok := m.overlayStack.Current().(*overlay.DiagnosticsPanel)
+	if !ok {
+		t.Fatalf("Expected D to open diagnostics, got %T", m.overlayStack.Current())
+	}
+	if _, interval := panel.AutoRefresh(); interval != time.Second {
+		t.Errorf("Expected an unset refresh interval to be raised to 1s, got %v", interval)
+	}
+
+	diag := &diagnostics.SystemDiagnostics{OverallState: diagnostics.HealthDegraded, Reasons: []string{"2 warnings"}}
+	updated, _ = m.Update(overlay.DiagnosticsRefreshMsg{Diagnostics: diag})
+	m = updated.(Model)
+
+	if view := m.overlayStack.Current().View(); !strings.Contains(view, "2 warnings") {
+		t.Errorf("Expected the refreshed diagnostics in the panel, got:\n%s", view)
+	}
+}
+
+func TestSessionDone_ArchiveTrigger(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.ArchiveOnDone = true
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+
+	done := monitor.SessionStateMsg{BeadID: "az-3", State: domain.SessionDone}
+
+	updated, cmd := m.Update(done)
+	if cmd == nil {
+		t.Fatal("Expected archive command on transition to done")
+	}
+
+	// Repeated done state is not a transition and must not archive again
+	_, cmd = updated.(Model).Update(done)
+	if cmd != nil {
+		t.Error("Expected no archive command when already done")
+	}
+}
+
+func TestSessionDone_ArchiveDisabled(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.ArchiveOnDone = false
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+
+	_, cmd := m.Update(monitor.SessionStateMsg{BeadID: "az-3", State: domain.SessionDone})
+	if cmd != nil {
+		t.Error("Expected no archive command when ArchiveOnDone is disabled")
+	}
+}
+
+// recordingTmuxRunner records tmux invocations for sequence assertions
+type recordingTmuxRunner struct {
+	calls    [][]string
+	capture  string
+	hasErr   error // returned by has-session
+	splitErr error // returned by split-window
+}
+
+func (r *recordingTmuxRunner) Run(ctx context.Context, args ...string) (string, error) {
+	r.calls = append(r.calls, args)
+	if len(args) > 0 && args[0] == "capture-pane" {
+		return r.capture, nil
+	}
+	if len(args) > 0 && args[0] == "has-session" {
+		return "", r.hasErr
+	}
+	if len(args) > 0 && args[0] == "split-window" {
+		return "%7\n", r.splitErr
+	}
+	return "", nil
+}
+
+// sentKeys returns the keys typed by each send-keys call in order
+func (r *recordingTmuxRunner) sentKeys() []string {
+	var keys []string
+	for _, call := range r.calls {
+		if typed, ok := typedKeys(call); ok {
+			keys = append(keys, typed)
+		}
+	}
+	return keys
+}
+
+// typedKeys returns the keys a send-keys call types, skipping the separate
+// Enter press that follows them
+func typedKeys(args []string) (string, bool) {
+	if len(args) < 4 || args[0] != "send-keys" {
+		return "", false
+	}
+	if args[3] == "-l" && len(args) > 4 {
+		return args[4], true
+	}
+	if len(args) == 4 && args[3] == "Enter" {
+		return "", false
+	}
+	return args[3], true
+}
+
+func TestRestartSessionCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		capture  string
+		wantKeys []string
+	}{
+		{
+			name:    "restart without error output",
+			capture: "",
+			wantKeys: []string{
+				"C-c",
+				"cd '/tmp/project-az-3'",
+				"claude",
+			},
+		},
+		{
+			name:    "restart with error context prompt",
+			capture: "working...\nError: it's broken\n",
+			wantKeys: []string{
+				"C-c",
+				"cd '/tmp/project-az-3'",
+				`claude 'The previous session failed with: working... | Error: it'\''s broken. Continue the task from the current state of the worktree.'`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			runner := &recordingTmuxRunner{capture: tt.capture}
+			m.tmuxClient = tmux.NewClient(runner, slog.Default())
+			m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionError, Worktree: "/tmp/project-az-3"}
+
+			msg := m.restartSessionCmd("az-3", "/tmp/project-az-3")()
+
+			if _, ok := msg.(sessionRestartedMsg); !ok {
+				t.Fatalf("Expected sessionRestartedMsg, got %T", msg)
+			}
+
+			// No new session or worktree is created
+			for _, call := range runner.calls {
+				if call[0] == "new-session" || call[0] == "kill-session" {
+					t.Errorf("Restart should reuse the existing session, got %v", call)
+				}
+			}
+
+			keys := runner.sentKeys()
+			if len(keys) != len(tt.wantKeys) {
+				t.Fatalf("Expected %d send-keys calls, got %d: %v", len(tt.wantKeys), len(keys), keys)
+			}
+			// The interrupt is pressed as a key, and not followed by Enter
+			interrupt := slices.IndexFunc(runner.calls, func(call []string) bool {
+				return slices.Equal(call, []string{"send-keys", "-t", "az-3", "C-c"})
+			})
+			if interrupt < 0 {
+				t.Fatalf("Expected C-c pressed, got %v", runner.calls)
+			}
+			if next := runner.calls[interrupt+1]; next[len(next)-1] == "Enter" {
+				t.Errorf("Expected no Enter after the interrupt, got %v", runner.calls)
+			}
+			for i := range keys {
+				if keys[i] != tt.wantKeys[i] {
+					t.Errorf("send-keys[%d] = %q, want %q", i, keys[i], tt.wantKeys[i])
+				}
+			}
+
+			updated, _ := m.Update(msg)
+			if state := updated.(Model).sessions["az-3"].State; state != domain.SessionBusy {
+				t.Errorf("Expected session to be busy after restart, got %v", state)
+			}
+		})
+	}
+}
+
+func TestSendText(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionWaiting}
+	m.overlayStack.Push(overlay.NewSendTextOverlay("az-3"))
+
+	updated, cmd := m.Update(overlay.SendTextMsg{BeadID: "az-3", Text: "yes"})
+	m = updated.(Model)
+	if !m.overlayStack.IsEmpty() {
+		t.Error("Expected the send overlay to close")
+	}
+	if cmd == nil {
+		t.Fatal("Expected a send command")
+	}
+
+	msg := cmd()
+	if keys := runner.sentKeys(); len(keys) != 1 || keys[0] != "yes" {
+		t.Errorf("Expected send-keys with %q, got %v", "yes", keys)
+	}
+	for _, call := range runner.calls {
+		if call[0] == "send-keys" && call[2] != "az-3" {
+			t.Errorf("Expected keys sent to az-3, got %v", call)
+		}
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].Level != ToastSuccess || !strings.Contains(m.toasts[0].Message, "az-3") {
+		t.Errorf("Expected a success toast for az-3, got %+v", m.toasts)
+	}
+}
+
+// staticPane implements monitor.TmuxClient with fixed pane output
+type staticPane string
+
+func (p staticPane) CapturePane(ctx context.Context, sessionName string) (string, error) {
+	return string(p), nil
+}
+
+func TestPersistSessionLogs_OnlyChangedOutput(t *testing.T) {
+	m := newTestModel()
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+	m.sessionMonitor = monitor.NewSessionMonitor(staticPane("building"))
+	m.sessionMonitor.Start(context.Background(), "az-3", nil)
+	defer m.sessionMonitor.StopAll()
+
+	// The monitor has seen the first capture; nothing new follows it
+	time.Sleep(600 * time.Millisecond)
+	if cmd := m.persistSessionLogsCmd(); cmd == nil {
+		t.Fatal("Expected the first output logged")
+	}
+	time.Sleep(600 * time.Millisecond)
+	if cmd := m.persistSessionLogsCmd(); cmd != nil {
+		t.Error("Expected no capture while the output is unchanged")
+	}
+}
+
+// scrollbackRunner is a tmux runner whose pane holds lines of scrollback,
+// answering capture-pane -S -<n> with the last n of them
+type scrollbackRunner struct {
+	lines []string
+}
+
+func (r *scrollbackRunner) Run(ctx context.Context, args ...string) (string, error) {
+	if len(args) == 0 || args[0] != "capture-pane" {
+		return "", nil
+	}
+	n, _ := strconv.Atoi(strings.TrimPrefix(args[len(args)-1], "-"))
+	return strings.Join(r.lines[max(len(r.lines)-n, 0):], "\n") + "\n", nil
+}
+
+func TestArchiveSession_WritesEachLineOnce(t *testing.T) {
+	m := newTestModel()
+	m.sessionLog = sessionlog.NewService(t.TempDir(), 0, slog.Default())
+	m.beadsClient = beads.NewClient(&recordingBeadsRunner{}, slog.Default())
+	runner := &scrollbackRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+
+	// Periodic flushes only capture the last sessionLogCaptureLines
+	for i := range sessionLogCaptureLines * 2 {
+		runner.lines = append(runner.lines, fmt.Sprintf("line %d", i))
+	}
+	m.persistSessionLog(context.Background(), "az-3")
+	runner.lines = append(runner.lines, "done")
+
+	msg := m.archiveSessionCmd("az-3")().(sessionArchivedMsg)
+	if msg.err != nil {
+		t.Fatalf("Unexpected error: %v", msg.err)
+	}
+
+	data, err := os.ReadFile(m.sessionLog.Path("az-3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if seen[line] {
+			t.Fatalf("Expected each line logged once, %q was written twice", line)
+		}
+		seen[line] = true
+	}
+	if !seen["done"] || !seen[fmt.Sprintf("line %d", sessionLogCaptureLines*2-1)] {
+		t.Errorf("Expected the final output logged, got %d lines", len(seen))
+	}
+}
+
+func TestAutoPauseIdleSessions(t *testing.T) {
+	tests := []struct {
+		name        string
+		minutes     int
+		idleFor     time.Duration
+		state       domain.SessionState
+		wantTrigger bool
+	}{
+		{name: "disabled", minutes: 0, idleFor: 24 * time.Hour, state: domain.SessionDone, wantTrigger: false},
+		{name: "done below threshold", minutes: 30, idleFor: 10 * time.Minute, state: domain.SessionDone, wantTrigger: false},
+		{name: "done beyond threshold", minutes: 30, idleFor: 31 * time.Minute, state: domain.SessionDone, wantTrigger: true},
+		{name: "already paused", minutes: 30, idleFor: 31 * time.Minute, state: domain.SessionPaused, wantTrigger: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Session.AutoPauseIdleMinutes = tt.minutes
+			m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: tt.state, Worktree: "/tmp/project-az-3"}
+
+			// Let the monitor observe the session finishing
+			m.sessionMonitor = monitor.NewSessionMonitor(staticPane("Task completed successfully"))
+			m.sessionMonitor.Start(context.Background(), "az-3", nil)
+			defer m.sessionMonitor.StopAll()
+			time.Sleep(600 * time.Millisecond)
+
+			cmd := m.autoPauseIdleSessionsCmd(time.Now().Add(tt.idleFor))
+			if (cmd != nil) != tt.wantTrigger {
+				t.Errorf("autoPauseIdleSessionsCmd() triggered = %v, want %v", cmd != nil, tt.wantTrigger)
+			}
+		})
+	}
+}
+
+func TestCheckAttach_DeadSessionOffersRestart(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingTmuxRunner{hasErr: errors.New("can't find session: az-3")}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy, Worktree: "/tmp/project-az-3"}
+
+	msg := m.checkAttachCmd("/tmp/project-az-3", "az-3")()
+	gone, ok := msg.(sessionGoneMsg)
+	if !ok || gone.err != nil {
+		t.Fatalf("Expected sessionGoneMsg without error, got %#v", msg)
+	}
+
+	updated, _ := m.Update(msg)
+	um := updated.(Model)
+	if _, prompted := um.overlayStack.Current().(*overlay.ConfirmDialog); !prompted {
+		t.Fatal("Expected a restart confirmation")
+	}
+	if um.pendingRestartBeadID != "az-3" {
+		t.Errorf("Expected pending restart for az-3, got %q", um.pendingRestartBeadID)
+	}
+	for _, toast := range um.toasts {
+		if strings.Contains(toast.Message, "attach-session") {
+			t.Errorf("Expected no attach command for a dead session, got %q", toast.Message)
+		}
+	}
+
+	// Confirming restarts in the existing worktree instead of creating one
+	updated, cmd := um.Update(overlay.SelectionMsg{Key: "yes", Value: overlay.ConfirmResult{Confirmed: true}})
+	um = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected a restart command")
+	}
+	runner.calls = nil
+	msg = cmd()
+	if _, ok := msg.(sessionResumedMsg); !ok {
+		t.Fatalf("Expected sessionResumedMsg, got %#v", msg)
+	}
+	if len(runner.calls) == 0 || runner.calls[0][0] != "new-session" || !slices.Contains(runner.calls[0], "/tmp/project-az-3") {
+		t.Errorf("Expected a new tmux session in the existing worktree, got %v", runner.calls)
+	}
+
+	updated, _ = um.Update(msg)
+	um = updated.(Model)
+	if state := um.sessions["az-3"].State; state != domain.SessionBusy {
+		t.Errorf("Expected the restarted session busy, got %v", state)
+	}
+}
+
+func TestPauseAndResumeSession(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.AutoPauseIdleMinutes = 30
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionDone, Worktree: "/tmp/project-az-3"}
+
+	msg := m.pauseSessionCmd("az-3", true)()
+	paused, ok := msg.(sessionPausedMsg)
+	if !ok || paused.err != nil {
+		t.Fatalf("Expected successful sessionPausedMsg, got %#v", msg)
+	}
+
+	killed := false
+	for _, call := range runner.calls {
+		if call[0] == "kill-session" {
+			killed = true
+		}
+	}
+	if !killed {
+		t.Error("Expected pause to kill the tmux session")
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	session := m.sessions["az-3"]
+	if session == nil || session.State != domain.SessionPaused {
+		t.Fatalf("Expected session to be kept and paused, got %+v", session)
+	}
+	if session.Worktree != "/tmp/project-az-3" {
+		t.Errorf("Expected worktree to be kept, got %q", session.Worktree)
+	}
+
+	runner.calls = nil
+	msg = m.resumeSessionCmd("az-3", session.Worktree)()
+	if _, ok := msg.(sessionResumedMsg); !ok {
+		t.Fatalf("Expected sessionResumedMsg, got %T", msg)
+	}
+	if len(runner.calls) == 0 || runner.calls[0][0] != "new-session" {
+		t.Errorf("Expected resume to create a new tmux session, got %v", runner.calls)
+	}
+
+	updated, _ = m.Update(msg)
+	if state := updated.(Model).sessions["az-3"].State; state != domain.SessionBusy {
+		t.Errorf("Expected session to be busy after resume, got %v", state)
+	}
+}
+
+func TestMarkMovedTasks(t *testing.T) {
+	m := newTestModel()
+	previous := m.tasks
+
+	current := make([]domain.Task, len(previous))
+	copy(current, previous)
+	current[0].Status = domain.StatusInProgress // az-1 moved
+	current = append(current, domain.Task{ID: "az-6", Title: "New", Status: domain.StatusOpen})
+
+	m.markMovedTasks(previous, current)
+
+	highlighted := m.highlightedTasks()
+	if len(highlighted) != 1 || !highlighted["az-1"] {
+		t.Errorf("Expected only az-1 to be highlighted, got %v", highlighted)
+	}
+}
+
+func TestMarkMovedTasks_OnRefresh(t *testing.T) {
+	m := newTestModel()
+
+	refreshed := make([]domain.Task, len(m.tasks))
+	copy(refreshed, m.tasks)
+	refreshed[1].Status = domain.StatusBlocked // az-2 moved
+
+	updated, _ := m.Update(beadsLoadedMsg{tasks: refreshed})
+	if !updated.(Model).highlightedTasks()["az-2"] {
+		t.Error("Expected az-2 to be highlighted after a refresh moved it")
+	}
+}
+
+func TestExpireMovedTasks(t *testing.T) {
+	m := newTestModel()
+	m.movedTasks["az-1"] = time.Now().Add(-time.Second)
+	m.movedTasks["az-2"] = time.Now().Add(movedHighlightDuration)
+
+	// Expired highlights are hidden before the next tick removes them
+	if m.highlightedTasks()["az-1"] {
+		t.Error("Expired highlight should not be rendered")
+	}
+
+	m.expireMovedTasks()
+
+	if _, ok := m.movedTasks["az-1"]; ok {
+		t.Error("Expected expired highlight to be removed")
+	}
+	if _, ok := m.movedTasks["az-2"]; !ok {
+		t.Error("Expected active highlight to be kept")
+	}
+}
+
+func TestOfflineBlocksPRAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		online   bool
+		wantCmd  bool
+		wantWarn bool
+	}{
+		{name: "online opens PR overlay", online: true, wantCmd: true},
+		{name: "offline short-circuits", online: false, wantCmd: false, wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			session := &domain.Session{BeadID: "az-3", State: domain.SessionBusy, Worktree: "/tmp/project-az-3"}
+			m.tasks[2].Session = session
+			m.sessions["az-3"] = session
+			m.nav.SelectTask("az-3", 1)
+			m.isOnline = tt.online
+
+			updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: "P"})
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("Expected command = %v, got %v", tt.wantCmd, cmd != nil)
+			}
+
+			warned := false
+			for _, toast := range updated.(Model).toasts {
+				if toast.Level == ToastWarning && strings.Contains(toast.Message, "offline") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarn {
+				t.Errorf("Expected offline warning = %v, got toasts %v", tt.wantWarn, updated.(Model).toasts)
+			}
+		})
+	}
+}
+
+func TestOfflineBlocksPRSubmit(t *testing.T) {
+	m := newTestModel()
+	m.isOnline = false
+
+	_, cmd := m.Update(overlay.PRCreatedMsg{})
+	if cmd != nil {
+		t.Error("Expected PR submission to be blocked while offline")
+	}
+}
+
+func TestRequireOnline(t *testing.T) {
+	m := newTestModel()
+
+	if !m.requireOnline(diagnostics.FeaturePRCreation) {
+		t.Error("Expected online model to allow network features")
+	}
+
+	m.isOnline = false
+	if m.requireOnline(diagnostics.FeatureGitPushPull) {
+		t.Error("Expected offline model to block git push/pull")
+	}
+	if !m.requireOnline("Local diff") {
+		t.Error("Expected features without network requirements to be allowed offline")
+	}
+	if len(m.toasts) != 1 {
+		t.Errorf("Expected one offline toast, got %d", len(m.toasts))
+	}
+}
+
+func TestStartSession_ConfirmOnDone(t *testing.T) {
+	tests := []struct {
+		name        string
+		beadID      string
+		column      int
+		skipConfirm bool
+		wantPrompt  bool
+	}{
+		{name: "open task starts directly", beadID: "az-1", column: 0, wantPrompt: false},
+		{name: "in progress task starts directly", beadID: "az-3", column: 1, wantPrompt: false},
+		{name: "blocked task starts directly", beadID: "az-4", column: 2, wantPrompt: false},
+		{name: "done task asks first", beadID: "az-5", column: 3, wantPrompt: true},
+		{name: "done task with confirmation skipped", beadID: "az-5", column: 3, skipConfirm: true, wantPrompt: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Session.SkipDoneConfirm = tt.skipConfirm
+			m.nav.SelectTask(tt.beadID, tt.column)
+
+			updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: "s"})
+			um := updated.(Model)
+			if !tt.wantPrompt && cmd == nil {
+				t.Error("Expected start session command")
+			}
+
+			_, prompted := um.overlayStack.Current().(*overlay.ConfirmDialog)
+			if prompted != tt.wantPrompt {
+				t.Errorf("Expected prompt = %v, got %v", tt.wantPrompt, prompted)
+			}
+			if tt.wantPrompt && um.pendingStartBeadID != tt.beadID {
+				t.Errorf("Expected pending start for %s, got %q", tt.beadID, um.pendingStartBeadID)
+			}
+		})
+	}
+}
+
+func TestStartSession_ConfirmResult(t *testing.T) {
+	for _, confirmed := range []bool{true, false} {
+		m := newTestModel()
+		m.nav.SelectTask("az-5", 3)
+
+		updated, _ := m.handleSelection(overlay.SelectionMsg{Key: "s"})
+		m = updated.(Model)
+
+		key := map[bool]string{true: "yes", false: "no"}[confirmed]
+		updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: key, Value: overlay.ConfirmResult{Confirmed: confirmed}})
+		m = updated.(Model)
+
+		if (cmd != nil) != confirmed {
+			t.Errorf("confirmed=%v: expected start command = %v, got %v", confirmed, confirmed, cmd != nil)
+		}
+		if m.pendingStartBeadID != "" {
+			t.Errorf("confirmed=%v: expected pending start to be cleared, got %q", confirmed, m.pendingStartBeadID)
+		}
+		if !m.overlayStack.IsEmpty() {
+			t.Errorf("confirmed=%v: expected dialog to be closed", confirmed)
+		}
+	}
+}
+
+func TestBeadsRetryDelay(t *testing.T) {
+	maxDelay := 60 * time.Second
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 2 * time.Second},
+		{failures: 1, want: 5 * time.Second},
+		{failures: 2, want: 10 * time.Second},
+		{failures: 3, want: 20 * time.Second},
+		{failures: 4, want: 40 * time.Second},
+		{failures: 5, want: 60 * time.Second},
+		{failures: 100, want: 60 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := beadsRetryDelay(tt.failures, maxDelay); got != tt.want {
+			t.Errorf("beadsRetryDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestBeadsRefreshBackoff(t *testing.T) {
+	m := newTestModel()
+	m.config.Network.OfflineTimeout = 30
+
+	// Each consecutive failure doubles the delay up to the offline timeout
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, delay := range want {
+		updated, _ := m.Update(beadsErrorMsg{err: errors.New("bd failed")})
+		m = updated.(Model)
+		if got := m.refreshDelay(); got != delay {
+			t.Errorf("After %d failures refreshDelay() = %v, want %v", i+1, got, delay)
+		}
+	}
+
+	// A successful load resets to the normal cadence
+	updated, _ := m.Update(beadsLoadedMsg{tasks: m.tasks})
+	m = updated.(Model)
+	if m.beadsFailures != 0 {
+		t.Errorf("Expected failures to reset on success, got %d", m.beadsFailures)
+	}
+	if got := m.refreshDelay(); got != 2*time.Second {
+		t.Errorf("refreshDelay() after success = %v, want 2s", got)
+	}
+}
+
+func TestBeadsRefreshReconnect(t *testing.T) {
+	m := newTestModel()
+	m.isOnline = false
+	m.beadsFailures = 3
+
+	updated, cmd := m.Update(network.StatusMsg{Online: true})
+	m = updated.(Model)
+
+	if !m.isOnline {
+		t.Error("Expected model to be online")
+	}
+	if m.beadsFailures != 0 {
+		t.Errorf("Expected failures to reset on reconnect, got %d", m.beadsFailures)
+	}
+	if cmd == nil {
+		t.Error("Expected an immediate beads reload on reconnect")
+	}
+
+	// Staying online doesn't trigger extra reloads
+	_, cmd = m.Update(network.StatusMsg{Online: true})
+	if cmd != nil {
+		t.Error("Expected no reload when already online")
+	}
+}
+
+func TestComputeEpicProgress(t *testing.T) {
+	m := newTestModel()
+	epicID := "az-10"
+	emptyEpicID := "az-11"
+	m.tasks = append(m.tasks,
+		domain.Task{ID: epicID, Title: "Epic", Status: domain.StatusInProgress, Type: domain.TypeEpic},
+		domain.Task{ID: emptyEpicID, Title: "Empty epic", Status: domain.StatusOpen, Type: domain.TypeEpic},
+	)
+	m.tasks[0].ParentID = &epicID // az-1, open
+	m.tasks[4].ParentID = &epicID // az-5, done
+
+	progress := m.computeEpicProgress()
+
+	if got := progress[epicID]; got.Done != 1 || got.Total != 2 {
+		t.Errorf("progress[%s] = %+v, want 1/2", epicID, got)
+	}
+	if got, ok := progress[emptyEpicID]; !ok || got.Total != 0 {
+		t.Errorf("progress[%s] = %+v, want an empty entry", emptyEpicID, got)
+	}
+	if _, ok := progress["az-1"]; ok {
+		t.Error("Non-epic tasks should not have progress")
+	}
+}
+
+// recordingBeadsRunner records bd invocations
+type recordingBeadsRunner struct {
+	calls [][]string
+}
+
+func (r *recordingBeadsRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, append([]string{name}, args...))
+	return nil, nil
+}
+
+func TestSelectMode_Toggle(t *testing.T) {
+	m := newTestModel()
+	m.editor.EnterSelect()
+	m.nav.SelectTask("az-1", 0)
+
+	// Space toggles the task under the cursor without moving
+	updated, _ := m.handleSelectMode(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m = updated.(Model)
+	if !m.editor.IsSelected("az-1") {
+		t.Fatal("Expected az-1 to be selected after space")
+	}
+
+	updated, _ = m.handleSelectMode(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m = updated.(Model)
+	if m.editor.IsSelected("az-1") {
+		t.Fatal("Expected az-1 to be deselected after second space")
+	}
+
+	// j extends the selection to the current task and moves down
+	updated, _ = m.handleSelectMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	if !m.editor.IsSelected("az-1") {
+		t.Error("Expected j to select the task it leaves")
+	}
+	if task, _ := m.getCurrentTaskAndSession(); task == nil || task.ID != "az-2" {
+		t.Errorf("Expected cursor on az-2 after j, got %v", task)
+	}
+}
+
+func TestSelectMode_SelectColumn(t *testing.T) {
+	m := newTestModel()
+	m.editor.EnterSelect()
+	m.nav.SelectTask("az-1", 0)
+
+	updated, _ := m.handleSelectMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+
+	got := m.editor.GetSelectedTasks()
+	if len(got) != 2 || !got["az-1"] || !got["az-2"] {
+		t.Errorf("Expected the Open column (az-1, az-2) selected, got %v", got)
+	}
+}
+
+func TestBulkSetStatus_UpdatesEachTask(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	m.editor.EnterSelect()
+	m.editor.Select("az-1")
+	m.editor.Select("az-2")
+
+	// The bulk menu's message is routed through Update
+	updated, cmd := m.Update(overlay.BulkActionMsg{Action: "D", SelectedIDs: []string{"az-1", "az-2"}})
+	if cmd == nil {
+		t.Fatal("Expected a bulk status command")
+	}
+
+	result, ok := cmd().(bulkStatusResultMsg)
+	if !ok {
+		t.Fatalf("Expected bulkStatusResultMsg, got %T", cmd())
+	}
+	if result.updated != 2 || result.failed != 0 {
+		t.Errorf("Expected 2 updated, 0 failed, got %+v", result)
+	}
+
+	want := [][]string{
+		{"bd", "update", "az-1", "--status=closed"},
+		{"bd", "update", "az-2", "--status=closed"},
+	}
+	if len(runner.calls) != len(want) {
+		t.Fatalf("Expected %d bd calls, got %v", len(want), runner.calls)
+	}
+	for i := range want {
+		if strings.Join(runner.calls[i], " ") != strings.Join(want[i], " ") {
+			t.Errorf("Call %d = %v, want %v", i, runner.calls[i], want[i])
+		}
+	}
+
+	// The result clears the selection and leaves select mode
+	m = updated.(Model)
+	updated, _ = m.Update(result)
+	m = updated.(Model)
+	if m.editor.HasSelection() || m.editor.IsSelect() {
+		t.Error("Expected selection cleared and normal mode after bulk action")
+	}
+}
+
+func TestBulkStartSessions(t *testing.T) {
+	m := newTestModel()
+	m.editor.EnterSelect()
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+
+	// az-1 starts; az-3 already has a session and az-5 is done
+	updated, cmd := m.handleBulkAction(overlay.BulkActionMsg{Action: "s", SelectedIDs: []string{"az-1", "az-3", "az-5"}})
+	m = updated.(Model)
+
+	if cmd == nil {
+		t.Fatal("Expected a start command for az-1")
+	}
+	if len(m.toasts) == 0 || m.toasts[len(m.toasts)-1].Message != "Starting 1 sessions (2 skipped)" {
+		t.Errorf("Unexpected toasts: %v", m.toasts)
+	}
+	if m.editor.IsSelect() {
+		t.Error("Expected normal mode after bulk start")
+	}
+}
+
+func TestReopenDoneTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus string
+		wantStart  bool
+	}{
+		{name: "reopen to open", key: "o", wantStatus: "--status=open", wantStart: false},
+		{name: "reopen and start", key: "O", wantStatus: "--status=in_progress", wantStart: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			runner := &recordingBeadsRunner{}
+			m.beadsClient = beads.NewClient(runner, slog.Default())
+			m.nav.SelectTask("az-5", 3)
+			m.overlayStack.Push(overlay.NewActionMenu(m.tasks[4], nil))
+
+			updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: tt.key})
+			m = updated.(Model)
+			if cmd == nil {
+				t.Fatal("Expected a reopen command")
+			}
+
+			result, ok := cmd().(taskReopenedMsg)
+			if !ok {
+				t.Fatalf("Expected taskReopenedMsg, got %T", cmd())
+			}
+			want := []string{"bd", "update", "az-5", tt.wantStatus}
+			if len(runner.calls) != 1 || strings.Join(runner.calls[0], " ") != strings.Join(want, " ") {
+				t.Fatalf("bd calls = %v, want [%v]", runner.calls, want)
+			}
+			if result.startSession != tt.wantStart {
+				t.Errorf("startSession = %v, want %v", result.startSession, tt.wantStart)
+			}
+
+			// Reopening with a start batches the reload with a session start
+			_, cmd = m.Update(result)
+			if cmd == nil {
+				t.Fatal("Expected a follow-up command")
+			}
+			_, batched := cmd().(tea.BatchMsg)
+			if batched != tt.wantStart {
+				t.Errorf("session start batched = %v, want %v", batched, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestSessionStarted_FollowNewSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		follow bool
+		want   string
+	}{
+		{name: "follows the new session", follow: true, want: "az-3"},
+		{name: "cursor stays put by default", follow: false, want: "az-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.UI.FollowNewSession = tt.follow
+			m.nav.SelectTask("az-1", 0)
+
+			updated, _ := m.Update(sessionStartedMsg{beadID: "az-3", worktreePath: "/tmp/az-3"})
+			m = updated.(Model)
+
+			if got := m.nav.GetCursor().TaskID; got != tt.want {
+				t.Errorf("cursor on %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionStarted_RecordsAndMonitorsSession(t *testing.T) {
+	m := newTestModel()
+	m.sessionMonitor = monitor.NewSessionMonitor(staticPane("Task completed successfully"))
+	defer m.sessionMonitor.StopAll()
+
+	updated, _ := m.Update(sessionStartedMsg{beadID: "az-3", worktreePath: "/tmp/az-3"})
+	m = updated.(Model)
+
+	session := m.sessions["az-3"]
+	if session == nil || session.State != domain.SessionBusy || session.Worktree != "/tmp/az-3" {
+		t.Fatalf("Expected a busy session in /tmp/az-3, got %+v", session)
+	}
+	time.Sleep(600 * time.Millisecond)
+	if state := m.sessionMonitor.GetState("az-3"); state != domain.SessionDone {
+		t.Errorf("Expected the monitor to watch az-3, got state %q", state)
+	}
+}
+
+func TestSessionStarted_FollowAcrossColumns(t *testing.T) {
+	m := newTestModel()
+	m.config.UI.FollowNewSession = true
+	m.nav.SelectTask("az-2", 0)
+
+	updated, _ := m.Update(sessionStartedMsg{beadID: "az-1", worktreePath: "/tmp/az-1"})
+	m = updated.(Model)
+
+	// The started task moves to In Progress on the next refresh
+	tasks := append([]domain.Task(nil), m.tasks...)
+	tasks[0].Status = domain.StatusInProgress
+	updated, _ = m.Update(beadsLoadedMsg{tasks: tasks})
+	m = updated.(Model)
+
+	pos := m.nav.GetPosition(m.buildColumns())
+	if m.nav.GetCursor().TaskID != "az-1" || pos.Column != 1 {
+		t.Errorf("cursor = %s in column %d, want az-1 in column 1", m.nav.GetCursor().TaskID, pos.Column)
+	}
+}
+
+func TestSessionLimiter(t *testing.T) {
+	l := newSessionLimiter(2)
+
+	if !l.TryAcquire("az-1") || !l.TryAcquire("az-2") {
+		t.Fatal("Expected the first two sessions to get slots")
+	}
+	if l.TryAcquire("az-3") {
+		t.Error("Expected az-3 to be refused while both slots are held")
+	}
+	if !l.TryAcquire("az-1") {
+		t.Error("Expected a session to keep the slot it already holds")
+	}
+
+	if !l.Release("az-1") {
+		t.Error("Expected az-1 to release its slot")
+	}
+	if l.Release("az-1") {
+		t.Error("Expected a second release to be a no-op")
+	}
+	if !l.TryAcquire("az-3") {
+		t.Error("Expected az-3 to take the freed slot")
+	}
+	if l.Running() != 2 {
+		t.Errorf("Running() = %d, want 2", l.Running())
+	}
+
+	unlimited := newSessionLimiter(0)
+	for i := 0; i < 10; i++ {
+		if !unlimited.TryAcquire(fmt.Sprintf("az-%d", i)) {
+			t.Fatalf("Expected a limit of 0 to be unlimited, refused at %d", i)
+		}
+	}
+}
+
+func TestStartSession_QueuesOverLimit(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.MaxConcurrent = 2
+	m.sessionLimiter = newSessionLimiter(2)
+
+	var started int
+	for _, id := range []string{"az-1", "az-2", "az-3"} {
+		if cmd := m.requestSessionStart(id); cmd != nil {
+			started++
+		}
+	}
+
+	if started != 2 {
+		t.Errorf("Expected 2 start commands, got %d", started)
+	}
+	if _, ok := m.sessions["az-3"]; ok {
+		t.Error("Expected queued az-3 to have no session record")
+	}
+	var queued *domain.Session
+	for _, task := range m.tasksWithSessions() {
+		if task.ID == "az-3" {
+			queued = task.Session
+		}
+	}
+	if queued == nil || queued.State != domain.SessionQueued {
+		t.Fatalf("Expected az-3 to show as queued, got %+v", queued)
+	}
+	if len(m.toasts) == 0 || m.toasts[len(m.toasts)-1].Message != "Session queued: az-3 (2 running)" {
+		t.Errorf("Unexpected toasts: %v", m.toasts)
+	}
+
+	// az-1 waiting for input frees its slot for az-3
+	m.sessions["az-1"] = &domain.Session{BeadID: "az-1", State: domain.SessionBusy}
+	updated, cmd := m.Update(monitor.SessionStateMsg{BeadID: "az-1", State: domain.SessionWaiting})
+	m = updated.(Model)
+
+	if cmd == nil {
+		t.Fatal("Expected the queued session to start")
+	}
+	if len(m.startQueue) != 0 {
+		t.Errorf("Expected an empty start queue, got %v", m.startQueue)
+	}
+	if m.sessionLimiter.Running() != 2 {
+		t.Errorf("Running() = %d, want 2", m.sessionLimiter.Running())
+	}
+}
+
+func TestStartSession_RepeatedStartOfQueuedBead(t *testing.T) {
+	m := newTestModel()
+	m.sessionLimiter = newSessionLimiter(1)
+
+	if cmd := m.requestSessionStart("az-1"); cmd == nil {
+		t.Fatal("Expected az-1 to start")
+	}
+	m.requestSessionStart("az-2")
+
+	// Starting either again, from the menu or a bulk start, changes nothing
+	if cmd := m.requestSessionStart("az-1"); cmd != nil {
+		t.Error("Expected no second start of az-1 while it is starting")
+	}
+	if cmd := m.requestSessionStart("az-2"); cmd != nil {
+		t.Error("Expected no start of queued az-2")
+	}
+	updated, _ := m.handleBulkAction(overlay.BulkActionMsg{Action: "s", SelectedIDs: []string{"az-2"}})
+	m = updated.(Model)
+	if len(m.startQueue) != 1 || m.startQueue[0] != "az-2" {
+		t.Fatalf("Expected az-2 queued once, got %v", m.startQueue)
+	}
+
+	// Freeing the slot starts az-2 once
+	m.sessions["az-1"] = &domain.Session{BeadID: "az-1", State: domain.SessionBusy}
+	updated, cmd := m.Update(monitor.SessionStateMsg{BeadID: "az-1", State: domain.SessionWaiting})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected the queued session to start")
+	}
+	if len(m.startQueue) != 0 {
+		t.Errorf("Expected an empty start queue, got %v", m.startQueue)
+	}
+	if cmd := m.requestSessionStart("az-2"); cmd != nil {
+		t.Error("Expected no second start of az-2 while it is starting")
+	}
+}
+
+func TestSessionStalled_WarnsOnce(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.StuckTimeoutMs = 600000
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+
+	stalled := monitor.SessionStateMsg{BeadID: "az-3", State: domain.SessionBusy, Stalled: true}
+	updated, _ := m.Update(stalled)
+	m = updated.(Model)
+	updated, _ = m.Update(stalled)
+	m = updated.(Model)
+
+	if !m.sessions["az-3"].Stalled {
+		t.Error("Expected az-3 to be flagged stalled")
+	}
+	if len(m.toasts) != 1 || m.toasts[0].Level != ToastWarning || !strings.Contains(m.toasts[0].Message, "10m0s") {
+		t.Errorf("Expected one stalled warning, got %+v", m.toasts)
+	}
+
+	// New output clears the flag
+	updated, _ = m.Update(monitor.SessionStateMsg{BeadID: "az-3", State: domain.SessionBusy})
+	m = updated.(Model)
+	if m.sessions["az-3"].Stalled {
+		t.Error("Expected new output to clear the stall")
+	}
+}
+
+func TestBulkStartSessions_QueuesOverLimit(t *testing.T) {
+	m := newTestModel()
+	m.sessionLimiter = newSessionLimiter(1)
+
+	updated, _ := m.handleBulkAction(overlay.BulkActionMsg{Action: "s", SelectedIDs: []string{"az-1", "az-2"}})
+	m = updated.(Model)
+
+	if len(m.toasts) == 0 || m.toasts[len(m.toasts)-1].Message != "Starting 1 sessions, 1 queued" {
+		t.Errorf("Unexpected toasts: %v", m.toasts)
+	}
+	if len(m.startQueue) != 1 || m.startQueue[0] != "az-2" {
+		t.Errorf("Expected az-2 queued, got %v", m.startQueue)
+	}
+}
+
+func TestStopQueuedSession(t *testing.T) {
+	m := newTestModel()
+	m.sessionLimiter = newSessionLimiter(1)
+	m.requestSessionStart("az-1")
+	m.requestSessionStart("az-2")
+	m.nav.SelectTask("az-2", 0)
+	m.overlayStack.Push(overlay.NewActionMenu(m.tasks[1], m.sessions["az-2"]))
+
+	updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: "x"})
+	m = updated.(Model)
+
+	if cmd != nil {
+		t.Error("Expected no stop command for a queued session")
+	}
+	if _, ok := m.sessions["az-2"]; ok {
+		t.Error("Expected the queued session to be removed")
+	}
+	if len(m.startQueue) != 0 {
+		t.Errorf("Expected an empty start queue, got %v", m.startQueue)
+	}
+}
+
+func TestFlagIdleSessions(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	m := newTestModel()
+	m.config.Session.IdleTimeoutMinutes = 60
+	m.sessions["az-1"] = &domain.Session{BeadID: "az-1", State: domain.SessionIdle, StateChangedAt: ago(90 * time.Minute)}
+	m.sessions["az-2"] = &domain.Session{BeadID: "az-2", State: domain.SessionDone, StateChangedAt: ago(10 * time.Minute)}
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionWaiting, StateChangedAt: ago(3 * time.Hour)}
+
+	m.flagIdleSessions(now)
+
+	if len(m.toasts) != 1 || m.toasts[0].Message != "Session az-1 idle for 90m; pause or clean it up to free its worktree" {
+		t.Fatalf("Unexpected toasts: %v", m.toasts)
+	}
+
+	// Already flagged sessions aren't flagged again
+	m.flagIdleSessions(now.Add(time.Minute))
+	if len(m.toasts) != 1 {
+		t.Errorf("Expected no repeat toast, got %v", m.toasts)
+	}
+
+	// Working in between resets the flag
+	m.sessions["az-1"].SetState(domain.SessionBusy, now)
+	m.flagIdleSessions(now)
+	m.sessions["az-1"].SetState(domain.SessionIdle, now)
+	m.flagIdleSessions(now.Add(61 * time.Minute))
+	if len(m.toasts) < 2 || m.toasts[1].Message != "Session az-1 idle for 61m; pause or clean it up to free its worktree" {
+		t.Errorf("Expected az-1 to be flagged again, got %v", m.toasts)
+	}
+}
+
+func TestFlagIdleSessions_Disabled(t *testing.T) {
+	m := newTestModel()
+	started := time.Now().Add(-24 * time.Hour)
+	m.sessions["az-1"] = &domain.Session{BeadID: "az-1", State: domain.SessionIdle, StartedAt: &started}
+
+	m.flagIdleSessions(time.Now())
+
+	if len(m.toasts) != 0 {
+		t.Errorf("Expected no toasts with idleTimeoutMinutes unset, got %v", m.toasts)
+	}
+}
+
+func TestCLITool_PerProject(t *testing.T) {
+	m := newTestModel()
+	m.config.CLITool = "opencode"
+	m.projectRegistry = &config.ProjectsRegistry{Projects: []config.Project{
+		{Name: "api", Path: t.TempDir(), CLITool: "aider"},
+		{Name: "web", Path: t.TempDir()},
+	}}
+
+	tests := []struct {
+		project string
+		want    string
+	}{
+		{"api", "aider"},
+		{"web", "opencode"},
+		{"missing", "opencode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			m.currentProject = tt.project
+			if got := m.cliTool(); got != tt.want {
+				t.Errorf("cliTool() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The resolved tool is what a resumed session launches
+	m.currentProject = "api"
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.resumeSessionCmd("az-3", "/tmp/project-az-3")()
+	if keys := runner.sentKeys(); len(keys) != 1 || keys[0] != "aider" {
+		t.Errorf("Expected the session to launch aider, got %v", keys)
+	}
+}
+
+func TestBrowserCommand(t *testing.T) {
+	const url = "https://github.com/org/repo/pull/42"
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"darwin", "open", []string{url}},
+		{"linux", "xdg-open", []string{url}},
+		{"freebsd", "xdg-open", []string{url}},
+		{"windows", "rundll32", []string{"url.dll,FileProtocolHandler", url}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := browserCommand(tt.goos, url)
+			if name != tt.wantName {
+				t.Errorf("browserCommand(%q) name = %q, want %q", tt.goos, name, tt.wantName)
+			}
+			if strings.Join(args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("browserCommand(%q) args = %v, want %v", tt.goos, args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOpenURLCmd_MissingOpener(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	msg, ok := openURLCmd("https://github.com/org/repo/pull/42")().(browserOpenedMsg)
+	if !ok || msg.err == nil {
+		t.Fatalf("Expected a browserOpenedMsg error without an opener, got %#v", msg)
+	}
+
+	m := newTestModel()
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].Level != ToastWarning ||
+		!strings.Contains(m.toasts[0].Message, "pull/42") {
+		t.Errorf("Expected a warning toast with the PR URL, got %v", m.toasts)
+	}
+}
+
+func TestPRCreated_OpenInBrowser(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.Update(prCreatedResultMsg{url: "https://github.com/org/repo/pull/42"})
+	if cmd != nil {
+		t.Error("Expected no browser command with openInBrowser off")
+	}
+
+	m.config.PR.OpenInBrowser = true
+	_, cmd = m.Update(prCreatedResultMsg{url: "https://github.com/org/repo/pull/42"})
+	if cmd == nil {
+		t.Error("Expected a browser command with openInBrowser on")
+	}
+
+	_, cmd = m.Update(prCreatedResultMsg{err: errors.New("gh failed")})
+	if cmd != nil {
+		t.Error("Expected no browser command when PR creation fails")
+	}
+}
+
+// recordingGitRunner records git commands, answering status with a fixed
+// porcelain output
+type recordingGitRunner struct {
+	calls  []string
+	status string
+}
+
+func (r *recordingGitRunner) Run(ctx context.Context, args ...string) (string, error) {
+	call := strings.Join(args, " ")
+	r.calls = append(r.calls, call)
+	if strings.HasSuffix(call, "status --porcelain") {
+		return r.status, nil
+	}
+	return "", nil
+}
+
+func TestFetchAndMerge_AutoStash(t *testing.T) {
+	tests := []struct {
+		name      string
+		autoStash bool
+		status    string
+		want      []string
+	}{
+		{
+			name:      "dirty tree is stashed around the merge",
+			autoStash: true,
+			status:    " M main.go",
+			want: []string{
+				"-C /tmp/project-az-3 status --porcelain",
+				"-C /tmp/project-az-3 stash push --include-untracked -m azedarach auto-stash",
+				"fetch origin",
+				"merge origin/main",
+				"-C /tmp/project-az-3 stash pop",
+			},
+		},
+		{
+			name:      "clean tree is not stashed",
+			autoStash: true,
+			status:    "",
+			want:      []string{"-C /tmp/project-az-3 status --porcelain", "fetch origin", "merge origin/main"},
+		},
+		{
+			name:   "auto-stash off",
+			status: " M main.go",
+			want:   []string{"fetch origin", "merge origin/main"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Git.AutoStash = tt.autoStash
+			runner := &recordingGitRunner{status: tt.status}
+			m.gitClient = git.NewClient(runner, slog.Default())
+
+			msg := m.fetchAndMergeCmd("/tmp/project-az-3", "main")().(fetchAndMergeResultMsg)
+
+			if msg.err != nil {
+				t.Fatalf("Unexpected error: %v", msg.err)
+			}
+			if msg.branch != "main" {
+				t.Errorf("Expected the result to name the branch merged from, got %q", msg.branch)
+			}
+			if strings.Join(runner.calls, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Expected commands %q, got %q", tt.want, runner.calls)
+			}
+		})
+	}
+}
+
+func TestFetchAndMerge_StashPopConflicts(t *testing.T) {
+	m := newTestModel()
+
+	updated, _ := m.Update(fetchAndMergeResultMsg{
+		worktree:  "/tmp/project-az-3",
+		result:    &git.MergeResult{Success: true},
+		stashed:   true,
+		popResult: &git.MergeResult{HasConflicts: true, ConflictFiles: []string{"main.go"}},
+	})
+	m = updated.(Model)
+
+	if _, ok := m.overlayStack.Current().(*overlay.ConflictOverlay); !ok {
+		t.Fatalf("Expected the conflict dialog, got %T", m.overlayStack.Current())
+	}
+	if len(m.toasts) != 1 || !strings.Contains(m.toasts[0].Message, "stashed changes") {
+		t.Errorf("Expected a toast about the stashed changes, got %v", m.toasts)
+	}
+}
+
+func TestBaseBranch_NamedInUpdateAndMerge(t *testing.T) {
+	m := newTestModel()
+	m.gitSyncService.SetBaseBranch("develop")
+
+	updated, _ := m.Update(fetchAndMergeResultMsg{
+		worktree: "/tmp/project-az-3",
+		branch:   m.baseBranch(),
+		result:   &git.MergeResult{Success: true},
+	})
+	m = updated.(Model)
+	if len(m.toasts) != 1 || m.toasts[0].Message != "Updated from develop successfully" {
+		t.Errorf("Expected the update toast to name develop, got %v", m.toasts)
+	}
+
+	source := m.tasks[2]
+	candidates := m.getMergeCandidates(&source)
+	if candidates[0].ID != overlay.BaseMergeTarget || candidates[0].Label != "develop" {
+		t.Errorf("Expected the base branch target labelled develop, got %+v", candidates[0])
+	}
+
+	updated, _ = m.Update(mergeResultMsg{sourceID: "az-3", targetID: "develop", result: &git.MergeResult{Success: true}})
+	m = updated.(Model)
+	if last := m.toasts[len(m.toasts)-1].Message; last != "Successfully merged az-3 into develop" {
+		t.Errorf("Expected the merge toast to name develop, got %q", last)
+	}
+}
+
+func TestPRCreateCommand(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{pr.ProviderGitHub, `gh pr create --head az/az-42 --title '[az-42] Don'\''t panic' --body 'Refs az-42'`},
+		{pr.ProviderGitLab, `glab mr create --source-branch az/az-42 --title '[az-42] Don'\''t panic' --description 'Refs az-42'`},
+	}
+	for _, tt := range tests {
+		got := prCreateCommand(tt.provider, "az/az-42", "[az-42] Don't panic", "Refs az-42")
+		if got != tt.want {
+			t.Errorf("prCreateCommand(%s) = %q, want %q", tt.provider, got, tt.want)
+		}
+	}
+}
+
+func TestCreatePRResult_Toast(t *testing.T) {
+	cmd := prCreateCommand(pr.ProviderGitHub, "az/az-42", "[az-42] Login", "Refs az-42")
+	tests := []struct {
+		name   string
+		copied bool
+		level  ToastLevel
+		prefix string
+	}{
+		{"copied to clipboard", true, ToastSuccess, "PR command copied: "},
+		{"no clipboard", false, ToastInfo, "Run: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			updated, _ := m.Update(createPRResultMsg{beadID: "az-42", cmd: cmd, copied: tt.copied})
+			m = updated.(Model)
+
+			if len(m.toasts) != 1 {
+				t.Fatalf("Expected 1 toast, got %v", m.toasts)
+			}
+			if m.toasts[0].Level != tt.level || m.toasts[0].Message != tt.prefix+cmd {
+				t.Errorf("Toast = %v %q, want %v %q", m.toasts[0].Level, m.toasts[0].Message, tt.level, tt.prefix+cmd)
+			}
+			// The command to run stays up long enough to copy
+			if !tt.copied && time.Until(m.toasts[0].Expires) <= m.toastDuration(ToastInfo) {
+				t.Errorf("Expected the command toast to outlast the info duration, expires in %s", time.Until(m.toasts[0].Expires))
+			}
+		})
+	}
+}
+
+// recordingPRRunner records gh invocations, answering pr view with a PR
+type recordingPRRunner struct {
+	calls [][]string
+}
+
+func (r *recordingPRRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, args)
+	if len(args) >= 2 && args[1] == "view" {
+		return []byte(`{"number": 7, "url": "https://github.com/org/repo/pull/7"}`), nil
+	}
+	return []byte("https://github.com/org/repo/pull/7\n"), nil
+}
+
+func TestCreatePR_AutoLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		autoLink bool
+		keyword  string
+		wantBody string
+	}{
+		{"closes keyword", true, "Closes", "Adds login.\n\nCloses az-3"},
+		{"format", true, "Part of {beadID}", "Adds login.\n\nPart of az-3"},
+		{"auto-link off", false, "Closes", "Adds login."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.PR.AutoLink = tt.autoLink
+			m.config.PR.LinkKeyword = tt.keyword
+			runner := &recordingPRRunner{}
+			m.prWorkflow = pr.NewPRWorkflow(runner, slog.Default())
+
+			m.createPRWithOverlayCmd(overlay.PRCreatedMsg{
+				Title:  "Add login",
+				Body:   "Adds login.",
+				Branch: "az/az-3",
+				BeadID: "az-3",
+			})()
+
+			body := ""
+			create := runner.calls[0]
+			for i, arg := range create {
+				if arg == "--body" {
+					body = create[i+1]
+				}
+			}
+			if body != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, body)
+			}
+		})
+	}
+}
+
+func TestRecordPR_PersistsMapping(t *testing.T) {
+	m := newTestModel()
+	m.prMapping = pr.Mapping{}
+	m.prMappingPath = filepath.Join(t.TempDir(), "prs.json")
+
+	updated, _ := m.Update(prCreatedResultMsg{beadID: "az-3", url: "https://github.com/org/repo/pull/7"})
+	m = updated.(Model)
+
+	task := m.findTask("az-3")
+	if task == nil || task.PRURL != "https://github.com/org/repo/pull/7" || task.PRNumber != 7 {
+		t.Fatalf("Expected az-3 to get PR #7, got %+v", task)
+	}
+
+	saved, err := pr.LoadMapping(m.prMappingPath)
+	if err != nil {
+		t.Fatalf("Failed to load saved mapping: %v", err)
+	}
+	if saved["az-3"] != "https://github.com/org/repo/pull/7" {
+		t.Errorf("Expected the mapping to be saved, got %v", saved)
+	}
+}
+
+func TestOpenPROverlay_ExistingPROffersOpen(t *testing.T) {
+	m := newTestModel()
+	m.prMapping = pr.Mapping{}
+	m.prMappingPath = filepath.Join(t.TempDir(), "prs.json")
+	url := "https://github.com/org/repo/pull/9"
+
+	updated, _ := m.Update(openPROverlayResultMsg{
+		branch:   "az/az-3",
+		beadID:   "az-3",
+		existing: &pr.PRInfo{Number: 9, URL: url, State: "OPEN"},
+	})
+	m = updated.(Model)
+
+	if _, prompted := m.overlayStack.Current().(*overlay.ConfirmDialog); !prompted {
+		t.Fatalf("Expected a confirmation instead of the create overlay, got %T", m.overlayStack.Current())
+	}
+	if task := m.findTask("az-3"); task == nil || task.PRURL != url {
+		t.Errorf("Expected the existing PR to be recorded on az-3, got %+v", task)
+	}
+
+	updated, cmd := m.handleSelection(overlay.SelectionMsg{Key: "yes", Value: overlay.ConfirmResult{Confirmed: true}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Error("Expected confirming to open the PR")
+	}
+	if m.pendingOpenURL != "" || m.pendingStartBeadID != "" {
+		t.Errorf("Expected pending confirmation state to be cleared, got %q / %q", m.pendingOpenURL, m.pendingStartBeadID)
+	}
+
+	// Without an existing PR the create overlay opens as before
+	updated, _ = m.Update(openPROverlayResultMsg{branch: "az/az-3", beadID: "az-3"})
+	m = updated.(Model)
+	if _, ok := m.overlayStack.Current().(*overlay.PRCreateOverlay); !ok {
+		t.Errorf("Expected the PR create overlay, got %T", m.overlayStack.Current())
+	}
+}
+
+func TestOpenPROverlay_PrefillsFromBead(t *testing.T) {
+	m := newTestModel()
+	m.config.PR.AutoLink = true
+	m.config.PR.LinkKeyword = "Closes"
+	m.tasks[2].Description = "Adds the feature."
+
+	updated, _ := m.Update(openPROverlayResultMsg{branch: "az/az-3", beadID: "az-3"})
+	m = updated.(Model)
+
+	prOverlay, ok := m.overlayStack.Current().(*overlay.PRCreateOverlay)
+	if !ok {
+		t.Fatalf("Expected the PR create overlay, got %T", m.overlayStack.Current())
+	}
+	_, cmd := prOverlay.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("Expected the prefilled form to submit")
+	}
+	created := cmd().(overlay.PRCreatedMsg)
+	if created.Title != "[az-3] Task 3" {
+		t.Errorf("Title = %q, want the bead's title", created.Title)
+	}
+	if created.Body != "Adds the feature.\n\nCloses az-3" {
+		t.Errorf("Body = %q, want the bead's description and link", created.Body)
+	}
+}
+
+// dirBeadsRunner records the directory each bd call runs in
+type dirBeadsRunner struct {
+	dir  string
+	dirs *[]string
+}
+
+func (r *dirBeadsRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	*r.dirs = append(*r.dirs, r.dir)
+	return []byte("[]"), nil
+}
+
+func (r *dirBeadsRunner) InDir(dir string) beads.CommandRunner {
+	return &dirBeadsRunner{dir: dir, dirs: r.dirs}
+}
+
+func TestCtrlR_ReloadsBeads(t *testing.T) {
+	m := newTestModel()
+	var dirs []string
+	m.beadsClient = beads.NewClient(&dirBeadsRunner{dirs: &dirs}, slog.Default())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if cmd == nil {
+		t.Fatal("Expected ctrl+r to reload beads")
+	}
+	if _, ok := cmd().(beadsLoadedMsg); !ok {
+		t.Fatal("Expected the reload to list beads")
+	}
+	if len(dirs) != 1 {
+		t.Errorf("Expected bd to run once, ran %d times", len(dirs))
+	}
+}
+
+// listBeadsRunner answers bd list with output
+type listBeadsRunner struct {
+	output string
+}
+
+func (r *listBeadsRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return []byte(r.output), nil
+}
+
+func TestLoadBeads_UnchangedRevisionKeepsTasks(t *testing.T) {
+	m := newTestModel()
+	runner := &listBeadsRunner{output: `[{"id": "az-1", "title": "Task 1", "status": "open"}, {"id": "az-2", "title": "Task 2", "status": "open"}]`}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+
+	msg := m.loadBeadsCmd()().(beadsLoadedMsg)
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if len(m.tasks) != 2 || m.beadsRevision == "" {
+		t.Fatalf("Expected 2 tasks and a revision, got %d tasks, revision %q", len(m.tasks), m.beadsRevision)
+	}
+	revision := m.beadsRevision
+
+	// Unchanged: the tasks held are kept, with what the app filled in
+	m.tasks[0].PRState = domain.PROpen
+	msg = m.loadBeadsCmd()().(beadsLoadedMsg)
+	if msg.revision != revision {
+		t.Errorf("Expected revision %q to be kept, got %q", revision, msg.revision)
+	}
+	if len(msg.tasks) != 2 || msg.tasks[0].PRState != domain.PROpen {
+		t.Errorf("Expected the held tasks back, got %+v", msg.tasks)
+	}
+
+	// Changed: the new list replaces them
+	runner.output = `[{"id": "az-1", "title": "Task 1", "status": "closed"}]`
+	msg = m.loadBeadsCmd()().(beadsLoadedMsg)
+	if msg.revision == revision || len(msg.tasks) != 1 || msg.tasks[0].Status != domain.StatusDone {
+		t.Errorf("Expected the changed list, got revision %q, tasks %+v", msg.revision, msg.tasks)
+	}
+}
+
+func TestBeadsRefresh_KeepsCursorOnTask(t *testing.T) {
+	m := newTestModel()
+	m.nav.SelectTask("az-1", 0)
+	m.nav.Resolve(m.buildColumns())
+
+	// A new P0 task sorts above the cursor in the Open column
+	tasks := append([]domain.Task{{ID: "az-0", Title: "Urgent", Status: domain.StatusOpen, Priority: domain.P0, Type: domain.TypeBug}}, m.tasks...)
+	updated, _ := m.Update(beadsLoadedMsg{tasks: tasks})
+	m = updated.(Model)
+
+	if task, _ := m.getCurrentTaskAndSession(); task == nil || task.ID != "az-1" {
+		t.Fatalf("Expected az-1 to stay selected, got %v", task)
+	}
+	before := getCursorPosition(m)
+
+	// The selected task goes away; the cursor stays where it was, on
+	// whichever of the two tasks left is nearest
+	var remaining []domain.Task
+	for _, task := range m.tasks {
+		if task.ID != "az-1" {
+			remaining = append(remaining, task)
+		}
+	}
+	updated, _ = m.Update(beadsLoadedMsg{tasks: remaining})
+	m = updated.(Model)
+	if pos := getCursorPosition(m); pos.Column != before.Column || pos.Task != min(before.Task, 1) {
+		t.Errorf("Expected the cursor near %+v, got %+v", before, pos)
+	}
+}
+
+func TestStatusBar_ShowsRefreshAge(t *testing.T) {
+	m := newTestModel()
+	if strings.Contains(m.renderStatusBar(), "updated") {
+		t.Error("Expected no refresh age before beads have loaded")
+	}
+
+	m.lastRefresh = time.Now().Add(-12 * time.Second)
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "updated 12s ago") {
+		t.Errorf("Expected the refresh age in the status bar, got: %s", bar)
+	}
+}
+
+func TestProjectSelected_RepointsServices(t *testing.T) {
+	m := newTestModel()
+	var dirs []string
+	m.beadsClient = beads.NewClient(&dirBeadsRunner{dirs: &dirs}, slog.Default())
+	projectDir := t.TempDir()
+
+	updated, cmd := m.Update(overlay.ProjectSelectedMsg{Project: config.Project{Name: "other", Path: projectDir}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected switching projects to reload beads")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected switching projects to batch the reload with base branch detection")
+	}
+	loaded := false
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if _, ok := c().(beadsLoadedMsg); ok {
+			loaded = true
+		}
+	}
+	if !loaded {
+		t.Fatal("Expected the reload to list beads")
+	}
+
+	if len(dirs) != 1 || dirs[0] != projectDir {
+		t.Errorf("Expected bd to run in %s, ran in %v", projectDir, dirs)
+	}
+	if want := filepath.Join(projectDir, ".beads"); m.beadsPath != want {
+		t.Errorf("Expected beads path %s, got %s", want, m.beadsPath)
+	}
+	if m.prMappingPath != pr.DefaultMappingPath(projectDir) {
+		t.Errorf("Expected the PR mapping of %s, got %s", projectDir, m.prMappingPath)
+	}
+}
+
+func TestProjectSelected_UsesProjectConfig(t *testing.T) {
+	m := newTestModel()
+	m.beadsClient = beads.NewClient(&dirBeadsRunner{dirs: new([]string)}, slog.Default())
+	shared := m.config
+
+	projectDir := t.TempDir()
+	projectCfg := &config.Config{
+		CLITool:   "opencode",
+		Git:       config.GitConfig{BaseBranch: "develop"},
+		DevServer: config.DevServerConfig{BasePort: 3050},
+		Board:     config.BoardConfig{StatusColors: map[string]string{"open": "red"}},
+		Session:   config.SessionConfig{MaxConcurrent: 1},
+	}
+	if err := config.SaveConfig(projectCfg, filepath.Join(projectDir, ".azedarach.json")); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	updated, _ := m.Update(overlay.ProjectSelectedMsg{Project: config.Project{Name: "other", Path: projectDir}})
+	m = updated.(Model)
+
+	if m.config != shared {
+		t.Error("Expected the config to be updated in place for the services sharing it")
+	}
+	if m.config.Git.BaseBranch != "develop" || m.config.CLITool != "opencode" {
+		t.Errorf("Expected the project's base branch and CLI tool, got %q and %q", m.config.Git.BaseBranch, m.config.CLITool)
+	}
+	if got := m.gitSyncService.BaseBranch(context.Background()); got != "develop" {
+		t.Errorf("Expected git sync to use develop, got %q", got)
+	}
+	// The first free port from the base, which something else may hold
+	if port, err := m.portAllocator.Allocate("az-1"); err != nil || port < 3050 || port > 3100 {
+		t.Errorf("Expected dev servers from port 3050, got %d (%v)", port, err)
+	}
+	// Services keeping their own copy of a setting follow the project too
+	if _, ok := m.styles.CardStatusColors[domain.StatusOpen]; !ok {
+		t.Error("Expected the project's status colors on the cards")
+	}
+	if !m.sessionLimiter.TryAcquire("az-1") || m.sessionLimiter.TryAcquire("az-2") {
+		t.Error("Expected the project's session limit of 1")
+	}
+
+	// Unset fields come from the defaults
+	if defaults := config.DefaultConfig(); m.config.DevServer.MaxPort != defaults.DevServer.MaxPort {
+		t.Errorf("Expected the default max port, got %d", m.config.DevServer.MaxPort)
+	}
+}
+
+func TestProjectSelected_ParksSessions(t *testing.T) {
+	m := newTestModel()
+	api := config.Project{Name: "api", Path: t.TempDir()}
+	web := config.Project{Name: "web", Path: t.TempDir()}
+	m.projectRegistry = &config.ProjectsRegistry{Projects: []config.Project{api, web}}
+	apiCfg := &config.Config{Session: config.SessionConfig{MaxConcurrent: 1}}
+	if err := config.SaveConfig(apiCfg, filepath.Join(api.Path, ".azedarach.json")); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	selectProject := func(project config.Project) {
+		updated, _ := m.Update(overlay.ProjectSelectedMsg{Project: project})
+		m = updated.(Model)
+	}
+
+	selectProject(api)
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+	// az-1 waits for the only slot, which another session holds
+	m.sessionLimiter.TryAcquire("az-9")
+	m.startQueue = []string{"az-1"}
+
+	selectProject(web)
+	if len(m.sessions) != 0 || len(m.startQueue) != 0 {
+		t.Fatalf("Expected no sessions on the other project's board, got %v and queue %v", m.sessions, m.startQueue)
+	}
+
+	// The parked session keeps its state while another project is shown
+	updated, _ := m.Update(monitor.SessionStateMsg{BeadID: "az-3", State: domain.SessionWaiting})
+	m = updated.(Model)
+
+	selectProject(api)
+	session, ok := m.sessions["az-3"]
+	if !ok || session.State != domain.SessionWaiting {
+		t.Errorf("Expected the project's session back and waiting, got %v", m.sessions)
+	}
+	if len(m.startQueue) != 1 || m.startQueue[0] != "az-1" {
+		t.Errorf("Expected the project's queued start back, got %v", m.startQueue)
+	}
+}
+
+func TestProjectSelected_KeepsConfigOverInvalidProjectConfig(t *testing.T) {
+	m := newTestModel()
+	m.beadsClient = beads.NewClient(&dirBeadsRunner{dirs: new([]string)}, slog.Default())
+	before := *m.config
+
+	projectDir := t.TempDir()
+	invalid := `{"devServer": {"basePort": 5000, "maxPort": 4000}}`
+	if err := os.WriteFile(filepath.Join(projectDir, ".azedarach.json"), []byte(invalid), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := m.Update(overlay.ProjectSelectedMsg{Project: config.Project{Name: "other", Path: projectDir}})
+	m = updated.(Model)
+
+	if m.config.DevServer.BasePort != before.DevServer.BasePort {
+		t.Errorf("Expected the invalid config not to apply, got base port %d", m.config.DevServer.BasePort)
+	}
+	var warned bool
+	for _, toast := range m.toasts {
+		if toast.Level == ToastWarning && strings.Contains(toast.Message, "Keeping current config") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("Expected a warning about the invalid config, got %+v", m.toasts)
+	}
+}
+
+func TestUndo_StatusChange(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+
+	updated, _ := m.Update(taskStatusResultMsg{taskID: "az-3", oldStatus: domain.StatusInProgress, newStatus: domain.StatusDone})
+	m = updated.(Model)
+	if len(m.undoHistory) != 1 {
+		t.Fatalf("Expected the move to be recorded, got %d actions", len(m.undoHistory))
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected ctrl+z to undo the move")
+	}
+	if len(m.undoHistory) != 0 {
+		t.Errorf("Expected the undone move to leave the history, got %d actions", len(m.undoHistory))
+	}
+
+	msg := cmd()
+	result, ok := msg.(undoResultMsg)
+	if !ok || result.err != nil {
+		t.Fatalf("Expected a successful undoResultMsg, got %#v", msg)
+	}
+	want := "bd update az-3 --status=in_progress"
+	if len(runner.calls) != 1 || strings.Join(runner.calls[0], " ") != want {
+		t.Errorf("Expected %q, got %v", want, runner.calls)
+	}
+
+	// Undoing doesn't record a new action that would redo it
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.undoHistory) != 0 {
+		t.Errorf("Expected undo not to be recorded, got %d actions", len(m.undoHistory))
+	}
+}
+
+// creatingBeadsRunner records bd calls, answering create with az-9
+type creatingBeadsRunner struct {
+	recordingBeadsRunner
+}
+
+func (r *creatingBeadsRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.recordingBeadsRunner.Run(ctx, name, args...)
+	if len(args) > 0 && args[0] == "create" {
+		return []byte(`{"id": "az-9"}`), nil
+	}
+	return nil, nil
+}
+
+func TestUndo_Delete(t *testing.T) {
+	m := newTestModel()
+
+	updated, _ := m.Update(taskDeletedResultMsg{taskID: "az-4"})
+	m = updated.(Model)
+	if len(m.undoHistory) != 1 || m.undoHistory[0].task.Title != "Task 4" {
+		t.Fatalf("Expected a copy of az-4 to be kept, got %+v", m.undoHistory)
+	}
+
+	runner := &creatingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	msg := m.undoCmd(m.undoHistory[0])()
+
+	if result, ok := msg.(undoResultMsg); !ok || result.err != nil || result.newID != "az-9" {
+		t.Fatalf("Expected az-4 to be restored as az-9, got %#v", msg)
+	}
+	if len(runner.calls) != 2 || runner.calls[0][1] != "create" || runner.calls[0][2] != "Task 4" {
+		t.Fatalf("Expected the task to be recreated then blocked, got %v", runner.calls)
+	}
+	if got := runner.calls[1][len(runner.calls[1])-1]; got != "--status=blocked" {
+		t.Errorf("Expected the recreated task to be blocked again, got %s", got)
+	}
+}
+
+func TestUndo_NothingToUndo(t *testing.T) {
+	m := newTestModel()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("Expected no command with an empty history")
+	}
+	if len(m.toasts) == 0 || m.toasts[len(m.toasts)-1].Message != "Nothing to undo" {
+		t.Errorf("Expected a nothing to undo toast, got %+v", m.toasts)
+	}
+}
+
+func TestUndoHistory_DropsOldest(t *testing.T) {
+	var h undoHistory
+	for i := 0; i < undoLimit+5; i++ {
+		h = h.push(undoAction{taskID: string(rune('a' + i))})
+	}
+	if len(h) != undoLimit {
+		t.Fatalf("Expected %d actions, got %d", undoLimit, len(h))
+	}
+
+	_, newest, ok := h.pop()
+	if !ok || newest.taskID != string(rune('a'+undoLimit+4)) {
+		t.Errorf("Expected the newest action last, got %+v", newest)
+	}
+	if h[0].taskID != "f" {
+		t.Errorf("Expected the 5 oldest to be dropped, oldest is %q", h[0].taskID)
+	}
+}
+
+func TestTaskMove_WarnsOverWIPLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		wantWarn bool
+	}{
+		{"over limit", 1, true},
+		{"within limit", 2, false},
+		{"unlimited", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Board.WIPLimits = map[string]int{"in_progress": tt.limit}
+
+			// az-3 is already in progress; az-1 joins it
+			updated, _ := m.Update(taskStatusResultMsg{taskID: "az-1", oldStatus: domain.StatusOpen, newStatus: domain.StatusInProgress})
+			m = updated.(Model)
+
+			warned := false
+			for _, toast := range m.toasts {
+				if toast.Level == ToastWarning && strings.Contains(toast.Message, "WIP limit (2/1)") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarn {
+				t.Errorf("Expected WIP warning = %v, got toasts %+v", tt.wantWarn, m.toasts)
+			}
+		})
+	}
+}
+
+func TestToggleDoneColumn(t *testing.T) {
+	m := newTestModel()
+
+	result, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	m = result.(Model)
+
+	done := m.buildColumns()[3]
+	if !done.Collapsed || len(done.Tasks) != 0 || done.Hidden != 1 {
+		t.Fatalf("Expected a collapsed Done column hiding az-5, got %+v", done)
+	}
+	if done.Count() != 1 {
+		t.Errorf("Expected the collapsed column to still count 1 task, got %d", done.Count())
+	}
+
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	m = result.(Model)
+
+	done = m.buildColumns()[3]
+	if done.Collapsed || len(done.Tasks) != 1 {
+		t.Errorf("Expected z again to expand Done, got %+v", done)
+	}
+}
+
+func TestBuildColumns_ConfiguredColumns(t *testing.T) {
+	m := newTestModel()
+	m.config.Board.Columns = []config.ColumnConfig{
+		{Title: "Doing", Status: "in_progress"},
+		{Title: "Todo", Status: "open"},
+	}
+
+	columns := m.buildColumns()
+	if len(columns) != 2 {
+		t.Fatalf("Expected the 2 configured columns, got %+v", columns)
+	}
+	if columns[0].Title != "Doing" || len(columns[0].Tasks) != 1 || columns[0].Tasks[0].ID != "az-3" {
+		t.Errorf("Expected Doing first holding az-3, got %+v", columns[0])
+	}
+	if columns[1].Title != "Todo" || len(columns[1].Tasks) != 2 {
+		t.Errorf("Expected Todo holding the 2 open tasks, got %+v", columns[1])
+	}
+
+	// The cursor's status follows the column order
+	m.nav.SelectTask("az-1", 1)
+	if status := m.columnStatus(); status != domain.StatusOpen {
+		t.Errorf("Expected the Todo column's status to be open, got %q", status)
+	}
+}
+
+func TestCustomStatus_ReviewColumn(t *testing.T) {
+	m := newTestModel()
+	m.config.Board.Columns = []config.ColumnConfig{
+		{Title: "Open", Status: "open"},
+		{Title: "In Progress", Status: "in_progress"},
+		{Title: "Review", Status: "review", Base: "in_progress"},
+		{Title: "Done", Status: "closed"},
+	}
+	m.tasks = append(m.tasks, domain.Task{ID: "az-6", Title: "Task 6", Status: "review", Type: domain.TypeTask})
+
+	columns := m.buildColumns()
+	if columns[2].Title != "Review" || len(columns[2].Tasks) != 1 || columns[2].Tasks[0].ID != "az-6" {
+		t.Fatalf("Expected the Review column to hold az-6, got %+v", columns[2])
+	}
+
+	// Moving right steps through the columns: in progress to review, then
+	// review to closed
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	m.moveTaskStatusCmd("az-3", 1)()
+	m.moveTaskStatusCmd("az-6", 1)()
+	want := [][]string{
+		{"bd", "update", "az-3", "--status=review"},
+		{"bd", "update", "az-6", "--status=closed"},
+	}
+	if len(runner.calls) != len(want) {
+		t.Fatalf("Expected %d bd calls, got %v", len(want), runner.calls)
+	}
+	for i := range want {
+		if !slices.Equal(runner.calls[i], want[i]) {
+			t.Errorf("Call %d = %v, want %v", i, runner.calls[i], want[i])
+		}
+	}
+
+	// Review behaves as in progress: its worktree isn't stale and it isn't done
+	if m.findTask("az-6").Status.Base(m.customStatuses()).IsTerminal() {
+		t.Error("Expected review to behave as in progress, not done")
+	}
+}
+
+func TestCustomStatus_WrittenAsBaseWithLabel(t *testing.T) {
+	m := newTestModel()
+	m.config.Board.Columns = []config.ColumnConfig{
+		{Title: "In Progress", Status: "in_progress"},
+		{Title: "Review", Status: "review", Base: "in_progress"},
+	}
+	runner := &showBeadsRunner{statuses: map[string]domain.Status{"az-3": domain.StatusInProgress}}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	m.applyConfig()
+
+	msg := m.moveTaskStatusCmd("az-3", 1)().(taskStatusResultMsg)
+	if msg.err != nil {
+		t.Fatalf("Expected the move to succeed against stock bd, got %v", msg.err)
+	}
+	if msg.newStatus != "review" {
+		t.Errorf("Expected the task to move to review, got %q", msg.newStatus)
+	}
+	want := [][]string{
+		{"update", "az-3", "--status=in_progress"},
+		{"label", "add", "az-3", "status:review"},
+	}
+	if len(runner.calls) != len(want) {
+		t.Fatalf("Expected %d bd calls, got %v", len(want), runner.calls)
+	}
+	for i := range want {
+		if !slices.Equal(runner.calls[i], want[i]) {
+			t.Errorf("call %d = %v, want %v", i, runner.calls[i], want[i])
+		}
+	}
+}
+
+func TestFocusMode_FollowsCursorColumn(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+
+	result, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	m = result.(Model)
+	if !m.focusMode {
+		t.Fatal("Expected Z to turn on focus mode")
+	}
+
+	board := m.renderBoardView()
+	if !strings.Contains(board, "Task 1") || strings.Contains(board, "Task 3") {
+		t.Errorf("Expected only the Open column's cards, got:\n%s", board)
+	}
+
+	// l moves the focus along with the cursor
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = result.(Model)
+	board = m.renderBoardView()
+	if !strings.Contains(board, "Task 3") || strings.Contains(board, "Task 1") {
+		t.Errorf("Expected only the In Progress column's cards, got:\n%s", board)
+	}
+
+	result, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	m = result.(Model)
+	if board := m.renderBoardView(); !strings.Contains(board, "Task 1") || !strings.Contains(board, "Task 3") {
+		t.Errorf("Expected every column after leaving focus mode, got:\n%s", board)
+	}
+}
+
+func TestBoardScroll_UpdatedInUpdateNotView(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.height = 12
+	m.tasks = nil
+	for i := 1; i <= 20; i++ {
+		m.tasks = append(m.tasks, domain.Task{ID: fmt.Sprintf("az-%d", i), Title: fmt.Sprintf("Task %d", i), Status: domain.StatusOpen})
+	}
+
+	// g e jumps to the last card, scrolling the column down to it
+	for _, key := range []rune{'g', 'e'} {
+		result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		m = result.(Model)
+	}
+	offset := m.nav.Offset(domain.StatusOpen)
+	if offset == 0 {
+		t.Fatal("Expected the column to scroll to the last card")
+	}
+
+	// Rendering draws from that scroll without changing it
+	m.nav.GetCursor().JumpToStart(m.buildColumns())
+	m.renderBoardView()
+	if got := m.nav.Offset(domain.StatusOpen); got != offset {
+		t.Errorf("Expected rendering to leave the scroll at %d, got %d", offset, got)
+	}
+
+	// A resize scrolls the cursor back into view
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 12})
+	m = result.(Model)
+	if got := m.nav.Offset(domain.StatusOpen); got != 0 {
+		t.Errorf("Expected a resize to scroll back to the cursor, got offset %d", got)
+	}
+}
+
+func TestMouse_ClickSelectsCardThenOpensMenu(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	click := tea.MouseMsg{X: 2, Y: 8, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+
+	// The second Open card starts below the header and first card
+	updated, _ := m.Update(click)
+	m = updated.(Model)
+	if pos := getCursorPosition(m); pos.Column != 0 || pos.Task != 1 {
+		t.Fatalf("Expected cursor on Open card 1, got %+v", pos)
+	}
+	if !m.overlayStack.IsEmpty() {
+		t.Fatal("Expected the first click only to select")
+	}
+
+	updated, _ = m.Update(click)
+	m = updated.(Model)
+	if _, ok := m.overlayStack.Current().(*overlay.ActionMenu); !ok {
+		t.Errorf("Expected a click on the selected card to open the action menu, got %T", m.overlayStack.Current())
+	}
+}
+
+func TestMouse_WheelMovesThroughColumnUnderPointer(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+
+	// Wheel over In Progress, 20 columns wide at 80
+	updated, _ := m.Update(tea.MouseMsg{X: 25, Y: 4, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	m = updated.(Model)
+	if pos := getCursorPosition(m); pos.Column != 1 {
+		t.Errorf("Expected the wheel to move to In Progress, got %+v", pos)
+	}
+
+	// Releases and motion are ignored
+	before := getCursorPosition(m)
+	updated, _ = m.Update(tea.MouseMsg{X: 2, Y: 8, Action: tea.MouseActionMotion})
+	m = updated.(Model)
+	if pos := getCursorPosition(m); pos != before {
+		t.Errorf("Expected motion to leave the cursor at %+v, got %+v", before, pos)
+	}
+}
+
+func TestView_OverlayFitsTerminal(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay overlay.Overlay
+	}{
+		{"confirm", overlay.NewConfirmDialog("Delete", "Delete az-1?")},
+		{"help taller than the screen", overlay.NewHelpOverlay()},
+		{"search bar", overlay.NewSearchOverlay()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.loading = false
+			m.overlayStack.Push(tt.overlay)
+
+			view := m.View()
+			if height := lipgloss.Height(view); height > m.height {
+				t.Errorf("Expected at most %d rows, got %d", m.height, height)
+			}
+			if width := lipgloss.Width(view); width > m.width {
+				t.Errorf("Expected at most %d columns, got %d", m.width, width)
+			}
+		})
+	}
+}
+
+func TestView_ModalKeepsBoardVisible(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.overlayStack.Push(overlay.NewConfirmDialog("Delete", "Delete az-1?"))
+
+	// Task 2's card is on the rows the modal covers, left of it
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "Task 2") || !strings.Contains(view, "Delete az-1?") {
+		t.Errorf("Expected the modal drawn over the board, got:\n%s", view)
+	}
+}
+
+func TestView_OverlayFitsTinyTerminal(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width, m.height = 40, 12
+	m.overlayStack.Push(overlay.NewHelpOverlay())
+
+	view := m.View()
+	if height := lipgloss.Height(view); height > m.height {
+		t.Fatalf("Expected at most %d rows, got %d", m.height, height)
+	}
+	if width := lipgloss.Width(view); width > m.width {
+		t.Errorf("Expected at most %d columns, got %d", m.width, width)
+	}
+
+	// The whole box fits, with the status bar still below it
+	plain := ansi.Strip(view)
+	if !strings.Contains(plain, "╭") || !strings.Contains(plain, "╯") {
+		t.Errorf("Expected the modal's borders on screen, got:\n%s", plain)
+	}
+	if bottom := strings.LastIndex(plain, "╯"); !strings.Contains(plain[bottom:], "NORMAL") {
+		t.Errorf("Expected the status bar below the modal, got:\n%s", plain)
+	}
+	if !strings.Contains(plain, "PgUp/PgDn: scroll (1-") {
+		t.Errorf("Expected a scroll hint, got:\n%s", plain)
+	}
+
+	// PgDn scrolls the help, and the first line scrolls off
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	m = updated.(Model)
+	if m.overlayStack.ScrollOffset() == 0 {
+		t.Fatal("Expected PgDn to scroll the modal")
+	}
+	if plain := ansi.Strip(m.View()); strings.Contains(plain, "PgUp/PgDn: scroll (1-") {
+		t.Errorf("Expected the window to move, got:\n%s", plain)
+	}
+}
+
+func TestQuit_WithoutSessionsQuits(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if cmd == nil {
+		t.Fatal("Expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected q to quit straight away without sessions")
+	}
+}
+
+func TestQuit_WithSessionsAsksFirst(t *testing.T) {
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyCtrlC},
+	} {
+		t.Run(key.String(), func(t *testing.T) {
+			m := newTestModel()
+			m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+
+			updated, cmd := m.Update(key)
+			m = updated.(Model)
+			if _, ok := m.overlayStack.Current().(*overlay.QuitConfirmOverlay); !ok {
+				t.Fatalf("Expected the quit confirmation, got %T", m.overlayStack.Current())
+			}
+			if cmd != nil {
+				if _, quit := cmd().(tea.QuitMsg); quit {
+					t.Error("Expected no quit before confirming")
+				}
+			}
+			if !strings.Contains(m.overlayStack.Current().View(), "az-3") {
+				t.Error("Expected the confirmation to list az-3")
+			}
+		})
+	}
+}
+
+func TestQuit_StopAllKillsSessions(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy}
+	m.sessions["az-4"] = &domain.Session{BeadID: "az-4", State: domain.SessionWaiting}
+
+	m.stopAllSessionsCmd()()
+
+	killed := map[string]bool{}
+	for _, call := range runner.calls {
+		if call[0] == "kill-session" {
+			killed[call[len(call)-1]] = true
+		}
+	}
+	if !killed["az-3"] || !killed["az-4"] {
+		t.Errorf("Expected both sessions killed, got calls %v", runner.calls)
+	}
+}
+
+func TestDependencyAdd_LinksInBd(t *testing.T) {
+	tests := []struct {
+		name     string
+		linkType domain.DependencyType
+		want     []string
+	}{
+		{"blocked by", domain.DependencyBlockedBy, []string{"bd", "dep", "add", "az-1", "az-2", "--type=blocks"}},
+		{"blocks", domain.DependencyBlocks, []string{"bd", "dep", "add", "az-2", "az-1", "--type=blocks"}},
+		{"related to", domain.DependencyRelatedTo, []string{"bd", "dep", "add", "az-1", "az-2", "--type=related"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			runner := &recordingBeadsRunner{}
+			m.beadsClient = beads.NewClient(runner, slog.Default())
+
+			_, cmd := m.Update(overlay.DependencyAddMsg{TaskID: "az-1", OtherID: "az-2", Type: tt.linkType})
+			if cmd == nil {
+				t.Fatal("Expected a command to add the dependency")
+			}
+			if result, ok := cmd().(dependencyResultMsg); !ok || result.err != nil {
+				t.Fatalf("Expected a successful dependencyResultMsg, got %#v", result)
+			}
+
+			if len(runner.calls) != 1 || strings.Join(runner.calls[0], " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Expected %v, got %v", tt.want, runner.calls)
+			}
+		})
+	}
+}
+
+func TestDependencyAdd_RefusesCycle(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	// az-2 is blocked by az-1
+	m.tasks[1].Dependencies = []domain.Dependency{{ID: "az-1", Type: domain.DependencyBlocks}}
+
+	updated, cmd := m.Update(overlay.DependencyAddMsg{TaskID: "az-1", OtherID: "az-2", Type: domain.DependencyBlockedBy})
+	m = updated.(Model)
+
+	if cmd != nil {
+		cmd()
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("Expected no bd call for a cycle, got %v", runner.calls)
+	}
+	if len(m.toasts) == 0 || m.toasts[len(m.toasts)-1].Level != ToastWarning {
+		t.Errorf("Expected a warning toast, got %v", m.toasts)
+	}
+}
+
+func TestDependencyRemove(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+
+	_, cmd := m.Update(overlay.DependencyRemoveMsg{
+		TaskID:     "az-2",
+		Dependency: domain.Dependency{ID: "az-1", Type: domain.DependencyBlocks},
+	})
+	if cmd == nil {
+		t.Fatal("Expected a command to remove the dependency")
+	}
+	cmd()
+
+	want := "bd dep remove az-2 az-1"
+	if len(runner.calls) != 1 || strings.Join(runner.calls[0], " ") != want {
+		t.Errorf("Expected %q, got %v", want, runner.calls)
+	}
+}
+
+func TestBlockedBadge_OnlyForUndoneBlockers(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 160 // Wide enough that the badge isn't cut from the card
+
+	tasks := append([]domain.Task(nil), m.tasks...)
+	// az-1 waits on az-3, which is in progress; az-2 on az-5, which is done
+	tasks[0].Dependencies = []domain.Dependency{{ID: "az-3", Type: domain.DependencyBlocks}}
+	tasks[1].Dependencies = []domain.Dependency{{ID: "az-5", Type: domain.DependencyBlocks}}
+
+	updated, _ := m.Update(beadsLoadedMsg{tasks: tasks})
+	m = updated.(Model)
+
+	if got := m.findTask("az-1").BlockedBy; len(got) != 1 || got[0] != "az-3" {
+		t.Errorf("Expected az-1 blocked by az-3, got %v", got)
+	}
+	if got := m.findTask("az-2").BlockedBy; len(got) != 0 {
+		t.Errorf("Expected az-2 unblocked once az-5 is done, got %v", got)
+	}
+
+	view := ansi.Strip(m.View())
+	if strings.Count(view, "blocked by 1") != 1 {
+		t.Errorf("Expected one blocked badge on the board, got:\n%s", view)
+	}
+
+	blockers := m.openBlockers("az-1")
+	if len(blockers) != 1 || blockers[0].Title != "Task 3" {
+		t.Errorf("Expected Task 3 as az-1's blocker, got %v", blockers)
+	}
+}
+
+func TestBeadsNotInstalled_PromptsOnce(t *testing.T) {
+	m := newTestModel()
+	notInstalled := &domain.BeadsError{Op: "list", Err: fmt.Errorf("%w: exec: \"bd\": executable file not found in $PATH", beads.ErrNotInstalled)}
+
+	updated, _ := m.Update(beadsErrorMsg{err: notInstalled})
+	m = updated.(Model)
+
+	if _, ok := m.overlayStack.Current().(*overlay.BeadsMissingOverlay); !ok {
+		t.Fatalf("Expected the missing-bd prompt, got %T", m.overlayStack.Current())
+	}
+	if len(m.toasts) != 0 {
+		t.Errorf("Expected no error toast, got %v", m.toasts)
+	}
+	if m.hasRefreshLoop {
+		t.Error("Expected the refresh loop not to start behind the prompt")
+	}
+
+	// A tick already in flight stops the loop instead of refreshing
+	m.hasRefreshLoop = true
+	updated, cmd := m.Update(tickMsg(time.Now()))
+	m = updated.(Model)
+	if cmd != nil || m.hasRefreshLoop {
+		t.Error("Expected the refresh loop to stop while the prompt is up")
+	}
+
+	// Dismissing restarts the loop; later failures stay quiet
+	updated, cmd = m.Update(overlay.SelectionMsg{Key: "beads_dismiss"})
+	m = updated.(Model)
+	if cmd == nil || !m.hasRefreshLoop {
+		t.Error("Expected dismissing to restart the refresh loop")
+	}
+	updated, _ = m.Update(beadsErrorMsg{err: notInstalled})
+	m = updated.(Model)
+	if !m.overlayStack.IsEmpty() || len(m.toasts) != 0 {
+		t.Error("Expected no prompt or toast for later failures")
+	}
+}
+
+func TestBeadsNotInstalled_Retry(t *testing.T) {
+	m := newTestModel()
+	runner := &recordingBeadsRunner{}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+
+	updated, _ := m.Update(beadsErrorMsg{err: beads.ErrNotInstalled})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(overlay.SelectionMsg{Key: "beads_retry"})
+	m = updated.(Model)
+	if !m.overlayStack.IsEmpty() {
+		t.Error("Expected retry to close the prompt")
+	}
+	if cmd == nil {
+		t.Fatal("Expected retry to reload beads")
+	}
+	cmd()
+	if len(runner.calls) != 1 || runner.calls[0][1] != "list" {
+		t.Errorf("Expected bd list, got %v", runner.calls)
+	}
+}
+
+func TestStartSession_UsesConfiguredBaseBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		global  string
+		project *config.Project
+		want    string
+	}{
+		{"global config", "develop", nil, "develop"},
+		{"project override", "develop", &config.Project{Name: "p", BaseBranch: "release"}, "release"},
+		{"detected when unset", "", nil, "trunk"},
+		{"configured main wins over detection", "main", nil, "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Git.BaseBranch = tt.global
+			runner := &recordingGitRunner{}
+			detect := &mockDefaultBranchRunner{recordingGitRunner: runner, branch: "trunk"}
+			m.worktreeManager = git.NewWorktreeManager(runner, "/tmp/project", slog.Default())
+			m.gitSyncService = git.NewGitSyncService(git.NewClient(detect, slog.Default()), nil, m.config, "/tmp/project", slog.Default())
+			m.gitSyncService.SetBaseBranch(config.ResolveBaseBranch(m.config, tt.project))
+			m.tmuxClient = tmux.NewClient(&recordingTmuxRunner{}, slog.Default())
+
+			if msg, ok := m.startSessionCmd("az-1")().(sessionErrorMsg); ok {
+				t.Fatalf("Unexpected error: %v", msg.err)
+			}
+
+			want := "worktree add -b az/az-1 /tmp/project-az-1 " + tt.want
+			found := false
+			for _, call := range runner.calls {
+				found = found || call == want
+			}
+			if !found {
+				t.Errorf("Expected %q, got %q", want, runner.calls)
+			}
+		})
+	}
+}
+
+// mockDefaultBranchRunner answers origin/HEAD lookups with branch
+type mockDefaultBranchRunner struct {
+	*recordingGitRunner
+	branch string
+}
+
+func (r *mockDefaultBranchRunner) Run(ctx context.Context, args ...string) (string, error) {
+	if strings.Join(args, " ") == "symbolic-ref refs/remotes/origin/HEAD" {
+		return "refs/remotes/origin/" + r.branch, nil
+	}
+	return r.recordingGitRunner.Run(ctx, args...)
+}
+
+func TestPRProvider_DetectedInBackground(t *testing.T) {
+	m := newTestModel()
+	m.gitClient = git.NewClient(&originURLRunner{url: "git@gitlab.com:team/repo.git"}, slog.Default())
+	if m.prProvider != pr.ProviderGitHub {
+		t.Fatalf("Expected GitHub until detection, got %s", m.prProvider)
+	}
+
+	// A detection for a project since switched away from is ignored
+	cmd := m.detectPRProviderCmd("/tmp/elsewhere")
+	updated, _ := m.Update(cmd())
+	if provider := updated.(Model).prProvider; provider != pr.ProviderGitHub {
+		t.Errorf("Expected a stale detection ignored, got %s", provider)
+	}
+
+	cmd = m.detectPRProviderCmd(m.projectDir())
+	updated, _ = m.Update(cmd())
+	if provider := updated.(Model).prProvider; provider != pr.ProviderGitLab {
+		t.Errorf("Expected GitLab detected from the origin remote, got %s", provider)
+	}
+
+	m.config.PR.Provider = pr.ProviderGitHub
+	if m.detectPRProviderCmd(m.projectDir()) != nil {
+		t.Error("Expected no detection with a configured provider")
+	}
+}
+
+// originURLRunner answers origin remote lookups with url
+type originURLRunner struct {
+	url string
+}
+
+func (r *originURLRunner) Run(ctx context.Context, args ...string) (string, error) {
+	if strings.Join(args, " ") == "remote get-url origin" {
+		return r.url, nil
+	}
+	return "", nil
+}
+
+// worktreeListRunner answers git worktree list with list and records the
+// other commands
+type worktreeListRunner struct {
+	recordingGitRunner
+	list     string
+	unmerged bool // git branch -d refuses
+}
+
+func (r *worktreeListRunner) Run(ctx context.Context, args ...string) (string, error) {
+	if strings.Join(args, " ") == "worktree list --porcelain" {
+		return r.list, nil
+	}
+	out, err := r.recordingGitRunner.Run(ctx, args...)
+	if r.unmerged && len(args) > 1 && args[0] == "branch" && args[1] == "-d" {
+		return "", errors.New("error: the branch is not fully merged")
+	}
+	return out, err
+}
+
+func TestStaleWorktrees(t *testing.T) {
+	stale := []git.Worktree{{Path: "/tmp/project-az-9", Branch: "az/az-9", BeadID: "az-9"}}
+
+	tests := []struct {
+		name        string
+		autoCleanup bool
+		confirm     bool
+		wantRemoved bool
+	}{
+		{"auto cleanup removes", true, false, true},
+		{"asks and removes on yes", false, true, true},
+		{"asks and keeps on no", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel()
+			m.config.Worktree.AutoCleanup = tt.autoCleanup
+			m.config.Worktree.KeepDays = 7
+			runner := &worktreeListRunner{list: "worktree /tmp/project-az-9\nbranch refs/heads/az/az-9\n"}
+			m.worktreeManager = git.NewWorktreeManager(runner, "/tmp/project", slog.Default())
+
+			updated, cmd := m.Update(staleWorktreesMsg{worktrees: stale})
+			m = updated.(Model)
+
+			if !tt.autoCleanup {
+				if _, ok := m.overlayStack.Current().(*overlay.ConfirmDialog); !ok {
+					t.Fatalf("Expected a confirmation, got %T", m.overlayStack.Current())
+				}
+				key := "no"
+				if tt.confirm {
+					key = "yes"
+				}
+				updated, cmd = m.Update(overlay.SelectionMsg{Key: key, Value: overlay.ConfirmResult{Confirmed: tt.confirm}})
+				m = updated.(Model)
+			}
+
+			if !tt.wantRemoved {
+				if cmd != nil {
+					t.Error("Expected nothing removed")
+				}
+				return
+			}
+			if cmd == nil {
+				t.Fatal("Expected a removal command")
+			}
+			result, ok := cmd().(worktreesRemovedMsg)
+			if !ok || len(result.removed) != 1 || result.removed[0] != "az-9" {
+				t.Fatalf("Expected az-9 removed, got %#v", result)
+			}
+			if !slices.Contains(runner.calls, "worktree remove /tmp/project-az-9") {
+				t.Errorf("Expected the worktree removed, got %v", runner.calls)
+			}
+			if !slices.Contains(runner.calls, "branch -d az/az-9") {
+				t.Errorf("Expected only a merged branch deleted, got %v", runner.calls)
+			}
+		})
+	}
+}
+
+func TestStaleWorktrees_KeepsUnmergedBranches(t *testing.T) {
+	m := newTestModel()
+	m.config.Worktree.AutoCleanup = true
+	m.config.Worktree.KeepDays = 7
+	runner := &worktreeListRunner{list: "worktree /tmp/project-az-9\nbranch refs/heads/az/az-9\n", unmerged: true}
+	m.worktreeManager = git.NewWorktreeManager(runner, "/tmp/project", slog.Default())
+
+	_, cmd := m.Update(staleWorktreesMsg{worktrees: []git.Worktree{{Path: "/tmp/project-az-9", Branch: "az/az-9", BeadID: "az-9"}}})
+	if cmd == nil {
+		t.Fatal("Expected a removal command")
+	}
+	result := cmd().(worktreesRemovedMsg)
+
+	if slices.Contains(runner.calls, "branch -D az/az-9") {
+		t.Error("Expected the unmerged branch not to be force deleted")
+	}
+	if !slices.Equal(result.kept, []string{"az/az-9"}) {
+		t.Errorf("Expected az/az-9 reported as kept, got %v", result.kept)
+	}
+
+	updated, _ := m.Update(result)
+	m = updated.(Model)
+	found := false
+	for _, toast := range m.toasts {
+		found = found || strings.Contains(toast.Message, "Kept 1 unmerged branches: az/az-9")
+	}
+	if !found {
+		t.Errorf("Expected a toast naming the kept branch, got %v", m.toasts)
+	}
+}
+
+func TestFindStaleWorktrees_KeepsOpenAndSessionBeads(t *testing.T) {
+	m := newTestModel()
+	m.config.Worktree.KeepDays = 7
+	m.sessions["az-5"] = &domain.Session{BeadID: "az-5", State: domain.SessionIdle}
+	list := strings.Join([]string{
+		"worktree /tmp/project-az-1", "branch refs/heads/az/az-1", "",
+		"worktree /tmp/project-az-5", "branch refs/heads/az/az-5", "",
+		"worktree /tmp/project-az-9", "branch refs/heads/az/az-9", "",
+	}, "\n")
+	runner := &staleGitRunner{list: list, lastCommit: time.Now().Add(-30 * 24 * time.Hour)}
+	m.worktreeManager = git.NewWorktreeManager(runner, "/tmp/project", slog.Default())
+
+	msg := m.findStaleWorktreesCmd()().(staleWorktreesMsg)
+
+	if msg.err != nil {
+		t.Fatalf("Unexpected error: %v", msg.err)
+	}
+	// az-1 is open and az-5 has a session; az-9 isn't loaded at all
+	if len(msg.worktrees) != 1 || msg.worktrees[0].BeadID != "az-9" {
+		t.Errorf("Expected only az-9 to be stale, got %v", msg.worktrees)
+	}
+}
+
+func TestFindStaleWorktrees_Disabled(t *testing.T) {
+	m := newTestModel()
+	m.config.Worktree.KeepDays = 0
+
+	if m.findStaleWorktreesCmd() != nil {
+		t.Error("Expected no sweep with keepDays 0")
+	}
+}
+
+// staleGitRunner lists worktrees whose last commit was at lastCommit, all
+// without changes
+type staleGitRunner struct {
+	list       string
+	lastCommit time.Time
+}
+
+func (r *staleGitRunner) Run(ctx context.Context, args ...string) (string, error) {
+	switch {
+	case strings.Join(args, " ") == "worktree list --porcelain":
+		return r.list, nil
+	case len(args) > 2 && args[0] == "-C" && args[2] == "log":
+		return fmt.Sprint(r.lastCommit.Unix()), nil
+	}
+	return "", nil
+}
+
+// showBeadsRunner answers bd show with the task's status and records the
+// other bd calls
+type showBeadsRunner struct {
+	statuses map[string]domain.Status
+	calls    [][]string
+}
+
+func (r *showBeadsRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if len(args) > 1 && args[0] == "show" {
+		return []byte(fmt.Sprintf(`{"id": %q, "status": %q}`, args[1], r.statuses[args[1]])), nil
+	}
+	r.calls = append(r.calls, args)
+	return []byte(`{"id": "az-9"}`), nil
+}
+
+func TestOfflineChanges_QueueAndReplay(t *testing.T) {
+	m := newTestModel()
+	queuePath := filepath.Join(t.TempDir(), "queue.json")
+	m.pendingChanges, _ = beads.OpenPendingQueue(queuePath)
+	runner := &showBeadsRunner{statuses: map[string]domain.Status{
+		"az-1": domain.StatusOpen,
+		"az-2": domain.StatusBlocked, // Moved by someone else meanwhile
+	}}
+	m.beadsClient = beads.NewClient(runner, slog.Default())
+	m.isOnline = false
+
+	// Offline changes are queued in order rather than made
+	for _, cmd := range []tea.Cmd{
+		m.moveTaskStatusCmd("az-1", 1),
+		m.saveTaskCmd(overlay.TaskCreatedMsg{Title: "Write docs", Type: domain.TypeTask, Priority: domain.P2}),
+		m.moveTaskStatusCmd("az-2", 1),
+	} {
+		updated, _ := m.Update(cmd())
+		m = updated.(Model)
+	}
+
+	if len(runner.calls) != 0 {
+		t.Fatalf("Expected no bd calls while offline, got %v", runner.calls)
+	}
+	if m.pendingChanges.Len() != 3 {
+		t.Fatalf("Expected 3 pending changes, got %d", m.pendingChanges.Len())
+	}
+	// The queued move shows on the board before it's made
+	if task := m.findTask("az-1"); task == nil || task.Status != domain.StatusInProgress {
+		t.Errorf("Expected az-1 moved locally, got %+v", task)
+	}
+	if !strings.Contains(m.renderStatusBar(), "3 pending changes") {
+		t.Errorf("Expected the status bar to count pending changes, got %q", m.renderStatusBar())
+	}
+
+	// The queue survives a restart
+	saved, err := beads.LoadQueue(queuePath)
+	if err != nil || len(saved) != 3 {
+		t.Fatalf("Expected 3 saved changes, got %v (%v)", saved, err)
+	}
+
+	// Back online, the queue is replayed in order
+	updated, cmd := m.Update(network.StatusMsg{Online: true})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected the queue to be replayed")
+	}
+	if again := m.replayChangesCmd(); again != nil {
+		t.Error("Expected no second replay while one is in flight")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	want := [][]string{
+		{"update", "az-1", "--status=in_progress"},
+		{"create", "Write docs", "--json", "-t", "task", "-p", "2"},
+	}
+	if len(runner.calls) != len(want) {
+		t.Fatalf("Expected %d bd calls, got %v", len(want), runner.calls)
+	}
+	for i := range want {
+		if !slices.Equal(runner.calls[i], want[i]) {
+			t.Errorf("call %d = %v, want %v", i, runner.calls[i], want[i])
+		}
+	}
+
+	// The conflicting move is reported on its own
+	var conflict bool
+	for _, toast := range m.toasts {
+		if toast.Level == ToastError && strings.Contains(toast.Message, "move az-2") {
+			conflict = true
+		}
+	}
+	if !conflict {
+		t.Errorf("Expected an error toast for the conflicting move, got %+v", m.toasts)
+	}
+
+	if m.pendingChanges.Len() != 0 {
+		t.Errorf("Expected an empty queue after replay, got %v", m.pendingChanges.Changes())
+	}
+	if m.replayingChanges {
+		t.Error("Expected the replay to be finished")
+	}
+	if _, err := os.Stat(queuePath); !os.IsNotExist(err) {
+		t.Errorf("Expected the saved queue to be removed, got %v", err)
+	}
+}
+
+func TestOfflineChanges_RepeatedMoveQueuesNextStatus(t *testing.T) {
+	m := newTestModel()
+	m.pendingChanges, _ = beads.OpenPendingQueue(filepath.Join(t.TempDir(), "queue.json"))
+	m.beadsClient = beads.NewClient(&showBeadsRunner{}, slog.Default())
+	m.isOnline = false
+
+	for range 2 {
+		updated, _ := m.Update(m.moveTaskStatusCmd("az-1", 1)())
+		m = updated.(Model)
+	}
+
+	changes := m.pendingChanges.Changes()
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 pending changes, got %v", changes)
+	}
+	if changes[1].From != changes[0].To || changes[0].From == changes[1].To {
+		t.Errorf("Expected the second move to continue from the first, got %+v", changes)
+	}
+
+	// A reload from bd, which doesn't have the moves yet, keeps them on the board
+	updated, _ := m.Update(beadsLoadedMsg{tasks: []domain.Task{{ID: "az-1", Status: domain.StatusOpen}}})
+	m = updated.(Model)
+	if task := m.findTask("az-1"); task == nil || task.Status != changes[1].To {
+		t.Errorf("Expected az-1 to stay at %s, got %+v", changes[1].To, task)
+	}
+}
+
+func TestAheadBehind_PolledAtSlowerCadence(t *testing.T) {
+	m := newTestModel()
+	m.gitSyncService.SetBaseBranch("main")
+	now := time.Now()
+
+	if cmd := m.aheadBehindCmd(now); cmd != nil {
+		t.Error("expected no comparison without sessions")
+	}
+
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy, Worktree: "/tmp/project-az-3"}
+	if cmd := m.aheadBehindCmd(now); cmd == nil {
+		t.Fatal("expected a comparison for a session with a worktree")
+	}
+	if !m.aheadBehindAt.Equal(now) {
+		t.Errorf("aheadBehindAt = %v, want %v", m.aheadBehindAt, now)
+	}
+
+	if cmd := m.aheadBehindCmd(now.Add(aheadBehindInterval / 2)); cmd != nil {
+		t.Error("expected no comparison before aheadBehindInterval has passed")
+	}
+	if cmd := m.aheadBehindCmd(now.Add(aheadBehindInterval)); cmd == nil {
+		t.Error("expected a comparison once aheadBehindInterval has passed")
+	}
+}
+
+func TestAheadBehindMsg_UpdatesSessions(t *testing.T) {
+	m := newTestModel()
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy, Worktree: "/tmp/project-az-3", Behind: 4}
+
+	updated, _ := m.Update(aheadBehindMsg{counts: map[string]branchCounts{
+		"az-3": {ahead: 3, behind: 1},
+		"az-9": {ahead: 1}, // Session ended since the comparison started
+	}})
+	model := updated.(Model)
+
+	session := model.sessions["az-3"]
+	if session.Ahead != 3 || session.Behind != 1 {
+		t.Errorf("session counts = ↑%d ↓%d, want ↑3 ↓1", session.Ahead, session.Behind)
+	}
+	if _, ok := model.sessions["az-9"]; ok {
+		t.Error("counts for an ended session shouldn't create one")
+	}
+}
+
+func TestShowDiffResult_OpensSummaryOverlay(t *testing.T) {
+	m := newTestModel()
+
+	updated, _ := m.Update(showDiffResultMsg{
+		beadID:  "az-3",
+		base:    "main",
+		summary: git.ParseChangeSummary("1\t1\ta.go\n", "abc1234 Change a\n"),
+	})
+	model := updated.(Model)
+
+	if _, ok := model.overlayStack.Current().(*overlay.DiffSummaryOverlay); !ok {
+		t.Fatalf("Expected the diff summary overlay, got %T", model.overlayStack.Current())
+	}
+	if len(model.toasts) != 0 {
+		t.Errorf("Expected no toast, got %v", model.toasts)
+	}
+}
+
+func TestResumeSession_AppliesLayout(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.InitCommands = []string{"source ~/.zshrc"}
+	m.config.Session.Layout.Panes = []config.PaneConfig{{Split: "right", Size: 30}}
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+
+	msg := m.resumeSessionCmd("az-3", "/tmp/project-az-3")()
+	resumed, ok := msg.(sessionResumedMsg)
+	if !ok || resumed.layoutErr != nil {
+		t.Fatalf("Expected the session to resume with its layout, got %#v", msg)
+	}
+
+	var ops []string
+	for _, call := range runner.calls {
+		if call[0] != "send-keys" {
+			ops = append(ops, call[0]+" "+call[len(call)-2])
+		} else if typed, ok := typedKeys(call); ok {
+			ops = append(ops, call[0]+" "+typed)
+		}
+	}
+	want := []string{
+		"new-session -c",
+		"split-window -c",
+		"send-keys source ~/.zshrc", // The layout's shell pane
+		"send-keys source ~/.zshrc", // The agent's pane
+		"send-keys " + buildRestartCommand(m.cliTool(), ""),
+	}
+	if strings.Join(ops, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tmux commands = %q, want %q", ops, want)
+	}
+	if agent := runner.calls[len(runner.calls)-1]; agent[2] != "az-3" {
+		t.Errorf("Expected the agent started in the session's first pane, got %v", agent)
+	}
+}
+
+func TestResumeSession_LayoutFailureOnlyWarns(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.Layout.Panes = []config.PaneConfig{{Split: "below"}}
+	runner := &recordingTmuxRunner{splitErr: errors.New("no space for new pane")}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionPaused, Worktree: "/tmp/project-az-3"}
+
+	msg := m.resumeSessionCmd("az-3", "/tmp/project-az-3")()
+	resumed, ok := msg.(sessionResumedMsg)
+	if !ok || resumed.layoutErr == nil {
+		t.Fatalf("Expected the session to resume reporting the layout error, got %#v", msg)
+	}
+	if keys := runner.sentKeys(); len(keys) != 1 {
+		t.Errorf("Expected the agent started despite the layout, got %v", keys)
+	}
+
+	updated, _ := m.Update(msg)
+	model := updated.(Model)
+	if state := model.sessions["az-3"].State; state != domain.SessionBusy {
+		t.Errorf("Expected the session busy, got %v", state)
+	}
+	warned := false
+	for _, toast := range model.toasts {
+		if toast.Level == ToastWarning && strings.Contains(toast.Message, "layout") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("Expected a layout warning, got %v", model.toasts)
+	}
+}
+
+func TestInitCommands_SentBeforeCLI(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.InitCommands = []string{"eval \"$(direnv hook zsh)\"", "nvm use"}
+	runner := &recordingTmuxRunner{}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+
+	msg := m.resumeSessionCmd("az-3", "/tmp/project-az-3")()
+	if resumed, ok := msg.(sessionResumedMsg); !ok || resumed.initErr != nil {
+		t.Fatalf("Expected the session to resume, got %#v", msg)
+	}
+
+	want := []string{"eval \"$(direnv hook zsh)\"", "nvm use", buildRestartCommand(m.cliTool(), "")}
+	if keys := runner.sentKeys(); strings.Join(keys, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected init commands before the CLI, got %q", keys)
+	}
+}
+
+func TestInitCommands_FailureOnlyWarns(t *testing.T) {
+	m := newTestModel()
+	m.config.Session.InitCommands = []string{"nvm use"}
+	runner := &failingSendRunner{fail: "nvm use"}
+	m.tmuxClient = tmux.NewClient(runner, slog.Default())
+
+	msg := m.resumeSessionCmd("az-3", "/tmp/project-az-3")()
+	resumed, ok := msg.(sessionResumedMsg)
+	if !ok || resumed.initErr == nil {
+		t.Fatalf("Expected the session to resume reporting the init failure, got %#v", msg)
+	}
+	if len(runner.sent) != 1 || runner.sent[0] != buildRestartCommand(m.cliTool(), "") {
+		t.Errorf("Expected the CLI launched anyway, got %q", runner.sent)
+	}
+}
+
+// failingSendRunner fails send-keys for one command and records the rest
+type failingSendRunner struct {
+	fail string
+	sent []string
+}
+
+func (r *failingSendRunner) Run(ctx context.Context, args ...string) (string, error) {
+	typed, ok := typedKeys(args)
+	if !ok {
+		return "", nil
+	}
+	if typed == r.fail {
+		return "", errors.New("pane is dead")
+	}
+	r.sent = append(r.sent, typed)
+	return "", nil
+}
+
+func TestPlanningAvailability(t *testing.T) {
+	planKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}}
+
+	t.Run("without an API key", func(t *testing.T) {
+		t.Setenv("ANTHROPIC_API_KEY", "")
+		m := newTestModel()
+		if m.planningAvailable {
+			t.Fatal("Expected planning to be unavailable without ANTHROPIC_API_KEY")
+		}
+
+		result, _ := m.handleNormalMode(planKey)
+		m = result.(Model)
+		if _, ok := m.overlayStack.Current().(*overlay.PlanningSetupOverlay); !ok {
+			t.Fatalf("Expected the planning setup prompt, got %T", m.overlayStack.Current())
+		}
+
+		// A start request from anywhere still can't run a plan
+		if cmd := m.startPlanningCmd("a feature"); cmd != nil {
+			t.Error("Expected no planning run without an API key")
+		}
+		if m.planner != nil {
+			t.Error("Expected no planning service without an API key")
+		}
+	})
+
+	t.Run("with an API key", func(t *testing.T) {
+		t.Setenv("ANTHROPIC_API_KEY", "test-key")
+		m := newTestModel()
+		if !m.planningAvailable {
+			t.Fatal("Expected planning to be available with ANTHROPIC_API_KEY set")
+		}
+
+		result, _ := m.handleNormalMode(planKey)
+		m = result.(Model)
+		if _, ok := m.overlayStack.Current().(*overlay.PlanningOverlay); !ok {
+			t.Fatalf("Expected the planning overlay, got %T", m.overlayStack.Current())
+		}
+	})
+}
+
+func TestStartPlanning_Offline(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	m := newTestModel()
+	m.isOnline = false
+
+	cmd := m.startPlanningCmd("a feature")
+	if cmd == nil {
+		t.Fatal("Expected an offline planning run to report its failure")
+	}
+	msg, ok := cmd().(planningResultMsg)
+	if !ok {
+		t.Fatalf("Expected planningResultMsg, got %T", cmd())
+	}
+	if msg.state.Status != domain.PlanningErrorStatus || !strings.Contains(msg.state.Error, "offline") {
+		t.Errorf("Expected an offline error, got %+v", msg.state)
+	}
+	if m.planner != nil {
+		t.Error("Expected no planning service while offline")
+	}
+}
+
+func TestPlanningResult_ShowsFailureInOverlay(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	m := newTestModel()
+	m.overlayStack.Push(overlay.NewPlanningOverlay())
+
+	state := failedPlanningState(domain.PlanningState{Status: domain.PlanningGenerating}, errors.New("rate limited"))
+	result, cmd := m.handlePlanningResult(planningResultMsg{state: state})
+	m = result.(Model)
+	if cmd != nil {
+		t.Error("Expected no reload when no beads were created")
+	}
+	if view := m.overlayStack.Current().View(); !strings.Contains(view, "rate limited") {
+		t.Errorf("Expected the planning overlay to show the error, got:\n%s", view)
+	}
+}
+
+func TestPlanningDraft_ResumesAndDiscards(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	t.Setenv("HOME", t.TempDir())
+	m := newTestModel()
+	draft := planning.Draft{
+		FeatureDescription: "Add login",
+		Plan: &domain.Plan{
+			EpicTitle: "Login",
+			Tasks:     []domain.PlannedTask{{ID: "task-1", Title: "Add form", Type: domain.TypeTask}},
+		},
+		SavedAt: time.Now(),
+	}
+	if err := planning.SaveDraft(m.planningDraftPath(), draft); err != nil {
+		t.Fatal(err)
+	}
+
+	m.openPlanning()
+	msg := m.loadPlanningDraftCmd()()
+	if _, ok := msg.(planningDraftMsg); !ok {
+		t.Fatalf("Expected the saved draft to load, got %T", msg)
+	}
+	result, _ := m.Update(msg)
+	m = result.(Model)
+
+	if m.planner == nil {
+		t.Fatal("Expected a planner resumed from the draft")
+	}
+	if view := m.overlayStack.Current().View(); !strings.Contains(view, "Add form") {
+		t.Errorf("Expected the draft's tasks up for review, got:\n%s", view)
+	}
+
+	result, cmd := m.Update(overlay.PlanningDiscardMsg{})
+	m = result.(Model)
+	if cmd != nil {
+		cmd()
+	}
+	if m.planner != nil {
+		t.Error("Expected discarding to drop the planner")
+	}
+	if saved, err := planning.LoadDraft(m.planningDraftPath()); err != nil || saved != nil {
+		t.Errorf("Expected the draft removed, got %v, %v", saved, err)
+	}
+}
+
+func TestSettingsSaved_AppliesConfigLive(t *testing.T) {
+	m := newTestModel()
+	m.overlayStack.Push(overlay.NewConfigSettingsOverlay(m.editor, m.config, m.configPath()))
+
+	saved := *m.config
+	saved.CLITool = "opencode"
+	saved.UI.ToastDurations = map[string]int{"info": 1234}
+	result, _ := m.Update(overlay.SettingsSavedMsg{Config: &saved, Path: "/tmp/.azedarach.json"})
+	m = result.(Model)
+
+	if m.config.CLITool != "opencode" {
+		t.Errorf("Expected the saved CLI tool to apply, got %q", m.config.CLITool)
+	}
+	if got := m.toastDuration(ToastInfo); got != 1234*time.Millisecond {
+		t.Errorf("Expected the saved toast duration to apply, got %v", got)
+	}
+	if m.overlayStack.Current() != nil {
+		t.Errorf("Expected settings to close after saving, got %T", m.overlayStack.Current())
+	}
+}
+
+func TestSettingsSaved_WriteErrorStaysInline(t *testing.T) {
+	m := newTestModel()
+	m.overlayStack.Push(overlay.NewConfigSettingsOverlay(m.editor, m.config, m.configPath()))
+
+	saved := *m.config
+	saved.CLITool = "opencode"
+	result, _ := m.Update(overlay.SettingsSavedMsg{Config: &saved, Err: errors.New("permission denied")})
+	m = result.(Model)
+
+	settings, ok := m.overlayStack.Current().(*overlay.SettingsOverlay)
+	if !ok {
+		t.Fatalf("Expected settings to stay open, got %T", m.overlayStack.Current())
+	}
+	if errs := settings.Errors(); len(errs) != 1 || errs[0] != "permission denied" {
+		t.Errorf("Expected the write error inline, got %v", errs)
+	}
+	if m.config.CLITool != "claude" {
+		t.Errorf("Expected an unsaved config not to apply, got %q", m.config.CLITool)
+	}
+}
+
+func TestExportColumns_RespectsFilterAndExpandsDone(t *testing.T) {
+	m := newTestModel()
+	m.showDone = false
+	m.editor.TogglePriorityFilter(domain.P3)
+	m.editor.TogglePriorityFilter(domain.P2)
+
+	columns := m.exportColumns()
+	var ids []string
+	for _, col := range columns {
+		if col.Collapsed {
+			t.Errorf("Expected %s expanded for export", col.Title)
+		}
+		for _, task := range col.Tasks {
+			ids = append(ids, task.ID)
+		}
+	}
+	// az-1 is P2 and open; az-5 is P3 in the collapsed Done column
+	if !slices.Equal(ids, []string{"az-1", "az-5"}) {
+		t.Errorf("Expected the filtered tasks az-1 and az-5, got %v", ids)
+	}
+}
+
+func TestOrchestrationOverlay_CountsRunningSessions(t *testing.T) {
+	m := newTestModel()
+	m.sessions["az-3"] = &domain.Session{BeadID: "az-3", State: domain.SessionBusy, Worktree: "/tmp/project-az-3"}
+
+	m.openOrchestrationOverlay()
+
+	orch, ok := m.overlayStack.Current().(*overlay.OrchestrationOverlay)
+	if !ok {
+		t.Fatalf("Expected the orchestration overlay, got %T", m.overlayStack.Current())
+	}
+	view := orch.View()
+	if !strings.Contains(view, "Parallelism: 1 active") {
+		t.Errorf("Expected az-3's session counted as active, got:\n%s", view)
+	}
+	if !strings.Contains(view, "az-3") {
+		t.Errorf("Expected az-3's session listed, got:\n%s", view)
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/ui/board"
+)
+
+// handleMouse moves the board cursor with the mouse. A click selects the
+// card under the pointer, and a click on the card that is already selected,
+// or a right click, opens its action menu. The wheel moves through the
+// column under the pointer, which scrolls it. The mouse only drives the
+// keyboard cursor, so keyboard navigation carries on from wherever it left
+// it. While a modal is open, the wheel scrolls it if it is taller than the
+// screen. Otherwise mouse input is ignored outside normal mode and in the
+// compact view.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	if !m.overlayStack.IsEmpty() {
+		if limit := m.overlayScrollLimit(); limit > 0 {
+			switch msg.Button {
+			case tea.MouseButtonWheelUp:
+				m.overlayStack.Scroll(-1, limit)
+			case tea.MouseButtonWheelDown:
+				m.overlayStack.Scroll(1, limit)
+			}
+		}
+		return m, nil
+	}
+	if !m.editor.IsNormal() || m.viewMode != ViewModeBoard || m.loading {
+		return m, nil
+	}
+
+	columns := m.buildColumns()
+	m.nav.ScrollIntoView(columns, m.height-1)
+	pos := m.nav.GetPosition(columns)
+	cursor := board.Cursor{Column: pos.Column, Task: pos.Task}
+
+	// The board takes the full height above the status bar
+	column, task, ok := board.CardAt(columns, cursor, m.focusedColumn(cursor), m.width, m.height-1, msg.X, msg.Y)
+	if !ok {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		if column != pos.Column {
+			m.nav.GetCursor().JumpToColumn(columns, column)
+		}
+		if msg.Button == tea.MouseButtonWheelUp {
+			m.nav.MoveUp(columns)
+		} else {
+			m.nav.MoveDown(columns)
+		}
+		return m, nil
+
+	case tea.MouseButtonLeft, tea.MouseButtonRight:
+		if task < 0 {
+			// A header or empty space selects the column
+			if column != pos.Column {
+				m.nav.GetCursor().JumpToColumn(columns, column)
+			}
+			return m, nil
+		}
+
+		clicked := columns[column].Tasks[task]
+		alreadySelected := column == pos.Column && task == pos.Task
+		m.nav.SelectTask(clicked.ID, column)
+		if alreadySelected || msg.Button == tea.MouseButtonRight {
+			return m, m.overlayStack.Push(m.newActionMenu(clicked, clicked.Session))
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
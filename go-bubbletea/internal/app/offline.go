@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+)
+
+// queueChangeMsg asks to hold a change in the offline queue instead of
+// making it now
+type queueChangeMsg struct {
+	change beads.PendingChange
+}
+
+// changesReplayedMsg reports replaying the offline queue once back online
+type changesReplayedMsg struct {
+	results []beads.ReplayResult
+	err     error // Saving the queue failed part way
+}
+
+// queueChange adds a change made while offline to the queue and saves it,
+// so a restart doesn't lose it. A status change shows on the board at once,
+// so that moving the task again queues the next move rather than the same
+// one.
+func (m *Model) queueChange(change beads.PendingChange) {
+	if err := m.pendingChanges.Add(change); err != nil {
+		m.logger.Warn("failed to save offline queue", "error", err)
+	}
+	if change.Kind == beads.PendingStatus {
+		m.applyPendingChanges()
+		m.resolveCursor()
+	}
+
+	m.addToast(Toast{
+		Level:   ToastInfo,
+		Message: fmt.Sprintf("Offline: will %s when back online (%d pending)", change.Describe(), m.pendingChanges.Len()),
+	})
+}
+
+// applyPendingChanges shows the queued status changes on the loaded tasks,
+// which bd doesn't know about yet
+func (m *Model) applyPendingChanges() {
+	for _, change := range m.pendingChanges.Changes() {
+		if change.Kind != beads.PendingStatus {
+			continue
+		}
+		for i := range m.tasks {
+			if m.tasks[i].ID == change.TaskID && m.tasks[i].Status == change.From {
+				m.tasks[i].Status = change.To
+			}
+		}
+	}
+}
+
+// replayChangesCmd makes the queued changes in the order they were queued.
+// Only one replay runs at a time, so that a change isn't made twice.
+func (m *Model) replayChangesCmd() tea.Cmd {
+	if m.replayingChanges || m.pendingChanges.Len() == 0 {
+		return nil
+	}
+	m.replayingChanges = true
+
+	client := m.beadsClient
+	queue := m.pendingChanges
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		results, err := beads.ReplayPending(ctx, client, queue)
+		return changesReplayedMsg{results: results, err: err}
+	}
+}
+
+// handleChangesReplayed reports each replayed change that failed. The
+// replay already dropped the changes it made from the queue.
+func (m *Model) handleChangesReplayed(msg changesReplayedMsg) tea.Cmd {
+	m.replayingChanges = false
+	if msg.err != nil {
+		m.logger.Warn("failed to save offline queue", "error", msg.err)
+	}
+
+	synced := 0
+	for _, result := range msg.results {
+		if result.Err != nil {
+			message := fmt.Sprintf("Couldn't %s: %v", result.Change.Describe(), result.Err)
+			if result.Kept {
+				message += " (will retry)"
+			}
+			m.addToast(Toast{
+				Level:   ToastError,
+				Message: message,
+			})
+			continue
+		}
+		synced++
+	}
+	if synced > 0 {
+		m.addToast(Toast{
+			Level:   ToastSuccess,
+			Message: fmt.Sprintf("Synced %d offline changes", synced),
+		})
+	}
+
+	return m.loadBeadsCmd()
+}
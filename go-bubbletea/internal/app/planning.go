@@ -0,0 +1,205 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+	"github.com/riordanpawley/azedarach/internal/services/diagnostics"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
+	"github.com/riordanpawley/azedarach/internal/ui/overlay"
+)
+
+// planningTimeout bounds each step of a planning run: drafting and reviewing
+// the plan, then creating its beads
+const planningTimeout = 10 * time.Minute
+
+// planningResultMsg reports a step of a planning run finishing, with the
+// planning state it left behind
+type planningResultMsg struct {
+	state domain.PlanningState
+	beads []domain.Task // Created, once the plan was approved
+}
+
+// planningDraftMsg carries the plan draft saved for the project, if any,
+// read when the planning overlay opens
+type planningDraftMsg struct {
+	draft *planning.Draft
+}
+
+// openPlanning opens the planning overlay, or explains how to set planning
+// up when there's no API key to plan with. A plan saved by an earlier run
+// is restored into the overlay once it has been read.
+func (m *Model) openPlanning() tea.Cmd {
+	if !m.planningAvailable {
+		return m.overlayStack.Push(overlay.NewPlanningSetupOverlay())
+	}
+	return tea.Batch(
+		m.overlayStack.Push(overlay.NewPlanningOverlay()),
+		m.loadPlanningDraftCmd(),
+	)
+}
+
+// planningDraftPath is where the project's in-progress plan is saved
+func (m Model) planningDraftPath() string {
+	return planning.DefaultDraftPath(m.projectDir())
+}
+
+// loadPlanningDraftCmd reads the project's saved plan draft
+func (m Model) loadPlanningDraftCmd() tea.Cmd {
+	path := m.planningDraftPath()
+	logger := m.logger
+	return func() tea.Msg {
+		draft, err := planning.LoadDraft(path)
+		if err != nil {
+			logger.Warn("ignoring unreadable plan draft", "path", path, "error", err)
+			return nil
+		}
+		if draft == nil {
+			return nil
+		}
+		return planningDraftMsg{draft: draft}
+	}
+}
+
+// handlePlanningDraft resumes a saved plan in the planning overlay, unless
+// the overlay was closed or a new run started while the draft was read
+func (m Model) handlePlanningDraft(msg planningDraftMsg) (tea.Model, tea.Cmd) {
+	planner, ok := m.overlayStack.Current().(*overlay.PlanningOverlay)
+	if !ok || !planner.Idle() {
+		return m, nil
+	}
+
+	svc, err := m.newPlanner()
+	if err != nil {
+		return m, nil
+	}
+	svc.RestoreDraft(msg.draft)
+	m.planner = svc
+	planner.UpdateState(svc.GetState())
+	m.addToast(Toast{
+		Level:   ToastInfo,
+		Message: fmt.Sprintf("Resumed the plan saved %s", msg.draft.SavedAt.Format(time.DateTime)),
+	})
+	return m, nil
+}
+
+// discardPlanningCmd forgets the plan under review and deletes its draft
+func (m *Model) discardPlanningCmd() tea.Cmd {
+	m.planner = nil
+	path := m.planningDraftPath()
+	logger := m.logger
+	return func() tea.Msg {
+		if err := planning.RemoveDraft(path); err != nil {
+			logger.Warn("failed to remove plan draft", "path", path, "error", err)
+		}
+		return nil
+	}
+}
+
+// newPlanner creates a planning service that saves its plan as the
+// project's draft while it runs
+func (m Model) newPlanner() (*planning.Service, error) {
+	svc, err := planning.NewService(
+		&http.Client{Timeout: 2 * time.Minute},
+		beads.PlanningClient{Client: m.beadsClient},
+		m.logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	svc.SetDraftPath(m.planningDraftPath())
+	return svc, nil
+}
+
+// startPlanningCmd drafts and reviews a plan for description, stopping once
+// it awaits the user's approval. Offline, the run fails straight away so
+// that the overlay doesn't wait on a plan that will never come.
+func (m *Model) startPlanningCmd(description string) tea.Cmd {
+	if !m.planningAvailable {
+		return nil
+	}
+	if !m.isOnline && diagnostics.RequiresNetwork(diagnostics.FeatureClaudeAPI) {
+		err := fmt.Errorf("you're offline - %s needs a network connection", diagnostics.FeatureClaudeAPI)
+		return func() tea.Msg {
+			return planningResultMsg{state: failedPlanningState(domain.PlanningState{}, err)}
+		}
+	}
+
+	svc, err := m.newPlanner()
+	if err != nil {
+		return func() tea.Msg {
+			return planningResultMsg{state: failedPlanningState(domain.PlanningState{}, err)}
+		}
+	}
+	m.planner = svc
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), planningTimeout)
+		defer cancel()
+
+		_, err := svc.PlanFeature(ctx, description)
+		state := svc.GetState()
+		if err != nil {
+			state = failedPlanningState(state, err)
+		}
+		return planningResultMsg{state: state}
+	}
+}
+
+// confirmPlanningCmd creates the beads of the plan the user approved
+func (m Model) confirmPlanningCmd(plan *domain.Plan) tea.Cmd {
+	svc := m.planner
+	if svc == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), planningTimeout)
+		defer cancel()
+
+		created, err := svc.CreateBeadsFromPlan(ctx, plan)
+		state := svc.GetState()
+		if err != nil {
+			state = failedPlanningState(state, err)
+		}
+		return planningResultMsg{state: state, beads: created}
+	}
+}
+
+// handlePlanningResult shows how a planning step went in the planning
+// overlay, if it's still open, and reloads the board once beads exist
+func (m Model) handlePlanningResult(msg planningResultMsg) (tea.Model, tea.Cmd) {
+	if planner, ok := m.overlayStack.Current().(*overlay.PlanningOverlay); ok {
+		planner.UpdateState(msg.state)
+	} else if msg.state.Status == domain.PlanningErrorStatus {
+		m.addToast(Toast{
+			Level:   ToastError,
+			Message: fmt.Sprintf("Planning failed: %s", msg.state.Error),
+		})
+	}
+
+	if len(msg.beads) == 0 {
+		return m, nil
+	}
+	m.planner = nil
+	m.addToast(Toast{
+		Level:   ToastSuccess,
+		Message: fmt.Sprintf("Created %d beads from the plan", len(msg.beads)),
+	})
+	return m, m.loadBeadsCmd()
+}
+
+// failedPlanningState marks state as failed with err, unless the planning
+// service already did
+func failedPlanningState(state domain.PlanningState, err error) domain.PlanningState {
+	if state.Status != domain.PlanningErrorStatus {
+		state.Status = domain.PlanningErrorStatus
+		state.Error = err.Error()
+	}
+	return state
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// sessionLimiter is a counting semaphore bounding how many sessions may be
+// starting or working at once. Slots are held per bead so that releasing
+// the same session twice frees only one slot. A limit of 0 is unlimited.
+type sessionLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	holders map[string]bool
+}
+
+// newSessionLimiter creates a limiter allowing limit concurrent sessions
+func newSessionLimiter(limit int) *sessionLimiter {
+	return &sessionLimiter{
+		limit:   limit,
+		holders: make(map[string]bool),
+	}
+}
+
+// TryAcquire takes a slot for beadID if one is free. A bead that already
+// holds a slot keeps it.
+func (l *sessionLimiter) TryAcquire(beadID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.holders[beadID] {
+		return true
+	}
+	if l.limit > 0 && len(l.holders) >= l.limit {
+		return false
+	}
+	l.holders[beadID] = true
+	return true
+}
+
+// Release frees the slot held by beadID, reporting whether it held one
+func (l *sessionLimiter) Release(beadID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.holders[beadID] {
+		return false
+	}
+	delete(l.holders, beadID)
+	return true
+}
+
+// Holds reports whether beadID holds a slot
+func (l *sessionLimiter) Holds(beadID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holders[beadID]
+}
+
+// Running returns how many slots are taken
+func (l *sessionLimiter) Running() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.holders)
+}
+
+// SetLimit changes how many sessions may hold a slot. Sessions already
+// holding one keep it when the limit drops.
+func (l *sessionLimiter) SetLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+}
+
+// isSettledState reports whether a session no longer needs its start slot:
+// it is waiting on the user, finished, or not running
+func isSettledState(state domain.SessionState) bool {
+	switch state {
+	case domain.SessionWaiting, domain.SessionDone, domain.SessionIdle,
+		domain.SessionError, domain.SessionPaused:
+		return true
+	}
+	return false
+}
+
+// startOrQueueSession starts a session for beadID when a slot is free.
+// Otherwise the bead is queued, shown with the queued state, and nil is
+// returned with queued set; it starts once another session settles. Queued
+// beads stay out of m.sessions, as they have no pane or worktree yet.
+// A bead that already has a session, or is queued or starting, is left
+// alone: nil is returned without queueing it again.
+func (m *Model) startOrQueueSession(beadID string) (cmd tea.Cmd, queued bool) {
+	if m.sessions[beadID] != nil || m.isQueued(beadID) || m.sessionLimiter.Holds(beadID) {
+		return nil, false
+	}
+	if m.sessionLimiter.TryAcquire(beadID) {
+		return m.startSessionCmd(beadID), false
+	}
+
+	m.startQueue = append(m.startQueue, beadID)
+	return nil, true
+}
+
+// isQueued reports whether beadID is waiting for a session slot
+func (m Model) isQueued(beadID string) bool {
+	return slices.Contains(m.startQueue, beadID)
+}
+
+// requestSessionStart is startOrQueueSession with a toast when the start
+// has to wait
+func (m *Model) requestSessionStart(beadID string) tea.Cmd {
+	cmd, queued := m.startOrQueueSession(beadID)
+	if queued {
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: fmt.Sprintf("Session queued: %s (%d running)", beadID, m.sessionLimiter.Running()),
+		})
+	}
+	return cmd
+}
+
+// releaseSessionSlot frees beadID's slot and starts queued sessions that
+// now fit
+func (m *Model) releaseSessionSlot(beadID string) tea.Cmd {
+	m.sessionLimiter.Release(beadID)
+	return m.drainStartQueue()
+}
+
+// drainStartQueue starts queued sessions while slots are free
+func (m *Model) drainStartQueue() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.startQueue) > 0 {
+		beadID := m.startQueue[0]
+		if !m.sessionLimiter.TryAcquire(beadID) {
+			break
+		}
+		m.startQueue = m.startQueue[1:]
+		cmds = append(cmds, m.startSessionCmd(beadID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// dequeueSession removes beadID from the start queue, reporting whether it
+// was queued
+func (m *Model) dequeueSession(beadID string) bool {
+	for i, id := range m.startQueue {
+		if id == beadID {
+			m.startQueue = append(m.startQueue[:i], m.startQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+)
+
+// undoLimit is how many actions ctrl+z can walk back
+const undoLimit = 20
+
+// undoKind is the kind of change an undoAction reverses
+type undoKind int
+
+const (
+	undoStatus undoKind = iota // A status change, reversed by restoring the old status
+	undoDelete                 // A delete, reversed by recreating the task
+)
+
+// undoAction records a change to a task with what is needed to reverse it
+type undoAction struct {
+	kind      undoKind
+	taskID    string
+	oldStatus domain.Status // Status before a status change
+	task      domain.Task   // Copy of a deleted task
+}
+
+// undoHistory is a bounded stack of reversible actions, newest last.
+// Pushing past undoLimit drops the oldest.
+type undoHistory []undoAction
+
+// push records action, dropping the oldest action when full
+func (h undoHistory) push(action undoAction) undoHistory {
+	h = append(h, action)
+	if len(h) > undoLimit {
+		h = h[len(h)-undoLimit:]
+	}
+	return h
+}
+
+// pop removes and returns the newest action
+func (h undoHistory) pop() (undoHistory, undoAction, bool) {
+	if len(h) == 0 {
+		return h, undoAction{}, false
+	}
+	return h[:len(h)-1], h[len(h)-1], true
+}
+
+// undoResultMsg reports the result of reversing an action. newID is the
+// ID a deleted task was recreated under.
+type undoResultMsg struct {
+	action undoAction
+	newID  string
+	err    error
+}
+
+// undoLast reverses the newest recorded action
+func (m *Model) undoLast() tea.Cmd {
+	history, action, ok := m.undoHistory.pop()
+	if !ok {
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: "Nothing to undo",
+		})
+		return nil
+	}
+	m.undoHistory = history
+	return m.undoCmd(action)
+}
+
+// undoCmd reverses action through the beads client. bd can't restore a
+// deleted bead, so a delete is undone by creating a copy with the same
+// details and status, under a new ID.
+func (m Model) undoCmd(action undoAction) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		switch action.kind {
+		case undoStatus:
+			err := m.beadsClient.Update(ctx, action.taskID, action.oldStatus)
+			return undoResultMsg{action: action, err: err}
+
+		case undoDelete:
+			task := action.task
+			id, err := m.beadsClient.Create(ctx, beads.CreateTaskParams{
+				Title:       task.Title,
+				Description: task.Description,
+				Type:        task.Type,
+				Priority:    task.Priority,
+				ParentID:    task.ParentID,
+			})
+			if err != nil {
+				return undoResultMsg{action: action, err: err}
+			}
+			if task.Status != domain.StatusOpen {
+				err = m.beadsClient.Update(ctx, id, task.Status)
+			}
+			return undoResultMsg{action: action, newID: id, err: err}
+		}
+
+		return undoResultMsg{action: action, err: fmt.Errorf("unknown undo action")}
+	}
+}
+
+// undoMessage describes a successful undo for a toast
+func undoMessage(msg undoResultMsg) string {
+	if msg.action.kind == undoDelete {
+		return fmt.Sprintf("Restored %s as %s", msg.action.taskID, msg.newID)
+	}
+	return fmt.Sprintf("Moved %s back to %s", msg.action.taskID, msg.action.oldStatus)
+}
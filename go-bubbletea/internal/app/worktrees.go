@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+	"github.com/riordanpawley/azedarach/internal/ui/overlay"
+)
+
+// staleWorktreesShown is how many stale worktrees the removal prompt names
+const staleWorktreesShown = 5
+
+// staleWorktreesMsg carries the worktrees found by the startup sweep
+type staleWorktreesMsg struct {
+	worktrees []git.Worktree
+	err       error
+}
+
+// worktreesRemovedMsg reports removing stale worktrees
+type worktreesRemovedMsg struct {
+	removed []string // Bead IDs
+	kept    []string // Branches kept as they weren't merged
+	failed  int
+}
+
+// findStaleWorktreesCmd looks for worktrees left behind by beads that are
+// closed or gone, with no commits in config.Worktree.KeepDays. A KeepDays
+// of 0 or less turns the sweep off.
+func (m Model) findStaleWorktreesCmd() tea.Cmd {
+	keepDays := m.config.Worktree.KeepDays
+	if keepDays <= 0 || m.worktreeManager == nil {
+		return nil
+	}
+
+	// Worktrees of open beads, and of any bead with a session, are in use
+	open := make(map[string]bool, len(m.tasks))
+	custom := m.customStatuses()
+	for _, task := range m.tasks {
+		if task.Status.Base(custom) != domain.StatusDone {
+			open[task.ID] = true
+		}
+	}
+	for beadID := range m.sessions {
+		open[beadID] = true
+	}
+
+	manager := m.worktreeManager
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		worktrees, err := manager.FindStale(ctx, open, time.Duration(keepDays)*24*time.Hour, time.Now())
+		return staleWorktreesMsg{worktrees: worktrees, err: err}
+	}
+}
+
+// handleStaleWorktrees removes the worktrees the sweep found when
+// config.Worktree.AutoCleanup is on, and asks first otherwise
+func (m *Model) handleStaleWorktrees(msg staleWorktreesMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Warn("failed to look for stale worktrees", "error", msg.err)
+		return nil
+	}
+	if len(msg.worktrees) == 0 {
+		return nil
+	}
+	if m.config.Worktree.AutoCleanup {
+		return m.removeWorktreesCmd(msg.worktrees)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d worktrees belong to closed beads and have no commits in %d days:\n", len(msg.worktrees), m.config.Worktree.KeepDays)
+	for i, wt := range msg.worktrees {
+		if i == staleWorktreesShown {
+			fmt.Fprintf(&b, "  …and %d more\n", len(msg.worktrees)-i)
+			break
+		}
+		fmt.Fprintf(&b, "  %s\n", wt.Path)
+	}
+	b.WriteString("Remove them? Branches with unmerged commits are kept.")
+
+	m.pendingStaleWorktrees = msg.worktrees
+	return m.overlayStack.Push(overlay.NewConfirmDialog("Stale Worktrees", b.String()))
+}
+
+// removeWorktreesCmd removes worktrees along with their branches, keeping
+// branches that aren't merged
+func (m Model) removeWorktreesCmd(worktrees []git.Worktree) tea.Cmd {
+	manager := m.worktreeManager
+	logger := m.logger
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var result worktreesRemovedMsg
+		for _, wt := range worktrees {
+			kept, err := manager.Remove(ctx, wt.BeadID)
+			if err != nil {
+				logger.Warn("failed to remove stale worktree", "path", wt.Path, "error", err)
+				result.failed++
+				continue
+			}
+			result.removed = append(result.removed, wt.BeadID)
+			if kept {
+				result.kept = append(result.kept, wt.Branch)
+			}
+		}
+		return result
+	}
+}
+
+// handleWorktreesRemoved reports the stale worktrees removed
+func (m *Model) handleWorktreesRemoved(msg worktreesRemovedMsg) {
+	if len(msg.removed) > 0 {
+		m.addToast(Toast{
+			Level:   ToastInfo,
+			Message: fmt.Sprintf("Removed %d stale worktrees: %s", len(msg.removed), strings.Join(msg.removed, ", ")),
+		})
+	}
+	if len(msg.kept) > 0 {
+		m.addToast(Toast{
+			Level:   ToastWarning,
+			Message: fmt.Sprintf("Kept %d unmerged branches: %s", len(msg.kept), strings.Join(msg.kept, ", ")),
+		})
+	}
+	if msg.failed > 0 {
+		m.addToast(Toast{
+			Level:   ToastWarning,
+			Message: fmt.Sprintf("Couldn't remove %d stale worktrees; see the log", msg.failed),
+		})
+	}
+}
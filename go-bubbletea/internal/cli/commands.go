@@ -1,26 +1,35 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/server"
 	"github.com/riordanpawley/azedarach/internal/services/beads"
 	"github.com/riordanpawley/azedarach/internal/services/git"
-	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/services/multiplexer"
+	"github.com/riordanpawley/azedarach/internal/services/network"
+	"github.com/riordanpawley/azedarach/internal/ui/overlay"
 )
 
 // Dependencies holds all the services needed for CLI commands
 type Dependencies struct {
 	Config          *config.Config
 	BeadsClient     *beads.Client
-	TmuxClient      *tmux.Client
+	TmuxClient      multiplexer.Multiplexer
 	WorktreeManager *git.WorktreeManager
+	GitSync         *git.GitSyncService // Resolves the base branch
 	Logger          *slog.Logger
 }
 
@@ -31,10 +40,19 @@ func NewDependencies(cfg *config.Config) (*Dependencies, error) {
 	// Initialize beads client
 	beadsRunner := &beads.ExecRunner{}
 	beadsClient := beads.NewClient(beadsRunner, logger)
+	custom := make(domain.CustomStatuses)
+	for _, col := range cfg.Board.ColumnsOrDefault() {
+		if col.Base != "" {
+			custom[domain.Status(col.Status)] = domain.Status(col.Base)
+		}
+	}
+	beadsClient.SetCustomStatuses(custom)
 
-	// Initialize tmux client
-	tmuxRunner := &tmux.ExecRunner{}
-	tmuxClient := tmux.NewClient(tmuxRunner, logger)
+	// Initialize the session multiplexer (tmux unless configured otherwise)
+	tmuxClient, err := multiplexer.New(cfg.Session.Multiplexer, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize git worktree manager
 	repoDir, err := os.Getwd()
@@ -43,17 +61,29 @@ func NewDependencies(cfg *config.Config) (*Dependencies, error) {
 	}
 	gitRunner := git.NewExecRunner(repoDir)
 	worktreeManager := git.NewWorktreeManager(gitRunner, repoDir, logger)
+	worktreeManager.SetFullExistsCheck(cfg.Worktree.FullExistsCheck)
+	worktreeManager.SetLayout(cfg.Worktree.BasePath, cfg.Worktree.NameFormat)
+
+	networkChecker, err := network.NewStatusCheckerFor(cfg.Network.CheckMethod, cfg.Network.CheckURL)
+	if err != nil {
+		return nil, err
+	}
+
+	gitSync := git.NewGitSyncService(git.NewClient(gitRunner, logger), networkChecker, cfg, repoDir, logger)
+	gitSync.SetBaseBranch(config.ResolveBaseBranch(cfg, registryProject()))
 
 	return &Dependencies{
 		Config:          cfg,
 		BeadsClient:     beadsClient,
 		TmuxClient:      tmuxClient,
 		WorktreeManager: worktreeManager,
+		GitSync:         gitSync,
 		Logger:          logger,
 	}, nil
 }
 
-// StartCommand starts a Claude session for the given bead ID
+// StartCommand starts a session running the configured CLI tool for the
+// given bead ID
 func StartCommand(deps *Dependencies, beadID string) error {
 	ctx := context.Background()
 
@@ -80,25 +110,30 @@ func StartCommand(deps *Dependencies, beadID string) error {
 
 	fmt.Printf("Starting session for: %s - %s\n", task.ID, task.Title)
 
-	// Create worktree for the task
-	baseBranch := "main" // TODO: Make configurable
-	fmt.Printf("Creating worktree from branch: %s\n", baseBranch)
-	worktree, err := deps.WorktreeManager.Create(ctx, beadID, baseBranch)
-	if err != nil {
-		return fmt.Errorf("failed to create worktree: %w", err)
+	// Reuse the task's worktree if a previous session left one, as when
+	// restarting a session that died, or else create it
+	worktree, err := deps.WorktreeManager.Get(ctx, beadID)
+	if err == nil {
+		fmt.Printf("Using existing worktree: %s\n", worktree.Path)
+	} else {
+		baseBranch := deps.GitSync.BaseBranch(ctx)
+		fmt.Printf("Creating worktree from branch: %s\n", baseBranch)
+		worktree, err = deps.WorktreeManager.Create(ctx, beadID, baseBranch)
+		if err != nil {
+			return fmt.Errorf("failed to create worktree: %w", err)
+		}
+		fmt.Printf("Worktree created: %s\n", worktree.Path)
 	}
-	fmt.Printf("Worktree created: %s\n", worktree.Path)
 
 	// Create tmux session
-	fmt.Printf("Creating tmux session: %s\n", beadID)
+	fmt.Printf("Creating %s session: %s\n", deps.Config.Session.Multiplexer, beadID)
 	err = deps.TmuxClient.NewSession(ctx, beadID, worktree.Path)
 	if err != nil {
-		return fmt.Errorf("failed to create tmux session: %w", err)
+		return fmt.Errorf("failed to create %s session: %w", deps.Config.Session.Multiplexer, err)
 	}
 
-	// Send Claude command to session
-	claudeCmd := "claude" // TODO: Make configurable or add more context
-	err = deps.TmuxClient.SendKeys(ctx, beadID, claudeCmd)
+	// Start the configured CLI tool in the session
+	err = deps.TmuxClient.SendKeys(ctx, beadID, resolveCLITool(deps.Config))
 	if err != nil {
 		return fmt.Errorf("failed to send keys: %w", err)
 	}
@@ -112,24 +147,32 @@ func StartCommand(deps *Dependencies, beadID string) error {
 
 	fmt.Printf("\n✓ Session started successfully\n")
 	fmt.Printf("  To attach: az attach %s\n", beadID)
-	fmt.Printf("  Or run:    tmux attach-session -t %s\n", beadID)
+	fmt.Printf("  Or run:    %s\n", deps.TmuxClient.AttachCommand(beadID))
 
 	return nil
 }
 
-// AttachCommand attaches to an existing tmux session
+// AttachCommand attaches to an existing tmux session. If the session has
+// died, it offers to start a new one first.
 func AttachCommand(deps *Dependencies, beadID string) error {
 	ctx := context.Background()
 
 	deps.Logger.Info("attaching to session", "bead_id", beadID)
 
 	// Check if session exists
-	exists, err := deps.TmuxClient.HasSession(ctx, beadID)
+	exists, err := deps.TmuxClient.SessionExists(ctx, beadID)
 	if err != nil {
 		return fmt.Errorf("failed to check session: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("session not found: %s (use 'az start %s' to create)", beadID, beadID)
+		question := fmt.Sprintf("Session %s is not running. Start it now?", beadID)
+		if !confirm(os.Stdin, os.Stdout, question) {
+			return fmt.Errorf("session not found: %s (use 'az start %s' to create)", beadID, beadID)
+		}
+		if err := StartCommand(deps, beadID); err != nil {
+			return err
+		}
+		fmt.Println()
 	}
 
 	fmt.Printf("Attaching to session: %s\n", beadID)
@@ -144,6 +187,18 @@ func AttachCommand(deps *Dependencies, beadID string) error {
 	return nil
 }
 
+// confirm asks a yes/no question on out, reading the answer from in.
+// Anything but y or yes, including end of input, is no.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
 // KillCommand kills a Claude session
 func KillCommand(deps *Dependencies, beadID string) error {
 	ctx := context.Background()
@@ -249,9 +304,109 @@ func StatusCommand(deps *Dependencies, beadID string) error {
 	return nil
 }
 
+// KeysCommand exports the keybinding cheat sheet. With no path it prints a
+// plain-text sheet to stdout; otherwise the format follows the file extension.
+func KeysCommand(path string) error {
+	categories := overlay.KeyCategories()
+
+	if path == "" {
+		fmt.Print(overlay.RenderCheatSheet(categories, overlay.CheatSheetText))
+		return nil
+	}
+
+	sheet := overlay.RenderCheatSheet(categories, overlay.CheatSheetFormatForPath(path))
+	if err := os.WriteFile(path, []byte(sheet), 0644); err != nil {
+		return fmt.Errorf("failed to write cheat sheet: %w", err)
+	}
+
+	fmt.Printf("Keybindings exported to %s\n", path)
+	return nil
+}
+
+// DefaultServeAddr is where `az serve` listens when no address is given
+const DefaultServeAddr = "127.0.0.1:7777"
+
+// resolveCLITool picks the CLI tool for the project in the working
+// directory, honouring a per-project override in the registry
+func resolveCLITool(cfg *config.Config) string {
+	return config.ResolveCLITool(cfg, registryProject())
+}
+
+// registryProject returns the registry entry of the project in the working
+// directory, or nil if it isn't registered
+func registryProject() *config.Project {
+	registry, err := config.LoadProjectsRegistry()
+	if err != nil {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	return registry.FindByPath(cwd)
+}
+
+// serveTokenPath is where `az serve` writes the token editor integrations
+// send to start and stop sessions
+func serveTokenPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".azedarach", "serve-token")
+}
+
+// ServeCommand runs the editor integration API until interrupted. addr is a
+// loopback host:port or "unix:<path>" for a unix socket. Starting and
+// stopping sessions needs the token written to serveTokenPath, readable
+// only by the user.
+func ServeCommand(deps *Dependencies, addr string) error {
+	token, err := server.NewToken()
+	if err != nil {
+		return err
+	}
+	tokenPath := serveTokenPath()
+	if err := os.MkdirAll(filepath.Dir(tokenPath), 0700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(tokenPath, []byte(token+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write token: %w", err)
+	}
+	defer os.Remove(tokenPath)
+
+	ln, err := server.Listen(addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	srv := server.New(server.Dependencies{
+		Beads:      deps.BeadsClient,
+		Tmux:       deps.TmuxClient,
+		Worktrees:  deps.WorktreeManager,
+		Diff:       worktreeDiffer{logger: deps.Logger},
+		CLITool:    resolveCLITool(deps.Config),
+		BaseBranch: deps.GitSync.BaseBranch(ctx),
+		Token:      token,
+		Logger:     deps.Logger,
+	})
+
+	fmt.Printf("Serving editor API on %s (Ctrl+C to stop)\n", ln.Addr())
+	fmt.Printf("Session start and stop need \"Authorization: Bearer <token>\" with the token in %s\n", tokenPath)
+	return srv.Serve(ctx, ln)
+}
+
+// worktreeDiffer runs git diff inside the requested worktree
+type worktreeDiffer struct {
+	logger *slog.Logger
+}
+
+func (d worktreeDiffer) Diff(ctx context.Context, worktree string) (string, error) {
+	return git.NewClient(git.NewExecRunner(worktree), d.logger).Diff(ctx, worktree)
+}
+
 // PrintUsage prints CLI usage information
 func PrintUsage() {
-	usage := `Usage: az [command] [arguments]
+	usage := `Usage: az [--debug] [command] [arguments]
 
 Commands:
   (no command)         Start the Azedarach TUI
@@ -259,8 +414,25 @@ Commands:
   attach <bead-id>     Attach to an existing session
   kill <bead-id>       Kill a session
   status [bead-id]     Show session status (all or specific bead)
+  keys [file]          Export keybinding cheat sheet (.md for markdown)
+  init <git-url> [dir] Clone a repository and register it as a project
+                       (--beads also runs 'bd init' in the clone)
+  serve [addr]         Serve a local API for editor integrations
+                       (default 127.0.0.1:7777, or unix:<path> for a socket;
+                       session actions need the token in ~/.azedarach/serve-token)
+  plan "<feature>"     Plan a feature with AI and create its beads
+                       (--dry-run prints the plan JSON instead)
+  export               Print the board grouped by column
+                       (--format md|json; filter with --status, --priority,
+                       --type and --search)
+  import <file>        Create beads from a markdown or JSON backlog, or a
+                       board exported with --format json
+                       (--dry-run lists them in creation order instead)
   help                 Show this help message
 
+Options:
+  --debug              Log at debug level to <session.logDir>/azedarach.log
+
 Examples:
   az                   # Start TUI
   az start az-123      # Start session for bead az-123
@@ -268,6 +440,12 @@ Examples:
   az kill az-123       # Kill az-123's session
   az status            # Show all active sessions
   az status az-123     # Show status for az-123
+  az keys keys.md      # Export keybindings as markdown
+  az init git@github.com:me/app.git --beads  # Clone, register and set up beads
+  az serve unix:/tmp/az.sock  # Serve the editor API on a unix socket
+  az plan "Add OAuth login" --dry-run  # Preview a plan without creating beads
+  az export --status in_progress > standup.md  # Share what's in progress
+  az import backlog.md --dry-run  # Preview the beads a backlog would create
 
 For more information, see: https://github.com/riordanpawley/azedarach
 `
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/ui/board"
+)
+
+// exportTimeout bounds listing the beads to export
+const exportTimeout = 30 * time.Second
+
+// ExportOptions configures `az export`. Values within a filter OR together,
+// and the filters AND, as in the TUI's filter menu.
+type ExportOptions struct {
+	Format     string   // "md" (the default) or "json"
+	Statuses   []string // Only tasks in these statuses
+	Priorities []string // Only tasks with these priorities, P0-P4 or 0-4
+	Types      []string // Only tasks of these types
+	Search     string   // Only tasks whose title, ID, description or labels contain this
+}
+
+// ExportCommand prints the board, grouped by column, to stdout
+func ExportCommand(deps *Dependencies, opts ExportOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	tasks, err := deps.BeadsClient.List(ctx)
+	if err != nil {
+		return err
+	}
+	return writeExport(os.Stdout, deps.Config, tasks, opts)
+}
+
+// writeExport renders tasks on the board cfg describes, filtered by opts,
+// to out
+func writeExport(out io.Writer, cfg *config.Config, tasks []domain.Task, opts ExportOptions) error {
+	format := board.ExportMarkdown
+	if opts.Format != "" {
+		var err error
+		if format, err = board.ParseExportFormat(opts.Format); err != nil {
+			return err
+		}
+	}
+
+	filter, err := opts.filter()
+	if err != nil {
+		return err
+	}
+	tasks = filter.Apply(tasks)
+
+	// Most urgent first, as there's no session to sort by
+	sort := &domain.Sort{Field: domain.SortByPriority, Order: domain.SortAsc}
+	var columns []board.Column
+	for _, col := range cfg.Board.ColumnsOrDefault() {
+		status := domain.Status(col.Status)
+		var inColumn []domain.Task
+		for _, task := range tasks {
+			if task.Status == status {
+				inColumn = append(inColumn, task)
+			}
+		}
+		columns = append(columns, board.Column{Title: col.Title, Status: status, Tasks: sort.Apply(inColumn)})
+	}
+
+	doc, err := board.Export(columns, format)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, doc)
+	return err
+}
+
+// filter builds the filter the options describe
+func (o ExportOptions) filter() (*domain.Filter, error) {
+	filter := domain.NewFilter()
+	for _, status := range o.Statuses {
+		filter.Status[domain.Status(status)] = true
+	}
+	for _, value := range o.Priorities {
+		priority, err := domain.ParsePriority(value)
+		if err != nil {
+			return nil, err
+		}
+		filter.Priority[priority] = true
+	}
+	for _, taskType := range o.Types {
+		filter.Type[domain.TaskType(taskType)] = true
+	}
+	filter.SearchQuery = o.Search
+	return filter, nil
+}
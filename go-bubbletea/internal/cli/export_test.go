@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportTasks = []domain.Task{
+	{ID: "az-1", Title: "Low priority chore", Status: domain.StatusOpen, Priority: domain.P3, Type: domain.TypeChore},
+	{ID: "az-2", Title: "Urgent bug", Status: domain.StatusOpen, Priority: domain.P0, Type: domain.TypeBug},
+	{ID: "az-3", Title: "Review me", Status: "review", Priority: domain.P2, Type: domain.TypeTask},
+	{ID: "az-4", Title: "In flight", Status: domain.StatusInProgress, Priority: domain.P1, Type: domain.TypeFeature},
+}
+
+func TestWriteExport_GroupsByConfiguredColumns(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Board.Columns = []config.ColumnConfig{
+		{Title: "Todo", Status: "open"},
+		{Title: "Review", Status: "review", Base: "in_progress"},
+	}
+
+	var out strings.Builder
+	require.NoError(t, writeExport(&out, cfg, exportTasks, ExportOptions{}))
+	doc := out.String()
+
+	assert.Contains(t, doc, "## Todo (2)")
+	assert.Contains(t, doc, "## Review (1)")
+	assert.NotContains(t, doc, "az-4", "tasks in no column are left out")
+	assert.Less(t, strings.Index(doc, "az-2"), strings.Index(doc, "az-1"), "most urgent first")
+}
+
+func TestWriteExport_Filters(t *testing.T) {
+	var out strings.Builder
+	opts := ExportOptions{Format: "json", Priorities: []string{"P0", "1"}}
+	require.NoError(t, writeExport(&out, config.DefaultConfig(), exportTasks, opts))
+	doc := out.String()
+
+	assert.Contains(t, doc, `"id": "az-2"`)
+	assert.Contains(t, doc, `"id": "az-4"`)
+	assert.NotContains(t, doc, `"id": "az-1"`)
+
+	out.Reset()
+	require.NoError(t, writeExport(&out, config.DefaultConfig(), exportTasks, ExportOptions{Search: "urgent"}))
+	assert.Contains(t, out.String(), "az-2")
+	assert.NotContains(t, out.String(), "az-4")
+}
+
+func TestWriteExport_RejectsBadOptions(t *testing.T) {
+	var out strings.Builder
+	assert.ErrorContains(t, writeExport(&out, config.DefaultConfig(), exportTasks, ExportOptions{Format: "csv"}), "unknown export format")
+	assert.ErrorContains(t, writeExport(&out, config.DefaultConfig(), exportTasks, ExportOptions{Priorities: []string{"P9"}}), "invalid priority")
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
+)
+
+// importTimeout bounds creating every bead of a backlog
+const importTimeout = 5 * time.Minute
+
+// ImportOptions configures `az import`
+type ImportOptions struct {
+	Path   string // Backlog file: .json, or markdown otherwise
+	DryRun bool   // Print the tasks in creation order instead of creating beads
+}
+
+// ImportCommand creates beads for the tasks in a backlog file. As with
+// `az plan`, stdout only carries the result: the created bead IDs, or the
+// creation order with DryRun.
+func ImportCommand(deps *Dependencies, opts ImportOptions) error {
+	data, err := os.ReadFile(opts.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read backlog: %w", err)
+	}
+	tasks, err := planning.ParseBacklog(opts.Path, data)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	return runImport(ctx, beads.PlanningClient{Client: deps.BeadsClient}, tasks, opts, deps.Logger, os.Stdout, os.Stderr)
+}
+
+// runImport creates tasks with client, or lists them in creation order with
+// DryRun, writing the result to out and dropped dependencies to progress
+func runImport(ctx context.Context, client planning.BeadsClient, tasks []domain.PlannedTask, opts ImportOptions, logger *slog.Logger, out, progress io.Writer) error {
+	ordered, dropped := planning.BacklogOrder(tasks)
+	for _, dep := range dropped {
+		fmt.Fprintf(progress, "! Dropped dependency %s -> %s to break a cycle\n", dep.TaskID, dep.DependsOn)
+	}
+
+	if opts.DryRun {
+		for i, task := range ordered {
+			line := fmt.Sprintf("%d. [%s %s] %s", i+1, domain.Priority(task.Priority), task.Type, task.Title)
+			if len(task.DependsOn) > 0 {
+				line += " (after " + strings.Join(task.DependsOn, ", ") + ")"
+			}
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+
+	created, err := planning.ImportBacklog(ctx, client, ordered, logger)
+	for _, task := range created {
+		fmt.Fprintln(out, task.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("created %d of %d beads: %w", len(created), len(ordered), err)
+	}
+	fmt.Fprintf(progress, "\n✓ Created %d beads\n", len(created))
+	return nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var importTasks = []domain.PlannedTask{
+	{ID: "deploy", Title: "Deploy", Type: domain.TypeChore, Priority: 1, DependsOn: []string{"api"}},
+	{ID: "api", Title: "API", Type: domain.TypeFeature, Priority: 2},
+}
+
+func TestRunImport_DryRunListsCreationOrder(t *testing.T) {
+	beadsClient := &recordingPlanBeads{}
+	var out, progress bytes.Buffer
+
+	err := runImport(context.Background(), beadsClient, importTasks, ImportOptions{DryRun: true}, slog.New(slog.NewTextHandler(io.Discard, nil)), &out, &progress)
+
+	require.NoError(t, err)
+	assert.Empty(t, beadsClient.created, "dry run creates nothing")
+	assert.Equal(t, "1. [P2 feature] API\n2. [P1 chore] Deploy (after api)\n", out.String())
+}
+
+func TestRunImport_PrintsCreatedIDs(t *testing.T) {
+	beadsClient := &recordingPlanBeads{}
+	var out, progress bytes.Buffer
+
+	err := runImport(context.Background(), beadsClient, importTasks, ImportOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)), &out, &progress)
+
+	require.NoError(t, err)
+	assert.Equal(t, "az-1\naz-2\n", out.String())
+	assert.Contains(t, progress.String(), "Created 2 beads")
+}
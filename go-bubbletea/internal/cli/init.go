@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+)
+
+// InitOptions configures `az init`
+type InitOptions struct {
+	URL       string // Repository to clone
+	Dir       string // Destination directory; defaults to ./<repo-name>
+	InitBeads bool   // Run `bd init` in the new clone
+}
+
+// InitRunner runs an external command in a working directory
+type InitRunner interface {
+	Run(ctx context.Context, dir string, name string, args ...string) error
+}
+
+// ExecInitRunner runs commands with os/exec, streaming their output
+type ExecInitRunner struct{}
+
+// Run executes the command in dir, attached to the terminal
+func (ExecInitRunner) Run(ctx context.Context, dir string, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// InitCommand clones a repository, registers it as a project and saves the
+// projects registry. The first registered project becomes the default.
+func InitCommand(opts InitOptions) error {
+	registry, err := config.LoadProjectsRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to load projects registry: %w", err)
+	}
+
+	project, err := InitProject(context.Background(), ExecInitRunner{}, registry, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := config.SaveProjectsRegistry(registry); err != nil {
+		return fmt.Errorf("failed to save projects registry: %w", err)
+	}
+
+	fmt.Printf("\n✓ Project %s registered at %s\n", project.Name, project.Path)
+	if registry.DefaultProject == project.Name {
+		fmt.Printf("  Set as default project\n")
+	}
+	fmt.Printf("  To start: cd %s && az\n", project.Path)
+
+	return nil
+}
+
+// InitProject clones opts.URL, optionally initializes beads in the clone and
+// adds it to registry. The registry is not saved.
+func InitProject(ctx context.Context, runner InitRunner, registry *config.ProjectsRegistry, opts InitOptions) (*config.Project, error) {
+	name := repoNameFromURL(opts.URL)
+	if name == "" {
+		return nil, fmt.Errorf("cannot derive a project name from %q", opts.URL)
+	}
+	if _, err := registry.Get(name); err == nil {
+		return nil, fmt.Errorf("project %s: %w", name, config.ErrDuplicateProject)
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = name
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve destination: %w", err)
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("destination already exists: %s", dir)
+	}
+
+	fmt.Printf("Cloning %s into %s\n", opts.URL, dir)
+	if err := runner.Run(ctx, filepath.Dir(dir), "git", "clone", opts.URL, dir); err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	if opts.InitBeads {
+		fmt.Printf("Initializing beads database\n")
+		if err := runner.Run(ctx, dir, "bd", "init"); err != nil {
+			return nil, fmt.Errorf("failed to initialize beads: %w", err)
+		}
+	}
+
+	if err := registry.Add(name, dir); err != nil {
+		return nil, fmt.Errorf("failed to register project: %w", err)
+	}
+
+	return registry.Get(name)
+}
+
+// repoNameFromURL returns the repository name from a clone URL, e.g.
+// "git@github.com:owner/repo.git" and "https://github.com/owner/repo" -> "repo"
+func repoNameFromURL(url string) string {
+	url = strings.TrimRight(url, "/")
+	url = strings.TrimSuffix(url, ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	return url
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloneRunner simulates git clone by creating the destination repo
+type fakeCloneRunner struct {
+	calls [][]string
+	err   error
+}
+
+func (r *fakeCloneRunner) Run(ctx context.Context, dir string, name string, args ...string) error {
+	r.calls = append(r.calls, append([]string{dir, name}, args...))
+	if r.err != nil {
+		return r.err
+	}
+	if name == "git" && len(args) == 3 && args[0] == "clone" {
+		return os.MkdirAll(filepath.Join(args[2], ".git"), 0755)
+	}
+	return nil
+}
+
+func TestInitProject_RegistersClone(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []config.Project
+		wantDefault string
+	}{
+		{
+			name:        "first project becomes default",
+			existing:    nil,
+			wantDefault: "widgets",
+		},
+		{
+			name:        "keeps existing default",
+			existing:    []config.Project{{Name: "other", Path: "/tmp/other"}},
+			wantDefault: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &config.ProjectsRegistry{Projects: tt.existing}
+			if len(tt.existing) > 0 {
+				registry.DefaultProject = tt.existing[0].Name
+			}
+			runner := &fakeCloneRunner{}
+			dest := filepath.Join(t.TempDir(), "widgets")
+
+			project, err := InitProject(context.Background(), runner, registry, InitOptions{
+				URL: "git@github.com:acme/widgets.git",
+				Dir: dest,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, "widgets", project.Name)
+			assert.Equal(t, dest, project.Path)
+			assert.Len(t, registry.Projects, len(tt.existing)+1)
+			assert.Equal(t, tt.wantDefault, registry.DefaultProject)
+
+			require.Len(t, runner.calls, 1)
+			assert.Equal(t, []string{filepath.Dir(dest), "git", "clone", "git@github.com:acme/widgets.git", dest}, runner.calls[0])
+		})
+	}
+}
+
+func TestInitProject_InitBeads(t *testing.T) {
+	registry := &config.ProjectsRegistry{}
+	runner := &fakeCloneRunner{}
+	dest := filepath.Join(t.TempDir(), "widgets")
+
+	_, err := InitProject(context.Background(), runner, registry, InitOptions{
+		URL:       "https://github.com/acme/widgets",
+		Dir:       dest,
+		InitBeads: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, runner.calls, 2)
+	assert.Equal(t, []string{dest, "bd", "init"}, runner.calls[1])
+}
+
+func TestInitProject_Errors(t *testing.T) {
+	t.Run("clone failure leaves registry untouched", func(t *testing.T) {
+		registry := &config.ProjectsRegistry{}
+		runner := &fakeCloneRunner{err: errors.New("repository not found")}
+
+		_, err := InitProject(context.Background(), runner, registry, InitOptions{
+			URL: "https://github.com/acme/widgets.git",
+			Dir: filepath.Join(t.TempDir(), "widgets"),
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to clone")
+		assert.Empty(t, registry.Projects)
+	})
+
+	t.Run("duplicate project name", func(t *testing.T) {
+		registry := &config.ProjectsRegistry{Projects: []config.Project{{Name: "widgets", Path: "/tmp/widgets"}}}
+		runner := &fakeCloneRunner{}
+
+		_, err := InitProject(context.Background(), runner, registry, InitOptions{
+			URL: "https://github.com/acme/widgets.git",
+			Dir: filepath.Join(t.TempDir(), "widgets"),
+		})
+
+		require.ErrorIs(t, err, config.ErrDuplicateProject)
+		assert.Empty(t, runner.calls, "should not clone when the name is taken")
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		registry := &config.ProjectsRegistry{}
+		runner := &fakeCloneRunner{}
+
+		_, err := InitProject(context.Background(), runner, registry, InitOptions{
+			URL: "https://github.com/acme/widgets.git",
+			Dir: t.TempDir(),
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+		assert.Empty(t, runner.calls)
+	})
+}
+
+func TestRepoNameFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "git@github.com:acme/widgets.git", want: "widgets"},
+		{url: "https://github.com/acme/widgets.git", want: "widgets"},
+		{url: "https://github.com/acme/widgets/", want: "widgets"},
+		{url: "/srv/git/widgets.git", want: "widgets"},
+		{url: "widgets", want: "widgets"},
+		{url: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := repoNameFromURL(tt.url); got != tt.want {
+			t.Errorf("repoNameFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/beads"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
+)
+
+// planTimeout bounds a whole headless planning run, including review passes
+const planTimeout = 10 * time.Minute
+
+// PlanOptions configures `az plan`
+type PlanOptions struct {
+	Description string // Feature to plan
+	DryRun      bool   // Print the final plan as JSON instead of creating beads
+}
+
+// PlanCommand runs the planning workflow without the TUI. Progress goes to
+// stderr so that stdout only carries the result: the created bead IDs, or
+// the plan JSON with DryRun.
+func PlanCommand(deps *Dependencies, opts PlanOptions) error {
+	if !planning.Available() {
+		return errors.New("ANTHROPIC_API_KEY is not set; export your Anthropic API key to use az plan")
+	}
+
+	svc, err := planning.NewService(
+		&http.Client{Timeout: 2 * time.Minute},
+		beads.PlanningClient{Client: deps.BeadsClient},
+		deps.Logger,
+	)
+	if err != nil {
+		return err
+	}
+	if !opts.DryRun {
+		// Keep the plan if bead creation is interrupted
+		if cwd, err := os.Getwd(); err == nil {
+			draftPath := planning.DefaultDraftPath(cwd)
+			svc.SetDraftPath(draftPath)
+			resumeDraft(svc, draftPath, opts.Description, os.Stderr, deps.Logger)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), planTimeout)
+	defer cancel()
+
+	return runPlan(ctx, svc, opts, os.Stdout, os.Stderr)
+}
+
+// runPlan drives svc, writing progress to progress and the result to out
+func runPlan(ctx context.Context, svc *planning.Service, opts PlanOptions, out, progress io.Writer) error {
+	svc.SetProgressFunc(func(state domain.PlanningState) {
+		if line := progressLine(state); line != "" {
+			fmt.Fprintln(progress, line)
+		}
+	})
+
+	if opts.DryRun {
+		plan, err := svc.PlanFeature(ctx, opts.Description)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode plan: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	var created []domain.Task
+	var err error
+	if state := svc.GetState(); state.Status == domain.PlanningAwaitingApproval && state.CurrentPlan != nil {
+		// A plan restored from a draft only needs its beads created
+		created, err = svc.CreateBeadsFromPlan(ctx, state.CurrentPlan)
+	} else {
+		created, err = svc.RunPlanningWorkflow(ctx, opts.Description)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, dep := range svc.GetState().DroppedDependencies {
+		fmt.Fprintf(progress, "! Dropped dependency %s -> %s to break a cycle\n", dep.TaskID, dep.DependsOn)
+	}
+	fmt.Fprintf(progress, "\n✓ Created %d beads\n", len(created))
+	for _, task := range created {
+		fmt.Fprintln(out, task.ID)
+	}
+	return nil
+}
+
+// resumeDraft restores the plan saved at draftPath into svc when it was
+// drafted for the same description, so that a run interrupted while
+// creating beads doesn't plan again from scratch
+func resumeDraft(svc *planning.Service, draftPath, description string, progress io.Writer, logger *slog.Logger) {
+	draft, err := planning.LoadDraft(draftPath)
+	if err != nil {
+		logger.Warn("ignoring unreadable plan draft", "path", draftPath, "error", err)
+		return
+	}
+	if draft == nil || draft.FeatureDescription != description {
+		return
+	}
+	fmt.Fprintf(progress, "Resuming the plan saved %s\n", draft.SavedAt.Format(time.DateTime))
+	svc.RestoreDraft(draft)
+}
+
+// progressLine describes a planning step for the terminal
+func progressLine(state domain.PlanningState) string {
+	switch state.Status {
+	case domain.PlanningGenerating:
+		return "Generating plan..."
+	case domain.PlanningReviewing:
+		return fmt.Sprintf("Reviewing plan (pass %d/%d)...", state.ReviewPass, state.MaxReviewPasses)
+	case domain.PlanningRefining:
+		return "Refining plan..."
+	case domain.PlanningCreatingBeads:
+		return "Creating beads..."
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/planning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceHTTPClient answers Anthropic API calls with canned texts in order
+type sequenceHTTPClient struct {
+	texts []string
+	calls int
+}
+
+func (c *sequenceHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	text := c.texts[c.calls]
+	c.calls++
+	body, _ := json.Marshal(map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+	})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+// recordingPlanBeads records the beads a planning run creates
+type recordingPlanBeads struct {
+	created []string
+}
+
+func (r *recordingPlanBeads) Create(ctx context.Context, title, description string, taskType domain.TaskType, priority int, design, acceptance string, estimate *int) (*domain.Task, error) {
+	id := "az-" + string(rune('1'+len(r.created)))
+	r.created = append(r.created, id)
+	return &domain.Task{ID: id, Title: title, Type: taskType}, nil
+}
+
+func (r *recordingPlanBeads) AddDependency(ctx context.Context, childID, parentID, depType string) error {
+	return nil
+}
+
+func newTestPlanner(t *testing.T, beadsClient planning.BeadsClient) *planning.Service {
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	httpClient := &sequenceHTTPClient{texts: []string{
+		`{"epicTitle": "Login", "tasks": [{"id": "task-1", "title": "Add form", "type": "task", "priority": 1}]}`,
+		`{"score": 95, "isApproved": true}`,
+	}}
+	svc, err := planning.NewService(httpClient, beadsClient, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return svc
+}
+
+func TestRunPlan_CreatesBeads(t *testing.T) {
+	beadsClient := &recordingPlanBeads{}
+	svc := newTestPlanner(t, beadsClient)
+	var out, progress bytes.Buffer
+
+	err := runPlan(context.Background(), svc, PlanOptions{Description: "Add login"}, &out, &progress)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"az-1", "az-2"}, beadsClient.created)
+	assert.Equal(t, "az-1\naz-2\n", out.String())
+	assert.Contains(t, progress.String(), "Generating plan...")
+	assert.Contains(t, progress.String(), "Reviewing plan (pass 1/5)...")
+	assert.Contains(t, progress.String(), "Created 2 beads")
+}
+
+func TestRunPlan_DryRun(t *testing.T) {
+	beadsClient := &recordingPlanBeads{}
+	svc := newTestPlanner(t, beadsClient)
+	var out, progress bytes.Buffer
+
+	err := runPlan(context.Background(), svc, PlanOptions{Description: "Add login", DryRun: true}, &out, &progress)
+
+	require.NoError(t, err)
+	assert.Empty(t, beadsClient.created)
+
+	var plan domain.Plan
+	require.NoError(t, json.Unmarshal(out.Bytes(), &plan))
+	assert.Equal(t, "Login", plan.EpicTitle)
+	require.Len(t, plan.Tasks, 1)
+	assert.Equal(t, "Add form", plan.Tasks[0].Title)
+}
+
+func TestRunPlan_ResumesMatchingDraft(t *testing.T) {
+	draftPath := filepath.Join(t.TempDir(), "plans", "project.json")
+	require.NoError(t, planning.SaveDraft(draftPath, planning.Draft{
+		FeatureDescription: "Add login",
+		Plan: &domain.Plan{
+			EpicTitle: "Login",
+			Tasks:     []domain.PlannedTask{{ID: "task-1", Title: "Add form", Type: domain.TypeTask}},
+		},
+		SavedAt: time.Now(),
+	}))
+
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	httpClient := &sequenceHTTPClient{}
+	beadsClient := &recordingPlanBeads{}
+	svc, err := planning.NewService(httpClient, beadsClient, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	svc.SetDraftPath(draftPath)
+	var out, progress bytes.Buffer
+
+	resumeDraft(svc, draftPath, "Add login", &progress, slog.Default())
+	err = runPlan(context.Background(), svc, PlanOptions{Description: "Add login"}, &out, &progress)
+
+	require.NoError(t, err)
+	assert.Zero(t, httpClient.calls, "a resumed plan shouldn't be planned again")
+	assert.Equal(t, []string{"az-1", "az-2"}, beadsClient.created)
+	assert.Contains(t, progress.String(), "Resuming the plan saved")
+
+	draft, err := planning.LoadDraft(draftPath)
+	require.NoError(t, err)
+	assert.Nil(t, draft, "the draft should be removed once its beads exist")
+}
+
+func TestResumeDraft_IgnoresOtherDescriptions(t *testing.T) {
+	draftPath := filepath.Join(t.TempDir(), "project.json")
+	require.NoError(t, planning.SaveDraft(draftPath, planning.Draft{
+		FeatureDescription: "Add search",
+		Plan:               &domain.Plan{EpicTitle: "Search"},
+	}))
+	svc := newTestPlanner(t, &recordingPlanBeads{})
+	var progress bytes.Buffer
+
+	resumeDraft(svc, draftPath, "Add login", &progress, slog.Default())
+
+	assert.Equal(t, domain.PlanningIdle, svc.GetState().Status)
+	assert.Empty(t, progress.String())
+}
+
+func TestPlanCommand_MissingAPIKey(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	err := PlanCommand(&Dependencies{}, PlanOptions{Description: "Add login"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ANTHROPIC_API_KEY")
+}
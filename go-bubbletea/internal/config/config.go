@@ -9,16 +9,20 @@ import (
 
 // Config represents the full Azedarach configuration
 type Config struct {
-	CLITool       string          `json:"cliTool"`
-	Git           GitConfig       `json:"git"`
-	Session       SessionConfig   `json:"session"`
-	PR            PRConfig        `json:"pr"`
-	Merge         MergeConfig     `json:"merge"`
-	Notifications NotifyConfig    `json:"notifications"`
-	Beads         BeadsConfig     `json:"beads"`
-	Network       NetworkConfig   `json:"network"`
-	DevServer     DevServerConfig `json:"devServer"`
-	Worktree      WorktreeConfig  `json:"worktree"`
+	CLITool       string            `json:"cliTool"`
+	Git           GitConfig         `json:"git"`
+	Session       SessionConfig     `json:"session"`
+	PR            PRConfig          `json:"pr"`
+	Merge         MergeConfig       `json:"merge"`
+	Notifications NotifyConfig      `json:"notifications"`
+	Beads         BeadsConfig       `json:"beads"`
+	Network       NetworkConfig     `json:"network"`
+	DevServer     DevServerConfig   `json:"devServer"`
+	Worktree      WorktreeConfig    `json:"worktree"`
+	Monitor       MonitorConfig     `json:"monitor"`
+	Diagnostics   DiagnosticsConfig `json:"diagnostics"`
+	UI            UIConfig          `json:"ui"`
+	Board         BoardConfig       `json:"board"`
 }
 
 // GitConfig contains Git-related settings
@@ -27,22 +31,48 @@ type GitConfig struct {
 	WorkflowMode         string `json:"workflowMode"`
 	ShowLineChanges      bool   `json:"showLineChanges"`
 	DefaultMergeStrategy string `json:"defaultMergeStrategy"`
+	AutoStash            bool   `json:"autoStash"` // Stash uncommitted changes around "update from main" and pop them after
 }
 
 // SessionConfig contains session management settings
 type SessionConfig struct {
-	Shell        string   `json:"shell"`
-	TimeoutMs    int      `json:"timeoutMs"`
-	LogDir       string   `json:"logDir"`
-	InitCommands []string `json:"initCommands"`
+	Shell                string       `json:"shell"`
+	Multiplexer          string       `json:"multiplexer"` // Terminal multiplexer hosting sessions: "tmux" or "zellij"
+	TimeoutMs            int          `json:"timeoutMs"`
+	LogDir               string       `json:"logDir"`
+	LogMaxMB             int          `json:"logMaxMB"`             // Size at which a session's log under LogDir is rotated
+	InitCommands         []string     `json:"initCommands"`         // Shell setup (nvm, direnv...) typed into new sessions before the CLI tool
+	ArchiveOnDone        bool         `json:"archiveOnDone"`        // Save final output and comment a summary on the bead when done
+	AutoPauseIdleMinutes int          `json:"autoPauseIdleMinutes"` // Pause sessions done or idle this long; 0 disables
+	SkipDoneConfirm      bool         `json:"skipDoneConfirm"`      // Start sessions on done tasks without asking
+	MaxConcurrent        int          `json:"maxConcurrent"`        // Sessions allowed to start or work at once; further starts queue. 0 is unlimited
+	IdleTimeoutMinutes   int          `json:"idleTimeoutMinutes"`   // Warn about sessions done or idle this long so they can be cleaned up; 0 disables
+	StuckTimeoutMs       int          `json:"stuckTimeoutMs"`       // Flag busy sessions whose output hasn't changed this long as stalled; 0 disables
+	Layout               LayoutConfig `json:"layout"`               // Panes opened beside the agent in new tmux sessions
+}
+
+// LayoutConfig is the pane template applied to new tmux sessions. The agent
+// keeps the first pane; InitCommands run in the first of Panes, the shell pane.
+type LayoutConfig struct {
+	Panes []PaneConfig `json:"panes"`
+}
+
+// PaneConfig is one pane split off a new session's window
+type PaneConfig struct {
+	Split   string `json:"split"`   // "right" of (default) or "below" the pane before it
+	Size    int    `json:"size"`    // Percent of the pane it splits from that it takes; 0 splits evenly
+	Command string `json:"command"` // Run in the pane after the init commands, e.g. "tail -f dev.log"
 }
 
 // PRConfig contains pull request settings
 type PRConfig struct {
-	DraftByDefault     bool `json:"draftByDefault"`
-	AutoLink           bool `json:"autoLink"`
-	NotifyAfterCreate  bool `json:"notifyAfterCreate"`
-	CreateWithoutMerge bool `json:"createWithoutMerge"`
+	DraftByDefault     bool   `json:"draftByDefault"`
+	AutoLink           bool   `json:"autoLink"`    // Append a link to the bead to the PR body
+	LinkKeyword        string `json:"linkKeyword"` // Word before the bead ID (Closes, Fixes, Resolves, Refs), or a format using {beadID}
+	NotifyAfterCreate  bool   `json:"notifyAfterCreate"`
+	CreateWithoutMerge bool   `json:"createWithoutMerge"`
+	OpenInBrowser      bool   `json:"openInBrowser"` // Open a newly created PR in the default browser
+	Provider           string `json:"provider"`      // github (gh) or gitlab (glab); empty detects it from the origin remote's URL
 }
 
 // MergeConfig contains merge strategy settings
@@ -67,9 +97,11 @@ type BeadsConfig struct {
 
 // NetworkConfig contains network-related settings
 type NetworkConfig struct {
-	CheckInterval  int `json:"checkInterval"`
-	OfflineTimeout int `json:"offlineTimeout"`
-	RetryAttempts  int `json:"retryAttempts"`
+	CheckInterval  int    `json:"checkInterval"`
+	OfflineTimeout int    `json:"offlineTimeout"`
+	RetryAttempts  int    `json:"retryAttempts"`
+	CheckURL       string `json:"checkURL"`    // What connectivity is checked against
+	CheckMethod    string `json:"checkMethod"` // "head" (HTTP HEAD), "tcp" (connect) or "dns" (lookup)
 }
 
 // DevServerConfig contains development server settings
@@ -81,10 +113,68 @@ type DevServerConfig struct {
 
 // WorktreeConfig contains git worktree settings
 type WorktreeConfig struct {
-	BasePath    string `json:"basePath"`
-	NameFormat  string `json:"nameFormat"`
-	AutoCleanup bool   `json:"autoCleanup"`
-	KeepDays    int    `json:"keepDays"`
+	BasePath        string `json:"basePath"`
+	NameFormat      string `json:"nameFormat"`
+	AutoCleanup     bool   `json:"autoCleanup"`
+	KeepDays        int    `json:"keepDays"`
+	FullExistsCheck bool   `json:"fullExistsCheck"` // Always list every worktree when checking for an existing one
+}
+
+// MonitorConfig contains session state detection settings
+type MonitorConfig struct {
+	MinConfidence float64 `json:"minConfidence"` // Detections at or below this keep the previous state
+	HistoryLines  int     `json:"historyLines"`  // Scrollback retained per session and scanned for state
+	CaptureLines  int     `json:"captureLines"`  // Lines read from the pane on each poll; larger costs more CPU
+}
+
+// DiagnosticsConfig contains the thresholds diagnostics judges overall health by
+type DiagnosticsConfig struct {
+	MaxPortConflicts int  `json:"maxPortConflicts"` // Dev server port conflicts tolerated before the system is degraded
+	OfflineDegraded  bool `json:"offlineDegraded"`  // Being offline only degrades the system rather than making it critical
+	MaxMemoryMB      int  `json:"maxMemoryMB"`      // Heap in use above this degrades the system
+	MaxGoroutines    int  `json:"maxGoroutines"`    // Goroutines above this degrade the system
+	RefreshInterval  int  `json:"refreshInterval"`  // Seconds between refreshes of the diagnostics panel once auto-refresh is on (a)
+}
+
+// UIConfig contains board display settings
+type UIConfig struct {
+	ShowAge          bool           `json:"showAge"`          // Annotate cards with time since last update and dim stale ones
+	FollowNewSession bool           `json:"followNewSession"` // Move the cursor to a task when its session starts
+	LogLevel         string         `json:"logLevel"`         // debug, info, warn or error for Session.LogDir/azedarach.log
+	MarkdownRender   bool           `json:"markdownRender"`   // Render bead descriptions as markdown in the detail panel and epic drill-down; false shows them as written
+	ToastDurations   map[string]int `json:"toastDurations"`   // Milliseconds a toast shows, keyed by level (info, success, warning, error)
+	PersistErrors    bool           `json:"persistErrors"`    // Keep error toasts until dismissed rather than letting them expire
+}
+
+// BoardConfig contains kanban flow settings
+type BoardConfig struct {
+	WIPLimits    map[string]int    `json:"wipLimits"`    // Max tasks per column, keyed by status (open, in_progress, blocked, closed); 0 or absent is unlimited
+	StatusColors map[string]string `json:"statusColors"` // Card border color by status: a palette name (red, blue...), #rrggbb or an ANSI number; absent keeps the theme's
+	Columns      []ColumnConfig    `json:"columns"`      // Board columns left to right; empty shows Open, In Progress, Blocked and Done
+}
+
+// ColumnConfig is a board column showing the tasks in one status
+type ColumnConfig struct {
+	Title  string `json:"title"`  // Header text
+	Status string `json:"status"` // Status the column holds: one of bd's (open, in_progress, blocked, closed) or a custom one such as review
+	Base   string `json:"base"`   // For a custom status, the bd status it behaves as, e.g. in_progress for review. bd stores the base, with the custom status in a status:<name> label
+}
+
+// DefaultBoardColumns is the board when Board.Columns is empty
+var DefaultBoardColumns = []ColumnConfig{
+	{Title: "Open", Status: "open"},
+	{Title: "In Progress", Status: "in_progress"},
+	{Title: "Blocked", Status: "blocked"},
+	{Title: "Done", Status: "closed"},
+}
+
+// ColumnsOrDefault returns the configured board columns, left to right,
+// or DefaultBoardColumns when none are
+func (b BoardConfig) ColumnsOrDefault() []ColumnConfig {
+	if len(b.Columns) == 0 {
+		return DefaultBoardColumns
+	}
+	return b.Columns
 }
 
 // DefaultConfig returns a Config with sensible defaults
@@ -92,24 +182,35 @@ func DefaultConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
 
 	return &Config{
-		CLITool: "claude",
+		CLITool: DefaultCLITool,
 		Git: GitConfig{
-			BaseBranch:           "main",
+			BaseBranch:           "",
 			WorkflowMode:         "worktree",
 			ShowLineChanges:      true,
 			DefaultMergeStrategy: "merge",
+			AutoStash:            false,
 		},
 		Session: SessionConfig{
-			Shell:        "zsh",
-			TimeoutMs:    30000,
-			LogDir:       filepath.Join(homeDir, ".azedarach", "logs"),
-			InitCommands: []string{},
+			Shell:                "zsh",
+			Multiplexer:          "tmux",
+			TimeoutMs:            30000,
+			LogDir:               filepath.Join(homeDir, ".azedarach", "logs"),
+			LogMaxMB:             10,
+			InitCommands:         []string{},
+			ArchiveOnDone:        false,
+			AutoPauseIdleMinutes: 0,
+			SkipDoneConfirm:      false,
+			MaxConcurrent:        0,
+			IdleTimeoutMinutes:   0,
+			StuckTimeoutMs:       0,
 		},
 		PR: PRConfig{
 			DraftByDefault:     true,
 			AutoLink:           true,
+			LinkKeyword:        "Closes",
 			NotifyAfterCreate:  true,
 			CreateWithoutMerge: false,
+			OpenInBrowser:      false,
 		},
 		Merge: MergeConfig{
 			Strategy:          "merge",
@@ -129,6 +230,8 @@ func DefaultConfig() *Config {
 			CheckInterval:  60,  // 1 minute
 			OfflineTimeout: 300, // 5 minutes
 			RetryAttempts:  3,
+			CheckURL:       "https://github.com",
+			CheckMethod:    "head",
 		},
 		DevServer: DevServerConfig{
 			BasePort:     3000,
@@ -136,10 +239,36 @@ func DefaultConfig() *Config {
 			Environments: make(map[string]string),
 		},
 		Worktree: WorktreeConfig{
-			BasePath:    "../",
-			NameFormat:  "{project}-{beadID}",
-			AutoCleanup: true,
-			KeepDays:    7,
+			BasePath:        "../",
+			NameFormat:      "{project}-{beadID}",
+			AutoCleanup:     true,
+			KeepDays:        7,
+			FullExistsCheck: false,
+		},
+		Monitor: MonitorConfig{
+			MinConfidence: 0.4,
+			HistoryLines:  500,
+			CaptureLines:  100,
+		},
+		Diagnostics: DiagnosticsConfig{
+			MaxPortConflicts: 0,
+			OfflineDegraded:  false,
+			MaxMemoryMB:      512,
+			MaxGoroutines:    10000,
+			RefreshInterval:  5,
+		},
+		UI: UIConfig{
+			ShowAge:          false,
+			FollowNewSession: false,
+			LogLevel:         "info",
+			MarkdownRender:   true,
+			ToastDurations: map[string]int{
+				"info":    3000,
+				"success": 3000,
+				"warning": 5000,
+				"error":   5000,
+			},
+			PersistErrors: false,
 		},
 	}
 }
@@ -150,9 +279,20 @@ func DefaultConfig() *Config {
 // 3. package.json "azedarach" key
 // 4. Defaults
 func LoadConfig(projectPath string) (*Config, error) {
-	// Start with defaults
-	defaultCfg := DefaultConfig()
+	cfg, err := loadProjectConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		// Return defaults if no config files found
+		return DefaultConfig(), nil
+	}
+	return MergeWithDefaults(cfg), nil
+}
 
+// loadProjectConfig reads a project's own config without applying defaults,
+// so unset fields stay empty. It returns nil if the project has none.
+func loadProjectConfig(projectPath string) (*Config, error) {
 	// Try loading from .azedarach.json with version migration
 	azedarachPath := filepath.Join(projectPath, ".azedarach.json")
 	if data, err := os.ReadFile(azedarachPath); err == nil {
@@ -160,7 +300,7 @@ func LoadConfig(projectPath string) (*Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse .azedarach.json: %w", err)
 		}
-		return MergeWithDefaults(cfg), nil
+		return cfg, nil
 	}
 
 	// Try loading from package.json
@@ -176,16 +316,15 @@ func LoadConfig(projectPath string) (*Config, error) {
 				// Fall back to direct parsing for backwards compat
 				var cfgDirect Config
 				if err := json.Unmarshal(packageJSON.Azedarach, &cfgDirect); err == nil {
-					return MergeWithDefaults(&cfgDirect), nil
+					return &cfgDirect, nil
 				}
 				return nil, fmt.Errorf("failed to parse package.json azedarach config: %w", err)
 			}
-			return MergeWithDefaults(cfg), nil
+			return cfg, nil
 		}
 	}
 
-	// Return defaults if no config files found
-	return defaultCfg, nil
+	return nil, nil
 }
 
 // SaveConfig saves configuration to the specified path with version information
@@ -202,6 +341,21 @@ func SaveConfig(cfg *Config, path string) error {
 	return nil
 }
 
+// EditConfigFile applies edit to the config of the project path is in, as
+// read without defaults, and saves it to path. Settings the project never
+// set stay unset, so they keep following the defaults.
+func EditConfigFile(path string, edit func(*Config)) error {
+	cfg, err := loadProjectConfig(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	edit(cfg)
+	return SaveConfig(cfg, path)
+}
+
 // MergeWithDefaults fills in missing values with defaults
 func MergeWithDefaults(cfg *Config) *Config {
 	defaults := DefaultConfig()
@@ -226,16 +380,27 @@ func MergeWithDefaults(cfg *Config) *Config {
 	if cfg.Session.Shell == "" {
 		cfg.Session.Shell = defaults.Session.Shell
 	}
+	if cfg.Session.Multiplexer == "" {
+		cfg.Session.Multiplexer = defaults.Session.Multiplexer
+	}
 	if cfg.Session.TimeoutMs == 0 {
 		cfg.Session.TimeoutMs = defaults.Session.TimeoutMs
 	}
 	if cfg.Session.LogDir == "" {
 		cfg.Session.LogDir = defaults.Session.LogDir
 	}
+	if cfg.Session.LogMaxMB == 0 {
+		cfg.Session.LogMaxMB = defaults.Session.LogMaxMB
+	}
 	if cfg.Session.InitCommands == nil {
 		cfg.Session.InitCommands = defaults.Session.InitCommands
 	}
 
+	// Merge PR config
+	if cfg.PR.LinkKeyword == "" {
+		cfg.PR.LinkKeyword = defaults.PR.LinkKeyword
+	}
+
 	// Merge Merge config
 	if cfg.Merge.Strategy == "" {
 		cfg.Merge.Strategy = defaults.Merge.Strategy
@@ -259,6 +424,12 @@ func MergeWithDefaults(cfg *Config) *Config {
 	if cfg.Network.RetryAttempts == 0 {
 		cfg.Network.RetryAttempts = defaults.Network.RetryAttempts
 	}
+	if cfg.Network.CheckURL == "" {
+		cfg.Network.CheckURL = defaults.Network.CheckURL
+	}
+	if cfg.Network.CheckMethod == "" {
+		cfg.Network.CheckMethod = defaults.Network.CheckMethod
+	}
 
 	// Merge DevServer config
 	if cfg.DevServer.BasePort == 0 {
@@ -282,6 +453,41 @@ func MergeWithDefaults(cfg *Config) *Config {
 		cfg.Worktree.KeepDays = defaults.Worktree.KeepDays
 	}
 
+	// Merge UI config
+	if cfg.UI.LogLevel == "" {
+		cfg.UI.LogLevel = defaults.UI.LogLevel
+	}
+	if cfg.UI.ToastDurations == nil {
+		cfg.UI.ToastDurations = make(map[string]int, len(defaults.UI.ToastDurations))
+	}
+	for level, ms := range defaults.UI.ToastDurations {
+		if _, ok := cfg.UI.ToastDurations[level]; !ok {
+			cfg.UI.ToastDurations[level] = ms
+		}
+	}
+
+	// Merge Monitor config
+	if cfg.Monitor.MinConfidence == 0 {
+		cfg.Monitor.MinConfidence = defaults.Monitor.MinConfidence
+	}
+	if cfg.Monitor.HistoryLines == 0 {
+		cfg.Monitor.HistoryLines = defaults.Monitor.HistoryLines
+	}
+	if cfg.Monitor.CaptureLines == 0 {
+		cfg.Monitor.CaptureLines = defaults.Monitor.CaptureLines
+	}
+
+	// Merge Diagnostics config
+	if cfg.Diagnostics.MaxMemoryMB == 0 {
+		cfg.Diagnostics.MaxMemoryMB = defaults.Diagnostics.MaxMemoryMB
+	}
+	if cfg.Diagnostics.MaxGoroutines == 0 {
+		cfg.Diagnostics.MaxGoroutines = defaults.Diagnostics.MaxGoroutines
+	}
+	if cfg.Diagnostics.RefreshInterval == 0 {
+		cfg.Diagnostics.RefreshInterval = defaults.Diagnostics.RefreshInterval
+	}
+
 	// Merge Notifications config
 	if cfg.Notifications.ErrorThreshold == 0 {
 		cfg.Notifications.ErrorThreshold = defaults.Notifications.ErrorThreshold
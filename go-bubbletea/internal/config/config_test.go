@@ -14,7 +14,7 @@ func TestDefaultConfig(t *testing.T) {
 
 	// Test basic defaults
 	assert.Equal(t, "claude", cfg.CLITool)
-	assert.Equal(t, "main", cfg.Git.BaseBranch)
+	assert.Empty(t, cfg.Git.BaseBranch, "unset, so detected from the repo")
 	assert.Equal(t, "worktree", cfg.Git.WorkflowMode)
 	assert.True(t, cfg.Git.ShowLineChanges)
 	assert.Equal(t, "merge", cfg.Git.DefaultMergeStrategy)
@@ -31,6 +31,9 @@ func TestDefaultConfig(t *testing.T) {
 	assert.True(t, cfg.PR.NotifyAfterCreate)
 	assert.False(t, cfg.PR.CreateWithoutMerge)
 
+	// Test UI defaults
+	assert.True(t, cfg.UI.MarkdownRender)
+
 	// Test merge defaults
 	assert.Equal(t, "merge", cfg.Merge.Strategy)
 	assert.False(t, cfg.Merge.AutoMerge)
@@ -283,6 +286,7 @@ func TestMergeWithDefaults(t *testing.T) {
 	assert.NotEmpty(t, merged.Session.LogDir)
 	assert.NotNil(t, merged.Session.InitCommands)
 	assert.Equal(t, ".beads", merged.Beads.Path)
+	assert.Equal(t, "Closes", merged.PR.LinkKeyword)
 }
 
 func TestMergeWithDefaultsEmptyConfig(t *testing.T) {
@@ -321,6 +325,15 @@ func TestMergeWithDefaultsNilSlices(t *testing.T) {
 	assert.NotNil(t, merged.DevServer.Environments)
 }
 
+func TestMergeWithDefaultsToastDurations(t *testing.T) {
+	// Levels left out of a partial map keep their defaults
+	cfg := &Config{UI: UIConfig{ToastDurations: map[string]int{"error": 10000}}}
+
+	merged := MergeWithDefaults(cfg)
+
+	assert.Equal(t, map[string]int{"info": 3000, "success": 3000, "warning": 5000, "error": 10000}, merged.UI.ToastDurations)
+}
+
 func TestLoadConfigPackageJSONWithoutAzedarach(t *testing.T) {
 	// Create temp directory
 	tmpDir := t.TempDir()
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -12,12 +13,15 @@ import (
 type ProjectsRegistry struct {
 	Projects       []Project `json:"projects"`
 	DefaultProject string    `json:"defaultProject"`
+	ScanRoot       string    `json:"scanRoot,omitempty"` // Directory scanned for repos to add; empty scans the working directory's parent
 }
 
 // Project represents a registered project
 type Project struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	CLITool    string `json:"cliTool,omitempty"`    // Overrides the CLI tool for this project's sessions
+	BaseBranch string `json:"baseBranch,omitempty"` // Overrides the branch worktrees start from and merge into
 }
 
 var (
@@ -225,6 +229,106 @@ func DetectProjectFromCwd() (*Project, error) {
 	}
 }
 
+// ScanDir returns the directory Scan looks in: ScanRoot, or else the parent
+// of the working directory, where sibling repos usually live
+func (r *ProjectsRegistry) ScanDir() (string, error) {
+	if r.ScanRoot != "" {
+		if strings.HasPrefix(r.ScanRoot, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(home, r.ScanRoot[2:]), nil
+		}
+		return r.ScanRoot, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(cwd), nil
+}
+
+// Scan finds the git repos directly inside root, one level deep, that
+// aren't registered yet, sorted by name. A repo is skipped if a project
+// already has its path or its directory name.
+func (r *ProjectsRegistry) Scan(root string) ([]Project, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(r.Projects)*2)
+	for _, p := range r.Projects {
+		known[p.Name] = true
+		known[filepath.Clean(p.Path)] = true
+	}
+
+	var found []Project
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		if !isGitRepo(path) || known[entry.Name()] || known[filepath.Clean(path)] {
+			continue
+		}
+		found = append(found, Project{Name: entry.Name(), Path: path})
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found, nil
+}
+
+// DefaultCLITool is the command started in new sessions when no CLI tool is
+// configured anywhere
+const DefaultCLITool = "claude"
+
+// ResolveCLITool returns the CLI tool to start in sessions for project. The
+// project's registry entry wins, then the cliTool in the project's own
+// .azedarach.json or package.json, then the global config, then
+// DefaultCLITool. project may be nil.
+func ResolveCLITool(global *Config, project *Project) string {
+	if project != nil {
+		if project.CLITool != "" {
+			return project.CLITool
+		}
+		if project.Path != "" {
+			if cfg, err := loadProjectConfig(project.Path); err == nil && cfg != nil && cfg.CLITool != "" {
+				return cfg.CLITool
+			}
+		}
+	}
+	if global != nil && global.CLITool != "" {
+		return global.CLITool
+	}
+	return DefaultCLITool
+}
+
+// ResolveBaseBranch returns the base branch configured for project: the
+// project's registry entry wins, then the git.baseBranch in the project's
+// own .azedarach.json or package.json, then the global config. Returns ""
+// when nothing is configured, leaving the branch to be detected from the
+// repo.
+// project may be nil.
+func ResolveBaseBranch(global *Config, project *Project) string {
+	if project != nil {
+		if project.BaseBranch != "" {
+			return project.BaseBranch
+		}
+		if project.Path != "" {
+			if cfg, err := loadProjectConfig(project.Path); err == nil && cfg != nil && cfg.Git.BaseBranch != "" {
+				return cfg.Git.BaseBranch
+			}
+		}
+	}
+	if global != nil && global.Git.BaseBranch != "" {
+		return global.Git.BaseBranch
+	}
+	return ""
+}
+
 // registryPath is a variable holding the function that returns the path to the projects registry file
 // This allows it to be overridden in tests
 var registryPath = func() (string, error) {
@@ -563,3 +563,186 @@ func createTempGitRepo(t *testing.T) string {
 	}
 	return dir
 }
+
+func TestResolveCLITool(t *testing.T) {
+	withConfig := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withConfig, ".azedarach.json"), []byte(`{"version": 1, "cliTool": "aider"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	withoutTool := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withoutTool, ".azedarach.json"), []byte(`{"version": 1, "session": {"shell": "bash"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		global  *Config
+		project *Project
+		want    string
+	}{
+		{
+			name:    "registry override wins",
+			global:  &Config{CLITool: "claude"},
+			project: &Project{Name: "p", Path: withConfig, CLITool: "opencode"},
+			want:    "opencode",
+		},
+		{
+			name:    "project config file",
+			global:  &Config{CLITool: "claude"},
+			project: &Project{Name: "p", Path: withConfig},
+			want:    "aider",
+		},
+		{
+			name:    "project config without a tool falls back to global",
+			global:  &Config{CLITool: "opencode"},
+			project: &Project{Name: "p", Path: withoutTool},
+			want:    "opencode",
+		},
+		{
+			name:    "project without config falls back to global",
+			global:  &Config{CLITool: "opencode"},
+			project: &Project{Name: "p", Path: t.TempDir()},
+			want:    "opencode",
+		},
+		{
+			name:   "no project uses global",
+			global: &Config{CLITool: "aider"},
+			want:   "aider",
+		},
+		{
+			name: "nothing configured",
+			want: DefaultCLITool,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveCLITool(tt.global, tt.project); got != tt.want {
+				t.Errorf("ResolveCLITool() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveBaseBranch(t *testing.T) {
+	withConfig := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withConfig, ".azedarach.json"), []byte(`{"version": 1, "git": {"baseBranch": "trunk"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	develop := DefaultConfig()
+	develop.Git.BaseBranch = "develop"
+	main := DefaultConfig()
+	main.Git.BaseBranch = "main"
+
+	tests := []struct {
+		name    string
+		global  *Config
+		project *Project
+		want    string
+	}{
+		{
+			name:    "registry override wins",
+			global:  develop,
+			project: &Project{Name: "p", Path: withConfig, BaseBranch: "release"},
+			want:    "release",
+		},
+		{
+			name:    "project config file",
+			global:  develop,
+			project: &Project{Name: "p", Path: withConfig},
+			want:    "trunk",
+		},
+		{
+			name:    "project without config falls back to global",
+			global:  develop,
+			project: &Project{Name: "p", Path: t.TempDir()},
+			want:    "develop",
+		},
+		{
+			name:   "configured main counts",
+			global: main,
+			want:   "main",
+		},
+		{
+			name:   "global default is left to detection",
+			global: DefaultConfig(),
+			want:   "",
+		},
+		{
+			name: "nothing configured",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveBaseBranch(tt.global, tt.project); got != tt.want {
+				t.Errorf("ResolveBaseBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectsRegistry_Scan(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(parts ...string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(append([]string{root}, parts...)...), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+	}
+	mkdir("web", ".git")
+	mkdir("api", ".git")
+	mkdir("notes")                   // Not a git repo
+	mkdir("tools", "nested", ".git") // Repo two levels deep
+	mkdir("registered", ".git")      // Already registered by path
+	mkdir("taken", ".git")           // Name used by another project
+	mkdir(".hidden", ".git")         // Dot directories are skipped
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	registry := &ProjectsRegistry{Projects: []Project{
+		{Name: "old-name", Path: filepath.Join(root, "registered")},
+		{Name: "taken", Path: "/elsewhere/taken"},
+	}}
+
+	found, err := registry.Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := []Project{
+		{Name: "api", Path: filepath.Join(root, "api")},
+		{Name: "web", Path: filepath.Join(root, "web")},
+	}
+	if len(found) != len(want) {
+		t.Fatalf("Scan() found %v, want %v", found, want)
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("Scan()[%d] = %v, want %v", i, found[i], want[i])
+		}
+	}
+
+	if _, err := registry.Scan(filepath.Join(root, "missing")); err == nil {
+		t.Error("Scan() of a missing directory should fail")
+	}
+}
+
+func TestProjectsRegistry_ScanDir(t *testing.T) {
+	registry := &ProjectsRegistry{ScanRoot: "/src"}
+	if dir, err := registry.ScanDir(); err != nil || dir != "/src" {
+		t.Errorf("ScanDir() = %q, %v, want /src", dir, err)
+	}
+
+	registry.ScanRoot = ""
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if dir, err := registry.ScanDir(); err != nil || dir != filepath.Dir(cwd) {
+		t.Errorf("ScanDir() = %q, %v, want the working directory's parent %q", dir, err, filepath.Dir(cwd))
+	}
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+// Known enum values for string settings
+var (
+	validWorkflowModes   = []string{"branch", "worktree", "origin"}
+	validMergeStrategies = []string{"merge", "rebase", "squash"}
+	validMultiplexers    = []string{"tmux", "zellij"}
+	validLogLevels       = []string{"debug", "info", "warn", "error"}
+	validColumnStatuses  = []string{"open", "in_progress", "blocked", "closed"}
+	validToastLevels     = []string{"info", "success", "warning", "error"}
+	validPRProviders     = []string{"github", "gitlab"}
+	validPaneSplits      = []string{"", "right", "below"}
+	validCheckMethods    = []string{"head", "tcp", "dns"}
+)
+
+// Validate checks the configuration for values that would otherwise
+// misbehave at runtime. It reports every problem at once, joined into a
+// single error with one line per invalid field.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// Git
+	if !contains(validWorkflowModes, c.Git.WorkflowMode) {
+		add("git.workflowMode must be one of %s, got %q", strings.Join(validWorkflowModes, ", "), c.Git.WorkflowMode)
+	}
+	if !contains(validMergeStrategies, c.Git.DefaultMergeStrategy) {
+		add("git.defaultMergeStrategy must be one of %s, got %q", strings.Join(validMergeStrategies, ", "), c.Git.DefaultMergeStrategy)
+	}
+
+	// Session
+	if !contains(validMultiplexers, c.Session.Multiplexer) {
+		add("session.multiplexer must be one of %s, got %q", strings.Join(validMultiplexers, ", "), c.Session.Multiplexer)
+	}
+	if c.Session.TimeoutMs <= 0 {
+		add("session.timeoutMs must be positive, got %d", c.Session.TimeoutMs)
+	}
+	if c.Session.AutoPauseIdleMinutes < 0 {
+		add("session.autoPauseIdleMinutes must not be negative, got %d", c.Session.AutoPauseIdleMinutes)
+	}
+	if c.Session.IdleTimeoutMinutes < 0 {
+		add("session.idleTimeoutMinutes must not be negative, got %d", c.Session.IdleTimeoutMinutes)
+	}
+	if c.Session.StuckTimeoutMs < 0 {
+		add("session.stuckTimeoutMs must not be negative, got %d", c.Session.StuckTimeoutMs)
+	}
+	if c.Session.LogMaxMB < 0 {
+		add("session.logMaxMB must not be negative, got %d", c.Session.LogMaxMB)
+	}
+	if c.Session.MaxConcurrent < 0 {
+		add("session.maxConcurrent must not be negative, got %d", c.Session.MaxConcurrent)
+	}
+	for i, pane := range c.Session.Layout.Panes {
+		if !contains(validPaneSplits, pane.Split) {
+			add("session.layout.panes[%d].split must be right or below, got %q", i, pane.Split)
+		}
+		if pane.Size < 0 || pane.Size > 90 {
+			add("session.layout.panes[%d].size must be between 0 and 90, got %d", i, pane.Size)
+		}
+	}
+	if err := checkWritableDir(c.Session.LogDir); err != nil {
+		add("session.logDir %q is not writable: %v", c.Session.LogDir, err)
+	}
+
+	// PR
+	if c.PR.Provider != "" && !contains(validPRProviders, c.PR.Provider) {
+		add("pr.provider must be one of %s, or empty to detect it, got %q", strings.Join(validPRProviders, ", "), c.PR.Provider)
+	}
+
+	// Merge
+	if !contains(validMergeStrategies, c.Merge.Strategy) {
+		add("merge.strategy must be one of %s, got %q", strings.Join(validMergeStrategies, ", "), c.Merge.Strategy)
+	}
+
+	// Notifications
+	if c.Notifications.ErrorThreshold < 0 {
+		add("notifications.errorThreshold must not be negative, got %d", c.Notifications.ErrorThreshold)
+	}
+
+	// Beads
+	if c.Beads.SyncInterval <= 0 {
+		add("beads.syncInterval must be positive, got %d", c.Beads.SyncInterval)
+	}
+
+	// Network
+	if c.Network.CheckInterval <= 0 {
+		add("network.checkInterval must be positive, got %d", c.Network.CheckInterval)
+	}
+	if c.Network.OfflineTimeout <= 0 {
+		add("network.offlineTimeout must be positive, got %d", c.Network.OfflineTimeout)
+	}
+	if c.Network.RetryAttempts < 0 {
+		add("network.retryAttempts must not be negative, got %d", c.Network.RetryAttempts)
+	}
+	if u, err := url.Parse(c.Network.CheckURL); err != nil || u.Hostname() == "" {
+		add("network.checkURL must be a URL with a host, got %q", c.Network.CheckURL)
+	}
+	if !contains(validCheckMethods, c.Network.CheckMethod) {
+		add("network.checkMethod must be one of %s, got %q", strings.Join(validCheckMethods, ", "), c.Network.CheckMethod)
+	}
+
+	// DevServer
+	if !validPort(c.DevServer.BasePort) {
+		add("devServer.basePort must be between 1 and 65535, got %d", c.DevServer.BasePort)
+	}
+	if !validPort(c.DevServer.MaxPort) {
+		add("devServer.maxPort must be between 1 and 65535, got %d", c.DevServer.MaxPort)
+	}
+	if c.DevServer.BasePort > c.DevServer.MaxPort {
+		add("devServer.basePort (%d) must not exceed devServer.maxPort (%d)", c.DevServer.BasePort, c.DevServer.MaxPort)
+	}
+
+	// Worktree
+	if c.Worktree.KeepDays < 0 {
+		add("worktree.keepDays must not be negative, got %d", c.Worktree.KeepDays)
+	}
+	// Without the bead in the name every bead would get the same worktree
+	if f := c.Worktree.NameFormat; f != "" && !strings.Contains(f, "{beadID}") && !strings.Contains(f, "{branch}") {
+		add("worktree.nameFormat must contain {beadID} or {branch}, got %q", f)
+	}
+
+	// Monitor
+	if c.Monitor.MinConfidence < 0 || c.Monitor.MinConfidence >= 1 {
+		add("monitor.minConfidence must be in [0, 1), got %g", c.Monitor.MinConfidence)
+	}
+	if c.Monitor.HistoryLines < 0 {
+		add("monitor.historyLines must not be negative, got %d", c.Monitor.HistoryLines)
+	}
+	if c.Monitor.CaptureLines < 0 {
+		add("monitor.captureLines must not be negative, got %d", c.Monitor.CaptureLines)
+	}
+
+	// Diagnostics
+	if c.Diagnostics.MaxPortConflicts < 0 {
+		add("diagnostics.maxPortConflicts must not be negative, got %d", c.Diagnostics.MaxPortConflicts)
+	}
+	if c.Diagnostics.MaxMemoryMB <= 0 {
+		add("diagnostics.maxMemoryMB must be positive, got %d", c.Diagnostics.MaxMemoryMB)
+	}
+	if c.Diagnostics.MaxGoroutines <= 0 {
+		add("diagnostics.maxGoroutines must be positive, got %d", c.Diagnostics.MaxGoroutines)
+	}
+	if c.Diagnostics.RefreshInterval < 1 || c.Diagnostics.RefreshInterval > 60 {
+		add("diagnostics.refreshInterval must be between 1 and 60 seconds, got %d", c.Diagnostics.RefreshInterval)
+	}
+
+	// UI
+	if !contains(validLogLevels, c.UI.LogLevel) {
+		add("ui.logLevel must be one of %s, got %q", strings.Join(validLogLevels, ", "), c.UI.LogLevel)
+	}
+	levels := make([]string, 0, len(c.UI.ToastDurations))
+	for level := range c.UI.ToastDurations {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		if !contains(validToastLevels, level) {
+			add("ui.toastDurations keys must be one of %s, got %q", strings.Join(validToastLevels, ", "), level)
+		} else if c.UI.ToastDurations[level] <= 0 {
+			add("ui.toastDurations.%s must be positive, got %d", level, c.UI.ToastDurations[level])
+		}
+	}
+
+	// Board
+	// Custom statuses in columns may also key WIP limits and colors
+	columnStatuses := append([]string(nil), validColumnStatuses...)
+	columned := make(map[string]bool, len(c.Board.Columns))
+	for i, col := range c.Board.Columns {
+		builtin := contains(validColumnStatuses, col.Status)
+		switch {
+		case col.Title == "":
+			add("board.columns[%d].title must not be empty", i)
+		case col.Status == "":
+			add("board.columns[%d].status must not be empty", i)
+		case columned[col.Status]:
+			add("board.columns[%d].status %q already has a column", i, col.Status)
+		case builtin && col.Base != "":
+			add("board.columns[%d].base is only for custom statuses, %q is bd's own", i, col.Status)
+		case !builtin && !contains(validColumnStatuses, col.Base):
+			add("board.columns[%d].base must be one of %s for custom status %q, got %q", i, strings.Join(validColumnStatuses, ", "), col.Status, col.Base)
+		}
+		if !columned[col.Status] && !builtin && col.Status != "" {
+			columnStatuses = append(columnStatuses, col.Status)
+		}
+		columned[col.Status] = true
+	}
+
+	statuses := make([]string, 0, len(c.Board.WIPLimits))
+	for status := range c.Board.WIPLimits {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if !contains(columnStatuses, status) {
+			add("board.wipLimits keys must be one of %s, got %q", strings.Join(columnStatuses, ", "), status)
+		} else if c.Board.WIPLimits[status] < 0 {
+			add("board.wipLimits.%s must not be negative, got %d", status, c.Board.WIPLimits[status])
+		}
+	}
+
+	colored := make([]string, 0, len(c.Board.StatusColors))
+	for status := range c.Board.StatusColors {
+		colored = append(colored, status)
+	}
+	sort.Strings(colored)
+	for _, status := range colored {
+		color := c.Board.StatusColors[status]
+		if !contains(columnStatuses, status) {
+			add("board.statusColors keys must be one of %s, got %q", strings.Join(columnStatuses, ", "), status)
+		} else if _, ok := styles.ParseColor(color); !ok {
+			add("board.statusColors.%s must be a color name, #rrggbb or an ANSI color number, got %q", status, color)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkWritableDir checks that dir, or the nearest ancestor that exists
+// when it doesn't yet, is a directory with write permission. It only looks:
+// the directory is created when logging starts.
+func checkWritableDir(dir string) error {
+	if dir == "" {
+		return errors.New("path is empty")
+	}
+	if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+
+	path := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(path)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", path)
+			}
+			if info.Mode().Perm()&0222 == 0 {
+				return fmt.Errorf("%s is read-only", path)
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return err
+		}
+		path = parent
+	}
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
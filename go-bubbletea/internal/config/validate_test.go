@@ -0,0 +1,357 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns the defaults with a log directory inside the test's temp dir
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Session.LogDir = filepath.Join(t.TempDir(), "logs")
+	return cfg
+}
+
+func TestValidate_Defaults(t *testing.T) {
+	cfg := validConfig(t)
+	require.NoError(t, cfg.Validate())
+
+	// Validation only looks; the log directory is created when logging starts
+	_, err := os.Stat(cfg.Session.LogDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestValidate_OriginWorkflowMode(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Git.WorkflowMode = "origin"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_ReadOnlyLogDir(t *testing.T) {
+	cfg := validConfig(t)
+	parent := filepath.Dir(cfg.Session.LogDir)
+	require.NoError(t, os.Chmod(parent, 0555))
+	t.Cleanup(func() { os.Chmod(parent, 0755) })
+
+	assert.ErrorContains(t, cfg.Validate(), "is read-only")
+}
+
+func TestValidate_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "unknown workflow mode",
+			mutate:  func(cfg *Config) { cfg.Git.WorkflowMode = "trunk" },
+			wantErr: "git.workflowMode",
+		},
+		{
+			name:    "unknown default merge strategy",
+			mutate:  func(cfg *Config) { cfg.Git.DefaultMergeStrategy = "octopus" },
+			wantErr: "git.defaultMergeStrategy",
+		},
+		{
+			name:    "unknown multiplexer",
+			mutate:  func(cfg *Config) { cfg.Session.Multiplexer = "screen" },
+			wantErr: "session.multiplexer",
+		},
+		{
+			name:    "negative session timeout",
+			mutate:  func(cfg *Config) { cfg.Session.TimeoutMs = -5 },
+			wantErr: "session.timeoutMs",
+		},
+		{
+			name:    "negative auto-pause threshold",
+			mutate:  func(cfg *Config) { cfg.Session.AutoPauseIdleMinutes = -10 },
+			wantErr: "session.autoPauseIdleMinutes",
+		},
+		{
+			name:    "negative idle timeout",
+			mutate:  func(cfg *Config) { cfg.Session.IdleTimeoutMinutes = -1 },
+			wantErr: "session.idleTimeoutMinutes",
+		},
+		{
+			name:    "negative stuck timeout",
+			mutate:  func(cfg *Config) { cfg.Session.StuckTimeoutMs = -1 },
+			wantErr: "session.stuckTimeoutMs",
+		},
+		{
+			name: "unknown pane split",
+			mutate: func(cfg *Config) {
+				cfg.Session.Layout.Panes = []PaneConfig{{Split: "left"}}
+			},
+			wantErr: "session.layout.panes[0].split",
+		},
+		{
+			name: "pane too large",
+			mutate: func(cfg *Config) {
+				cfg.Session.Layout.Panes = []PaneConfig{{Split: "right"}, {Split: "below", Size: 95}}
+			},
+			wantErr: "session.layout.panes[1].size",
+		},
+		{
+			name:    "negative max concurrent sessions",
+			mutate:  func(cfg *Config) { cfg.Session.MaxConcurrent = -1 },
+			wantErr: "session.maxConcurrent",
+		},
+		{
+			name: "log dir under a file",
+			mutate: func(cfg *Config) {
+				parent := filepath.Join(filepath.Dir(cfg.Session.LogDir), "not-a-dir")
+				require.NoError(t, os.WriteFile(parent, []byte("x"), 0644))
+				cfg.Session.LogDir = filepath.Join(parent, "logs")
+			},
+			wantErr: "session.logDir",
+		},
+		{
+			name:    "empty log dir",
+			mutate:  func(cfg *Config) { cfg.Session.LogDir = "" },
+			wantErr: "session.logDir",
+		},
+		{
+			name:    "unknown PR provider",
+			mutate:  func(cfg *Config) { cfg.PR.Provider = "bitbucket" },
+			wantErr: "pr.provider",
+		},
+		{
+			name:    "unknown merge strategy",
+			mutate:  func(cfg *Config) { cfg.Merge.Strategy = "fast-forward" },
+			wantErr: "merge.strategy",
+		},
+		{
+			name:    "negative error threshold",
+			mutate:  func(cfg *Config) { cfg.Notifications.ErrorThreshold = -1 },
+			wantErr: "notifications.errorThreshold",
+		},
+		{
+			name:    "negative beads sync interval",
+			mutate:  func(cfg *Config) { cfg.Beads.SyncInterval = -300 },
+			wantErr: "beads.syncInterval",
+		},
+		{
+			name:    "negative network check interval",
+			mutate:  func(cfg *Config) { cfg.Network.CheckInterval = -1 },
+			wantErr: "network.checkInterval",
+		},
+		{
+			name:    "negative offline timeout",
+			mutate:  func(cfg *Config) { cfg.Network.OfflineTimeout = -1 },
+			wantErr: "network.offlineTimeout",
+		},
+		{
+			name:    "negative retry attempts",
+			mutate:  func(cfg *Config) { cfg.Network.RetryAttempts = -2 },
+			wantErr: "network.retryAttempts",
+		},
+		{
+			name:    "check URL without a host",
+			mutate:  func(cfg *Config) { cfg.Network.CheckURL = "github.com" },
+			wantErr: "network.checkURL",
+		},
+		{
+			name:    "unknown check method",
+			mutate:  func(cfg *Config) { cfg.Network.CheckMethod = "icmp" },
+			wantErr: "network.checkMethod must be one of head, tcp, dns",
+		},
+		{
+			name:    "base port out of range",
+			mutate:  func(cfg *Config) { cfg.DevServer.BasePort = -1 },
+			wantErr: "devServer.basePort must be between",
+		},
+		{
+			name:    "max port out of range",
+			mutate:  func(cfg *Config) { cfg.DevServer.MaxPort = 70000 },
+			wantErr: "devServer.maxPort",
+		},
+		{
+			name: "base port above max port",
+			mutate: func(cfg *Config) {
+				cfg.DevServer.BasePort = 4000
+				cfg.DevServer.MaxPort = 3000
+			},
+			wantErr: "must not exceed devServer.maxPort",
+		},
+		{
+			name:    "negative keep days",
+			mutate:  func(cfg *Config) { cfg.Worktree.KeepDays = -7 },
+			wantErr: "worktree.keepDays",
+		},
+		{
+			name:    "negative session log size",
+			mutate:  func(cfg *Config) { cfg.Session.LogMaxMB = -1 },
+			wantErr: "session.logMaxMB",
+		},
+		{
+			name:    "worktree name format without the bead",
+			mutate:  func(cfg *Config) { cfg.Worktree.NameFormat = "{project}-wt" },
+			wantErr: "worktree.nameFormat",
+		},
+		{
+			name:    "min confidence out of range",
+			mutate:  func(cfg *Config) { cfg.Monitor.MinConfidence = 1.5 },
+			wantErr: "monitor.minConfidence",
+		},
+		{
+			name:    "negative history lines",
+			mutate:  func(cfg *Config) { cfg.Monitor.HistoryLines = -1 },
+			wantErr: "monitor.historyLines",
+		},
+		{
+			name:    "negative capture lines",
+			mutate:  func(cfg *Config) { cfg.Monitor.CaptureLines = -1 },
+			wantErr: "monitor.captureLines",
+		},
+		{
+			name:    "negative port conflict allowance",
+			mutate:  func(cfg *Config) { cfg.Diagnostics.MaxPortConflicts = -1 },
+			wantErr: "diagnostics.maxPortConflicts",
+		},
+		{
+			name:    "negative memory ceiling",
+			mutate:  func(cfg *Config) { cfg.Diagnostics.MaxMemoryMB = -512 },
+			wantErr: "diagnostics.maxMemoryMB",
+		},
+		{
+			name:    "zero goroutine ceiling",
+			mutate:  func(cfg *Config) { cfg.Diagnostics.MaxGoroutines = 0 },
+			wantErr: "diagnostics.maxGoroutines",
+		},
+		{
+			name:    "refresh interval too long",
+			mutate:  func(cfg *Config) { cfg.Diagnostics.RefreshInterval = 600 },
+			wantErr: "diagnostics.refreshInterval must be between 1 and 60 seconds, got 600",
+		},
+		{
+			name:    "unknown log level",
+			mutate:  func(cfg *Config) { cfg.UI.LogLevel = "verbose" },
+			wantErr: "ui.logLevel",
+		},
+		{
+			name:    "unknown toast level",
+			mutate:  func(cfg *Config) { cfg.UI.ToastDurations = map[string]int{"fatal": 1000} },
+			wantErr: `ui.toastDurations keys must be one of info, success, warning, error, got "fatal"`,
+		},
+		{
+			name:    "zero toast duration",
+			mutate:  func(cfg *Config) { cfg.UI.ToastDurations = map[string]int{"error": 0} },
+			wantErr: "ui.toastDurations.error",
+		},
+		{
+			name:    "unknown WIP limit column",
+			mutate:  func(cfg *Config) { cfg.Board.WIPLimits = map[string]int{"in_progress": 3, "review": 2} },
+			wantErr: `board.wipLimits keys must be one of open, in_progress, blocked, closed, got "review"`,
+		},
+		{
+			name:    "negative WIP limit",
+			mutate:  func(cfg *Config) { cfg.Board.WIPLimits = map[string]int{"in_progress": -1} },
+			wantErr: "board.wipLimits.in_progress",
+		},
+		{
+			name:    "status color for unknown status",
+			mutate:  func(cfg *Config) { cfg.Board.StatusColors = map[string]string{"review": "blue"} },
+			wantErr: `board.statusColors keys must be one of open, in_progress, blocked, closed, got "review"`,
+		},
+		{
+			name:    "unknown status color",
+			mutate:  func(cfg *Config) { cfg.Board.StatusColors = map[string]string{"blocked": "crimson"} },
+			wantErr: "board.statusColors.blocked",
+		},
+		{
+			name:    "untitled column",
+			mutate:  func(cfg *Config) { cfg.Board.Columns = []ColumnConfig{{Status: "open"}} },
+			wantErr: "board.columns[0].title",
+		},
+		{
+			name:    "custom status without a base",
+			mutate:  func(cfg *Config) { cfg.Board.Columns = []ColumnConfig{{Title: "Review", Status: "review"}} },
+			wantErr: `board.columns[0].base must be one of open, in_progress, blocked, closed for custom status "review", got ""`,
+		},
+		{
+			name: "base on a built-in status",
+			mutate: func(cfg *Config) {
+				cfg.Board.Columns = []ColumnConfig{{Title: "Doing", Status: "in_progress", Base: "open"}}
+			},
+			wantErr: "board.columns[0].base is only for custom statuses",
+		},
+		{
+			name: "two columns for one status",
+			mutate: func(cfg *Config) {
+				cfg.Board.Columns = []ColumnConfig{{Title: "Open", Status: "open"}, {Title: "Todo", Status: "open"}}
+			},
+			wantErr: `board.columns[1].status "open" already has a column`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidate_CustomStatusColumns(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Board.Columns = []ColumnConfig{
+		{Title: "Open", Status: "open"},
+		{Title: "Review", Status: "review", Base: "in_progress"},
+		{Title: "Done", Status: "closed"},
+	}
+	cfg.Board.WIPLimits = map[string]int{"review": 2}
+	cfg.Board.StatusColors = map[string]string{"review": "mauve"}
+	require.NoError(t, cfg.Validate())
+
+	// Keys must still name a status with a column or one of bd's own
+	cfg.Board.WIPLimits = map[string]int{"qa": 1}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `board.wipLimits keys must be one of open, in_progress, blocked, closed, review, got "qa"`)
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Session.TimeoutMs = -1
+	cfg.DevServer.BasePort = 5000
+	cfg.DevServer.MaxPort = 4000
+	cfg.Git.WorkflowMode = "trunk"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	lines := strings.Split(err.Error(), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, err.Error(), "session.timeoutMs")
+	assert.Contains(t, err.Error(), "devServer.basePort")
+	assert.Contains(t, err.Error(), "git.workflowMode")
+}
+
+func TestValidate_LoadedConfigNegativeTimeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	configJSON := `{"session": {"timeoutMs": -100, "logDir": "` + filepath.ToSlash(filepath.Join(tmpDir, "logs")) + `"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".azedarach.json"), []byte(configJSON), 0644))
+
+	cfg, err := LoadConfig(tmpDir)
+	require.NoError(t, err)
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "session.timeoutMs must be positive, got -100")
+}
+
+func TestValidate_StatusColors(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Board.StatusColors = map[string]string{"blocked": "Red", "in_progress": "#89b4fa", "open": "33", "closed": "#abc"}
+	assert.NoError(t, cfg.Validate())
+}
@@ -25,6 +25,8 @@ type VersionedConfig struct {
 	Network       NetworkConfig   `json:"network,omitempty"`
 	DevServer     DevServerConfig `json:"devServer,omitempty"`
 	Worktree      WorktreeConfig  `json:"worktree,omitempty"`
+	Monitor       MonitorConfig   `json:"monitor,omitempty"`
+	UI            UIConfig        `json:"ui,omitempty"`
 }
 
 // Migration represents a config migration function
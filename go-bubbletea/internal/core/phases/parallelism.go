@@ -0,0 +1,69 @@
+package phases
+
+import (
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// Parallelism compares how many tasks could be worked at once with how many
+// sessions are actually working
+type Parallelism struct {
+	// Ready is the number of workable tasks in phase 0 (no unfinished blockers)
+	Ready int
+	// Active is the number of sessions currently busy or waiting for input
+	Active int
+}
+
+// ComputeParallelism derives the parallelization metric from the board.
+//
+// Only open and in-progress non-epic tasks are candidates; closed tasks are
+// excluded before phase computation so they no longer block their dependents.
+func ComputeParallelism(tasks []domain.Task) Parallelism {
+	candidates := make(map[string]domain.Task)
+	candidateIDs := make(map[string]bool)
+	var result Parallelism
+
+	for _, task := range tasks {
+		if task.Session != nil && isActiveSession(task.Session.State) {
+			result.Active++
+		}
+
+		if task.Type == domain.TypeEpic {
+			continue
+		}
+		if task.Status != domain.StatusOpen && task.Status != domain.StatusInProgress {
+			continue
+		}
+		candidates[task.ID] = task
+		candidateIDs[task.ID] = true
+	}
+
+	phases := ComputeDependencyPhases(candidateIDs, candidates)
+	result.Ready = phases.PhaseCounts[0]
+
+	return result
+}
+
+// Efficiency returns the share of ready tasks that have an active session,
+// between 0 and 1. With nothing ready there is no idle capacity, so it is 1.
+func (p Parallelism) Efficiency() float64 {
+	if p.Ready <= 0 {
+		return 1
+	}
+	if p.Active >= p.Ready {
+		return 1
+	}
+	return float64(p.Active) / float64(p.Ready)
+}
+
+// Available returns how many more sessions could be started on ready tasks
+func (p Parallelism) Available() int {
+	if p.Active >= p.Ready {
+		return 0
+	}
+	return p.Ready - p.Active
+}
+
+// isActiveSession reports whether a session in this state is occupying an agent
+func isActiveSession(state domain.SessionState) bool {
+	return state == domain.SessionBusy || state == domain.SessionWaiting
+}
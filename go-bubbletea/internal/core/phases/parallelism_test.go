@@ -0,0 +1,85 @@
+package phases
+
+import (
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func TestParallelism_Efficiency(t *testing.T) {
+	tests := []struct {
+		name          string
+		ready         int
+		active        int
+		wantEff       float64
+		wantAvailable int
+	}{
+		{name: "nothing ready", ready: 0, active: 0, wantEff: 1, wantAvailable: 0},
+		{name: "nothing active", ready: 4, active: 0, wantEff: 0, wantAvailable: 4},
+		{name: "partially used", ready: 4, active: 1, wantEff: 0.25, wantAvailable: 3},
+		{name: "fully used", ready: 3, active: 3, wantEff: 1, wantAvailable: 0},
+		{name: "more active than ready", ready: 2, active: 5, wantEff: 1, wantAvailable: 0},
+		{name: "active with nothing ready", ready: 0, active: 2, wantEff: 1, wantAvailable: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Parallelism{Ready: tt.ready, Active: tt.active}
+
+			if got := p.Efficiency(); got != tt.wantEff {
+				t.Errorf("Efficiency() = %v, want %v", got, tt.wantEff)
+			}
+			if got := p.Available(); got != tt.wantAvailable {
+				t.Errorf("Available() = %v, want %v", got, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestComputeParallelism(t *testing.T) {
+	withSession := func(task domain.Task, state domain.SessionState) domain.Task {
+		task.Session = &domain.Session{BeadID: task.ID, State: state}
+		return task
+	}
+	withStatus := func(task domain.Task, status domain.Status) domain.Task {
+		task.Status = status
+		return task
+	}
+
+	epic := makeTask("az-epic", "Epic")
+	epic.Type = domain.TypeEpic
+
+	tasks := []domain.Task{
+		withSession(makeTask("az-1", "Ready and busy"), domain.SessionBusy),
+		makeTask("az-2", "Ready and idle"),
+		makeTask("az-3", "Blocked by az-1", "az-1"),
+		makeTask("az-4", "Blocked by closed az-5", "az-5"),
+		withStatus(makeTask("az-5", "Closed"), domain.StatusDone),
+		withStatus(makeTask("az-6", "Externally blocked"), domain.StatusBlocked),
+		withSession(withStatus(makeTask("az-7", "In progress, waiting"), domain.StatusInProgress), domain.SessionWaiting),
+		withSession(makeTask("az-8", "Session finished"), domain.SessionDone),
+		epic,
+	}
+
+	got := ComputeParallelism(tasks)
+
+	// Ready: az-1, az-2, az-4 (closed blocker no longer counts), az-7, az-8
+	if got.Ready != 5 {
+		t.Errorf("Ready = %d, want 5", got.Ready)
+	}
+	// Active: az-1 (busy), az-7 (waiting)
+	if got.Active != 2 {
+		t.Errorf("Active = %d, want 2", got.Active)
+	}
+}
+
+func TestComputeParallelism_Empty(t *testing.T) {
+	got := ComputeParallelism(nil)
+
+	if got.Ready != 0 || got.Active != 0 {
+		t.Errorf("ComputeParallelism(nil) = %+v, want zero", got)
+	}
+	if got.Efficiency() != 1 {
+		t.Errorf("Efficiency() = %v, want 1", got.Efficiency())
+	}
+}
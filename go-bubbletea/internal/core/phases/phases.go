@@ -221,3 +221,78 @@ func GetBlockerTitles(taskID string, phases map[string]TaskPhaseInfo, tasks map[
 
 	return titles
 }
+
+// HasCycle returns true if the "blocks" dependencies among tasks form a
+// cycle. It runs the same phase computation over all of them: tasks on a
+// cycle can't be peeled off, and end up in one phase blocked by each other.
+func HasCycle(tasks map[string]domain.Task) bool {
+	ids := make(map[string]bool, len(tasks))
+	for id := range tasks {
+		ids[id] = true
+	}
+
+	result := ComputeDependencyPhases(ids, tasks)
+	for _, info := range result.Phases {
+		for _, blockerID := range info.BlockedBy {
+			// Resolved blockers always sit in an earlier phase
+			if result.Phases[blockerID].Phase >= info.Phase {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WouldCreateCycle returns true if making blockerID block taskID would
+// close a cycle, including a task blocking itself
+//
+// Parameters:
+//   - tasks: Map of task ID to full Task (with dependencies)
+//   - taskID: The task that would be blocked
+//   - blockerID: The task that would block it
+func WouldCreateCycle(tasks map[string]domain.Task, taskID, blockerID string) bool {
+	if taskID == blockerID {
+		return true
+	}
+
+	task, exists := tasks[taskID]
+	if !exists {
+		return false
+	}
+
+	withDep := make(map[string]domain.Task, len(tasks))
+	for id, t := range tasks {
+		withDep[id] = t
+	}
+	task.Dependencies = append(append([]domain.Dependency(nil), task.Dependencies...), domain.Dependency{
+		ID:   blockerID,
+		Type: domain.DependencyBlocks,
+	})
+	withDep[taskID] = task
+
+	return HasCycle(withDep)
+}
+
+// ComputeOpenBlockers finds the tasks held up by blockers that aren't done
+// yet, across the whole task set rather than within an epic. Done tasks are
+// left out of the phase computation, so only open blockers remain.
+//
+// Returns: Map from blocked task ID to the IDs of its open blockers
+func ComputeOpenBlockers(tasks map[string]domain.Task) map[string][]string {
+	open := make(map[string]bool, len(tasks))
+	for id, task := range tasks {
+		if task.Status != domain.StatusDone {
+			open[id] = true
+		}
+	}
+
+	blocked := make(map[string][]string)
+	for id, info := range ComputeDependencyPhases(open, tasks).Phases {
+		// Ready tasks have no blockers left, except on a cycle, where
+		// every task is blocked
+		if len(info.BlockedBy) > 0 {
+			blocked[id] = info.BlockedBy
+		}
+	}
+	return blocked
+}
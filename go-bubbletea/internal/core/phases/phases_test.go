@@ -450,3 +450,89 @@ func TestComputeDependencyPhases_OnlyBlockedByDependencies(t *testing.T) {
 		}
 	}
 }
+
+func TestWouldCreateCycle(t *testing.T) {
+	// az-3 is blocked by az-2, which is blocked by az-1
+	tasks := map[string]domain.Task{
+		"az-1": makeTask("az-1", "First"),
+		"az-2": makeTask("az-2", "Second", "az-1"),
+		"az-3": makeTask("az-3", "Third", "az-2"),
+		"az-4": makeTask("az-4", "Unrelated"),
+	}
+
+	tests := []struct {
+		name      string
+		taskID    string
+		blockerID string
+		want      bool
+	}{
+		{"extends the chain", "az-4", "az-3", false},
+		{"shortcut in the same direction", "az-3", "az-1", false},
+		{"direct reversal", "az-1", "az-2", true},
+		{"closes the chain", "az-1", "az-3", true},
+		{"self", "az-4", "az-4", true},
+		{"unknown task", "az-9", "az-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WouldCreateCycle(tasks, tt.taskID, tt.blockerID); got != tt.want {
+				t.Errorf("WouldCreateCycle(%s, %s) = %v, want %v", tt.taskID, tt.blockerID, got, tt.want)
+			}
+		})
+	}
+
+	// The tasks passed in are left alone
+	if len(tasks["az-1"].Dependencies) != 0 {
+		t.Errorf("Expected az-1 unchanged, got %+v", tasks["az-1"].Dependencies)
+	}
+}
+
+func TestHasCycle(t *testing.T) {
+	acyclic := map[string]domain.Task{
+		"az-1": makeTask("az-1", "First"),
+		"az-2": makeTask("az-2", "Second", "az-1"),
+	}
+	if HasCycle(acyclic) {
+		t.Error("Expected no cycle in a chain")
+	}
+
+	// A cycle behind an otherwise ready task is still found
+	cyclic := map[string]domain.Task{
+		"az-1": makeTask("az-1", "Ready"),
+		"az-2": makeTask("az-2", "A", "az-3", "az-1"),
+		"az-3": makeTask("az-3", "B", "az-2"),
+	}
+	if !HasCycle(cyclic) {
+		t.Error("Expected the az-2/az-3 cycle to be found")
+	}
+}
+
+func TestComputeOpenBlockers(t *testing.T) {
+	done := makeTask("az-1", "Done blocker")
+	done.Status = domain.StatusDone
+
+	tasks := map[string]domain.Task{
+		"az-1": done,
+		"az-2": makeTask("az-2", "Open blocker"),
+		"az-3": makeTask("az-3", "Blocked by done", "az-1"),
+		"az-4": makeTask("az-4", "Blocked by open", "az-2"),
+		"az-5": makeTask("az-5", "Blocked by both", "az-1", "az-2"),
+		"az-6": makeTask("az-6", "Blocked by missing", "az-99"),
+	}
+
+	got := ComputeOpenBlockers(tasks)
+
+	want := map[string][]string{
+		"az-4": {"az-2"},
+		"az-5": {"az-2"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for id, blockers := range want {
+		if len(got[id]) != 1 || got[id][0] != blockers[0] {
+			t.Errorf("%s: expected blockers %v, got %v", id, blockers, got[id])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package phases
+
+import (
+	"math"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// PlanParallelizationScore scores how much of a plan can be worked at once,
+// from its dependency graph rather than the model's own estimate: 100 when
+// every task can start right away, 0 when the tasks form a single chain.
+//
+// The score compares the critical path (the number of phases) with the
+// number of tasks: (tasks - phases) / (tasks - 1). A plan with one task
+// scores 100 and an empty plan 0. Dependencies on IDs outside the plan are
+// ignored.
+func PlanParallelizationScore(plan *domain.Plan) int {
+	n := len(plan.Tasks)
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		return 100
+	}
+
+	ids := make(map[string]bool, n)
+	tasks := make(map[string]domain.Task, n)
+	for _, planned := range plan.Tasks {
+		task := domain.Task{ID: planned.ID}
+		for _, dep := range planned.DependsOn {
+			task.Dependencies = append(task.Dependencies, domain.Dependency{ID: dep, Type: domain.DependencyBlocks})
+		}
+		ids[planned.ID] = true
+		tasks[planned.ID] = task
+	}
+
+	result := ComputeDependencyPhases(ids, tasks)
+	phases := result.MaxPhase + 1
+
+	return int(math.Round(100 * float64(n-phases) / float64(n-1)))
+}
@@ -0,0 +1,59 @@
+package phases
+
+import (
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func TestPlanParallelizationScore(t *testing.T) {
+	task := func(id string, deps ...string) domain.PlannedTask {
+		return domain.PlannedTask{ID: id, DependsOn: deps}
+	}
+
+	tests := []struct {
+		name  string
+		tasks []domain.PlannedTask
+		want  int
+	}{
+		{
+			name: "empty plan",
+			want: 0,
+		},
+		{
+			name:  "single task",
+			tasks: []domain.PlannedTask{task("t1")},
+			want:  100,
+		},
+		{
+			name:  "fully parallel",
+			tasks: []domain.PlannedTask{task("t1"), task("t2"), task("t3"), task("t4")},
+			want:  100,
+		},
+		{
+			name:  "linear chain",
+			tasks: []domain.PlannedTask{task("t1"), task("t2", "t1"), task("t3", "t2"), task("t4", "t3")},
+			want:  0,
+		},
+		{
+			name: "fan out after one task",
+			// t1 -> {t2, t3, t4, t5}: two phases over five tasks
+			tasks: []domain.PlannedTask{task("t1"), task("t2", "t1"), task("t3", "t1"), task("t4", "t1"), task("t5", "t1")},
+			want:  75,
+		},
+		{
+			name:  "dependencies outside the plan are ignored",
+			tasks: []domain.PlannedTask{task("t1", "gone"), task("t2")},
+			want:  100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlanParallelizationScore(&domain.Plan{Tasks: tt.tasks, ParallelizationScore: 50})
+			if got != tt.want {
+				t.Errorf("PlanParallelizationScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
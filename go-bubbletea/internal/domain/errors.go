@@ -56,6 +56,24 @@ func (e *TmuxError) Unwrap() error {
 	return e.Err
 }
 
+// ZellijError represents an error from zellij operations
+type ZellijError struct {
+	Op      string
+	Session string
+	Err     error
+}
+
+func (e *ZellijError) Error() string {
+	if e.Session != "" {
+		return fmt.Sprintf("zellij %s [%s]: %v", e.Op, e.Session, e.Err)
+	}
+	return fmt.Sprintf("zellij %s: %v", e.Op, e.Err)
+}
+
+func (e *ZellijError) Unwrap() error {
+	return e.Err
+}
+
 // GitError represents an error from git operations
 type GitError struct {
 	Op       string
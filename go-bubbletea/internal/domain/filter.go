@@ -1,19 +1,63 @@
 package domain
 
 import (
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// MatchMode is how a filter combines its categories (status, priority, type,
+// session state, assignee and labels). Values within a category always OR.
+type MatchMode int
+
+const (
+	// MatchAllOf requires a task to match every active category
+	MatchAllOf MatchMode = iota
+	// MatchAnyOf requires a task to match at least one active category
+	MatchAnyOf
+)
+
+// String returns the mode as shown in the filter menu
+func (m MatchMode) String() string {
+	if m == MatchAnyOf {
+		return "any"
+	}
+	return "all"
+}
+
+// SearchScope is which fields a search query is matched against
+type SearchScope int
+
+const (
+	// SearchAllFields matches the title, ID, description and labels
+	SearchAllFields SearchScope = iota
+	// SearchTitleOnly matches just the title and ID
+	SearchTitleOnly
+)
+
+// String returns the scope as shown in the search bar
+func (s SearchScope) String() string {
+	if s == SearchTitleOnly {
+		return "title"
+	}
+	return "all fields"
+}
+
 // Filter represents task filtering state
 type Filter struct {
 	Status           map[Status]bool
 	Priority         map[Priority]bool
 	Type             map[TaskType]bool
 	SessionState     map[SessionState]bool
+	Assignee         map[string]bool // "" selects unassigned tasks
+	Labels           map[string]bool // Tasks with any of these labels
 	HideEpicChildren bool
+	HasAttachments   bool // Only tasks with at least one attachment
 	AgeMaxDays       *int
 	SearchQuery      string
+	SearchScope      SearchScope
+	MatchMode        MatchMode // Across categories; the toggles, age and search always apply
 }
 
 // NewFilter creates a new empty filter
@@ -23,6 +67,8 @@ func NewFilter() *Filter {
 		Priority:     make(map[Priority]bool),
 		Type:         make(map[TaskType]bool),
 		SessionState: make(map[SessionState]bool),
+		Assignee:     make(map[string]bool),
+		Labels:       make(map[string]bool),
 	}
 }
 
@@ -32,7 +78,10 @@ func (f *Filter) IsActive() bool {
 		len(f.Priority) > 0 ||
 		len(f.Type) > 0 ||
 		len(f.SessionState) > 0 ||
+		len(f.Assignee) > 0 ||
+		len(f.Labels) > 0 ||
 		f.HideEpicChildren ||
+		f.HasAttachments ||
 		f.AgeMaxDays != nil ||
 		f.SearchQuery != ""
 }
@@ -43,9 +92,11 @@ func (f *Filter) Apply(tasks []Task) []Task {
 		return tasks
 	}
 
+	// Lower the query once rather than per task
+	query := strings.ToLower(f.SearchQuery)
 	result := make([]Task, 0, len(tasks))
 	for _, task := range tasks {
-		if f.Matches(task) {
+		if f.matches(task, query) {
 			result = append(result, task)
 		}
 	}
@@ -53,37 +104,15 @@ func (f *Filter) Apply(tasks []Task) []Task {
 }
 
 // Matches returns true if the task passes all active filters
-// Uses AND logic between filter types, OR logic within filter types
+// Uses OR logic within filter types; between them MatchMode decides
 func (f *Filter) Matches(t Task) bool {
-	// Status filter (OR within)
-	if len(f.Status) > 0 {
-		if !f.Status[t.Status] {
-			return false
-		}
-	}
-
-	// Priority filter (OR within)
-	if len(f.Priority) > 0 {
-		if !f.Priority[t.Priority] {
-			return false
-		}
-	}
+	return f.matches(t, strings.ToLower(f.SearchQuery))
+}
 
-	// Type filter (OR within)
-	if len(f.Type) > 0 {
-		if !f.Type[t.Type] {
-			return false
-		}
-	}
-
-	// Session state filter (OR within)
-	if len(f.SessionState) > 0 {
-		if t.Session == nil {
-			return false
-		}
-		if !f.SessionState[t.Session.State] {
-			return false
-		}
+// matches is Matches with the search query already lower cased
+func (f *Filter) matches(t Task, query string) bool {
+	if !f.matchesCategories(t) {
+		return false
 	}
 
 	// Hide epic children
@@ -93,6 +122,11 @@ func (f *Filter) Matches(t Task) bool {
 		}
 	}
 
+	// Attachments
+	if f.HasAttachments && t.Attachments == 0 {
+		return false
+	}
+
 	// Age filter
 	if f.AgeMaxDays != nil {
 		// Calculate days since update (truncate to day boundaries for consistent comparison)
@@ -105,27 +139,107 @@ func (f *Filter) Matches(t Task) bool {
 		}
 	}
 
-	// Search query (case-insensitive, matches title or ID)
-	if f.SearchQuery != "" {
-		query := strings.ToLower(f.SearchQuery)
-		title := strings.ToLower(t.Title)
-		id := strings.ToLower(t.ID)
+	// Search query (case-insensitive, scope decides the fields)
+	if query != "" && !f.matchesSearch(t, query) {
+		return false
+	}
 
-		if !strings.Contains(title, query) && !strings.Contains(id, query) {
-			return false
+	return true
+}
+
+// matchesSearch reports whether the task contains query, already lower
+// cased, in a field the search scope covers. Title and ID are checked
+// first as they're short; description and labels only when those miss.
+func (f *Filter) matchesSearch(t Task, query string) bool {
+	if containsFold(t.Title, query) || containsFold(t.ID, query) {
+		return true
+	}
+	if f.SearchScope == SearchTitleOnly {
+		return false
+	}
+	if containsFold(t.Description, query) {
+		return true
+	}
+	return slices.ContainsFunc(t.Labels, func(label string) bool { return containsFold(label, query) })
+}
+
+// containsFold reports whether s contains query, which must already be lower
+// cased, ignoring case. ASCII text is compared in place so long descriptions
+// aren't copied; anything else is lowered first.
+func containsFold(s, query string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return strings.Contains(strings.ToLower(s), query)
+		}
+	}
+	for i := 0; i+len(query) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(query)], query) {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// matchesCategories applies the status, priority, type, session state,
+// assignee and label filters, each OR within, combined by MatchMode
+func (f *Filter) matchesCategories(t Task) bool {
+	var active, matched int
+	check := func(ok bool) {
+		active++
+		if ok {
+			matched++
+		}
+	}
+
+	if len(f.Status) > 0 {
+		check(f.Status[t.Status])
+	}
+	if len(f.Priority) > 0 {
+		check(f.Priority[t.Priority])
+	}
+	if len(f.Type) > 0 {
+		check(f.Type[t.Type])
+	}
+	if len(f.SessionState) > 0 {
+		check(t.Session != nil && f.SessionState[t.Session.State])
+	}
+	if len(f.Assignee) > 0 {
+		check(f.Assignee[t.Assignee])
+	}
+	if len(f.Labels) > 0 {
+		// Any selected label
+		check(slices.ContainsFunc(t.Labels, func(label string) bool { return f.Labels[label] }))
+	}
+
+	if active == 0 {
+		return true
+	}
+	if f.MatchMode == MatchAnyOf {
+		return matched > 0
+	}
+	return matched == active
 }
 
-// Clear resets all filters
+// ToggleMatchMode switches between matching all and any categories
+func (f *Filter) ToggleMatchMode() {
+	if f.MatchMode == MatchAnyOf {
+		f.MatchMode = MatchAllOf
+	} else {
+		f.MatchMode = MatchAnyOf
+	}
+}
+
+// Clear resets all filters. The match mode and search scope are kept, as
+// they're how filters apply rather than filters themselves.
 func (f *Filter) Clear() {
 	f.Status = make(map[Status]bool)
 	f.Priority = make(map[Priority]bool)
 	f.Type = make(map[TaskType]bool)
 	f.SessionState = make(map[SessionState]bool)
+	f.Assignee = make(map[string]bool)
+	f.Labels = make(map[string]bool)
 	f.HideEpicChildren = false
+	f.HasAttachments = false
 	f.AgeMaxDays = nil
 	f.SearchQuery = ""
 }
@@ -165,3 +279,21 @@ func (f *Filter) ToggleSessionState(s SessionState) {
 		f.SessionState[s] = true
 	}
 }
+
+// ToggleAssignee toggles an assignee filter; "" is unassigned
+func (f *Filter) ToggleAssignee(assignee string) {
+	if f.Assignee[assignee] {
+		delete(f.Assignee, assignee)
+	} else {
+		f.Assignee[assignee] = true
+	}
+}
+
+// ToggleLabel toggles a label filter
+func (f *Filter) ToggleLabel(label string) {
+	if f.Labels[label] {
+		delete(f.Labels, label)
+	} else {
+		f.Labels[label] = true
+	}
+}
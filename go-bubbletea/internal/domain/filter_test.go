@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -51,6 +52,20 @@ func TestFilter_IsActive(t *testing.T) {
 			},
 			active: true,
 		},
+		{
+			name: "assignee filter is active",
+			setup: func(f *Filter) {
+				f.ToggleAssignee("alice")
+			},
+			active: true,
+		},
+		{
+			name: "label filter is active",
+			setup: func(f *Filter) {
+				f.ToggleLabel("backend")
+			},
+			active: true,
+		},
 		{
 			name: "search query is active",
 			setup: func(f *Filter) {
@@ -65,6 +80,13 @@ func TestFilter_IsActive(t *testing.T) {
 			},
 			active: true,
 		},
+		{
+			name: "has attachments is active",
+			setup: func(f *Filter) {
+				f.HasAttachments = true
+			},
+			active: true,
+		},
 		{
 			name: "age max is active",
 			setup: func(f *Filter) {
@@ -285,6 +307,62 @@ func TestFilter_Matches_SearchQuery(t *testing.T) {
 	}
 }
 
+func TestFilter_Matches_SearchScope(t *testing.T) {
+	task := Task{
+		ID:          "az-7",
+		Title:       "Speed up the board",
+		Description: "Rendering stalls on columns with HUNDREDS of cards",
+		Labels:      []string{"perf", "ui-Render"},
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		scope   SearchScope
+		matches bool
+	}{
+		{name: "description only", query: "hundreds", scope: SearchAllFields, matches: true},
+		{name: "description case-insensitive", query: "STALLS", scope: SearchAllFields, matches: true},
+		{name: "label", query: "render", scope: SearchAllFields, matches: true},
+		{name: "title still matches", query: "board", scope: SearchAllFields, matches: true},
+		{name: "nowhere", query: "database", scope: SearchAllFields, matches: false},
+		{name: "description ignored in title scope", query: "hundreds", scope: SearchTitleOnly, matches: false},
+		{name: "label ignored in title scope", query: "perf", scope: SearchTitleOnly, matches: false},
+		{name: "title in title scope", query: "speed", scope: SearchTitleOnly, matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFilter()
+			f.SearchQuery = tt.query
+			f.SearchScope = tt.scope
+			if got := f.Matches(task); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v for query %q in %s", got, tt.matches, tt.query, tt.scope)
+			}
+		})
+	}
+}
+
+func TestContainsFold(t *testing.T) {
+	tests := []struct {
+		s, query string
+		want     bool
+	}{
+		{"Fix Authentication", "authentication", true},
+		{"Fix Authentication", "auth x", false},
+		{"short", "longer than s", false},
+		{"anything", "", true},
+		{"Café crème ÉCLAIR", "éclair", true},
+		{"Café crème", "creme", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsFold(tt.s, tt.query); got != tt.want {
+			t.Errorf("containsFold(%q, %q) = %v, want %v", tt.s, tt.query, got, tt.want)
+		}
+	}
+}
+
 func TestFilter_Matches_HideEpicChildren(t *testing.T) {
 	f := NewFilter()
 	f.HideEpicChildren = true
@@ -317,6 +395,106 @@ func TestFilter_Matches_HideEpicChildren(t *testing.T) {
 	}
 }
 
+func TestFilter_Matches_Assignee(t *testing.T) {
+	f := NewFilter()
+	f.ToggleAssignee("alice")
+	f.ToggleAssignee("")
+
+	tests := []struct {
+		name     string
+		assignee string
+		matches  bool
+	}{
+		{name: "selected assignee", assignee: "alice", matches: true},
+		{name: "unassigned when selected", assignee: "", matches: true},
+		{name: "other assignee", assignee: "bob", matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{Assignee: tt.assignee}
+			if got := f.Matches(task); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestFilter_Matches_Labels(t *testing.T) {
+	f := NewFilter()
+	f.ToggleLabel("backend")
+	f.ToggleLabel("urgent")
+
+	tests := []struct {
+		name    string
+		labels  []string
+		matches bool
+	}{
+		{name: "one selected label", labels: []string{"backend"}, matches: true},
+		{name: "another selected label among others", labels: []string{"docs", "urgent"}, matches: true},
+		{name: "no selected label", labels: []string{"docs", "frontend"}, matches: false},
+		{name: "no labels", labels: nil, matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{Labels: tt.labels}
+			if got := f.Matches(task); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestFilter_Matches_AssigneeAndLabels(t *testing.T) {
+	// Categories AND together: alice's backend tasks only
+	f := NewFilter()
+	f.ToggleAssignee("alice")
+	f.ToggleLabel("backend")
+
+	tasks := []Task{
+		{ID: "az-1", Assignee: "alice", Labels: []string{"backend"}},
+		{ID: "az-2", Assignee: "alice", Labels: []string{"frontend"}},
+		{ID: "az-3", Assignee: "bob", Labels: []string{"backend"}},
+	}
+
+	result := f.Apply(tasks)
+	if len(result) != 1 || result[0].ID != "az-1" {
+		t.Errorf("Apply() = %v, want only az-1", result)
+	}
+}
+
+func TestFilter_Matches_HasAttachments(t *testing.T) {
+	f := NewFilter()
+	f.HasAttachments = true
+
+	tests := []struct {
+		name        string
+		attachments int
+		matches     bool
+	}{
+		{
+			name:        "task without attachments is hidden",
+			attachments: 0,
+			matches:     false,
+		},
+		{
+			name:        "task with attachments is shown",
+			attachments: 2,
+			matches:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Task{Attachments: tt.attachments}
+			if got := f.Matches(task); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
+
 func TestFilter_Matches_AgeMaxDays(t *testing.T) {
 	now := time.Now()
 	maxDays := 7
@@ -468,11 +646,65 @@ func TestFilter_Apply(t *testing.T) {
 	}
 }
 
+func TestFilter_Apply_MatchMode(t *testing.T) {
+	tasks := []Task{
+		{ID: "az-1", Title: "P0 bug", Priority: P0, Type: TypeBug},
+		{ID: "az-2", Title: "P0 feature", Priority: P0, Type: TypeFeature},
+		{ID: "az-3", Title: "P2 bug", Priority: P2, Type: TypeBug},
+		{ID: "az-4", Title: "P2 chore", Priority: P2, Type: TypeChore},
+		{ID: "az-5", Title: "P1 task", Priority: P1, Type: TypeTask},
+	}
+
+	f := NewFilter()
+	f.TogglePriority(P0)
+	f.TogglePriority(P1)
+	f.ToggleType(TypeBug)
+
+	ids := func(tasks []Task) string {
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// AllOf: (P0 or P1) and bug
+	if got := ids(f.Apply(tasks)); got != "az-1" {
+		t.Errorf("AllOf Apply() = %s, want az-1", got)
+	}
+
+	// AnyOf: (P0 or P1) or bug
+	f.ToggleMatchMode()
+	if f.MatchMode != MatchAnyOf {
+		t.Fatalf("MatchMode = %v, want any", f.MatchMode)
+	}
+	if got := ids(f.Apply(tasks)); got != "az-1,az-2,az-3,az-5" {
+		t.Errorf("AnyOf Apply() = %s, want az-1,az-2,az-3,az-5", got)
+	}
+
+	// Restrictions outside the categories still apply to every task
+	f.SearchQuery = "bug"
+	if got := ids(f.Apply(tasks)); got != "az-1,az-3" {
+		t.Errorf("AnyOf Apply() with search = %s, want az-1,az-3", got)
+	}
+
+	// Clearing keeps the mode
+	f.Clear()
+	if f.MatchMode != MatchAnyOf {
+		t.Errorf("Clear() reset MatchMode to %v", f.MatchMode)
+	}
+	if got := ids(f.Apply(tasks)); got != "az-1,az-2,az-3,az-4,az-5" {
+		t.Errorf("Apply() with no categories = %s, want every task", got)
+	}
+}
+
 func TestFilter_Clear(t *testing.T) {
 	f := NewFilter()
 	f.ToggleStatus(StatusOpen)
 	f.TogglePriority(P0)
 	f.ToggleType(TypeBug)
+	f.ToggleAssignee("alice")
+	f.ToggleLabel("backend")
 	f.SearchQuery = "test"
 	f.HideEpicChildren = true
 	days := 7
@@ -489,7 +721,7 @@ func TestFilter_Clear(t *testing.T) {
 	}
 
 	// Verify all fields are cleared
-	if len(f.Status) > 0 || len(f.Priority) > 0 || len(f.Type) > 0 || len(f.SessionState) > 0 {
+	if len(f.Status) > 0 || len(f.Priority) > 0 || len(f.Type) > 0 || len(f.SessionState) > 0 || len(f.Assignee) > 0 || len(f.Labels) > 0 {
 		t.Error("Clear() should empty all filter maps")
 	}
 	if f.SearchQuery != "" {
@@ -26,6 +26,35 @@ type Plan struct {
 	ParallelizationScore int           `json:"parallelizationScore"` // 0-100, how parallelizable
 }
 
+// WithoutTasks returns a copy of the plan without the excluded tasks.
+// Dependencies on excluded tasks are dropped from the remaining ones.
+func (p *Plan) WithoutTasks(excluded map[string]bool) *Plan {
+	out := *p
+	out.Tasks = make([]PlannedTask, 0, len(p.Tasks))
+
+	for _, task := range p.Tasks {
+		if excluded[task.ID] {
+			continue
+		}
+		var deps []string
+		for _, dep := range task.DependsOn {
+			if !excluded[dep] {
+				deps = append(deps, dep)
+			}
+		}
+		task.DependsOn = deps
+		out.Tasks = append(out.Tasks, task)
+	}
+
+	return &out
+}
+
+// PlanDependency is a single dependsOn link between two planned tasks
+type PlanDependency struct {
+	TaskID    string `json:"taskId"`    // Task that has the dependency
+	DependsOn string `json:"dependsOn"` // Task it depends on
+}
+
 // ReviewFeedback represents AI review feedback for a plan
 type ReviewFeedback struct {
 	Score                        int                 `json:"score"`                        // 0-100 quality score
@@ -48,26 +77,28 @@ type MissingDependency struct {
 type PlanningStatus string
 
 const (
-	PlanningIdle          PlanningStatus = "idle"
-	PlanningGenerating    PlanningStatus = "generating"
-	PlanningReviewing     PlanningStatus = "reviewing"
-	PlanningRefining      PlanningStatus = "refining"
-	PlanningCreatingBeads PlanningStatus = "creating_beads"
-	PlanningComplete      PlanningStatus = "complete"
-	PlanningErrorStatus   PlanningStatus = "error"
+	PlanningIdle             PlanningStatus = "idle"
+	PlanningGenerating       PlanningStatus = "generating"
+	PlanningReviewing        PlanningStatus = "reviewing"
+	PlanningRefining         PlanningStatus = "refining"
+	PlanningAwaitingApproval PlanningStatus = "awaiting_approval" // Plan is ready for the user to edit and confirm
+	PlanningCreatingBeads    PlanningStatus = "creating_beads"
+	PlanningComplete         PlanningStatus = "complete"
+	PlanningErrorStatus      PlanningStatus = "error"
 )
 
 // PlanningState tracks the state of a planning session
 type PlanningState struct {
-	Status             PlanningStatus   // Current status
-	FeatureDescription string           // Original feature description
-	CurrentPlan        *Plan            // Current plan being worked on
-	ReviewPass         int              // Current review pass number
-	MaxReviewPasses    int              // Maximum number of review passes
-	ReviewHistory      []ReviewFeedback // History of review feedback
-	CreatedBeads       []Task           // Beads created from the plan
-	Error              string           // Error message if status is error
-	UpdatedAt          time.Time        // Last update time
+	Status              PlanningStatus   // Current status
+	FeatureDescription  string           // Original feature description
+	CurrentPlan         *Plan            // Current plan being worked on
+	ReviewPass          int              // Current review pass number
+	MaxReviewPasses     int              // Maximum number of review passes
+	ReviewHistory       []ReviewFeedback // History of review feedback
+	CreatedBeads        []Task           // Beads created from the plan
+	DroppedDependencies []PlanDependency // Dependencies dropped to break cycles before creating beads
+	Error               string           // Error message if status is error
+	UpdatedAt           time.Time        // Last update time
 }
 
 // PlanningError represents an error during planning
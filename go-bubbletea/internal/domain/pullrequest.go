@@ -0,0 +1,36 @@
+package domain
+
+// PRState summarises the review/merge state of a task's pull request
+type PRState string
+
+const (
+	PRNone             PRState = ""
+	PROpen             PRState = "open"
+	PRApproved         PRState = "approved"
+	PRChangesRequested PRState = "changes_requested"
+	PRMerged           PRState = "merged"
+	PRClosed           PRState = "closed"
+)
+
+// Icon returns a unicode icon for the PR state
+func (s PRState) Icon() string {
+	switch s {
+	case PROpen:
+		return "⇡"
+	case PRApproved:
+		return "✔"
+	case PRChangesRequested:
+		return "✎"
+	case PRMerged:
+		return "⛙"
+	case PRClosed:
+		return "⊘"
+	default:
+		return ""
+	}
+}
+
+// String returns the display string
+func (s PRState) String() string {
+	return string(s)
+}
@@ -4,11 +4,43 @@ import "time"
 
 // Session represents an active Claude session
 type Session struct {
-	BeadID    string       `json:"bead_id"`
-	State     SessionState `json:"state"`
-	StartedAt *time.Time   `json:"started_at,omitempty"`
-	Worktree  string       `json:"worktree,omitempty"`
-	DevServer *DevServer   `json:"dev_server,omitempty"`
+	BeadID         string       `json:"bead_id"`
+	State          SessionState `json:"state"`
+	StartedAt      *time.Time   `json:"started_at,omitempty"`
+	StateChangedAt *time.Time   `json:"state_changed_at,omitempty"`
+	Worktree       string       `json:"worktree,omitempty"`
+	DevServer      *DevServer   `json:"dev_server,omitempty"`
+	BusyReason     BusyReason   `json:"busy_reason,omitempty"` // What a busy session is doing, if its output says
+	Stalled        bool         `json:"stalled,omitempty"`     // Busy with no new output for session.stuckTimeoutMs
+	Ahead          int          `json:"ahead,omitempty"`       // Commits on the session's branch that aren't on the base branch
+	Behind         int          `json:"behind,omitempty"`      // Commits on the base branch that the session's branch lacks
+}
+
+// SetState changes the session state, recording when it changed
+func (s *Session) SetState(state SessionState, now time.Time) {
+	if s.State == state {
+		return
+	}
+	s.State = state
+	s.StateChangedAt = &now
+}
+
+// IdleFor returns how long the session has been idle or done as of now,
+// timed from its last state change, or from its start if the state never
+// changed. Other states return zero: a waiting session needs an answer
+// rather than cleanup, and a paused one was parked on purpose.
+func (s *Session) IdleFor(now time.Time) time.Duration {
+	if s.State != SessionIdle && s.State != SessionDone {
+		return 0
+	}
+	since := s.StateChangedAt
+	if since == nil {
+		since = s.StartedAt
+	}
+	if since == nil || now.Before(*since) {
+		return 0
+	}
+	return now.Sub(*since)
 }
 
 // SessionState represents the current state of a session
@@ -21,6 +53,7 @@ const (
 	SessionDone    SessionState = "done"
 	SessionError   SessionState = "error"
 	SessionPaused  SessionState = "paused"
+	SessionQueued  SessionState = "queued" // Waiting for a free slot under session.maxConcurrent
 )
 
 // Icon returns a unicode icon for the state
@@ -38,6 +71,8 @@ func (s SessionState) Icon() string {
 		return "✗"
 	case SessionPaused:
 		return "⏸"
+	case SessionQueued:
+		return "◌"
 	default:
 		return "?"
 	}
@@ -48,6 +83,33 @@ func (s SessionState) String() string {
 	return string(s)
 }
 
+// BusyReason tells apart what a busy session is doing, so active generation
+// can be told from a long build or a stuck process
+type BusyReason string
+
+const (
+	BusyThinking  BusyReason = "thinking" // Generating a response
+	BusyToolUse   BusyReason = "tool"     // Running a tool call
+	BusyCompiling BusyReason = "compiling"
+	BusyTesting   BusyReason = "testing"
+)
+
+// Icon returns a unicode icon for the reason, falling back to the busy icon
+func (r BusyReason) Icon() string {
+	switch r {
+	case BusyThinking:
+		return "✻"
+	case BusyToolUse:
+		return "⚙"
+	case BusyCompiling:
+		return "⚒"
+	case BusyTesting:
+		return "⧗"
+	default:
+		return SessionBusy.Icon()
+	}
+}
+
 // DevServer represents a running dev server
 type DevServer struct {
 	Port    int    `json:"port"`
@@ -1,6 +1,9 @@
 package domain
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestSessionState_Icon(t *testing.T) {
 	tests := []struct {
@@ -13,6 +16,7 @@ func TestSessionState_Icon(t *testing.T) {
 		{SessionDone, "✓"},
 		{SessionError, "✗"},
 		{SessionPaused, "⏸"},
+		{SessionQueued, "◌"},
 		{SessionState("unknown"), "?"},
 	}
 
@@ -24,3 +28,77 @@ func TestSessionState_Icon(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_IdleFor(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		session Session
+		want    time.Duration
+	}{
+		{
+			name:    "idle since last state change",
+			session: Session{State: SessionIdle, StartedAt: at(3 * time.Hour), StateChangedAt: at(45 * time.Minute)},
+			want:    45 * time.Minute,
+		},
+		{
+			name:    "done since last state change",
+			session: Session{State: SessionDone, StateChangedAt: at(10 * time.Minute)},
+			want:    10 * time.Minute,
+		},
+		{
+			name:    "falls back to start time",
+			session: Session{State: SessionIdle, StartedAt: at(2 * time.Hour)},
+			want:    2 * time.Hour,
+		},
+		{
+			name:    "waiting sessions are never idle",
+			session: Session{State: SessionWaiting, StateChangedAt: at(3 * time.Hour)},
+		},
+		{
+			name:    "busy sessions are never idle",
+			session: Session{State: SessionBusy, StateChangedAt: at(3 * time.Hour)},
+		},
+		{
+			name:    "paused sessions are never idle",
+			session: Session{State: SessionPaused, StateChangedAt: at(3 * time.Hour)},
+		},
+		{
+			name:    "no timestamps",
+			session: Session{State: SessionIdle},
+		},
+		{
+			name:    "change in the future",
+			session: Session{State: SessionDone, StateChangedAt: at(-time.Minute)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.IdleFor(now); got != tt.want {
+				t.Errorf("IdleFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_SetState(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	s := Session{State: SessionBusy}
+
+	s.SetState(SessionDone, now)
+	if s.State != SessionDone || s.StateChangedAt == nil || !s.StateChangedAt.Equal(now) {
+		t.Fatalf("SetState(done) = %v at %v, want done at %v", s.State, s.StateChangedAt, now)
+	}
+
+	// Repeating the current state keeps the original change time
+	s.SetState(SessionDone, now.Add(time.Hour))
+	if !s.StateChangedAt.Equal(now) {
+		t.Errorf("StateChangedAt = %v after repeated state, want %v", s.StateChangedAt, now)
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "sort"
+import (
+	"cmp"
+	"sort"
+	"strings"
+)
 
 // SortField represents a field to sort by
 type SortField string
@@ -8,7 +12,9 @@ type SortField string
 const (
 	SortBySession  SortField = "session"
 	SortByPriority SortField = "priority"
+	SortByAge      SortField = "age"
 	SortByUpdated  SortField = "updated"
+	SortByTitle    SortField = "title"
 )
 
 // SortOrder represents sort direction
@@ -44,6 +50,7 @@ func (s *Sort) Toggle(field SortField) {
 }
 
 // Apply sorts a list of tasks
+// Ties on the sort field fall back to bead ID so ordering is deterministic.
 func (s *Sort) Apply(tasks []Task) []Task {
 	if len(tasks) == 0 {
 		return tasks
@@ -53,37 +60,44 @@ func (s *Sort) Apply(tasks []Task) []Task {
 	result := make([]Task, len(tasks))
 	copy(result, tasks)
 
-	// Sort based on field
+	sort.SliceStable(result, func(i, j int) bool {
+		c := s.compare(result[i], result[j])
+		if s.Order == SortDesc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// compare orders two tasks by the sort field in ascending order,
+// returning a negative number if a sorts before b, positive if after
+func (s *Sort) compare(a, b Task) int {
 	switch s.Field {
 	case SortByPriority:
-		sort.SliceStable(result, func(i, j int) bool {
-			if s.Order == SortAsc {
-				return result[i].Priority < result[j].Priority
-			}
-			return result[i].Priority > result[j].Priority
-		})
+		return cmp.Compare(a.Priority, b.Priority)
+
+	case SortByAge:
+		return a.CreatedAt.Compare(b.CreatedAt)
 
 	case SortByUpdated:
-		sort.SliceStable(result, func(i, j int) bool {
-			if s.Order == SortAsc {
-				return result[i].UpdatedAt.Before(result[j].UpdatedAt)
-			}
-			return result[i].UpdatedAt.After(result[j].UpdatedAt)
-		})
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+
+	case SortByTitle:
+		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
 
 	case SortBySession:
-		sort.SliceStable(result, func(i, j int) bool {
-			pi := sessionStatePriority(getSessionState(result[i]))
-			pj := sessionStatePriority(getSessionState(result[j]))
-
-			if s.Order == SortAsc {
-				return pi > pj // Higher priority first in ascending
-			}
-			return pi < pj // Lower priority first in descending
-		})
+		// Higher priority first in ascending
+		pa := sessionStatePriority(getSessionState(a))
+		pb := sessionStatePriority(getSessionState(b))
+		return cmp.Compare(pb, pa)
 	}
 
-	return result
+	return 0
 }
 
 // getSessionState returns the session state for a task, handling nil sessions
@@ -211,3 +211,72 @@ func TestSort_Apply_StableSort(t *testing.T) {
 		}
 	}
 }
+
+func TestSort_Apply_Age(t *testing.T) {
+	now := time.Now()
+	tasks := []Task{
+		{ID: "az-1", CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "az-2", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "az-3", CreatedAt: now.Add(-72 * time.Hour)},
+	}
+
+	t.Run("ascending (oldest first)", func(t *testing.T) {
+		s := Sort{Field: SortByAge, Order: SortAsc}
+		assertOrder(t, s.Apply(tasks), []string{"az-3", "az-1", "az-2"})
+	})
+
+	t.Run("descending (newest first)", func(t *testing.T) {
+		s := Sort{Field: SortByAge, Order: SortDesc}
+		assertOrder(t, s.Apply(tasks), []string{"az-2", "az-1", "az-3"})
+	})
+}
+
+func TestSort_Apply_Title(t *testing.T) {
+	tasks := []Task{
+		{ID: "az-1", Title: "fix login"},
+		{ID: "az-2", Title: "Add search"},
+		{ID: "az-3", Title: "Bump deps"},
+	}
+
+	t.Run("ascending (case-insensitive)", func(t *testing.T) {
+		s := Sort{Field: SortByTitle, Order: SortAsc}
+		assertOrder(t, s.Apply(tasks), []string{"az-2", "az-3", "az-1"})
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		s := Sort{Field: SortByTitle, Order: SortDesc}
+		assertOrder(t, s.Apply(tasks), []string{"az-1", "az-3", "az-2"})
+	})
+}
+
+func TestSort_Apply_IDTiebreak(t *testing.T) {
+	created := time.Now()
+	// Input deliberately out of ID order; all keys tie
+	tasks := []Task{
+		{ID: "az-3", Title: "Same", Priority: P1, CreatedAt: created, UpdatedAt: created},
+		{ID: "az-1", Title: "Same", Priority: P1, CreatedAt: created, UpdatedAt: created},
+		{ID: "az-2", Title: "Same", Priority: P1, CreatedAt: created, UpdatedAt: created},
+	}
+
+	fields := []SortField{SortBySession, SortByPriority, SortByAge, SortByUpdated, SortByTitle}
+	for _, field := range fields {
+		for _, order := range []SortOrder{SortAsc, SortDesc} {
+			s := Sort{Field: field, Order: order}
+			t.Run(string(field), func(t *testing.T) {
+				assertOrder(t, s.Apply(tasks), []string{"az-1", "az-2", "az-3"})
+			})
+		}
+	}
+}
+
+func assertOrder(t *testing.T, result []Task, want []string) {
+	t.Helper()
+	if len(result) != len(want) {
+		t.Fatalf("Apply() returned %d tasks, want %d", len(result), len(want))
+	}
+	for i, task := range result {
+		if task.ID != want[i] {
+			t.Errorf("Apply()[%d] = %s, want %s", i, task.ID, want[i])
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // DependencyType represents the type of dependency relationship
 type DependencyType string
@@ -23,14 +28,33 @@ type Task struct {
 	ID           string       `json:"id"`
 	Title        string       `json:"title"`
 	Description  string       `json:"description,omitempty"`
+	Design       string       `json:"design,omitempty"`
+	Acceptance   string       `json:"acceptance_criteria,omitempty"`
 	Status       Status       `json:"status"`
 	Priority     Priority     `json:"priority"`
 	Type         TaskType     `json:"issue_type"`
+	Assignee     string       `json:"assignee,omitempty"`
+	Labels       []string     `json:"labels,omitempty"`
 	ParentID     *string      `json:"parent_id,omitempty"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
 	Session      *Session     `json:"session,omitempty"`
+	PRState      PRState      `json:"-"` // Populated by the PR status poller
+	PRURL        string       `json:"-"` // Populated from the saved PR mapping
+	PRNumber     int          `json:"-"` // Parsed from PRURL; 0 when unknown
+	Attachments  int          `json:"-"` // Populated from the attachment service on load
+	BlockedBy    []string     `json:"-"` // Blockers not done yet, populated from the dependency phases on load
 	CreatedAt    time.Time    `json:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at"`
+	ClosedAt     *time.Time   `json:"closed_at,omitempty"`
+}
+
+// CompletedAt returns when the task was closed, falling back to its last
+// update for beads that don't record it
+func (t Task) CompletedAt() time.Time {
+	if t.ClosedAt != nil {
+		return *t.ClosedAt
+	}
+	return t.UpdatedAt
 }
 
 // Status represents task status
@@ -43,7 +67,30 @@ const (
 	StatusDone       Status = "closed"
 )
 
-// Column returns the kanban column index for this status
+// CustomStatuses maps statuses beyond bd's own, such as review, to the
+// built-in status each behaves as
+type CustomStatuses map[Status]Status
+
+// IsBuiltin reports whether s is one of bd's own statuses
+func (s Status) IsBuiltin() bool {
+	switch s {
+	case StatusOpen, StatusInProgress, StatusBlocked, StatusDone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Base returns the built-in status s behaves as: its mapping in custom, or
+// s itself for bd's own statuses and unmapped ones
+func (s Status) Base(custom CustomStatuses) Status {
+	if base, ok := custom[s]; ok {
+		return base
+	}
+	return s
+}
+
+// Column returns the column index for this status on the default board
 func (s Status) Column() int {
 	switch s {
 	case StatusOpen:
@@ -59,6 +106,11 @@ func (s Status) Column() int {
 	}
 }
 
+// IsTerminal reports whether no further work is expected on the task
+func (s Status) IsTerminal() bool {
+	return s == StatusDone
+}
+
 // String returns the display string
 func (s Status) String() string {
 	return string(s)
@@ -80,6 +132,15 @@ func (p Priority) String() string {
 	return []string{"P0", "P1", "P2", "P3", "P4"}[p]
 }
 
+// ParsePriority reads a priority written P0-P4 or 0-4
+func ParsePriority(s string) (Priority, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "P"))
+	if err != nil || n < int(P0) || n > int(P4) {
+		return 0, fmt.Errorf("invalid priority %q (want P0-P4)", s)
+	}
+	return Priority(n), nil
+}
+
 // TaskType represents the type of task
 type TaskType string
 
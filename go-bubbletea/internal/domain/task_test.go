@@ -25,6 +25,55 @@ func TestStatus_Column(t *testing.T) {
 	}
 }
 
+func TestStatus_IsTerminal(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   bool
+	}{
+		{StatusOpen, false},
+		{StatusInProgress, false},
+		{StatusBlocked, false},
+		{StatusDone, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsTerminal(); got != tt.want {
+				t.Errorf("Status.IsTerminal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatus_Base(t *testing.T) {
+	custom := CustomStatuses{"review": StatusInProgress, "wontfix": StatusDone}
+
+	tests := []struct {
+		status Status
+		want   Status
+	}{
+		{"review", StatusInProgress},
+		{"wontfix", StatusDone},
+		{StatusBlocked, StatusBlocked},
+		{Status("unknown"), Status("unknown")},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.Base(custom); got != tt.want {
+				t.Errorf("Status.Base() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !Status("wontfix").Base(custom).IsTerminal() {
+		t.Error("Expected a custom status based on closed to be terminal")
+	}
+	if Status("review").IsBuiltin() || !StatusDone.IsBuiltin() {
+		t.Error("Expected only bd's own statuses to be built in")
+	}
+}
+
 func TestPriority_String(t *testing.T) {
 	tests := []struct {
 		priority Priority
@@ -46,6 +95,34 @@ func TestPriority_String(t *testing.T) {
 	}
 }
 
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Priority
+		wantErr bool
+	}{
+		{"P0", P0, false},
+		{"p2", P2, false},
+		{"3", P3, false},
+		{" P4 ", P4, false},
+		{"P5", 0, true},
+		{"-1", 0, true},
+		{"high", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePriority(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriority(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePriority(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTaskType_Short(t *testing.T) {
 	tests := []struct {
 		taskType TaskType
@@ -0,0 +1,339 @@
+// Package server exposes a small local HTTP API so editor integrations can
+// list tasks, control sessions and fetch diffs without driving the TUI.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+)
+
+// BeadsClient defines the beads operations needed by the server
+type BeadsClient interface {
+	List(ctx context.Context) ([]domain.Task, error)
+	Update(ctx context.Context, id string, status domain.Status) error
+}
+
+// TmuxClient defines the tmux operations needed by the server
+type TmuxClient interface {
+	HasSession(ctx context.Context, name string) (bool, error)
+	NewSession(ctx context.Context, name string, workdir string) error
+	SendKeys(ctx context.Context, name string, keys string) error
+	KillSession(ctx context.Context, name string) error
+}
+
+// WorktreeManager defines the worktree operations needed by the server
+type WorktreeManager interface {
+	Create(ctx context.Context, beadID string, baseBranch string) (*git.Worktree, error)
+	Get(ctx context.Context, beadID string) (*git.Worktree, error)
+}
+
+// DiffClient returns the working-tree diff of a worktree
+type DiffClient interface {
+	Diff(ctx context.Context, worktree string) (string, error)
+}
+
+// Dependencies holds the services the server delegates to
+type Dependencies struct {
+	Beads      BeadsClient
+	Tmux       TmuxClient
+	Worktrees  WorktreeManager
+	Diff       DiffClient
+	CLITool    string // command sent to new sessions, e.g. "claude"
+	BaseBranch string // branch new worktrees are created from
+	Token      string // bearer token required to start and stop sessions
+	Logger     *slog.Logger
+}
+
+// beadIDPattern is what a bead ID looks like, e.g. "az-12" or "az-12.3"
+var beadIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Server serves the editor integration API
+type Server struct {
+	deps Dependencies
+	mux  *http.ServeMux
+}
+
+// TaskListResponse is returned by GET /tasks
+type TaskListResponse struct {
+	Tasks []domain.Task `json:"tasks"`
+}
+
+// SessionResponse is returned by the session start and stop actions
+type SessionResponse struct {
+	BeadID   string `json:"bead_id"`
+	Worktree string `json:"worktree,omitempty"`
+	Status   string `json:"status"`
+}
+
+// DiffResponse is returned by GET /sessions/{id}/diff
+type DiffResponse struct {
+	BeadID string `json:"bead_id"`
+	Diff   string `json:"diff"`
+}
+
+// ErrorResponse is returned with every non-2xx status
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// New creates a server and registers its routes
+func New(deps Dependencies) *Server {
+	if deps.Logger == nil {
+		deps.Logger = slog.Default()
+	}
+	if deps.CLITool == "" {
+		deps.CLITool = "claude"
+	}
+	if deps.BaseBranch == "" {
+		deps.BaseBranch = "main"
+	}
+
+	s := &Server{deps: deps, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /tasks", s.handleListTasks)
+	s.mux.HandleFunc("POST /sessions/{id}/start", s.authorized(s.handleStartSession))
+	s.mux.HandleFunc("POST /sessions/{id}/stop", s.authorized(s.handleStopSession))
+	s.mux.HandleFunc("GET /sessions/{id}/diff", s.handleDiff)
+	return s
+}
+
+// NewToken returns a random token for Dependencies.Token
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handler returns the HTTP handler serving the API. Requests must come from
+// the local machine: a Host or Origin header naming anything else, as sent
+// by a web page or through DNS rebinding, is refused.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLocalRequest(r) {
+			s.writeError(w, http.StatusForbidden, errors.New("request is not from the local machine"))
+			return
+		}
+		s.mux.ServeHTTP(w, r)
+	})
+}
+
+// isLocalRequest reports whether r's Host and, if set, Origin name the
+// loopback interface. Requests over a unix socket carry whatever Host the
+// client chose, which is localhost for curl and most HTTP clients.
+func isLocalRequest(r *http.Request) bool {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if !isLoopback(strings.Trim(host, "[]")) {
+		return false
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && isLoopback(u.Hostname())
+}
+
+// authorized wraps a handler that changes sessions so it requires the
+// server's token, as "Authorization: Bearer <token>", and a bead ID
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.deps.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.deps.Token)) != 1 {
+			s.writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		if !beadIDPattern.MatchString(r.PathValue("id")) {
+			s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid bead ID %q", r.PathValue("id")))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Serve serves the API on ln until ctx is cancelled
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	s.deps.Logger.Info("editor API listening", "addr", ln.Addr().String())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Listen opens a listener for the API. Addresses starting with "unix:" are
+// unix socket paths; anything else must be a loopback host:port so the API
+// is never reachable from other machines.
+func Listen(addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		// Remove a stale socket left behind by a previous run
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+		return net.Listen("unix", path)
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("refusing to listen on non-loopback address %q", addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// isLoopback reports whether host only accepts local connections
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.deps.Beads.List(r.Context())
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list tasks: %w", err))
+		return
+	}
+	if tasks == nil {
+		tasks = []domain.Task{}
+	}
+	s.writeJSON(w, http.StatusOK, TaskListResponse{Tasks: tasks})
+}
+
+func (s *Server) handleStartSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	beadID := r.PathValue("id")
+
+	tasks, err := s.deps.Beads.List(ctx)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list tasks: %w", err))
+		return
+	}
+	if !slices.ContainsFunc(tasks, func(task domain.Task) bool { return task.ID == beadID }) {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("bead not found: %s", beadID))
+		return
+	}
+
+	exists, err := s.deps.Tmux.HasSession(ctx, beadID)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to check session: %w", err))
+		return
+	}
+	if exists {
+		s.writeError(w, http.StatusConflict, fmt.Errorf("session already exists: %s", beadID))
+		return
+	}
+
+	worktree, err := s.deps.Worktrees.Create(ctx, beadID, s.deps.BaseBranch)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to create worktree: %w", err))
+		return
+	}
+	if err := s.deps.Tmux.NewSession(ctx, beadID, worktree.Path); err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to create tmux session: %w", err))
+		return
+	}
+	if err := s.deps.Tmux.SendKeys(ctx, beadID, s.deps.CLITool); err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to send keys: %w", err))
+		return
+	}
+
+	// Don't fail the request if only the status update fails
+	if err := s.deps.Beads.Update(ctx, beadID, domain.StatusInProgress); err != nil {
+		s.deps.Logger.Warn("failed to update bead status", "bead_id", beadID, "error", err)
+	}
+
+	s.writeJSON(w, http.StatusCreated, SessionResponse{
+		BeadID:   beadID,
+		Worktree: worktree.Path,
+		Status:   "started",
+	})
+}
+
+func (s *Server) handleStopSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	beadID := r.PathValue("id")
+
+	exists, err := s.deps.Tmux.HasSession(ctx, beadID)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to check session: %w", err))
+		return
+	}
+	if !exists {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("session not found: %s", beadID))
+		return
+	}
+	if err := s.deps.Tmux.KillSession(ctx, beadID); err != nil {
+		s.writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to kill session: %w", err))
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, SessionResponse{BeadID: beadID, Status: "stopped"})
+}
+
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	beadID := r.PathValue("id")
+
+	worktree, err := s.deps.Worktrees.Get(ctx, beadID)
+	if err != nil {
+		s.writeError(w, http.StatusNotFound, err)
+		return
+	}
+	diff, err := s.deps.Diff.Diff(ctx, worktree.Path)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, DiffResponse{BeadID: beadID, Diff: diff})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.deps.Logger.Warn("failed to write response", "error", err)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.deps.Logger.Debug("request failed", "status", status, "error", err)
+	s.writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
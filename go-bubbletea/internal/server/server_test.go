@@ -0,0 +1,351 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBeads struct {
+	tasks   []domain.Task
+	err     error
+	updated map[string]domain.Status
+}
+
+func (f *fakeBeads) List(ctx context.Context) ([]domain.Task, error) {
+	return f.tasks, f.err
+}
+
+func (f *fakeBeads) Update(ctx context.Context, id string, status domain.Status) error {
+	if f.updated == nil {
+		f.updated = make(map[string]domain.Status)
+	}
+	f.updated[id] = status
+	return nil
+}
+
+type fakeTmux struct {
+	sessions map[string]string // name -> workdir
+	keys     map[string]string
+	killed   []string
+}
+
+func (f *fakeTmux) HasSession(ctx context.Context, name string) (bool, error) {
+	_, ok := f.sessions[name]
+	return ok, nil
+}
+
+func (f *fakeTmux) NewSession(ctx context.Context, name string, workdir string) error {
+	f.sessions[name] = workdir
+	return nil
+}
+
+func (f *fakeTmux) SendKeys(ctx context.Context, name string, keys string) error {
+	f.keys[name] = keys
+	return nil
+}
+
+func (f *fakeTmux) KillSession(ctx context.Context, name string) error {
+	delete(f.sessions, name)
+	f.killed = append(f.killed, name)
+	return nil
+}
+
+type fakeWorktrees struct {
+	created map[string]string // beadID -> base branch
+}
+
+func (f *fakeWorktrees) Create(ctx context.Context, beadID string, baseBranch string) (*git.Worktree, error) {
+	f.created[beadID] = baseBranch
+	return &git.Worktree{Path: "/tmp/project-" + beadID, Branch: "az/" + beadID, BeadID: beadID}, nil
+}
+
+func (f *fakeWorktrees) Get(ctx context.Context, beadID string) (*git.Worktree, error) {
+	if _, ok := f.created[beadID]; !ok {
+		return nil, errors.New("worktree for bead " + beadID + " not found")
+	}
+	return &git.Worktree{Path: "/tmp/project-" + beadID, BeadID: beadID}, nil
+}
+
+type fakeDiff struct{}
+
+func (fakeDiff) Diff(ctx context.Context, worktree string) (string, error) {
+	return "diff --git a/" + worktree + " b/" + worktree, nil
+}
+
+type testServer struct {
+	*Server
+	beads     *fakeBeads
+	tmux      *fakeTmux
+	worktrees *fakeWorktrees
+}
+
+func newTestServer() testServer {
+	beads := &fakeBeads{tasks: []domain.Task{
+		{ID: "az-1", Title: "First", Status: domain.StatusOpen, Type: domain.TypeTask},
+		{ID: "az-2", Title: "Second", Status: domain.StatusInProgress, Type: domain.TypeBug},
+	}}
+	tmux := &fakeTmux{sessions: map[string]string{}, keys: map[string]string{}}
+	worktrees := &fakeWorktrees{created: map[string]string{}}
+
+	s := New(Dependencies{
+		Beads:      beads,
+		Tmux:       tmux,
+		Worktrees:  worktrees,
+		Diff:       fakeDiff{},
+		CLITool:    "opencode",
+		BaseBranch: "develop",
+		Token:      testToken,
+	})
+	return testServer{Server: s, beads: beads, tmux: tmux, worktrees: worktrees}
+}
+
+const testToken = "secret"
+
+// do sends a request as a local editor integration holding the token would
+func (s testServer) do(method, path string) *httptest.ResponseRecorder {
+	return s.doWith(method, path, map[string]string{"Authorization": "Bearer " + testToken})
+}
+
+// doWith sends a request from localhost with only the given headers
+func (s testServer) doWith(method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Host = "localhost:7777"
+	for name, value := range headers {
+		if name == "Host" {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListTasks(t *testing.T) {
+	s := newTestServer()
+
+	rec := s.do(http.MethodGet, "/tasks")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string][]map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body["tasks"], 2)
+	assert.Equal(t, "az-1", body["tasks"][0]["id"])
+	assert.Equal(t, "open", body["tasks"][0]["status"])
+	assert.Equal(t, "az-2", body["tasks"][1]["id"])
+	assert.Equal(t, "bug", body["tasks"][1]["issue_type"])
+}
+
+func TestListTasks_Empty(t *testing.T) {
+	s := newTestServer()
+	s.beads.tasks = nil
+
+	rec := s.do(http.MethodGet, "/tasks")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"tasks": []}`, rec.Body.String())
+}
+
+func TestListTasks_Error(t *testing.T) {
+	s := newTestServer()
+	s.beads.err = errors.New("bd not found")
+
+	rec := s.do(http.MethodGet, "/tasks")
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error": "failed to list tasks: bd not found"}`, rec.Body.String())
+}
+
+func TestStartSession(t *testing.T) {
+	s := newTestServer()
+
+	rec := s.do(http.MethodPost, "/sessions/az-1/start")
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"bead_id": "az-1", "worktree": "/tmp/project-az-1", "status": "started"}`, rec.Body.String())
+
+	assert.Equal(t, "develop", s.worktrees.created["az-1"])
+	assert.Equal(t, "/tmp/project-az-1", s.tmux.sessions["az-1"])
+	assert.Equal(t, "opencode", s.tmux.keys["az-1"])
+	assert.Equal(t, domain.StatusInProgress, s.beads.updated["az-1"])
+}
+
+func TestStartSession_AlreadyRunning(t *testing.T) {
+	s := newTestServer()
+	s.tmux.sessions["az-1"] = "/tmp/project-az-1"
+
+	rec := s.do(http.MethodPost, "/sessions/az-1/start")
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"error": "session already exists: az-1"}`, rec.Body.String())
+	assert.Empty(t, s.worktrees.created)
+}
+
+func TestStartSession_UnknownBead(t *testing.T) {
+	s := newTestServer()
+
+	rec := s.do(http.MethodPost, "/sessions/az-9/start")
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error": "bead not found: az-9"}`, rec.Body.String())
+	assert.Empty(t, s.worktrees.created)
+}
+
+func TestSessionRoutes_RequireToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{name: "no token", headers: nil},
+		{name: "wrong token", headers: map[string]string{"Authorization": "Bearer guess"}},
+		{name: "not a bearer token", headers: map[string]string{"Authorization": testToken}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+			s.tmux.sessions["az-2"] = "/tmp/project-az-2"
+
+			rec := s.doWith(http.MethodPost, "/sessions/az-1/start", tt.headers)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			rec = s.doWith(http.MethodPost, "/sessions/az-2/stop", tt.headers)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+			assert.Empty(t, s.worktrees.created)
+			assert.Empty(t, s.tmux.killed)
+		})
+	}
+}
+
+func TestSessionRoutes_RejectInvalidID(t *testing.T) {
+	s := newTestServer()
+
+	rec := s.do(http.MethodPost, "/sessions/-t/stop")
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error": "invalid bead ID \"-t\""}`, rec.Body.String())
+}
+
+func TestHandler_RejectsForeignRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{name: "local", headers: nil, wantStatus: http.StatusOK},
+		{name: "loopback IP", headers: map[string]string{"Host": "127.0.0.1:7777"}, wantStatus: http.StatusOK},
+		{name: "local origin", headers: map[string]string{"Origin": "http://localhost:3000"}, wantStatus: http.StatusOK},
+		{name: "rebound host", headers: map[string]string{"Host": "attacker.example:7777"}, wantStatus: http.StatusForbidden},
+		{name: "foreign origin", headers: map[string]string{"Origin": "https://attacker.example"}, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+
+			rec := s.doWith(http.MethodGet, "/tasks", tt.headers)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestStopSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		running    bool
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "running session is killed",
+			running:    true,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"bead_id": "az-1", "status": "stopped"}`,
+		},
+		{
+			name:       "missing session",
+			running:    false,
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error": "session not found: az-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer()
+			if tt.running {
+				s.tmux.sessions["az-1"] = "/tmp/project-az-1"
+			}
+
+			rec := s.do(http.MethodPost, "/sessions/az-1/stop")
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	s := newTestServer()
+	s.worktrees.created["az-2"] = "main"
+
+	rec := s.do(http.MethodGet, "/sessions/az-2/diff")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"bead_id": "az-2", "diff": "diff --git a//tmp/project-az-2 b//tmp/project-az-2"}`, rec.Body.String())
+
+	rec = s.do(http.MethodGet, "/sessions/az-9/diff")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	s := newTestServer()
+
+	rec := s.do(http.MethodGet, "/sessions/az-1/start")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Empty(t, s.worktrees.created)
+}
+
+func TestListen_RejectsNonLoopback(t *testing.T) {
+	tests := []struct {
+		addr    string
+		wantErr bool
+	}{
+		{addr: "127.0.0.1:0", wantErr: false},
+		{addr: "localhost:0", wantErr: false},
+		{addr: "[::1]:0", wantErr: false},
+		{addr: "0.0.0.0:0", wantErr: true},
+		{addr: ":0", wantErr: true},
+		{addr: "192.168.1.10:7777", wantErr: true},
+		{addr: "not-an-address", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			ln, err := Listen(tt.addr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				// IPv6 loopback may be unavailable in some sandboxes
+				t.Skipf("loopback listen unavailable: %v", err)
+			}
+			ln.Close()
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package applog sets up the application's own log. The TUI owns the
+// terminal, so logs go to a size-rotated file of JSON lines instead of
+// stderr.
+package applog
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileName is the log file created in the log directory
+const FileName = "azedarach.log"
+
+// DefaultMaxBytes caps the log before it is rotated
+const DefaultMaxBytes = 5 * 1024 * 1024
+
+// ParseLevel converts a ui.logLevel value to a slog level. An empty value
+// is info.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// New creates a logger writing JSON lines at level or above to FileName in
+// dir, rotating the file once it reaches maxBytes. A leading "~/" in dir is
+// expanded to the user's home directory. Close the returned closer on exit.
+func New(dir string, level slog.Level, maxBytes int64) (*slog.Logger, io.Closer, error) {
+	dir = expandHome(dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	w, err := NewRotatingWriter(filepath.Join(dir, FileName), maxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
+	return slog.New(handler), w, nil
+}
+
+// Install makes a logger from New the slog default. The level comes from
+// levelName unless debug is set, which forces debug.
+func Install(dir, levelName string, debug bool) (io.Closer, error) {
+	level, err := ParseLevel(levelName)
+	if err != nil {
+		return nil, err
+	}
+	if debug {
+		level = slog.LevelDebug
+	}
+
+	logger, closer, err := New(dir, level, DefaultMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	return closer, nil
+}
+
+// RotatingWriter appends to a file, moving it aside to path.1 once it
+// reaches maxBytes. Only the previous rotation is kept.
+type RotatingWriter struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingWriter opens path for appending
+func NewRotatingWriter(path string, maxBytes int64) (*RotatingWriter, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	w := &RotatingWriter{path: path, maxBytes: maxBytes}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p, rotating first if it would take the file past maxBytes
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+func (w *RotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file to path.1, replacing any previous
+// rotation, and starts a new one
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return w.open()
+}
+
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -0,0 +1,110 @@
+package applog
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "debug", want: slog.LevelDebug},
+		{name: "", want: slog.LevelInfo},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "verbose", want: slog.LevelInfo, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew_WritesJSONLinesAtLevel(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	logger, closer, err := New(dir, slog.LevelWarn, 0)
+	require.NoError(t, err)
+
+	logger.Info("hidden")
+	logger.Warn("disk low", "free", 10)
+	logger.Error("failed", "beadID", "az-1")
+	require.NoError(t, closer.Close())
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "line %q", scanner.Text())
+		records = append(records, record)
+	}
+
+	require.Len(t, records, 2)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "disk low", records[0]["msg"])
+	assert.Equal(t, float64(10), records[0]["free"])
+	assert.Equal(t, "ERROR", records[1]["level"])
+	assert.Equal(t, "az-1", records[1]["beadID"])
+}
+
+func TestRotatingWriter_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	w, err := NewRotatingWriter(path, 20)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("first line 123456\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("second line\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "second line\n", string(current))
+	assert.True(t, strings.HasPrefix(string(rotated), "first line"))
+}
+
+func TestInstall_DebugOverridesLevel(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	dir := t.TempDir()
+	closer, err := Install(dir, "error", true)
+	require.NoError(t, err)
+
+	slog.Debug("visible")
+	require.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"msg":"visible"`)
+
+	_, err = Install(dir, "verbose", false)
+	assert.Error(t, err)
+}
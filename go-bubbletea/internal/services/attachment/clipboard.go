@@ -21,6 +21,32 @@ func ReadImageFromClipboard(ctx context.Context) ([]byte, error) {
 	}
 }
 
+// WriteTextToClipboard puts text on the system clipboard
+// Supports macOS (pbcopy), Linux (wl-copy, xclip, xsel)
+func WriteTextToClipboard(ctx context.Context, text string) error {
+	var cmd *exec.Cmd
+	switch {
+	case runtime.GOOS == "darwin":
+		cmd = exec.CommandContext(ctx, "pbcopy")
+	case runtime.GOOS != "linux":
+		return fmt.Errorf("clipboard writing not supported on %s", runtime.GOOS)
+	case hasCommand("wl-copy"):
+		cmd = exec.CommandContext(ctx, "wl-copy")
+	case hasCommand("xclip"):
+		cmd = exec.CommandContext(ctx, "xclip", "-selection", "clipboard")
+	case hasCommand("xsel"):
+		cmd = exec.CommandContext(ctx, "xsel", "--clipboard", "--input")
+	default:
+		return fmt.Errorf("no clipboard tool found (tried wl-copy, xclip, xsel)")
+	}
+
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to write clipboard with %s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
+
 // readClipboardMacOS reads clipboard on macOS using osascript/pngpaste
 func readClipboardMacOS(ctx context.Context) ([]byte, error) {
 	// Try pngpaste first (faster and more reliable for images)
@@ -146,6 +146,26 @@ func (s *Service) List(ctx context.Context, beadID string) ([]Attachment, error)
 	return attachments, nil
 }
 
+// Count returns how many attachments a bead has. It only reads the bead's
+// directory listing, so it is cheap enough to call for every bead on load.
+func (s *Service) Count(beadID string) (int, error) {
+	entries, err := os.ReadDir(s.getImagesDir(beadID))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read images directory: %w", err)
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // Delete removes an attachment by ID
 func (s *Service) Delete(ctx context.Context, beadID, attachmentID string) error {
 	s.logger.Debug("deleting attachment", "bead_id", beadID, "attachment_id", attachmentID)
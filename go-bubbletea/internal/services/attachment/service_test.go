@@ -142,6 +142,46 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestCount(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsPath := filepath.Join(tmpDir, "beads")
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(beadsPath, logger)
+
+	// Count when the bead has no images directory
+	count, err := service.Count("az-123")
+	if err != nil {
+		t.Fatalf("failed to count attachments: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 attachments, got %d", count)
+	}
+
+	testFile := filepath.Join(tmpDir, "test.png")
+	if err := os.WriteFile(testFile, []byte{0x89, 0x50, 0x4E, 0x47}, 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := service.Attach(context.Background(), "az-123", testFile); err != nil {
+			t.Fatalf("failed to attach file: %v", err)
+		}
+	}
+
+	// Subdirectories aren't attachments
+	if err := os.MkdirAll(filepath.Join(beadsPath, "images", "az-123", "nested"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	count, err = service.Count("az-123")
+	if err != nil {
+		t.Fatalf("failed to count attachments: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 attachments, got %d", count)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	tmpDir := t.TempDir()
 	beadsPath := filepath.Join(tmpDir, "beads")
@@ -0,0 +1,53 @@
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// listCache remembers the beads of the last list by their JSON, so that
+// polling parses only the beads that changed since
+type listCache struct {
+	mu    sync.Mutex
+	tasks map[string]domain.Task
+}
+
+// listRevision identifies one state of the bd list output
+func listRevision(out []byte) string {
+	sum := sha256.Sum256(out)
+	return hex.EncodeToString(sum[:])
+}
+
+// parse parses bd list output, reusing the task parsed last time for any
+// bead whose JSON is unchanged. Reused tasks share their slices and
+// pointers with the earlier list.
+func (lc *listCache) parse(out []byte) ([]domain.Task, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(out, &raws); err != nil {
+		return nil, err
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	parsed := make(map[string]domain.Task, len(raws))
+	tasks := make([]domain.Task, 0, len(raws))
+	for _, raw := range raws {
+		key := string(raw)
+		task, ok := lc.tasks[key]
+		if !ok {
+			if err := json.Unmarshal(raw, &task); err != nil {
+				return nil, err
+			}
+		}
+		parsed[key] = task
+		tasks = append(tasks, task)
+	}
+	// Beads no longer listed drop out of the cache
+	lc.tasks = parsed
+	return tasks, nil
+}
@@ -1,9 +1,13 @@
 package beads
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"os/exec"
+	"strings"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
@@ -12,6 +16,8 @@ import (
 type Client struct {
 	runner CommandRunner
 	logger *slog.Logger
+	cache  *listCache
+	custom domain.CustomStatuses
 }
 
 // NewClient creates a new Beads client with dependency injection
@@ -19,9 +25,23 @@ func NewClient(runner CommandRunner, logger *slog.Logger) *Client {
 	return &Client{
 		runner: runner,
 		logger: logger,
+		cache:  &listCache{},
 	}
 }
 
+// InDir returns a client that runs bd in dir, the root of the project whose
+// beads to use. A runner that isn't a DirRunner can't move, so the client
+// is returned unchanged.
+func (c *Client) InDir(dir string) *Client {
+	runner, ok := c.runner.(DirRunner)
+	if !ok {
+		return c
+	}
+	moved := NewClient(runner.InDir(dir), c.logger)
+	moved.custom = c.custom
+	return moved
+}
+
 // List fetches all beads using `bd list --json`
 func (c *Client) List(ctx context.Context) ([]domain.Task, error) {
 	c.logger.Debug("fetching beads list")
@@ -37,7 +57,33 @@ func (c *Client) List(ctx context.Context) ([]domain.Task, error) {
 	}
 
 	c.logger.Debug("fetched beads", "count", len(tasks))
-	return tasks, nil
+	return c.withCustomStatuses(tasks), nil
+}
+
+// ListSince is List for polling. since is the revision returned along with
+// the tasks the caller already has, or "" for none. When bd's output is the
+// same as at since, it returns since again and no tasks, without parsing;
+// otherwise the tasks and their new revision, parsing only the beads whose
+// JSON changed since the last list.
+func (c *Client) ListSince(ctx context.Context, since string) ([]domain.Task, string, error) {
+	out, err := c.runner.Run(ctx, "bd", "list", "--json")
+	if err != nil {
+		return nil, "", &domain.BeadsError{Op: "list", Err: err}
+	}
+
+	revision := listRevision(out)
+	if revision == since {
+		c.logger.Debug("beads unchanged")
+		return nil, since, nil
+	}
+
+	tasks, err := c.cache.parse(out)
+	if err != nil {
+		return nil, "", &domain.BeadsError{Op: "list", Message: "failed to parse JSON", Err: err}
+	}
+
+	c.logger.Debug("fetched beads", "count", len(tasks))
+	return c.withCustomStatuses(tasks), revision, nil
 }
 
 // Search queries beads using `bd search query --json`
@@ -55,7 +101,65 @@ func (c *Client) Search(ctx context.Context, query string) ([]domain.Task, error
 	}
 
 	c.logger.Debug("found beads", "count", len(tasks))
-	return tasks, nil
+	return c.withCustomStatuses(tasks), nil
+}
+
+// Get fetches a single bead using `bd show --json`. A bead that doesn't
+// exist is reported as a BeadsError wrapping domain.ErrNotFound.
+func (c *Client) Get(ctx context.Context, id string) (*domain.Task, error) {
+	c.logger.Debug("fetching bead", "id", id)
+
+	out, err := c.runner.Run(ctx, "bd", "show", id, "--json")
+	if err != nil {
+		if isNotFound(err) {
+			return nil, &domain.BeadsError{Op: "show", BeadID: id, Message: "bead not found", Err: domain.ErrNotFound}
+		}
+		return nil, &domain.BeadsError{Op: "show", BeadID: id, Message: err.Error(), Err: err}
+	}
+
+	task, err := parseShow(out)
+	if err != nil {
+		return nil, &domain.BeadsError{Op: "show", BeadID: id, Message: "failed to parse JSON", Err: err}
+	}
+	if task == nil {
+		return nil, &domain.BeadsError{Op: "show", BeadID: id, Message: "bead not found", Err: domain.ErrNotFound}
+	}
+	c.applyCustomStatus(task)
+	return task, nil
+}
+
+// parseShow parses `bd show --json` output. Depending on the bd version
+// that is the bead itself or an array of the beads asked for; an empty
+// array returns nil.
+func parseShow(out []byte) (*domain.Task, error) {
+	out = bytes.TrimSpace(out)
+	if bytes.HasPrefix(out, []byte("[")) {
+		var tasks []domain.Task
+		if err := json.Unmarshal(out, &tasks); err != nil {
+			return nil, err
+		}
+		if len(tasks) == 0 {
+			return nil, nil
+		}
+		return &tasks[0], nil
+	}
+
+	var task domain.Task
+	if err := json.Unmarshal(out, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// isNotFound reports whether bd failed because the bead doesn't exist
+func isNotFound(err error) bool {
+	msg := err.Error()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		msg = string(exitErr.Stderr)
+	}
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "no issue")
 }
 
 // Ready fetches unblocked tasks using `bd ready --json`
@@ -73,17 +177,24 @@ func (c *Client) Ready(ctx context.Context) ([]domain.Task, error) {
 	}
 
 	c.logger.Debug("found ready beads", "count", len(tasks))
-	return tasks, nil
+	return c.withCustomStatuses(tasks), nil
 }
 
-// Update changes a bead's status using `bd update id --status=status`
+// Update changes a bead's status using `bd update id --status=status`. A
+// custom status is written as its base, with the custom one kept in the
+// bead's status label.
 func (c *Client) Update(ctx context.Context, id string, status domain.Status) error {
 	c.logger.Debug("updating bead status", "id", id, "status", status)
 
-	_, err := c.runner.Run(ctx, "bd", "update", id, "--status="+string(status))
+	_, err := c.runner.Run(ctx, "bd", "update", id, "--status="+string(status.Base(c.custom)))
 	if err != nil {
 		return &domain.BeadsError{Op: "update", BeadID: id, Err: err}
 	}
+	if len(c.custom) > 0 {
+		if err := c.syncStatusLabel(ctx, id, status); err != nil {
+			return &domain.BeadsError{Op: "update", BeadID: id, Message: "failed to set the status label", Err: err}
+		}
+	}
 
 	c.logger.Debug("bead updated", "id", id)
 	return nil
@@ -96,6 +207,8 @@ type CreateTaskParams struct {
 	Type        domain.TaskType
 	Priority    domain.Priority
 	ParentID    *string
+	Design      string // Technical design notes
+	Acceptance  string // Acceptance criteria
 }
 
 // Create creates a new task using `bd create "title" -t type -p priority --json`
@@ -109,6 +222,15 @@ func (c *Client) Create(ctx context.Context, params CreateTaskParams) (string, e
 	if params.ParentID != nil {
 		args = append(args, "--parent", *params.ParentID)
 	}
+	if params.Description != "" {
+		args = append(args, "--description="+params.Description)
+	}
+	if params.Design != "" {
+		args = append(args, "--design="+params.Design)
+	}
+	if params.Acceptance != "" {
+		args = append(args, "--acceptance="+params.Acceptance)
+	}
 
 	out, err := c.runner.Run(ctx, "bd", args...)
 	if err != nil {
@@ -133,6 +255,33 @@ func (c *Client) Create(ctx context.Context, params CreateTaskParams) (string, e
 	return task.ID, nil
 }
 
+// AddDependency records that childID depends on parentID using
+// `bd dep add child parent --type=depType`
+func (c *Client) AddDependency(ctx context.Context, childID, parentID, depType string) error {
+	c.logger.Debug("adding bead dependency", "child", childID, "parent", parentID, "type", depType)
+
+	_, err := c.runner.Run(ctx, "bd", "dep", "add", childID, parentID, "--type="+depType)
+	if err != nil {
+		return &domain.BeadsError{Op: "dep-add", BeadID: childID, Err: err}
+	}
+
+	c.logger.Debug("bead dependency added", "child", childID, "parent", parentID)
+	return nil
+}
+
+// RemoveDependency removes the dependency of childID on parentID
+func (c *Client) RemoveDependency(ctx context.Context, childID, parentID string) error {
+	c.logger.Debug("removing bead dependency", "child", childID, "parent", parentID)
+
+	_, err := c.runner.Run(ctx, "bd", "dep", "remove", childID, parentID)
+	if err != nil {
+		return &domain.BeadsError{Op: "dep-remove", BeadID: childID, Message: err.Error(), Err: err}
+	}
+
+	c.logger.Debug("bead dependency removed", "child", childID, "parent", parentID)
+	return nil
+}
+
 // Close marks a bead as complete using `bd close id --reason=reason`
 func (c *Client) Close(ctx context.Context, id string, reason string) error {
 	c.logger.Debug("closing bead", "id", id, "reason", reason)
@@ -176,6 +325,19 @@ func (c *Client) Archive(ctx context.Context, id string) error {
 	return nil
 }
 
+// Comment adds a comment to a bead using `bd comments add id text`
+func (c *Client) Comment(ctx context.Context, id string, text string) error {
+	c.logger.Debug("commenting on bead", "id", id)
+
+	_, err := c.runner.Run(ctx, "bd", "comments", "add", id, text)
+	if err != nil {
+		return &domain.BeadsError{Op: "comment", BeadID: id, Err: err}
+	}
+
+	c.logger.Debug("bead comment added", "id", id)
+	return nil
+}
+
 type UpdateTaskParams struct {
 	Title       string
 	Description string
@@ -2,8 +2,11 @@ package beads
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
@@ -15,9 +18,11 @@ import (
 type mockRunner struct {
 	output []byte
 	err    error
+	args   []string // arguments of the last call
 }
 
 func (m *mockRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	m.args = args
 	return m.output, m.err
 }
 
@@ -78,6 +83,135 @@ func TestClient_List(t *testing.T) {
 	}
 }
 
+func TestClient_List_AssigneeAndLabels(t *testing.T) {
+	runner := &mockRunner{output: []byte(`[
+		{"id": "az-1", "title": "Task 1", "status": "open", "assignee": "alice", "labels": ["backend", "urgent"], "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-01T00:00:00Z"},
+		{"id": "az-2", "title": "Task 2", "status": "open", "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-01T00:00:00Z"}
+	]`)}
+	client := NewClient(runner, slog.Default())
+
+	tasks, err := client.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+
+	assert.Equal(t, "alice", tasks[0].Assignee)
+	assert.Equal(t, []string{"backend", "urgent"}, tasks[0].Labels)
+	assert.Empty(t, tasks[1].Assignee)
+	assert.Empty(t, tasks[1].Labels)
+}
+
+func TestClient_ListSince(t *testing.T) {
+	runner := &mockRunner{output: []byte(`[
+		{"id": "az-1", "title": "Task 1", "status": "open", "parent_id": "az-9"},
+		{"id": "az-2", "title": "Task 2", "status": "open"}
+	]`)}
+	client := NewClient(runner, slog.Default())
+	ctx := context.Background()
+
+	tasks, revision, err := client.ListSince(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.NotEmpty(t, revision)
+
+	// The same output at the same revision isn't parsed again
+	unchanged, again, err := client.ListSince(ctx, revision)
+	require.NoError(t, err)
+	assert.Nil(t, unchanged)
+	assert.Equal(t, revision, again)
+
+	// Changed output: only the changed bead is parsed, az-1 is reused
+	runner.output = []byte(`[
+		{"id": "az-1", "title": "Task 1", "status": "open", "parent_id": "az-9"},
+		{"id": "az-2", "title": "Task 2 renamed", "status": "in_progress"}
+	]`)
+	changed, next, err := client.ListSince(ctx, revision)
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	assert.NotEqual(t, revision, next)
+	assert.Same(t, tasks[0].ParentID, changed[0].ParentID, "unchanged bead should be reused, not reparsed")
+	assert.Equal(t, "Task 2 renamed", changed[1].Title)
+	assert.Equal(t, domain.StatusInProgress, changed[1].Status)
+
+	// A caller without the tasks gets them whatever the revision
+	fresh, _, err := client.ListSince(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, fresh, 2)
+
+	runner.output = []byte(`not json`)
+	_, _, err = client.ListSince(ctx, next)
+	var beadsErr *domain.BeadsError
+	require.ErrorAs(t, err, &beadsErr)
+	assert.Equal(t, "list", beadsErr.Op)
+}
+
+func TestClient_Get(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		runErr       error
+		wantTitle    string
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name:      "single object",
+			output:    `{"id": "az-1", "title": "Task 1", "status": "open", "priority": 1, "type": "task", "description": "Fresh", "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-02T00:00:00Z"}`,
+			wantTitle: "Task 1",
+		},
+		{
+			name:      "array of one",
+			output:    `[{"id": "az-1", "title": "Task 1", "status": "open", "priority": 1, "type": "task", "created_at": "2025-01-01T00:00:00Z", "updated_at": "2025-01-01T00:00:00Z"}]`,
+			wantTitle: "Task 1",
+		},
+		{
+			name:         "empty array",
+			output:       `[]`,
+			wantNotFound: true,
+		},
+		{
+			name:         "deleted bead",
+			runErr:       errors.New("Error: issue az-1 not found"),
+			wantNotFound: true,
+		},
+		{
+			name:    "invalid json",
+			output:  `not json`,
+			wantErr: true,
+		},
+		{
+			name:    "runner error",
+			runErr:  errors.New("command failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				output: []byte(tt.output),
+				err:    tt.runErr,
+			}
+			client := NewClient(runner, slog.Default())
+
+			task, err := client.Get(context.Background(), "az-1")
+			assert.Equal(t, []string{"show", "az-1", "--json"}, runner.args)
+
+			if tt.wantNotFound || tt.wantErr {
+				require.Error(t, err)
+				var beadsErr *domain.BeadsError
+				assert.ErrorAs(t, err, &beadsErr)
+				assert.Equal(t, "show", beadsErr.Op)
+				assert.Equal(t, tt.wantNotFound, errors.Is(err, domain.ErrNotFound))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "az-1", task.ID)
+			assert.Equal(t, tt.wantTitle, task.Title)
+		})
+	}
+}
+
 func TestClient_Search(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -238,6 +372,93 @@ func TestClient_Update(t *testing.T) {
 	}
 }
 
+// labelRunner plays bd for one bead, keeping its status and labels
+type labelRunner struct {
+	status string
+	labels []string
+	calls  [][]string
+}
+
+func (r *labelRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, args)
+	switch {
+	case args[0] == "update":
+		r.status = strings.TrimPrefix(args[2], "--status=")
+	case args[0] == "label" && args[1] == "add":
+		r.labels = append(r.labels, args[3])
+	case args[0] == "label" && args[1] == "remove":
+		r.labels = slices.DeleteFunc(r.labels, func(l string) bool { return l == args[3] })
+	}
+	task := map[string]any{"id": "az-1", "title": "Task 1", "status": r.status, "labels": r.labels}
+	if args[0] == "list" {
+		return json.Marshal([]any{task})
+	}
+	return json.Marshal(task)
+}
+
+func TestClient_CustomStatus(t *testing.T) {
+	custom := domain.CustomStatuses{"review": domain.StatusInProgress, "qa": domain.StatusInProgress}
+
+	t.Run("written as its base with a status label", func(t *testing.T) {
+		runner := &labelRunner{status: "in_progress", labels: []string{"frontend"}}
+		client := NewClient(runner, slog.Default())
+		client.SetCustomStatuses(custom)
+
+		require.NoError(t, client.Update(context.Background(), "az-1", "review"))
+		assert.Equal(t, []string{"update", "az-1", "--status=in_progress"}, runner.calls[0])
+		assert.Equal(t, "in_progress", runner.status)
+		assert.Equal(t, []string{"frontend", "status:review"}, runner.labels)
+
+		task, err := client.Get(context.Background(), "az-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Status("review"), task.Status)
+		assert.Equal(t, []string{"frontend"}, task.Labels)
+
+		tasks, err := client.List(context.Background())
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, domain.Status("review"), tasks[0].Status)
+	})
+
+	t.Run("moving on replaces the label", func(t *testing.T) {
+		runner := &labelRunner{status: "in_progress", labels: []string{"status:review"}}
+		client := NewClient(runner, slog.Default())
+		client.SetCustomStatuses(custom)
+
+		require.NoError(t, client.Update(context.Background(), "az-1", "qa"))
+		assert.Equal(t, []string{"status:qa"}, runner.labels)
+
+		require.NoError(t, client.Update(context.Background(), "az-1", domain.StatusDone))
+		assert.Equal(t, "closed", runner.status)
+		assert.Empty(t, runner.labels)
+	})
+
+	t.Run("label ignored once the base changed", func(t *testing.T) {
+		runner := &labelRunner{status: "closed", labels: []string{"status:review"}}
+		client := NewClient(runner, slog.Default())
+		client.SetCustomStatuses(custom)
+
+		task, err := client.Get(context.Background(), "az-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusDone, task.Status)
+	})
+
+	t.Run("no custom statuses leaves labels alone", func(t *testing.T) {
+		runner := &labelRunner{status: "open", labels: []string{"status:review"}}
+		client := NewClient(runner, slog.Default())
+
+		require.NoError(t, client.Update(context.Background(), "az-1", domain.StatusInProgress))
+		assert.Len(t, runner.calls, 1)
+		assert.Equal(t, []string{"status:review"}, runner.labels)
+	})
+
+	t.Run("kept by InDir", func(t *testing.T) {
+		client := NewClient(&ExecRunner{}, slog.Default())
+		client.SetCustomStatuses(custom)
+		assert.Equal(t, custom, client.InDir("/tmp/project").custom)
+	})
+}
+
 func TestClient_Close(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -285,14 +506,58 @@ func TestClient_Close(t *testing.T) {
 	}
 }
 
-func TestClient_Create(t *testing.T) {
+func TestClient_Comment(t *testing.T) {
 	tests := []struct {
 		name    string
-		params  CreateTaskParams
-		output  string
+		id      string
+		text    string
 		runErr  error
-		wantID  string
 		wantErr bool
+	}{
+		{
+			name: "successful comment",
+			id:   "az-1",
+			text: "Session completed",
+		},
+		{
+			name:    "runner error",
+			id:      "az-2",
+			text:    "Session completed",
+			runErr:  errors.New("comment failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			err := client.Comment(context.Background(), tt.id, tt.text)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				var beadsErr *domain.BeadsError
+				assert.ErrorAs(t, err, &beadsErr)
+				assert.Equal(t, "comment", beadsErr.Op)
+				assert.Equal(t, tt.id, beadsErr.BeadID)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   CreateTaskParams
+		output   string
+		runErr   error
+		wantID   string
+		wantArgs []string
+		wantErr  bool
 	}{
 		{
 			name: "successful creation",
@@ -315,6 +580,20 @@ func TestClient_Create(t *testing.T) {
 			output: `{"id": "az-124"}`,
 			wantID: "az-124",
 		},
+		{
+			name: "successful creation with details",
+			params: CreateTaskParams{
+				Title:       "Planned",
+				Description: "What it does",
+				Type:        domain.TypeTask,
+				Priority:    domain.P1,
+				Design:      "How",
+				Acceptance:  "Done when",
+			},
+			output:   `{"id": "az-125"}`,
+			wantID:   "az-125",
+			wantArgs: []string{"create", "Planned", "--json", "-t", "task", "-p", "1", "--description=What it does", "--design=How", "--acceptance=Done when"},
+		},
 		{
 			name:    "runner error",
 			params:  CreateTaskParams{Title: "Fail"},
@@ -340,10 +619,62 @@ func TestClient_Create(t *testing.T) {
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantID, id)
+			if tt.wantArgs != nil {
+				assert.Equal(t, tt.wantArgs, runner.args)
+			}
 		})
 	}
 }
 
+func TestClient_AddDependency(t *testing.T) {
+	t.Run("adds dependency", func(t *testing.T) {
+		runner := &mockRunner{}
+		client := NewClient(runner, slog.Default())
+
+		err := client.AddDependency(context.Background(), "az-2", "az-1", "parent-child")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dep", "add", "az-2", "az-1", "--type=parent-child"}, runner.args)
+	})
+
+	t.Run("runner error", func(t *testing.T) {
+		runner := &mockRunner{err: errors.New("dep failed")}
+		client := NewClient(runner, slog.Default())
+
+		err := client.AddDependency(context.Background(), "az-2", "az-1", "blocks")
+
+		require.Error(t, err)
+		var beadsErr *domain.BeadsError
+		require.ErrorAs(t, err, &beadsErr)
+		assert.Equal(t, "dep-add", beadsErr.Op)
+		assert.Equal(t, "az-2", beadsErr.BeadID)
+	})
+}
+
+func TestClient_RemoveDependency(t *testing.T) {
+	t.Run("removes dependency", func(t *testing.T) {
+		runner := &mockRunner{}
+		client := NewClient(runner, slog.Default())
+
+		err := client.RemoveDependency(context.Background(), "az-2", "az-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dep", "remove", "az-2", "az-1"}, runner.args)
+	})
+
+	t.Run("runner error", func(t *testing.T) {
+		runner := &mockRunner{err: errors.New("dep failed")}
+		client := NewClient(runner, slog.Default())
+
+		err := client.RemoveDependency(context.Background(), "az-2", "az-1")
+
+		require.Error(t, err)
+		var beadsErr *domain.BeadsError
+		require.ErrorAs(t, err, &beadsErr)
+		assert.Equal(t, "dep-remove", beadsErr.Op)
+	})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -376,3 +707,19 @@ func TestClient_ErrorWrapping(t *testing.T) {
 		assert.Contains(t, err.Error(), "az-123")
 	})
 }
+
+func TestClient_InDir(t *testing.T) {
+	t.Run("exec runner moves", func(t *testing.T) {
+		client := NewClient(&ExecRunner{}, slog.Default())
+		moved := client.InDir("/tmp/project")
+
+		runner, ok := moved.runner.(*ExecRunner)
+		require.True(t, ok)
+		assert.Equal(t, "/tmp/project", runner.dir)
+	})
+
+	t.Run("fixed runner stays", func(t *testing.T) {
+		client := NewClient(&mockRunner{}, slog.Default())
+		assert.Same(t, client, client.InDir("/tmp/project"))
+	})
+}
@@ -0,0 +1,42 @@
+package beads
+
+import (
+	"context"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// PlanningClient adapts Client to the bead-creating interface the planning
+// service expects
+type PlanningClient struct {
+	Client *Client
+}
+
+// Create creates a planned task as an open bead
+func (p PlanningClient) Create(ctx context.Context, title, description string, taskType domain.TaskType, priority int, design, acceptance string, estimate *int) (*domain.Task, error) {
+	id, err := p.Client.Create(ctx, CreateTaskParams{
+		Title:       title,
+		Description: description,
+		Type:        taskType,
+		Priority:    domain.Priority(priority),
+		Design:      design,
+		Acceptance:  acceptance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Task{
+		ID:          id,
+		Title:       title,
+		Description: description,
+		Type:        taskType,
+		Priority:    domain.Priority(priority),
+		Status:      domain.StatusOpen,
+	}, nil
+}
+
+// AddDependency records that childID depends on parentID
+func (p PlanningClient) AddDependency(ctx context.Context, childID, parentID, depType string) error {
+	return p.Client.AddDependency(ctx, childID, parentID, depType)
+}
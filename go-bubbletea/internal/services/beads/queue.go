@@ -0,0 +1,229 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/statefile"
+)
+
+// ErrConflict is returned when replaying a queued change whose task changed
+// since the change was queued
+var ErrConflict = errors.New("task changed since the change was queued")
+
+// ErrHeld is returned for a queued change that wasn't tried because an
+// earlier change to the same task failed and stays queued
+var ErrHeld = errors.New("an earlier change to the task is still queued")
+
+// PendingKind is the kind of change held in the offline queue
+type PendingKind string
+
+const (
+	PendingStatus PendingKind = "status" // A status change of TaskID from From to To
+	PendingCreate PendingKind = "create" // A new task described by Create
+)
+
+// PendingChange is a mutation recorded while offline, to be made once the
+// network is back
+type PendingChange struct {
+	Kind     PendingKind       `json:"kind"`
+	TaskID   string            `json:"taskId,omitempty"`
+	From     domain.Status     `json:"from,omitempty"`
+	To       domain.Status     `json:"to,omitempty"`
+	Create   *CreateTaskParams `json:"create,omitempty"`
+	QueuedAt time.Time         `json:"queuedAt"`
+}
+
+// Describe returns a short description of the change for messages
+func (c PendingChange) Describe() string {
+	switch c.Kind {
+	case PendingStatus:
+		return fmt.Sprintf("move %s to %s", c.TaskID, c.To)
+	case PendingCreate:
+		if c.Create != nil {
+			return fmt.Sprintf("create %q", c.Create.Title)
+		}
+	}
+	return string(c.Kind)
+}
+
+// Queue is the offline queue of changes, oldest first
+type Queue []PendingChange
+
+// DefaultQueuePath returns where a project's offline queue is kept:
+// ~/.azedarach/queue/<project-dir>-<hash>.json
+func DefaultQueuePath(projectPath string) string {
+	return statefile.Path("queue", projectPath)
+}
+
+// SaveQueue writes queue to path, replacing the previous file atomically.
+// An empty queue removes the file.
+func SaveQueue(path string, queue Queue) error {
+	if len(queue) == 0 {
+		if err := statefile.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove offline queue: %w", err)
+		}
+		return nil
+	}
+	if err := statefile.Save(path, queue); err != nil {
+		return fmt.Errorf("failed to write offline queue: %w", err)
+	}
+	return nil
+}
+
+// LoadQueue reads the queue at path. It returns an empty queue without an
+// error when none has been saved.
+func LoadQueue(path string) (Queue, error) {
+	var queue Queue
+	if _, err := statefile.Load(path, &queue); err != nil {
+		return nil, fmt.Errorf("failed to read offline queue: %w", err)
+	}
+	return queue, nil
+}
+
+// PendingQueue is a project's offline queue, saved after every change to
+// it. It is safe for concurrent use, so that a replay can drop each change
+// as soon as it's made while more are queued.
+type PendingQueue struct {
+	mu      sync.Mutex
+	path    string
+	changes Queue
+}
+
+// OpenPendingQueue loads the queue saved at path. On an error the queue
+// starts empty, and is still saved to path.
+func OpenPendingQueue(path string) (*PendingQueue, error) {
+	changes, err := LoadQueue(path)
+	return &PendingQueue{path: path, changes: changes}, err
+}
+
+// Add appends change to the queue and saves it
+func (q *PendingQueue) Add(change PendingChange) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.changes = append(q.changes, change)
+	return SaveQueue(q.path, q.changes)
+}
+
+// Remove drops change from the queue and saves it
+func (q *PendingQueue) Remove(change PendingChange) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i := slices.IndexFunc(q.changes, func(c PendingChange) bool { return c.same(change) })
+	if i < 0 {
+		return nil
+	}
+	q.changes = slices.Delete(q.changes, i, i+1)
+	return SaveQueue(q.path, q.changes)
+}
+
+// Changes returns a copy of the queued changes, oldest first
+func (q *PendingQueue) Changes() Queue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Clone(q.changes)
+}
+
+// Len returns how many changes are queued
+func (q *PendingQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.changes)
+}
+
+// same reports whether c and other record the same queued change
+func (c PendingChange) same(other PendingChange) bool {
+	return c.Kind == other.Kind && c.TaskID == other.TaskID && c.To == other.To &&
+		c.QueuedAt.Equal(other.QueuedAt)
+}
+
+// Mutator is the part of the beads client that queued changes are replayed
+// through
+type Mutator interface {
+	Get(ctx context.Context, id string) (*domain.Task, error)
+	Update(ctx context.Context, id string, status domain.Status) error
+	Create(ctx context.Context, params CreateTaskParams) (string, error)
+}
+
+// ReplayResult is the outcome of replaying one queued change
+type ReplayResult struct {
+	Change PendingChange
+	Err    error
+	Kept   bool // Failed but left queued, to retry on the next replay
+}
+
+// Replay makes the queued changes in order. A change that fails, including
+// a status change whose task is no longer in the status it was moved from
+// (ErrConflict), is reported in its result and the rest still run.
+func Replay(ctx context.Context, client Mutator, queue Queue) []ReplayResult {
+	results := make([]ReplayResult, 0, len(queue))
+	for _, change := range queue {
+		results = append(results, ReplayResult{Change: change, Err: replayChange(ctx, client, change)})
+	}
+	return results
+}
+
+// ReplayPending makes the changes in queue in order like Replay, dropping
+// each from the queue as soon as it's made, so that a crash part way
+// doesn't make it twice. A change that can never be made, because its task
+// was moved elsewhere or deleted, is dropped too; other failures stay
+// queued to retry. The later changes to a task whose change stays queued
+// are kept without being tried (ErrHeld), since each was queued from the
+// status the earlier one moves it to.
+func ReplayPending(ctx context.Context, client Mutator, queue *PendingQueue) ([]ReplayResult, error) {
+	changes := queue.Changes()
+	results := make([]ReplayResult, 0, len(changes))
+	held := make(map[string]bool)
+	for _, change := range changes {
+		if change.TaskID != "" && held[change.TaskID] {
+			results = append(results, ReplayResult{Change: change, Err: ErrHeld, Kept: true})
+			continue
+		}
+		err := replayChange(ctx, client, change)
+		if err != nil && !errors.Is(err, ErrConflict) && !errors.Is(err, domain.ErrNotFound) {
+			results = append(results, ReplayResult{Change: change, Err: err, Kept: true})
+			if change.TaskID != "" {
+				held[change.TaskID] = true
+			}
+			continue
+		}
+		results = append(results, ReplayResult{Change: change, Err: err})
+		if err := queue.Remove(change); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// replayChange makes a single queued change
+func replayChange(ctx context.Context, client Mutator, change PendingChange) error {
+	switch change.Kind {
+	case PendingStatus:
+		task, err := client.Get(ctx, change.TaskID)
+		if err != nil {
+			return err
+		}
+		if task.Status == change.To {
+			return nil // Already made elsewhere
+		}
+		if task.Status != change.From {
+			return fmt.Errorf("%w: %s is now %s", ErrConflict, change.TaskID, task.Status)
+		}
+		return client.Update(ctx, change.TaskID, change.To)
+
+	case PendingCreate:
+		if change.Create == nil {
+			return fmt.Errorf("queued create has no task")
+		}
+		_, err := client.Create(ctx, *change.Create)
+		return err
+
+	default:
+		return fmt.Errorf("unknown queued change %q", change.Kind)
+	}
+}
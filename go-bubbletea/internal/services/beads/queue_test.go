@@ -0,0 +1,233 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMutator records replayed changes against a fixed set of tasks
+type mockMutator struct {
+	statuses map[string]domain.Status
+	calls    []string
+	failOn   string // Title of a create that fails
+}
+
+func (m *mockMutator) Get(ctx context.Context, id string) (*domain.Task, error) {
+	status, ok := m.statuses[id]
+	if !ok {
+		return nil, &domain.BeadsError{Op: "show", BeadID: id, Err: domain.ErrNotFound}
+	}
+	return &domain.Task{ID: id, Status: status}, nil
+}
+
+func (m *mockMutator) Update(ctx context.Context, id string, status domain.Status) error {
+	m.calls = append(m.calls, fmt.Sprintf("update %s %s", id, status))
+	m.statuses[id] = status
+	return nil
+}
+
+func (m *mockMutator) Create(ctx context.Context, params CreateTaskParams) (string, error) {
+	if params.Title == m.failOn {
+		return "", errors.New("bd create failed")
+	}
+	m.calls = append(m.calls, "create "+params.Title)
+	return "az-new", nil
+}
+
+func TestQueue_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue", "project.json")
+
+	// Nothing saved yet
+	queue, err := LoadQueue(path)
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+
+	queued := Queue{
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusOpen, To: domain.StatusInProgress, QueuedAt: time.Unix(100, 0).UTC()},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "New task", Type: domain.TypeBug, Priority: domain.P1}, QueuedAt: time.Unix(200, 0).UTC()},
+	}
+	require.NoError(t, SaveQueue(path, queued))
+
+	loaded, err := LoadQueue(path)
+	require.NoError(t, err)
+	assert.Equal(t, queued, loaded)
+
+	// An empty queue leaves no file behind
+	require.NoError(t, SaveQueue(path, nil))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestReplay_InOrder(t *testing.T) {
+	client := &mockMutator{statuses: map[string]domain.Status{
+		"az-1": domain.StatusOpen,
+	}}
+	queue := Queue{
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusOpen, To: domain.StatusInProgress},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "First"}},
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusInProgress, To: domain.StatusDone},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "Second"}},
+	}
+
+	results := Replay(context.Background(), client, queue)
+
+	require.Len(t, results, 4)
+	for i, result := range results {
+		assert.NoError(t, result.Err)
+		assert.Equal(t, queue[i], result.Change)
+	}
+	assert.Equal(t, []string{
+		"update az-1 in_progress",
+		"create First",
+		"update az-1 closed",
+		"create Second",
+	}, client.calls)
+}
+
+func TestReplay_ConflictsDontAbort(t *testing.T) {
+	client := &mockMutator{
+		statuses: map[string]domain.Status{
+			"az-1": domain.StatusBlocked, // Moved elsewhere while we were offline
+			"az-2": domain.StatusOpen,
+			"az-3": domain.StatusInProgress, // Already where we wanted it
+		},
+		failOn: "Broken",
+	}
+	queue := Queue{
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusOpen, To: domain.StatusInProgress},
+		{Kind: PendingStatus, TaskID: "az-gone", From: domain.StatusOpen, To: domain.StatusInProgress},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "Broken"}},
+		{Kind: PendingStatus, TaskID: "az-3", From: domain.StatusOpen, To: domain.StatusInProgress},
+		{Kind: PendingStatus, TaskID: "az-2", From: domain.StatusOpen, To: domain.StatusInProgress},
+	}
+
+	results := Replay(context.Background(), client, queue)
+
+	require.Len(t, results, 5)
+	assert.ErrorIs(t, results[0].Err, ErrConflict)
+	assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+	assert.Error(t, results[2].Err)
+	assert.NoError(t, results[3].Err)
+	assert.NoError(t, results[4].Err)
+	assert.Equal(t, []string{"update az-2 in_progress"}, client.calls)
+}
+
+// savingMutator records how many changes were still saved each time a
+// change is made
+type savingMutator struct {
+	mockMutator
+	path  string
+	saved []int
+}
+
+func (m *savingMutator) Create(ctx context.Context, params CreateTaskParams) (string, error) {
+	queue, _ := LoadQueue(m.path)
+	m.saved = append(m.saved, len(queue))
+	return m.mockMutator.Create(ctx, params)
+}
+
+func TestReplayPending_DropsEachChangeAsMade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	queue, err := OpenPendingQueue(path)
+	require.NoError(t, err)
+	changes := Queue{
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "First"}, QueuedAt: time.Unix(1, 0)},
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusOpen, To: domain.StatusInProgress, QueuedAt: time.Unix(2, 0)},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "Broken"}, QueuedAt: time.Unix(3, 0)},
+		{Kind: PendingCreate, Create: &CreateTaskParams{Title: "Second"}, QueuedAt: time.Unix(4, 0)},
+	}
+	for _, change := range changes {
+		require.NoError(t, queue.Add(change))
+	}
+	client := &savingMutator{
+		mockMutator: mockMutator{
+			statuses: map[string]domain.Status{"az-1": domain.StatusBlocked},
+			failOn:   "Broken",
+		},
+		path: path,
+	}
+
+	results, err := ReplayPending(context.Background(), client, queue)
+
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, ErrConflict)
+	assert.False(t, results[1].Kept, "a conflict can never be made, so it isn't kept")
+	assert.Error(t, results[2].Err)
+	assert.True(t, results[2].Kept)
+	assert.NoError(t, results[3].Err)
+
+	// Each change left the saved queue before the next was made
+	assert.Equal(t, []int{4, 2, 2}, client.saved)
+
+	// Only the failed create stays queued, on disk too
+	assert.Equal(t, Queue{changes[2]}, queue.Changes())
+	saved, err := LoadQueue(path)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "Broken", saved[0].Create.Title)
+}
+
+// flakyMutator fails the first update of each task listed in failOnce
+type flakyMutator struct {
+	mockMutator
+	failOnce map[string]bool
+}
+
+func (m *flakyMutator) Update(ctx context.Context, id string, status domain.Status) error {
+	if m.failOnce[id] {
+		delete(m.failOnce, id)
+		return errors.New("connection reset")
+	}
+	return m.mockMutator.Update(ctx, id, status)
+}
+
+func TestReplayPending_HoldsLaterChangesToAKeptTask(t *testing.T) {
+	queue, err := OpenPendingQueue(filepath.Join(t.TempDir(), "queue.json"))
+	require.NoError(t, err)
+	changes := Queue{
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusOpen, To: domain.StatusInProgress, QueuedAt: time.Unix(1, 0)},
+		{Kind: PendingStatus, TaskID: "az-1", From: domain.StatusInProgress, To: domain.StatusDone, QueuedAt: time.Unix(2, 0)},
+		{Kind: PendingStatus, TaskID: "az-2", From: domain.StatusOpen, To: domain.StatusBlocked, QueuedAt: time.Unix(3, 0)},
+	}
+	for _, change := range changes {
+		require.NoError(t, queue.Add(change))
+	}
+	client := &flakyMutator{
+		mockMutator: mockMutator{statuses: map[string]domain.Status{
+			"az-1": domain.StatusOpen,
+			"az-2": domain.StatusOpen,
+		}},
+		failOnce: map[string]bool{"az-1": true},
+	}
+
+	results, err := ReplayPending(context.Background(), client, queue)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Kept)
+	assert.ErrorIs(t, results[1].Err, ErrHeld)
+	assert.True(t, results[1].Kept, "the later move isn't tried, so it isn't lost as a conflict")
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, Queue{changes[0], changes[1]}, queue.Changes())
+
+	// Once the connection is back, both moves of az-1 are made in order
+	results, err = ReplayPending(context.Background(), client, queue)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.Empty(t, queue.Changes())
+	assert.Equal(t, domain.StatusDone, client.statuses["az-1"])
+}
@@ -2,17 +2,39 @@ package beads
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os/exec"
 	"time"
 )
 
+// ErrNotInstalled is returned when the beads CLI isn't installed, so that
+// callers can tell it apart from bd failing
+var ErrNotInstalled = errors.New("beads CLI not found")
+
 // CommandRunner abstracts command execution for testing
 type CommandRunner interface {
 	Run(ctx context.Context, name string, args ...string) ([]byte, error)
 }
 
-// ExecRunner runs real shell commands using os/exec
-type ExecRunner struct{}
+// DirRunner is a CommandRunner that can run its commands in another
+// directory, so that a client can follow the selected project
+type DirRunner interface {
+	CommandRunner
+	InDir(dir string) CommandRunner
+}
+
+// ExecRunner runs real shell commands using os/exec. The zero value runs
+// them in the current directory.
+type ExecRunner struct {
+	dir string
+}
+
+// InDir returns an ExecRunner that runs commands in dir
+func (r *ExecRunner) InDir(dir string) CommandRunner {
+	return &ExecRunner{dir: dir}
+}
 
 // Run executes a command with a 5-second timeout
 func (r *ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
@@ -24,5 +46,21 @@ func (r *ExecRunner) Run(ctx context.Context, name string, args ...string) ([]by
 	}
 
 	cmd := exec.CommandContext(ctx, name, args...)
-	return cmd.Output()
+	cmd.Dir = r.dir
+	out, err := cmd.Output()
+	if isNotInstalled(err, name) {
+		return out, fmt.Errorf("%w: %v", ErrNotInstalled, err)
+	}
+	return out, err
+}
+
+// isNotInstalled reports whether err means the command name couldn't be
+// found: not on PATH, or a path to it that doesn't exist. Other missing
+// files, like the working directory, are a different problem.
+func isNotInstalled(err error, name string) bool {
+	if errors.Is(err, exec.ErrNotFound) {
+		return true
+	}
+	var pathErr *fs.PathError
+	return errors.As(err, &pathErr) && pathErr.Path == name && errors.Is(pathErr.Err, fs.ErrNotExist)
 }
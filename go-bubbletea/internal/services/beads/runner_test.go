@@ -0,0 +1,42 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNotInstalled(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"not on PATH", &exec.Error{Name: "bd", Err: exec.ErrNotFound}, true},
+		{"missing path", &fs.PathError{Op: "fork/exec", Path: "bd", Err: fs.ErrNotExist}, true},
+		{"missing directory", &fs.PathError{Op: "chdir", Path: "/gone", Err: fs.ErrNotExist}, false},
+		{"exit error", &exec.ExitError{}, false},
+		{"other error", errors.New("boom"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isNotInstalled(tt.err, "bd"))
+		})
+	}
+}
+
+func TestExecRunner_NotInstalled(t *testing.T) {
+	runner := &ExecRunner{}
+
+	_, err := runner.Run(context.Background(), "azedarach-test-no-such-bd", "list")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotInstalled)
+}
@@ -0,0 +1,91 @@
+package beads
+
+import (
+	"context"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// statusLabelPrefix marks the label that holds a bead's custom status. bd
+// only knows its own statuses, so a bead in a custom one such as review is
+// stored as its base status plus the label status:review.
+const statusLabelPrefix = "status:"
+
+// SetCustomStatuses sets the custom statuses the client maps to and from
+// bd's own: Update writes a custom status as its base plus a status label,
+// and reads report the custom status again.
+func (c *Client) SetCustomStatuses(custom domain.CustomStatuses) {
+	c.custom = custom
+}
+
+// withCustomStatuses returns tasks with each bead's custom status applied
+// and its status label dropped. A label whose custom status no longer has
+// the bead's base, as after a move by another tool, is ignored.
+func (c *Client) withCustomStatuses(tasks []domain.Task) []domain.Task {
+	if len(c.custom) == 0 {
+		return tasks
+	}
+	for i := range tasks {
+		c.applyCustomStatus(&tasks[i])
+	}
+	return tasks
+}
+
+// applyCustomStatus is withCustomStatuses for a single task. Labels is
+// rebuilt rather than filtered in place, since the list cache shares it.
+func (c *Client) applyCustomStatus(task *domain.Task) {
+	if len(c.custom) == 0 {
+		return
+	}
+	var labels []string
+	for _, label := range task.Labels {
+		name, ok := strings.CutPrefix(label, statusLabelPrefix)
+		if !ok {
+			labels = append(labels, label)
+			continue
+		}
+		if base, ok := c.custom[domain.Status(name)]; ok && base == task.Status {
+			task.Status = domain.Status(name)
+		}
+	}
+	task.Labels = labels
+}
+
+// syncStatusLabel makes the bead's status label match status: it removes
+// any other status label and adds one for a custom status.
+func (c *Client) syncStatusLabel(ctx context.Context, id string, status domain.Status) error {
+	out, err := c.runner.Run(ctx, "bd", "show", id, "--json")
+	if err != nil {
+		return err
+	}
+	task, err := parseShow(out)
+	if err != nil {
+		return err
+	}
+
+	want := ""
+	if _, ok := c.custom[status]; ok {
+		want = statusLabelPrefix + string(status)
+	}
+	has := false
+	if task != nil {
+		for _, label := range task.Labels {
+			if label == want {
+				has = true
+				continue
+			}
+			if strings.HasPrefix(label, statusLabelPrefix) {
+				if _, err := c.runner.Run(ctx, "bd", "label", "remove", id, label); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if want != "" && !has {
+		if _, err := c.runner.Run(ctx, "bd", "label", "add", id, want); err != nil {
+			return err
+		}
+	}
+	return nil
+}
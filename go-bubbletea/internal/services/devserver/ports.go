@@ -22,6 +22,14 @@ func NewPortAllocator(basePort int) *PortAllocator {
 	}
 }
 
+// SetBasePort moves where later allocations start; ports already
+// allocated are kept
+func (p *PortAllocator) SetBasePort(basePort int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.basePort = basePort
+}
+
 // Allocate finds an available port starting from basePort and assigns it to the beadID.
 // Returns an error if the bead already has a port or if no ports are available.
 func (p *PortAllocator) Allocate(beadID string) (int, error) {
@@ -0,0 +1,98 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Process is one entry of the system process table
+type Process struct {
+	PID  int
+	PPID int
+	CPU  float64 // Percent of one core
+	RSS  uint64  // Resident memory in bytes
+}
+
+// ProcessMetrics is the resource usage of a session's whole process tree
+type ProcessMetrics struct {
+	CPU float64 // Percent of one core, summed over the tree
+	RSS uint64  // Resident memory in bytes, summed over the tree
+}
+
+// ProcessLister reads the system process table
+type ProcessLister interface {
+	ListProcesses(ctx context.Context) ([]Process, error)
+}
+
+// PSLister lists processes with ps, which macOS and Linux both provide
+type PSLister struct{}
+
+// ListProcesses runs ps over every process
+// Uses: ps -A -o pid= -o ppid= -o pcpu= -o rss=
+func (PSLister) ListProcesses(ctx context.Context) ([]Process, error) {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("process metrics not supported on %s", runtime.GOOS)
+	}
+
+	out, err := exec.CommandContext(ctx, "ps", "-A", "-o", "pid=", "-o", "ppid=", "-o", "pcpu=", "-o", "rss=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ps: %w", err)
+	}
+	return parsePS(string(out)), nil
+}
+
+// parsePS parses ps output of pid, ppid, pcpu and rss (in KiB) per line,
+// skipping lines it can't read
+func parsePS(out string) []Process {
+	var procs []Process
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		cpu, err3 := strconv.ParseFloat(fields[2], 64)
+		rss, err4 := strconv.ParseUint(fields[3], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		procs = append(procs, Process{PID: pid, PPID: ppid, CPU: cpu, RSS: rss * 1024})
+	}
+	return procs
+}
+
+// treeMetrics sums the usage of the processes rooted at roots and all their
+// descendants. A process is counted once even if it's under several roots;
+// roots missing from procs contribute nothing.
+func treeMetrics(roots []int, procs []Process) ProcessMetrics {
+	byPID := make(map[int]Process, len(procs))
+	children := make(map[int][]int, len(procs))
+	for _, p := range procs {
+		byPID[p.PID] = p
+		children[p.PPID] = append(children[p.PPID], p.PID)
+	}
+
+	var metrics ProcessMetrics
+	seen := make(map[int]bool)
+	queue := append([]int(nil), roots...)
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+
+		if p, ok := byPID[pid]; ok {
+			metrics.CPU += p.CPU
+			metrics.RSS += p.RSS
+		}
+		queue = append(queue, children[pid]...)
+	}
+	return metrics
+}
@@ -0,0 +1,140 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/tmux"
+)
+
+// The tmux client is what supplies pane processes in the app
+var _ PaneLister = (*tmux.Client)(nil)
+
+// stubProcesses serves a fixed process table
+type stubProcesses struct {
+	procs []Process
+	err   error
+}
+
+func (s stubProcesses) ListProcesses(ctx context.Context) ([]Process, error) {
+	return s.procs, s.err
+}
+
+// sampleProcesses is two sessions' trees: az-1's shell 100 runs claude 101,
+// which runs a test process 102; az-2's shell 200 is idle. 1 is init.
+var sampleProcesses = []Process{
+	{PID: 1, PPID: 0, CPU: 0.1, RSS: 8 << 20},
+	{PID: 100, PPID: 1, CPU: 0.5, RSS: 4 << 20},
+	{PID: 101, PPID: 100, CPU: 42.0, RSS: 300 << 20},
+	{PID: 102, PPID: 101, CPU: 55.5, RSS: 120 << 20},
+	{PID: 200, PPID: 1, CPU: 0.0, RSS: 4 << 20},
+}
+
+func TestTreeMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		roots   []int
+		wantCPU float64
+		wantRSS uint64
+	}{
+		{"whole tree", []int{100}, 98.0, 424 << 20},
+		{"subtree", []int{101}, 97.5, 420 << 20},
+		{"idle shell", []int{200}, 0.0, 4 << 20},
+		{"several panes", []int{100, 200}, 98.0, 428 << 20},
+		{"overlapping roots counted once", []int{100, 101}, 98.0, 424 << 20},
+		{"exited pane", []int{999}, 0.0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := treeMetrics(tt.roots, sampleProcesses)
+			if metrics.CPU != tt.wantCPU {
+				t.Errorf("CPU = %v, want %v", metrics.CPU, tt.wantCPU)
+			}
+			if metrics.RSS != tt.wantRSS {
+				t.Errorf("RSS = %v, want %v", metrics.RSS, tt.wantRSS)
+			}
+		})
+	}
+}
+
+func TestParsePS(t *testing.T) {
+	out := "    1     0  0.0  9216\n  100     1  2.5  4096\nbad line\n  101   100 97.3 307200\n"
+
+	procs := parsePS(out)
+	want := []Process{
+		{PID: 1, PPID: 0, CPU: 0.0, RSS: 9216 * 1024},
+		{PID: 100, PPID: 1, CPU: 2.5, RSS: 4096 * 1024},
+		{PID: 101, PPID: 100, CPU: 97.3, RSS: 307200 * 1024},
+	}
+	if len(procs) != len(want) {
+		t.Fatalf("parsed %d processes, want %d: %+v", len(procs), len(want), procs)
+	}
+	for i := range want {
+		if procs[i] != want[i] {
+			t.Errorf("process %d = %+v, want %+v", i, procs[i], want[i])
+		}
+	}
+}
+
+func TestGetSessionHealth_Metrics(t *testing.T) {
+	sessions := map[string]*domain.Session{
+		"az-1": {BeadID: "az-1", State: domain.SessionBusy},
+		"az-2": {BeadID: "az-2", State: domain.SessionWaiting},
+		"az-3": {BeadID: "az-3", State: domain.SessionIdle}, // No tmux session left
+	}
+	tmux := &mockTmuxClient{panePIDs: map[string][]int{"az-1": {100}, "az-2": {200}}}
+	service := NewService(tmux, &mockPortAllocator{}, &mockNetworkChecker{online: true}, defaultThresholds)
+	service.processes = stubProcesses{procs: sampleProcesses}
+
+	metrics := make(map[string]*ProcessMetrics)
+	for _, info := range service.GetSessionHealth(context.Background(), sessions) {
+		metrics[info.BeadID] = info.Metrics
+	}
+
+	if m := metrics["az-1"]; m == nil || m.CPU != 98.0 || m.RSS != 424<<20 {
+		t.Errorf("az-1 metrics = %+v, want cpu 98 and 424 MiB", m)
+	}
+	if m := metrics["az-2"]; m == nil || m.CPU != 0 || m.RSS != 4<<20 {
+		t.Errorf("az-2 metrics = %+v, want cpu 0 and 4 MiB", m)
+	}
+	if m := metrics["az-3"]; m != nil {
+		t.Errorf("az-3 metrics = %+v, want none without panes", m)
+	}
+}
+
+func TestGetSessionHealth_MetricsUnavailable(t *testing.T) {
+	sessions := map[string]*domain.Session{
+		"az-1": {BeadID: "az-1", State: domain.SessionBusy},
+	}
+	tmux := &mockTmuxClient{panePIDs: map[string][]int{"az-1": {100}}}
+	service := NewService(tmux, &mockPortAllocator{}, &mockNetworkChecker{online: true}, defaultThresholds)
+	service.processes = stubProcesses{err: errors.New("process metrics not supported on windows")}
+
+	health := service.GetSessionHealth(context.Background(), sessions)
+	if len(health) != 1 || health[0].Metrics != nil {
+		t.Errorf("expected az-1 without metrics, got %+v", health)
+	}
+}
+
+func TestGetSessionHealth_NoPaneLister(t *testing.T) {
+	sessions := map[string]*domain.Session{
+		"az-1": {BeadID: "az-1", State: domain.SessionBusy},
+	}
+	// A backend without pane pids, as with zellij
+	service := NewService(noPanesTmux{}, &mockPortAllocator{}, &mockNetworkChecker{online: true}, defaultThresholds)
+	service.processes = stubProcesses{procs: sampleProcesses}
+
+	health := service.GetSessionHealth(context.Background(), sessions)
+	if len(health) != 1 || health[0].Metrics != nil {
+		t.Errorf("expected az-1 without metrics, got %+v", health)
+	}
+}
+
+// noPanesTmux is a TmuxClient that can't list pane processes
+type noPanesTmux struct{}
+
+func (noPanesTmux) ListSessions(ctx context.Context) ([]string, error)        { return nil, nil }
+func (noPanesTmux) HasSession(ctx context.Context, name string) (bool, error) { return false, nil }
@@ -5,11 +5,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
@@ -38,6 +40,7 @@ type SessionInfo struct {
 	StartedAt *time.Time
 	Worktree  string
 	Uptime    time.Duration
+	Metrics   *ProcessMetrics // CPU and memory of the session's processes, nil when unavailable
 }
 
 // WorktreeInfo represents information about a git worktree
@@ -56,6 +59,67 @@ type NetworkInfo struct {
 	LastCheck   time.Time
 	Latency     time.Duration
 	HealthState HealthStatus
+
+	// History is the latency of the latest checks, oldest first; a
+	// negative entry is a check that failed
+	History []time.Duration
+}
+
+// LatencyStats returns the lowest, mean and highest latency of the checks
+// in History that succeeded; ok is false if none did
+func (n NetworkInfo) LatencyStats() (lo, avg, hi time.Duration, ok bool) {
+	var total time.Duration
+	count := 0
+	for _, latency := range n.History {
+		if latency < 0 {
+			continue
+		}
+		if count == 0 || latency < lo {
+			lo = latency
+		}
+		if latency > hi {
+			hi = latency
+		}
+		total += latency
+		count++
+	}
+	if count == 0 {
+		return 0, 0, 0, false
+	}
+	return lo, total / time.Duration(count), hi, true
+}
+
+// NetworkFeature describes a feature and whether it needs connectivity
+type NetworkFeature struct {
+	Name            string
+	RequiresNetwork bool
+}
+
+// Features listed in the diagnostics Network section
+const (
+	FeaturePRCreation     = "GitHub PR Creation"
+	FeatureGitPushPull    = "Git Push/Pull"
+	FeaturePackageInstall = "Package Installation"
+	FeatureClaudeAPI      = "Claude API"
+)
+
+// NetworkFeatures is the list of network-dependent features. It drives both
+// the diagnostics display and gating of online-only actions.
+var NetworkFeatures = []NetworkFeature{
+	{Name: FeaturePRCreation, RequiresNetwork: true},
+	{Name: FeatureGitPushPull, RequiresNetwork: true},
+	{Name: FeaturePackageInstall, RequiresNetwork: true},
+	{Name: FeatureClaudeAPI, RequiresNetwork: true},
+}
+
+// RequiresNetwork reports whether the named feature needs connectivity
+func RequiresNetwork(feature string) bool {
+	for _, f := range NetworkFeatures {
+		if f.Name == feature {
+			return f.RequiresNetwork
+		}
+	}
+	return false
 }
 
 // SystemInfo represents overall system information
@@ -71,6 +135,8 @@ type SystemInfo struct {
 type SystemDiagnostics struct {
 	Timestamp    time.Time
 	OverallState HealthStatus
+	Reasons      []string // Why OverallState isn't healthy, worst first
+	BeadsPath    string   // .beads directory of the current project, if known
 	Ports        []PortInfo
 	Sessions     []SessionInfo
 	Worktrees    []WorktreeInfo
@@ -86,6 +152,13 @@ type TmuxClient interface {
 	HasSession(ctx context.Context, name string) (bool, error)
 }
 
+// PaneLister is implemented by multiplexer clients that can name the process
+// running in each of a session's panes. Only tmux does; sessions under other
+// backends get no resource metrics.
+type PaneLister interface {
+	PanePIDs(ctx context.Context, name string) ([]int, error)
+}
+
 // GitClient interface for git operations
 type GitClient interface {
 	ListWorktrees(ctx context.Context) ([]string, error)
@@ -102,6 +175,12 @@ type NetworkChecker interface {
 	LastCheck() time.Time
 }
 
+// LatencyReporter is implemented by network checkers that keep the latency
+// of their latest checks
+type LatencyReporter interface {
+	LatencyHistory() []time.Duration
+}
+
 // Service provides system diagnostics and health monitoring
 type Service struct {
 	mu sync.RWMutex
@@ -110,21 +189,34 @@ type Service struct {
 	tmuxClient     TmuxClient
 	portAllocator  PortAllocator
 	networkChecker NetworkChecker
+	processes      ProcessLister
+
+	// Thresholds OverallState is judged by
+	thresholds config.DiagnosticsConfig
 
 	// Cached diagnostics
 	lastDiagnostics *SystemDiagnostics
 	lastUpdate      time.Time
 }
 
-// NewService creates a new diagnostics service
-func NewService(tmux TmuxClient, ports PortAllocator, network NetworkChecker) *Service {
+// NewService creates a new diagnostics service judging health by thresholds
+func NewService(tmux TmuxClient, ports PortAllocator, network NetworkChecker, thresholds config.DiagnosticsConfig) *Service {
 	return &Service{
 		tmuxClient:     tmux,
 		portAllocator:  ports,
 		networkChecker: network,
+		processes:      PSLister{},
+		thresholds:     thresholds,
 	}
 }
 
+// SetThresholds changes the thresholds later diagnostics are judged by
+func (s *Service) SetThresholds(thresholds config.DiagnosticsConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thresholds = thresholds
+}
+
 // GetSystemStatus returns the overall system health status
 func (s *Service) GetSystemStatus(ctx context.Context, sessions map[string]*domain.Session) HealthStatus {
 	diag := s.CollectDiagnostics(ctx, sessions, nil)
@@ -156,10 +248,18 @@ func (s *Service) GetPortConflicts(ctx context.Context, sessions map[string]*dom
 	return conflicts
 }
 
-// GetSessionHealth returns session status summary
+// GetSessionHealth returns session status summary, with each session's CPU
+// and memory use where the process table can be read
 func (s *Service) GetSessionHealth(ctx context.Context, sessions map[string]*domain.Session) []SessionInfo {
 	var sessionInfos []SessionInfo
 
+	// One process table serves every session; without it metrics are left out
+	panes, canListPanes := s.tmuxClient.(PaneLister)
+	var procs []Process
+	if len(sessions) > 0 && canListPanes && s.processes != nil {
+		procs, _ = s.processes.ListProcesses(ctx)
+	}
+
 	for beadID, session := range sessions {
 		info := SessionInfo{
 			Name:      beadID,
@@ -173,6 +273,13 @@ func (s *Service) GetSessionHealth(ctx context.Context, sessions map[string]*dom
 			info.Uptime = time.Since(*session.StartedAt)
 		}
 
+		if len(procs) > 0 {
+			if pids, err := panes.PanePIDs(ctx, beadID); err == nil && len(pids) > 0 {
+				metrics := treeMetrics(pids, procs)
+				info.Metrics = &metrics
+			}
+		}
+
 		sessionInfos = append(sessionInfos, info)
 	}
 
@@ -214,6 +321,7 @@ func (s *Service) CollectDiagnostics(ctx context.Context, sessions map[string]*d
 	// Collect port information
 	var ports []PortInfo
 	seenPorts := make(map[int]bool)
+	portConflicts := 0
 
 	for beadID, session := range sessions {
 		if session.DevServer != nil {
@@ -230,6 +338,7 @@ func (s *Service) CollectDiagnostics(ctx context.Context, sessions map[string]*d
 
 				// Add warning if port is in use but not available
 				if session.DevServer.Running && !available {
+					portConflicts++
 					warnings = append(warnings, fmt.Sprintf("Port %d allocated to %s but not available", port, beadID))
 				}
 			}
@@ -260,17 +369,39 @@ func (s *Service) CollectDiagnostics(ctx context.Context, sessions map[string]*d
 	// Collect worktree information
 	worktreeInfos := s.GetWorktreeStatus(ctx, sessions)
 
+	// Check the current project's beads directory
+	var beadsDir string
+	if beadsPath != nil {
+		beadsDir = *beadsPath
+		if _, err := os.Stat(beadsDir); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Beads directory not found: %s", beadsDir))
+		}
+	}
+
+	// Warnings so far beyond port conflicts each degrade health
+	otherWarnings := len(warnings) - portConflicts
+
 	// Collect network information
 	network := NetworkInfo{
 		IsOnline:  s.networkChecker.IsOnline(),
 		LastCheck: s.networkChecker.LastCheck(),
 	}
+	if reporter, ok := s.networkChecker.(LatencyReporter); ok {
+		network.History = reporter.LatencyHistory()
+		if n := len(network.History); n > 0 && network.History[n-1] >= 0 {
+			network.Latency = network.History[n-1]
+		}
+	}
 
-	if !network.IsOnline {
+	switch {
+	case network.IsOnline:
+		network.HealthState = HealthHealthy
+	case s.thresholds.OfflineDegraded:
+		network.HealthState = HealthDegraded
+		warnings = append(warnings, "Network is offline")
+	default:
 		network.HealthState = HealthCritical
 		errors = append(errors, "Network is offline")
-	} else {
-		network.HealthState = HealthHealthy
 	}
 
 	// Collect system information
@@ -285,17 +416,19 @@ func (s *Service) CollectDiagnostics(ctx context.Context, sessions map[string]*d
 		MemoryUsage:  memStats.Alloc,
 	}
 
-	// Determine overall health state
-	overallState := HealthHealthy
-	if len(errors) > 0 {
-		overallState = HealthCritical
-	} else if len(warnings) > 0 {
-		overallState = HealthDegraded
-	}
+	overallState, reasons := evaluateHealth(healthInputs{
+		online:        network.IsOnline,
+		portConflicts: portConflicts,
+		otherWarnings: otherWarnings,
+		memoryUsage:   system.MemoryUsage,
+		goroutines:    system.NumGoroutine,
+	}, s.thresholds)
 
 	diag := &SystemDiagnostics{
 		Timestamp:    now,
 		OverallState: overallState,
+		Reasons:      reasons,
+		BeadsPath:    beadsDir,
 		Ports:        ports,
 		Sessions:     sessionInfos,
 		Worktrees:    worktreeInfos,
@@ -317,6 +450,9 @@ func (s *Service) FormatDiagnostics(diag *SystemDiagnostics) string {
 
 	// Overall status
 	b.WriteString(fmt.Sprintf("System Status: %s\n", strings.ToUpper(string(diag.OverallState))))
+	for _, reason := range diag.Reasons {
+		b.WriteString(fmt.Sprintf("  because: %s\n", reason))
+	}
 	b.WriteString(fmt.Sprintf("Last Updated: %s\n\n", diag.Timestamp.Format("15:04:05")))
 
 	// Errors
@@ -356,6 +492,9 @@ func (s *Service) FormatDiagnostics(diag *SystemDiagnostics) string {
 			if session.Uptime > 0 {
 				b.WriteString(fmt.Sprintf(" (uptime: %s)", formatDuration(session.Uptime)))
 			}
+			if session.Metrics != nil {
+				b.WriteString(fmt.Sprintf(" [cpu: %.1f%%, mem: %s]", session.Metrics.CPU, formatBytes(session.Metrics.RSS)))
+			}
 			b.WriteString("\n")
 		}
 	}
@@ -403,8 +542,63 @@ func (s *Service) GetCachedDiagnostics() *SystemDiagnostics {
 	return s.lastDiagnostics
 }
 
+// healthInputs are the measurements overall health is judged on
+type healthInputs struct {
+	online        bool
+	portConflicts int
+	otherWarnings int // Orphaned sessions, a missing beads directory...
+	memoryUsage   uint64
+	goroutines    int
+}
+
+// evaluateHealth judges overall health against thresholds, returning the
+// state and a reason for each threshold crossed, critical ones first
+func evaluateHealth(in healthInputs, thresholds config.DiagnosticsConfig) (HealthStatus, []string) {
+	var critical, degraded []string
+
+	if !in.online {
+		if thresholds.OfflineDegraded {
+			degraded = append(degraded, "Network is offline")
+		} else {
+			critical = append(critical, "Network is offline")
+		}
+	}
+	if in.portConflicts > thresholds.MaxPortConflicts {
+		degraded = append(degraded, fmt.Sprintf("%s, more than the %d allowed",
+			plural(in.portConflicts, "port conflict"), thresholds.MaxPortConflicts))
+	}
+	if ceiling := uint64(thresholds.MaxMemoryMB) * 1024 * 1024; ceiling > 0 && in.memoryUsage > ceiling {
+		degraded = append(degraded, fmt.Sprintf("Memory use %s is over the %d MB ceiling",
+			formatBytes(in.memoryUsage), thresholds.MaxMemoryMB))
+	}
+	if thresholds.MaxGoroutines > 0 && in.goroutines > thresholds.MaxGoroutines {
+		degraded = append(degraded, fmt.Sprintf("%d goroutines is over the ceiling of %d",
+			in.goroutines, thresholds.MaxGoroutines))
+	}
+	if in.otherWarnings > 0 {
+		degraded = append(degraded, plural(in.otherWarnings, "warning"))
+	}
+
+	switch {
+	case len(critical) > 0:
+		return HealthCritical, append(critical, degraded...)
+	case len(degraded) > 0:
+		return HealthDegraded, degraded
+	default:
+		return HealthHealthy, nil
+	}
+}
+
 // Helper functions
 
+// plural formats n of noun, adding an s unless n is 1
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
 // isPortAvailable checks if a port is available by attempting to listen on it
 func isPortAvailable(port int) bool {
 	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
@@ -2,18 +2,26 @@ package diagnostics
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
+// defaultThresholds judges health as an unconfigured project would
+var defaultThresholds = config.DefaultConfig().Diagnostics
+
 // Mock TmuxClient for testing
 type mockTmuxClient struct {
-	sessions    []string
-	sessionErr  error
-	hasSession  bool
-	hasErr      error
+	sessions   []string
+	sessionErr error
+	hasSession bool
+	hasErr     error
+	panePIDs   map[string][]int
 }
 
 func (m *mockTmuxClient) ListSessions(ctx context.Context) ([]string, error) {
@@ -24,6 +32,14 @@ func (m *mockTmuxClient) HasSession(ctx context.Context, name string) (bool, err
 	return m.hasSession, m.hasErr
 }
 
+func (m *mockTmuxClient) PanePIDs(ctx context.Context, name string) ([]int, error) {
+	pids, ok := m.panePIDs[name]
+	if !ok {
+		return nil, errors.New("can't find session " + name)
+	}
+	return pids, nil
+}
+
 // Mock PortAllocator for testing
 type mockPortAllocator struct {
 	ports map[string]int
@@ -56,7 +72,7 @@ func TestNewService(t *testing.T) {
 	ports := &mockPortAllocator{}
 	network := &mockNetworkChecker{}
 
-	service := NewService(tmux, ports, network)
+	service := NewService(tmux, ports, network, defaultThresholds)
 
 	if service == nil {
 		t.Fatal("NewService returned nil")
@@ -99,7 +115,7 @@ func TestGetSystemStatus(t *testing.T) {
 			ports := &mockPortAllocator{}
 			network := &mockNetworkChecker{online: tt.online}
 
-			service := NewService(tmux, ports, network)
+			service := NewService(tmux, ports, network, defaultThresholds)
 			ctx := context.Background()
 
 			status := service.GetSystemStatus(ctx, tt.sessions)
@@ -145,7 +161,7 @@ func TestGetPortConflicts(t *testing.T) {
 			ports := &mockPortAllocator{}
 			network := &mockNetworkChecker{}
 
-			service := NewService(tmux, ports, network)
+			service := NewService(tmux, ports, network, defaultThresholds)
 			ctx := context.Background()
 
 			conflicts := service.GetPortConflicts(ctx, tt.sessions)
@@ -203,7 +219,7 @@ func TestGetSessionHealth(t *testing.T) {
 			ports := &mockPortAllocator{}
 			network := &mockNetworkChecker{}
 
-			service := NewService(tmux, ports, network)
+			service := NewService(tmux, ports, network, defaultThresholds)
 			ctx := context.Background()
 
 			health := service.GetSessionHealth(ctx, tt.sessions)
@@ -268,7 +284,7 @@ func TestGetWorktreeStatus(t *testing.T) {
 			ports := &mockPortAllocator{}
 			network := &mockNetworkChecker{}
 
-			service := NewService(tmux, ports, network)
+			service := NewService(tmux, ports, network, defaultThresholds)
 			ctx := context.Background()
 
 			worktrees := service.GetWorktreeStatus(ctx, tt.sessions)
@@ -333,7 +349,7 @@ func TestCollectDiagnostics(t *testing.T) {
 				lastCheck: now,
 			}
 
-			service := NewService(tmux, ports, network)
+			service := NewService(tmux, ports, network, defaultThresholds)
 			ctx := context.Background()
 
 			diag := service.CollectDiagnostics(ctx, tt.sessions, nil)
@@ -371,6 +387,146 @@ func TestCollectDiagnostics(t *testing.T) {
 	}
 }
 
+func TestCollectDiagnostics_BeadsPath(t *testing.T) {
+	service := NewService(&mockTmuxClient{}, &mockPortAllocator{}, &mockNetworkChecker{online: true}, defaultThresholds)
+	ctx := context.Background()
+
+	present := t.TempDir()
+	diag := service.CollectDiagnostics(ctx, map[string]*domain.Session{}, &present)
+	if diag.BeadsPath != present {
+		t.Errorf("CollectDiagnostics() BeadsPath = %q, want %q", diag.BeadsPath, present)
+	}
+	if len(diag.Warnings) != 0 {
+		t.Errorf("CollectDiagnostics() warnings = %v, want none", diag.Warnings)
+	}
+
+	missing := filepath.Join(present, "missing", ".beads")
+	diag = service.CollectDiagnostics(ctx, map[string]*domain.Session{}, &missing)
+	if len(diag.Warnings) != 1 || !strings.Contains(diag.Warnings[0], missing) {
+		t.Errorf("CollectDiagnostics() warnings = %v, want one about %s", diag.Warnings, missing)
+	}
+}
+
+func TestEvaluateHealth(t *testing.T) {
+	const mb = 1024 * 1024
+	calm := healthInputs{online: true, memoryUsage: 64 * mb, goroutines: 40}
+
+	tests := []struct {
+		name        string
+		inputs      func(in *healthInputs)
+		thresholds  func(th *config.DiagnosticsConfig)
+		want        HealthStatus
+		wantReasons []string
+	}{
+		{
+			name: "all within thresholds",
+			want: HealthHealthy,
+		},
+		{
+			name:        "offline is critical by default",
+			inputs:      func(in *healthInputs) { in.online = false },
+			want:        HealthCritical,
+			wantReasons: []string{"Network is offline"},
+		},
+		{
+			name:        "offline only degrades when configured",
+			inputs:      func(in *healthInputs) { in.online = false },
+			thresholds:  func(th *config.DiagnosticsConfig) { th.OfflineDegraded = true },
+			want:        HealthDegraded,
+			wantReasons: []string{"Network is offline"},
+		},
+		{
+			name:        "first port conflict degrades by default",
+			inputs:      func(in *healthInputs) { in.portConflicts = 1 },
+			want:        HealthDegraded,
+			wantReasons: []string{"1 port conflict, more than the 0 allowed"},
+		},
+		{
+			name:       "port conflicts within the allowance",
+			inputs:     func(in *healthInputs) { in.portConflicts = 2 },
+			thresholds: func(th *config.DiagnosticsConfig) { th.MaxPortConflicts = 2 },
+			want:       HealthHealthy,
+		},
+		{
+			name:        "port conflicts past the allowance",
+			inputs:      func(in *healthInputs) { in.portConflicts = 3 },
+			thresholds:  func(th *config.DiagnosticsConfig) { th.MaxPortConflicts = 2 },
+			want:        HealthDegraded,
+			wantReasons: []string{"3 port conflicts, more than the 2 allowed"},
+		},
+		{
+			name:        "memory over the ceiling",
+			inputs:      func(in *healthInputs) { in.memoryUsage = 300 * mb },
+			thresholds:  func(th *config.DiagnosticsConfig) { th.MaxMemoryMB = 256 },
+			want:        HealthDegraded,
+			wantReasons: []string{"Memory use 300.00 MB is over the 256 MB ceiling"},
+		},
+		{
+			name:        "goroutines over the ceiling",
+			inputs:      func(in *healthInputs) { in.goroutines = 150 },
+			thresholds:  func(th *config.DiagnosticsConfig) { th.MaxGoroutines = 100 },
+			want:        HealthDegraded,
+			wantReasons: []string{"150 goroutines is over the ceiling of 100"},
+		},
+		{
+			name:        "other warnings degrade",
+			inputs:      func(in *healthInputs) { in.otherWarnings = 2 },
+			want:        HealthDegraded,
+			wantReasons: []string{"2 warnings"},
+		},
+		{
+			name: "critical reasons come first",
+			inputs: func(in *healthInputs) {
+				in.portConflicts = 1
+				in.online = false
+			},
+			want:        HealthCritical,
+			wantReasons: []string{"Network is offline", "1 port conflict, more than the 0 allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := calm
+			if tt.inputs != nil {
+				tt.inputs(&in)
+			}
+			thresholds := defaultThresholds
+			if tt.thresholds != nil {
+				tt.thresholds(&thresholds)
+			}
+
+			state, reasons := evaluateHealth(in, thresholds)
+			if state != tt.want {
+				t.Errorf("evaluateHealth() state = %v, want %v", state, tt.want)
+			}
+			if strings.Join(reasons, "|") != strings.Join(tt.wantReasons, "|") {
+				t.Errorf("evaluateHealth() reasons = %q, want %q", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestCollectDiagnostics_OfflineDegraded(t *testing.T) {
+	thresholds := defaultThresholds
+	thresholds.OfflineDegraded = true
+	service := NewService(&mockTmuxClient{}, &mockPortAllocator{}, &mockNetworkChecker{online: false}, thresholds)
+
+	diag := service.CollectDiagnostics(context.Background(), map[string]*domain.Session{}, nil)
+	if diag.OverallState != HealthDegraded {
+		t.Errorf("OverallState = %v, want %v", diag.OverallState, HealthDegraded)
+	}
+	if diag.Network.HealthState != HealthDegraded {
+		t.Errorf("Network.HealthState = %v, want %v", diag.Network.HealthState, HealthDegraded)
+	}
+	if len(diag.Errors) != 0 || len(diag.Warnings) != 1 {
+		t.Errorf("expected offline as the only warning, got errors %v, warnings %v", diag.Errors, diag.Warnings)
+	}
+	if len(diag.Reasons) != 1 || diag.Reasons[0] != "Network is offline" {
+		t.Errorf("Reasons = %q, want the network", diag.Reasons)
+	}
+}
+
 func TestFormatDiagnostics(t *testing.T) {
 	now := time.Now()
 
@@ -399,7 +555,7 @@ func TestFormatDiagnostics(t *testing.T) {
 	ports := &mockPortAllocator{}
 	network := &mockNetworkChecker{}
 
-	service := NewService(tmux, ports, network)
+	service := NewService(tmux, ports, network, defaultThresholds)
 
 	output := service.FormatDiagnostics(diag)
 
@@ -482,3 +638,28 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRequiresNetwork(t *testing.T) {
+	for _, feature := range NetworkFeatures {
+		if !RequiresNetwork(feature.Name) {
+			t.Errorf("RequiresNetwork(%q) = false, want true", feature.Name)
+		}
+	}
+	if RequiresNetwork("Local diff") {
+		t.Error("RequiresNetwork() for an unlisted feature = true, want false")
+	}
+}
+
+func TestNetworkInfo_LatencyStats(t *testing.T) {
+	ms := time.Millisecond
+	info := NetworkInfo{History: []time.Duration{30 * ms, -1, 10 * ms, 50 * ms}}
+	lo, avg, hi, ok := info.LatencyStats()
+	if !ok || lo != 10*ms || avg != 30*ms || hi != 50*ms {
+		t.Errorf("LatencyStats() = %v, %v, %v, %v, want 10ms, 30ms, 50ms, true", lo, avg, hi, ok)
+	}
+
+	// Failed checks alone give no stats
+	if _, _, _, ok := (NetworkInfo{History: []time.Duration{-1, -1}}).LatencyStats(); ok {
+		t.Error("LatencyStats() ok = true with only failed checks")
+	}
+}
@@ -127,6 +127,11 @@ func (s *Service) SetSearchQuery(query string) {
 	s.filter.SearchQuery = query
 }
 
+// SetSearchScope sets which fields the search query matches
+func (s *Service) SetSearchScope(scope domain.SearchScope) {
+	s.filter.SearchScope = scope
+}
+
 // ClearSearch clears the search query
 func (s *Service) ClearSearch() {
 	s.filter.SearchQuery = ""
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 )
 
@@ -47,7 +48,7 @@ func NewClient(runner CommandRunner, logger *slog.Logger) *Client {
 func (c *Client) Status(ctx context.Context, worktree string) (*GitStatus, error) {
 	c.logger.Debug("getting git status", "worktree", worktree)
 
-	output, err := c.runner.Run(ctx, "status", "--porcelain")
+	output, err := c.runner.Run(ctx, "-C", worktree, "status", "--porcelain")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git status: %w", err)
 	}
@@ -148,6 +149,33 @@ func (c *Client) DiffStat(ctx context.Context, worktree string) (string, error)
 	return output, nil
 }
 
+// BranchNumstat returns the per-file line counts ('git diff --numstat') of
+// the worktree, uncommitted work included, against where its branch forked
+// from base.
+func (c *Client) BranchNumstat(ctx context.Context, worktree, base string) (string, error) {
+	c.logger.Debug("getting branch numstat", "worktree", worktree, "base", base)
+
+	output, err := c.runner.Run(ctx, "-C", worktree, "diff", "--numstat", "--merge-base", base)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff stat: %w", err)
+	}
+
+	return output, nil
+}
+
+// Log returns the commits in revRange of the worktree, one "<hash> <subject>"
+// per line, newest first.
+func (c *Client) Log(ctx context.Context, worktree, revRange string) (string, error) {
+	c.logger.Debug("getting log", "worktree", worktree, "range", revRange)
+
+	output, err := c.runner.Run(ctx, "-C", worktree, "log", "--format=%h %s", revRange)
+	if err != nil {
+		return "", fmt.Errorf("failed to get log: %w", err)
+	}
+
+	return output, nil
+}
+
 // Push pushes the specified branch to the remote repository.
 func (c *Client) Push(ctx context.Context, worktree, remote, branch string) error {
 	c.logger.Info("pushing branch", "worktree", worktree, "remote", remote, "branch", branch)
@@ -176,6 +204,38 @@ func (c *Client) CurrentBranch(ctx context.Context, worktree string) (string, er
 	return branch, nil
 }
 
+// DefaultBranch returns the remote's default branch by resolving origin/HEAD.
+// It parses the output of 'git symbolic-ref refs/remotes/origin/HEAD', e.g.
+// "refs/remotes/origin/main" yields "main".
+func (c *Client) DefaultBranch(ctx context.Context) (string, error) {
+	c.logger.Debug("getting default branch")
+
+	output, err := c.runner.Run(ctx, "symbolic-ref", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	branch, err := parseDefaultBranch(output)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug("default branch", "branch", branch)
+	return branch, nil
+}
+
+// RemoteURL returns the URL of the named remote.
+func (c *Client) RemoteURL(ctx context.Context, remote string) (string, error) {
+	c.logger.Debug("getting remote URL", "remote", remote)
+
+	output, err := c.runner.Run(ctx, "remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %s: %w", remote, err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
 // Checkout checks out the specified branch.
 func (c *Client) Checkout(ctx context.Context, worktree, branch string) error {
 	c.logger.Info("checking out branch", "worktree", worktree, "branch", branch)
@@ -208,6 +268,20 @@ func (c *Client) RevListCount(ctx context.Context, worktree, revRange string) (i
 	return count, nil
 }
 
+// AheadBehind returns how many commits the worktree's branch has that base
+// doesn't (ahead), and how many base has that the branch doesn't (behind).
+// It parses the output of 'git rev-list --left-right --count HEAD...base'.
+func (c *Client) AheadBehind(ctx context.Context, worktree, base string) (ahead, behind int, err error) {
+	c.logger.Debug("getting ahead/behind counts", "worktree", worktree, "base", base)
+
+	output, err := c.runner.Run(ctx, "-C", worktree, "rev-list", "--left-right", "--count", "HEAD..."+base)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare with %s: %w", base, err)
+	}
+
+	return parseAheadBehind(output)
+}
+
 // Pull pulls updates from the remote repository.
 // This is used for updating the local base branch when origin mode is enabled.
 func (c *Client) Pull(ctx context.Context, worktree, remote, branch string) error {
@@ -236,6 +310,53 @@ func (c *Client) FetchRef(ctx context.Context, worktree, remote, refSpec string)
 	return nil
 }
 
+// autoStashMessage labels stashes made by Stash so they can be told apart
+// from the user's own in 'git stash list'.
+const autoStashMessage = "azedarach auto-stash"
+
+// Stash stashes the worktree's uncommitted changes, including untracked
+// files, leaving a clean working tree.
+func (c *Client) Stash(ctx context.Context, worktree string) error {
+	c.logger.Info("stashing changes", "worktree", worktree)
+
+	_, err := c.runner.Run(ctx, "-C", worktree, "stash", "push", "--include-untracked", "-m", autoStashMessage)
+	if err != nil {
+		return fmt.Errorf("failed to stash changes: %w", err)
+	}
+
+	c.logger.Info("changes stashed successfully")
+	return nil
+}
+
+// StashPop restores the worktree's most recently stashed changes.
+// Like Merge, conflicts are reported in the result rather than as an error;
+// git keeps the stash entry when the pop conflicts.
+func (c *Client) StashPop(ctx context.Context, worktree string) (*MergeResult, error) {
+	c.logger.Info("popping stash", "worktree", worktree)
+
+	output, err := c.runner.Run(ctx, "-C", worktree, "stash", "pop")
+	if err == nil {
+		c.logger.Info("stash popped successfully")
+		return &MergeResult{Success: true, Message: output}, nil
+	}
+
+	// Conflict details go to stdout, which a failed run may not return, so
+	// ask git for the unmerged paths instead
+	unmerged, diffErr := c.runner.Run(ctx, "-C", worktree, "diff", "--name-only", "--diff-filter=U")
+	if diffErr != nil || strings.TrimSpace(unmerged) == "" {
+		c.logger.Error("stash pop failed", "error", err)
+		return nil, fmt.Errorf("failed to pop stash: %w", err)
+	}
+
+	files := strings.Split(strings.TrimSpace(unmerged), "\n")
+	c.logger.Warn("stash pop has conflicts", "conflicts", files)
+	return &MergeResult{
+		HasConflicts:  true,
+		ConflictFiles: files,
+		Message:       output,
+	}, nil
+}
+
 // parseGitStatus parses the output of 'git status --porcelain'.
 // The format is: XY PATH
 // Where X is the status of the index and Y is the status of the working tree.
@@ -298,6 +419,37 @@ func parseGitStatus(output string) *GitStatus {
 	return status
 }
 
+// parseDefaultBranch extracts the branch name from symbolic-ref output.
+// Accepts both the full form "refs/remotes/origin/main" and the short
+// form "origin/main". Branch names containing slashes are preserved.
+func parseDefaultBranch(output string) (string, error) {
+	ref := strings.TrimSpace(output)
+
+	for _, prefix := range []string{"refs/remotes/origin/", "origin/"} {
+		if branch, ok := strings.CutPrefix(ref, prefix); ok && branch != "" {
+			return branch, nil
+		}
+	}
+
+	return "", fmt.Errorf("unexpected symbolic-ref output: %q", ref)
+}
+
+// parseAheadBehind parses the two counts printed by 'git rev-list
+// --left-right --count', e.g. "3\t1"
+func parseAheadBehind(output string) (ahead, behind int, err error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+	if ahead, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+	if behind, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+	return ahead, behind, nil
+}
+
 // parseConflicts extracts conflict file paths from git merge output.
 // Handles multiple conflict formats:
 //   - "CONFLICT (content): Merge conflict in <file>"
@@ -102,7 +102,7 @@ A  staged-added.txt
 		t.Run(tt.name, func(t *testing.T) {
 			runner := &mockRunner{
 				runFunc: func(ctx context.Context, args ...string) (string, error) {
-					if len(args) >= 2 && args[0] == "status" && args[1] == "--porcelain" {
+					if strings.Join(args, " ") == "-C /fake/worktree status --porcelain" {
 						return tt.gitOutput, nil
 					}
 					return "", fmt.Errorf("unexpected command: %v", args)
@@ -477,3 +477,233 @@ func TestMergeError(t *testing.T) {
 		t.Errorf("Error message should mention merge failure, got: %v", err)
 	}
 }
+
+func TestDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		gitOutput      string
+		gitErr         error
+		expectedBranch string
+		wantErr        bool
+	}{
+		{
+			name:           "main branch",
+			gitOutput:      "refs/remotes/origin/main",
+			expectedBranch: "main",
+		},
+		{
+			name:           "master branch with trailing newline",
+			gitOutput:      "refs/remotes/origin/master\n",
+			expectedBranch: "master",
+		},
+		{
+			name:           "branch containing slash",
+			gitOutput:      "refs/remotes/origin/release/v2",
+			expectedBranch: "release/v2",
+		},
+		{
+			name:           "short form",
+			gitOutput:      "origin/develop",
+			expectedBranch: "develop",
+		},
+		{
+			name:      "unexpected output",
+			gitOutput: "HEAD",
+			wantErr:   true,
+		},
+		{
+			name:    "origin HEAD not set",
+			gitErr:  fmt.Errorf("fatal: ref refs/remotes/origin/HEAD is not a symbolic ref"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				runFunc: func(ctx context.Context, args ...string) (string, error) {
+					if len(args) == 2 && args[0] == "symbolic-ref" && args[1] == "refs/remotes/origin/HEAD" {
+						return tt.gitOutput, tt.gitErr
+					}
+					return "", fmt.Errorf("unexpected command: %v", args)
+				},
+			}
+
+			client := NewClient(runner, slog.Default())
+			branch, err := client.DefaultBranch(context.Background())
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DefaultBranch() expected error, got %q", branch)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("DefaultBranch() error = %v", err)
+			}
+
+			if branch != tt.expectedBranch {
+				t.Errorf("DefaultBranch() = %v, want %v", branch, tt.expectedBranch)
+			}
+		})
+	}
+}
+
+func TestStash(t *testing.T) {
+	var got []string
+	runner := &mockRunner{
+		runFunc: func(ctx context.Context, args ...string) (string, error) {
+			got = args
+			return "Saved working directory and index state On az-1: azedarach auto-stash", nil
+		},
+	}
+
+	client := NewClient(runner, slog.Default())
+	if err := client.Stash(context.Background(), "/fake/worktree"); err != nil {
+		t.Fatalf("Stash() error = %v", err)
+	}
+
+	want := "-C /fake/worktree stash push --include-untracked -m azedarach auto-stash"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Stash() ran %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestStashPop(t *testing.T) {
+	tests := []struct {
+		name          string
+		popErr        error
+		unmerged      string
+		wantErr       bool
+		wantConflicts []string
+	}{
+		{
+			name: "clean pop",
+		},
+		{
+			name:          "pop with conflicts",
+			popErr:        fmt.Errorf("exit status 1: The stash entry is kept in case you need it again."),
+			unmerged:      "file1.txt\nfile2.txt",
+			wantConflicts: []string{"file1.txt", "file2.txt"},
+		},
+		{
+			name:    "pop fails without conflicts",
+			popErr:  fmt.Errorf("exit status 1: No stash entries found."),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				runFunc: func(ctx context.Context, args ...string) (string, error) {
+					switch strings.Join(args, " ") {
+					case "-C /fake/worktree stash pop":
+						return "", tt.popErr
+					case "-C /fake/worktree diff --name-only --diff-filter=U":
+						return tt.unmerged, nil
+					}
+					return "", fmt.Errorf("unexpected command: %v", args)
+				},
+			}
+
+			client := NewClient(runner, slog.Default())
+			result, err := client.StashPop(context.Background(), "/fake/worktree")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("StashPop() should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StashPop() error = %v", err)
+			}
+			if result.HasConflicts != (len(tt.wantConflicts) > 0) {
+				t.Errorf("HasConflicts = %v, want %v", result.HasConflicts, len(tt.wantConflicts) > 0)
+			}
+			compareStringSlices(t, "ConflictFiles", result.ConflictFiles, tt.wantConflicts)
+		})
+	}
+}
+
+func TestRemoteURL(t *testing.T) {
+	runner := &mockRunner{
+		runFunc: func(ctx context.Context, args ...string) (string, error) {
+			if strings.Join(args, " ") == "remote get-url origin" {
+				return "git@gitlab.com:org/repo.git\n", nil
+			}
+			return "", fmt.Errorf("unexpected command: %v", args)
+		},
+	}
+
+	client := NewClient(runner, slog.Default())
+	url, err := client.RemoteURL(context.Background(), "origin")
+	if err != nil {
+		t.Fatalf("RemoteURL() error = %v", err)
+	}
+	if url != "git@gitlab.com:org/repo.git" {
+		t.Errorf("RemoteURL() = %q, want %q", url, "git@gitlab.com:org/repo.git")
+	}
+
+	if _, err := client.RemoteURL(context.Background(), "upstream"); err == nil {
+		t.Error("RemoteURL() should fail for a missing remote")
+	}
+}
+
+func TestParseAheadBehind(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantAhead  int
+		wantBehind int
+		wantErr    bool
+	}{
+		{name: "ahead and behind", output: "3\t1\n", wantAhead: 3, wantBehind: 1},
+		{name: "even", output: "0\t0", wantAhead: 0, wantBehind: 0},
+		{name: "only behind", output: "0\t12\n", wantAhead: 0, wantBehind: 12},
+		{name: "spaces", output: "  5   2  ", wantAhead: 5, wantBehind: 2},
+		{name: "empty", output: "", wantErr: true},
+		{name: "one count", output: "4\n", wantErr: true},
+		{name: "not a number", output: "fatal\tbad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ahead, behind, err := parseAheadBehind(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseAheadBehind(%q) expected error, got %d %d", tt.output, ahead, behind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAheadBehind(%q) error = %v", tt.output, err)
+			}
+			if ahead != tt.wantAhead || behind != tt.wantBehind {
+				t.Errorf("parseAheadBehind(%q) = %d, %d, want %d, %d", tt.output, ahead, behind, tt.wantAhead, tt.wantBehind)
+			}
+		})
+	}
+}
+
+func TestAheadBehind(t *testing.T) {
+	runner := &mockRunner{
+		runFunc: func(ctx context.Context, args ...string) (string, error) {
+			if strings.Join(args, " ") == "-C /wt/az-1 rev-list --left-right --count HEAD...main" {
+				return "2\t7\n", nil
+			}
+			return "", fmt.Errorf("unexpected command: %v", args)
+		},
+	}
+
+	client := NewClient(runner, slog.Default())
+	ahead, behind, err := client.AheadBehind(context.Background(), "/wt/az-1", "main")
+	if err != nil {
+		t.Fatalf("AheadBehind() error = %v", err)
+	}
+	if ahead != 2 || behind != 7 {
+		t.Errorf("AheadBehind() = %d, %d, want 2, 7", ahead, behind)
+	}
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FindStale returns the bead worktrees that are safe to clean up: their
+// bead isn't in open, their last commit is older than keepFor, and they
+// have no uncommitted changes. A worktree that can't be checked is kept.
+func (w *WorktreeManager) FindStale(ctx context.Context, open map[string]bool, keepFor time.Duration, now time.Time) ([]Worktree, error) {
+	worktrees, err := w.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []Worktree
+	for _, wt := range worktrees {
+		if open[wt.BeadID] {
+			continue
+		}
+
+		last, err := w.lastCommitTime(ctx, wt.Path)
+		if err != nil {
+			w.logger.Warn("skipping worktree, can't read last commit", "path", wt.Path, "error", err)
+			continue
+		}
+		if now.Sub(last) < keepFor {
+			continue
+		}
+
+		dirty, err := w.hasChanges(ctx, wt.Path)
+		if err != nil {
+			w.logger.Warn("skipping worktree, can't read status", "path", wt.Path, "error", err)
+			continue
+		}
+		if dirty {
+			w.logger.Info("keeping stale worktree with uncommitted changes", "path", wt.Path)
+			continue
+		}
+
+		stale = append(stale, wt)
+	}
+
+	return stale, nil
+}
+
+// lastCommitTime returns when the commit checked out in the worktree at
+// path was made
+func (w *WorktreeManager) lastCommitTime(ctx context.Context, path string) (time.Time, error) {
+	// git -C <path> log -1 --format=%ct
+	output, err := w.runner.Run(ctx, "-C", path, "log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected commit time %q: %w", output, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// hasChanges reports whether the worktree at path has uncommitted changes,
+// including untracked files
+func (w *WorktreeManager) hasChanges(ctx context.Context, path string) (bool, error) {
+	// git -C <path> status --porcelain
+	output, err := w.runner.Run(ctx, "-C", path, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorktreeManager_FindStale(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-10 * 24 * time.Hour)
+	recent := now.Add(-2 * 24 * time.Hour)
+
+	list := strings.Join([]string{
+		"worktree /repo",
+		"branch refs/heads/main",
+		"",
+		"worktree /repo-az-open",
+		"branch refs/heads/az/az-open",
+		"",
+		"worktree /repo-az-old",
+		"branch refs/heads/az/az-old",
+		"",
+		"worktree /repo-az-recent",
+		"branch refs/heads/az/az-recent",
+		"",
+		"worktree /repo-az-dirty",
+		"branch refs/heads/az/az-dirty",
+		"",
+		"worktree /repo-az-gone",
+		"branch refs/heads/az/az-gone",
+	}, "\n")
+
+	commits := map[string]time.Time{
+		"/repo-az-open":   old,
+		"/repo-az-old":    old,
+		"/repo-az-recent": recent,
+		"/repo-az-dirty":  old,
+	}
+
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		switch {
+		case strings.Join(args, " ") == "worktree list --porcelain":
+			return list, nil
+		case args[0] == "-C" && args[2] == "log":
+			commit, ok := commits[args[1]]
+			if !ok {
+				// The worktree's directory was deleted by hand
+				return "", errors.New("cannot change to directory")
+			}
+			return fmt.Sprint(commit.Unix()), nil
+		case args[0] == "-C" && args[2] == "status":
+			if args[1] == "/repo-az-dirty" {
+				return " M main.go", nil
+			}
+			return "", nil
+		}
+		return "", nil
+	}
+
+	manager := NewWorktreeManager(mock, "/repo", slog.Default())
+	stale, err := manager.FindStale(context.Background(), map[string]bool{"az-open": true}, 7*24*time.Hour, now)
+
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "az-old", stale[0].BeadID)
+	assert.Equal(t, "/repo-az-old", stale[0].Path)
+
+	// Open beads aren't even looked at
+	for _, cmd := range mock.commands {
+		assert.NotContains(t, cmd, "/repo-az-open")
+	}
+}
+
+func TestWorktreeManager_FindStale_ListError(t *testing.T) {
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		return "", errors.New("not a git repository")
+	}
+
+	manager := NewWorktreeManager(mock, "/repo", slog.Default())
+	_, err := manager.FindStale(context.Background(), nil, time.Hour, time.Now())
+
+	assert.Error(t, err)
+}
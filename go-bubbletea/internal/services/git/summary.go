@@ -0,0 +1,72 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Commit is one commit from a branch's log
+type Commit struct {
+	Hash    string
+	Subject string
+}
+
+// FileStat is one file's line of 'git diff --numstat'. Changes is the
+// number of changed lines, or 0 for a binary file.
+type FileStat struct {
+	Path    string
+	Changes int
+	Binary  bool
+}
+
+// ChangeSummary is a compact account of a branch's work: the commits it has
+// on top of its base and the size of its changes
+type ChangeSummary struct {
+	Commits    []Commit
+	Files      []FileStat
+	Insertions int
+	Deletions  int
+}
+
+// FilesChanged returns the number of files the branch changes
+func (s ChangeSummary) FilesChanged() int {
+	return len(s.Files)
+}
+
+// ParseChangeSummary builds a summary from the output of
+// 'git diff --numstat' and of 'git log --format="%h %s"'. Numstat gives
+// each path in full, where --stat abbreviates long ones.
+func ParseChangeSummary(numstat, log string) ChangeSummary {
+	var summary ChangeSummary
+
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, " ")
+		summary.Commits = append(summary.Commits, Commit{Hash: hash, Subject: subject})
+	}
+
+	for _, line := range strings.Split(numstat, "\n") {
+		// "<added>\t<deleted>\t<path>", with "-" counts for a binary file
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		file := FileStat{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			file.Binary = true
+		} else {
+			added, _ := strconv.Atoi(fields[0])
+			deleted, _ := strconv.Atoi(fields[1])
+			file.Changes = added + deleted
+			summary.Insertions += added
+			summary.Deletions += deleted
+		}
+		summary.Files = append(summary.Files, file)
+	}
+
+	return summary
+}
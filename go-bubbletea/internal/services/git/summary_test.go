@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChangeSummary(t *testing.T) {
+	numstat := "35\t7\tinternal/app/model.go\n" +
+		"3\t4\tinternal/ui/board/card.go\n" +
+		"-\t-\tdocs/screenshot.png\n" +
+		"1\t0\tinternal/services/some/deeply/nested/package/with/a/very/long/path/that/stat/would/abbreviate.go\n"
+	log := "a1b2c3d Show branch counts on cards\n9f8e7d6 Add AheadBehind to the git client\n"
+
+	summary := ParseChangeSummary(numstat, log)
+
+	assert.Equal(t, []Commit{
+		{Hash: "a1b2c3d", Subject: "Show branch counts on cards"},
+		{Hash: "9f8e7d6", Subject: "Add AheadBehind to the git client"},
+	}, summary.Commits)
+	assert.Equal(t, []FileStat{
+		{Path: "internal/app/model.go", Changes: 42},
+		{Path: "internal/ui/board/card.go", Changes: 7},
+		{Path: "docs/screenshot.png", Binary: true},
+		{Path: "internal/services/some/deeply/nested/package/with/a/very/long/path/that/stat/would/abbreviate.go", Changes: 1},
+	}, summary.Files)
+	assert.Equal(t, 4, summary.FilesChanged())
+	assert.Equal(t, 39, summary.Insertions)
+	assert.Equal(t, 11, summary.Deletions)
+}
+
+func TestParseChangeSummary_Partial(t *testing.T) {
+	tests := []struct {
+		name           string
+		numstat        string
+		log            string
+		wantCommits    int
+		wantFiles      int
+		wantInsertions int
+		wantDeletions  int
+	}{
+		{name: "no changes", numstat: "", log: ""},
+		{
+			name:           "only insertions",
+			numstat:        "1\t0\tREADME.md\n",
+			wantFiles:      1,
+			wantInsertions: 1,
+		},
+		{
+			name:          "only deletions",
+			numstat:       "0\t5\told.go\n",
+			wantFiles:     1,
+			wantDeletions: 5,
+		},
+		{
+			name:        "commits without uncommitted changes",
+			log:         "abc1234 Fix the thing\n",
+			wantCommits: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := ParseChangeSummary(tt.numstat, tt.log)
+			assert.Len(t, summary.Commits, tt.wantCommits)
+			assert.Equal(t, tt.wantFiles, summary.FilesChanged())
+			assert.Equal(t, tt.wantInsertions, summary.Insertions)
+			assert.Equal(t, tt.wantDeletions, summary.Deletions)
+		})
+	}
+}
+
+func TestBranchNumstatAndLog(t *testing.T) {
+	runner := &mockRunner{
+		runFunc: func(ctx context.Context, args ...string) (string, error) {
+			switch strings.Join(args, " ") {
+			case "-C /wt/az-1 diff --numstat --merge-base main":
+				return "1\t1\ta.go\n", nil
+			case "-C /wt/az-1 log --format=%h %s main..HEAD":
+				return "abc1234 Change a\n", nil
+			}
+			return "", fmt.Errorf("unexpected command: %v", args)
+		},
+	}
+	client := NewClient(runner, slog.Default())
+
+	stat, err := client.BranchNumstat(context.Background(), "/wt/az-1", "main")
+	require.NoError(t, err)
+	log, err := client.Log(context.Background(), "/wt/az-1", "main..HEAD")
+	require.NoError(t, err)
+
+	summary := ParseChangeSummary(stat, log)
+	assert.Len(t, summary.Commits, 1)
+	assert.Equal(t, 1, summary.FilesChanged())
+}
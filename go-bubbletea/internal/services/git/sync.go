@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,6 +23,10 @@ type GitSyncService struct {
 	isFetching    bool
 	lastNotified  int
 	isLocked      bool
+
+	// Detected default branch per project path
+	baseBranchMu sync.Mutex
+	baseBranches map[string]string
 }
 
 type GitSyncMsg struct {
@@ -37,6 +42,7 @@ func NewGitSyncService(gitClient *Client, networkChecker *network.StatusChecker,
 		config:         cfg,
 		projectPath:    projectPath,
 		logger:         logger,
+		baseBranches:   make(map[string]string),
 	}
 }
 
@@ -60,7 +66,7 @@ func (s *GitSyncService) FetchAndCheck() tea.Cmd {
 		defer cancel()
 
 		remote := "origin"
-		baseBranch := s.config.Git.BaseBranch
+		baseBranch := s.BaseBranch(ctx)
 
 		err := s.gitClient.Fetch(ctx, s.projectPath, remote)
 		if err != nil {
@@ -88,7 +94,7 @@ func (s *GitSyncService) Pull() tea.Cmd {
 		defer cancel()
 
 		remote := "origin"
-		baseBranch := s.config.Git.BaseBranch
+		baseBranch := s.BaseBranch(ctx)
 
 		currentBranch, err := s.gitClient.CurrentBranch(ctx, s.projectPath)
 		if err != nil {
@@ -115,6 +121,62 @@ func (s *GitSyncService) Pull() tea.Cmd {
 	}
 }
 
+// SetBaseBranch pins the project's base branch, as configured for it,
+// instead of detecting it. An empty branch goes back to detecting.
+func (s *GitSyncService) SetBaseBranch(branch string) {
+	s.baseBranchMu.Lock()
+	defer s.baseBranchMu.Unlock()
+
+	if branch == "" {
+		delete(s.baseBranches, s.projectPath)
+		return
+	}
+	s.baseBranches[s.projectPath] = branch
+}
+
+// BaseBranch returns the base branch for the project: the one pinned with
+// SetBaseBranch, otherwise the remote's default branch, detected and cached
+// per project. If detection fails it falls back to config.Git.BaseBranch,
+// then "main", and detects again next time.
+func (s *GitSyncService) BaseBranch(ctx context.Context) string {
+	s.baseBranchMu.Lock()
+	defer s.baseBranchMu.Unlock()
+
+	if branch, ok := s.baseBranches[s.projectPath]; ok {
+		return branch
+	}
+
+	branch, err := s.gitClient.DefaultBranch(ctx)
+	if err != nil || branch == "" {
+		s.logger.Debug("default branch detection failed, using config", "project", s.projectPath, "error", err)
+		return s.fallbackBaseBranch()
+	}
+
+	s.baseBranches[s.projectPath] = branch
+	return branch
+}
+
+// KnownBaseBranch returns the base branch BaseBranch has pinned or detected
+// for the project, or the fallback if it hasn't yet. It never runs git, so
+// it is safe to call from Update.
+func (s *GitSyncService) KnownBaseBranch() string {
+	s.baseBranchMu.Lock()
+	defer s.baseBranchMu.Unlock()
+
+	if branch, ok := s.baseBranches[s.projectPath]; ok {
+		return branch
+	}
+	return s.fallbackBaseBranch()
+}
+
+// fallbackBaseBranch returns config.Git.BaseBranch, or "main" if unset
+func (s *GitSyncService) fallbackBaseBranch() string {
+	if s.config.Git.BaseBranch != "" {
+		return s.config.Git.BaseBranch
+	}
+	return "main"
+}
+
 func (s *GitSyncService) ShouldNotify(count int) bool {
 	if s.config.Git.WorkflowMode != "origin" {
 		return false
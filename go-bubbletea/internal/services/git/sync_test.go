@@ -0,0 +1,103 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+)
+
+func TestGitSyncService_BaseBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		symbolicRef    string
+		symbolicRefErr error
+		configBranch   string
+		expectedBranch string
+		expectedCalls  int
+	}{
+		{
+			name:           "detected from origin HEAD",
+			symbolicRef:    "refs/remotes/origin/trunk",
+			configBranch:   "main",
+			expectedBranch: "trunk",
+			expectedCalls:  1,
+		},
+		{
+			name:           "falls back to config",
+			symbolicRefErr: fmt.Errorf("not a symbolic ref"),
+			configBranch:   "develop",
+			expectedBranch: "develop",
+			expectedCalls:  2,
+		},
+		{
+			name:           "falls back to main",
+			symbolicRefErr: fmt.Errorf("not a symbolic ref"),
+			expectedBranch: "main",
+			expectedCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			runner := &mockRunner{
+				runFunc: func(ctx context.Context, args ...string) (string, error) {
+					calls++
+					return tt.symbolicRef, tt.symbolicRefErr
+				},
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.Git.BaseBranch = tt.configBranch
+
+			svc := NewGitSyncService(NewClient(runner, slog.Default()), nil, cfg, "/fake/project", slog.Default())
+
+			if got := svc.BaseBranch(context.Background()); got != tt.expectedBranch {
+				t.Errorf("BaseBranch() = %v, want %v", got, tt.expectedBranch)
+			}
+
+			// Only a detected branch is cached; a failure detects again
+			if got := svc.BaseBranch(context.Background()); got != tt.expectedBranch {
+				t.Errorf("second BaseBranch() = %v, want %v", got, tt.expectedBranch)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d git calls, got %d", tt.expectedCalls, calls)
+			}
+			if got := svc.KnownBaseBranch(); got != tt.expectedBranch {
+				t.Errorf("KnownBaseBranch() = %v, want %v", got, tt.expectedBranch)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected KnownBaseBranch not to run git, got %d calls", calls)
+			}
+		})
+	}
+}
+
+func TestGitSyncService_SetBaseBranch(t *testing.T) {
+	calls := 0
+	runner := &mockRunner{
+		runFunc: func(ctx context.Context, args ...string) (string, error) {
+			calls++
+			return "refs/remotes/origin/trunk", nil
+		},
+	}
+	svc := NewGitSyncService(NewClient(runner, slog.Default()), nil, config.DefaultConfig(), "/fake/project", slog.Default())
+
+	// A pinned branch skips detection
+	svc.SetBaseBranch("release")
+	if got := svc.BaseBranch(context.Background()); got != "release" {
+		t.Errorf("BaseBranch() = %v, want release", got)
+	}
+	if calls != 0 {
+		t.Errorf("expected no git calls, got %d", calls)
+	}
+
+	// Unpinning goes back to detection
+	svc.SetBaseBranch("")
+	if got := svc.BaseBranch(context.Background()); got != "trunk" {
+		t.Errorf("BaseBranch() = %v, want trunk", got)
+	}
+}
@@ -2,8 +2,11 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 )
@@ -13,6 +16,12 @@ type WorktreeManager struct {
 	runner  CommandRunner
 	logger  *slog.Logger
 	repoDir string // Main repository directory (absolute path)
+
+	fullExistsCheck bool // Always list worktrees instead of trying the fast path
+
+	// Where worktrees are created; see SetLayout
+	basePath   string
+	nameFormat string
 }
 
 // Worktree represents a git worktree associated with a bead.
@@ -34,17 +43,69 @@ func NewWorktreeManager(runner CommandRunner, repoDir string, logger *slog.Logge
 	}
 }
 
-// Create creates a new worktree for the given bead ID.
-// It creates the worktree at ../RepoName-beadID/ with branch az/beadID.
-func (w *WorktreeManager) Create(ctx context.Context, beadID string, baseBranch string) (*Worktree, error) {
-	// Get repository name from repoDir
-	repoName := filepath.Base(w.repoDir)
+// SetFullExistsCheck makes Create always list every worktree to check
+// whether one exists, instead of first checking the bead's branch and path.
+func (w *WorktreeManager) SetFullExistsCheck(full bool) {
+	w.fullExistsCheck = full
+}
+
+// Worktree layout used when none is configured: ../RepoName-beadID/
+const (
+	defaultWorktreeBasePath   = "../"
+	defaultWorktreeNameFormat = "{project}-{beadID}"
+)
+
+// SetLayout sets where Create puts worktrees. basePath is the directory
+// they're created in, relative to the repository unless absolute; a leading
+// "~/" is the home directory. nameFormat names each worktree, with
+// {project} replaced by the repository's directory name, {beadID} by the
+// bead and {branch} by its branch, with slashes turned into dashes. Empty
+// values keep the default, ../RepoName-beadID/.
+func (w *WorktreeManager) SetLayout(basePath, nameFormat string) {
+	w.basePath = basePath
+	w.nameFormat = nameFormat
+}
+
+// worktreePath returns where the worktree for beadID is created, by
+// default ../RepoName-beadID/
+func (w *WorktreeManager) worktreePath(beadID string) string {
+	basePath := w.basePath
+	if basePath == "" {
+		basePath = defaultWorktreeBasePath
+	}
+	if strings.HasPrefix(basePath, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			basePath = filepath.Join(home, basePath[2:])
+		}
+	}
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(w.repoDir, basePath)
+	}
 
-	// Calculate worktree path: ../RepoName-beadID/
-	worktreePath := filepath.Join(filepath.Dir(w.repoDir), fmt.Sprintf("%s-%s", repoName, beadID))
+	nameFormat := w.nameFormat
+	if nameFormat == "" {
+		nameFormat = defaultWorktreeNameFormat
+	}
+	name := strings.NewReplacer(
+		"{project}", filepath.Base(w.repoDir),
+		"{beadID}", beadID,
+		"{branch}", strings.ReplaceAll(branchName(beadID), "/", "-"),
+	).Replace(nameFormat)
 
-	// Branch name: az/beadID
-	branchName := fmt.Sprintf("az/%s", beadID)
+	return filepath.Join(basePath, name)
+}
+
+// branchName returns the branch for beadID: az/beadID
+func branchName(beadID string) string {
+	return fmt.Sprintf("az/%s", beadID)
+}
+
+// Create creates a new worktree for the given bead ID.
+// It creates the worktree where SetLayout says, by default at
+// ../RepoName-beadID/, with branch az/beadID.
+func (w *WorktreeManager) Create(ctx context.Context, beadID string, baseBranch string) (*Worktree, error) {
+	worktreePath := w.worktreePath(beadID)
+	branchName := branchName(beadID)
 
 	w.logger.Info("creating worktree",
 		"beadID", beadID,
@@ -53,8 +114,12 @@ func (w *WorktreeManager) Create(ctx context.Context, beadID string, baseBranch
 		"baseBranch", baseBranch,
 	)
 
-	// Check if worktree already exists
-	exists, err := w.Exists(ctx, beadID)
+	// Check if worktree already exists, listing them all only when the
+	// branch and path can't settle it
+	exists, ok, err := w.existsFast(ctx, beadID)
+	if err == nil && !ok {
+		exists, err = w.Exists(ctx, beadID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if worktree exists: %w", err)
 	}
@@ -108,6 +173,29 @@ func (w *WorktreeManager) Delete(ctx context.Context, beadID string) error {
 	return nil
 }
 
+// Remove removes the worktree for the given bead ID and deletes its branch
+// only if it's merged (git branch -d), so commits that exist nowhere else
+// survive. It reports whether the branch was kept.
+func (w *WorktreeManager) Remove(ctx context.Context, beadID string) (branchKept bool, err error) {
+	w.logger.Info("removing worktree", "beadID", beadID)
+
+	worktree, err := w.Get(ctx, beadID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get worktree info: %w", err)
+	}
+
+	if _, err := w.runner.Run(ctx, "worktree", "remove", worktree.Path); err != nil {
+		return false, fmt.Errorf("failed to remove worktree: %w", err)
+	}
+
+	// git branch -d refuses to delete unmerged branches
+	if _, err := w.runner.Run(ctx, "branch", "-d", worktree.Branch); err != nil {
+		w.logger.Info("kept unmerged branch", "branch", worktree.Branch, "error", err)
+		return true, nil
+	}
+	return false, nil
+}
+
 // Get returns information about the worktree for the given bead ID.
 func (w *WorktreeManager) Get(ctx context.Context, beadID string) (*Worktree, error) {
 	worktrees, err := w.List(ctx)
@@ -148,6 +236,41 @@ func (w *WorktreeManager) Exists(ctx context.Context, beadID string) (bool, erro
 	return true, nil
 }
 
+// existsFast checks for the bead's worktree without listing every worktree.
+// ok is false when the answer isn't certain and Exists must be used.
+//
+// Without an az/beadID branch there can be no worktree for the bead. With
+// the branch and a checkout at the expected path, the worktree exists.
+// Anything else, such as a branch checked out elsewhere, needs the list.
+// A git failure other than the branch missing is returned.
+func (w *WorktreeManager) existsFast(ctx context.Context, beadID string) (exists, ok bool, err error) {
+	if w.fullExistsCheck {
+		return false, false, nil
+	}
+
+	// git rev-parse --verify --quiet refs/heads/az/beadID exits with status
+	// 1 when the branch is missing
+	if _, err := w.runner.Run(ctx, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName(beadID)); err != nil {
+		if isExitStatus(err, 1) {
+			return false, true, nil
+		}
+		return false, false, err
+	}
+
+	// A worktree checkout has a .git file pointing back at the repository
+	if info, err := os.Stat(filepath.Join(w.worktreePath(beadID), ".git")); err == nil && !info.IsDir() {
+		return true, true, nil
+	}
+
+	return false, false, nil
+}
+
+// isExitStatus reports whether err is from git exiting with status code
+func isExitStatus(err error, code int) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == code
+}
+
 // parseWorktreeList parses the output of 'git worktree list --porcelain'.
 // Example output:
 //   worktree /home/user/repo
@@ -1,9 +1,14 @@
 package git
 
 import (
+	"errors"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -112,6 +117,130 @@ branch refs/heads/az/bead-123
 	assert.Contains(t, err.Error(), "already exists")
 }
 
+func TestWorktreeManager_Create_ExistsFastPath(t *testing.T) {
+	ctx := context.Background()
+	listOutput := `worktree /home/user/test-repo
+HEAD abc123
+branch refs/heads/main
+
+worktree %s
+HEAD def456
+branch refs/heads/az/bead-123
+`
+
+	tests := []struct {
+		name            string
+		branchExists    bool
+		checkout        bool // Create a worktree checkout at the expected path
+		fullExistsCheck bool
+		wantErr         bool
+		wantList        bool
+	}{
+		{
+			name:     "missing branch skips the worktree list",
+			wantList: false,
+		},
+		{
+			name:         "branch and checkout exist without listing",
+			branchExists: true,
+			checkout:     true,
+			wantErr:      true,
+			wantList:     false,
+		},
+		{
+			name:         "branch without checkout falls back to the list",
+			branchExists: true,
+			wantErr:      true,
+			wantList:     true,
+		},
+		{
+			name:            "full check always lists",
+			fullExistsCheck: true,
+			wantErr:         true,
+			wantList:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := filepath.Join(t.TempDir(), "test-repo")
+			worktreePath := repoDir + "-bead-123"
+			if tt.checkout {
+				require.NoError(t, os.MkdirAll(worktreePath, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(worktreePath, ".git"), []byte("gitdir: "+repoDir), 0644))
+			}
+
+			mock := NewMockRunner()
+			mock.handler = func(ctx context.Context, args ...string) (string, error) {
+				switch args[0] {
+				case "rev-parse":
+					if !tt.branchExists {
+						return "", exitStatusError(t, 1)
+					}
+					return "def456", nil
+				case "worktree":
+					if args[1] == "list" {
+						// The listed worktree only exists when the branch does
+						if tt.branchExists || tt.fullExistsCheck {
+							return fmt.Sprintf(listOutput, worktreePath), nil
+						}
+					}
+				}
+				return "", nil
+			}
+
+			manager := NewWorktreeManager(mock, repoDir, slog.Default())
+			manager.SetFullExistsCheck(tt.fullExistsCheck)
+
+			_, err := manager.Create(ctx, "bead-123", "main")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "already exists")
+			} else {
+				require.NoError(t, err)
+				mock.AssertCommand(t, fmt.Sprintf("worktree add -b az/bead-123 %s main", worktreePath))
+			}
+
+			listed := false
+			for _, cmd := range mock.commands {
+				if cmd == "worktree list --porcelain" {
+					listed = true
+				}
+			}
+			assert.Equal(t, tt.wantList, listed)
+		})
+	}
+}
+
+func TestWorktreeManager_Create_RevParseFailure(t *testing.T) {
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		if args[0] == "rev-parse" {
+			// Not a missing branch, e.g. a corrupt repository
+			return "", exitStatusError(t, 128)
+		}
+		return "", nil
+	}
+
+	manager := NewWorktreeManager(mock, "/home/user/test-repo", slog.Default())
+	_, err := manager.Create(context.Background(), "bead-123", "main")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to check if worktree exists")
+	for _, cmd := range mock.commands {
+		assert.NotContains(t, cmd, "worktree add")
+	}
+}
+
+// exitStatusError returns the error of a git command exiting with code
+func exitStatusError(tb testing.TB, code int) error {
+	tb.Helper()
+	err := exec.Command("sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+	require.Error(tb, err)
+	return fmt.Errorf("git rev-parse failed: %w", err)
+}
+
 func TestWorktreeManager_Delete(t *testing.T) {
 	ctx := context.Background()
 	repoDir := "/home/user/test-repo"
@@ -151,6 +280,54 @@ branch refs/heads/az/bead-123
 	mock.AssertCommand(t, "branch -D az/bead-123")
 }
 
+func TestWorktreeManager_Remove_KeepsUnmergedBranch(t *testing.T) {
+	ctx := context.Background()
+	repoDir := "/home/user/test-repo"
+
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		if len(args) > 0 && args[0] == "worktree" && args[1] == "list" {
+			return `worktree /home/user/test-repo-bead-123
+HEAD def456
+branch refs/heads/az/bead-123
+`, nil
+		}
+		// git branch -d refuses an unmerged branch
+		if len(args) > 0 && args[0] == "branch" && args[1] == "-d" {
+			return "", errors.New("error: the branch 'az/bead-123' is not fully merged")
+		}
+		return "", nil
+	}
+
+	manager := NewWorktreeManager(mock, repoDir, slog.Default())
+
+	kept, err := manager.Remove(ctx, "bead-123")
+
+	require.NoError(t, err)
+	assert.True(t, kept)
+	mock.AssertCommand(t, "worktree remove /home/user/test-repo-bead-123")
+	mock.AssertCommand(t, "branch -d az/bead-123")
+	assert.NotContains(t, mock.commands, "branch -D az/bead-123")
+}
+
+func TestWorktreeManager_Remove_DeletesMergedBranch(t *testing.T) {
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		if len(args) > 0 && args[0] == "worktree" && args[1] == "list" {
+			return "worktree /home/user/test-repo-bead-123\nbranch refs/heads/az/bead-123\n", nil
+		}
+		return "", nil
+	}
+
+	manager := NewWorktreeManager(mock, "/home/user/test-repo", slog.Default())
+
+	kept, err := manager.Remove(context.Background(), "bead-123")
+
+	require.NoError(t, err)
+	assert.False(t, kept)
+	mock.AssertCommand(t, "branch -d az/bead-123")
+}
+
 func TestWorktreeManager_Delete_NotFound(t *testing.T) {
 	ctx := context.Background()
 	repoDir := "/home/user/test-repo"
@@ -432,6 +609,69 @@ func TestWorktreeManager_PathGeneration(t *testing.T) {
 	}
 }
 
+func TestWorktreeManager_Layout(t *testing.T) {
+	ctx := context.Background()
+	repoDir := "/home/user/my-repo"
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		basePath     string
+		nameFormat   string
+		expectedPath string
+	}{
+		{
+			name:         "empty keeps the default",
+			expectedPath: "/home/user/my-repo-bead-xyz",
+		},
+		{
+			name:         "config defaults",
+			basePath:     "../",
+			nameFormat:   "{project}-{beadID}",
+			expectedPath: "/home/user/my-repo-bead-xyz",
+		},
+		{
+			name:         "sibling worktrees dir",
+			basePath:     "../worktrees",
+			nameFormat:   "{beadID}",
+			expectedPath: "/home/user/worktrees/bead-xyz",
+		},
+		{
+			name:         "inside the repo",
+			basePath:     ".worktrees",
+			nameFormat:   "{branch}",
+			expectedPath: "/home/user/my-repo/.worktrees/az-bead-xyz",
+		},
+		{
+			name:         "absolute base path",
+			basePath:     "/tmp/wt",
+			nameFormat:   "{project}/{beadID}",
+			expectedPath: "/tmp/wt/my-repo/bead-xyz",
+		},
+		{
+			name:         "home base path",
+			basePath:     "~/worktrees",
+			expectedPath: filepath.Join(home, "worktrees", "my-repo-bead-xyz"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := NewMockRunner()
+			manager := NewWorktreeManager(mock, repoDir, slog.Default())
+			manager.SetLayout(tc.basePath, tc.nameFormat)
+
+			worktree, err := manager.Create(ctx, "bead-xyz", "main")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, worktree.Path)
+			mock.AssertCommand(t, "worktree add -b az/bead-xyz "+tc.expectedPath+" main")
+		})
+	}
+}
+
 func TestParseWorktreeList(t *testing.T) {
 	repoDir := "/home/user/test-repo"
 	logger := slog.Default()
@@ -583,3 +823,49 @@ branch refs/heads/az/bead-456
 		_ = manager.parseWorktreeList(output)
 	}
 }
+
+// manyWorktrees returns 'git worktree list --porcelain' output for n bead
+// worktrees
+func manyWorktrees(n int) string {
+	var sb strings.Builder
+	sb.WriteString("worktree /home/user/test-repo\nHEAD abc123\nbranch refs/heads/main\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "worktree /home/user/test-repo-bead-%d\nHEAD def456\nbranch refs/heads/az/bead-%d\n\n", i, i)
+	}
+	return sb.String()
+}
+
+func benchmarkCreateExistsCheck(b *testing.B, fullExistsCheck bool) {
+	output := manyWorktrees(500)
+	mock := NewMockRunner()
+	mock.handler = func(ctx context.Context, args ...string) (string, error) {
+		switch args[0] {
+		case "rev-parse":
+			return "", exitStatusError(b, 1)
+		case "worktree":
+			if args[1] == "list" {
+				return output, nil
+			}
+		}
+		return "", nil
+	}
+	manager := NewWorktreeManager(mock, "/home/user/test-repo", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	manager.SetFullExistsCheck(fullExistsCheck)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := manager.Create(ctx, "bead-new", "main"); err != nil {
+			b.Fatal(err)
+		}
+		mock.Reset()
+	}
+}
+
+func BenchmarkCreate_FastExistsCheck(b *testing.B) {
+	benchmarkCreateExistsCheck(b, false)
+}
+
+func BenchmarkCreate_FullExistsCheck(b *testing.B) {
+	benchmarkCreateExistsCheck(b, true)
+}
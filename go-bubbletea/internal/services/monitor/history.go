@@ -0,0 +1,76 @@
+package monitor
+
+import "strings"
+
+// DefaultHistoryLines is the number of lines retained per session when no
+// history size is configured, the same as the monitor.historyLines default
+const DefaultHistoryLines = 500
+
+// historyBuffer accumulates successive pane captures into a rolling
+// scrollback. Captures are overlapping windows of the pane, so only the lines
+// following the overlap with the retained tail are appended.
+type historyBuffer struct {
+	lines    []string
+	maxLines int
+}
+
+// newHistoryBuffer creates a buffer that keeps at most maxLines lines
+func newHistoryBuffer(maxLines int) *historyBuffer {
+	if maxLines <= 0 {
+		maxLines = DefaultHistoryLines
+	}
+	return &historyBuffer{maxLines: maxLines}
+}
+
+// Append merges a capture into the buffer, dropping the oldest lines once
+// the buffer exceeds its size
+func (b *historyBuffer) Append(capture string) {
+	current := captureLines(capture)
+	b.lines = append(b.lines, freshLines(b.lines, current)...)
+
+	if excess := len(b.lines) - b.maxLines; excess > 0 {
+		b.lines = append([]string(nil), b.lines[excess:]...)
+	}
+}
+
+// String returns the retained scrollback, oldest line first
+func (b *historyBuffer) String() string {
+	return strings.Join(b.lines, "\n")
+}
+
+// freshLines returns the lines of current that follow the longest suffix of
+// retained that is also a prefix of current
+func freshLines(retained, current []string) []string {
+	maxOverlap := min(len(retained), len(current))
+
+	for overlap := maxOverlap; overlap > 0; overlap-- {
+		if equalLines(retained[len(retained)-overlap:], current[:overlap]) {
+			return current[overlap:]
+		}
+	}
+
+	return current
+}
+
+// equalLines reports whether two line slices are identical
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// captureLines splits a pane capture into lines, dropping the blank padding
+// tmux adds below the cursor
+func captureLines(capture string) []string {
+	trimmed := strings.TrimRight(capture, "\n ")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "\n")
+}
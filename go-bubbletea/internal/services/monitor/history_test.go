@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/config"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func TestHistoryBuffer_Append(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxLines int
+		captures []string
+		want     string
+	}{
+		{
+			name:     "single capture",
+			maxLines: 10,
+			captures: []string{"a\nb\nc\n"},
+			want:     "a\nb\nc",
+		},
+		{
+			name:     "identical captures are not duplicated",
+			maxLines: 10,
+			captures: []string{"a\nb\nc", "a\nb\nc", "a\nb\nc"},
+			want:     "a\nb\nc",
+		},
+		{
+			name:     "scrolled capture appends only new lines",
+			maxLines: 10,
+			captures: []string{"a\nb\nc", "b\nc\nd", "c\nd\ne"},
+			want:     "a\nb\nc\nd\ne",
+		},
+		{
+			name:     "capture without overlap is appended whole",
+			maxLines: 10,
+			captures: []string{"a\nb", "x\ny"},
+			want:     "a\nb\nx\ny",
+		},
+		{
+			name:     "blank padding is ignored",
+			maxLines: 10,
+			captures: []string{"a\nb\n\n\n", "a\nb\n   \n"},
+			want:     "a\nb",
+		},
+		{
+			name:     "oldest lines are dropped past the limit",
+			maxLines: 3,
+			captures: []string{"a\nb\nc", "c\nd\ne"},
+			want:     "c\nd\ne",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newHistoryBuffer(tt.maxLines)
+			for _, capture := range tt.captures {
+				b.Append(capture)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryBuffer_DefaultSize(t *testing.T) {
+	b := newHistoryBuffer(0)
+	if b.maxLines != DefaultHistoryLines {
+		t.Errorf("maxLines = %d, want %d", b.maxLines, DefaultHistoryLines)
+	}
+}
+
+// burst returns n lines of output that match no state pattern
+func burst(from, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("compiling module %d", from+i)
+	}
+	return lines
+}
+
+func TestDetectStateInHistory_PromptBeforeBurst(t *testing.T) {
+	// The prompt is printed, then a burst of output scrolls it out of the
+	// 100-line capture before the next poll
+	first := strings.Join(append(burst(0, 99), "Do you want to proceed? [y/n]"), "\n")
+	second := strings.Join(burst(119, 100), "\n")
+
+	b := newHistoryBuffer(500)
+	b.Append(first)
+	if state := DetectStateInHistory(b.String(), 500, 100).State; state != domain.SessionWaiting {
+		t.Fatalf("DetectStateInHistory(first capture) = %v, want %v", state, domain.SessionWaiting)
+	}
+
+	// Output after the prompt means it was answered, so the prompt left in
+	// the scrollback no longer counts
+	b.Append(second)
+	if state := DetectStateInHistory(b.String(), 500, 100).State; state != domain.SessionBusy {
+		t.Errorf("DetectStateInHistory(history) = %v, want %v", state, domain.SessionBusy)
+	}
+	if state := DetectStateInWindow(b.String(), 500).State; state != domain.SessionWaiting {
+		t.Errorf("DetectStateInWindow(history) = %v, want %v", state, domain.SessionWaiting)
+	}
+}
+
+func TestDetectStateInHistory_DoneBeforeBurst(t *testing.T) {
+	// Done isn't limited to the latest capture
+	history := strings.Join(append([]string{"Task completed successfully"}, burst(0, 150)...), "\n")
+	if state := DetectStateInHistory(history, 500, 100).State; state != domain.SessionDone {
+		t.Errorf("DetectStateInHistory() = %v, want %v", state, domain.SessionDone)
+	}
+}
+
+func TestDefaultHistoryLines_MatchesConfig(t *testing.T) {
+	if want := config.DefaultConfig().Monitor.HistoryLines; DefaultHistoryLines != want {
+		t.Errorf("DefaultHistoryLines = %d, want the config default %d", DefaultHistoryLines, want)
+	}
+}
@@ -211,6 +211,50 @@ var statePatterns = []StatePattern{
 	// Command execution
 	{domain.SessionBusy, regexp.MustCompile(`(?i)Running command`), PriorityBusy},
 	{domain.SessionBusy, regexp.MustCompile(`(?i)Executing`), PriorityBusy},
+
+	// Claude Code's spinner while generating
+	{domain.SessionBusy, regexp.MustCompile(`(?i)Thinking(…|\.\.\.)`), PriorityBusy},
+	{domain.SessionBusy, regexp.MustCompile(`(?i)esc to interrupt`), PriorityBusy},
+}
+
+// busyReasonLines is how many of the last non-blank lines are searched for
+// what a busy session is doing
+const busyReasonLines = 15
+
+// busyReasonPatterns classify busy output, most specific first. Claude Code
+// keeps its spinner on screen while tools run, so a visible tool call or
+// build outranks the spinner.
+var busyReasonPatterns = []struct {
+	Reason  domain.BusyReason
+	Pattern *regexp.Regexp
+}{
+	{domain.BusyTesting, regexp.MustCompile(`(?i)Running tests?|Executing tests?|\b(go|npm|pnpm|yarn|bun|cargo) (run )?test\b|\b(pytest|jest|vitest)\b`)},
+	{domain.BusyCompiling, regexp.MustCompile(`(?i)Compiling|Building|\b(go|cargo) build\b|\btsc\b`)},
+	{domain.BusyToolUse, regexp.MustCompile(`^\s*⏺\s*\w+\(|(?i)Running command|Executing`)},
+	{domain.BusyThinking, regexp.MustCompile(`(?i)Thinking(…|\.\.\.)|esc to interrupt`)},
+}
+
+// detectBusyReason returns what the last busyReasonLines non-blank lines say
+// a busy session is doing, or "" when they don't say
+func detectBusyReason(lines []string) domain.BusyReason {
+	best := len(busyReasonPatterns)
+	seen := 0
+	for i := len(lines) - 1; i >= 0 && seen < busyReasonLines; i-- {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		seen++
+		for rank, rp := range busyReasonPatterns[:best] {
+			if rp.Pattern.MatchString(lines[i]) {
+				best = rank
+				break
+			}
+		}
+	}
+	if best == len(busyReasonPatterns) {
+		return ""
+	}
+	return busyReasonPatterns[best].Reason
 }
 
 // DetectionResult represents a state detection result with confidence
@@ -218,10 +262,15 @@ type DetectionResult struct {
 	State      domain.SessionState
 	Match      *PatternMatch
 	Confidence float64
+	Reason     domain.BusyReason // What a busy session is doing, if known
 }
 
+// DefaultCaptureLines is how many lines of a pane are captured per poll when
+// no capture size is configured, and the window DetectState scans
+const DefaultCaptureLines = 100
+
 // DetectState analyzes session output and determines the current state
-// It checks the last 100 lines for state patterns in priority order.
+// It checks the last DefaultCaptureLines lines for state patterns in priority order.
 // Returns SessionBusy if no patterns match and output is non-empty.
 func DetectState(output string) domain.SessionState {
 	result := DetectStateWithContext(output)
@@ -231,11 +280,28 @@ func DetectState(output string) domain.SessionState {
 // DetectStateWithContext analyzes session output and returns detailed detection information
 // including the matched pattern, line context, and confidence score.
 func DetectStateWithContext(output string) DetectionResult {
-	// Check last 100 lines for patterns
+	return DetectStateInWindow(output, DefaultCaptureLines)
+}
+
+// DetectStateInWindow is DetectStateWithContext over the last window lines of
+// output, for captures of a configured size
+func DetectStateInWindow(output string, window int) DetectionResult {
+	return DetectStateInHistory(output, window, window)
+}
+
+// DetectStateInHistory is DetectStateInWindow over scrollback accumulated
+// across several captures. Error and waiting patterns only count in the last
+// recent lines, the latest capture, as a prompt or error further back has
+// already been answered or moved past.
+func DetectStateInHistory(output string, window, recent int) DetectionResult {
+	if window <= 0 {
+		window = DefaultCaptureLines
+	}
+
 	lines := strings.Split(output, "\n")
 	startLine := 0
-	if len(lines) > 100 {
-		startLine = len(lines) - 100
+	if len(lines) > window {
+		startLine = len(lines) - window
 		lines = lines[startLine:]
 	}
 
@@ -248,7 +314,11 @@ func DetectStateWithContext(output string) DetectionResult {
 			continue
 		}
 
+		stale := i < len(lines)-recent
 		for _, sp := range statePatterns {
+			if stale && sp.Priority >= PriorityWaiting {
+				continue
+			}
 			if sp.Pattern.MatchString(line) {
 				// Calculate confidence based on line recency (more recent = higher confidence)
 				linePosition := float64(i) / float64(len(lines))
@@ -275,11 +345,15 @@ func DetectStateWithContext(output string) DetectionResult {
 
 	// Return best match if found
 	if bestMatch != nil {
-		return DetectionResult{
+		result := DetectionResult{
 			State:      bestMatch.State,
 			Match:      bestMatch,
 			Confidence: bestMatch.Confidence,
 		}
+		if result.State == domain.SessionBusy {
+			result.Reason = detectBusyReason(lines)
+		}
+		return result
 	}
 
 	// Default to busy if we have non-empty output
@@ -288,6 +362,7 @@ func DetectStateWithContext(output string) DetectionResult {
 			State:      domain.SessionBusy,
 			Match:      nil,
 			Confidence: 0.3, // Low confidence for default state
+			Reason:     detectBusyReason(lines),
 		}
 	}
 
@@ -568,6 +568,35 @@ func TestDetectState_ExactlyOneHundredLines(t *testing.T) {
 	}
 }
 
+func TestDetectStateInWindow_ConfiguredSize(t *testing.T) {
+	// A prompt 150 lines back, behind a burst of plain output
+	lines := []string{"Do you want to continue? [y/n]"}
+	for i := 0; i < 149; i++ {
+		lines = append(lines, "normal output line")
+	}
+	output := strings.Join(lines, "\n")
+
+	tests := []struct {
+		name   string
+		window int
+		want   domain.SessionState
+	}{
+		{"default window misses it", 0, domain.SessionBusy},
+		{"smaller window misses it", 50, domain.SessionBusy},
+		{"larger window finds it", 200, domain.SessionWaiting},
+		{"exact window finds it", 150, domain.SessionWaiting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DetectStateInWindow(output, tt.window)
+			if result.State != tt.want {
+				t.Errorf("DetectStateInWindow(window=%d) = %v, want %v", tt.window, result.State, tt.want)
+			}
+		})
+	}
+}
+
 func TestDetectState_MultilinePatterns(t *testing.T) {
 	output := `Starting task...
 Processing files...
@@ -761,3 +790,83 @@ Error: recent error at line 5`
 		t.Errorf("Expected high confidence for recent match, got: %v", result.Confidence)
 	}
 }
+
+// ============================================================================
+// BUSY REASON TESTS
+// ============================================================================
+
+func TestDetectStateWithContext_BusyReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   domain.BusyReason
+	}{
+		{
+			name:   "thinking spinner",
+			output: "> Add retries to the client\n\n✻ Thinking… (12s · esc to interrupt)",
+			want:   domain.BusyThinking,
+		},
+		{
+			name:   "spinner with another verb",
+			output: "✶ Cogitating… (3s · ↑ 120 tokens · esc to interrupt)",
+			want:   domain.BusyThinking,
+		},
+		{
+			name:   "compiling",
+			output: "⏺ Bash(go build ./...)\n  ⎿  Running…\n\n✻ Honking… (40s · esc to interrupt)",
+			want:   domain.BusyCompiling,
+		},
+		{
+			name:   "compiling output",
+			output: "   Compiling serde v1.0.200\n   Compiling tokio v1.37.0",
+			want:   domain.BusyCompiling,
+		},
+		{
+			name:   "running tests",
+			output: "⏺ Bash(go test ./internal/...)\n  ⎿  Running…\n\n✻ Thinking… (1m 2s · esc to interrupt)",
+			want:   domain.BusyTesting,
+		},
+		{
+			name:   "running tests output",
+			output: "Running tests for auth module...",
+			want:   domain.BusyTesting,
+		},
+		{
+			name:   "other tool call",
+			output: "⏺ Read(internal/app/model.go)\n  ⎿  Read 120 lines",
+			want:   domain.BusyToolUse,
+		},
+		{
+			name:   "unclassified output",
+			output: "Some plain output",
+			want:   "",
+		},
+		{
+			name:   "old marker scrolled past the reason window",
+			output: "✻ Thinking… (2s · esc to interrupt)" + strings.Repeat("\nplain output", busyReasonLines),
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DetectStateWithContext(tt.output)
+			if result.State != domain.SessionBusy {
+				t.Fatalf("State = %v, want %v", result.State, domain.SessionBusy)
+			}
+			if result.Reason != tt.want {
+				t.Errorf("Reason = %q, want %q", result.Reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectStateWithContext_NoReasonUnlessBusy(t *testing.T) {
+	result := DetectStateWithContext("✻ Thinking… (2s · esc to interrupt)\nDo you want to proceed? [y/n]")
+	if result.State != domain.SessionWaiting {
+		t.Fatalf("State = %v, want %v", result.State, domain.SessionWaiting)
+	}
+	if result.Reason != "" {
+		t.Errorf("Reason = %q, want none for a waiting session", result.Reason)
+	}
+}
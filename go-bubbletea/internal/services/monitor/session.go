@@ -2,6 +2,7 @@ package monitor
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -20,33 +21,92 @@ type ProgramSender interface {
 	Send(msg tea.Msg)
 }
 
+// DefaultMinConfidence sits above the 0.3 confidence of the default-busy
+// classification and below the 0.5 floor of any pattern match.
+const DefaultMinConfidence = 0.4
+
 // SessionMonitor monitors tmux sessions and detects state changes
 type SessionMonitor struct {
-	tmux     TmuxClient
-	mu       sync.RWMutex
-	sessions map[string]*monitoredSession
-	wg       sync.WaitGroup
+	tmux          TmuxClient
+	mu            sync.RWMutex
+	sessions      map[string]*monitoredSession
+	wg            sync.WaitGroup
+	minConfidence float64
+	historyLines  int
+	captureLines  int
+	stuckTimeout  time.Duration
 }
 
 // monitoredSession represents a session being monitored
 type monitoredSession struct {
-	beadID string
-	cancel context.CancelFunc
-	state  domain.SessionState
+	beadID    string
+	cancel    context.CancelFunc
+	state     domain.SessionState
+	reason    domain.BusyReason
+	stalled   bool
+	changedAt time.Time // When state last changed
+
+	outputHash    uint64 // Of the last capture; see outputHash
+	outputChanged bool   // Since TakeOutputChange last reported it
 }
 
 // SessionStateMsg is sent to the Bubble Tea program when state changes
 type SessionStateMsg struct {
-	BeadID string
-	State  domain.SessionState
+	BeadID  string
+	State   domain.SessionState
+	Reason  domain.BusyReason // Set while busy when the output shows why
+	Stalled bool              // Busy with no new output for the stuck timeout
 }
 
 // NewSessionMonitor creates a new session monitor
 func NewSessionMonitor(tmux TmuxClient) *SessionMonitor {
 	return &SessionMonitor{
-		tmux:     tmux,
-		sessions: make(map[string]*monitoredSession),
+		tmux:          tmux,
+		sessions:      make(map[string]*monitoredSession),
+		minConfidence: DefaultMinConfidence,
+		historyLines:  DefaultHistoryLines,
+		captureLines:  DefaultCaptureLines,
+	}
+}
+
+// SetMinConfidence sets the confidence a detection must exceed to change a
+// session's state. Should be called before any session is started.
+func (m *SessionMonitor) SetMinConfidence(minConfidence float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.minConfidence = minConfidence
+}
+
+// SetHistoryLines sets how many lines of scrollback are retained per session
+// and scanned for state. Should be called before any session is started.
+func (m *SessionMonitor) SetHistoryLines(lines int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lines <= 0 {
+		lines = DefaultHistoryLines
 	}
+	m.historyLines = lines
+}
+
+// SetCaptureLines tells the monitor how many lines its TmuxClient captures
+// per poll, so detection never scans less than a whole capture. Should be
+// called before any session is started.
+func (m *SessionMonitor) SetCaptureLines(lines int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lines <= 0 {
+		lines = DefaultCaptureLines
+	}
+	m.captureLines = lines
+}
+
+// SetStuckTimeout sets how long a busy session's pane may stay unchanged
+// before it is reported stalled; 0 turns stall detection off. Should be
+// called before any session is started.
+func (m *SessionMonitor) SetStuckTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stuckTimeout = timeout
 }
 
 // Start begins monitoring a session
@@ -64,9 +124,11 @@ func (m *SessionMonitor) Start(ctx context.Context, beadID string, program Progr
 	monitorCtx, cancel := context.WithCancel(ctx)
 
 	session := &monitoredSession{
-		beadID: beadID,
-		cancel: cancel,
-		state:  domain.SessionIdle,
+		beadID:        beadID,
+		cancel:        cancel,
+		state:         domain.SessionIdle,
+		changedAt:     time.Now(),
+		outputChanged: true,
 	}
 	m.sessions[beadID] = session
 
@@ -97,6 +159,43 @@ func (m *SessionMonitor) GetState(beadID string) domain.SessionState {
 	return domain.SessionIdle
 }
 
+// TakeOutputChange reports whether a session's pane has shown new output
+// since the last call, or since monitoring started, and clears the change.
+// Nothing is known of a session that isn't monitored, so it reports true.
+func (m *SessionMonitor) TakeOutputChange(beadID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[beadID]
+	if !ok {
+		return true
+	}
+	changed := session.outputChanged
+	session.outputChanged = false
+	return changed
+}
+
+// IdleSessions returns the bead IDs of sessions that have been done or idle
+// for longer than threshold as of now, sorted by bead ID
+func (m *SessionMonitor) IdleSessions(now time.Time, threshold time.Duration) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var idle []string
+	for beadID, session := range m.sessions {
+		if isIdleState(session.state) && now.Sub(session.changedAt) > threshold {
+			idle = append(idle, beadID)
+		}
+	}
+	sort.Strings(idle)
+	return idle
+}
+
+// isIdleState reports whether a session in this state is no longer working
+func isIdleState(state domain.SessionState) bool {
+	return state == domain.SessionDone || state == domain.SessionIdle
+}
+
 // StopAll stops monitoring all sessions
 func (m *SessionMonitor) StopAll() {
 	m.mu.Lock()
@@ -117,6 +216,14 @@ func (m *SessionMonitor) monitor(ctx context.Context, beadID string, program Pro
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 
+	// Accumulate captures so a prompt that scrolled out of a single capture
+	// behind a burst of output is still seen
+	m.mu.RLock()
+	window := max(m.historyLines, m.captureLines)
+	history := newHistoryBuffer(window)
+	stall := newStallTracker(m.stuckTimeout)
+	m.mu.RUnlock()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -129,8 +236,12 @@ func (m *SessionMonitor) monitor(ctx context.Context, beadID string, program Pro
 				continue
 			}
 
-			// Detect state from output
-			newState := DetectState(output)
+			// Detect state from the accumulated scrollback, with prompts and
+			// errors only from the latest capture
+			history.Append(output)
+			result := DetectStateInHistory(history.String(), window, len(captureLines(output)))
+			newState := result.State
+			sum := outputHash(output)
 
 			// Check if state changed
 			m.mu.Lock()
@@ -139,16 +250,36 @@ func (m *SessionMonitor) monitor(ctx context.Context, beadID string, program Pro
 				m.mu.Unlock()
 				return // Session was stopped
 			}
+			if sum != session.outputHash {
+				session.outputHash = sum
+				session.outputChanged = true
+			}
 
-			if session.state != newState {
+			changed := acceptTransition(session.state, result, m.minConfidence)
+			if changed {
 				session.state = newState
+				session.changedAt = time.Now()
+			}
+
+			// A busy session reports what it's doing as that changes too
+			reason := domain.BusyReason("")
+			if session.state == domain.SessionBusy && newState == domain.SessionBusy {
+				reason = result.Reason
+			}
+			stalled := stall.Observe(output, session.state == domain.SessionBusy, time.Now())
+			if changed || reason != session.reason || stalled != session.stalled {
+				session.reason = reason
+				session.stalled = stalled
+				state := session.state
 				m.mu.Unlock()
 
 				// Send state change message to program
 				if program != nil {
 					program.Send(SessionStateMsg{
-						BeadID: beadID,
-						State:  newState,
+						BeadID:  beadID,
+						State:   state,
+						Reason:  reason,
+						Stalled: stalled,
 					})
 				}
 			} else {
@@ -157,3 +288,17 @@ func (m *SessionMonitor) monitor(ctx context.Context, beadID string, program Pro
 		}
 	}
 }
+
+// acceptTransition reports whether a detection result should replace the
+// current state. Results at or below minConfidence keep the previous state so
+// noisy output doesn't flap, except when leaving the initial idle state where
+// a weak classification is still better than none.
+func acceptTransition(current domain.SessionState, result DetectionResult, minConfidence float64) bool {
+	if result.State == current {
+		return false
+	}
+	if result.Confidence > minConfidence {
+		return true
+	}
+	return current == domain.SessionIdle
+}
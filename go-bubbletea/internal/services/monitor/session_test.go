@@ -298,3 +298,157 @@ func TestSessionMonitor_ContextCancellation(t *testing.T) {
 
 	monitor.Stop("test-bead")
 }
+
+func TestAcceptTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		current domain.SessionState
+		result  DetectionResult
+		want    bool
+	}{
+		{
+			name:    "low-confidence busy does not clobber waiting",
+			current: domain.SessionWaiting,
+			result:  DetectionResult{State: domain.SessionBusy, Confidence: 0.3},
+			want:    false,
+		},
+		{
+			name:    "low-confidence busy does not clobber error",
+			current: domain.SessionError,
+			result:  DetectionResult{State: domain.SessionBusy, Confidence: 0.3},
+			want:    false,
+		},
+		{
+			name:    "pattern-matched busy replaces waiting",
+			current: domain.SessionWaiting,
+			result:  DetectionResult{State: domain.SessionBusy, Confidence: 0.9},
+			want:    true,
+		},
+		{
+			name:    "confidence equal to threshold is rejected",
+			current: domain.SessionWaiting,
+			result:  DetectionResult{State: domain.SessionDone, Confidence: DefaultMinConfidence},
+			want:    false,
+		},
+		{
+			name:    "low-confidence busy leaves initial idle",
+			current: domain.SessionIdle,
+			result:  DetectionResult{State: domain.SessionBusy, Confidence: 0.3},
+			want:    true,
+		},
+		{
+			name:    "same state is not a transition",
+			current: domain.SessionBusy,
+			result:  DetectionResult{State: domain.SessionBusy, Confidence: 1.0},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acceptTransition(tt.current, tt.result, DefaultMinConfidence)
+			if got != tt.want {
+				t.Errorf("acceptTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionMonitor_LowConfidenceKeepsWaiting(t *testing.T) {
+	tmux := &mockTmuxClient{output: "Do you want to continue? [y/n]"}
+	monitor := NewSessionMonitor(tmux)
+	program := &mockProgram{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor.Start(ctx, "test-bead", program)
+	time.Sleep(600 * time.Millisecond)
+
+	if state := monitor.GetState("test-bead"); state != domain.SessionWaiting {
+		t.Fatalf("GetState() = %v, want %v", state, domain.SessionWaiting)
+	}
+
+	// Unmatched output only yields a 0.3-confidence busy classification
+	tmux.output = "some unrelated output"
+	time.Sleep(600 * time.Millisecond)
+
+	if state := monitor.GetState("test-bead"); state != domain.SessionWaiting {
+		t.Errorf("GetState() after low-confidence busy = %v, want %v", state, domain.SessionWaiting)
+	}
+
+	monitor.Stop("test-bead")
+}
+
+func TestSessionMonitor_IdleSessions(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	threshold := 30 * time.Minute
+
+	monitor := NewSessionMonitor(&mockTmuxClient{})
+	monitor.sessions = map[string]*monitoredSession{
+		"done-long":    {beadID: "done-long", state: domain.SessionDone, changedAt: now.Add(-45 * time.Minute)},
+		"idle-long":    {beadID: "idle-long", state: domain.SessionIdle, changedAt: now.Add(-2 * time.Hour)},
+		"done-recent":  {beadID: "done-recent", state: domain.SessionDone, changedAt: now.Add(-10 * time.Minute)},
+		"done-exact":   {beadID: "done-exact", state: domain.SessionDone, changedAt: now.Add(-threshold)},
+		"busy-long":    {beadID: "busy-long", state: domain.SessionBusy, changedAt: now.Add(-3 * time.Hour)},
+		"waiting-long": {beadID: "waiting-long", state: domain.SessionWaiting, changedAt: now.Add(-3 * time.Hour)},
+	}
+
+	got := monitor.IdleSessions(now, threshold)
+	want := []string{"done-long", "idle-long"}
+
+	if len(got) != len(want) {
+		t.Fatalf("IdleSessions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("IdleSessions()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSessionMonitor_IdleClockStartsAtStateChange(t *testing.T) {
+	tmux := &mockTmuxClient{output: "Task completed successfully"}
+	monitor := NewSessionMonitor(tmux)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor.Start(ctx, "test-bead", &mockProgram{})
+	time.Sleep(600 * time.Millisecond)
+
+	if state := monitor.GetState("test-bead"); state != domain.SessionDone {
+		t.Fatalf("GetState() = %v, want %v", state, domain.SessionDone)
+	}
+	if idle := monitor.IdleSessions(time.Now(), time.Minute); len(idle) != 0 {
+		t.Errorf("IdleSessions() right after completion = %v, want none", idle)
+	}
+	if idle := monitor.IdleSessions(time.Now().Add(2*time.Minute), time.Minute); len(idle) != 1 {
+		t.Errorf("IdleSessions() after threshold = %v, want [test-bead]", idle)
+	}
+
+	monitor.Stop("test-bead")
+}
+
+func TestSessionMonitor_TakeOutputChange(t *testing.T) {
+	monitor := NewSessionMonitor(&mockTmuxClient{output: "⏺ Bash(make)\n✻ Building… (3s · esc to interrupt)"})
+
+	if !monitor.TakeOutputChange("az-1") {
+		t.Error("Expected an unmonitored session to report a change")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	monitor.Start(ctx, "az-1", nil)
+	defer monitor.Stop("az-1")
+
+	time.Sleep(600 * time.Millisecond)
+	if !monitor.TakeOutputChange("az-1") {
+		t.Error("Expected the first capture to count as a change")
+	}
+
+	time.Sleep(600 * time.Millisecond)
+	if monitor.TakeOutputChange("az-1") {
+		t.Error("Expected no change while the pane shows the same output")
+	}
+}
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Lines that change while an agent is hung as much as while it works: a
+// spinner frame, or an elapsed-time counter such as "(42s · esc to
+// interrupt)" or "1m 5s"
+var (
+	spinnerLine = regexp.MustCompile(`^\s*[·✢✳✶✻✽⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏◐◓◑◒]\s`)
+	timerLine   = regexp.MustCompile(`(?i)esc to interrupt|\(\d+s\b|\b\d+m\s?\d+s\b|\belapsed\b`)
+)
+
+// stallTracker notices a busy session whose pane hasn't changed for a
+// while, which usually means the agent is hung rather than working
+type stallTracker struct {
+	timeout   time.Duration // 0 disables stall detection
+	hash      uint64
+	changedAt time.Time // When the pane last changed
+	seen      bool
+}
+
+// newStallTracker creates a tracker that flags a session once its pane has
+// been unchanged for timeout while busy
+func newStallTracker(timeout time.Duration) *stallTracker {
+	return &stallTracker{timeout: timeout}
+}
+
+// Observe records a capture taken at now and reports whether the session is
+// stalled: busy, with the same pane content for at least the timeout. Any
+// change to the pane, other than to spinner and timer lines, restarts the
+// clock.
+func (t *stallTracker) Observe(capture string, busy bool, now time.Time) bool {
+	sum := outputHash(capture)
+
+	if !t.seen || sum != t.hash {
+		t.seen = true
+		t.hash = sum
+		t.changedAt = now
+		return false
+	}
+
+	if t.timeout <= 0 || !busy {
+		return false
+	}
+	return now.Sub(t.changedAt) >= t.timeout
+}
+
+// outputHash hashes the output of capture that shows progress, leaving out
+// spinner and timer lines
+func outputHash(capture string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(stallContent(capture)))
+	return h.Sum64()
+}
+
+// stallContent returns capture without its spinner and timer lines, which
+// keep changing even when the agent makes no progress
+func stallContent(capture string) string {
+	lines := strings.Split(capture, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if spinnerLine.MatchString(line) || timerLine.MatchString(line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
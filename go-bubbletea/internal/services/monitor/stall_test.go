@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStallTracker(t *testing.T) {
+	start := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	type capture struct {
+		output string
+		busy   bool
+		after  time.Duration // Since start
+		want   bool
+	}
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		captures []capture
+	}{
+		{
+			name:    "unchanged busy output stalls after the timeout",
+			timeout: time.Minute,
+			captures: []capture{
+				{"⏺ Bash(make deploy)", true, 0, false},
+				{"⏺ Bash(make deploy)", true, 30 * time.Second, false},
+				{"⏺ Bash(make deploy)", true, time.Minute, true},
+				{"⏺ Bash(make deploy)", true, 2 * time.Minute, true},
+			},
+		},
+		{
+			name:    "changing output resets the clock",
+			timeout: time.Minute,
+			captures: []capture{
+				{"step 1", true, 0, false},
+				{"step 1", true, 50 * time.Second, false},
+				{"step 1\nstep 2", true, 55 * time.Second, false},
+				{"step 1\nstep 2", true, 100 * time.Second, false},
+				{"step 1\nstep 2", true, 115 * time.Second, true},
+			},
+		},
+		{
+			name:    "new output clears a stall",
+			timeout: time.Minute,
+			captures: []capture{
+				{"hung", true, 0, false},
+				{"hung", true, 2 * time.Minute, true},
+				{"hung\nback", true, 3 * time.Minute, false},
+			},
+		},
+		{
+			name:    "only busy sessions stall",
+			timeout: time.Minute,
+			captures: []capture{
+				{"Do you want to proceed? [y/n]", false, 0, false},
+				{"Do you want to proceed? [y/n]", false, 10 * time.Minute, false},
+			},
+		},
+		{
+			name:    "spinner and timer lines don't count as output",
+			timeout: time.Minute,
+			captures: []capture{
+				{"⏺ Bash(make deploy)\n✻ Deploying… (12s · esc to interrupt)", true, 0, false},
+				{"⏺ Bash(make deploy)\n✽ Deploying… (42s · esc to interrupt)", true, 30 * time.Second, false},
+				{"⏺ Bash(make deploy)\n✢ Deploying… (1m 12s · esc to interrupt)", true, time.Minute, true},
+				{"⏺ Bash(make deploy)\n  ⎿ done\n✢ Deploying… (1m 20s · esc to interrupt)", true, 70 * time.Second, false},
+			},
+		},
+		{
+			name:    "zero timeout disables detection",
+			timeout: 0,
+			captures: []capture{
+				{"hung", true, 0, false},
+				{"hung", true, time.Hour, false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newStallTracker(tt.timeout)
+			for i, c := range tt.captures {
+				if got := tracker.Observe(c.output, c.busy, start.Add(c.after)); got != c.want {
+					t.Errorf("capture %d at %s: stalled = %v, want %v", i, c.after, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package multiplexer selects the terminal multiplexer that hosts sessions.
+//
+// tmux is the default; zellij can be chosen with config.Session.Multiplexer.
+// Both backends expose the same operations so the monitor, attach hints and
+// session lifecycle don't depend on which one is running.
+package multiplexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/services/zellij"
+)
+
+// Supported multiplexer backends
+const (
+	Tmux   = "tmux"
+	Zellij = "zellij"
+)
+
+// Multiplexer defines the session operations shared by all backends
+type Multiplexer interface {
+	// NewSession creates a detached session running in workdir
+	NewSession(ctx context.Context, name string, workdir string) error
+	// HasSession reports whether a session with the given name exists
+	HasSession(ctx context.Context, name string) (bool, error)
+	// SessionExists is HasSession, but failing to run the multiplexer is
+	// an error rather than a missing session
+	SessionExists(ctx context.Context, name string) (bool, error)
+	// AttachSession attaches the current terminal to a session (blocking)
+	AttachSession(ctx context.Context, name string) error
+	// AttachCommand returns the shell command a user can run to attach
+	AttachCommand(name string) string
+	// KillSession terminates a session
+	KillSession(ctx context.Context, name string) error
+	// SendKeys types keys into a session followed by Enter
+	SendKeys(ctx context.Context, name string, keys string) error
+	// SendKey presses a single key, named the way tmux names it (such as
+	// "C-c"), without pressing Enter after it
+	SendKey(ctx context.Context, name string, key string) error
+	// CapturePane returns the last lines of a session's visible output
+	CapturePane(ctx context.Context, name string, lines int) (string, error)
+	// ListSessions returns the names of all sessions
+	ListSessions(ctx context.Context) ([]string, error)
+	// SetEnvironment sets an environment variable for a session
+	SetEnvironment(ctx context.Context, name, key, value string) error
+}
+
+// LayoutApplier is implemented by backends that can split a new session
+// into the panes of a layout template. Only tmux does.
+type LayoutApplier interface {
+	// ApplyLayout opens layout's panes beside a new session's first pane,
+	// running initCommands in the first of them
+	ApplyLayout(ctx context.Context, name, workdir string, layout tmux.Layout, initCommands []string) error
+}
+
+// Compile-time checks that both backends implement Multiplexer
+var (
+	_ Multiplexer   = (*tmux.Client)(nil)
+	_ Multiplexer   = (*zellij.Client)(nil)
+	_ LayoutApplier = (*tmux.Client)(nil)
+)
+
+// New creates the multiplexer client for the named backend.
+// An empty name selects tmux.
+func New(name string, logger *slog.Logger) (Multiplexer, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	switch name {
+	case "", Tmux:
+		return tmux.NewClient(&tmux.ExecRunner{}, logger), nil
+	case Zellij:
+		return zellij.NewClient(&zellij.ExecRunner{}, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown multiplexer %q (expected %s or %s)", name, Tmux, Zellij)
+	}
+}
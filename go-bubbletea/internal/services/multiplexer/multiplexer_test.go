@@ -0,0 +1,39 @@
+package multiplexer
+
+import (
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/services/zellij"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		want    Multiplexer
+		wantErr bool
+	}{
+		{name: "default is tmux", backend: "", want: &tmux.Client{}},
+		{name: "tmux", backend: Tmux, want: &tmux.Client{}},
+		{name: "zellij", backend: Zellij, want: &zellij.Client{}},
+		{name: "unknown", backend: "screen", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.backend, nil)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "screen")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, got)
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 
 // Position represents a computed position in the board
 type Position struct {
-	Column int  // 0=Open, 1=InProgress, 2=Blocked, 3=Done
+	Column int  // Index of the board column
 	Task   int  // Index within the column
 	Valid  bool // Whether the position is valid
 }
@@ -17,6 +17,7 @@ type Position struct {
 type Cursor struct {
 	TaskID         string // Primary state: selected task ID
 	FallbackColumn int    // Column to use when TaskID not found
+	FallbackTask   int    // Index the task was last at, to fall back near
 }
 
 // FindPosition computes the position of the cursor's task in the given columns
@@ -42,13 +43,15 @@ func (c *Cursor) FindPosition(columns []board.Column) Position {
 		}
 	}
 
-	// Task not found (filtered out?), use fallback
+	// Task not found (filtered out or gone), use the task now nearest where
+	// it was
 	col := c.FallbackColumn
 	if col >= len(columns) {
 		col = 0
 	}
 	if col < len(columns) && len(columns[col].Tasks) > 0 {
-		return Position{Column: col, Task: 0, Valid: true}
+		task := max(min(c.FallbackTask, len(columns[col].Tasks)-1), 0)
+		return Position{Column: col, Task: task, Valid: true}
 	}
 	return Position{Column: col, Task: 0, Valid: false}
 }
@@ -80,6 +83,7 @@ func (c *Cursor) MoveVertical(columns []board.Column, delta int) string {
 	if newIdx >= 0 && newIdx < len(col.Tasks) {
 		c.TaskID = col.Tasks[newIdx].ID
 		c.FallbackColumn = pos.Column
+		c.FallbackTask = newIdx
 	}
 	return c.TaskID
 }
@@ -105,6 +109,7 @@ func (c *Cursor) MoveHorizontal(columns []board.Column, delta int) string {
 			taskIdx = len(columns[newCol].Tasks) - 1
 		}
 		c.TaskID = columns[newCol].Tasks[taskIdx].ID
+		c.FallbackTask = taskIdx
 	} else {
 		c.TaskID = "" // No task in new column
 	}
@@ -116,6 +121,7 @@ func (c *Cursor) JumpToStart(columns []board.Column) string {
 	pos := c.FindPosition(columns)
 	if pos.Column < len(columns) && len(columns[pos.Column].Tasks) > 0 {
 		c.TaskID = columns[pos.Column].Tasks[0].ID
+		c.FallbackTask = 0
 	}
 	return c.TaskID
 }
@@ -127,6 +133,7 @@ func (c *Cursor) JumpToEnd(columns []board.Column) string {
 		col := columns[pos.Column]
 		if len(col.Tasks) > 0 {
 			c.TaskID = col.Tasks[len(col.Tasks)-1].ID
+			c.FallbackTask = len(col.Tasks) - 1
 		}
 	}
 	return c.TaskID
@@ -151,6 +158,7 @@ func (c *Cursor) JumpToColumn(columns []board.Column, colIdx int) string {
 			taskIdx = len(columns[colIdx].Tasks) - 1
 		}
 		c.TaskID = columns[colIdx].Tasks[taskIdx].ID
+		c.FallbackTask = taskIdx
 	} else {
 		c.TaskID = "" // No task in target column
 	}
@@ -160,12 +168,17 @@ func (c *Cursor) JumpToColumn(columns []board.Column, colIdx int) string {
 // Service manages navigation state
 type Service struct {
 	cursor Cursor
+
+	// Index of the first card drawn in each column, by the column's status
+	// so it follows the column through focus and paging
+	offsets map[domain.Status]int
 }
 
 // NewService creates a new navigation service
 func NewService() *Service {
 	return &Service{
-		cursor: Cursor{},
+		cursor:  Cursor{},
+		offsets: make(map[domain.Status]int),
 	}
 }
 
@@ -197,17 +210,11 @@ func (s *Service) GetCurrentTask(columns []board.Column) (*domain.Task, *domain.
 
 // GetCurrentStatus returns the status for the current column
 func (s *Service) GetCurrentStatus(columns []board.Column) domain.Status {
-	statuses := []domain.Status{
-		domain.StatusOpen,
-		domain.StatusInProgress,
-		domain.StatusBlocked,
-		domain.StatusDone,
-	}
 	pos := s.cursor.FindPosition(columns)
-	if pos.Column < 0 || pos.Column >= len(statuses) {
+	if pos.Column < 0 || pos.Column >= len(columns) {
 		return domain.StatusOpen
 	}
-	return statuses[pos.Column]
+	return columns[pos.Column].Status
 }
 
 // MoveDown moves cursor down in current column
@@ -230,16 +237,104 @@ func (s *Service) MoveRight(columns []board.Column) {
 	s.cursor.MoveHorizontal(columns, 1)
 }
 
-// HalfPageDown moves cursor half a page down
+// MoveLeftSkipEmpty moves cursor to the nearest column on the left with
+// tasks, skipping empty ones. It returns false, leaving the cursor, if
+// there's none.
+func (s *Service) MoveLeftSkipEmpty(columns []board.Column) bool {
+	return s.moveSkipEmpty(columns, -1)
+}
+
+// MoveRightSkipEmpty moves cursor to the nearest column on the right with
+// tasks, skipping empty ones. It returns false, leaving the cursor, if
+// there's none.
+func (s *Service) MoveRightSkipEmpty(columns []board.Column) bool {
+	return s.moveSkipEmpty(columns, 1)
+}
+
+// moveSkipEmpty moves cursor to the first column with tasks from the
+// current one in direction delta
+func (s *Service) moveSkipEmpty(columns []board.Column, delta int) bool {
+	pos := s.cursor.FindPosition(columns)
+	for col := pos.Column + delta; col >= 0 && col < len(columns); col += delta {
+		if len(columns[col].Tasks) > 0 {
+			s.cursor.JumpToColumn(columns, col)
+			return true
+		}
+	}
+	return false
+}
+
+// HalfPageDown moves cursor half a page down, scrolling the column with it
 func (s *Service) HalfPageDown(columns []board.Column, halfPage int) {
+	s.scrollColumn(columns, halfPage)
 	s.cursor.MoveVertical(columns, halfPage)
 }
 
-// HalfPageUp moves cursor half a page up
+// HalfPageUp moves cursor half a page up, scrolling the column with it
 func (s *Service) HalfPageUp(columns []board.Column, halfPage int) {
+	s.scrollColumn(columns, -halfPage)
 	s.cursor.MoveVertical(columns, -halfPage)
 }
 
+// scrollColumn moves the scroll of the cursor's column by delta cards. The
+// next ScrollIntoView clamps it to the column.
+func (s *Service) scrollColumn(columns []board.Column, delta int) {
+	pos := s.cursor.FindPosition(columns)
+	if !pos.Valid || pos.Column >= len(columns) {
+		return
+	}
+	status := columns[pos.Column].Status
+	s.offsets[status] = max(s.offsets[status]+delta, 0)
+}
+
+// Resolve re-finds the cursor's task in columns rebuilt after a refresh or
+// filter change, and remembers where it is now. The same task stays
+// selected wherever it moved. While it's filtered out or gone the cursor
+// falls back to the task nearest where it was last found, and returns to
+// the task if it comes back.
+func (s *Service) Resolve(columns []board.Column) Position {
+	pos := s.cursor.FindPosition(columns)
+	if pos.Valid && columns[pos.Column].Tasks[pos.Task].ID == s.cursor.TaskID {
+		s.cursor.FallbackColumn = pos.Column
+		s.cursor.FallbackTask = pos.Task
+	}
+	return pos
+}
+
+// Offset returns the index of the first card drawn in the column for status
+func (s *Service) Offset(status domain.Status) int {
+	return s.offsets[status]
+}
+
+// ScrollIntoView scrolls each column as little as needed to keep the cursor
+// card in view on a board drawn at height, recording the scroll and setting
+// it as each column's Offset for rendering
+func (s *Service) ScrollIntoView(columns []board.Column, height int) {
+	pos := s.cursor.FindPosition(columns)
+	viewHeight := board.ColumnViewHeight(height)
+	for i := range columns {
+		if columns[i].Collapsed {
+			// No cards drawn; keep its scroll for when it's expanded
+			continue
+		}
+		cursorTask := -1
+		if pos.Valid && i == pos.Column {
+			cursorTask = pos.Task
+		}
+		offset := board.ScrollOffset(columns[i].Tasks, s.offsets[columns[i].Status], cursorTask, viewHeight)
+		s.offsets[columns[i].Status] = offset
+		columns[i].Offset = offset
+	}
+}
+
+// ApplyOffsets sets each column's Offset for rendering to the scroll last
+// recorded by ScrollIntoView, without changing it
+func (s *Service) ApplyOffsets(columns []board.Column) {
+	for i := range columns {
+		columns[i].Offset = s.offsets[columns[i].Status]
+	}
+}
+
 // GotoTop moves cursor to first task in column
 func (s *Service) GotoTop(columns []board.Column) {
 	s.cursor.JumpToStart(columns)
@@ -269,9 +364,10 @@ func (s *Service) SelectTask(taskID string, column int) {
 func (s *Service) JumpToTaskByIndex(columns []board.Column, flatIndex int) bool {
 	currentIndex := 0
 	for colIdx, col := range columns {
-		for _, task := range col.Tasks {
+		for taskIdx, task := range col.Tasks {
 			if currentIndex == flatIndex {
 				s.cursor.SetTask(task.ID, colIdx)
+				s.cursor.FallbackTask = taskIdx
 				return true
 			}
 			currentIndex++
@@ -283,9 +379,10 @@ func (s *Service) JumpToTaskByIndex(columns []board.Column, flatIndex int) bool
 // JumpToTaskByID finds and selects a task by ID
 func (s *Service) JumpToTaskByID(columns []board.Column, taskID string) bool {
 	for colIdx, col := range columns {
-		for _, task := range col.Tasks {
+		for taskIdx, task := range col.Tasks {
 			if task.ID == taskID {
 				s.cursor.SetTask(task.ID, colIdx)
+				s.cursor.FallbackTask = taskIdx
 				return true
 			}
 		}
@@ -1,6 +1,7 @@
 package navigation
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
@@ -10,26 +11,30 @@ import (
 func makeTestColumns() []board.Column {
 	return []board.Column{
 		{
-			Title: "Open",
+			Title:  "Open",
+			Status: domain.StatusOpen,
 			Tasks: []domain.Task{
 				{ID: "az-1", Title: "Task 1", Status: domain.StatusOpen},
 				{ID: "az-2", Title: "Task 2", Status: domain.StatusOpen},
 			},
 		},
 		{
-			Title: "In Progress",
+			Title:  "In Progress",
+			Status: domain.StatusInProgress,
 			Tasks: []domain.Task{
 				{ID: "az-3", Title: "Task 3", Status: domain.StatusInProgress},
 			},
 		},
 		{
-			Title: "Blocked",
+			Title:  "Blocked",
+			Status: domain.StatusBlocked,
 			Tasks: []domain.Task{
 				{ID: "az-4", Title: "Task 4", Status: domain.StatusBlocked},
 			},
 		},
 		{
-			Title: "Done",
+			Title:  "Done",
+			Status: domain.StatusDone,
 			Tasks: []domain.Task{
 				{ID: "az-5", Title: "Task 5", Status: domain.StatusDone},
 			},
@@ -133,6 +138,50 @@ func TestService_MoveLeftRight(t *testing.T) {
 	}
 }
 
+func TestService_MoveSkipEmpty(t *testing.T) {
+	svc := NewService()
+	columns := makeTestColumns()
+	// A filter has emptied In Progress and Blocked
+	columns[1].Tasks = nil
+	columns[2].Tasks = nil
+
+	svc.SelectTask("az-2", 0)
+
+	if !svc.MoveRightSkipEmpty(columns) {
+		t.Fatal("Expected a column with tasks to the right")
+	}
+	if task, _ := svc.GetCurrentTask(columns); task == nil || task.ID != "az-5" {
+		t.Errorf("Expected to skip to az-5 in Done, got %v", task)
+	}
+
+	// Nothing further right: the cursor stays
+	if svc.MoveRightSkipEmpty(columns) {
+		t.Error("Expected no column with tasks right of Done")
+	}
+	if pos := svc.GetPosition(columns); pos.Column != 3 {
+		t.Errorf("Expected to stay in column 3, got %d", pos.Column)
+	}
+
+	if !svc.MoveLeftSkipEmpty(columns) {
+		t.Fatal("Expected a column with tasks to the left")
+	}
+	if pos := svc.GetPosition(columns); pos.Column != 0 {
+		t.Errorf("Expected to skip back to column 0, got %d", pos.Column)
+	}
+
+	// Plain movement still stops on empty columns
+	svc.MoveRight(columns)
+	if pos := svc.GetPosition(columns); pos.Column != 1 {
+		t.Errorf("Expected MoveRight to stop on the empty column 1, got %d", pos.Column)
+	}
+	if !svc.MoveRightSkipEmpty(columns) {
+		t.Fatal("Expected to skip on from an empty column")
+	}
+	if pos := svc.GetPosition(columns); pos.Column != 3 {
+		t.Errorf("Expected column 3 from the empty column, got %d", pos.Column)
+	}
+}
+
 func TestService_GotoTopBottom(t *testing.T) {
 	svc := NewService()
 	columns := makeTestColumns()
@@ -301,6 +350,114 @@ func TestService_HalfPageScroll(t *testing.T) {
 	}
 }
 
+func TestService_HalfPageScrollsColumn(t *testing.T) {
+	tasks := make([]domain.Task, 20)
+	for i := range tasks {
+		tasks[i] = domain.Task{ID: fmt.Sprintf("t-%d", i)}
+	}
+	columns := []board.Column{{Title: "Open", Status: domain.StatusOpen, Tasks: tasks}}
+	const height = 24 // Four cards in view
+
+	svc := NewService()
+	svc.SelectTask("t-1", 0)
+	svc.ScrollIntoView(columns, height)
+
+	// The column scrolls with the cursor, which keeps its row on screen
+	svc.HalfPageDown(columns, 2)
+	svc.ScrollIntoView(columns, height)
+	if pos := svc.GetPosition(columns); pos.Task != 3 {
+		t.Errorf("Expected task 3, got %d", pos.Task)
+	}
+	if offset := svc.Offset(domain.StatusOpen); offset != 2 {
+		t.Errorf("Expected the column scrolled to 2, got %d", offset)
+	}
+
+	svc.HalfPageUp(columns, 2)
+	svc.ScrollIntoView(columns, height)
+	if offset := svc.Offset(domain.StatusOpen); offset != 0 {
+		t.Errorf("Expected the column scrolled back to 0, got %d", offset)
+	}
+}
+
+func TestService_ScrollIntoView(t *testing.T) {
+	tasks := make([]domain.Task, 10)
+	for i := range tasks {
+		tasks[i] = domain.Task{ID: fmt.Sprintf("t-%d", i)}
+	}
+	columns := []board.Column{
+		{Title: "Open", Status: domain.StatusOpen, Tasks: tasks},
+		{Title: "Done", Status: domain.StatusDone, Collapsed: true, Hidden: 3},
+	}
+	const height = 24 // Four cards in view
+
+	svc := NewService()
+	svc.SelectTask("t-5", 0)
+	svc.ScrollIntoView(columns, height)
+	if columns[0].Offset != 2 || svc.Offset(domain.StatusOpen) != 2 {
+		t.Errorf("Expected the column scrolled to 2 to show t-5 last, got %d (recorded %d)", columns[0].Offset, svc.Offset(domain.StatusOpen))
+	}
+
+	// Moving within the view doesn't scroll
+	svc.MoveUp(columns)
+	svc.MoveUp(columns)
+	svc.ScrollIntoView(columns, height)
+	if offset := svc.Offset(domain.StatusOpen); offset != 2 {
+		t.Errorf("Expected the column to stay at 2, got %d", offset)
+	}
+
+	// Moving above it scrolls up to the cursor
+	svc.GotoTop(columns)
+	svc.ScrollIntoView(columns, height)
+	if offset := svc.Offset(domain.StatusOpen); offset != 0 {
+		t.Errorf("Expected the column scrolled to 0, got %d", offset)
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	column := func(ids ...string) []board.Column {
+		var tasks []domain.Task
+		for _, id := range ids {
+			tasks = append(tasks, domain.Task{ID: id})
+		}
+		return []board.Column{{Title: "Open", Status: domain.StatusOpen, Tasks: tasks}}
+	}
+
+	svc := NewService()
+	columns := column("t-1", "t-2", "t-3", "t-4")
+	svc.SelectTask("t-3", 0)
+	svc.Resolve(columns)
+
+	// A task inserted above keeps the same task selected
+	columns = column("t-0", "t-1", "t-2", "t-3", "t-4")
+	pos := svc.Resolve(columns)
+	if svc.GetCursor().TaskID != "t-3" || pos.Task != 3 {
+		t.Errorf("Expected t-3 still selected at 3, got %s at %d", svc.GetCursor().TaskID, pos.Task)
+	}
+
+	// Filtered out, the cursor falls back to the task now where it was
+	// rather than the top of the column
+	columns = column("t-0", "t-1", "t-2", "t-4")
+	pos = svc.Resolve(columns)
+	if !pos.Valid || pos.Task != 3 {
+		t.Errorf("Expected fallback to index 3, got %+v", pos)
+	}
+	if task, _ := svc.GetCurrentTask(columns); task == nil || task.ID != "t-4" {
+		t.Errorf("Expected t-4 under the cursor, got %v", task)
+	}
+
+	// Fewer tasks than that: the nearest is the last
+	columns = column("t-0", "t-1")
+	if pos = svc.Resolve(columns); pos.Task != 1 {
+		t.Errorf("Expected fallback to the last task, got %d", pos.Task)
+	}
+
+	// Back again, the task is selected once more
+	columns = column("t-0", "t-1", "t-2", "t-3", "t-4")
+	if pos = svc.Resolve(columns); pos.Task != 3 {
+		t.Errorf("Expected t-3 back at 3, got %d", pos.Task)
+	}
+}
+
 func TestCursor_EmptyColumns(t *testing.T) {
 	columns := []board.Column{
 		{Title: "Empty", Tasks: []domain.Task{}},
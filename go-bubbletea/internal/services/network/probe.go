@@ -0,0 +1,138 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Check methods a StatusChecker can probe its target with
+const (
+	MethodHead = "head" // HTTP HEAD request to the URL
+	MethodTCP  = "tcp"  // TCP connect to the URL's host and port
+	MethodDNS  = "dns"  // DNS lookup of the URL's host
+)
+
+// DefaultCheckURL is what connectivity is checked against unless configured
+const DefaultCheckURL = "https://github.com"
+
+// Probe makes one connectivity check, returning nil if the target answered
+type Probe interface {
+	Probe(ctx context.Context) error
+}
+
+// Result is how a connectivity check turned out
+type Result int
+
+const (
+	ResultOnline Result = iota
+	ResultOffline
+	ResultTimeout
+)
+
+// String returns a human-readable result
+func (r Result) String() string {
+	switch r {
+	case ResultOnline:
+		return "online"
+	case ResultTimeout:
+		return "timed out"
+	default:
+		return "offline"
+	}
+}
+
+// classify turns a probe's error into a check result
+func classify(err error) Result {
+	if err == nil {
+		return ResultOnline
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ResultTimeout
+	}
+	return ResultOffline
+}
+
+// HTTPProbe sends a HEAD request to URL; any 2xx or 3xx response is online
+type HTTPProbe struct {
+	URL    string
+	Client *http.Client
+}
+
+// Probe sends the HEAD request
+func (p HTTPProbe) Probe(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("HEAD %s: %s", p.URL, resp.Status)
+	}
+	return nil
+}
+
+// TCPProbe opens and closes a TCP connection to Address, a host:port
+type TCPProbe struct {
+	Address string
+}
+
+// Probe connects to the address
+func (p TCPProbe) Probe(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.Address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// DNSProbe looks up Host, which only needs a reachable resolver
+type DNSProbe struct {
+	Host string
+}
+
+// Probe resolves the host
+func (p DNSProbe) Probe(ctx context.Context) error {
+	_, err := net.DefaultResolver.LookupHost(ctx, p.Host)
+	return err
+}
+
+// NewProbe creates a probe checking checkURL by method, one of MethodHead,
+// MethodTCP or MethodDNS. HEAD requests are sent with client.
+func NewProbe(method, checkURL string, client *http.Client) (Probe, error) {
+	u, err := url.Parse(checkURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check URL %q: %w", checkURL, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("check URL %q has no host", checkURL)
+	}
+
+	switch method {
+	case MethodHead:
+		return HTTPProbe{URL: checkURL, Client: client}, nil
+	case MethodTCP:
+		port := u.Port()
+		if port == "" {
+			port = "443"
+			if u.Scheme == "http" {
+				port = "80"
+			}
+		}
+		return TCPProbe{Address: net.JoinHostPort(u.Hostname(), port)}, nil
+	case MethodDNS:
+		return DNSProbe{Host: u.Hostname()}, nil
+	default:
+		return nil, fmt.Errorf("unknown check method %q", method)
+	}
+}
@@ -0,0 +1,125 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubProbe answers every check with err, or waits out the check's
+// deadline if hang is set
+type stubProbe struct {
+	err  error
+	hang bool
+}
+
+func (p stubProbe) Probe(ctx context.Context) error {
+	if p.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return p.err
+}
+
+func TestCheck_ClassifiesProbeResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		probe      stubProbe
+		wantOnline bool
+		wantResult Result
+	}{
+		{"answered", stubProbe{}, true, ResultOnline},
+		{"refused", stubProbe{err: errors.New("connection refused")}, false, ResultOffline},
+		{"timed out", stubProbe{hang: true}, false, ResultTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewStatusCheckerWithProbe(tt.probe)
+			checker.timeout = 10 * time.Millisecond
+
+			assert.Equal(t, tt.wantOnline, checker.Check(context.Background()))
+			assert.Equal(t, tt.wantOnline, checker.IsOnline())
+			assert.Equal(t, tt.wantResult, checker.LastResult())
+
+			history := checker.LatencyHistory()
+			require.Len(t, history, 1)
+			assert.Equal(t, tt.wantOnline, history[0] != Offline)
+		})
+	}
+}
+
+func TestClassify_NetTimeout(t *testing.T) {
+	err := &net.OpError{Op: "dial", Err: timeoutError{}}
+	assert.Equal(t, ResultTimeout, classify(err))
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestNewProbe(t *testing.T) {
+	client := &http.Client{}
+	tests := []struct {
+		name     string
+		method   string
+		checkURL string
+		want     Probe
+	}{
+		{"head", MethodHead, "https://example.com/health", HTTPProbe{URL: "https://example.com/health", Client: client}},
+		{"tcp on the https port", MethodTCP, "https://example.com", TCPProbe{Address: "example.com:443"}},
+		{"tcp on the http port", MethodTCP, "http://example.com", TCPProbe{Address: "example.com:80"}},
+		{"tcp on an explicit port", MethodTCP, "https://proxy.corp:8443", TCPProbe{Address: "proxy.corp:8443"}},
+		{"dns", MethodDNS, "https://example.com/health", DNSProbe{Host: "example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe, err := NewProbe(tt.method, tt.checkURL, client)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, probe)
+		})
+	}
+
+	_, err := NewProbe("icmp", DefaultCheckURL, client)
+	assert.ErrorContains(t, err, "unknown check method")
+
+	_, err = NewProbe(MethodHead, "github.com", client)
+	assert.ErrorContains(t, err, "has no host")
+}
+
+func TestHTTPProbe(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	probe := HTTPProbe{URL: server.URL, Client: server.Client()}
+	assert.NoError(t, probe.Probe(context.Background()))
+
+	status = http.StatusServiceUnavailable
+	assert.Error(t, probe.Probe(context.Background()))
+}
+
+func TestTCPProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	assert.NoError(t, TCPProbe{Address: addr}.Probe(context.Background()))
+
+	// Nothing listening once it's closed
+	require.NoError(t, listener.Close())
+	assert.Error(t, TCPProbe{Address: addr}.Probe(context.Background()))
+}
@@ -9,12 +9,28 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// latencyHistorySize is how many recent checks the latency history keeps
+const latencyHistorySize = 30
+
+// Offline marks a check in the latency history that failed, leaving a gap
+const Offline time.Duration = -1
+
 // StatusChecker monitors network connectivity status
 type StatusChecker struct {
 	mu        sync.RWMutex
 	isOnline  bool
 	lastCheck time.Time
 	client    *http.Client
+
+	// What each check asks, how long it waits, and how the last one went
+	probe      Probe
+	timeout    time.Duration
+	lastResult Result
+
+	// Ring of the latest check latencies; next is where the next one goes
+	latencies [latencyHistorySize]time.Duration
+	next      int
+	samples   int
 }
 
 // StatusMsg is sent when the network status changes
@@ -22,42 +38,94 @@ type StatusMsg struct {
 	Online bool
 }
 
-// NewStatusChecker creates a new network status checker
+// checkTimeout is how long a check waits for its target before it counts
+// as timed out
+const checkTimeout = 5 * time.Second
+
+// NewStatusChecker creates a new network status checker sending HEAD
+// requests to DefaultCheckURL
 func NewStatusChecker() *StatusChecker {
+	s := newStatusChecker()
+	s.probe = HTTPProbe{URL: DefaultCheckURL, Client: s.client}
+	return s
+}
+
+// NewStatusCheckerFor creates a network status checker probing checkURL by
+// method, one of MethodHead, MethodTCP or MethodDNS
+func NewStatusCheckerFor(method, checkURL string) (*StatusChecker, error) {
+	s := newStatusChecker()
+	probe, err := NewProbe(method, checkURL, s.client)
+	if err != nil {
+		return nil, err
+	}
+	s.probe = probe
+	return s, nil
+}
+
+// NewStatusCheckerWithProbe creates a network status checker asking probe
+func NewStatusCheckerWithProbe(probe Probe) *StatusChecker {
+	s := newStatusChecker()
+	s.probe = probe
+	return s
+}
+
+// Reconfigure makes later checks probe checkURL by method, as
+// NewStatusCheckerFor does. On an error the checker is left as it was.
+func (s *StatusChecker) Reconfigure(method, checkURL string) error {
+	probe, err := NewProbe(method, checkURL, s.client)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probe = probe
+	return nil
+}
+
+func newStatusChecker() *StatusChecker {
 	return &StatusChecker{
 		isOnline: true, // Optimistically assume online
 		client: &http.Client{
-			Timeout: 5 * time.Second,
+			Timeout: checkTimeout,
 			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
 				DisableKeepAlives: true,
 			},
 		},
+		timeout: checkTimeout,
 	}
 }
 
-// Check performs a connectivity check to github.com
-// Returns true if online, false if offline
+// Check probes the configured target, waiting at most the check timeout.
+// Returns true if online, false if offline or timed out.
 func (s *StatusChecker) Check(ctx context.Context) bool {
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, "https://github.com", nil)
-	if err != nil {
-		s.setOnline(false)
-		return false
-	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		s.setOnline(false)
-		return false
-	}
-	defer resp.Body.Close()
+	s.mu.RLock()
+	probe := s.probe
+	s.mu.RUnlock()
 
-	// Any 2xx or 3xx response means we're online
-	online := resp.StatusCode >= 200 && resp.StatusCode < 400
+	start := time.Now()
+	result := classify(probe.Probe(ctx))
+	latency := time.Since(start)
 
-	s.setOnline(online)
+	s.mu.Lock()
+	s.lastResult = result
+	s.mu.Unlock()
+
+	online := result == ResultOnline
+	s.record(online, latency)
 	return online
 }
 
+// LastResult returns how the last connectivity check turned out
+func (s *StatusChecker) LastResult() Result {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastResult
+}
+
 // IsOnline returns the cached online status
 func (s *StatusChecker) IsOnline() bool {
 	s.mu.RLock()
@@ -72,6 +140,20 @@ func (s *StatusChecker) LastCheck() time.Time {
 	return s.lastCheck
 }
 
+// LatencyHistory returns the latencies of the latest checks, oldest first.
+// Checks that failed are Offline.
+func (s *StatusChecker) LatencyHistory() []time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := make([]time.Duration, 0, s.samples)
+	start := (s.next - s.samples + latencyHistorySize) % latencyHistorySize
+	for i := 0; i < s.samples; i++ {
+		history = append(history, s.latencies[(start+i)%latencyHistorySize])
+	}
+	return history
+}
+
 // setOnline updates the cached online status
 func (s *StatusChecker) setOnline(online bool) {
 	s.mu.Lock()
@@ -80,6 +162,22 @@ func (s *StatusChecker) setOnline(online bool) {
 	s.lastCheck = time.Now()
 }
 
+// record updates the cached online status and adds a check taking latency
+// to the history, as a gap if it failed
+func (s *StatusChecker) record(online bool, latency time.Duration) {
+	if !online {
+		latency = Offline
+	}
+
+	s.setOnline(online)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[s.next] = latency
+	s.next = (s.next + 1) % latencyHistorySize
+	s.samples = min(s.samples+1, latencyHistorySize)
+}
+
 // StartMonitoring begins polling network status at the specified interval
 // Sends StatusMsg to the program when status changes
 func (s *StatusChecker) StartMonitoring(ctx context.Context, program *tea.Program, interval time.Duration) {
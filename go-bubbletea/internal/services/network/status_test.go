@@ -159,3 +159,23 @@ func TestCheckCmd(t *testing.T) {
 	// Just verify the message type is correct
 	_ = statusMsg.Online
 }
+
+func TestLatencyHistory(t *testing.T) {
+	checker := NewStatusChecker()
+	assert.Empty(t, checker.LatencyHistory())
+
+	checker.record(true, 20*time.Millisecond)
+	checker.record(false, 5*time.Second)
+	checker.record(true, 40*time.Millisecond)
+	assert.Equal(t, []time.Duration{20 * time.Millisecond, Offline, 40 * time.Millisecond}, checker.LatencyHistory())
+	assert.True(t, checker.IsOnline())
+
+	// The ring keeps only the latest checks, oldest first
+	for i := 1; i <= latencyHistorySize+5; i++ {
+		checker.record(true, time.Duration(i)*time.Millisecond)
+	}
+	history := checker.LatencyHistory()
+	require.Len(t, history, latencyHistorySize)
+	assert.Equal(t, 6*time.Millisecond, history[0])
+	assert.Equal(t, time.Duration(latencyHistorySize+5)*time.Millisecond, history[len(history)-1])
+}
@@ -0,0 +1,397 @@
+package planning
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// Backlog defaults for fields a backlog file leaves out
+const (
+	backlogDefaultType     = domain.TypeTask
+	backlogDefaultPriority = int(domain.P2)
+)
+
+// backlogTaskTypes are the types a backlog task may have. Unlike a plan's
+// tasks, a backlog may hold its own epics.
+var backlogTaskTypes = map[domain.TaskType]bool{
+	domain.TypeTask:    true,
+	domain.TypeBug:     true,
+	domain.TypeFeature: true,
+	domain.TypeChore:   true,
+	domain.TypeEpic:    true,
+}
+
+// backlogTask is a task as written in a JSON backlog. Priority is a pointer
+// so that a missing one takes the default rather than P0.
+type backlogTask struct {
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Type        domain.TaskType  `json:"type"`
+	Priority    *backlogPriority `json:"priority"`
+	DependsOn   []string         `json:"dependsOn"`
+	Depends     []string         `json:"depends"` // Same as DependsOn, as in markdown
+	Design      string           `json:"design"`
+	Acceptance  string           `json:"acceptance"`
+}
+
+// backlogPriority is a JSON backlog priority: a number (0-4) or, as a
+// board export writes it, a string ("P0"-"P4")
+type backlogPriority int
+
+// UnmarshalJSON reads a priority number or string
+func (p *backlogPriority) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		*p = backlogPriority(number)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("priority must be a number or P0-P4, got %s", data)
+	}
+	priority, err := domain.ParsePriority(text)
+	if err != nil {
+		return err
+	}
+	*p = backlogPriority(priority)
+	return nil
+}
+
+// exportHeader is the table header an exported markdown board gives each
+// column, which marks a file as an export rather than a backlog
+var exportHeader = []string{"id", "title", "priority", "status"}
+
+// ParseBacklog reads the tasks in a backlog file, JSON when path ends in
+// .json and markdown otherwise. Missing IDs default to the title, types to
+// task and priorities to P2, and dependencies naming a task by title are
+// resolved to its ID. The tasks are validated but may still depend on each
+// other in a cycle; BacklogOrder breaks those.
+//
+// A JSON backlog is an array of tasks, or an object with a "tasks" array,
+// each with a title and optionally an id, description, type, priority
+// (0-4 or P0-P4), dependsOn (or depends), design and acceptance. A board
+// exported with `az export --format json` is read as a backlog of the
+// tasks in all its columns.
+//
+// A markdown backlog has a "## Title" heading per task. Lines straight
+// after it may set "id:", "type:", "priority:" (P0-P4) and "dependsOn:"
+// (or "depends:", a comma separated list), optionally as list items; the
+// text after them is the description. Anything before the first heading is
+// ignored. A board exported as markdown is rejected, as its column headings
+// would read as tasks.
+func ParseBacklog(path string, data []byte) ([]domain.PlannedTask, error) {
+	var tasks []domain.PlannedTask
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		tasks, err = parseJSONBacklog(data)
+	} else {
+		tasks, err = parseMarkdownBacklog(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolveBacklogReferences(tasks)
+	if err := validateBacklog(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// parseJSONBacklog reads a JSON array of tasks or an object holding one
+func parseJSONBacklog(data []byte) ([]domain.PlannedTask, error) {
+	var raw []backlogTask
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var doc struct {
+			Tasks   []backlogTask `json:"tasks"`
+			Columns []struct {
+				Tasks []backlogTask `json:"tasks"`
+			} `json:"columns"` // A board export
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse backlog JSON: %w", err)
+		}
+		raw = doc.Tasks
+		for _, column := range doc.Columns {
+			raw = append(raw, column.Tasks...)
+		}
+	} else if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse backlog JSON: %w", err)
+	}
+
+	tasks := make([]domain.PlannedTask, len(raw))
+	for i, task := range raw {
+		priority := backlogDefaultPriority
+		if task.Priority != nil {
+			priority = int(*task.Priority)
+		}
+		tasks[i] = withBacklogDefaults(domain.PlannedTask{
+			ID:          task.ID,
+			Title:       task.Title,
+			Description: task.Description,
+			Type:        task.Type,
+			Priority:    priority,
+			DependsOn:   append(task.DependsOn, task.Depends...),
+			Design:      task.Design,
+			Acceptance:  task.Acceptance,
+		})
+	}
+	return tasks, nil
+}
+
+// parseMarkdownBacklog reads a task per "## " heading
+func parseMarkdownBacklog(data []byte) ([]domain.PlannedTask, error) {
+	var tasks []domain.PlannedTask
+	var description []string
+	inFields := false
+
+	finish := func() {
+		if len(tasks) > 0 {
+			tasks[len(tasks)-1].Description = strings.TrimSpace(strings.Join(description, "\n"))
+		}
+		description = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		if isExportHeader(text) {
+			return nil, fmt.Errorf(`line %d: this looks like a board exported as markdown, whose columns would read as tasks; export it with "az export --format json" and import that instead`, line)
+		}
+		if title, ok := strings.CutPrefix(text, "## "); ok {
+			finish()
+			tasks = append(tasks, domain.PlannedTask{
+				Title:    strings.TrimSpace(title),
+				Priority: backlogDefaultPriority,
+			})
+			inFields = true
+			continue
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		if inFields {
+			key, value, ok := backlogField(text)
+			if ok {
+				if err := setBacklogField(&tasks[len(tasks)-1], key, value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				continue
+			}
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			inFields = false
+		}
+		description = append(description, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read backlog: %w", err)
+	}
+	finish()
+
+	for i := range tasks {
+		tasks[i] = withBacklogDefaults(tasks[i])
+	}
+	return tasks, nil
+}
+
+// backlogField splits a "key: value" line, optionally a list item, if key
+// is a field a markdown backlog task may set
+func backlogField(line string) (key, value string, ok bool) {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	switch key {
+	case "id", "type", "priority", "depends", "dependson":
+		return key, strings.TrimSpace(value), true
+	}
+	return "", "", false
+}
+
+// isExportHeader reports whether line is the header of a task table in an
+// exported markdown board
+func isExportHeader(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "|") || !strings.HasSuffix(line, "|") {
+		return false
+	}
+	cells := strings.Split(strings.Trim(line, "|"), "|")
+	if len(cells) != len(exportHeader) {
+		return false
+	}
+	for i, cell := range cells {
+		if strings.ToLower(strings.TrimSpace(cell)) != exportHeader[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setBacklogField sets a field of task from a markdown backlog
+func setBacklogField(task *domain.PlannedTask, key, value string) error {
+	switch key {
+	case "id":
+		task.ID = value
+	case "type":
+		task.Type = domain.TaskType(strings.ToLower(value))
+	case "priority":
+		priority, err := domain.ParsePriority(value)
+		if err != nil {
+			return err
+		}
+		task.Priority = int(priority)
+	case "depends", "dependson":
+		for _, dep := range strings.Split(value, ",") {
+			if dep = strings.TrimSpace(dep); dep != "" {
+				task.DependsOn = append(task.DependsOn, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// withBacklogDefaults fills in the ID and type when the backlog left them
+// out
+func withBacklogDefaults(task domain.PlannedTask) domain.PlannedTask {
+	task.Title = strings.TrimSpace(task.Title)
+	if task.ID == "" {
+		task.ID = task.Title
+	}
+	if task.Type == "" {
+		task.Type = backlogDefaultType
+	}
+	return task
+}
+
+// resolveBacklogReferences rewrites dependencies that name a task by its
+// title, ignoring case, to that task's ID. IDs win over titles, and titles
+// shared by several tasks are left for validation to report.
+func resolveBacklogReferences(tasks []domain.PlannedTask) {
+	ids := planTaskIDs(tasks)
+	byTitle := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		title := strings.ToLower(task.Title)
+		if _, seen := byTitle[title]; seen {
+			byTitle[title] = ""
+			continue
+		}
+		byTitle[title] = task.ID
+	}
+
+	for i := range tasks {
+		for j, dep := range tasks[i].DependsOn {
+			if ids[dep] {
+				continue
+			}
+			if id := byTitle[strings.ToLower(dep)]; id != "" {
+				tasks[i].DependsOn[j] = id
+			}
+		}
+	}
+}
+
+// validateBacklog checks a parsed backlog: at least one task, titles,
+// unique IDs, priorities 0-4, known types and dependencies on tasks in the
+// backlog. It returns an error listing every violation, or nil.
+func validateBacklog(tasks []domain.PlannedTask) error {
+	var violations []string
+	add := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if len(tasks) == 0 {
+		add("backlog has no tasks")
+	}
+
+	ids := make(map[string]bool, len(tasks))
+	for i, task := range tasks {
+		name := task.ID
+		if name == "" {
+			name = fmt.Sprintf("%d", i+1)
+		}
+
+		if task.Title == "" {
+			add("task %s has no title", name)
+		}
+		if ids[task.ID] {
+			add("task id %q is used more than once", task.ID)
+		}
+		ids[task.ID] = true
+		if task.Priority < int(domain.P0) || task.Priority > int(domain.P4) {
+			add("task %s has priority %d, want 0-4", name, task.Priority)
+		}
+		if !backlogTaskTypes[task.Type] {
+			add("task %s has unknown type %q, want task, bug, feature, chore or epic", name, task.Type)
+		}
+	}
+
+	for _, task := range tasks {
+		for _, dep := range task.DependsOn {
+			switch {
+			case dep == task.ID:
+				add("task %s depends on itself", task.ID)
+			case !ids[dep]:
+				add("task %s depends on unknown task %q", task.ID, dep)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("invalid backlog: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// BacklogOrder returns the tasks in the order ImportBacklog creates them,
+// each after the tasks it depends on, along with the dependencies dropped
+// to break cycles
+func BacklogOrder(tasks []domain.PlannedTask) ([]domain.PlannedTask, []domain.PlanDependency) {
+	acyclic, dropped := BreakDependencyCycles(&domain.Plan{Tasks: tasks})
+	return creationOrder(acyclic.Tasks), dropped
+}
+
+// ImportBacklog creates beads for tasks, which must be in BacklogOrder, and
+// links each to the beads it depends on. It stops at the first bead that
+// can't be created, returning those created so far, so that no bead is left
+// without its dependencies.
+func ImportBacklog(ctx context.Context, client BeadsClient, tasks []domain.PlannedTask, logger *slog.Logger) ([]domain.Task, error) {
+	created := make([]domain.Task, 0, len(tasks))
+	idMapping := make(map[string]string, len(tasks)) // Backlog IDs to bead IDs
+
+	for _, task := range tasks {
+		bead, err := client.Create(ctx, task.Title, task.Description, task.Type, task.Priority, task.Design, task.Acceptance, task.Estimate)
+		if err != nil {
+			return created, fmt.Errorf("failed to create %q: %w", task.Title, err)
+		}
+		idMapping[task.ID] = bead.ID
+		created = append(created, *bead)
+
+		for _, depID := range task.DependsOn {
+			realDepID, ok := idMapping[depID]
+			if !ok {
+				logger.Warn("skipping dependency on uncreated task", "task", bead.ID, "dependsOn", depID)
+				continue
+			}
+			if err := client.AddDependency(ctx, bead.ID, realDepID, "blocks"); err != nil {
+				logger.Warn("failed to add dependency", "task", bead.ID, "dep", realDepID, "error", err)
+			}
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,201 @@
+package planning
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// linkingBeadsClient records the dependencies added between created beads
+type linkingBeadsClient struct {
+	mockBeadsClient
+	deps [][2]string // child, parent
+}
+
+func (c *linkingBeadsClient) AddDependency(ctx context.Context, childID, parentID, depType string) error {
+	c.deps = append(c.deps, [2]string{childID, parentID})
+	return nil
+}
+
+const markdownBacklog = `# Q3 backlog
+
+Anything before the first task is ignored.
+
+## Deploy
+- type: chore
+- priority: P1
+- depends: API, schema
+
+Roll it out.
+
+## API
+depends: schema
+
+Serve the data.
+Over HTTP.
+
+## Database schema
+id: schema
+type: feature
+priority: 0
+`
+
+func TestParseBacklog_Markdown(t *testing.T) {
+	tasks, err := ParseBacklog("backlog.md", []byte(markdownBacklog))
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+
+	assert.Equal(t, domain.PlannedTask{
+		ID: "Deploy", Title: "Deploy", Description: "Roll it out.",
+		Type: domain.TypeChore, Priority: 1, DependsOn: []string{"API", "schema"},
+	}, tasks[0])
+	assert.Equal(t, domain.PlannedTask{
+		ID: "API", Title: "API", Description: "Serve the data.\nOver HTTP.",
+		Type: domain.TypeTask, Priority: 2, DependsOn: []string{"schema"},
+	}, tasks[1])
+	assert.Equal(t, domain.PlannedTask{
+		ID: "schema", Title: "Database schema", Type: domain.TypeFeature, Priority: 0,
+	}, tasks[2])
+}
+
+func TestParseBacklog_JSON(t *testing.T) {
+	for name, data := range map[string]string{
+		"array":  `[{"title": "Schema", "priority": 0}, {"id": "api", "title": "API", "type": "feature", "dependsOn": ["schema"]}]`,
+		"object": `{"tasks": [{"title": "Schema", "priority": 0}, {"id": "api", "title": "API", "type": "feature", "dependsOn": ["schema"]}]}`,
+		"depends": `[{"title": "Schema", "priority": "P0"}, {"id": "api", "title": "API", "type": "feature", "depends": ["schema"]}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			tasks, err := ParseBacklog("backlog.json", []byte(data))
+			require.NoError(t, err)
+			require.Len(t, tasks, 2)
+
+			// A missing priority is P2, not P0; the title reference resolves
+			assert.Equal(t, 0, tasks[0].Priority)
+			assert.Equal(t, 2, tasks[1].Priority)
+			assert.Equal(t, domain.TypeTask, tasks[0].Type)
+			assert.Equal(t, []string{"Schema"}, tasks[1].DependsOn)
+		})
+	}
+}
+
+func TestParseBacklog_DependsOnInMarkdown(t *testing.T) {
+	tasks, err := ParseBacklog("backlog.md", []byte("## Schema\n## API\n- dependsOn: Schema\n"))
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, []string{"Schema"}, tasks[1].DependsOn)
+}
+
+func TestParseBacklog_JSONExport(t *testing.T) {
+	// As written by az export --format json
+	export := `{"columns": [
+		{"title": "Open", "status": "open", "tasks": [
+			{"id": "az-1", "title": "Schema", "priority": "P0", "status": "open", "type": "feature"}
+		]},
+		{"title": "Done", "status": "closed", "tasks": [
+			{"id": "az-2", "title": "API", "priority": "P3", "status": "closed", "type": "bug"}
+		]}
+	]}`
+
+	tasks, err := ParseBacklog("board.json", []byte(export))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PlannedTask{
+		{ID: "az-1", Title: "Schema", Type: domain.TypeFeature, Priority: 0},
+		{ID: "az-2", Title: "API", Type: domain.TypeBug, Priority: 3},
+	}, tasks)
+}
+
+func TestParseBacklog_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		data string
+		want string
+	}{
+		{"empty", "backlog.md", "# Nothing here\n", "backlog has no tasks"},
+		{"unknown dependency", "backlog.md", "## A\ndepends: B\n", `task A depends on unknown task "B"`},
+		{"self dependency", "backlog.md", "## A\ndepends: A\n", "task A depends on itself"},
+		{"duplicate id", "backlog.md", "## A\n## A\n", `task id "A" is used more than once`},
+		{"unknown type", "backlog.json", `[{"title": "A", "type": "story"}]`, `task A has unknown type "story"`},
+		{"priority out of range", "backlog.json", `[{"title": "A", "priority": 7}]`, "task A has priority 7, want 0-4"},
+		{"bad markdown priority", "backlog.md", "## A\npriority: urgent\n", `line 2: invalid priority "urgent"`},
+		{"bad JSON", "backlog.json", `{"tasks": [`, "failed to parse backlog JSON"},
+		{"bad JSON priority", "backlog.json", `[{"title": "A", "priority": "urgent"}]`, `invalid priority "urgent"`},
+		{"markdown export", "board.md", "# Azedarach Board\n\n## Open (1)\n\n| ID | Title | Priority | Status |\n|----|-------|----------|--------|\n| `az-1` | A | P2 | open |\n", `line 5: this looks like a board exported as markdown`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBacklog(tt.path, []byte(tt.data))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseBacklog_AmbiguousTitleIsNotResolved(t *testing.T) {
+	data := `[{"id": "a1", "title": "Docs"}, {"id": "a2", "title": "Docs"}, {"title": "Ship", "dependsOn": ["docs"]}]`
+	_, err := ParseBacklog("backlog.json", []byte(data))
+	assert.ErrorContains(t, err, `task Ship depends on unknown task "docs"`)
+}
+
+func TestBacklogOrder_DependenciesFirst(t *testing.T) {
+	tasks, err := ParseBacklog("backlog.md", []byte(markdownBacklog))
+	require.NoError(t, err)
+
+	ordered, dropped := BacklogOrder(tasks)
+	assert.Empty(t, dropped)
+
+	var ids []string
+	for _, task := range ordered {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []string{"schema", "API", "Deploy"}, ids)
+}
+
+func TestBacklogOrder_BreaksCycles(t *testing.T) {
+	tasks := []domain.PlannedTask{
+		{ID: "a", Priority: 1, DependsOn: []string{"b"}},
+		{ID: "b", Priority: 3, DependsOn: []string{"a"}},
+		{ID: "c", Priority: 2, DependsOn: []string{"a"}},
+	}
+
+	ordered, dropped := BacklogOrder(tasks)
+	assert.Equal(t, []domain.PlanDependency{{TaskID: "a", DependsOn: "b"}}, dropped)
+	require.Len(t, ordered, 3)
+	assert.Equal(t, "a", ordered[0].ID)
+
+	// The input is left as it was
+	assert.Equal(t, []string{"b"}, tasks[0].DependsOn)
+}
+
+func TestImportBacklog_LinksCreatedBeads(t *testing.T) {
+	tasks, err := ParseBacklog("backlog.md", []byte(markdownBacklog))
+	require.NoError(t, err)
+	ordered, _ := BacklogOrder(tasks)
+
+	client := &linkingBeadsClient{}
+	created, err := ImportBacklog(context.Background(), client, ordered, slog.Default())
+	require.NoError(t, err)
+
+	// schema, API, Deploy become az-1, az-2, az-3
+	require.Len(t, created, 3)
+	assert.Equal(t, "Database schema", created[0].Title)
+	assert.Equal(t, [][2]string{
+		{"az-2", "az-1"}, // API after schema
+		{"az-3", "az-2"}, // Deploy after API
+		{"az-3", "az-1"}, // Deploy after schema
+	}, client.deps)
+}
+
+func TestImportBacklog_StopsAtFailure(t *testing.T) {
+	client := &linkingBeadsClient{mockBeadsClient: mockBeadsClient{createErr: errors.New("bd not found")}}
+	tasks := []domain.PlannedTask{{ID: "a", Title: "A", Type: domain.TypeTask}}
+
+	created, err := ImportBacklog(context.Background(), client, tasks, slog.Default())
+	assert.ErrorContains(t, err, `failed to create "A"`)
+	assert.Empty(t, created)
+}
@@ -0,0 +1,196 @@
+package planning
+
+import (
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// BreakDependencyCycles returns a copy of plan whose dependsOn graph has no
+// cycles, along with the dependencies removed to get there.
+//
+// Cycles are found the same way as dependency phases: tasks whose
+// dependencies are all resolved are peeled off (Kahn's algorithm) and any
+// tasks left over sit on or behind a cycle. From the dependencies that lie
+// on a cycle, the least important one is dropped and the search repeats.
+// The least important dependency is the one on the lowest priority task,
+// then from the lowest priority task, then the latest in plan order, so the
+// result is the same for the same plan.
+//
+// Dependencies on IDs that aren't in the plan are ignored here; they can't
+// take part in a cycle.
+func BreakDependencyCycles(plan *domain.Plan) (*domain.Plan, []domain.PlanDependency) {
+	out := *plan
+	out.Tasks = make([]domain.PlannedTask, len(plan.Tasks))
+	for i, task := range plan.Tasks {
+		task.DependsOn = append([]string(nil), task.DependsOn...)
+		out.Tasks[i] = task
+	}
+
+	var dropped []domain.PlanDependency
+	for {
+		stuck := unresolvedTasks(out.Tasks)
+		if len(stuck) == 0 {
+			return &out, dropped
+		}
+
+		edge, ok := leastImportantCycleEdge(out.Tasks, stuck)
+		if !ok {
+			// Unreachable: tasks left by Kahn's algorithm always include a cycle
+			return &out, dropped
+		}
+		removeDependency(out.Tasks, edge)
+		dropped = append(dropped, edge)
+	}
+}
+
+// creationOrder returns the tasks of an acyclic plan so that every task
+// comes after the tasks it depends on. Tasks are taken phase by phase,
+// keeping plan order within a phase.
+func creationOrder(tasks []domain.PlannedTask) []domain.PlannedTask {
+	known := planTaskIDs(tasks)
+	done := make(map[string]bool, len(tasks))
+	order := make([]domain.PlannedTask, 0, len(tasks))
+
+	for len(order) < len(tasks) {
+		var phase []domain.PlannedTask
+		for _, task := range tasks {
+			if !done[task.ID] && dependenciesDone(task, known, done) {
+				phase = append(phase, task)
+			}
+		}
+		if len(phase) == 0 {
+			// A cycle is left; callers break cycles first
+			break
+		}
+		for _, task := range phase {
+			done[task.ID] = true
+		}
+		order = append(order, phase...)
+	}
+
+	return order
+}
+
+// unresolvedTasks runs Kahn's algorithm over tasks and returns the IDs it
+// can't resolve, which are empty for an acyclic plan
+func unresolvedTasks(tasks []domain.PlannedTask) map[string]bool {
+	known := planTaskIDs(tasks)
+	done := make(map[string]bool, len(tasks))
+
+	for progress := true; progress; {
+		progress = false
+		for _, task := range tasks {
+			if !done[task.ID] && dependenciesDone(task, known, done) {
+				done[task.ID] = true
+				progress = true
+			}
+		}
+	}
+
+	stuck := make(map[string]bool)
+	for _, task := range tasks {
+		if !done[task.ID] {
+			stuck[task.ID] = true
+		}
+	}
+	return stuck
+}
+
+// leastImportantCycleEdge picks the dependency to drop among those between
+// stuck tasks that close a cycle
+func leastImportantCycleEdge(tasks []domain.PlannedTask, stuck map[string]bool) (domain.PlanDependency, bool) {
+	index := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+
+	var best domain.PlanDependency
+	found := false
+	for _, task := range tasks {
+		if !stuck[task.ID] {
+			continue
+		}
+		for _, dep := range task.DependsOn {
+			// task -> dep is on a cycle when dep leads back to task
+			if !stuck[dep] || !dependsOnTransitively(tasks, index, dep, task.ID) {
+				continue
+			}
+			edge := domain.PlanDependency{TaskID: task.ID, DependsOn: dep}
+			if !found || lessImportant(tasks, index, edge, best) {
+				best = edge
+				found = true
+			}
+		}
+	}
+	return best, found
+}
+
+// lessImportant reports whether dependency a should be dropped before b
+func lessImportant(tasks []domain.PlannedTask, index map[string]int, a, b domain.PlanDependency) bool {
+	aDep, bDep := tasks[index[a.DependsOn]], tasks[index[b.DependsOn]]
+	if aDep.Priority != bDep.Priority {
+		return aDep.Priority > bDep.Priority
+	}
+	aTask, bTask := tasks[index[a.TaskID]], tasks[index[b.TaskID]]
+	if aTask.Priority != bTask.Priority {
+		return aTask.Priority > bTask.Priority
+	}
+	if index[a.TaskID] != index[b.TaskID] {
+		return index[a.TaskID] > index[b.TaskID]
+	}
+	return index[a.DependsOn] > index[b.DependsOn]
+}
+
+// dependsOnTransitively reports whether from reaches to by following
+// dependsOn links
+func dependsOnTransitively(tasks []domain.PlannedTask, index map[string]int, from, to string) bool {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range tasks[index[id]].DependsOn {
+			if dep == to {
+				return true
+			}
+			if _, ok := index[dep]; ok && !seen[dep] {
+				seen[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
+
+// removeDependency deletes edge from the task it belongs to
+func removeDependency(tasks []domain.PlannedTask, edge domain.PlanDependency) {
+	for i := range tasks {
+		if tasks[i].ID != edge.TaskID {
+			continue
+		}
+		deps := tasks[i].DependsOn[:0]
+		for _, dep := range tasks[i].DependsOn {
+			if dep != edge.DependsOn {
+				deps = append(deps, dep)
+			}
+		}
+		tasks[i].DependsOn = deps
+	}
+}
+
+// dependenciesDone reports whether every known dependency of task is done
+func dependenciesDone(task domain.PlannedTask, known, done map[string]bool) bool {
+	for _, dep := range task.DependsOn {
+		if known[dep] && !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func planTaskIDs(tasks []domain.PlannedTask) map[string]bool {
+	ids := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		ids[task.ID] = true
+	}
+	return ids
+}
@@ -0,0 +1,150 @@
+package planning
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name        string
+		tasks       []domain.PlannedTask
+		wantDropped []domain.PlanDependency
+	}{
+		{
+			name: "acyclic plan is unchanged",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1},
+				{ID: "task-2", Priority: 1, DependsOn: []string{"task-1"}},
+				{ID: "task-3", Priority: 1, DependsOn: []string{"task-1", "task-2"}},
+			},
+		},
+		{
+			name: "two task cycle drops the dependency on the lower priority task",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1, DependsOn: []string{"task-2"}},
+				{ID: "task-2", Priority: 3, DependsOn: []string{"task-1"}},
+			},
+			wantDropped: []domain.PlanDependency{{TaskID: "task-1", DependsOn: "task-2"}},
+		},
+		{
+			name: "equal priorities drop the latest dependency in plan order",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 2, DependsOn: []string{"task-3"}},
+				{ID: "task-2", Priority: 2, DependsOn: []string{"task-1"}},
+				{ID: "task-3", Priority: 2, DependsOn: []string{"task-2"}},
+			},
+			wantDropped: []domain.PlanDependency{{TaskID: "task-3", DependsOn: "task-2"}},
+		},
+		{
+			name: "self dependency",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1, DependsOn: []string{"task-1"}},
+			},
+			wantDropped: []domain.PlanDependency{{TaskID: "task-1", DependsOn: "task-1"}},
+		},
+		{
+			name: "tasks behind a cycle keep their dependencies",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1, DependsOn: []string{"task-2"}},
+				{ID: "task-2", Priority: 1, DependsOn: []string{"task-1"}},
+				{ID: "task-3", Priority: 4, DependsOn: []string{"task-1"}},
+			},
+			wantDropped: []domain.PlanDependency{{TaskID: "task-2", DependsOn: "task-1"}},
+		},
+		{
+			name: "separate cycles are each broken once",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1, DependsOn: []string{"task-2"}},
+				{ID: "task-2", Priority: 2, DependsOn: []string{"task-1"}},
+				{ID: "task-3", Priority: 1, DependsOn: []string{"task-4"}},
+				{ID: "task-4", Priority: 4, DependsOn: []string{"task-3"}},
+			},
+			wantDropped: []domain.PlanDependency{
+				{TaskID: "task-3", DependsOn: "task-4"},
+				{TaskID: "task-1", DependsOn: "task-2"},
+			},
+		},
+		{
+			name: "unknown dependencies are not cycles",
+			tasks: []domain.PlannedTask{
+				{ID: "task-1", Priority: 1, DependsOn: []string{"task-9"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &domain.Plan{Tasks: tt.tasks}
+
+			got, dropped := BreakDependencyCycles(plan)
+
+			assert.Equal(t, tt.wantDropped, dropped)
+			assert.Empty(t, unresolvedTasks(got.Tasks), "result still has a cycle")
+			assert.Len(t, creationOrder(got.Tasks), len(tt.tasks))
+		})
+	}
+}
+
+func TestBreakDependencyCycles_DoesNotModifyPlan(t *testing.T) {
+	plan := &domain.Plan{Tasks: []domain.PlannedTask{
+		{ID: "task-1", DependsOn: []string{"task-2"}},
+		{ID: "task-2", DependsOn: []string{"task-1"}},
+	}}
+
+	_, dropped := BreakDependencyCycles(plan)
+
+	require.Len(t, dropped, 1)
+	assert.Equal(t, []string{"task-2"}, plan.Tasks[0].DependsOn)
+	assert.Equal(t, []string{"task-1"}, plan.Tasks[1].DependsOn)
+}
+
+func TestCreationOrder(t *testing.T) {
+	tasks := []domain.PlannedTask{
+		{ID: "task-1", DependsOn: []string{"task-3"}},
+		{ID: "task-2"},
+		{ID: "task-3", DependsOn: []string{"task-2"}},
+		{ID: "task-4"},
+	}
+
+	var ids []string
+	for _, task := range creationOrder(tasks) {
+		ids = append(ids, task.ID)
+	}
+
+	assert.Equal(t, []string{"task-2", "task-4", "task-3", "task-1"}, ids)
+}
+
+func TestService_CreateBeadsFromPlan_Cycle(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	beadsClient := &mockBeadsClient{}
+	svc, err := NewService(&mockHTTPClient{}, beadsClient, slog.Default())
+	require.NoError(t, err)
+
+	plan := &domain.Plan{
+		EpicTitle: "Epic",
+		Tasks: []domain.PlannedTask{
+			{ID: "task-1", Title: "Schema", Type: domain.TypeTask, Priority: 1, DependsOn: []string{"task-3"}},
+			{ID: "task-2", Title: "API", Type: domain.TypeTask, Priority: 1, DependsOn: []string{"task-1"}},
+			{ID: "task-3", Title: "Docs", Type: domain.TypeTask, Priority: 4, DependsOn: []string{"task-2"}},
+		},
+	}
+
+	created, err := svc.CreateBeadsFromPlan(context.Background(), plan)
+	require.NoError(t, err)
+
+	var titles []string
+	for _, task := range created {
+		titles = append(titles, task.Title)
+	}
+	assert.Equal(t, []string{"Epic", "Schema", "API", "Docs"}, titles)
+	assert.Equal(t, []domain.PlanDependency{{TaskID: "task-1", DependsOn: "task-3"}}, svc.GetState().DroppedDependencies)
+}
@@ -0,0 +1,56 @@
+package planning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/services/statefile"
+)
+
+// Draft is a generated plan saved to disk before beads are created from it,
+// so that a crash doesn't lose the AI's work
+type Draft struct {
+	FeatureDescription string       `json:"featureDescription"`
+	Plan               *domain.Plan `json:"plan"`
+	SavedAt            time.Time    `json:"savedAt"`
+}
+
+// DefaultDraftPath returns where the plan draft for a project is kept:
+// ~/.azedarach/plans/<project-dir>-<hash>.json
+func DefaultDraftPath(projectPath string) string {
+	return statefile.Path("plans", projectPath)
+}
+
+// SaveDraft writes draft to path, replacing any previous draft atomically
+func SaveDraft(path string, draft Draft) error {
+	if err := statefile.Save(path, draft); err != nil {
+		return fmt.Errorf("failed to write draft: %w", err)
+	}
+	return nil
+}
+
+// LoadDraft reads the draft at path. It returns nil without an error when
+// no draft has been saved.
+func LoadDraft(path string) (*Draft, error) {
+	var draft Draft
+	ok, err := statefile.Load(path, &draft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	if draft.Plan == nil {
+		return nil, fmt.Errorf("failed to read draft: no plan")
+	}
+	return &draft, nil
+}
+
+// RemoveDraft deletes the draft at path, if any
+func RemoveDraft(path string) error {
+	if err := statefile.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove draft: %w", err)
+	}
+	return nil
+}
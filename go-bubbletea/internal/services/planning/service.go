@@ -119,11 +119,21 @@ type Service struct {
 	logger      *slog.Logger
 	apiKey      string
 	state       *domain.PlanningState
+	onProgress  func(domain.PlanningState)
+	draftPath   string
+}
+
+// apiKeyEnv is the environment variable planning reads the Anthropic API key from
+const apiKeyEnv = "ANTHROPIC_API_KEY"
+
+// Available reports whether planning can run, which needs an Anthropic API key
+func Available() bool {
+	return os.Getenv(apiKeyEnv) != ""
 }
 
 // NewService creates a new planning service
 func NewService(httpClient HTTPClient, beadsClient BeadsClient, logger *slog.Logger) (*Service, error) {
-	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	apiKey := os.Getenv(apiKeyEnv)
 	if apiKey == "" {
 		return nil, errors.New("ANTHROPIC_API_KEY environment variable not set")
 	}
@@ -148,6 +158,50 @@ func (s *Service) GetState() domain.PlanningState {
 	return *s.state
 }
 
+// SetProgressFunc registers fn to be called with a snapshot of the planning
+// state whenever the workflow moves to a new step
+func (s *Service) SetProgressFunc(fn func(domain.PlanningState)) {
+	s.onProgress = fn
+}
+
+// SetDraftPath enables saving the current plan to path after every planning
+// step. The draft is removed once beads have been created from it.
+func (s *Service) SetDraftPath(path string) {
+	s.draftPath = path
+}
+
+// RestoreDraft resumes a plan saved by a previous run, ready for approval
+func (s *Service) RestoreDraft(draft *Draft) {
+	s.state.Status = domain.PlanningAwaitingApproval
+	s.state.FeatureDescription = draft.FeatureDescription
+	s.state.CurrentPlan = draft.Plan
+	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
+}
+
+// saveDraft persists the current plan if a draft path is set. Failures are
+// logged rather than returned so that they never interrupt planning.
+func (s *Service) saveDraft() {
+	if s.draftPath == "" || s.state.CurrentPlan == nil {
+		return
+	}
+	draft := Draft{
+		FeatureDescription: s.state.FeatureDescription,
+		Plan:               s.state.CurrentPlan,
+		SavedAt:            time.Now(),
+	}
+	if err := SaveDraft(s.draftPath, draft); err != nil {
+		s.logger.Warn("failed to save plan draft", "path", s.draftPath, "error", err)
+	}
+}
+
+// reportProgress notifies the progress func, if any, of the current state
+func (s *Service) reportProgress() {
+	if s.onProgress != nil {
+		s.onProgress(*s.state)
+	}
+}
+
 // Reset resets the planning state
 func (s *Service) Reset() {
 	s.state = &domain.PlanningState{
@@ -263,6 +317,7 @@ func (s *Service) GeneratePlan(ctx context.Context, featureDescription string) (
 	s.state.Status = domain.PlanningGenerating
 	s.state.FeatureDescription = featureDescription
 	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
 
 	prompt := generationPrompt + featureDescription
 	response, err := s.callClaude(ctx, prompt)
@@ -289,9 +344,25 @@ func (s *Service) GeneratePlan(ctx context.Context, featureDescription string) (
 		}
 	}
 
+	// A plan that parsed but breaks the schema gets one refinement pass to
+	// fix it before it counts as a failure
+	var invalid *PlanValidationError
+	if errors.As(ValidatePlan(&plan), &invalid) {
+		s.logger.Warn("generated plan is invalid, refining", "error", invalid)
+		refined, err := s.RefinePlan(ctx, &plan, validationFeedback(invalid))
+		if err != nil {
+			s.state.Status = domain.PlanningErrorStatus
+			s.state.Error = err.Error()
+			s.state.UpdatedAt = time.Now()
+			return nil, err
+		}
+		plan = *refined
+	}
+
 	s.state.Status = domain.PlanningReviewing
 	s.state.CurrentPlan = &plan
 	s.state.UpdatedAt = time.Now()
+	s.saveDraft()
 
 	s.logger.Info("plan generated", "tasks", len(plan.Tasks))
 	return &plan, nil
@@ -335,6 +406,7 @@ func (s *Service) RefinePlan(ctx context.Context, plan *domain.Plan, feedback *d
 
 	s.state.Status = domain.PlanningRefining
 	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
 
 	feedbackJSON, err := json.MarshalIndent(feedback, "", "  ")
 	if err != nil {
@@ -367,16 +439,27 @@ func (s *Service) RefinePlan(ctx context.Context, plan *domain.Plan, feedback *d
 		}
 	}
 
+	if err := ValidatePlan(&refinedPlan); err != nil {
+		return nil, &domain.PlanningError{
+			Phase:   "refinement",
+			Message: "refined plan is invalid",
+			Err:     err,
+		}
+	}
+
 	s.logger.Info("plan refined", "tasks", len(refinedPlan.Tasks))
 	return &refinedPlan, nil
 }
 
-// CreateBeadsFromPlan creates beads from a finalized plan
+// CreateBeadsFromPlan creates beads from a finalized plan. Dependency cycles
+// are broken first (see BreakDependencyCycles) and the dropped dependencies
+// recorded in the planning state.
 func (s *Service) CreateBeadsFromPlan(ctx context.Context, plan *domain.Plan) ([]domain.Task, error) {
 	s.logger.Info("creating beads from plan")
 
 	s.state.Status = domain.PlanningCreatingBeads
 	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
 
 	createdBeads := []domain.Task{}
 	idMapping := make(map[string]string) // Map temp IDs to real bead IDs
@@ -406,19 +489,16 @@ func (s *Service) CreateBeadsFromPlan(ctx context.Context, plan *domain.Plan) ([
 
 	createdBeads = append(createdBeads, *epic)
 
-	// 2. Create tasks in dependency order
-	// First, create tasks with no dependencies
-	var noDeps, withDeps []domain.PlannedTask
-	for _, task := range plan.Tasks {
-		if len(task.DependsOn) == 0 {
-			noDeps = append(noDeps, task)
-		} else {
-			withDeps = append(withDeps, task)
-		}
+	// 2. Break dependency cycles, which would otherwise leave the tasks on
+	// them uncreated
+	plan, dropped := BreakDependencyCycles(plan)
+	for _, dep := range dropped {
+		s.logger.Warn("dropped dependency to break a cycle", "task", dep.TaskID, "dependsOn", dep.DependsOn)
 	}
+	s.state.DroppedDependencies = dropped
 
-	// Create tasks without dependencies
-	for _, task := range noDeps {
+	// 3. Create tasks in dependency order
+	for _, task := range creationOrder(plan.Tasks) {
 		s.logger.Debug("creating task", "title", task.Title)
 		bead, err := s.beadsClient.Create(
 			ctx,
@@ -442,77 +522,30 @@ func (s *Service) CreateBeadsFromPlan(ctx context.Context, plan *domain.Plan) ([
 		if err := s.beadsClient.AddDependency(ctx, bead.ID, epic.ID, "parent-child"); err != nil {
 			s.logger.Warn("failed to link task to epic", "task", bead.ID, "error", err)
 		}
-	}
-
-	// Create tasks with dependencies (may need multiple passes)
-	remaining := withDeps
-	maxIterations := 10
-
-	for len(remaining) > 0 && maxIterations > 0 {
-		maxIterations--
-		var canCreate, stillWaiting []domain.PlannedTask
-
-		for _, task := range remaining {
-			allDepsResolved := true
-			for _, depID := range task.DependsOn {
-				if _, ok := idMapping[depID]; !ok {
-					allDepsResolved = false
-					break
-				}
-			}
-
-			if allDepsResolved {
-				canCreate = append(canCreate, task)
-			} else {
-				stillWaiting = append(stillWaiting, task)
-			}
-		}
 
-		for _, task := range canCreate {
-			s.logger.Debug("creating task with dependencies", "title", task.Title)
-			bead, err := s.beadsClient.Create(
-				ctx,
-				task.Title,
-				task.Description,
-				task.Type,
-				task.Priority,
-				task.Design,
-				task.Acceptance,
-				task.Estimate,
-			)
-			if err != nil {
-				s.logger.Warn("failed to create task", "title", task.Title, "error", err)
+		// Add task dependencies (blocks relationship)
+		for _, depID := range task.DependsOn {
+			realDepID, ok := idMapping[depID]
+			if !ok {
+				s.logger.Warn("skipping dependency on uncreated task", "task", bead.ID, "dependsOn", depID)
 				continue
 			}
-
-			idMapping[task.ID] = bead.ID
-			createdBeads = append(createdBeads, *bead)
-
-			// Link to epic as child
-			if err := s.beadsClient.AddDependency(ctx, bead.ID, epic.ID, "parent-child"); err != nil {
-				s.logger.Warn("failed to link task to epic", "task", bead.ID, "error", err)
-			}
-
-			// Add task dependencies (blocks relationship)
-			for _, depID := range task.DependsOn {
-				if realDepID, ok := idMapping[depID]; ok {
-					if err := s.beadsClient.AddDependency(ctx, bead.ID, realDepID, "blocks"); err != nil {
-						s.logger.Warn("failed to add dependency", "task", bead.ID, "dep", realDepID, "error", err)
-					}
-				}
+			if err := s.beadsClient.AddDependency(ctx, bead.ID, realDepID, "blocks"); err != nil {
+				s.logger.Warn("failed to add dependency", "task", bead.ID, "dep", realDepID, "error", err)
 			}
 		}
-
-		remaining = stillWaiting
-	}
-
-	if len(remaining) > 0 {
-		s.logger.Warn("could not resolve dependencies", "count", len(remaining))
 	}
 
 	s.state.Status = domain.PlanningComplete
 	s.state.CreatedBeads = createdBeads
 	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
+
+	if s.draftPath != "" {
+		if err := RemoveDraft(s.draftPath); err != nil {
+			s.logger.Warn("failed to remove plan draft", "path", s.draftPath, "error", err)
+		}
+	}
 
 	s.logger.Info("beads created", "count", len(createdBeads))
 	return createdBeads, nil
@@ -522,6 +555,20 @@ func (s *Service) CreateBeadsFromPlan(ctx context.Context, plan *domain.Plan) ([
 func (s *Service) RunPlanningWorkflow(ctx context.Context, featureDescription string) ([]domain.Task, error) {
 	s.logger.Info("starting planning workflow", "description", featureDescription)
 
+	plan, err := s.PlanFeature(ctx, featureDescription)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Create beads from the final plan
+	return s.CreateBeadsFromPlan(ctx, plan)
+}
+
+// PlanFeature generates a plan and runs the review and refine loop until the
+// plan is approved or the review passes run out. No beads are created: the
+// state is left awaiting approval so the plan can be edited before it is
+// passed to CreateBeadsFromPlan.
+func (s *Service) PlanFeature(ctx context.Context, featureDescription string) (*domain.Plan, error) {
 	// 1. Generate initial plan
 	plan, err := s.GeneratePlan(ctx, featureDescription)
 	if err != nil {
@@ -530,8 +577,10 @@ func (s *Service) RunPlanningWorkflow(ctx context.Context, featureDescription st
 
 	// 2. Review and refine loop
 	for pass := 1; pass <= s.state.MaxReviewPasses; pass++ {
+		s.state.Status = domain.PlanningReviewing
 		s.state.ReviewPass = pass
 		s.state.UpdatedAt = time.Now()
+		s.reportProgress()
 
 		feedback, err := s.ReviewPlan(ctx, plan)
 		if err != nil {
@@ -559,9 +608,13 @@ func (s *Service) RunPlanningWorkflow(ctx context.Context, featureDescription st
 			}
 			s.state.CurrentPlan = plan
 			s.state.UpdatedAt = time.Now()
+			s.saveDraft()
 		}
 	}
 
-	// 3. Create beads from the final plan
-	return s.CreateBeadsFromPlan(ctx, plan)
+	s.state.Status = domain.PlanningAwaitingApproval
+	s.state.UpdatedAt = time.Now()
+	s.reportProgress()
+
+	return plan, nil
 }
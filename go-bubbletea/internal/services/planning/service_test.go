@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
@@ -70,6 +72,14 @@ func createMockAPIResponse(text string) *http.Response {
 	}
 }
 
+func TestAvailable(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	assert.False(t, Available())
+
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	assert.True(t, Available())
+}
+
 func TestNewService(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -570,3 +580,108 @@ func TestParseJSONResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestService_PlanFeature(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	httpClient := &mockHTTPClientWithSequence{
+		responses: []string{
+			`{"epicTitle": "Test Feature", "tasks": [{"id": "task-1", "title": "Task 1", "type": "task", "priority": 1}]}`,
+			`{"score": 60, "isApproved": false, "issues": ["too vague"]}`,
+			`{"epicTitle": "Test Feature", "tasks": [{"id": "task-1", "title": "Task 1 (refined)", "type": "task", "priority": 1}]}`,
+			`{"score": 90, "isApproved": true}`,
+		},
+	}
+	beadsClient := &mockBeadsClient{}
+
+	svc, err := NewService(httpClient, beadsClient, slog.Default())
+	require.NoError(t, err)
+
+	var steps []string
+	svc.SetProgressFunc(func(state domain.PlanningState) {
+		if state.Status == domain.PlanningReviewing {
+			steps = append(steps, fmt.Sprintf("%s %d", state.Status, state.ReviewPass))
+			return
+		}
+		steps = append(steps, string(state.Status))
+	})
+
+	plan, err := svc.PlanFeature(context.Background(), "Add test feature")
+
+	require.NoError(t, err)
+	require.Len(t, plan.Tasks, 1)
+	assert.Equal(t, "Task 1 (refined)", plan.Tasks[0].Title)
+	assert.Empty(t, beadsClient.createdTasks, "planning alone must not create beads")
+	assert.Equal(t, []string{"generating", "reviewing 1", "refining", "reviewing 2", "awaiting_approval"}, steps)
+	assert.Equal(t, domain.PlanningAwaitingApproval, svc.GetState().Status)
+}
+
+func TestService_DraftPersistence(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	httpClient := &mockHTTPClientWithSequence{
+		responses: []string{
+			`{"epicTitle": "Test Feature", "tasks": [{"id": "task-1", "title": "Task 1", "type": "task", "priority": 1}]}`,
+			`{"score": 90, "isApproved": true}`,
+		},
+	}
+	svc, err := NewService(httpClient, &mockBeadsClient{}, slog.Default())
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "plans", "project.json")
+	svc.SetDraftPath(path)
+
+	plan, err := svc.PlanFeature(context.Background(), "Add test feature")
+	require.NoError(t, err)
+
+	// The approved plan survives until beads are created from it
+	draft, err := LoadDraft(path)
+	require.NoError(t, err)
+	require.NotNil(t, draft)
+	assert.Equal(t, "Add test feature", draft.FeatureDescription)
+	assert.Equal(t, "Test Feature", draft.Plan.EpicTitle)
+
+	// A fresh service can pick the draft up again
+	restored, err := NewService(httpClient, &mockBeadsClient{}, slog.Default())
+	require.NoError(t, err)
+	restored.RestoreDraft(draft)
+	assert.Equal(t, domain.PlanningAwaitingApproval, restored.GetState().Status)
+	assert.Equal(t, "Task 1", restored.GetState().CurrentPlan.Tasks[0].Title)
+
+	_, err = svc.CreateBeadsFromPlan(context.Background(), plan)
+	require.NoError(t, err)
+
+	draft, err = LoadDraft(path)
+	require.NoError(t, err)
+	assert.Nil(t, draft, "draft should be removed once beads exist")
+}
+
+func TestService_CreateBeadsFromPlan_ExcludedTasks(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	beadsClient := &mockBeadsClient{}
+	svc, err := NewService(&mockHTTPClient{}, beadsClient, slog.Default())
+	require.NoError(t, err)
+
+	plan := &domain.Plan{
+		EpicTitle: "Epic",
+		Tasks: []domain.PlannedTask{
+			{ID: "task-1", Title: "Keep", Type: domain.TypeTask},
+			{ID: "task-2", Title: "Drop", Type: domain.TypeTask},
+			{ID: "task-3", Title: "Depends on dropped", Type: domain.TypeTask, DependsOn: []string{"task-2"}},
+		},
+	}
+
+	created, err := svc.CreateBeadsFromPlan(context.Background(), plan.WithoutTasks(map[string]bool{"task-2": true}))
+	require.NoError(t, err)
+
+	var titles []string
+	for _, task := range created {
+		titles = append(titles, task.Title)
+	}
+	assert.Equal(t, []string{"Epic", "Keep", "Depends on dropped"}, titles)
+	assert.Len(t, plan.Tasks, 3, "the original plan is not modified")
+}
@@ -0,0 +1,96 @@
+package planning
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// plannedTaskTypes are the types a planned task may have; the epic is
+// created separately
+var plannedTaskTypes = map[domain.TaskType]bool{
+	domain.TypeTask:    true,
+	domain.TypeBug:     true,
+	domain.TypeFeature: true,
+	domain.TypeChore:   true,
+}
+
+// PlanValidationError lists what is wrong with a plan that parsed but
+// can't be turned into beads
+type PlanValidationError struct {
+	Violations []string
+}
+
+func (e *PlanValidationError) Error() string {
+	return "invalid plan: " + strings.Join(e.Violations, "; ")
+}
+
+// ValidatePlan checks the structure of a parsed plan: an epic title, at
+// least one task, unique task IDs, titles, priorities 1-4, known task types
+// and dependencies on tasks in the plan. It returns a *PlanValidationError
+// listing every violation, or nil.
+func ValidatePlan(plan *domain.Plan) error {
+	var violations []string
+	add := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if strings.TrimSpace(plan.EpicTitle) == "" {
+		add("epicTitle is empty")
+	}
+	if len(plan.Tasks) == 0 {
+		add("plan has no tasks")
+	}
+
+	ids := make(map[string]bool, len(plan.Tasks))
+	for i, task := range plan.Tasks {
+		if task.ID == "" {
+			add("task %d has no id", i+1)
+			continue
+		}
+		if ids[task.ID] {
+			add("task id %q is used more than once", task.ID)
+		}
+		ids[task.ID] = true
+	}
+
+	for i, task := range plan.Tasks {
+		name := task.ID
+		if name == "" {
+			name = fmt.Sprintf("%d", i+1)
+		}
+
+		if strings.TrimSpace(task.Title) == "" {
+			add("task %s has no title", name)
+		}
+		if task.Priority < 1 || task.Priority > 4 {
+			add("task %s has priority %d, want 1-4", name, task.Priority)
+		}
+		if !plannedTaskTypes[task.Type] {
+			add("task %s has unknown type %q, want task, bug, feature or chore", name, task.Type)
+		}
+		for _, dep := range task.DependsOn {
+			switch {
+			case dep == task.ID:
+				add("task %s depends on itself", name)
+			case !ids[dep]:
+				add("task %s depends on unknown task %q", name, dep)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PlanValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// validationFeedback turns plan violations into review feedback, so a
+// refinement pass can fix them
+func validationFeedback(err *PlanValidationError) *domain.ReviewFeedback {
+	return &domain.ReviewFeedback{
+		Issues:     err.Violations,
+		IsApproved: false,
+	}
+}
@@ -0,0 +1,195 @@
+package planning
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validPlan() *domain.Plan {
+	return &domain.Plan{
+		EpicTitle: "User Authentication",
+		Tasks: []domain.PlannedTask{
+			{ID: "task-1", Title: "Create user model", Type: domain.TypeTask, Priority: 1},
+			{ID: "task-2", Title: "Login endpoint", Type: domain.TypeFeature, Priority: 2, DependsOn: []string{"task-1"}},
+		},
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(plan *domain.Plan)
+		want   string // Expected violation; empty for a valid plan
+	}{
+		{
+			name:   "valid plan",
+			mutate: func(plan *domain.Plan) {},
+		},
+		{
+			name:   "empty epic title",
+			mutate: func(plan *domain.Plan) { plan.EpicTitle = "  " },
+			want:   "epicTitle is empty",
+		},
+		{
+			name:   "no tasks",
+			mutate: func(plan *domain.Plan) { plan.Tasks = nil },
+			want:   "plan has no tasks",
+		},
+		{
+			name:   "priority 0",
+			mutate: func(plan *domain.Plan) { plan.Tasks[0].Priority = 0 },
+			want:   "task task-1 has priority 0, want 1-4",
+		},
+		{
+			name:   "priority 99",
+			mutate: func(plan *domain.Plan) { plan.Tasks[1].Priority = 99 },
+			want:   "task task-2 has priority 99, want 1-4",
+		},
+		{
+			name:   "unknown type",
+			mutate: func(plan *domain.Plan) { plan.Tasks[0].Type = "story" },
+			want:   `task task-1 has unknown type "story"`,
+		},
+		{
+			name:   "missing type",
+			mutate: func(plan *domain.Plan) { plan.Tasks[0].Type = "" },
+			want:   `task task-1 has unknown type ""`,
+		},
+		{
+			name:   "epic as a task",
+			mutate: func(plan *domain.Plan) { plan.Tasks[0].Type = domain.TypeEpic },
+			want:   `task task-1 has unknown type "epic"`,
+		},
+		{
+			name:   "dependency on unknown task",
+			mutate: func(plan *domain.Plan) { plan.Tasks[1].DependsOn = []string{"task-9"} },
+			want:   `task task-2 depends on unknown task "task-9"`,
+		},
+		{
+			name:   "dependency on itself",
+			mutate: func(plan *domain.Plan) { plan.Tasks[1].DependsOn = []string{"task-2"} },
+			want:   "task task-2 depends on itself",
+		},
+		{
+			name:   "missing task id",
+			mutate: func(plan *domain.Plan) { plan.Tasks[0].ID = "" },
+			want:   "task 1 has no id",
+		},
+		{
+			name:   "duplicate task id",
+			mutate: func(plan *domain.Plan) { plan.Tasks[1].ID = "task-1" },
+			want:   `task id "task-1" is used more than once`,
+		},
+		{
+			name:   "missing title",
+			mutate: func(plan *domain.Plan) { plan.Tasks[1].Title = "" },
+			want:   "task task-2 has no title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := validPlan()
+			tt.mutate(plan)
+
+			err := ValidatePlan(plan)
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var invalid *PlanValidationError
+			require.True(t, errors.As(err, &invalid), "expected a PlanValidationError, got %v", err)
+			assert.Contains(t, invalid.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidatePlan_ListsEveryViolation(t *testing.T) {
+	plan := validPlan()
+	plan.EpicTitle = ""
+	plan.Tasks[0].Priority = 7
+	plan.Tasks[1].Type = "story"
+
+	var invalid *PlanValidationError
+	require.True(t, errors.As(ValidatePlan(plan), &invalid))
+	assert.Len(t, invalid.Violations, 3)
+}
+
+const invalidPlanResponse = `{
+	"epicTitle": "Feature",
+	"tasks": [
+		{"id": "task-1", "title": "Task 1", "type": "task", "priority": 0, "dependsOn": ["task-7"]}
+	]
+}`
+
+const validPlanResponse = `{
+	"epicTitle": "Feature",
+	"tasks": [
+		{"id": "task-1", "title": "Task 1", "type": "task", "priority": 2, "dependsOn": []}
+	]
+}`
+
+func TestService_GeneratePlan_RefinesInvalidPlan(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	httpClient := &mockHTTPClientWithSequence{
+		responses: []string{invalidPlanResponse, validPlanResponse},
+	}
+	svc, err := NewService(httpClient, &mockBeadsClient{}, slog.Default())
+	require.NoError(t, err)
+
+	plan, err := svc.GeneratePlan(context.Background(), "Add feature")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, httpClient.callCount, "expected a refinement pass")
+	assert.Equal(t, 2, plan.Tasks[0].Priority)
+	assert.Equal(t, domain.PlanningReviewing, svc.state.Status)
+}
+
+func TestService_GeneratePlan_FailsWhenRefinementStaysInvalid(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	httpClient := &mockHTTPClientWithSequence{
+		responses: []string{invalidPlanResponse, invalidPlanResponse},
+	}
+	svc, err := NewService(httpClient, &mockBeadsClient{}, slog.Default())
+	require.NoError(t, err)
+
+	plan, err := svc.GeneratePlan(context.Background(), "Add feature")
+
+	require.Error(t, err)
+	assert.Nil(t, plan)
+	assert.Equal(t, domain.PlanningErrorStatus, svc.state.Status)
+
+	var planningErr *domain.PlanningError
+	require.True(t, errors.As(err, &planningErr))
+	assert.Equal(t, "refinement", planningErr.Phase)
+
+	var invalid *PlanValidationError
+	assert.True(t, errors.As(err, &invalid))
+}
+
+func TestService_RefinePlan_RejectsInvalidPlan(t *testing.T) {
+	os.Setenv("ANTHROPIC_API_KEY", "test-key")
+	defer os.Unsetenv("ANTHROPIC_API_KEY")
+
+	httpClient := &mockHTTPClient{response: createMockAPIResponse(invalidPlanResponse)}
+	svc, err := NewService(httpClient, &mockBeadsClient{}, slog.Default())
+	require.NoError(t, err)
+
+	plan, err := svc.RefinePlan(context.Background(), validPlan(), &domain.ReviewFeedback{})
+
+	require.Error(t, err)
+	assert.Nil(t, plan)
+	assert.Contains(t, err.Error(), "priority 0")
+}
@@ -0,0 +1,28 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// DefaultTitle returns the title a bead's PR starts with: the bead's title,
+// prefixed with its ID
+func DefaultTitle(task domain.Task) string {
+	title := strings.TrimSpace(task.Title)
+	if title == "" {
+		return fmt.Sprintf("[%s]", task.ID)
+	}
+	return fmt.Sprintf("[%s] %s", task.ID, title)
+}
+
+// DefaultBody returns the body a bead's PR starts with: the bead's
+// description, followed by the link to the bead when autoLink is set
+func DefaultBody(task domain.Task, autoLink bool, linkKeyword string) string {
+	body := strings.TrimSpace(task.Description)
+	if autoLink {
+		body = AppendLink(body, linkKeyword, task.ID)
+	}
+	return body
+}
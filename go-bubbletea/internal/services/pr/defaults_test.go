@@ -0,0 +1,49 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		task domain.Task
+		want string
+	}{
+		{name: "bead title", task: domain.Task{ID: "az-42", Title: "Add login form"}, want: "[az-42] Add login form"},
+		{name: "trims", task: domain.Task{ID: "az-42", Title: "  Add login form\n"}, want: "[az-42] Add login form"},
+		{name: "untitled", task: domain.Task{ID: "az-42"}, want: "[az-42]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultTitle(tt.task))
+		})
+	}
+}
+
+func TestDefaultBody(t *testing.T) {
+	task := domain.Task{ID: "az-42", Description: "Adds the login form.\n\n- validates email\n"}
+
+	tests := []struct {
+		name     string
+		task     domain.Task
+		autoLink bool
+		keyword  string
+		want     string
+	}{
+		{name: "description and link", task: task, autoLink: true, keyword: "Closes", want: "Adds the login form.\n\n- validates email\n\nCloses az-42"},
+		{name: "default keyword", task: task, autoLink: true, keyword: "", want: "Adds the login form.\n\n- validates email\n\nCloses az-42"},
+		{name: "no link", task: task, autoLink: false, keyword: "Closes", want: "Adds the login form.\n\n- validates email"},
+		{name: "no description", task: domain.Task{ID: "az-42"}, autoLink: true, keyword: "Closes", want: "Closes az-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultBody(tt.task, tt.autoLink, tt.keyword))
+		})
+	}
+}
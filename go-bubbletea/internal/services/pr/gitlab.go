@@ -0,0 +1,275 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// GitLabWorkflow manages GitLab merge requests via glab CLI. Merge
+// requests are reported as PRInfo and PRStatus in GitHub's terms, so the
+// rest of the app needn't know which host it's talking to.
+type GitLabWorkflow struct {
+	runner CommandRunner
+	logger *slog.Logger
+}
+
+// gitlabMR is a merge request as glab reports it with --output json
+type gitlabMR struct {
+	IID                 int    `json:"iid"`
+	Title               string `json:"title"`
+	WebURL              string `json:"web_url"`
+	State               string `json:"state"` // opened, closed, merged, locked
+	Draft               bool   `json:"draft"`
+	SourceBranch        string `json:"source_branch"`
+	TargetBranch        string `json:"target_branch"`
+	MergeStatus         string `json:"merge_status"`          // can_be_merged, cannot_be_merged, unchecked
+	DetailedMergeStatus string `json:"detailed_merge_status"` // e.g. not_approved
+}
+
+// info converts the merge request to GitHub's terms
+func (mr gitlabMR) info() PRInfo {
+	return PRInfo{
+		Number:  mr.IID,
+		Title:   mr.Title,
+		URL:     mr.WebURL,
+		State:   mr.state(),
+		Draft:   mr.Draft,
+		Branch:  mr.SourceBranch,
+		BaseRef: mr.TargetBranch,
+	}
+}
+
+// state returns the merge request's state as gh spells it
+func (mr gitlabMR) state() string {
+	switch strings.ToLower(mr.State) {
+	case "opened":
+		return "OPEN"
+	case "merged":
+		return "MERGED"
+	case "closed", "locked":
+		return "CLOSED"
+	}
+	return strings.ToUpper(mr.State)
+}
+
+// status returns the merge request's review and merge state as gh spells
+// it. glab doesn't report approvals, beyond a merge being blocked on them.
+func (mr gitlabMR) status() *PRStatus {
+	status := &PRStatus{State: mr.state(), Mergeable: "UNKNOWN"}
+	switch mr.MergeStatus {
+	case "can_be_merged":
+		status.Mergeable = "MERGEABLE"
+	case "cannot_be_merged":
+		status.Mergeable = "CONFLICTING"
+	}
+	if mr.DetailedMergeStatus == "not_approved" {
+		status.ReviewDecision = "REVIEW_REQUIRED"
+	}
+	return status
+}
+
+// NewGitLabWorkflow creates a new merge request workflow service
+func NewGitLabWorkflow(runner CommandRunner, logger *slog.Logger) *GitLabWorkflow {
+	return &GitLabWorkflow{
+		runner: runner,
+		logger: logger,
+	}
+}
+
+// glabCreateArgs maps PR parameters to glab mr create flags
+func glabCreateArgs(params CreatePRParams) []string {
+	args := []string{
+		"mr", "create",
+		"--title", params.Title,
+		"--description", params.Body,
+		"--source-branch", params.Branch,
+		"--target-branch", params.BaseBranch,
+		"--yes", // Don't prompt for anything left out
+	}
+
+	if params.Draft {
+		args = append(args, "--draft")
+	}
+	return args
+}
+
+// Create creates a new merge request via glab mr create
+func (w *GitLabWorkflow) Create(ctx context.Context, params CreatePRParams) (*PRInfo, error) {
+	w.logger.Debug("creating MR",
+		"title", params.Title,
+		"branch", params.Branch,
+		"base", params.BaseBranch,
+		"draft", params.Draft,
+		"bead_id", params.BeadID,
+	)
+
+	out, err := w.runner.Run(ctx, "glab", glabCreateArgs(params)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MR: %w (output: %s)", err, string(out))
+	}
+
+	w.logger.Info("MR created", "branch", params.Branch)
+
+	// Fetch the MR info using the branch
+	return w.Get(ctx, params.Branch)
+}
+
+// view runs glab mr view for a branch
+func (w *GitLabWorkflow) view(ctx context.Context, branch string) ([]byte, error) {
+	return w.runner.Run(ctx, "glab", "mr", "view", branch, "--output", "json")
+}
+
+// Get retrieves MR information for a given branch
+func (w *GitLabWorkflow) Get(ctx context.Context, branch string) (*PRInfo, error) {
+	w.logger.Debug("fetching MR info", "branch", branch)
+
+	out, err := w.view(ctx, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MR info for branch %s: %w", branch, err)
+	}
+
+	mr, err := parseGitLabMR(out)
+	if err != nil {
+		return nil, err
+	}
+	info := mr.info()
+	return &info, nil
+}
+
+// Status retrieves the review/merge state of the MR for a branch.
+// A branch without an MR is not an error: an empty PRStatus is returned instead.
+func (w *GitLabWorkflow) Status(ctx context.Context, branch string) (*PRStatus, error) {
+	w.logger.Debug("fetching MR status", "branch", branch)
+
+	out, err := w.view(ctx, branch)
+	if err != nil {
+		if isNoMRError(out) {
+			return &PRStatus{}, nil
+		}
+		return nil, fmt.Errorf("failed to get MR status for branch %s: %w (output: %s)", branch, err, string(out))
+	}
+
+	mr, err := parseGitLabMR(out)
+	if err != nil {
+		return nil, err
+	}
+	return mr.status(), nil
+}
+
+// Existing returns the open merge request for a branch, or nil if there is
+// none
+func (w *GitLabWorkflow) Existing(ctx context.Context, branch string) (*PRInfo, error) {
+	w.logger.Debug("checking for existing MR", "branch", branch)
+
+	out, err := w.view(ctx, branch)
+	if err != nil {
+		if isNoMRError(out) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check for MR on branch %s: %w (output: %s)", branch, err, string(out))
+	}
+
+	mr, err := parseGitLabMR(out)
+	if err != nil {
+		return nil, err
+	}
+	info := mr.info()
+	if info.State != "OPEN" {
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// List retrieves all open merge requests
+func (w *GitLabWorkflow) List(ctx context.Context) ([]PRInfo, error) {
+	w.logger.Debug("listing open MRs")
+
+	out, err := w.runner.Run(ctx, "glab", "mr", "list", "--output", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MRs: %w", err)
+	}
+
+	var mrs []gitlabMR
+	if err := json.Unmarshal(out, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to parse MR list JSON: %w", err)
+	}
+
+	prs := make([]PRInfo, 0, len(mrs))
+	for _, mr := range mrs {
+		prs = append(prs, mr.info())
+	}
+	return prs, nil
+}
+
+// Merge merges a merge request using the specified strategy
+func (w *GitLabWorkflow) Merge(ctx context.Context, prNumber int, strategy string) error {
+	w.logger.Debug("merging MR", "number", prNumber, "strategy", strategy)
+
+	args := []string{
+		"mr", "merge", fmt.Sprintf("%d", prNumber), "--yes",
+	}
+
+	// A plain merge is glab's default
+	switch strategy {
+	case "squash":
+		args = append(args, "--squash")
+	case "rebase":
+		args = append(args, "--rebase")
+	case "merge":
+	default:
+		return fmt.Errorf("invalid merge strategy: %s (must be squash, rebase, or merge)", strategy)
+	}
+
+	out, err := w.runner.Run(ctx, "glab", args...)
+	if err != nil {
+		return fmt.Errorf("failed to merge MR %d: %w (output: %s)", prNumber, err, string(out))
+	}
+
+	w.logger.Info("MR merged", "number", prNumber, "strategy", strategy)
+	return nil
+}
+
+// Close closes a merge request without merging
+func (w *GitLabWorkflow) Close(ctx context.Context, prNumber int) error {
+	w.logger.Debug("closing MR", "number", prNumber)
+
+	out, err := w.runner.Run(ctx, "glab", "mr", "close", fmt.Sprintf("%d", prNumber))
+	if err != nil {
+		return fmt.Errorf("failed to close MR %d: %w (output: %s)", prNumber, err, string(out))
+	}
+
+	w.logger.Info("MR closed", "number", prNumber)
+	return nil
+}
+
+// MarkReady marks a draft merge request as ready for review
+func (w *GitLabWorkflow) MarkReady(ctx context.Context, prNumber int) error {
+	w.logger.Debug("marking MR ready for review", "number", prNumber)
+
+	out, err := w.runner.Run(ctx, "glab", "mr", "update", fmt.Sprintf("%d", prNumber), "--ready")
+	if err != nil {
+		return fmt.Errorf("failed to mark MR %d ready: %w (output: %s)", prNumber, err, string(out))
+	}
+
+	w.logger.Info("MR marked ready", "number", prNumber)
+	return nil
+}
+
+// parseGitLabMR parses the JSON output of glab mr view --output json
+func parseGitLabMR(out []byte) (gitlabMR, error) {
+	var mr gitlabMR
+	if err := json.Unmarshal(out, &mr); err != nil {
+		return gitlabMR{}, fmt.Errorf("failed to parse MR JSON: %w", err)
+	}
+	return mr, nil
+}
+
+// isNoMRError reports whether glab output indicates the branch has no
+// merge request
+func isNoMRError(out []byte) bool {
+	lower := strings.ToLower(string(out))
+	return strings.Contains(lower, "no open merge request") || strings.Contains(lower, "404 not found")
+}
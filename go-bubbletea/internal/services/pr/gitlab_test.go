@@ -0,0 +1,184 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingRunner records each command and answers from a function of it
+type recordingRunner struct {
+	calls  []string
+	answer func(args []string) ([]byte, error)
+}
+
+func (r *recordingRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	r.calls = append(r.calls, name+" "+strings.Join(args, " "))
+	if r.answer == nil {
+		return nil, nil
+	}
+	return r.answer(args)
+}
+
+func TestGlabCreateArgs(t *testing.T) {
+	params := CreatePRParams{
+		Title:      "[az-42] Login form",
+		Body:       "Adds the form.\n\nRefs az-42",
+		Branch:     "az/az-42",
+		BaseBranch: "main",
+		BeadID:     "az-42",
+	}
+
+	assert.Equal(t, []string{
+		"mr", "create",
+		"--title", "[az-42] Login form",
+		"--description", "Adds the form.\n\nRefs az-42",
+		"--source-branch", "az/az-42",
+		"--target-branch", "main",
+		"--yes",
+	}, glabCreateArgs(params))
+
+	params.Draft = true
+	assert.Equal(t, "--draft", glabCreateArgs(params)[len(glabCreateArgs(params))-1])
+}
+
+const gitlabMRJSON = `{
+	"iid": 7,
+	"title": "[az-42] Login form",
+	"web_url": "https://gitlab.com/org/repo/-/merge_requests/7",
+	"state": "opened",
+	"draft": true,
+	"source_branch": "az/az-42",
+	"target_branch": "main",
+	"merge_status": "can_be_merged",
+	"detailed_merge_status": "not_approved"
+}`
+
+func TestGitLabWorkflow_Create(t *testing.T) {
+	runner := &recordingRunner{answer: func(args []string) ([]byte, error) {
+		if args[1] == "view" {
+			return []byte(gitlabMRJSON), nil
+		}
+		return []byte("https://gitlab.com/org/repo/-/merge_requests/7\n"), nil
+	}}
+	w := NewGitLabWorkflow(runner, slog.Default())
+
+	info, err := w.Create(context.Background(), CreatePRParams{Title: "T", Body: "B", Branch: "az/az-42", BaseBranch: "main", Draft: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, PRInfo{
+		Number:  7,
+		Title:   "[az-42] Login form",
+		URL:     "https://gitlab.com/org/repo/-/merge_requests/7",
+		State:   "OPEN",
+		Draft:   true,
+		Branch:  "az/az-42",
+		BaseRef: "main",
+	}, *info)
+	require.Len(t, runner.calls, 2)
+	assert.True(t, strings.HasPrefix(runner.calls[0], "glab mr create "), runner.calls[0])
+	assert.Equal(t, "glab mr view az/az-42 --output json", runner.calls[1])
+}
+
+func TestGitLabWorkflow_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		err     error
+		want    PRStatus
+		summary domain.PRState
+		wantErr bool
+	}{
+		{
+			name:    "open, awaiting approval",
+			out:     gitlabMRJSON,
+			want:    PRStatus{State: "OPEN", Mergeable: "MERGEABLE", ReviewDecision: "REVIEW_REQUIRED"},
+			summary: domain.PROpen,
+		},
+		{
+			name:    "merged",
+			out:     `{"iid": 7, "state": "merged", "merge_status": "can_be_merged"}`,
+			want:    PRStatus{State: "MERGED", Mergeable: "MERGEABLE"},
+			summary: domain.PRMerged,
+		},
+		{
+			name:    "conflicting",
+			out:     `{"iid": 7, "state": "opened", "merge_status": "cannot_be_merged"}`,
+			want:    PRStatus{State: "OPEN", Mergeable: "CONFLICTING"},
+			summary: domain.PROpen,
+		},
+		{
+			name:    "no MR",
+			out:     `no open merge request available for "az/az-42"`,
+			err:     errors.New("exit status 1"),
+			want:    PRStatus{},
+			summary: domain.PRNone,
+		},
+		{
+			name:    "glab fails",
+			out:     "could not authenticate",
+			err:     errors.New("exit status 1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &recordingRunner{answer: func(args []string) ([]byte, error) {
+				return []byte(tt.out), tt.err
+			}}
+			status, err := NewGitLabWorkflow(runner, slog.Default()).Status(context.Background(), "az/az-42")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *status)
+			assert.Equal(t, tt.summary, status.Summary())
+		})
+	}
+}
+
+func TestGitLabWorkflow_Merge(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     string
+		wantErr  bool
+	}{
+		{strategy: "merge", want: "glab mr merge 7 --yes"},
+		{strategy: "squash", want: "glab mr merge 7 --yes --squash"},
+		{strategy: "rebase", want: "glab mr merge 7 --yes --rebase"},
+		{strategy: "octopus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			runner := &recordingRunner{}
+			err := NewGitLabWorkflow(runner, slog.Default()).Merge(context.Background(), 7, tt.strategy)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, runner.calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, runner.calls)
+		})
+	}
+}
+
+func TestGitLabWorkflow_CloseAndMarkReady(t *testing.T) {
+	runner := &recordingRunner{}
+	w := NewGitLabWorkflow(runner, slog.Default())
+
+	require.NoError(t, w.Close(context.Background(), 7))
+	require.NoError(t, w.MarkReady(context.Background(), 7))
+	assert.Equal(t, []string{"glab mr close 7", "glab mr update 7 --ready"}, runner.calls)
+}
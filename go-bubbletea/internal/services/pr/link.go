@@ -0,0 +1,36 @@
+package pr
+
+import "strings"
+
+// DefaultLinkKeyword marks the PR as closing its bead
+const DefaultLinkKeyword = "Closes"
+
+// LinkText returns the line linking a PR to its bead. keyword is either a
+// word put before the bead ID, such as "Closes" or "Fixes", or a format
+// where {beadID} stands for the bead ID.
+func LinkText(keyword, beadID string) string {
+	if keyword == "" {
+		keyword = DefaultLinkKeyword
+	}
+	if strings.Contains(keyword, "{beadID}") {
+		return strings.ReplaceAll(keyword, "{beadID}", beadID)
+	}
+	return keyword + " " + beadID
+}
+
+// AppendLink adds the bead link to the end of a PR body, unless the body
+// already has it
+func AppendLink(body, keyword, beadID string) string {
+	if beadID == "" {
+		return body
+	}
+
+	link := LinkText(keyword, beadID)
+	if strings.Contains(body, link) {
+		return body
+	}
+	if strings.TrimSpace(body) == "" {
+		return link
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + link
+}
@@ -0,0 +1,48 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkText(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		want    string
+	}{
+		{name: "default keyword", keyword: "", want: "Closes az-42"},
+		{name: "closes", keyword: "Closes", want: "Closes az-42"},
+		{name: "fixes", keyword: "Fixes", want: "Fixes az-42"},
+		{name: "resolves", keyword: "Resolves", want: "Resolves az-42"},
+		{name: "refs", keyword: "Refs", want: "Refs az-42"},
+		{name: "format", keyword: "Bead: [{beadID}](https://tracker.example.com/{beadID})", want: "Bead: [az-42](https://tracker.example.com/az-42)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LinkText(tt.keyword, "az-42"))
+		})
+	}
+}
+
+func TestAppendLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		beadID string
+		want   string
+	}{
+		{name: "appends after body", body: "Adds login.\n", beadID: "az-42", want: "Adds login.\n\nCloses az-42"},
+		{name: "empty body", body: "", beadID: "az-42", want: "Closes az-42"},
+		{name: "already linked", body: "Adds login.\n\nCloses az-42", beadID: "az-42", want: "Adds login.\n\nCloses az-42"},
+		{name: "no bead", body: "Adds login.", beadID: "", want: "Adds login."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AppendLink(tt.body, "Closes", tt.beadID))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/riordanpawley/azedarach/internal/services/statefile"
+)
+
+// Mapping records the PR created for each bead, keyed by bead ID, so that
+// a bead isn't given a second PR
+type Mapping map[string]string
+
+// DefaultMappingPath returns where a project's PR mapping is kept:
+// ~/.azedarach/prs/<project-dir>-<hash>.json
+func DefaultMappingPath(projectPath string) string {
+	return statefile.Path("prs", projectPath)
+}
+
+// SaveMapping writes mapping to path, replacing the previous file atomically
+func SaveMapping(path string, mapping Mapping) error {
+	if err := statefile.Save(path, mapping); err != nil {
+		return fmt.Errorf("failed to write PR mapping: %w", err)
+	}
+	return nil
+}
+
+// LoadMapping reads the mapping at path. It returns an empty mapping
+// without an error when none has been saved.
+func LoadMapping(path string) (Mapping, error) {
+	mapping := Mapping{}
+	if _, err := statefile.Load(path, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to read PR mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+var prNumberRegex = regexp.MustCompile(`/(?:pull|merge_requests)/(\d+)`)
+
+// NumberFromURL returns the PR number in a GitHub PR or GitLab MR URL, or 0
+// if it has none
+func NumberFromURL(url string) int {
+	matches := prNumberRegex.FindStringSubmatch(url)
+	if matches == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(matches[1])
+	return n
+}
@@ -0,0 +1,50 @@
+package pr
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapping_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prs", "project.json")
+
+	// Nothing saved yet
+	mapping, err := LoadMapping(path)
+	require.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	mapping["az-1"] = "https://github.com/org/repo/pull/7"
+	mapping["az-2"] = "https://github.com/org/repo/pull/9"
+	require.NoError(t, SaveMapping(path, mapping))
+
+	loaded, err := LoadMapping(path)
+	require.NoError(t, err)
+	assert.Equal(t, mapping, loaded)
+
+	// Saving again replaces the file
+	delete(mapping, "az-2")
+	require.NoError(t, SaveMapping(path, mapping))
+	loaded, err = LoadMapping(path)
+	require.NoError(t, err)
+	assert.Equal(t, Mapping{"az-1": "https://github.com/org/repo/pull/7"}, loaded)
+}
+
+func TestNumberFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{"https://github.com/org/repo/pull/42", 42},
+		{"https://github.com/org/repo/pull/42/files", 42},
+		{"https://gitlab.com/org/repo/-/merge_requests/7", 7},
+		{"https://github.com/org/repo", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NumberFromURL(tt.url), tt.url)
+	}
+}
@@ -0,0 +1,58 @@
+package pr
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// Code hosts whose CLI the PR workflow can drive
+const (
+	ProviderGitHub = "github" // gh
+	ProviderGitLab = "gitlab" // glab; pull requests are merge requests
+)
+
+// Provider creates and manages pull requests on a code host
+type Provider interface {
+	Create(ctx context.Context, params CreatePRParams) (*PRInfo, error)
+	Get(ctx context.Context, branch string) (*PRInfo, error)
+	Status(ctx context.Context, branch string) (*PRStatus, error)
+	Existing(ctx context.Context, branch string) (*PRInfo, error)
+	List(ctx context.Context) ([]PRInfo, error)
+	Merge(ctx context.Context, prNumber int, strategy string) error
+	Close(ctx context.Context, prNumber int) error
+	MarkReady(ctx context.Context, prNumber int) error
+}
+
+var (
+	_ Provider = (*PRWorkflow)(nil)
+	_ Provider = (*GitLabWorkflow)(nil)
+)
+
+// NewProvider returns the provider named by name, GitHub unless it's
+// ProviderGitLab
+func NewProvider(name string, runner CommandRunner, logger *slog.Logger) Provider {
+	if name == ProviderGitLab {
+		return NewGitLabWorkflow(runner, logger)
+	}
+	return NewPRWorkflow(runner, logger)
+}
+
+// DetectProvider names the code host of a git remote URL, in either the
+// https or the ssh form. Remotes of unknown hosts are taken to be GitHub.
+func DetectProvider(remoteURL string) string {
+	host := strings.ToLower(remoteURL)
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	host, _, _ = strings.Cut(host, ":") // Port, or the path of git@host:path
+
+	if strings.Contains(host, "gitlab") {
+		return ProviderGitLab
+	}
+	return ProviderGitHub
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
 // PRWorkflow manages GitHub PR operations via gh CLI
@@ -25,6 +27,42 @@ type PRInfo struct {
 	BaseRef  string `json:"baseRefName"`
 }
 
+// PRStatus contains the review and merge state of a branch's pull request.
+// A zero-value PRStatus (empty State) means no PR exists for the branch.
+type PRStatus struct {
+	State          string `json:"state"`          // OPEN, CLOSED, MERGED
+	Mergeable      string `json:"mergeable"`      // MERGEABLE, CONFLICTING, UNKNOWN
+	ReviewDecision string `json:"reviewDecision"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED
+}
+
+// Exists returns true if the status describes an actual pull request
+func (s *PRStatus) Exists() bool {
+	return s != nil && s.State != ""
+}
+
+// Summary collapses the status into a single state for display on cards
+func (s *PRStatus) Summary() domain.PRState {
+	if !s.Exists() {
+		return domain.PRNone
+	}
+
+	switch strings.ToUpper(s.State) {
+	case "MERGED":
+		return domain.PRMerged
+	case "CLOSED":
+		return domain.PRClosed
+	}
+
+	switch strings.ToUpper(s.ReviewDecision) {
+	case "APPROVED":
+		return domain.PRApproved
+	case "CHANGES_REQUESTED":
+		return domain.PRChangesRequested
+	}
+
+	return domain.PROpen
+}
+
 // CreatePRParams contains parameters for creating a pull request
 type CreatePRParams struct {
 	Title      string
@@ -105,6 +143,73 @@ func (w *PRWorkflow) Get(ctx context.Context, branch string) (*PRInfo, error) {
 	return &info, nil
 }
 
+// Status retrieves the review/merge state of the PR for a branch.
+// A branch without a PR is not an error: an empty PRStatus is returned instead.
+func (w *PRWorkflow) Status(ctx context.Context, branch string) (*PRStatus, error) {
+	w.logger.Debug("fetching PR status", "branch", branch)
+
+	args := []string{
+		"pr", "view", branch,
+		"--json", "state,mergeable,reviewDecision",
+	}
+
+	out, err := w.runner.Run(ctx, "gh", args...)
+	if err != nil {
+		if isNoPRError(out) {
+			return &PRStatus{}, nil
+		}
+		return nil, fmt.Errorf("failed to get PR status for branch %s: %w (output: %s)", branch, err, string(out))
+	}
+
+	return parsePRStatus(out)
+}
+
+// parsePRStatus parses the JSON output of gh pr view --json state,mergeable,reviewDecision
+func parsePRStatus(out []byte) (*PRStatus, error) {
+	var status PRStatus
+	if err := json.Unmarshal(out, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse PR status JSON: %w", err)
+	}
+	return &status, nil
+}
+
+// isNoPRError reports whether gh output indicates the branch has no pull request
+func isNoPRError(out []byte) bool {
+	return strings.Contains(strings.ToLower(string(out)), "no pull requests found")
+}
+
+// Existing returns the open pull request for a branch, or nil if there is
+// none. Closed and merged PRs don't count, since a new PR can be created
+// for the branch.
+func (w *PRWorkflow) Existing(ctx context.Context, branch string) (*PRInfo, error) {
+	w.logger.Debug("checking for existing PR", "branch", branch)
+
+	args := []string{
+		"pr", "view", branch,
+		"--json", "url,state",
+	}
+
+	out, err := w.runner.Run(ctx, "gh", args...)
+	if err != nil {
+		if isNoPRError(out) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check for PR on branch %s: %w (output: %s)", branch, err, string(out))
+	}
+
+	var info PRInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse PR JSON: %w", err)
+	}
+	if !strings.EqualFold(info.State, "OPEN") {
+		return nil, nil
+	}
+
+	info.Number = NumberFromURL(info.URL)
+	info.Branch = branch
+	return &info, nil
+}
+
 // List retrieves all open pull requests
 func (w *PRWorkflow) List(ctx context.Context) ([]PRInfo, error) {
 	w.logger.Debug("listing open PRs")
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -412,3 +413,153 @@ func TestPRWorkflow_MarkReady(t *testing.T) {
 		})
 	}
 }
+
+func TestPRWorkflow_Status(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		runErr      error
+		wantExists  bool
+		wantSummary domain.PRState
+		wantErr     bool
+	}{
+		{
+			name:        "open PR awaiting review",
+			output:      `{"state": "OPEN", "mergeable": "MERGEABLE", "reviewDecision": "REVIEW_REQUIRED"}`,
+			wantExists:  true,
+			wantSummary: domain.PROpen,
+		},
+		{
+			name:        "approved PR",
+			output:      `{"state": "OPEN", "mergeable": "MERGEABLE", "reviewDecision": "APPROVED"}`,
+			wantExists:  true,
+			wantSummary: domain.PRApproved,
+		},
+		{
+			name:        "changes requested",
+			output:      `{"state": "OPEN", "mergeable": "CONFLICTING", "reviewDecision": "CHANGES_REQUESTED"}`,
+			wantExists:  true,
+			wantSummary: domain.PRChangesRequested,
+		},
+		{
+			name:        "merged PR",
+			output:      `{"state": "MERGED", "mergeable": "UNKNOWN", "reviewDecision": "APPROVED"}`,
+			wantExists:  true,
+			wantSummary: domain.PRMerged,
+		},
+		{
+			name:        "closed PR",
+			output:      `{"state": "CLOSED", "mergeable": "UNKNOWN", "reviewDecision": ""}`,
+			wantExists:  true,
+			wantSummary: domain.PRClosed,
+		},
+		{
+			name:        "no PR for branch",
+			output:      `no pull requests found for branch "az/az-1"`,
+			runErr:      errors.New("exit status 1"),
+			wantExists:  false,
+			wantSummary: domain.PRNone,
+		},
+		{
+			name:    "runner error",
+			output:  "HTTP 502",
+			runErr:  errors.New("exit status 1"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			output:  `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				output: []byte(tt.output),
+				err:    tt.runErr,
+			}
+			workflow := NewPRWorkflow(runner, slog.Default())
+
+			status, err := workflow.Status(context.Background(), "az/az-1")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExists, status.Exists())
+			assert.Equal(t, tt.wantSummary, status.Summary())
+		})
+	}
+}
+
+func TestPRWorkflow_Existing(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		runErr     error
+		wantURL    string
+		wantNumber int
+		wantErr    bool
+	}{
+		{
+			name:       "open PR",
+			output:     `{"url": "https://github.com/owner/repo/pull/42", "state": "OPEN"}`,
+			wantURL:    "https://github.com/owner/repo/pull/42",
+			wantNumber: 42,
+		},
+		{
+			name:   "merged PR",
+			output: `{"url": "https://github.com/owner/repo/pull/41", "state": "MERGED"}`,
+		},
+		{
+			name:   "closed PR",
+			output: `{"url": "https://github.com/owner/repo/pull/40", "state": "CLOSED"}`,
+		},
+		{
+			name:   "no PR for branch",
+			output: `no pull requests found for branch "az/az-1"`,
+			runErr: errors.New("exit status 1"),
+		},
+		{
+			name:    "runner error",
+			output:  "HTTP 502",
+			runErr:  errors.New("exit status 1"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			output:  `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{
+				output: []byte(tt.output),
+				err:    tt.runErr,
+			}
+			workflow := NewPRWorkflow(runner, slog.Default())
+
+			info, err := workflow.Existing(context.Background(), "az/az-1")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.wantURL == "" {
+				assert.Nil(t, info)
+				return
+			}
+			require.NotNil(t, info)
+			assert.Equal(t, tt.wantURL, info.URL)
+			assert.Equal(t, tt.wantNumber, info.Number)
+			assert.Equal(t, "az/az-1", info.Branch)
+		})
+	}
+}
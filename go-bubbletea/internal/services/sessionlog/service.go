@@ -0,0 +1,222 @@
+package sessionlog
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxBytes caps a session log before it is rotated, when no cap is
+// configured
+const DefaultMaxBytes = 10 * 1024 * 1024
+
+// Service persists captured session output to per-bead log files so a
+// transcript survives beyond tmux scrollback and after the session ends.
+type Service struct {
+	dir      string
+	maxBytes int64
+	logger   *slog.Logger
+
+	mu sync.Mutex
+	// Last captured snapshot per bead, used to skip lines already written
+	lastLines map[string][]string
+}
+
+// NewService creates a session log service writing under dir.
+// A leading "~/" in dir is expanded to the user's home directory.
+func NewService(dir string, maxBytes int64, logger *slog.Logger) *Service {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &Service{
+		dir:       expandHome(dir),
+		maxBytes:  maxBytes,
+		logger:    logger,
+		lastLines: make(map[string][]string),
+	}
+}
+
+// SetDir changes the directory later output is written to, e.g. for a
+// project configured with its own log directory. Logs already written stay
+// where they are.
+func (s *Service) SetDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dir = expandHome(dir)
+}
+
+// SetMaxBytes changes the size at which logs are rotated; 0 or less is
+// DefaultMaxBytes
+func (s *Service) SetMaxBytes(maxBytes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	s.maxBytes = maxBytes
+}
+
+// Path returns the log file path for a bead
+func (s *Service) Path(beadID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logPath(beadID)
+}
+
+// logPath is Path for callers already holding s.mu
+func (s *Service) logPath(beadID string) string {
+	return filepath.Join(s.dir, beadID+".log")
+}
+
+// rotatedPath returns the path of the previous (rotated) log for a bead.
+// Callers hold s.mu.
+func (s *Service) rotatedPath(beadID string) string {
+	return s.logPath(beadID) + ".1"
+}
+
+// Append writes the lines of a pane capture that were not already written
+// by the previous Append for the same bead. Captures are overlapping windows
+// of the pane, so only the part following the overlap is new.
+func (s *Service) Append(beadID, output string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := splitLines(output)
+	fresh := newLines(s.lastLines[beadID], current)
+	s.lastLines[beadID] = current
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if err := s.rotateIfNeeded(beadID); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.logPath(beadID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(strings.Join(fresh, "\n") + "\n"); err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+
+	s.logger.Debug("session log appended", "bead_id", beadID, "lines", len(fresh))
+	return nil
+}
+
+// Summary returns the last maxLines non-blank lines of a capture, used to
+// record what a session did without copying its whole transcript
+func Summary(output string, maxLines int) string {
+	var lines []string
+	for _, line := range splitLines(output) {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Forget drops the dedup state for a bead, e.g. once its session has ended
+func (s *Service) Forget(beadID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.lastLines, beadID)
+}
+
+// rotateIfNeeded moves the log aside once it reaches maxBytes, replacing
+// any previous rotation
+func (s *Service) rotateIfNeeded(beadID string) error {
+	info, err := os.Stat(s.logPath(beadID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat session log: %w", err)
+	}
+
+	if info.Size() < s.maxBytes {
+		return nil
+	}
+
+	if err := os.Rename(s.logPath(beadID), s.rotatedPath(beadID)); err != nil {
+		return fmt.Errorf("failed to rotate session log: %w", err)
+	}
+
+	s.logger.Info("session log rotated", "bead_id", beadID, "size", info.Size())
+	return nil
+}
+
+// newLines returns the lines of current that follow previous. A capture
+// reaching further back, such as the whole scrollback archived when a
+// session ends, holds all of previous somewhere inside it; otherwise the
+// two overlap where the longest suffix of previous is a prefix of current.
+func newLines(previous, current []string) []string {
+	if len(previous) == 0 {
+		return current
+	}
+
+	// Searched from the end, so the latest copy of repeated output wins
+	for start := len(current) - len(previous); start > 0; start-- {
+		if equalLines(previous, current[start:start+len(previous)]) {
+			return current[start+len(previous):]
+		}
+	}
+
+	maxOverlap := min(len(previous), len(current))
+
+	for overlap := maxOverlap; overlap > 0; overlap-- {
+		if equalLines(previous[len(previous)-overlap:], current[:overlap]) {
+			return current[overlap:]
+		}
+	}
+
+	return current
+}
+
+// equalLines reports whether two line slices are identical
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitLines splits a pane capture into lines, dropping the blank padding
+// tmux adds below the cursor
+func splitLines(output string) []string {
+	trimmed := strings.TrimRight(output, "\n ")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "\n")
+}
+
+// expandHome expands a leading "~/" to the user's home directory
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
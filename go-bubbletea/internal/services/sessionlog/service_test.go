@@ -0,0 +1,129 @@
+package sessionlog
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous []string
+		current  []string
+		want     []string
+	}{
+		{
+			name:    "first capture",
+			current: []string{"a", "b"},
+			want:    []string{"a", "b"},
+		},
+		{
+			name:     "unchanged capture",
+			previous: []string{"a", "b", "c"},
+			current:  []string{"a", "b", "c"},
+			want:     []string{},
+		},
+		{
+			name:     "appended lines",
+			previous: []string{"a", "b"},
+			current:  []string{"a", "b", "c", "d"},
+			want:     []string{"c", "d"},
+		},
+		{
+			name:     "window scrolled",
+			previous: []string{"a", "b", "c"},
+			current:  []string{"c", "d", "e"},
+			want:     []string{"d", "e"},
+		},
+		{
+			name:     "repeated lines use longest overlap",
+			previous: []string{"$", "ok", "$"},
+			current:  []string{"$", "ok", "$", "ok"},
+			want:     []string{"ok"},
+		},
+		{
+			name:     "longer capture holds the previous window",
+			previous: []string{"c", "d"},
+			current:  []string{"a", "b", "c", "d", "e"},
+			want:     []string{"e"},
+		},
+		{
+			name:     "no overlap",
+			previous: []string{"a", "b"},
+			current:  []string{"x", "y"},
+			want:     []string{"x", "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newLines(tt.previous, tt.current)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestService_AppendDeduplicates(t *testing.T) {
+	svc := NewService(t.TempDir(), 0, slog.Default())
+
+	require.NoError(t, svc.Append("az-1", "line 1\nline 2\n\n\n"))
+	require.NoError(t, svc.Append("az-1", "line 1\nline 2\n"))
+	require.NoError(t, svc.Append("az-1", "line 2\nline 3\nline 4\n"))
+
+	data, err := os.ReadFile(svc.Path("az-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "line 1\nline 2\nline 3\nline 4\n", string(data))
+}
+
+func TestService_AppendSeparatesBeads(t *testing.T) {
+	svc := NewService(t.TempDir(), 0, slog.Default())
+
+	require.NoError(t, svc.Append("az-1", "one"))
+	require.NoError(t, svc.Append("az-2", "one"))
+
+	for _, beadID := range []string{"az-1", "az-2"} {
+		got, err := os.ReadFile(svc.Path(beadID))
+		require.NoError(t, err)
+		assert.Equal(t, "one\n", string(got))
+	}
+}
+
+func TestService_Rotate(t *testing.T) {
+	svc := NewService(t.TempDir(), 0, slog.Default())
+	svc.SetMaxBytes(10)
+
+	require.NoError(t, svc.Append("az-1", "0123456789"))
+	require.NoError(t, svc.Append("az-1", "0123456789\nnext"))
+
+	rotated, err := os.ReadFile(svc.rotatedPath("az-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789\n", string(rotated))
+
+	current, err := os.ReadFile(svc.Path("az-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "next\n", string(current))
+}
+
+func TestSummary(t *testing.T) {
+	output := "start\n\nstep 1\nstep 2\n   \ndone\n\n"
+
+	assert.Equal(t, "step 2\ndone", Summary(output, 2))
+	assert.Equal(t, "start\nstep 1\nstep 2\ndone", Summary(output, 10))
+	assert.Empty(t, Summary("", 5))
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(expandHome("~/logs"), home))
+	assert.Equal(t, "/var/log", expandHome("/var/log"))
+}
@@ -0,0 +1,82 @@
+// Package statefile keeps small per-project JSON state files under
+// ~/.azedarach, such as plan drafts, the offline queue and the PR mapping
+package statefile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Path returns where state of the given kind is kept for a project:
+// ~/.azedarach/<kind>/<project-dir>-<hash>.json. The hash of the project's
+// absolute path keeps apart projects that share a directory name.
+func Path(kind, projectPath string) string {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	sum := sha256.Sum256([]byte(projectPath))
+	name := filepath.Base(projectPath) + "-" + hex.EncodeToString(sum[:6]) + ".json"
+
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".azedarach", kind, name)
+}
+
+// Save writes v to path as JSON, replacing the previous file atomically
+func Save(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// A unique temp file in the same directory, so concurrent saves don't
+	// write over each other's halves and the rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads the JSON at path into v. It reports false without an error
+// when nothing has been saved.
+func Load(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove deletes the file at path, if any
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package statefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath_KeepsSameNamedProjectsApart(t *testing.T) {
+	a := Path("plans", "/work/client-a/app")
+	b := Path("plans", "/work/client-b/app")
+
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, "plans", filepath.Base(filepath.Dir(a)))
+	assert.Regexp(t, `^app-[0-9a-f]{12}\.json$`, filepath.Base(a))
+	assert.Equal(t, a, Path("plans", "/work/client-a/app/"), "the same project should map to the same file")
+}
+
+func TestSaveLoadRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue", "project.json")
+
+	var got map[string]int
+	ok, err := Load(path, &got)
+	require.NoError(t, err)
+	assert.False(t, ok, "nothing saved yet")
+
+	require.NoError(t, Save(path, map[string]int{"a": 1}))
+	require.NoError(t, Save(path, map[string]int{"b": 2}))
+
+	ok, err = Load(path, &got)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int{"b": 2}, got)
+
+	// No temp files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, Remove(path))
+	require.NoError(t, Remove(path), "removing twice is fine")
+	ok, err = Load(path, &got)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+
+	var got map[string]int
+	_, err := Load(path, &got)
+	assert.Error(t, err)
+}
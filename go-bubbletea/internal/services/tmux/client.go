@@ -2,8 +2,11 @@ package tmux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
@@ -59,6 +62,52 @@ func (c *Client) HasSession(ctx context.Context, name string) (bool, error) {
 	return true, nil
 }
 
+// SessionExists checks if a tmux session with the given name exists.
+// Unlike HasSession, only has-session's answers that the session is gone
+// count as missing; failing to run tmux at all is returned as an error.
+// Uses: tmux has-session -t <name>
+func (c *Client) SessionExists(ctx context.Context, name string) (bool, error) {
+	c.logger.Debug("checking tmux session", "name", name)
+
+	_, err := c.runner.Run(ctx, "has-session", "-t", name)
+	if err == nil {
+		return true, nil
+	}
+	if isMissingSession(err) {
+		c.logger.Debug("tmux session not found", "name", name)
+		return false, nil
+	}
+	return false, &domain.TmuxError{Op: "has-session", Session: name, Err: err}
+}
+
+// missingSessionMessages are what has-session prints when the session
+// isn't there, including when no tmux server is running at all
+var missingSessionMessages = []string{
+	"can't find session",
+	"session not found",
+	"no server running",
+	"error connecting to",
+}
+
+// isMissingSession reports whether a has-session failure means the session
+// doesn't exist, going by tmux's stderr
+func isMissingSession(err error) bool {
+	var output string
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		output = string(exitErr.Stderr)
+	} else {
+		output = err.Error()
+	}
+
+	for _, msg := range missingSessionMessages {
+		if strings.Contains(output, msg) {
+			return true
+		}
+	}
+	return false
+}
+
 // AttachSession attaches to an existing tmux session
 // Note: This is a blocking operation meant to be used with exec.Cmd
 // Uses: tmux attach-session -t <name>
@@ -73,6 +122,11 @@ func (c *Client) AttachSession(ctx context.Context, name string) error {
 	return nil
 }
 
+// AttachCommand returns the shell command a user can run to attach to a session
+func (c *Client) AttachCommand(name string) string {
+	return "tmux attach-session -t " + name
+}
+
 // KillSession terminates a tmux session
 // Uses: tmux kill-session -t <name>
 func (c *Client) KillSession(ctx context.Context, name string) error {
@@ -87,20 +141,40 @@ func (c *Client) KillSession(ctx context.Context, name string) error {
 	return nil
 }
 
-// SendKeys sends keystrokes to a tmux session
-// Uses: tmux send-keys -t <name> <keys> C-m
+// SendKeys types keys into a tmux session followed by Enter. The keys are
+// sent literally, so text such as "Enter" or "Escape" isn't read as a key
+// name; use SendKey to press one.
+// Uses: tmux send-keys -t <name> -l <keys>
+//
+//	tmux send-keys -t <name> Enter
 func (c *Client) SendKeys(ctx context.Context, name string, keys string) error {
 	c.logger.Debug("sending keys to tmux session", "name", name, "keys", keys)
 
-	_, err := c.runner.Run(ctx, "send-keys", "-t", name, keys, "C-m")
-	if err != nil {
-		return &domain.TmuxError{Op: "send-keys", Session: name, Err: err}
+	for _, args := range [][]string{
+		{"send-keys", "-t", name, "-l", keys},
+		{"send-keys", "-t", name, "Enter"},
+	} {
+		if _, err := c.runner.Run(ctx, args...); err != nil {
+			return &domain.TmuxError{Op: "send-keys", Session: name, Err: err}
+		}
 	}
 
 	c.logger.Debug("keys sent to tmux session", "name", name)
 	return nil
 }
 
+// SendKey presses the key tmux names key, such as "C-c" or "Escape", in a
+// tmux session, without pressing Enter after it
+// Uses: tmux send-keys -t <name> <key>
+func (c *Client) SendKey(ctx context.Context, name string, key string) error {
+	c.logger.Debug("sending key to tmux session", "name", name, "key", key)
+
+	if _, err := c.runner.Run(ctx, "send-keys", "-t", name, key); err != nil {
+		return &domain.TmuxError{Op: "send-keys", Session: name, Err: err}
+	}
+	return nil
+}
+
 // CapturePane captures the last N lines from a tmux session's pane
 // Uses: tmux capture-pane -t <name> -p -S -<lines>
 func (c *Client) CapturePane(ctx context.Context, name string, lines int) (string, error) {
@@ -138,6 +212,28 @@ func (c *Client) ListSessions(ctx context.Context) ([]string, error) {
 	return sessions, nil
 }
 
+// PanePIDs returns the process ID of the program running in each pane of a
+// session, in every window
+// Uses: tmux list-panes -s -t <name> -F "#{pane_pid}"
+func (c *Client) PanePIDs(ctx context.Context, name string) ([]int, error) {
+	c.logger.Debug("listing tmux pane pids", "name", name)
+
+	out, err := c.runner.Run(ctx, "list-panes", "-s", "-t", name, "-F", "#{pane_pid}")
+	if err != nil {
+		return nil, &domain.TmuxError{Op: "list-panes", Session: name, Err: err}
+	}
+
+	var pids []int
+	for _, line := range strings.Fields(out) {
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, &domain.TmuxError{Op: "list-panes", Session: name, Err: fmt.Errorf("bad pane pid %q", line)}
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
 // SetEnvironment sets an environment variable in a tmux session
 // Uses: tmux set-environment -t <name> <key> <value>
 func (c *Client) SetEnvironment(ctx context.Context, name, key, value string) error {
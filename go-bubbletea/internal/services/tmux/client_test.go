@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"os/exec"
 	"testing"
 
 	"github.com/riordanpawley/azedarach/internal/domain"
@@ -111,6 +112,12 @@ func TestClient_HasSession(t *testing.T) {
 	}
 }
 
+func TestClient_AttachCommand(t *testing.T) {
+	client := NewClient(&mockRunner{}, slog.Default())
+
+	assert.Equal(t, "tmux attach-session -t az-1", client.AttachCommand("az-1"))
+}
+
 func TestClient_KillSession(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -153,21 +160,39 @@ func TestClient_KillSession(t *testing.T) {
 
 func TestClient_SendKeys(t *testing.T) {
 	tests := []struct {
-		name    string
-		session string
-		keys    string
-		runErr  error
-		wantErr bool
+		name      string
+		session   string
+		keys      string
+		runErr    error
+		wantErr   bool
+		wantCalls [][]string
 	}{
 		{
 			name:    "send simple command",
 			session: "test-session",
 			keys:    "echo hello",
+			wantCalls: [][]string{
+				{"send-keys", "-t", "test-session", "-l", "echo hello"},
+				{"send-keys", "-t", "test-session", "Enter"},
+			},
+		},
+		{
+			name:    "text that names a key is typed",
+			session: "test-session",
+			keys:    "Escape",
+			wantCalls: [][]string{
+				{"send-keys", "-t", "test-session", "-l", "Escape"},
+				{"send-keys", "-t", "test-session", "Enter"},
+			},
 		},
 		{
-			name:    "send complex command",
+			name:    "control key name is typed",
 			session: "test-session",
-			keys:    "cd /tmp && ls -la",
+			keys:    "C-c",
+			wantCalls: [][]string{
+				{"send-keys", "-t", "test-session", "-l", "C-c"},
+				{"send-keys", "-t", "test-session", "Enter"},
+			},
 		},
 		{
 			name:    "runner error",
@@ -180,7 +205,7 @@ func TestClient_SendKeys(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			runner := &mockRunner{err: tt.runErr}
+			runner := &recordingRunner{failOn: "send-keys", failWith: tt.runErr}
 			client := NewClient(runner, slog.Default())
 
 			err := client.SendKeys(context.Background(), tt.session, tt.keys)
@@ -194,10 +219,26 @@ func TestClient_SendKeys(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, runner.calls)
 		})
 	}
 }
 
+func TestClient_SendKey(t *testing.T) {
+	runner := &recordingRunner{}
+	client := NewClient(runner, slog.Default())
+
+	require.NoError(t, client.SendKey(context.Background(), "test-session", "C-c"))
+	assert.Equal(t, [][]string{{"send-keys", "-t", "test-session", "C-c"}}, runner.calls, "pressed without Enter")
+
+	runner = &recordingRunner{failOn: "send-keys", failWith: errors.New("no session")}
+	client = NewClient(runner, slog.Default())
+	err := client.SendKey(context.Background(), "test-session", "C-c")
+	var tmuxErr *domain.TmuxError
+	require.ErrorAs(t, err, &tmuxErr)
+	assert.Equal(t, "send-keys", tmuxErr.Op)
+}
+
 func TestClient_CapturePane(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -313,6 +354,51 @@ func TestClient_ListSessions(t *testing.T) {
 	}
 }
 
+func TestClient_PanePIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		runErr   error
+		wantPIDs []int
+		wantErr  bool
+	}{
+		{
+			name:     "one pid per pane",
+			output:   "4242\n4250\n",
+			wantPIDs: []int{4242, 4250},
+		},
+		{
+			name:    "missing session",
+			runErr:  errors.New("can't find session"),
+			wantErr: true,
+		},
+		{
+			name:    "unreadable pid",
+			output:  "4242\nnope\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{output: tt.output, err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			pids, err := client.PanePIDs(context.Background(), "az-1")
+
+			if tt.wantErr {
+				var tmuxErr *domain.TmuxError
+				require.ErrorAs(t, err, &tmuxErr)
+				assert.Equal(t, "list-panes", tmuxErr.Op)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPIDs, pids)
+		})
+	}
+}
+
 func TestClient_SetEnvironment(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -393,3 +479,62 @@ func TestClient_ErrorWrapping(t *testing.T) {
 		assert.Contains(t, err.Error(), "session-123")
 	})
 }
+
+func TestClient_SessionExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		runErr     error
+		wantExists bool
+		wantErr    bool
+	}{
+		{
+			name:       "session exists",
+			wantExists: true,
+		},
+		{
+			name:   "session missing",
+			runErr: &exec.ExitError{Stderr: []byte("can't find session: az-1\n")},
+		},
+		{
+			name:   "older tmux wording",
+			runErr: &exec.ExitError{Stderr: []byte("session not found: az-1\n")},
+		},
+		{
+			name:   "no server running",
+			runErr: &exec.ExitError{Stderr: []byte("no server running on /tmp/tmux-1000/default\n")},
+		},
+		{
+			name:   "no server socket",
+			runErr: &exec.ExitError{Stderr: []byte("error connecting to /tmp/tmux-1000/default (No such file or directory)\n")},
+		},
+		{
+			name:    "tmux not installed",
+			runErr:  exec.ErrNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "timed out",
+			runErr:  context.DeadlineExceeded,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(&mockRunner{err: tt.runErr}, slog.Default())
+
+			exists, err := client.SessionExists(context.Background(), "az-1")
+
+			if tt.wantErr {
+				var tmuxErr *domain.TmuxError
+				require.ErrorAs(t, err, &tmuxErr)
+				assert.Equal(t, "has-session", tmuxErr.Op)
+				assert.False(t, exists)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExists, exists)
+		})
+	}
+}
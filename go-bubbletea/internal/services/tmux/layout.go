@@ -0,0 +1,81 @@
+package tmux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// Ways a pane can be split off the pane before it
+const (
+	SplitRight = "right"
+	SplitBelow = "below"
+)
+
+// Pane is one pane of a Layout
+type Pane struct {
+	Split   string // SplitRight (the default) or SplitBelow the pane before it
+	Size    int    // Percent of the pane split from; 0 splits evenly
+	Command string // Run once the pane is open
+}
+
+// Layout is a pane template for a new session. The session's first pane,
+// where the agent runs, is left as it is and keeps focus.
+type Layout struct {
+	Panes []Pane
+}
+
+// splitArgs returns the split-window arguments opening pane off target.
+// -d keeps focus on the agent's pane, which SendKeys and CapturePane use.
+func splitArgs(target, workdir string, pane Pane) []string {
+	args := []string{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", target}
+	if pane.Split == SplitBelow {
+		args = append(args, "-v")
+	} else {
+		args = append(args, "-h")
+	}
+	if pane.Size > 0 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", pane.Size))
+	}
+	if workdir != "" {
+		args = append(args, "-c", workdir)
+	}
+	return args
+}
+
+// ApplyLayout splits a new session's window into layout's panes, each off
+// the one before it. initCommands are typed into the first of them, the
+// shell pane, ahead of its own command.
+// Uses: tmux split-window -d -P -F "#{pane_id}" -t <target> -h|-v [-l <size>%] -c <workdir>
+func (c *Client) ApplyLayout(ctx context.Context, name, workdir string, layout Layout, initCommands []string) error {
+	c.logger.Debug("applying tmux layout", "name", name, "panes", len(layout.Panes))
+
+	target := name
+	for i, pane := range layout.Panes {
+		out, err := c.runner.Run(ctx, splitArgs(target, workdir, pane)...)
+		if err != nil {
+			return &domain.TmuxError{Op: "split-window", Session: name, Err: err}
+		}
+		paneID := strings.TrimSpace(out)
+
+		var commands []string
+		if i == 0 {
+			commands = append(commands, initCommands...)
+		}
+		if pane.Command != "" {
+			commands = append(commands, pane.Command)
+		}
+		for _, command := range commands {
+			if _, err := c.runner.Run(ctx, "send-keys", "-t", paneID, command, "C-m"); err != nil {
+				return &domain.TmuxError{Op: "send-keys", Session: name, Err: err}
+			}
+		}
+
+		target = paneID
+	}
+
+	c.logger.Debug("tmux layout applied", "name", name)
+	return nil
+}
@@ -0,0 +1,75 @@
+package tmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingRunner records each tmux command and answers split-window with
+// a new pane ID, as -P -F "#{pane_id}" would
+type recordingRunner struct {
+	calls    [][]string
+	panes    int
+	failOn   string
+	failWith error
+}
+
+func (r *recordingRunner) Run(ctx context.Context, args ...string) (string, error) {
+	r.calls = append(r.calls, args)
+	if args[0] == r.failOn {
+		return "", r.failWith
+	}
+	if args[0] == "split-window" {
+		r.panes++
+		return fmt.Sprintf("%%%d\n", r.panes), nil
+	}
+	return "", nil
+}
+
+func TestClient_ApplyLayout_TwoPanes(t *testing.T) {
+	runner := &recordingRunner{}
+	client := NewClient(runner, slog.Default())
+
+	layout := Layout{Panes: []Pane{
+		{Split: SplitRight, Size: 35},
+		{Split: SplitBelow, Command: "tail -f dev.log"},
+	}}
+	err := client.ApplyLayout(context.Background(), "az-1", "/wt/az-1", layout, []string{"source ~/.zshrc", "nvm use"})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", "az-1", "-h", "-l", "35%", "-c", "/wt/az-1"},
+		{"send-keys", "-t", "%1", "source ~/.zshrc", "C-m"},
+		{"send-keys", "-t", "%1", "nvm use", "C-m"},
+		{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", "%1", "-v", "-c", "/wt/az-1"},
+		{"send-keys", "-t", "%2", "tail -f dev.log", "C-m"},
+	}, runner.calls)
+}
+
+func TestClient_ApplyLayout_Empty(t *testing.T) {
+	runner := &recordingRunner{}
+	client := NewClient(runner, slog.Default())
+
+	err := client.ApplyLayout(context.Background(), "az-1", "/wt/az-1", Layout{}, []string{"source ~/.zshrc"})
+	require.NoError(t, err)
+	assert.Empty(t, runner.calls, "no panes means nothing to split and no shell pane for init commands")
+}
+
+func TestClient_ApplyLayout_Error(t *testing.T) {
+	runner := &recordingRunner{failOn: "split-window", failWith: errors.New("no space for new pane")}
+	client := NewClient(runner, slog.Default())
+
+	err := client.ApplyLayout(context.Background(), "az-1", "", Layout{Panes: []Pane{{}, {}}}, nil)
+
+	var tmuxErr *domain.TmuxError
+	require.ErrorAs(t, err, &tmuxErr)
+	assert.Equal(t, "split-window", tmuxErr.Op)
+	assert.Len(t, runner.calls, 1, "a failed split stops the layout")
+}
@@ -8,7 +8,7 @@ import (
 
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/services/monitor"
-	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/services/multiplexer"
 )
 
 // StateChangeCallback is called when a session's state changes
@@ -16,7 +16,7 @@ type StateChangeCallback func(beadID string, state domain.SessionState)
 
 // SessionMonitor monitors tmux sessions for Claude state changes
 type SessionMonitor struct {
-	tmux      *tmux.Client
+	tmux      multiplexer.Multiplexer
 	mu        sync.RWMutex
 	monitors  map[string]*monitorState
 	wg        sync.WaitGroup
@@ -33,7 +33,7 @@ type monitorState struct {
 }
 
 // NewSessionMonitor creates a new session monitor
-func NewSessionMonitor(tmuxClient *tmux.Client, logger *slog.Logger) *SessionMonitor {
+func NewSessionMonitor(tmuxClient multiplexer.Multiplexer, logger *slog.Logger) *SessionMonitor {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -42,7 +42,7 @@ func NewSessionMonitor(tmuxClient *tmux.Client, logger *slog.Logger) *SessionMon
 		tmux:      tmuxClient,
 		monitors:  make(map[string]*monitorState),
 		logger:    logger,
-		pollLines: 100, // Capture last 100 lines by default
+		pollLines: monitor.DefaultCaptureLines,
 	}
 }
 
@@ -143,7 +143,7 @@ func (m *SessionMonitor) monitorLoop(ctx context.Context, state *monitorState) {
 			}
 
 			// Detect state from output using monitor package patterns
-			newState := monitor.DetectState(output)
+			newState := monitor.DetectStateInWindow(output, m.pollLines).State
 
 			// Check if state changed
 			m.mu.Lock()
@@ -11,7 +11,7 @@ import (
 	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/services/git"
-	"github.com/riordanpawley/azedarach/internal/services/tmux"
+	"github.com/riordanpawley/azedarach/internal/services/multiplexer"
 )
 
 // SessionStatus represents the current status of a worktree session
@@ -37,7 +37,7 @@ type WorktreeSession struct {
 
 // WorktreeSessionService manages Claude sessions in git worktrees
 type WorktreeSessionService struct {
-	tmux        *tmux.Client
+	tmux        multiplexer.Multiplexer
 	git         *git.Client
 	worktree    *git.WorktreeManager
 	projectRoot string
@@ -50,7 +50,7 @@ type WorktreeSessionService struct {
 
 // NewWorktreeSessionService creates a new worktree session service
 func NewWorktreeSessionService(
-	tmuxClient *tmux.Client,
+	tmuxClient multiplexer.Multiplexer,
 	gitClient *git.Client,
 	worktreeManager *git.WorktreeManager,
 	projectRoot string,
@@ -194,7 +194,7 @@ func (s *WorktreeSessionService) Stop(ctx context.Context, beadID string) error
 	s.logger.Info("stopping Claude session", "beadID", beadID)
 
 	// Send Ctrl+C to interrupt the session
-	if err := s.tmux.SendKeys(ctx, session.TmuxSession, "C-c"); err != nil {
+	if err := s.tmux.SendKey(ctx, session.TmuxSession, "C-c"); err != nil {
 		return fmt.Errorf("failed to send interrupt to tmux session: %w", err)
 	}
 
@@ -38,6 +38,10 @@ func (m *mockTmuxClient) SendKeys(ctx context.Context, name string, keys string)
 	return nil
 }
 
+func (m *mockTmuxClient) SendKey(ctx context.Context, name string, key string) error {
+	return nil
+}
+
 func (m *mockTmuxClient) CapturePane(ctx context.Context, name string, lines int) (string, error) {
 	return "", nil
 }
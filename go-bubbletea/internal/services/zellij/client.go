@@ -0,0 +1,238 @@
+package zellij
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// enterKey is the byte zellij writes to submit a line, like tmux's C-m
+const enterKey = "13"
+
+// Client wraps zellij CLI for session management operations
+type Client struct {
+	runner CommandRunner
+	logger *slog.Logger
+}
+
+// NewClient creates a new zellij client with dependency injection
+func NewClient(runner CommandRunner, logger *slog.Logger) *Client {
+	return &Client{
+		runner: runner,
+		logger: logger,
+	}
+}
+
+// NewSession creates a new detached zellij session with the given name and working directory
+// Uses: zellij attach --create-background <name> options --default-cwd <workdir>
+func (c *Client) NewSession(ctx context.Context, name string, workdir string) error {
+	c.logger.Debug("creating zellij session", "name", name, "workdir", workdir)
+
+	args := []string{"attach", "--create-background", name}
+	if workdir != "" {
+		args = append(args, "options", "--default-cwd", workdir)
+	}
+
+	_, err := c.runner.Run(ctx, args...)
+	if err != nil {
+		return &domain.ZellijError{Op: "new-session", Session: name, Err: err}
+	}
+
+	c.logger.Debug("zellij session created", "name", name)
+	return nil
+}
+
+// HasSession checks if a zellij session with the given name exists
+// Uses: zellij list-sessions --short --no-formatting
+func (c *Client) HasSession(ctx context.Context, name string) (bool, error) {
+	c.logger.Debug("checking zellij session", "name", name)
+
+	sessions, err := c.ListSessions(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if session == name {
+			c.logger.Debug("zellij session exists", "name", name)
+			return true, nil
+		}
+	}
+
+	c.logger.Debug("zellij session not found", "name", name)
+	return false, nil
+}
+
+// SessionExists checks if a zellij session with the given name exists.
+// HasSession already reports failures to list sessions, so this is the same.
+func (c *Client) SessionExists(ctx context.Context, name string) (bool, error) {
+	return c.HasSession(ctx, name)
+}
+
+// AttachSession attaches to an existing zellij session
+// Note: This is a blocking operation meant to be used with exec.Cmd
+// Uses: zellij attach <name>
+func (c *Client) AttachSession(ctx context.Context, name string) error {
+	c.logger.Debug("attaching to zellij session", "name", name)
+
+	_, err := c.runner.Run(ctx, "attach", name)
+	if err != nil {
+		return &domain.ZellijError{Op: "attach", Session: name, Err: err}
+	}
+
+	return nil
+}
+
+// AttachCommand returns the shell command a user can run to attach to a session
+func (c *Client) AttachCommand(name string) string {
+	return "zellij attach " + name
+}
+
+// KillSession terminates a zellij session
+// Uses: zellij kill-session <name>
+func (c *Client) KillSession(ctx context.Context, name string) error {
+	c.logger.Debug("killing zellij session", "name", name)
+
+	_, err := c.runner.Run(ctx, "kill-session", name)
+	if err != nil {
+		return &domain.ZellijError{Op: "kill-session", Session: name, Err: err}
+	}
+
+	c.logger.Debug("zellij session killed", "name", name)
+	return nil
+}
+
+// SendKeys types keys into a zellij session followed by Enter. The keys are
+// typed as text; use SendKey to press a control key.
+// Uses: zellij --session <name> action write-chars <keys>
+//
+//	zellij --session <name> action write 13
+func (c *Client) SendKeys(ctx context.Context, name string, keys string) error {
+	c.logger.Debug("sending keys to zellij session", "name", name, "keys", keys)
+
+	for _, args := range sendKeysArgs(name, keys) {
+		if _, err := c.runner.Run(ctx, args...); err != nil {
+			return &domain.ZellijError{Op: "send-keys", Session: name, Err: err}
+		}
+	}
+
+	c.logger.Debug("keys sent to zellij session", "name", name)
+	return nil
+}
+
+// sendKeysArgs builds the zellij invocations that type keys and press Enter
+func sendKeysArgs(name string, keys string) [][]string {
+	action := []string{"--session", name, "action"}
+	return [][]string{
+		append(append([]string{}, action...), "write-chars", keys),
+		append(append([]string{}, action...), "write", enterKey),
+	}
+}
+
+// SendKey presses a tmux-style control key such as "C-c" in a zellij
+// session, as its control byte, without pressing Enter after it
+// Uses: zellij --session <name> action write <byte>
+func (c *Client) SendKey(ctx context.Context, name string, key string) error {
+	c.logger.Debug("sending key to zellij session", "name", name, "key", key)
+
+	b, ok := controlByte(key)
+	if !ok {
+		return &domain.ZellijError{Op: "send-key", Session: name, Err: fmt.Errorf("unsupported key %q", key)}
+	}
+	if _, err := c.runner.Run(ctx, "--session", name, "action", "write", strconv.Itoa(b)); err != nil {
+		return &domain.ZellijError{Op: "send-key", Session: name, Err: err}
+	}
+	return nil
+}
+
+// controlByte converts a tmux key name like "C-c" to its control byte (3)
+func controlByte(keys string) (int, bool) {
+	if len(keys) != 3 || !strings.HasPrefix(keys, "C-") {
+		return 0, false
+	}
+	letter := keys[2]
+	if letter < 'a' || letter > 'z' {
+		return 0, false
+	}
+	return int(letter-'a') + 1, true
+}
+
+// CapturePane captures the last N lines from a zellij session's focused pane.
+// zellij can only dump the screen to a file, so a temporary file is used.
+// Uses: zellij --session <name> action dump-screen --full <path>
+func (c *Client) CapturePane(ctx context.Context, name string, lines int) (string, error) {
+	c.logger.Debug("capturing zellij pane", "name", name, "lines", lines)
+
+	f, err := os.CreateTemp("", "azedarach-zellij-*.txt")
+	if err != nil {
+		return "", &domain.ZellijError{Op: "dump-screen", Session: name, Err: err}
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	if _, err := c.runner.Run(ctx, "--session", name, "action", "dump-screen", "--full", path); err != nil {
+		return "", &domain.ZellijError{Op: "dump-screen", Session: name, Err: err}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", &domain.ZellijError{Op: "dump-screen", Session: name, Err: err}
+	}
+
+	out := lastLines(string(data), lines)
+	c.logger.Debug("zellij pane captured", "name", name, "bytes", len(out))
+	return out, nil
+}
+
+// lastLines returns the last n lines of s, or all of s when n <= 0
+func lastLines(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	trimmed := strings.TrimRight(s, "\n")
+	all := strings.Split(trimmed, "\n")
+	if len(all) <= n {
+		return s
+	}
+	return strings.Join(all[len(all)-n:], "\n") + "\n"
+}
+
+// ListSessions returns a list of all zellij session names
+// Uses: zellij list-sessions --short --no-formatting
+func (c *Client) ListSessions(ctx context.Context) ([]string, error) {
+	c.logger.Debug("listing zellij sessions")
+
+	out, err := c.runner.Run(ctx, "list-sessions", "--short", "--no-formatting")
+	if err != nil {
+		// If no sessions exist, zellij returns an error
+		// Return empty list instead
+		c.logger.Debug("no zellij sessions found")
+		return []string{}, nil
+	}
+
+	sessions := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sessions = append(sessions, line)
+		}
+	}
+
+	c.logger.Debug("zellij sessions listed", "count", len(sessions))
+	return sessions, nil
+}
+
+// SetEnvironment is not supported: zellij sessions have no per-session
+// environment that can be changed after creation
+func (c *Client) SetEnvironment(ctx context.Context, name, key, value string) error {
+	return &domain.ZellijError{
+		Op:      "set-environment",
+		Session: name,
+		Err:     fmt.Errorf("setting %s: %w", key, errors.ErrUnsupported),
+	}
+}
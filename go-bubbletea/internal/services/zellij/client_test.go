@@ -0,0 +1,370 @@
+package zellij
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRunner implements CommandRunner for testing and records every call
+type mockRunner struct {
+	output string
+	err    error
+	screen string // written to the dump-screen path, simulating zellij
+	calls  [][]string
+}
+
+func (m *mockRunner) Run(ctx context.Context, args ...string) (string, error) {
+	m.calls = append(m.calls, args)
+	if m.err != nil {
+		return "", m.err
+	}
+	if len(args) > 3 && args[3] == "dump-screen" {
+		if err := os.WriteFile(args[len(args)-1], []byte(m.screen), 0644); err != nil {
+			return "", err
+		}
+	}
+	return m.output, nil
+}
+
+func TestClient_NewSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  string
+		workdir  string
+		runErr   error
+		wantErr  bool
+		wantArgs []string
+	}{
+		{
+			name:     "create session with workdir",
+			session:  "test-session",
+			workdir:  "/home/user/project",
+			wantArgs: []string{"attach", "--create-background", "test-session", "options", "--default-cwd", "/home/user/project"},
+		},
+		{
+			name:     "create session without workdir",
+			session:  "test-session",
+			workdir:  "",
+			wantArgs: []string{"attach", "--create-background", "test-session"},
+		},
+		{
+			name:    "runner error",
+			session: "test-session",
+			workdir: "/tmp",
+			runErr:  errors.New("zellij command failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			err := client.NewSession(context.Background(), tt.session, tt.workdir)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				var zellijErr *domain.ZellijError
+				assert.ErrorAs(t, err, &zellijErr)
+				assert.Equal(t, "new-session", zellijErr.Op)
+				assert.Equal(t, tt.session, zellijErr.Session)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, [][]string{tt.wantArgs}, runner.calls)
+		})
+	}
+}
+
+func TestClient_HasSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		session  string
+		output   string
+		runErr   error
+		wantBool bool
+	}{
+		{
+			name:     "session exists",
+			session:  "existing-session",
+			output:   "other\nexisting-session\n",
+			wantBool: true,
+		},
+		{
+			name:     "session does not exist",
+			session:  "missing-session",
+			output:   "other\n",
+			wantBool: false,
+		},
+		{
+			name:     "no sessions",
+			session:  "missing-session",
+			runErr:   errors.New("No active zellij sessions found."),
+			wantBool: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{output: tt.output, err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			exists, err := client.HasSession(context.Background(), tt.session)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBool, exists)
+			assert.Equal(t, []string{"list-sessions", "--short", "--no-formatting"}, runner.calls[0])
+		})
+	}
+}
+
+func TestClient_AttachSession(t *testing.T) {
+	runner := &mockRunner{}
+	client := NewClient(runner, slog.Default())
+
+	require.NoError(t, client.AttachSession(context.Background(), "test-session"))
+	assert.Equal(t, [][]string{{"attach", "test-session"}}, runner.calls)
+	assert.Equal(t, "zellij attach test-session", client.AttachCommand("test-session"))
+}
+
+func TestClient_KillSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		runErr  error
+		wantErr bool
+	}{
+		{
+			name:    "successful kill",
+			session: "test-session",
+		},
+		{
+			name:    "runner error",
+			session: "test-session",
+			runErr:  errors.New("kill failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			err := client.KillSession(context.Background(), tt.session)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				var zellijErr *domain.ZellijError
+				assert.ErrorAs(t, err, &zellijErr)
+				assert.Equal(t, "kill-session", zellijErr.Op)
+				assert.Equal(t, tt.session, zellijErr.Session)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, [][]string{{"kill-session", "test-session"}}, runner.calls)
+		})
+	}
+}
+
+func TestClient_SendKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		session   string
+		keys      string
+		runErr    error
+		wantErr   bool
+		wantCalls [][]string
+	}{
+		{
+			name:    "send simple command",
+			session: "test-session",
+			keys:    "echo hello",
+			wantCalls: [][]string{
+				{"--session", "test-session", "action", "write-chars", "echo hello"},
+				{"--session", "test-session", "action", "write", "13"},
+			},
+		},
+		{
+			name:    "send complex command",
+			session: "test-session",
+			keys:    "cd /tmp && ls -la",
+			wantCalls: [][]string{
+				{"--session", "test-session", "action", "write-chars", "cd /tmp && ls -la"},
+				{"--session", "test-session", "action", "write", "13"},
+			},
+		},
+		{
+			name:    "control key name is typed",
+			session: "test-session",
+			keys:    "C-c",
+			wantCalls: [][]string{
+				{"--session", "test-session", "action", "write-chars", "C-c"},
+				{"--session", "test-session", "action", "write", "13"},
+			},
+		},
+		{
+			name:    "runner error",
+			session: "test-session",
+			keys:    "test",
+			runErr:  errors.New("write-chars failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			err := client.SendKeys(context.Background(), tt.session, tt.keys)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				var zellijErr *domain.ZellijError
+				assert.ErrorAs(t, err, &zellijErr)
+				assert.Equal(t, "send-keys", zellijErr.Op)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, runner.calls)
+		})
+	}
+}
+
+func TestClient_SendKey(t *testing.T) {
+	runner := &mockRunner{}
+	client := NewClient(runner, slog.Default())
+
+	require.NoError(t, client.SendKey(context.Background(), "test-session", "C-c"))
+	assert.Equal(t, [][]string{{"--session", "test-session", "action", "write", "3"}}, runner.calls, "pressed without Enter")
+
+	err := client.SendKey(context.Background(), "test-session", "Escape")
+	var zellijErr *domain.ZellijError
+	require.ErrorAs(t, err, &zellijErr)
+	assert.Equal(t, "send-key", zellijErr.Op)
+	assert.Len(t, runner.calls, 1, "unsupported keys aren't sent")
+}
+
+func TestClient_CapturePane(t *testing.T) {
+	tests := []struct {
+		name       string
+		session    string
+		lines      int
+		screen     string
+		runErr     error
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:       "fewer lines than requested",
+			session:    "test-session",
+			lines:      10,
+			screen:     "line1\nline2\nline3\n",
+			wantOutput: "line1\nline2\nline3\n",
+		},
+		{
+			name:       "keeps only the last lines",
+			session:    "test-session",
+			lines:      2,
+			screen:     "line1\nline2\nline3\n",
+			wantOutput: "line2\nline3\n",
+		},
+		{
+			name:    "runner error",
+			session: "test-session",
+			lines:   10,
+			runErr:  errors.New("dump failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{screen: tt.screen, err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			output, err := client.CapturePane(context.Background(), tt.session, tt.lines)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				var zellijErr *domain.ZellijError
+				assert.ErrorAs(t, err, &zellijErr)
+				assert.Equal(t, "dump-screen", zellijErr.Op)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutput, output)
+
+			// The dump file is removed after reading
+			require.Len(t, runner.calls, 1)
+			args := runner.calls[0]
+			assert.Equal(t, []string{"--session", tt.session, "action", "dump-screen", "--full"}, args[:len(args)-1])
+			_, statErr := os.Stat(args[len(args)-1])
+			assert.True(t, os.IsNotExist(statErr))
+		})
+	}
+}
+
+func TestClient_ListSessions(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		runErr    error
+		wantNames []string
+	}{
+		{
+			name:      "multiple sessions",
+			output:    "session1\nsession2\nsession3\n",
+			wantNames: []string{"session1", "session2", "session3"},
+		},
+		{
+			name:      "no sessions",
+			runErr:    errors.New("No active zellij sessions found."),
+			wantNames: []string{},
+		},
+		{
+			name:      "empty output",
+			output:    "",
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &mockRunner{output: tt.output, err: tt.runErr}
+			client := NewClient(runner, slog.Default())
+
+			sessions, err := client.ListSessions(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNames, sessions)
+		})
+	}
+}
+
+func TestClient_SetEnvironment(t *testing.T) {
+	runner := &mockRunner{}
+	client := NewClient(runner, slog.Default())
+
+	err := client.SetEnvironment(context.Background(), "test-session", "ENV", "production")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+	var zellijErr *domain.ZellijError
+	require.ErrorAs(t, err, &zellijErr)
+	assert.Equal(t, "set-environment", zellijErr.Op)
+	assert.Empty(t, runner.calls)
+}
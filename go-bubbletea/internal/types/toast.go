@@ -7,6 +7,14 @@ type Toast struct {
 	Level   ToastLevel
 	Message string
 	Expires time.Time
+	Count   int       // Times this toast was raised while visible; 0 and 1 both mean once
+	Raised  time.Time // When the toast was last raised
+	Persist bool      // Shown until dismissed, whatever Expires says
+}
+
+// Active reports whether the toast is still shown at now
+func (t Toast) Active(now time.Time) bool {
+	return t.Persist || t.Expires.After(now)
 }
 
 // ToastLevel indicates the severity of a toast
@@ -18,3 +26,17 @@ const (
 	ToastWarning
 	ToastError
 )
+
+// String returns the level's name
+func (l ToastLevel) String() string {
+	switch l {
+	case ToastSuccess:
+		return "success"
+	case ToastWarning:
+		return "warning"
+	case ToastError:
+		return "error"
+	default:
+		return "info"
+	}
+}
@@ -1,6 +1,8 @@
 package board
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/riordanpawley/azedarach/internal/core/phases"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
@@ -8,13 +10,23 @@ import (
 
 const statusBarHeight = 1
 
-// Render renders the entire kanban board with 4 columns
+// NoFocus is the focusedColumn of a board showing every column side by side
+const NoFocus = -1
+
+// Render renders the kanban board. With a focusedColumn other than
+// NoFocus, that column takes the width and the others are collapsed to
+// their headers. Columns that don't fit the width are paged, showing the
+// page holding the cursor between strips counting the columns either side.
 func Render(
 	columns []Column,
 	cursor Cursor,
+	focusedColumn int,
 	selectedTasks map[string]bool,
+	movedTasks map[string]bool,
 	phaseData map[string]phases.TaskPhaseInfo,
 	showPhases bool,
+	epicProgress map[string]EpicProgress,
+	age AgeOptions,
 	s *styles.Styles,
 	width int,
 	height int,
@@ -23,30 +35,97 @@ func Render(
 		return ""
 	}
 
-	columnWidth := width / len(columns)
+	layout := layoutColumns(columns, cursor, focusedColumn, width)
 
-	columnStrings := make([]string, len(columns))
-	for i, col := range columns {
-		isActive := i == cursor.Column
+	columnStrings := make([]string, 0, len(layout.columns)+2)
+	if layout.paged {
+		columnStrings = append(columnStrings, renderPager(fmt.Sprintf("◀%d", layout.first), layout.first > 0, s))
+	}
+	for i, col := range layout.columns {
+		isActive := layout.first+i == cursor.Column
 		cursorTask := -1
 		if isActive {
 			cursorTask = cursor.Task
 		}
 
-		columnStrings[i] = renderColumn(
-			col.Title,
-			col.Tasks,
+		columnStrings = append(columnStrings, renderColumn(
+			col,
 			cursorTask,
 			isActive,
 			selectedTasks,
+			movedTasks,
 			phaseData,
 			showPhases,
-			columnWidth,
+			epicProgress,
+			age,
+			layout.widths[i],
 			height,
 			s,
-		)
+		))
+	}
+	if layout.paged {
+		right := len(columns) - layout.first - len(layout.columns)
+		columnStrings = append(columnStrings, renderPager(fmt.Sprintf("%d▶", right), right > 0, s))
 	}
 
 	// Join columns horizontally
 	return lipgloss.JoinHorizontal(lipgloss.Top, columnStrings...)
 }
+
+// focusColumns returns columns as drawn with focused taking the board: the
+// others are collapsed, with their tasks counted in Hidden
+func focusColumns(columns []Column, focused int) []Column {
+	if focused < 0 || focused >= len(columns) {
+		return columns
+	}
+
+	focusedColumns := make([]Column, len(columns))
+	for i, col := range columns {
+		if i != focused && !col.Collapsed {
+			col.Collapsed = true
+			col.Hidden += len(col.Tasks)
+			col.Tasks = nil
+		}
+		focusedColumns[i] = col
+	}
+	return focusedColumns
+}
+
+// columnWidths splits width between columns. Collapsed columns take
+// collapsedColumnWidth and the others share the rest evenly.
+func columnWidths(columns []Column, width int) []int {
+	collapsed := 0
+	for _, col := range columns {
+		if col.Collapsed {
+			collapsed++
+		}
+	}
+
+	widths := make([]int, len(columns))
+	expanded := len(columns) - collapsed
+	if expanded == 0 {
+		for i := range widths {
+			widths[i] = width / len(columns)
+		}
+		return widths
+	}
+
+	share := (width - collapsed*collapsedColumnWidth) / expanded
+	for i, col := range columns {
+		if col.Collapsed {
+			widths[i] = collapsedColumnWidth
+		} else {
+			widths[i] = share
+		}
+	}
+	return widths
+}
+
+// renderPager renders the strip at one edge of a paged board, with label
+// when there are columns off that edge
+func renderPager(label string, more bool, s *styles.Styles) string {
+	if !more {
+		label = ""
+	}
+	return s.ColumnHeader.Width(pagerWidth).Render(label)
+}
@@ -2,10 +2,14 @@ package board
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
@@ -68,10 +72,12 @@ func TestRender(t *testing.T) {
 
 	s := styles.New()
 	columns := CreatePlaceholderData()
+	// Placeholder epic az-4 has 3 of 5 children done
+	epicProgress := map[string]EpicProgress{"az-4": {Done: 3, Total: 5}}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := Render(columns, tt.cursor, tt.selectedTasks, nil, false, s, tt.width, tt.height)
+			got := Render(columns, tt.cursor, NoFocus, tt.selectedTasks, nil, nil, false, epicProgress, AgeOptions{}, s, tt.width, tt.height)
 
 			goldenFile := filepath.Join("testdata", tt.name+".golden")
 
@@ -171,13 +177,138 @@ func TestRenderCard(t *testing.T) {
 
 func TestRenderEmptyBoard(t *testing.T) {
 	s := styles.New()
-	got := Render([]Column{}, Cursor{}, make(map[string]bool), nil, false, s, 120, 30)
+	got := Render([]Column{}, Cursor{}, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 120, 30)
 
 	if got != "" {
 		t.Errorf("Render() with empty columns should return empty string, got: %q", got)
 	}
 }
 
+func TestRenderMovedTasks(t *testing.T) {
+	s := styles.New()
+	columns := CreatePlaceholderData()
+	cursor := Cursor{Column: 0, Task: 0}
+
+	plain := Render(columns, cursor, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 120, 30)
+	if strings.Contains(plain, "┏") {
+		t.Fatal("No card should be highlighted without moved tasks")
+	}
+
+	// Moved cards get a thick border
+	moved := Render(columns, cursor, NoFocus, make(map[string]bool), map[string]bool{"az-3": true}, nil, false, nil, AgeOptions{}, s, 120, 30)
+	if strings.Count(moved, "┏") != 1 {
+		t.Errorf("Expected exactly one highlighted card, got:\n%s", moved)
+	}
+
+	// The cursor style takes precedence over the highlight
+	onCursor := Render(columns, cursor, NoFocus, make(map[string]bool), map[string]bool{"az-1": true}, nil, false, nil, AgeOptions{}, s, 120, 30)
+	if strings.Contains(onCursor, "┏") {
+		t.Error("Cursor card should keep the cursor style when moved")
+	}
+}
+
+func TestRenderPRBadge(t *testing.T) {
+	s := styles.New()
+	columns := CreatePlaceholderData()
+	columns[0].Tasks[0].PRURL = "https://github.com/org/repo/pull/7"
+	columns[0].Tasks[0].PRNumber = 7
+
+	got := Render(columns, Cursor{}, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 160, 30)
+	if strings.Count(got, "PR #7") != 1 {
+		t.Errorf("Expected one PR #7 badge, got:\n%s", got)
+	}
+}
+
+func TestRenderWIPLimitHeader(t *testing.T) {
+	s := styles.New()
+	if s.ColumnHeaderOverLimit.GetForeground() != styles.Red {
+		t.Error("Expected over-limit headers to be red")
+	}
+	// Mark headers drawn with the over-limit style; colors aren't rendered in tests
+	s.ColumnHeaderOverLimit = s.ColumnHeaderOverLimit.Transform(strings.ToUpper)
+
+	columns := CreatePlaceholderData()
+	columns[0].Limit = 2 // Open holds 3
+	columns[1].Limit = 2 // In Progress holds 2
+
+	got := Render(columns, Cursor{}, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 160, 30)
+	if !strings.Contains(got, "OPEN (3/2)") {
+		t.Errorf("Expected the over-limit Open header in the over-limit style, got:\n%s", got)
+	}
+	if !strings.Contains(got, "In Progress (2/2)") {
+		t.Errorf("Expected the at-limit In Progress header in the normal style, got:\n%s", got)
+	}
+	if !strings.Contains(got, "Blocked (1)") {
+		t.Errorf("Expected no limit on Blocked, got:\n%s", got)
+	}
+}
+
+func TestRenderCollapsedColumn(t *testing.T) {
+	s := styles.New()
+	columns := CreatePlaceholderData()
+	done := columns[3]
+	columns[3] = Column{Title: done.Title, Collapsed: true, Hidden: len(done.Tasks)}
+
+	// The collapsed column gives its share of the width to the others
+	widths := columnWidths(columns, 160)
+	want := []int{49, 49, 49, collapsedColumnWidth}
+	for i := range want {
+		if widths[i] != want[i] {
+			t.Errorf("columnWidths()[%d] = %d, want %d", i, widths[i], want[i])
+		}
+	}
+
+	got := Render(columns, Cursor{}, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 160, 30)
+	if !strings.Contains(got, "Done (2)") {
+		t.Errorf("Expected the collapsed column to keep its count, got:\n%s", got)
+	}
+	for _, task := range done.Tasks {
+		if strings.Contains(got, task.Title) {
+			t.Errorf("Expected %q to be hidden in the collapsed column", task.Title)
+		}
+	}
+	if width := lipgloss.Width(got); width > 160 {
+		t.Errorf("Expected the board to fit 160 columns, got %d", width)
+	}
+}
+
+func TestRenderFocusedColumn(t *testing.T) {
+	s := styles.New()
+	columns := CreatePlaceholderData()
+	inProgress := columns[1]
+
+	got := Render(columns, Cursor{Column: 1}, 1, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 80, 30)
+
+	// The focused column takes all but the thin headers of the others
+	if width := lipgloss.Width(got); width > 80 {
+		t.Errorf("Expected the board to fit 80 columns, got %d", width)
+	}
+	firstLine := strings.Split(got, "\n")[0]
+	for _, header := range []string{"Open (", "In Progress (", "Bloc"} {
+		if !strings.Contains(firstLine, header) {
+			t.Errorf("Expected header %q on the first line, got %q", header, firstLine)
+		}
+	}
+	for _, task := range inProgress.Tasks {
+		if !strings.Contains(got, task.Title) {
+			t.Errorf("Expected focused column's %q at full width, got:\n%s", task.Title, got)
+		}
+	}
+	for _, task := range columns[0].Tasks {
+		if strings.Contains(got, task.Title) {
+			t.Errorf("Expected %q hidden outside the focused column", task.Title)
+		}
+	}
+
+	widths := columnWidths(focusColumns(columns, 1), 80)
+	want := []int{collapsedColumnWidth, 80 - 3*collapsedColumnWidth, collapsedColumnWidth, collapsedColumnWidth}
+	for i := range want {
+		if widths[i] != want[i] {
+			t.Errorf("focused columnWidths()[%d] = %d, want %d", i, widths[i], want[i])
+		}
+	}
+}
+
 func TestCursorBounds(t *testing.T) {
 	// Test that rendering doesn't panic with out-of-bounds cursor
 	s := styles.New()
@@ -200,7 +331,35 @@ func TestCursorBounds(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Should not panic
-			_ = Render(columns, tt.cursor, make(map[string]bool), nil, false, s, 120, 30)
+			_ = Render(columns, tt.cursor, NoFocus, make(map[string]bool), nil, nil, false, nil, AgeOptions{}, s, 120, 30)
+		})
+	}
+}
+
+// BenchmarkRender_LongColumn renders a 500-card column into a terminal-sized
+// view, which only renders the cards in view, and into one tall enough to
+// show every card, which is what rendering the whole column costs
+func BenchmarkRender_LongColumn(b *testing.B) {
+	tasks := make([]domain.Task, 500)
+	for i := range tasks {
+		tasks[i] = domain.Task{ID: fmt.Sprintf("az-%d", i), Title: fmt.Sprintf("Card %d", i), Type: domain.TypeTask}
+	}
+	columns := []Column{{Title: "Open", Status: domain.StatusOpen, Tasks: tasks}}
+	cursor := Cursor{Column: 0, Task: 250}
+	s := styles.New()
+
+	for _, bench := range []struct {
+		name   string
+		height int
+	}{
+		{"in view", 40},
+		{"every card", len(tasks)*cardHeight + headerLines},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Render(columns, cursor, NoFocus, nil, nil, nil, false, nil, AgeOptions{}, s, 120, bench.height)
+			}
 		})
 	}
 }
@@ -6,19 +6,27 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/riordanpawley/azedarach/internal/core/phases"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
 // renderCard renders a task card
-func renderCard(task domain.Task, isCursor bool, isSelected bool, width int, phaseInfo *phases.TaskPhaseInfo, showPhases bool, s *styles.Styles) string {
+func renderCard(task domain.Task, isCursor bool, isSelected bool, isMoved bool, width int, phaseInfo *phases.TaskPhaseInfo, showPhases bool, progress EpicProgress, age AgeOptions, s *styles.Styles) string {
 	// Choose card style based on state
 	cardStyle := s.Card
 	if isSelected {
 		cardStyle = s.CardSelected
 	} else if isCursor {
 		cardStyle = s.CardActive
+	} else if isMoved {
+		cardStyle = s.CardMoved
+	} else if task.Status == domain.StatusOpen && len(task.BlockedBy) > 0 {
+		// Looks ready to pick up, but isn't
+		cardStyle = s.CardBlocked
+	} else if accent, ok := cardAccent(task, s); ok {
+		cardStyle = cardStyle.BorderForeground(accent)
 	}
 
 	// Apply width
@@ -61,14 +69,48 @@ func renderCard(task domain.Task, isCursor bool, isSelected bool, width int, pha
 		cursor = "▶"
 	}
 
+	// Age since last update
+	var ageBadge string
+	ageText, stale := cardAge(task, age, time.Now())
+	if ageText != "" {
+		ageBadge = s.TaskAge.Render(ageText)
+	}
+
 	// Build the card content
 	titleLine := cursor + title
+	if stale {
+		titleLine = s.TaskStale.Render(titleLine)
+	}
 
-	// Badge line: priority • type [• phase]
+	// Badge line: priority • type [• phase] [• blocked] [• PR] [• attachments] [• age]
 	badgeLine := lipgloss.JoinHorizontal(lipgloss.Left, priorityBadge, " • ", typeBadge)
 	if phaseBadge != "" {
 		badgeLine = lipgloss.JoinHorizontal(lipgloss.Left, badgeLine, " • ", phaseBadge)
 	}
+	if len(task.BlockedBy) > 0 && task.Status != domain.StatusDone {
+		blockedBadge := s.BlockedBadge.Render(fmt.Sprintf("⛔ blocked by %d", len(task.BlockedBy)))
+		badgeLine = lipgloss.JoinHorizontal(lipgloss.Left, badgeLine, " • ", blockedBadge)
+	}
+	if task.PRState != domain.PRNone || task.PRNumber > 0 {
+		label := "PR"
+		if task.PRNumber > 0 {
+			label = fmt.Sprintf("PR #%d", task.PRNumber)
+		}
+		if task.PRState != domain.PRNone {
+			label = task.PRState.Icon() + " " + label
+		}
+		prBadge := s.PRState(task.PRState).Render(label)
+		badgeLine = lipgloss.JoinHorizontal(lipgloss.Left, badgeLine, " • ", prBadge)
+	}
+	if task.Attachments > 0 {
+		attachBadge := s.AttachmentBadge.Render(fmt.Sprintf("📎%d", task.Attachments))
+		badgeLine = lipgloss.JoinHorizontal(lipgloss.Left, badgeLine, " • ", attachBadge)
+	}
+	if ageBadge != "" {
+		badgeLine = lipgloss.JoinHorizontal(lipgloss.Left, badgeLine, " • ", ageBadge)
+	}
+	// Cut badges that don't fit rather than wrap the card onto another line
+	badgeLine = ansi.Truncate(badgeLine, width-cardStyle.GetHorizontalPadding(), "…")
 
 	// Session status row (if session exists)
 	var sessionRow string
@@ -79,7 +121,7 @@ func renderCard(task domain.Task, isCursor bool, isSelected bool, width int, pha
 	// Epic progress (if epic type)
 	var epicProgress string
 	if task.Type == domain.TypeEpic {
-		epicProgress = renderEpicProgress(task, width, s)
+		epicProgress = renderEpicProgress(progress, s)
 	}
 
 	// Compose card content
@@ -95,6 +137,23 @@ func renderCard(task domain.Task, isCursor bool, isSelected bool, width int, pha
 	return cardStyle.Render(content)
 }
 
+// cardAccent returns the border color of a card with its status colored in
+// config. A running session's state, if it wants attention, colors the
+// border instead, so busy, waiting and failed sessions still stand out.
+func cardAccent(task domain.Task, s *styles.Styles) (lipgloss.TerminalColor, bool) {
+	color, ok := s.CardStatusColors[task.Status]
+	if !ok {
+		return nil, false
+	}
+	if task.Session != nil {
+		switch task.Session.State {
+		case domain.SessionBusy, domain.SessionWaiting, domain.SessionError:
+			return s.SessionState(task.Session.State).GetForeground(), true
+		}
+	}
+	return color, true
+}
+
 // renderSessionStatus renders the session status line with icon and elapsed time
 func renderSessionStatus(session *domain.Session, s *styles.Styles) string {
 	icon := session.State.Icon()
@@ -107,10 +166,36 @@ func renderSessionStatus(session *domain.Session, s *styles.Styles) string {
 	}
 
 	stateStyle := s.SessionState(session.State)
+	if session.State == domain.SessionBusy && session.BusyReason != "" {
+		icon = session.BusyReason.Icon()
+		stateStyle = s.BusyReason(session.BusyReason)
+	}
+	if session.State == domain.SessionBusy && session.Stalled {
+		icon = "⚠ stalled"
+		stateStyle = s.BusyStalled
+	}
+	status := stateStyle.Render(icon)
 	if elapsed != "" {
-		return stateStyle.Render(fmt.Sprintf("%s %s", icon, elapsed))
+		status = stateStyle.Render(fmt.Sprintf("%s %s", icon, elapsed))
+	}
+	if branch := renderAheadBehind(session, s); branch != "" {
+		status += " " + branch
+	}
+	return status
+}
+
+// renderAheadBehind renders how far the session's branch is from the base
+// branch as "↑3 ↓1". Being behind is highlighted, as a nudge to update from
+// main. Nothing is rendered for a branch level with base.
+func renderAheadBehind(session *domain.Session, s *styles.Styles) string {
+	var parts []string
+	if session.Ahead > 0 {
+		parts = append(parts, s.BranchAhead.Render(fmt.Sprintf("↑%d", session.Ahead)))
 	}
-	return stateStyle.Render(icon)
+	if session.Behind > 0 {
+		parts = append(parts, s.BranchBehind.Render(fmt.Sprintf("↓%d", session.Behind)))
+	}
+	return strings.Join(parts, " ")
 }
 
 // formatDuration formats a duration as "2h 34m" or "45m"
@@ -124,27 +209,54 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", m)
 }
 
-// renderEpicProgress renders the epic progress bar with completion ratio
-func renderEpicProgress(task domain.Task, width int, s *styles.Styles) string {
-	// TODO: Get child counts from task metadata
-	// For now, use placeholder values
-	completed := 3
-	total := 5
+// cardAge returns the age label for a task and whether it is stale.
+// Tasks without an UpdatedAt timestamp get no label.
+func cardAge(task domain.Task, age AgeOptions, now time.Time) (string, bool) {
+	if !age.Show || task.UpdatedAt.IsZero() {
+		return "", false
+	}
+	sinceUpdate := now.Sub(task.UpdatedAt)
+	stale := age.StaleAfter > 0 && sinceUpdate > age.StaleAfter
+	return formatAge(sinceUpdate), stale
+}
 
-	if total == 0 {
-		return ""
+// formatAge formats a duration as a compact relative time: "45m", "3h" or "12d"
+func formatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
 	}
+}
 
-	percent := float64(completed) / float64(total)
+// renderEpicProgress renders the epic progress bar with completion ratio.
+// Epics without children render an empty bar.
+func renderEpicProgress(progress EpicProgress, s *styles.Styles) string {
 	barWidth := 6
-	filled := int(percent * float64(barWidth))
+	filled := int(progress.Fraction() * float64(barWidth))
 	empty := barWidth - filled
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", empty)
-	return s.EpicProgress.Render(fmt.Sprintf("[%d/%d] %s", completed, total, bar))
+	return s.EpicProgress.Render(fmt.Sprintf("[%d/%d] %s", progress.Done, progress.Total, bar))
 }
 
 // RenderCard is the exported version for testing
 func RenderCard(task domain.Task, isCursor bool, isSelected bool, width int, s *styles.Styles) string {
-	return renderCard(task, isCursor, isSelected, width, nil, false, s)
+	return renderCard(task, isCursor, isSelected, false, width, nil, false, EpicProgress{}, AgeOptions{}, s)
+}
+
+// RenderCardWithAge is the exported version for testing age annotations
+func RenderCardWithAge(task domain.Task, width int, age AgeOptions, s *styles.Styles) string {
+	return renderCard(task, false, false, false, width, nil, false, EpicProgress{}, age, s)
+}
+
+// RenderCardWithEpicProgress is the exported version for testing epic progress
+func RenderCardWithEpicProgress(task domain.Task, width int, progress EpicProgress, s *styles.Styles) string {
+	return renderCard(task, false, false, false, width, nil, false, progress, AgeOptions{}, s)
 }
@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
@@ -58,6 +60,31 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     string
+	}{
+		{name: "just now", duration: 20 * time.Second, want: "0m"},
+		{name: "minutes", duration: 45 * time.Minute, want: "45m"},
+		{name: "one hour", duration: time.Hour, want: "1h"},
+		{name: "hours truncate", duration: 23*time.Hour + 59*time.Minute, want: "23h"},
+		{name: "one day", duration: 24 * time.Hour, want: "1d"},
+		{name: "days", duration: 10*24*time.Hour + 5*time.Hour, want: "10d"},
+		{name: "negative clamps to zero", duration: -5 * time.Minute, want: "0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatAge(tt.duration)
+			if got != tt.want {
+				t.Errorf("formatAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRenderCard_Basic(t *testing.T) {
 	s := styles.New()
 	task := domain.Task{
@@ -87,6 +114,37 @@ func TestRenderCard_Basic(t *testing.T) {
 	}
 }
 
+func TestRenderCard_StatusColors(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	s := styles.New()
+	s.SetCardStatusColors(map[string]string{"blocked": "#ff0000"})
+	configured := "38;2;255;0;0" // #ff0000 as a foreground escape
+
+	blocked := domain.Task{ID: "az-1", Title: "Blocked task", Status: domain.StatusBlocked, Type: domain.TypeTask}
+	if card := RenderCard(blocked, false, false, 30, s); !strings.Contains(card, configured) {
+		t.Errorf("Blocked card should use the configured color, got: %q", card)
+	}
+
+	open := domain.Task{ID: "az-2", Title: "Open task", Status: domain.StatusOpen, Type: domain.TypeTask}
+	if card := RenderCard(open, false, false, 30, s); strings.Contains(card, configured) {
+		t.Errorf("Open card has no configured color and should keep the theme's, got: %q", card)
+	}
+
+	// The cursor's highlight wins over the status color
+	if card := RenderCard(blocked, true, false, 30, s); strings.Contains(card, configured) {
+		t.Errorf("Card under the cursor should use the active border, got: %q", card)
+	}
+
+	// A session waiting on the user colors the border instead
+	blocked.Session = &domain.Session{BeadID: "az-1", State: domain.SessionWaiting}
+	if card := RenderCard(blocked, false, false, 30, s); strings.Contains(card, configured) {
+		t.Errorf("Card with a waiting session should take the session's color, got: %q", card)
+	}
+}
+
 func TestRenderCard_WithSession(t *testing.T) {
 	s := styles.New()
 	startedAt := time.Now().Add(-2*time.Hour - 30*time.Minute)
@@ -181,6 +239,207 @@ func TestRenderCard_WithSessionNoElapsed(t *testing.T) {
 	}
 }
 
+func TestRenderCard_WithPRState(t *testing.T) {
+	s := styles.New()
+
+	tests := []struct {
+		name  string
+		state domain.PRState
+	}{
+		{name: "open PR", state: domain.PROpen},
+		{name: "approved PR", state: domain.PRApproved},
+		{name: "changes requested", state: domain.PRChangesRequested},
+		{name: "merged PR", state: domain.PRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := domain.Task{
+				ID:       "az-321",
+				Title:    "Task with " + tt.name,
+				Status:   domain.StatusInProgress,
+				Priority: domain.P2,
+				Type:     domain.TypeTask,
+				PRState:  tt.state,
+			}
+
+			result := RenderCard(task, false, false, 40, s)
+			stripped := stripANSI(result)
+
+			if !strings.Contains(stripped, tt.state.Icon()+" PR") {
+				t.Errorf("Card should contain PR badge %s, got: %s", tt.state.Icon(), stripped)
+			}
+		})
+	}
+
+	// A recorded PR shows its number, with or without a polled state
+	numbered := domain.Task{ID: "az-323", Title: "PR task", Status: domain.StatusInProgress, Type: domain.TypeTask, PRNumber: 7}
+	if !strings.Contains(stripANSI(RenderCard(numbered, false, false, 40, s)), "PR #7") {
+		t.Error("Card with a recorded PR should contain PR #7")
+	}
+	numbered.PRState = domain.PRApproved
+	if !strings.Contains(stripANSI(RenderCard(numbered, false, false, 40, s)), domain.PRApproved.Icon()+" PR #7") {
+		t.Error("Card should combine the PR state icon and number")
+	}
+
+	// No PR badge without a PR
+	task := domain.Task{ID: "az-322", Title: "Plain task", Status: domain.StatusOpen, Type: domain.TypeTask}
+	if strings.Contains(stripANSI(RenderCard(task, false, false, 40, s)), " PR") {
+		t.Error("Card without a PR should not contain a PR badge")
+	}
+}
+
+func TestRenderCard_WithAttachments(t *testing.T) {
+	s := styles.New()
+
+	task := domain.Task{
+		ID:          "az-323",
+		Title:       "Task with screenshots",
+		Status:      domain.StatusOpen,
+		Priority:    domain.P2,
+		Type:        domain.TypeTask,
+		Attachments: 3,
+	}
+	if !strings.Contains(stripANSI(RenderCard(task, false, false, 40, s)), "📎3") {
+		t.Error("Card should contain the attachment count badge")
+	}
+
+	task.Attachments = 0
+	if strings.Contains(stripANSI(RenderCard(task, false, false, 40, s)), "📎") {
+		t.Error("Card without attachments should not contain an attachment badge")
+	}
+}
+
+func TestRenderCard_Blocked(t *testing.T) {
+	s := styles.New()
+
+	task := domain.Task{
+		ID:        "az-324",
+		Title:     "Waiting on the API",
+		Status:    domain.StatusOpen,
+		Priority:  domain.P2,
+		Type:      domain.TypeTask,
+		BlockedBy: []string{"az-1", "az-2"},
+	}
+	if !strings.Contains(stripANSI(RenderCard(task, false, false, 40, s)), "⛔ blocked by 2") {
+		t.Error("Card with undone blockers should contain the blocked badge")
+	}
+
+	task.BlockedBy = nil
+	if strings.Contains(stripANSI(RenderCard(task, false, false, 40, s)), "blocked by") {
+		t.Error("Card without blockers should not contain a blocked badge")
+	}
+}
+
+func TestRenderCard_BadgesKeepCardHeight(t *testing.T) {
+	s := styles.New()
+
+	task := domain.Task{
+		ID:       "az-317",
+		Title:    "Busy card",
+		Status:   domain.StatusOpen,
+		Priority: domain.P1,
+		Type:     domain.TypeTask,
+	}
+	plain := RenderCard(task, false, false, 30, s)
+
+	task.BlockedBy = []string{"az-1", "az-2"}
+	task.PRState = domain.PROpen
+	task.PRNumber = 1234
+	task.Attachments = 12
+	busy := RenderCard(task, false, false, 30, s)
+
+	if lipgloss.Height(busy) != lipgloss.Height(plain) {
+		t.Errorf("Expected badges not to wrap the card to %d lines, got:\n%s", lipgloss.Height(busy), busy)
+	}
+	if lipgloss.Width(busy) != lipgloss.Width(plain) {
+		t.Errorf("Expected badges not to widen the card, got:\n%s", busy)
+	}
+	if !strings.Contains(stripANSI(busy), "…") {
+		t.Errorf("Expected cut badges to end in an ellipsis, got:\n%s", stripANSI(busy))
+	}
+}
+
+func TestRenderCard_WithAge(t *testing.T) {
+	s := styles.New()
+	task := domain.Task{
+		ID:        "az-400",
+		Title:     "Old task",
+		Status:    domain.StatusOpen,
+		Priority:  domain.P2,
+		Type:      domain.TypeTask,
+		UpdatedAt: time.Now().Add(-3*24*time.Hour - time.Hour),
+	}
+
+	shown := stripANSI(RenderCardWithAge(task, 40, AgeOptions{Show: true}, s))
+	if !strings.Contains(shown, "3d") {
+		t.Errorf("Card should contain age 3d, got: %s", shown)
+	}
+
+	hidden := stripANSI(RenderCardWithAge(task, 40, AgeOptions{Show: false}, s))
+	if strings.Contains(hidden, "3d") {
+		t.Errorf("Card should not contain age when disabled, got: %s", hidden)
+	}
+
+	task.UpdatedAt = time.Time{}
+	unknown := stripANSI(RenderCardWithAge(task, 40, AgeOptions{Show: true}, s))
+	if strings.Count(unknown, "•") != 1 {
+		t.Errorf("Card with unknown UpdatedAt should not show an age badge, got: %s", unknown)
+	}
+}
+
+func TestCardAge(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		updatedAt time.Time
+		age       AgeOptions
+		wantText  string
+		wantStale bool
+	}{
+		{
+			name:      "disabled",
+			updatedAt: now.Add(-10 * 24 * time.Hour),
+			age:       AgeOptions{Show: false, StaleAfter: week},
+		},
+		{
+			name: "unknown update time",
+			age:  AgeOptions{Show: true, StaleAfter: week},
+		},
+		{
+			name:      "fresh task",
+			updatedAt: now.Add(-2 * time.Hour),
+			age:       AgeOptions{Show: true, StaleAfter: week},
+			wantText:  "2h",
+		},
+		{
+			name:      "stale task",
+			updatedAt: now.Add(-10 * 24 * time.Hour),
+			age:       AgeOptions{Show: true, StaleAfter: week},
+			wantText:  "10d",
+			wantStale: true,
+		},
+		{
+			name:      "no stale threshold",
+			updatedAt: now.Add(-100 * 24 * time.Hour),
+			age:       AgeOptions{Show: true},
+			wantText:  "100d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := domain.Task{ID: "az-401", UpdatedAt: tt.updatedAt}
+			gotText, gotStale := cardAge(task, tt.age, now)
+			if gotText != tt.wantText || gotStale != tt.wantStale {
+				t.Errorf("cardAge() = (%q, %v), want (%q, %v)", gotText, gotStale, tt.wantText, tt.wantStale)
+			}
+		})
+	}
+}
+
 func TestRenderCard_Epic(t *testing.T) {
 	s := styles.New()
 
@@ -205,7 +464,7 @@ func TestRenderCard_Epic(t *testing.T) {
 		t.Errorf("Epic card should contain progress bar, got: %s", stripped)
 	}
 
-	// Should contain ratio (from placeholder values)
+	// Should contain ratio (0/0 without progress data)
 	if !strings.Contains(stripped, "/") {
 		t.Errorf("Epic card should contain completion ratio, got: %s", stripped)
 	}
@@ -310,29 +569,60 @@ func TestRenderCard_TitleTruncation(t *testing.T) {
 
 func TestRenderEpicProgress(t *testing.T) {
 	s := styles.New()
-	task := domain.Task{
-		ID:       "az-epic-test",
-		Title:    "Test epic",
-		Status:   domain.StatusInProgress,
-		Priority: domain.P1,
-		Type:     domain.TypeEpic,
+
+	tests := []struct {
+		name     string
+		progress EpicProgress
+		want     string
+	}{
+		{name: "no children", progress: EpicProgress{}, want: "[0/0] ░░░░░░"},
+		{name: "nothing done", progress: EpicProgress{Done: 0, Total: 4}, want: "[0/4] ░░░░░░"},
+		{name: "partially done", progress: EpicProgress{Done: 3, Total: 5}, want: "[3/5] ███░░░"},
+		{name: "half done", progress: EpicProgress{Done: 2, Total: 4}, want: "[2/4] ███░░░"},
+		{name: "all done", progress: EpicProgress{Done: 5, Total: 5}, want: "[5/5] ██████"},
 	}
 
-	result := renderEpicProgress(task, 40, s)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stripANSI(renderEpicProgress(tt.progress, s))
+			if got != tt.want {
+				t.Errorf("renderEpicProgress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
 
-	// Should contain progress ratio (placeholder: 3/5)
-	if !strings.Contains(result, "3") || !strings.Contains(result, "5") {
-		t.Error("Epic progress should contain completion counts")
+func TestEpicProgress_Fraction(t *testing.T) {
+	tests := []struct {
+		progress EpicProgress
+		want     float64
+	}{
+		{progress: EpicProgress{}, want: 0},
+		{progress: EpicProgress{Done: 1, Total: 4}, want: 0.25},
+		{progress: EpicProgress{Done: 3, Total: 3}, want: 1},
 	}
 
-	// Should contain filled blocks
-	if !strings.Contains(result, "█") {
-		t.Error("Epic progress should contain filled blocks")
+	for _, tt := range tests {
+		if got := tt.progress.Fraction(); got != tt.want {
+			t.Errorf("%+v.Fraction() = %v, want %v", tt.progress, got, tt.want)
+		}
+	}
+}
+
+func TestRenderCardWithEpicProgress(t *testing.T) {
+	s := styles.New()
+	epic := domain.Task{ID: "az-epic-3", Title: "Epic", Status: domain.StatusInProgress, Type: domain.TypeEpic}
+	task := domain.Task{ID: "az-500", Title: "Plain task", Status: domain.StatusOpen, Type: domain.TypeTask}
+
+	got := stripANSI(RenderCardWithEpicProgress(epic, 30, EpicProgress{Done: 1, Total: 2}, s))
+	if !strings.Contains(got, "[1/2] ███░░░") {
+		t.Errorf("Epic card should show 1/2 progress, got: %s", got)
 	}
 
-	// Should contain empty blocks
-	if !strings.Contains(result, "░") {
-		t.Error("Epic progress should contain empty blocks")
+	// Only epics get a progress bar
+	got = stripANSI(RenderCardWithEpicProgress(task, 30, EpicProgress{Done: 1, Total: 2}, s))
+	if strings.Contains(got, "[1/2]") {
+		t.Errorf("Non-epic card should not show progress, got: %s", got)
 	}
 }
 
@@ -378,6 +668,63 @@ func TestRenderSessionStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("busy with a reason", func(t *testing.T) {
+		session := &domain.Session{
+			BeadID:     "test",
+			State:      domain.SessionBusy,
+			BusyReason: domain.BusyThinking,
+		}
+
+		stripped := stripANSI(renderSessionStatus(session, s))
+
+		if !strings.Contains(stripped, "✻") || strings.Contains(stripped, "●") {
+			t.Errorf("Thinking session should show the thinking icon, got: %s", stripped)
+		}
+	})
+
+	t.Run("stalled busy session", func(t *testing.T) {
+		session := &domain.Session{
+			BeadID:     "test",
+			State:      domain.SessionBusy,
+			BusyReason: domain.BusyThinking,
+			Stalled:    true,
+		}
+
+		stripped := stripANSI(renderSessionStatus(session, s))
+
+		if !strings.Contains(stripped, "stalled") {
+			t.Errorf("Stalled session should say so, got: %s", stripped)
+		}
+	})
+
+	t.Run("branch ahead and behind base", func(t *testing.T) {
+		session := &domain.Session{
+			BeadID: "test",
+			State:  domain.SessionWaiting,
+			Ahead:  3,
+			Behind: 1,
+		}
+
+		stripped := stripANSI(renderSessionStatus(session, s))
+
+		if !strings.Contains(stripped, "↑3 ↓1") {
+			t.Errorf("Session should show ahead/behind counts, got: %s", stripped)
+		}
+	})
+
+	t.Run("branch level with base", func(t *testing.T) {
+		session := &domain.Session{
+			BeadID: "test",
+			State:  domain.SessionWaiting,
+		}
+
+		stripped := stripANSI(renderSessionStatus(session, s))
+
+		if strings.Contains(stripped, "↑") || strings.Contains(stripped, "↓") {
+			t.Errorf("Session level with base shouldn't show counts, got: %s", stripped)
+		}
+	})
+
 	t.Run("error session", func(t *testing.T) {
 		session := &domain.Session{
 			BeadID: "test",
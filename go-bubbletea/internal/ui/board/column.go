@@ -6,67 +6,81 @@ import (
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/riordanpawley/azedarach/internal/core/phases"
-	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
 const cardHeight = 5
 
+// collapsedColumnWidth is the width of a collapsed column, which shows only
+// its title and count
+const collapsedColumnWidth = 12
+
 func renderColumn(
-	title string,
-	tasks []domain.Task,
+	col Column,
 	cursorTask int,
 	isActive bool,
 	selectedTasks map[string]bool,
+	movedTasks map[string]bool,
 	phaseData map[string]phases.TaskPhaseInfo,
 	showPhases bool,
+	epicProgress map[string]EpicProgress,
+	age AgeOptions,
 	width int,
 	height int,
 	s *styles.Styles,
 ) string {
+	tasks := col.Tasks
+
 	headerStyle := s.ColumnHeader
 	if isActive {
 		headerStyle = s.ColumnHeaderActive
 	}
+	if col.OverLimit() {
+		headerStyle = s.ColumnHeaderOverLimit
+	}
 
-	headerText := fmt.Sprintf("%s (%d)", title, len(tasks))
-	header := headerStyle.Width(width).Render(headerText)
+	count := fmt.Sprintf(" (%d)", col.Count())
+	if col.Limit > 0 {
+		count = fmt.Sprintf(" (%d/%d)", col.Count(), col.Limit)
+	}
+	title := col.Title
+	if col.Collapsed {
+		// Shorten the title rather than wrap the header, keeping the count
+		title = ansi.Truncate(title, width-headerStyle.GetHorizontalFrameSize()-len(count), "…")
+	}
+	header := headerStyle.Width(width).Render(title + count)
+	if col.Collapsed {
+		return header
+	}
 
-	availableHeight := height - 2
+	availableHeight := ColumnViewHeight(height)
 
 	var cardContent strings.Builder
 	cardWidth := width - 2
 
-	for i, task := range tasks {
+	// Only the cards in view are rendered, from the scroll offset down until
+	// the column is full. CardAt relies on the same offset.
+	lines := 0
+	for i := ScrollOffset(tasks, col.Offset, cursorTask, availableHeight); i < len(tasks) && lines < availableHeight; i++ {
+		task := tasks[i]
+		lines += cardLines(task)
 		isCursor := isActive && i == cursorTask
 		isSelected := selectedTasks[task.ID]
+		isMoved := movedTasks[task.ID]
 
 		var phaseInfo *phases.TaskPhaseInfo
 		if info, exists := phaseData[task.ID]; exists {
 			phaseInfo = &info
 		}
 
-		cardContent.WriteString(renderCard(task, isCursor, isSelected, cardWidth, phaseInfo, showPhases, s))
+		cardContent.WriteString(renderCard(task, isCursor, isSelected, isMoved, cardWidth, phaseInfo, showPhases, epicProgress[task.ID], age, s))
 		cardContent.WriteString("\n")
 	}
 
 	vp := viewport.New(width, availableHeight)
 	vp.SetContent(cardContent.String())
 
-	// Calculate viewport offset to keep cursor visible
-	// Each card is roughly cardHeight tall + 1 for newline
-	// But let's just use LineDown based on index for now
-	if cursorTask >= 0 && cursorTask < len(tasks) {
-		linesPerCard := cardHeight + 1
-		scrollLine := cursorTask * linesPerCard
-
-		// Ensure we don't scroll past content
-		vp.GotoTop()
-		for i := 0; i < scrollLine; i++ {
-			vp.LineDown(1)
-		}
-	}
-
 	return lipgloss.JoinVertical(lipgloss.Left, header, vp.View())
 }
@@ -0,0 +1,105 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// ExportFormat is the output format of an exported board
+type ExportFormat int
+
+const (
+	ExportMarkdown ExportFormat = iota
+	ExportJSON
+)
+
+// ParseExportFormat returns the export format called name: "md" or
+// "markdown", or "json"
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(name) {
+	case "md", "markdown":
+		return ExportMarkdown, nil
+	case "json":
+		return ExportJSON, nil
+	default:
+		return 0, fmt.Errorf("unknown export format %q (want md or json)", name)
+	}
+}
+
+// exportedColumn is a column in a JSON export
+type exportedColumn struct {
+	Title  string         `json:"title"`
+	Status domain.Status  `json:"status"`
+	Tasks  []exportedTask `json:"tasks"`
+}
+
+// exportedTask is a task in a JSON export
+type exportedTask struct {
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Priority string          `json:"priority"`
+	Status   domain.Status   `json:"status"`
+	Type     domain.TaskType `json:"type"`
+}
+
+// Export renders columns as a document for sharing outside the TUI, each
+// column's tasks in order. Collapsed columns should be expanded first, as
+// their tasks aren't in Tasks.
+func Export(columns []Column, format ExportFormat) (string, error) {
+	if format == ExportJSON {
+		return exportJSON(columns)
+	}
+	return exportMarkdown(columns), nil
+}
+
+// exportMarkdown renders one section per column with a table of its tasks
+func exportMarkdown(columns []Column) string {
+	var b strings.Builder
+	b.WriteString("# Azedarach Board\n")
+
+	for _, col := range columns {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", col.Title, len(col.Tasks))
+		if len(col.Tasks) == 0 {
+			b.WriteString("_No tasks_\n")
+			continue
+		}
+		b.WriteString("| ID | Title | Priority | Status |\n")
+		b.WriteString("|----|-------|----------|--------|\n")
+		for _, task := range col.Tasks {
+			title := strings.ReplaceAll(task.Title, "|", `\|`)
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", task.ID, title, task.Priority, task.Status)
+		}
+	}
+
+	return b.String()
+}
+
+// exportJSON renders the columns as {"columns": [...]}
+func exportJSON(columns []Column) (string, error) {
+	doc := struct {
+		Columns []exportedColumn `json:"columns"`
+	}{Columns: make([]exportedColumn, 0, len(columns))}
+
+	for _, col := range columns {
+		exported := exportedColumn{Title: col.Title, Status: col.Status, Tasks: make([]exportedTask, 0, len(col.Tasks))}
+		for _, task := range col.Tasks {
+			exported.Tasks = append(exported.Tasks, exportedTask{
+				ID:       task.ID,
+				Title:    task.Title,
+				Priority: task.Priority.String(),
+				Status:   task.Status,
+				Type:     task.Type,
+			})
+		}
+		doc.Columns = append(doc.Columns, exported)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode board: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,106 @@
+package board
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+var exportColumns = []Column{
+	{Title: "Open", Status: domain.StatusOpen, Tasks: []domain.Task{
+		{ID: "az-1", Title: "Fix login | signup", Status: domain.StatusOpen, Priority: domain.P1, Type: domain.TypeBug},
+		{ID: "az-2", Title: "Write docs", Status: domain.StatusOpen, Priority: domain.P3, Type: domain.TypeTask},
+	}},
+	{Title: "In Progress", Status: domain.StatusInProgress},
+	{Title: "Done", Status: domain.StatusDone, Tasks: []domain.Task{
+		{ID: "az-3", Title: "Ship it", Status: domain.StatusDone, Priority: domain.P0, Type: domain.TypeFeature},
+	}},
+}
+
+func TestExport_Markdown(t *testing.T) {
+	got, err := Export(exportColumns, ExportMarkdown)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	want := "# Azedarach Board\n" +
+		"\n## Open (2)\n\n" +
+		"| ID | Title | Priority | Status |\n" +
+		"|----|-------|----------|--------|\n" +
+		"| `az-1` | Fix login \\| signup | P1 | open |\n" +
+		"| `az-2` | Write docs | P3 | open |\n" +
+		"\n## In Progress (0)\n\n" +
+		"_No tasks_\n" +
+		"\n## Done (1)\n\n" +
+		"| ID | Title | Priority | Status |\n" +
+		"|----|-------|----------|--------|\n" +
+		"| `az-3` | Ship it | P0 | closed |\n"
+	if got != want {
+		t.Errorf("Markdown export mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExport_MarkdownKeepsColumnOrder(t *testing.T) {
+	got, err := Export(exportColumns, ExportMarkdown)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	open := strings.Index(got, "## Open")
+	progress := strings.Index(got, "## In Progress")
+	done := strings.Index(got, "## Done")
+	if !(open < progress && progress < done) {
+		t.Errorf("Expected columns left to right, got sections at %d, %d and %d", open, progress, done)
+	}
+	if first, second := strings.Index(got, "az-1"), strings.Index(got, "az-2"); first > second {
+		t.Error("Expected tasks in their column order")
+	}
+}
+
+func TestExport_JSON(t *testing.T) {
+	got, err := Export(exportColumns, ExportJSON)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	var doc struct {
+		Columns []struct {
+			Title  string `json:"title"`
+			Status string `json:"status"`
+			Tasks  []struct {
+				ID       string `json:"id"`
+				Title    string `json:"title"`
+				Priority string `json:"priority"`
+				Status   string `json:"status"`
+				Type     string `json:"type"`
+			} `json:"tasks"`
+		} `json:"columns"`
+	}
+	if err := json.Unmarshal([]byte(got), &doc); err != nil {
+		t.Fatalf("Expected valid JSON, got %v:\n%s", err, got)
+	}
+
+	if len(doc.Columns) != 3 || doc.Columns[1].Title != "In Progress" {
+		t.Fatalf("Expected the three columns in order, got %+v", doc.Columns)
+	}
+	if doc.Columns[1].Tasks == nil {
+		t.Error("Expected an empty column to have an empty task list, not null")
+	}
+	task := doc.Columns[0].Tasks[0]
+	if task.ID != "az-1" || task.Priority != "P1" || task.Status != "open" || task.Type != "bug" {
+		t.Errorf("Unexpected exported task %+v", task)
+	}
+}
+
+func TestParseExportFormat(t *testing.T) {
+	for name, want := range map[string]ExportFormat{"md": ExportMarkdown, "Markdown": ExportMarkdown, "json": ExportJSON} {
+		if got, err := ParseExportFormat(name); err != nil || got != want {
+			t.Errorf("ParseExportFormat(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseExportFormat("csv"); err == nil {
+		t.Error("Expected csv to be rejected")
+	}
+}
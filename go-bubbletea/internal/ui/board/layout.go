@@ -0,0 +1,192 @@
+package board
+
+import "github.com/riordanpawley/azedarach/internal/domain"
+
+// headerLines is the height of a column header, including its margin
+const headerLines = 2
+
+// minColumnWidth is the narrowest an expanded column is drawn. Columns that
+// don't fit the board at this width are paged.
+const minColumnWidth = 20
+
+// pagerWidth is the width of the strip at either edge of a paged board
+const pagerWidth = 5
+
+// cardLines is how many lines a card takes in its column, including the
+// margin below it. Sessions and epic progress each add a row.
+func cardLines(task domain.Task) int {
+	lines := cardHeight
+	if task.Session != nil {
+		lines++
+	}
+	if task.Type == domain.TypeEpic {
+		lines++
+	}
+	return lines
+}
+
+// ColumnViewHeight is how many lines of cards each column shows on a board
+// drawn at height
+func ColumnViewHeight(height int) int {
+	return height - headerLines
+}
+
+// PageCards is how many cards a column shows on a board drawn at height,
+// counting cards without a session or progress row
+func PageCards(height int) int {
+	return max(ColumnViewHeight(height)/cardHeight, 1)
+}
+
+// ScrollOffset returns the index of the first card drawn in a column of
+// tasks showing viewHeight lines of cards. It starts from offset, the
+// column's previous scroll, and moves it as little as needed to bring
+// cursorTask fully into view; columns without the cursor pass -1. A column
+// isn't scrolled further than it takes to show its last card.
+func ScrollOffset(tasks []domain.Task, offset, cursorTask, viewHeight int) int {
+	offset = max(min(offset, lastOffset(tasks, viewHeight)), 0)
+	if cursorTask < 0 || cursorTask >= len(tasks) {
+		return offset
+	}
+
+	if cursorTask < offset {
+		return cursorTask
+	}
+	lines := 0
+	for i := offset; i <= cursorTask; i++ {
+		lines += cardLines(tasks[i])
+	}
+	for offset < cursorTask && lines > viewHeight {
+		lines -= cardLines(tasks[offset])
+		offset++
+	}
+	return offset
+}
+
+// lastOffset is the furthest a column of tasks showing viewHeight lines is
+// scrolled: the first of the cards that end the column and fit the view
+func lastOffset(tasks []domain.Task, viewHeight int) int {
+	lines := 0
+	for i := len(tasks) - 1; i >= 0; i-- {
+		lines += cardLines(tasks[i])
+		if lines > viewHeight {
+			return min(i+1, len(tasks)-1)
+		}
+	}
+	return 0
+}
+
+// boardLayout is how the board's columns are laid out across its width
+type boardLayout struct {
+	columns []Column // Columns drawn, with focus mode applied
+	first   int      // Index of columns[0] on the board
+	widths  []int    // Width of each drawn column
+	paged   bool     // Some columns are off screen; pager strips edge the board
+}
+
+// layoutColumns lays out columns for a board drawn at width with the given
+// cursor and focused column
+func layoutColumns(columns []Column, cursor Cursor, focusedColumn, width int) boardLayout {
+	columns = focusColumns(columns, focusedColumn)
+	first, last, paged := columnWindow(columns, cursor.Column, width)
+	if paged {
+		width -= 2 * pagerWidth
+	}
+	return boardLayout{
+		columns: columns[first:last],
+		first:   first,
+		widths:  columnWidths(columns[first:last], width),
+		paged:   paged,
+	}
+}
+
+// columnWindow returns the columns drawn at width, from first up to but not
+// including last. When they don't all fit at their narrowest, the columns
+// are split into pages, each as many as fit between the pager strips, and
+// the page holding cursorColumn is drawn.
+func columnWindow(columns []Column, cursorColumn, width int) (first, last int, paged bool) {
+	needed := 0
+	for _, col := range columns {
+		needed += col.minWidth()
+	}
+	if needed <= width {
+		return 0, len(columns), false
+	}
+
+	cursorColumn = max(min(cursorColumn, len(columns)-1), 0)
+	available := width - 2*pagerWidth
+	for first < len(columns) {
+		used := 0
+		last = first
+		// A page always holds at least one column, however narrow the board
+		for last < len(columns) && (last == first || used+columns[last].minWidth() <= available) {
+			used += columns[last].minWidth()
+			last++
+		}
+		if cursorColumn < last {
+			break
+		}
+		first = last
+	}
+	return first, last, true
+}
+
+// minWidth is the narrowest the column is drawn
+func (c Column) minWidth() int {
+	if c.Collapsed {
+		return collapsedColumnWidth
+	}
+	return minColumnWidth
+}
+
+// CardAt maps a cell of a board drawn by Render with the same columns,
+// cursor, focused column, width and height back to what is drawn there.
+// column is the index on the board, whichever page is drawn; task is the
+// index of the card under the cell, or -1 over a header or empty space; ok
+// is false if the cell is outside every column, pager strips included.
+func CardAt(columns []Column, cursor Cursor, focusedColumn, width, height, x, y int) (column, task int, ok bool) {
+	if x < 0 || y < 0 || y >= height {
+		return 0, -1, false
+	}
+	layout := layoutColumns(columns, cursor, focusedColumn, width)
+
+	left := 0
+	if layout.paged {
+		left = pagerWidth
+	}
+	if x < left {
+		return 0, -1, false
+	}
+	column = -1
+	for i, w := range layout.widths {
+		if x < left+w {
+			column = i
+			break
+		}
+		left += w
+	}
+	if column < 0 {
+		return 0, -1, false
+	}
+
+	col := layout.columns[column]
+	column += layout.first
+	viewHeight := height - headerLines
+	if col.Collapsed || y < headerLines || y-headerLines >= viewHeight {
+		return column, -1, true
+	}
+
+	cursorTask := -1
+	if column == cursor.Column {
+		cursorTask = cursor.Task
+	}
+	line := y - headerLines
+
+	top := 0
+	for i := ScrollOffset(col.Tasks, col.Offset, cursorTask, viewHeight); i < len(col.Tasks); i++ {
+		top += cardLines(col.Tasks[i])
+		if line < top {
+			return column, i, true
+		}
+	}
+	return column, -1, true
+}
@@ -0,0 +1,224 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+func TestCardAt(t *testing.T) {
+	// A column long enough to scroll, with cards of every height
+	var tasks []domain.Task
+	for i := 0; i < 10; i++ {
+		task := domain.Task{ID: fmt.Sprintf("az-%d", i), Title: fmt.Sprintf("Card %c", 'A'+i), Type: domain.TypeTask}
+		switch i {
+		case 2:
+			task.Session = &domain.Session{BeadID: task.ID, State: domain.SessionIdle}
+		case 5:
+			task.Type = domain.TypeEpic
+		}
+		tasks = append(tasks, task)
+	}
+	columns := CreatePlaceholderData()
+	columns[0] = Column{Title: "Open", Tasks: tasks}
+
+	const width, height = 120, 30
+	s := styles.New()
+
+	for _, cursor := range []Cursor{{Column: 0, Task: 0}, {Column: 0, Task: 6}, {Column: 1, Task: 0}} {
+		lines := strings.Split(Render(columns, cursor, NoFocus, nil, nil, nil, false, nil, AgeOptions{}, s, width, height), "\n")
+
+		// Every card drawn on screen is found where its title is drawn
+		drawn := 0
+		for i, task := range tasks {
+			for y, line := range lines {
+				if !strings.Contains(line, task.Title) {
+					continue
+				}
+				drawn++
+				column, got, ok := CardAt(columns, cursor, NoFocus, width, height, 2, y)
+				if !ok || column != 0 || got != i {
+					t.Errorf("cursor %+v: CardAt(2, %d) = %d, %d, %v; want %s at 0, %d", cursor, y, column, got, ok, task.Title, i)
+				}
+			}
+		}
+		if drawn < 4 {
+			t.Errorf("cursor %+v: expected at least 4 cards drawn, found %d", cursor, drawn)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		x, y       int
+		wantColumn int
+		wantTask   int
+		wantOK     bool
+	}{
+		{"header", 2, 0, 0, -1, true},
+		{"second column", 40, 3, 1, 0, true},
+		{"empty space", 100, 25, 3, -1, true},
+		{"right of the board", width, 3, 0, -1, false},
+		{"below the board", 2, height, 0, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, task, ok := CardAt(columns, Cursor{}, NoFocus, width, height, tt.x, tt.y)
+			if ok != tt.wantOK || (ok && (column != tt.wantColumn || task != tt.wantTask)) {
+				t.Errorf("CardAt(%d, %d) = %d, %d, %v; want %d, %d, %v", tt.x, tt.y, column, task, ok, tt.wantColumn, tt.wantTask, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCardAt_CollapsedColumn(t *testing.T) {
+	columns := CreatePlaceholderData()
+	columns[3] = Column{Title: "Done", Collapsed: true, Hidden: 2}
+
+	// The collapsed column is the last 12 columns of the board
+	if column, task, ok := CardAt(columns, Cursor{}, NoFocus, 160, 30, 155, 5); !ok || column != 3 || task != -1 {
+		t.Errorf("CardAt over a collapsed column = %d, %d, %v; want 3, -1, true", column, task, ok)
+	}
+}
+
+func TestCardAt_FocusedColumn(t *testing.T) {
+	columns := CreatePlaceholderData()
+
+	// Focused on In Progress: Open is the first 12 columns, then In Progress
+	if column, task, ok := CardAt(columns, Cursor{Column: 1}, 1, 80, 30, 5, 3); !ok || column != 0 || task != -1 {
+		t.Errorf("CardAt over a collapsed column = %d, %d, %v; want 0, -1, true", column, task, ok)
+	}
+	if column, task, ok := CardAt(columns, Cursor{Column: 1}, 1, 80, 30, 20, 3); !ok || column != 1 || task != 0 {
+		t.Errorf("CardAt over the focused column = %d, %d, %v; want 1, 0, true", column, task, ok)
+	}
+}
+
+func TestScrollOffset(t *testing.T) {
+	// Cards are cardHeight lines each; four fit a view of 22 lines
+	tasks := make([]domain.Task, 10)
+	const viewHeight = 22
+
+	tests := []struct {
+		name       string
+		tasks      []domain.Task
+		offset     int
+		cursorTask int
+		want       int
+	}{
+		{"cursor in view", tasks, 0, 3, 0},
+		{"cursor just below", tasks, 0, 4, 1},
+		{"cursor far below", tasks, 0, 8, 5},
+		{"cursor above", tasks, 5, 2, 2},
+		{"scroll kept without the cursor", tasks, 3, -1, 3},
+		{"cursor in a scrolled view", tasks, 3, 5, 3},
+		{"past the last card", tasks, 9, -1, 6},
+		{"negative", tasks, -2, -1, 0},
+		{"column shorter than the view", tasks[:3], 2, 2, 0},
+		{"empty column", nil, 4, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScrollOffset(tt.tasks, tt.offset, tt.cursorTask, viewHeight); got != tt.want {
+				t.Errorf("ScrollOffset(offset %d, cursor %d) = %d, want %d", tt.offset, tt.cursorTask, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_DrawsOnlyCardsInView(t *testing.T) {
+	var tasks []domain.Task
+	for i := 0; i < 100; i++ {
+		tasks = append(tasks, domain.Task{ID: fmt.Sprintf("az-%d", i), Title: fmt.Sprintf("Card %03d", i), Type: domain.TypeTask})
+	}
+	columns := []Column{{Title: "Open", Tasks: tasks, Offset: 40}}
+
+	out := Render(columns, Cursor{Column: 0, Task: 42}, NoFocus, nil, nil, nil, false, nil, AgeOptions{}, styles.New(), 60, 30)
+	if got := strings.Count(out, "\n") + 1; got != 30 {
+		t.Errorf("Expected the column to fill 30 lines, got %d", got)
+	}
+	for _, title := range []string{"Card 040", "Card 042", "Card 045"} {
+		if !strings.Contains(out, title) {
+			t.Errorf("Expected %s drawn", title)
+		}
+	}
+	for _, title := range []string{"Card 000", "Card 039", "Card 047"} {
+		if strings.Contains(out, title) {
+			t.Errorf("Expected %s out of view", title)
+		}
+	}
+}
+
+func TestColumnWindow(t *testing.T) {
+	// Six columns need 120 cells at minColumnWidth; a page has the width
+	// left between the pager strips
+	columns := make([]Column, 6)
+
+	tests := []struct {
+		name      string
+		cursor    int
+		width     int
+		wantFirst int
+		wantLast  int
+		wantPaged bool
+	}{
+		{"all fit", 4, 120, 0, 6, false},
+		{"first page", 1, 100, 0, 4, true},
+		{"second page", 4, 100, 4, 6, true},
+		{"last column of a page", 3, 100, 0, 4, true},
+		{"three to a page", 3, 70, 3, 6, true},
+		{"narrower than a column", 2, 15, 2, 3, true},
+		{"cursor past the end", 9, 70, 3, 6, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, paged := columnWindow(columns, tt.cursor, tt.width)
+			if first != tt.wantFirst || last != tt.wantLast || paged != tt.wantPaged {
+				t.Errorf("columnWindow(cursor %d, width %d) = %d, %d, %v; want %d, %d, %v",
+					tt.cursor, tt.width, first, last, paged, tt.wantFirst, tt.wantLast, tt.wantPaged)
+			}
+		})
+	}
+}
+
+func TestColumnWindow_CollapsedColumns(t *testing.T) {
+	// Collapsed columns are narrower, so more of them fit on a page
+	columns := make([]Column, 6)
+	for i := 1; i < len(columns); i++ {
+		columns[i].Collapsed = true
+	}
+
+	if first, last, paged := columnWindow(columns, 5, 80); first != 0 || last != 6 || paged {
+		t.Errorf("columnWindow(width 80) = %d, %d, %v; want 0, 6, false", first, last, paged)
+	}
+	if first, last, paged := columnWindow(columns, 2, 70); first != 0 || last != 4 || !paged {
+		t.Errorf("columnWindow(width 70) = %d, %d, %v; want 0, 4, true", first, last, paged)
+	}
+}
+
+func TestCardAt_PagedColumns(t *testing.T) {
+	columns := CreatePlaceholderData()
+	columns = append(columns, Column{Title: "Review"}, Column{Title: "QA"})
+	cursor := Cursor{Column: 3}
+
+	// At 100 cells the six columns page four at a time: the cursor's page
+	// holds Open to Done between the pager strips
+	if _, _, ok := CardAt(columns, cursor, NoFocus, 100, 30, 2, 3); ok {
+		t.Error("CardAt over the left pager strip should be outside every column")
+	}
+	if column, task, ok := CardAt(columns, cursor, NoFocus, 100, 30, pagerWidth+2, 3); !ok || column != 0 || task != 0 {
+		t.Errorf("CardAt over the first column = %d, %d, %v; want 0, 0, true", column, task, ok)
+	}
+
+	// On the second page the board's index of the column comes back
+	cursor = Cursor{Column: 5}
+	if column, task, ok := CardAt(columns, cursor, NoFocus, 100, 30, pagerWidth+50, 3); !ok || column != 5 || task != -1 {
+		t.Errorf("CardAt over QA = %d, %d, %v; want 5, -1, true", column, task, ok)
+	}
+
+	lines := strings.Split(Render(columns, cursor, NoFocus, nil, nil, nil, false, nil, AgeOptions{}, styles.New(), 100, 30), "\n")
+	if !strings.Contains(lines[0], "◀4") || !strings.Contains(lines[0], "Review") || strings.Contains(lines[0], "Open") {
+		t.Errorf("second page header = %q; want the pager counting 4 columns to the left, then Review", lines[0])
+	}
+}
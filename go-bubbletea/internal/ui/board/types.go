@@ -1,24 +1,71 @@
 package board
 
-import "github.com/riordanpawley/azedarach/internal/domain"
+import (
+	"time"
+
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
 
 // Column represents a kanban column with tasks
 type Column struct {
-	Title string
-	Tasks []domain.Task
+	Title  string
+	Status domain.Status // Status of the tasks the column holds
+	Tasks  []domain.Task
+	Limit  int // WIP limit shown in the header; 0 is unlimited
+
+	// A collapsed column shows only its header. Its tasks are left out of
+	// Tasks, so that navigation skips them, and counted in Hidden.
+	Collapsed bool
+	Hidden    int
+
+	// Offset is the index of the first card drawn, the column's scroll.
+	// Rendering moves it further if need be to show the cursor.
+	Offset int
+}
+
+// Count returns how many tasks the column holds, shown or not
+func (c Column) Count() int {
+	return len(c.Tasks) + c.Hidden
+}
+
+// OverLimit reports whether the column holds more tasks than its WIP limit
+func (c Column) OverLimit() bool {
+	return c.Limit > 0 && c.Count() > c.Limit
 }
 
 // Cursor represents the current cursor position
 type Cursor struct {
-	Column int // Column index (0-3)
+	Column int // Column index
 	Task   int // Task index within column
 }
 
+// AgeOptions controls the relative-time annotation on cards
+type AgeOptions struct {
+	Show       bool          // Show time since the task was last updated
+	StaleAfter time.Duration // Dim cards not updated within this window (0 disables)
+}
+
+// EpicProgress counts how many of an epic's children are completed
+type EpicProgress struct {
+	Done  int
+	Total int
+}
+
+// Fraction returns the completed share between 0 and 1.
+// An epic without children has made no progress.
+func (p EpicProgress) Fraction() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
 // CreatePlaceholderData creates sample data for testing Phase 1 rendering
 func CreatePlaceholderData() []Column {
 	return []Column{
 		{
-			Title: "Open",
+			Title:  "Open",
+			Status: domain.StatusOpen,
 			Tasks: []domain.Task{
 				{
 					ID:       "az-1",
@@ -44,7 +91,8 @@ func CreatePlaceholderData() []Column {
 			},
 		},
 		{
-			Title: "In Progress",
+			Title:  "In Progress",
+			Status: domain.StatusInProgress,
 			Tasks: []domain.Task{
 				{
 					ID:       "az-3",
@@ -63,7 +111,8 @@ func CreatePlaceholderData() []Column {
 			},
 		},
 		{
-			Title: "Blocked",
+			Title:  "Blocked",
+			Status: domain.StatusBlocked,
 			Tasks: []domain.Task{
 				{
 					ID:       "az-6",
@@ -75,7 +124,8 @@ func CreatePlaceholderData() []Column {
 			},
 		},
 		{
-			Title: "Done",
+			Title:  "Done",
+			Status: domain.StatusDone,
 			Tasks: []domain.Task{
 				{
 					ID:       "az-7",
@@ -0,0 +1,161 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minSideBySideColumn is the least content width, in columns, each side of
+// the side-by-side view needs. Narrower panes fall back to unified.
+const minSideBySideColumn = 20
+
+// linePair is a row of the side-by-side view: an old line next to a new
+// line, as indexes into a hunk's lines. A side with nothing on it is -1.
+type linePair struct {
+	old int
+	new int
+}
+
+// pairLines aligns a hunk's unified lines into side-by-side rows. Context
+// lines sit on both sides. A run of deletions followed by additions is a
+// change: its lines are paired up in order, and whichever side is longer
+// gets rows with nothing opposite.
+func pairLines(hunk DiffHunk) []linePair {
+	var pairs []linePair
+	var deletes, adds []int
+
+	flush := func() {
+		for i := 0; i < len(deletes) || i < len(adds); i++ {
+			pair := linePair{old: -1, new: -1}
+			if i < len(deletes) {
+				pair.old = deletes[i]
+			}
+			if i < len(adds) {
+				pair.new = adds[i]
+			}
+			pairs = append(pairs, pair)
+		}
+		deletes, adds = deletes[:0], adds[:0]
+	}
+
+	for i, line := range hunk.Lines {
+		switch line.Type {
+		case LineDelete:
+			// A deletion after additions starts a new change
+			if len(adds) > 0 {
+				flush()
+			}
+			deletes = append(deletes, i)
+		case LineAdd:
+			adds = append(adds, i)
+		default:
+			flush()
+			pairs = append(pairs, linePair{old: i, new: i})
+		}
+	}
+	flush()
+
+	return pairs
+}
+
+// changedSpan returns the rune range [start, end) of a that differs from b,
+// found by trimming their common prefix and suffix
+func changedSpan(a, b string) (start, end int) {
+	ar, br := []rune(a), []rune(b)
+	for start < len(ar) && start < len(br) && ar[start] == br[start] {
+		start++
+	}
+	end = len(ar)
+	for j := len(br); end > start && j > start && ar[end-1] == br[j-1]; j-- {
+		end--
+	}
+	return start, end
+}
+
+// canSideBySide reports whether the diff pane is wide enough for two columns
+func (d *DiffViewer) canSideBySide() bool {
+	return sideColumnWidth(d.paneWidth())-8 >= minSideBySideColumn
+}
+
+// sideColumnWidth is the width of each side of a pane width columns wide,
+// leaving room for the " │ " between them
+func sideColumnWidth(width int) int {
+	return (width - 3) / 2
+}
+
+// renderPairRow renders a side-by-side row, old line on the left and new
+// line on the right. In a changed pair, the part of each line that differs
+// from the other side is highlighted.
+func (d *DiffViewer) renderPairRow(row diffRow, width int) string {
+	hunk := d.files[row.file].Hunks[row.hunk]
+	colWidth := sideColumnWidth(width)
+
+	var oldLine, newLine *DiffLine
+	if row.pair.old >= 0 {
+		oldLine = &hunk.Lines[row.pair.old]
+	}
+	if row.pair.new >= 0 {
+		newLine = &hunk.Lines[row.pair.new]
+	}
+
+	oldText, newText := "", ""
+	if oldLine != nil {
+		oldText = expandTabs(oldLine.Content)
+	}
+	if newLine != nil {
+		newText = expandTabs(newLine.Content)
+	}
+
+	left := d.renderSide(oldLine, oldText, newText, newLine != nil, colWidth)
+	right := d.renderSide(newLine, newText, oldText, oldLine != nil, colWidth)
+	return left + d.styles.Dimmed.Render(" │ ") + right
+}
+
+// renderSide renders one side of a pair, padded to width. other is the
+// line on the opposite side, used to find the changed span when hasOther.
+func (d *DiffViewer) renderSide(line *DiffLine, text, other string, hasOther bool, width int) string {
+	if line == nil {
+		return strings.Repeat(" ", width)
+	}
+
+	prefix, lineNum, style, highlight := " ", line.NewLine, d.styles.ContextLine, d.styles.ContextLine
+	switch line.Type {
+	case LineAdd:
+		prefix, style, highlight = "+", d.styles.AddLine, d.styles.AddHighlight
+	case LineDelete:
+		prefix, lineNum, style, highlight = "-", line.OldLine, d.styles.DeleteLine, d.styles.DeleteHighlight
+	}
+
+	// Only a changed pair has a span to mark; a lone line is all change
+	start, end := 0, 0
+	if line.Type != LineContext && hasOther {
+		start, end = changedSpan(text, other)
+	}
+
+	// Line number, space, prefix and space take 8 columns
+	content := renderSpan(truncateRight(text, width-8), start, end, style, highlight)
+	rendered := d.styles.LineNumber.Render(fmt.Sprintf("%5d", lineNum)) + " " + style.Render(prefix+" ") + content
+	if pad := width - lipgloss.Width(rendered); pad > 0 {
+		rendered += strings.Repeat(" ", pad)
+	}
+	return rendered
+}
+
+// renderSpan renders text in style with runes [start, end) in highlight
+func renderSpan(text string, start, end int, style, highlight lipgloss.Style) string {
+	runes := []rune(text)
+	start, end = min(start, len(runes)), min(end, len(runes))
+	if start >= end {
+		return style.Render(text)
+	}
+	return style.Render(string(runes[:start])) +
+		highlight.Render(string(runes[start:end])) +
+		style.Render(string(runes[end:]))
+}
+
+// expandTabs replaces tabs with spaces so that columns line up
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
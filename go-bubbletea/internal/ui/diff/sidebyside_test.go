@@ -0,0 +1,176 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPairLines(t *testing.T) {
+	files := ParseUnifiedDiff(`diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,6 +1,6 @@
+ package main
+-var a = 1
+-var b = 2
++var a = 10
+ func main() {
+-	old()
++	first()
++	second()
+ }
+`)
+	hunk := files[0].Hunks[0]
+
+	want := []linePair{
+		{old: 0, new: 0},  // package main
+		{old: 1, new: 3},  // var a = 1 | var a = 10
+		{old: 2, new: -1}, // var b = 2 |
+		{old: 4, new: 4},  // func main() {
+		{old: 5, new: 6},  // old() | first()
+		{old: -1, new: 7}, //       | second()
+		{old: 8, new: 8},  // }
+	}
+	got := pairLines(hunk)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Old and new line numbers stay aligned across the change
+	for _, pair := range got {
+		if pair.old >= 0 && hunk.Lines[pair.old].Type == LineAdd {
+			t.Errorf("Added line %d on the old side", pair.old)
+		}
+		if pair.new >= 0 && hunk.Lines[pair.new].Type == LineDelete {
+			t.Errorf("Deleted line %d on the new side", pair.new)
+		}
+	}
+}
+
+func TestPairLines_AddAfterDeleteRunsSplit(t *testing.T) {
+	hunk := DiffHunk{Lines: []DiffLine{
+		{Type: LineAdd, Content: "a"},
+		{Type: LineDelete, Content: "b"},
+		{Type: LineAdd, Content: "c"},
+	}}
+
+	want := []linePair{{old: -1, new: 0}, {old: 1, new: 2}}
+	got := pairLines(hunk)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChangedSpan(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		start, end int
+	}{
+		{"var a = 1", "var a = 10", 9, 9},
+		{"var a = 10", "var a = 1", 9, 10},
+		{"old()", "first()", 0, 3},
+		{"same", "same", 4, 4},
+		{"", "new", 0, 0},
+	}
+
+	for _, tt := range tests {
+		start, end := changedSpan(tt.a, tt.b)
+		if start != tt.start || end != tt.end {
+			t.Errorf("changedSpan(%q, %q) = %d, %d; want %d, %d", tt.a, tt.b, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestDiffViewer_SideBySideToggle(t *testing.T) {
+	viewer := loadedViewer(t, twoFileDiff)
+	viewer.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !viewer.showSideBySide() {
+		t.Fatal("Expected side-by-side after s on a wide terminal")
+	}
+
+	var kinds []rowKind
+	for _, row := range viewer.layout() {
+		kinds = append(kinds, row.kind)
+	}
+	want := []rowKind{
+		rowFileSeparator, rowHunkHeader, rowPair, rowPair, rowPair,
+		rowFileSeparator, rowHunkHeader, rowPair, rowPair, rowPair,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(kinds), kinds)
+	}
+
+	view := viewer.View()
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "var a = 1") {
+			if !strings.Contains(line, "var a = 2") || !strings.Contains(line, "│") {
+				t.Errorf("Expected old and new lines side by side, got %q", line)
+			}
+		}
+	}
+
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if viewer.showSideBySide() {
+		t.Error("Expected unified after toggling s again")
+	}
+}
+
+func TestDiffViewer_SideBySideFallsBackWhenNarrow(t *testing.T) {
+	viewer := loadedViewer(t, twoFileDiff)
+	viewer.viewHeight = 3
+	viewer.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	// Shrinking the terminal reflows to unified, staying on the same file
+	viewer.Update(tea.WindowSizeMsg{Width: 70, Height: 40})
+	if viewer.showSideBySide() {
+		t.Fatal("Expected unified on a narrow terminal")
+	}
+	if kind := viewer.layout()[2].kind; kind != rowContext {
+		t.Errorf("Expected unified rows, got kind %v", kind)
+	}
+	if viewer.scrollY != viewer.fileStarts[1] {
+		t.Errorf("Expected scrollY %d at util.go, got %d", viewer.fileStarts[1], viewer.scrollY)
+	}
+	if !strings.Contains(viewer.View(), "split (too narrow)") {
+		t.Error("Expected the footer to say side-by-side doesn't fit")
+	}
+
+	// Growing it again restores side-by-side
+	viewer.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	if !viewer.showSideBySide() {
+		t.Error("Expected side-by-side once the terminal is wide again")
+	}
+}
+
+func TestDiffViewer_SideBySideRowsFitPane(t *testing.T) {
+	viewer := loadedViewer(t, twoFileDiff)
+	viewer.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	width := viewer.paneWidth()
+	for _, row := range viewer.layout() {
+		if row.kind != rowPair {
+			continue
+		}
+		if got := lipgloss.Width(viewer.renderRow(row, width)); got > width {
+			t.Errorf("Row %+v is %d columns, pane is %d", row, got, width)
+		}
+	}
+}
@@ -12,6 +12,7 @@ type Styles struct {
 	Title   lipgloss.Style
 
 	// File list styles
+	Sidebar            lipgloss.Style
 	FileHeader         lipgloss.Style
 	FileHeaderSelected lipgloss.Style
 	FileHeaderExpanded lipgloss.Style
@@ -34,6 +35,12 @@ type Styles struct {
 	HunkHeader  lipgloss.Style
 	LineNumber  lipgloss.Style
 
+	// Changed part of a line, in the side-by-side view
+	AddHighlight    lipgloss.Style
+	DeleteHighlight lipgloss.Style
+
+	FileSeparator lipgloss.Style
+
 	// Navigation hints
 	Footer   lipgloss.Style
 	KeyHint  lipgloss.Style
@@ -54,6 +61,11 @@ func New() *Styles {
 			Bold(true).
 			MarginBottom(1),
 
+		Sidebar: lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderRight(true).
+			BorderForeground(styles.Surface2),
+
 		FileHeader: lipgloss.NewStyle().
 			Foreground(styles.Text).
 			Bold(true),
@@ -105,6 +117,16 @@ func New() *Styles {
 		DeleteLine: lipgloss.NewStyle().
 			Foreground(styles.Red),
 
+		AddHighlight: lipgloss.NewStyle().
+			Foreground(styles.Green).
+			Background(styles.Surface1).
+			Bold(true),
+
+		DeleteHighlight: lipgloss.NewStyle().
+			Foreground(styles.Red).
+			Background(styles.Surface1).
+			Bold(true),
+
 		ContextLine: lipgloss.NewStyle().
 			Foreground(styles.Subtext0),
 
@@ -112,6 +134,10 @@ func New() *Styles {
 			Foreground(styles.Blue).
 			Bold(true),
 
+		FileSeparator: lipgloss.NewStyle().
+			Foreground(styles.Mauve).
+			Bold(true),
+
 		LineNumber: lipgloss.NewStyle().
 			Foreground(styles.Overlay1).
 			Width(5).
@@ -3,6 +3,7 @@ package diff
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,7 +12,11 @@ import (
 	"github.com/riordanpawley/azedarach/internal/ui/overlay"
 )
 
-// DiffViewer displays git diff output with file navigation and syntax highlighting
+// DiffViewer displays git diff output as a file list sidebar next to the
+// diff itself. Moving between files in the sidebar scrolls the diff to that
+// file, and only the rows in view are rendered so large diffs stay fast.
+// The diff is unified by default, or side by side when toggled on and the
+// terminal is wide enough.
 type DiffViewer struct {
 	worktree   string
 	diffOutput string
@@ -20,13 +25,48 @@ type DiffViewer struct {
 	scrollY    int
 	expanded   map[int]bool // Which files are expanded to show hunks
 	styles     *Styles
-	width      int
+	width      int // Terminal size, 0 until a tea.WindowSizeMsg arrives
 	height     int
-	viewHeight int // Available height for content display
+	viewHeight int  // Available height for content display
+	sideBySide bool // Side-by-side requested; see showSideBySide
 	loading    bool
 	err        error
+
+	// Layout of the diff pane, rebuilt when files, expansion or mode change
+	rows        []diffRow
+	fileStarts  []int // Index into rows of each file's separator
+	layoutDirty bool
+	layoutSplit bool // Whether rows were built side by side
+}
+
+// rowKind classifies a row of the diff pane, which decides its style
+type rowKind int
+
+const (
+	rowFileSeparator rowKind = iota
+	rowHunkHeader
+	rowAdd
+	rowDelete
+	rowContext
+	rowPair // Side by side: an old line next to a new line
+)
+
+// diffRow is one line of the diff pane. Rows point into the parsed diff
+// rather than holding rendered text, so building them is cheap.
+type diffRow struct {
+	kind rowKind
+	file int
+	hunk int
+	line int
+	pair linePair // For rowPair
 }
 
+// sidebarWidth is the width of the file list, including its border
+const sidebarWidth = 28
+
+// defaultOverlayWidth is the viewer's width before the terminal size is known
+const defaultOverlayWidth = 100
+
 // NewDiffViewer creates a new diff viewer for the specified worktree
 func NewDiffViewer(worktree string) *DiffViewer {
 	return &DiffViewer{
@@ -36,8 +76,6 @@ func NewDiffViewer(worktree string) *DiffViewer {
 		scrollY:    0,
 		expanded:   make(map[int]bool),
 		styles:     New(),
-		width:      80,
-		height:     30,
 		viewHeight: 20,
 		loading:    false,
 	}
@@ -75,6 +113,18 @@ func (d *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		d.diffOutput = msg.Output
 		d.files = ParseUnifiedDiff(msg.Output)
+		// Show every file's changes; the sidebar is for getting around
+		for i := range d.files {
+			d.expanded[i] = true
+		}
+		d.layoutDirty = true
+		return d, nil
+
+	case tea.WindowSizeMsg:
+		// The pane reflows on the next layout, falling back to unified if
+		// side by side no longer fits
+		d.width = msg.Width
+		d.height = msg.Height
 		return d, nil
 
 	case tea.KeyMsg:
@@ -114,10 +164,30 @@ func (d *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return d, nil
 
+		case "J", "ctrl+e":
+			// Scroll the diff by a line
+			d.scrollBy(1)
+			return d, nil
+
+		case "K", "ctrl+y":
+			d.scrollBy(-1)
+			return d, nil
+
+		case "ctrl+d":
+			// Scroll the diff by half a page
+			d.scrollBy(d.viewHeight / 2)
+			return d, nil
+
+		case "ctrl+u":
+			d.scrollBy(-d.viewHeight / 2)
+			return d, nil
+
 		case "enter", " ":
 			// Toggle file expansion
 			if d.cursor >= 0 && d.cursor < len(d.files) {
 				d.expanded[d.cursor] = !d.expanded[d.cursor]
+				d.layoutDirty = true
+				d.ensureCursorVisible()
 			}
 			return d, nil
 
@@ -126,11 +196,21 @@ func (d *DiffViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			for i := range d.files {
 				d.expanded[i] = true
 			}
+			d.layoutDirty = true
+			d.ensureCursorVisible()
 			return d, nil
 
 		case "C":
 			// Collapse all
 			d.expanded = make(map[int]bool)
+			d.layoutDirty = true
+			d.ensureCursorVisible()
+			return d, nil
+
+		case "s":
+			// Toggle side-by-side
+			d.sideBySide = !d.sideBySide
+			d.layout()
 			return d, nil
 		}
 	}
@@ -154,20 +234,11 @@ func (d *DiffViewer) View() string {
 
 	var content strings.Builder
 
-	// Render files with proper scrolling
-	visibleLines := d.renderFiles()
-	lines := strings.Split(visibleLines, "\n")
-
-	// Apply scroll window
-	start := d.scrollY
-	end := min(d.scrollY+d.viewHeight, len(lines))
-
-	for i := start; i < end; i++ {
-		if i < len(lines) {
-			content.WriteString(lines[i])
-			content.WriteString("\n")
-		}
-	}
+	content.WriteString(lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		d.renderSidebar(),
+		d.renderDiffPane(),
+	))
 
 	// Add footer with navigation hints
 	footer := d.renderFooter()
@@ -187,186 +258,258 @@ func (d *DiffViewer) Title() string {
 
 // Size returns the overlay dimensions
 func (d *DiffViewer) Size() (width, height int) {
-	d.viewHeight = 20 // Content viewing area
-	return 100, 30    // Total overlay size
+	d.viewHeight = 20           // Content viewing area
+	return d.overlayWidth(), 30 // Total overlay size
+}
+
+// overlayWidth fills the terminal's width once it is known, leaving a
+// margin around the overlay
+func (d *DiffViewer) overlayWidth() int {
+	if d.width > 0 {
+		return d.width - 4
+	}
+	return defaultOverlayWidth
 }
 
-// renderFiles renders all files with their diffs
-func (d *DiffViewer) renderFiles() string {
-	var b strings.Builder
+// showSideBySide reports whether the diff is shown side by side: it was
+// toggled on and the pane is wide enough
+func (d *DiffViewer) showSideBySide() bool {
+	return d.sideBySide && d.canSideBySide()
+}
+
+// layout returns the rows of the diff pane, rebuilding them if the files,
+// their expansion or the view mode changed
+func (d *DiffViewer) layout() []diffRow {
+	split := d.showSideBySide()
+	modeChanged := split != d.layoutSplit
+	if !d.layoutDirty && !modeChanged && d.fileStarts != nil && len(d.fileStarts) == len(d.files) {
+		return d.rows
+	}
 
+	d.rows = d.rows[:0]
+	d.fileStarts = make([]int, len(d.files))
 	for i, file := range d.files {
-		isSelected := i == d.cursor
-		isExpanded := d.expanded[i]
-
-		// File header with status badge
-		var headerStyle lipgloss.Style
-		if isSelected {
-			if isExpanded {
-				headerStyle = d.styles.FileHeaderExpanded
-			} else {
-				headerStyle = d.styles.FileHeaderSelected
+		d.fileStarts[i] = len(d.rows)
+		d.rows = append(d.rows, diffRow{kind: rowFileSeparator, file: i})
+		if !d.expanded[i] {
+			continue
+		}
+		for h, hunk := range file.Hunks {
+			d.rows = append(d.rows, diffRow{kind: rowHunkHeader, file: i, hunk: h})
+			if split {
+				for _, pair := range pairLines(hunk) {
+					d.rows = append(d.rows, diffRow{kind: rowPair, file: i, hunk: h, pair: pair})
+				}
+				continue
+			}
+			for l, line := range hunk.Lines {
+				d.rows = append(d.rows, diffRow{kind: lineRowKind(line.Type), file: i, hunk: h, line: l})
 			}
-		} else {
-			headerStyle = d.styles.FileHeader
 		}
+	}
+	d.layoutDirty = false
+	d.layoutSplit = split
+
+	// Row indexes differ between modes; stay on the selected file
+	if modeChanged && d.cursor < len(d.fileStarts) {
+		d.scrollY = d.fileStarts[d.cursor]
+		d.clampScroll()
+	}
+	return d.rows
+}
+
+// lineRowKind maps a diff line type to its row kind
+func lineRowKind(t LineType) rowKind {
+	switch t {
+	case LineAdd:
+		return rowAdd
+	case LineDelete:
+		return rowDelete
+	default:
+		return rowContext
+	}
+}
+
+// rowStyle returns the style for a kind of row
+func (d *DiffViewer) rowStyle(kind rowKind) lipgloss.Style {
+	switch kind {
+	case rowFileSeparator:
+		return d.styles.FileSeparator
+	case rowHunkHeader:
+		return d.styles.HunkHeader
+	case rowAdd:
+		return d.styles.AddLine
+	case rowDelete:
+		return d.styles.DeleteLine
+	default:
+		return d.styles.ContextLine
+	}
+}
+
+// renderSidebar renders the file list, keeping the cursor in view
+func (d *DiffViewer) renderSidebar() string {
+	start := 0
+	if d.cursor >= d.viewHeight {
+		start = d.cursor - d.viewHeight + 1
+	}
+	end := min(start+d.viewHeight, len(d.files))
+
+	lines := make([]string, 0, d.viewHeight)
+	for i := start; i < end; i++ {
+		file := d.files[i]
 
-		// Render file header line
 		cursor := " "
-		if isSelected {
+		pathStyle := d.styles.FilePath
+		if i == d.cursor {
 			cursor = "▶"
+			pathStyle = d.styles.FilePathSelected
 		}
 
-		expandMarker := "►"
-		if isExpanded {
-			expandMarker = "▼"
-		}
-
-		badge := d.styles.FileStatusBadge(file.Status)
-		path := file.Path
-		if file.Status == FileRenamed && file.OldPath != file.Path {
-			path = fmt.Sprintf("%s → %s", file.OldPath, file.Path)
-		}
+		stats := d.styles.FileStatsAdd.Render(fmt.Sprintf("+%d", file.Additions)) +
+			d.styles.FileStatsDel.Render(fmt.Sprintf("-%d", file.Deletions))
+		// cursor, badge and three spaces around the path and stats
+		pathWidth := sidebarWidth - 6 - lipgloss.Width(stats)
+		lines = append(lines, fmt.Sprintf("%s %s %s %s",
+			cursor,
+			d.styles.FileStatusBadge(file.Status),
+			pathStyle.Render(truncateLeft(path.Base(file.Path), pathWidth)),
+			stats,
+		))
+	}
+	for len(lines) < d.viewHeight {
+		lines = append(lines, "")
+	}
 
-		statsRendered := lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			d.styles.FileStatsAdd.Render(fmt.Sprintf("+%d", file.Additions)),
-			" ",
-			d.styles.FileStatsDel.Render(fmt.Sprintf("-%d", file.Deletions)),
-		)
+	return d.styles.Sidebar.Width(sidebarWidth - 1).Render(strings.Join(lines, "\n"))
+}
 
-		headerLine := lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			cursor,
-			" ",
-			expandMarker,
-			" ",
-			badge,
-			" ",
-			headerStyle.Render(path),
-			" ",
-			d.styles.FileStats.Render("("),
-			statsRendered,
-			d.styles.FileStats.Render(")"),
-		)
-
-		b.WriteString(headerLine)
-		b.WriteString("\n")
-
-		// Render hunks if expanded
-		if isExpanded {
-			for _, hunk := range file.Hunks {
-				b.WriteString(d.renderHunk(hunk))
-			}
-			b.WriteString("\n")
-		}
+// renderDiffPane renders the rows of the diff in view. Rows outside the
+// scroll window are never rendered.
+func (d *DiffViewer) renderDiffPane() string {
+	rows := d.layout()
+	d.clampScroll()
+
+	width := d.paneWidth()
+	end := min(d.scrollY+d.viewHeight, len(rows))
+	lines := make([]string, 0, d.viewHeight)
+	for _, row := range rows[d.scrollY:end] {
+		lines = append(lines, d.renderRow(row, width))
 	}
 
-	return b.String()
+	return lipgloss.NewStyle().PaddingLeft(1).Render(strings.Join(lines, "\n"))
 }
 
-// renderHunk renders a single diff hunk
-func (d *DiffViewer) renderHunk(hunk DiffHunk) string {
-	var b strings.Builder
+// paneWidth is the width available to a row of the diff pane
+func (d *DiffViewer) paneWidth() int {
+	// Overlay border and padding, the sidebar and the pane's left padding
+	return d.overlayWidth() - 6 - sidebarWidth - 1
+}
 
-	// Hunk header
-	b.WriteString("  ")
-	b.WriteString(d.styles.HunkHeader.Render(hunk.Header))
-	b.WriteString("\n")
+// renderRow renders a single row of the diff pane, cut to width
+func (d *DiffViewer) renderRow(row diffRow, width int) string {
+	style := d.rowStyle(row.kind)
+	file := d.files[row.file]
 
-	// Hunk lines
-	for _, line := range hunk.Lines {
-		b.WriteString(d.renderLine(line))
-		b.WriteString("\n")
-	}
+	switch row.kind {
+	case rowFileSeparator:
+		label := file.Path
+		if file.Status == FileRenamed && file.OldPath != file.Path {
+			label = fmt.Sprintf("%s → %s", file.OldPath, file.Path)
+		}
+		label = fmt.Sprintf("── %s (+%d -%d) ", label, file.Additions, file.Deletions)
+		if row.file == d.cursor {
+			style = d.styles.FileHeaderSelected
+		}
+		if fill := width - lipgloss.Width(label); fill > 0 {
+			label += strings.Repeat("─", fill)
+		}
+		return style.Render(truncateRight(label, width))
 
-	return b.String()
-}
+	case rowHunkHeader:
+		return style.Render(truncateRight(file.Hunks[row.hunk].Header, width))
 
-// renderLine renders a single diff line
-func (d *DiffViewer) renderLine(line DiffLine) string {
-	var prefix, lineNum, content string
-	var style lipgloss.Style
+	case rowPair:
+		return d.renderPairRow(row, width)
+	}
 
-	switch line.Type {
-	case LineAdd:
+	line := file.Hunks[row.hunk].Lines[row.line]
+	prefix, lineNum := " ", line.NewLine
+	switch row.kind {
+	case rowAdd:
 		prefix = "+"
-		lineNum = fmt.Sprintf("%5d", line.NewLine)
-		content = line.Content
-		style = d.styles.AddLine
+	case rowDelete:
+		prefix, lineNum = "-", line.OldLine
+	}
 
-	case LineDelete:
-		prefix = "-"
-		lineNum = fmt.Sprintf("%5d", line.OldLine)
-		content = line.Content
-		style = d.styles.DeleteLine
-
-	case LineContext:
-		prefix = " "
-		lineNum = fmt.Sprintf("%5d", line.NewLine)
-		content = line.Content
-		style = d.styles.ContextLine
-	}
-
-	lineNumRendered := d.styles.LineNumber.Render(lineNum)
-
-	return lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		"    ",
-		lineNumRendered,
-		" ",
-		style.Render(prefix),
-		" ",
-		style.Render(content),
-	)
+	// Line number, space, prefix and space take 8 columns
+	text := truncateRight(expandTabs(line.Content), width-8)
+	return d.styles.LineNumber.Render(fmt.Sprintf("%5d", lineNum)) + " " + style.Render(prefix+" "+text)
 }
 
 // renderFooter renders navigation hints
 func (d *DiffViewer) renderFooter() string {
+	// The footer's top margin applies to the whole line, not each hint
+	text := d.styles.Footer.UnsetMargins()
 	hints := []string{
-		d.styles.KeyHint.Render("j/k") + d.styles.Footer.Render(" navigate"),
-		d.styles.KeyHint.Render("g/G") + d.styles.Footer.Render(" jump top/bottom"),
-		d.styles.KeyHint.Render("Enter") + d.styles.Footer.Render(" expand/collapse"),
-		d.styles.KeyHint.Render("E/C") + d.styles.Footer.Render(" expand/collapse all"),
-		d.styles.KeyHint.Render("q/Esc") + d.styles.Footer.Render(" close"),
+		d.styles.KeyHint.Render("j/k") + text.Render(" file"),
+		d.styles.KeyHint.Render("J/K ^d/^u") + text.Render(" scroll"),
+		d.styles.KeyHint.Render("Enter") + text.Render(" expand/collapse"),
+		d.styles.KeyHint.Render("E/C") + text.Render(" all"),
+		d.styles.KeyHint.Render("s") + text.Render(d.modeHint()),
+		d.styles.KeyHint.Render("q/Esc") + text.Render(" close"),
 	}
 
 	fileInfo := ""
 	if len(d.files) > 0 {
-		fileInfo = d.styles.Footer.Render(fmt.Sprintf("  [File %d/%d]", d.cursor+1, len(d.files)))
+		fileInfo = text.Render(fmt.Sprintf("  [File %d/%d]", d.cursor+1, len(d.files)))
 	}
 
-	return lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		strings.Join(hints, d.styles.Footer.Render(" • ")),
-		fileInfo,
-	)
+	return d.styles.Footer.Render(strings.Join(hints, text.Render(" • ")) + fileInfo)
+}
+
+// modeHint describes what the s key does, or why it does nothing
+func (d *DiffViewer) modeHint() string {
+	switch {
+	case d.showSideBySide():
+		return " unified"
+	case d.sideBySide:
+		return " split (too narrow)"
+	default:
+		return " split"
+	}
 }
 
-// ensureCursorVisible adjusts scrollY to keep cursor in view
+// ensureCursorVisible scrolls the diff pane to the selected file
 func (d *DiffViewer) ensureCursorVisible() {
-	// Calculate the line position of the cursor
-	linePos := 0
-	for i := 0; i < d.cursor && i < len(d.files); i++ {
-		linePos++ // File header line
-		if d.expanded[i] {
-			// Count hunk lines
-			for _, hunk := range d.files[i].Hunks {
-				linePos++ // Hunk header
-				linePos += len(hunk.Lines)
-			}
-			linePos++ // Blank line after expanded file
-		}
+	d.layout()
+	if d.cursor < 0 || d.cursor >= len(d.fileStarts) {
+		return
 	}
+	d.scrollY = d.fileStarts[d.cursor]
+	d.clampScroll()
+}
+
+// scrollBy scrolls the diff pane by n rows, selecting the file at the top
+func (d *DiffViewer) scrollBy(n int) {
+	d.layout()
+	d.scrollY += n
+	d.clampScroll()
 
-	// Adjust scroll to keep cursor visible
-	if linePos < d.scrollY {
-		d.scrollY = linePos
-	} else if linePos >= d.scrollY+d.viewHeight {
-		d.scrollY = linePos - d.viewHeight + 1
+	for i := len(d.fileStarts) - 1; i >= 0; i-- {
+		if d.fileStarts[i] <= d.scrollY {
+			d.cursor = i
+			break
+		}
 	}
 }
 
+// clampScroll keeps scrollY within the rows, leaving no blank space at the
+// bottom when the diff is longer than the pane
+func (d *DiffViewer) clampScroll() {
+	maxScroll := max(len(d.rows)-d.viewHeight, 0)
+	d.scrollY = max(min(d.scrollY, maxScroll), 0)
+}
+
 // Helper functions
 
 func min(a, b int) int {
@@ -382,3 +525,33 @@ func plural(n int) string {
 	}
 	return "s"
 }
+
+// truncateRight cuts s to width columns, marking the cut with an ellipsis
+func truncateRight(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// truncateLeft cuts s to width columns from the left, keeping its end
+func truncateLeft(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[1:]
+	}
+	return "…" + string(runes)
+}
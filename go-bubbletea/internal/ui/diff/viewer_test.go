@@ -1,10 +1,12 @@
 package diff
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/riordanpawley/azedarach/internal/ui/overlay"
 )
 
@@ -418,3 +420,117 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+const twoFileDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-var a = 1
++var a = 2
+ func main() {}
+diff --git a/util.go b/util.go
+--- a/util.go
++++ b/util.go
+@@ -10,2 +10,3 @@ func helper() {
+ 	x := 1
++	y := 2
+ 	return
+`
+
+func loadedViewer(t *testing.T, output string) *DiffViewer {
+	t.Helper()
+	viewer := NewDiffViewer("/test")
+	updatedModel, _ := viewer.Update(LoadDiffMsg{Output: output})
+	return updatedModel.(*DiffViewer)
+}
+
+func TestDiffViewer_RowStyles(t *testing.T) {
+	viewer := loadedViewer(t, twoFileDiff)
+
+	var kinds []rowKind
+	for _, row := range viewer.layout() {
+		kinds = append(kinds, row.kind)
+	}
+	want := []rowKind{
+		rowFileSeparator, rowHunkHeader, rowContext, rowDelete, rowAdd, rowContext,
+		rowFileSeparator, rowHunkHeader, rowContext, rowAdd, rowContext,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(kinds), kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("Row %d kind = %v, want %v", i, kinds[i], want[i])
+		}
+	}
+
+	styleTests := []struct {
+		kind rowKind
+		want lipgloss.Style
+	}{
+		{rowAdd, viewer.styles.AddLine},
+		{rowDelete, viewer.styles.DeleteLine},
+		{rowContext, viewer.styles.ContextLine},
+		{rowHunkHeader, viewer.styles.HunkHeader},
+		{rowFileSeparator, viewer.styles.FileSeparator},
+	}
+	for _, tt := range styleTests {
+		if got := viewer.rowStyle(tt.kind).GetForeground(); got != tt.want.GetForeground() {
+			t.Errorf("rowStyle(%v) foreground = %v, want %v", tt.kind, got, tt.want.GetForeground())
+		}
+	}
+	if viewer.styles.AddLine.GetForeground() == viewer.styles.DeleteLine.GetForeground() {
+		t.Error("Expected added and removed lines to be colored differently")
+	}
+}
+
+func TestDiffViewer_FileNavigationJumpsToFile(t *testing.T) {
+	viewer := loadedViewer(t, twoFileDiff)
+	viewer.viewHeight = 3
+
+	updatedModel, _ := viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	viewer = updatedModel.(*DiffViewer)
+
+	if viewer.cursor != 1 {
+		t.Fatalf("Expected cursor 1, got %d", viewer.cursor)
+	}
+	if viewer.scrollY != viewer.fileStarts[1] {
+		t.Errorf("Expected scrollY %d at util.go, got %d", viewer.fileStarts[1], viewer.scrollY)
+	}
+	if view := viewer.View(); !strings.Contains(view, "@@ -10,2 +10,3 @@") {
+		t.Errorf("Expected util.go's hunk in view:\n%s", view)
+	}
+
+	// Scrolling the diff back up selects the file at the top
+	updatedModel, _ = viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	viewer = updatedModel.(*DiffViewer)
+	if viewer.cursor != 0 {
+		t.Errorf("Expected cursor 0 after scrolling into main.go, got %d", viewer.cursor)
+	}
+}
+
+func TestDiffViewer_RendersVisibleRowsOnly(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("diff --git a/big.go b/big.go\n--- a/big.go\n+++ b/big.go\n@@ -0,0 +1,5000 @@\n")
+	for i := 1; i <= 5000; i++ {
+		fmt.Fprintf(&b, "+line %d\n", i)
+	}
+	viewer := loadedViewer(t, b.String())
+	viewer.Size()
+
+	view := viewer.View()
+
+	if !strings.Contains(view, "line 1") {
+		t.Error("Expected the first lines in view")
+	}
+	if strings.Contains(view, "line 4999") {
+		t.Error("Expected lines past the pane not to be rendered")
+	}
+
+	viewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	viewer.scrollBy(len(viewer.rows))
+	if view := viewer.View(); !strings.Contains(view, "line 5000") {
+		t.Error("Expected the last line after scrolling to the end")
+	}
+}
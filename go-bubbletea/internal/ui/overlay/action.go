@@ -1,6 +1,7 @@
 package overlay
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,25 +17,37 @@ type Action struct {
 
 // ActionMenu is a menu overlay for task actions
 type ActionMenu struct {
-	task    domain.Task
-	session *domain.Session
-	actions []Action
-	cursor  int
-	styles  *Styles
+	task       domain.Task
+	session    *domain.Session
+	baseBranch string // Named by the update and merge actions
+	actions    []Action
+	cursor     int
+	styles     *Styles
 }
 
 // NewActionMenu creates a new action menu for the given task
 func NewActionMenu(task domain.Task, session *domain.Session) *ActionMenu {
 	s := New()
 	menu := &ActionMenu{
-		task:    task,
-		session: session,
-		styles:  s,
+		task:       task,
+		session:    session,
+		baseBranch: "main",
+		styles:     s,
 	}
 	menu.actions = menu.buildActions()
 	return menu
 }
 
+// SetBaseBranch names branch, the project's base branch, in the update and
+// merge actions
+func (m *ActionMenu) SetBaseBranch(branch string) {
+	if branch == "" {
+		return
+	}
+	m.baseBranch = branch
+	m.actions = m.buildActions()
+}
+
 // buildActions creates the action list based on task and session state
 func (m *ActionMenu) buildActions() []Action {
 	actions := []Action{}
@@ -43,9 +56,13 @@ func (m *ActionMenu) buildActions() []Action {
 	if m.session == nil {
 		actions = append(actions, Action{Key: "s", Label: "Start session", Enabled: true})
 		actions = append(actions, Action{Key: "S", Label: "Start session + work", Enabled: true})
+	} else if m.session.State == domain.SessionQueued {
+		// Waiting for a slot, so there's no pane to attach or send to yet
+		actions = append(actions, Action{Key: "x", Label: "Remove from queue", Enabled: true})
 	} else {
 		// Attach action (always available when session exists)
 		actions = append(actions, Action{Key: "a", Label: "Attach to session", Enabled: true})
+		actions = append(actions, Action{Key: "t", Label: "Send text", Enabled: m.session.State != domain.SessionPaused})
 
 		// State-specific actions
 		switch m.session.State {
@@ -57,7 +74,10 @@ func (m *ActionMenu) buildActions() []Action {
 		case domain.SessionPaused:
 			actions = append(actions, Action{Key: "R", Label: "Resume session", Enabled: true})
 			actions = append(actions, Action{Key: "x", Label: "Stop session", Enabled: true})
-		case domain.SessionDone, domain.SessionError:
+		case domain.SessionError:
+			actions = append(actions, Action{Key: "T", Label: "Retry session", Enabled: true})
+			actions = append(actions, Action{Key: "x", Label: "Stop session", Enabled: true})
+		case domain.SessionDone:
 			actions = append(actions, Action{Key: "x", Label: "Stop session", Enabled: true})
 		}
 	}
@@ -69,11 +89,20 @@ func (m *ActionMenu) buildActions() []Action {
 
 	// Git actions (enabled when session exists and has worktree)
 	hasWorktree := m.session != nil && m.session.Worktree != ""
+	prAction := Action{Key: "P", Label: "Create PR", Enabled: hasWorktree}
+	if m.task.PRURL != "" {
+		prAction = Action{Key: "P", Label: "Open PR", Enabled: true}
+		if m.task.PRNumber > 0 {
+			prAction.Label = fmt.Sprintf("Open PR #%d", m.task.PRNumber)
+		}
+	}
 	actions = append(actions,
-		Action{Key: "u", Label: "Update from main", Enabled: hasWorktree},
-		Action{Key: "m", Label: "Merge to main", Enabled: hasWorktree},
-		Action{Key: "P", Label: "Create PR", Enabled: hasWorktree},
+		Action{Key: "u", Label: "Update from " + m.baseBranch, Enabled: hasWorktree},
+		Action{Key: "m", Label: "Merge to " + m.baseBranch, Enabled: hasWorktree},
+		prAction,
+		Action{Key: "y", Label: "Copy PR command", Enabled: hasWorktree},
 		Action{Key: "f", Label: "Show diff", Enabled: hasWorktree},
+		Action{Key: "F", Label: "Diff summary", Enabled: hasWorktree},
 	)
 
 	// Task actions separator
@@ -87,6 +116,14 @@ func (m *ActionMenu) buildActions() []Action {
 		Action{Key: "d", Label: "Delete task", Enabled: true},
 	)
 
+	// Reopen actions for finished tasks that need rework
+	if m.task.Status == domain.StatusDone {
+		actions = append(actions,
+			Action{Key: "o", Label: "Reopen task", Enabled: true},
+			Action{Key: "O", Label: "Reopen + start session", Enabled: true},
+		)
+	}
+
 	return actions
 }
 
@@ -262,6 +299,8 @@ func (m *BulkActionMenu) buildActions() []Action {
 		{Key: "D", Label: "Set to Done", Enabled: true},
 		{Key: "", Label: "───────────────────", Enabled: false},
 		// Other actions
+		{Key: "s", Label: "Start sessions", Enabled: true},
+		{Key: "c", Label: "Close selected", Enabled: true},
 		{Key: "d", Label: "Delete selected", Enabled: true},
 		{Key: "x", Label: "Clear selection", Enabled: true},
 	}
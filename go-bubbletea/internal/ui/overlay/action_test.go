@@ -71,7 +71,7 @@ func TestActionMenu_BuildActions_NoSession(t *testing.T) {
 
 	// Git actions should be disabled
 	for _, action := range menu.actions {
-		if action.Key == "u" || action.Key == "m" || action.Key == "P" {
+		if action.Key == "u" || action.Key == "m" || action.Key == "P" || action.Key == "y" {
 			if action.Enabled {
 				t.Errorf("expected git action '%s' to be disabled without session", action.Key)
 			}
@@ -115,7 +115,7 @@ func TestActionMenu_BuildActions_ActiveSession(t *testing.T) {
 
 	// Git actions should be enabled with worktree
 	for _, action := range menu.actions {
-		if action.Key == "u" || action.Key == "m" || action.Key == "P" || action.Key == "f" {
+		if action.Key == "u" || action.Key == "m" || action.Key == "P" || action.Key == "y" || action.Key == "f" || action.Key == "F" {
 			if !action.Enabled {
 				t.Errorf("expected git action '%s' to be enabled with worktree", action.Key)
 			}
@@ -123,6 +123,48 @@ func TestActionMenu_BuildActions_ActiveSession(t *testing.T) {
 	}
 }
 
+func TestActionMenu_SetBaseBranch(t *testing.T) {
+	task := domain.Task{ID: "az-123", Status: domain.StatusInProgress}
+	session := &domain.Session{BeadID: "az-123", State: domain.SessionBusy, Worktree: "/path/to/worktree"}
+
+	menu := NewActionMenu(task, session)
+	menu.SetBaseBranch("develop")
+
+	labels := make(map[string]string)
+	for _, action := range menu.actions {
+		labels[action.Key] = action.Label
+	}
+	if labels["u"] != "Update from develop" {
+		t.Errorf("expected the update action to name develop, got %q", labels["u"])
+	}
+	if labels["m"] != "Merge to develop" {
+		t.Errorf("expected the merge action to name develop, got %q", labels["m"])
+	}
+}
+
+func TestActionMenu_BuildActions_ExistingPR(t *testing.T) {
+	task := domain.Task{
+		ID:       "az-123",
+		Status:   domain.StatusInProgress,
+		PRURL:    "https://github.com/org/repo/pull/7",
+		PRNumber: 7,
+	}
+
+	// Opening a recorded PR doesn't need a session
+	menu := NewActionMenu(task, nil)
+
+	for _, action := range menu.actions {
+		if action.Key != "P" {
+			continue
+		}
+		if action.Label != "Open PR #7" || !action.Enabled {
+			t.Errorf("expected enabled 'Open PR #7' action, got %q (enabled %v)", action.Label, action.Enabled)
+		}
+		return
+	}
+	t.Error("expected a P action")
+}
+
 func TestActionMenu_BuildActions_PausedSession(t *testing.T) {
 	task := domain.Task{
 		ID:     "az-123",
@@ -150,6 +192,29 @@ func TestActionMenu_BuildActions_PausedSession(t *testing.T) {
 	}
 }
 
+func TestActionMenu_BuildActions_QueuedSession(t *testing.T) {
+	task := domain.Task{
+		ID:     "az-123",
+		Status: domain.StatusOpen,
+	}
+	session := &domain.Session{BeadID: "az-123", State: domain.SessionQueued}
+
+	menu := NewActionMenu(task, session)
+
+	hasRemove := false
+	for _, action := range menu.actions {
+		switch action.Key {
+		case "a", "t":
+			t.Errorf("expected no %q action for a queued session", action.Key)
+		case "x":
+			hasRemove = action.Enabled
+		}
+	}
+	if !hasRemove {
+		t.Error("expected 'Remove from queue' action for queued session")
+	}
+}
+
 func TestActionMenu_MoveActions(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -353,3 +418,44 @@ func TestActionMenu_SelectByKey_Disabled(t *testing.T) {
 		t.Error("expected nil command when selecting disabled action")
 	}
 }
+
+func TestActionMenu_BuildActions_ErrorSessionRetry(t *testing.T) {
+	task := domain.Task{ID: "az-123", Status: domain.StatusInProgress}
+
+	hasRetry := func(state domain.SessionState) bool {
+		session := &domain.Session{BeadID: "az-123", State: state, Worktree: "/tmp/wt"}
+		for _, action := range NewActionMenu(task, session).actions {
+			if action.Key == "T" && action.Enabled {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasRetry(domain.SessionError) {
+		t.Error("expected 'Retry session' action for errored session")
+	}
+	if hasRetry(domain.SessionBusy) {
+		t.Error("expected no 'Retry session' action for busy session")
+	}
+}
+
+func TestActionMenu_BuildActions_ReopenDoneTask(t *testing.T) {
+	hasReopen := func(status domain.Status) bool {
+		task := domain.Task{ID: "az-123", Status: status}
+		found := 0
+		for _, action := range NewActionMenu(task, nil).actions {
+			if (action.Key == "o" || action.Key == "O") && action.Enabled {
+				found++
+			}
+		}
+		return found == 2
+	}
+
+	if !hasReopen(domain.StatusDone) {
+		t.Error("expected reopen actions for done task")
+	}
+	if hasReopen(domain.StatusInProgress) {
+		t.Error("expected no reopen actions for in-progress task")
+	}
+}
@@ -0,0 +1,202 @@
+package overlay
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+// archiveRows is how many tasks the archive shows at once
+const archiveRows = 15
+
+// ArchiveRange limits the archive to tasks completed within a window
+type ArchiveRange struct {
+	Label  string
+	Within time.Duration // 0 is no limit
+}
+
+// ArchiveRanges are the completion date filters, cycled with tab
+var ArchiveRanges = []ArchiveRange{
+	{Label: "All", Within: 0},
+	{Label: "Today", Within: 24 * time.Hour},
+	{Label: "7 days", Within: 7 * 24 * time.Hour},
+	{Label: "30 days", Within: 30 * 24 * time.Hour},
+}
+
+// ArchiveOverlay lists completed tasks, most recently completed first,
+// filtered by completion date
+type ArchiveOverlay struct {
+	tasks    []domain.Task
+	rangeIdx int
+	cursor   int
+	offset   int
+	now      func() time.Time
+	styles   *Styles
+}
+
+// NewArchiveOverlay creates an archive of the done tasks among tasks
+func NewArchiveOverlay(tasks []domain.Task) *ArchiveOverlay {
+	var done []domain.Task
+	for _, task := range tasks {
+		if task.Status == domain.StatusDone {
+			done = append(done, task)
+		}
+	}
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].CompletedAt().After(done[j].CompletedAt())
+	})
+
+	return &ArchiveOverlay{
+		tasks:  done,
+		now:    time.Now,
+		styles: New(),
+	}
+}
+
+// Init initializes the archive
+func (a *ArchiveOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (a *ArchiveOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return a, nil
+	}
+
+	switch keyMsg.String() {
+	case "q", "esc":
+		return a, func() tea.Msg { return CloseOverlayMsg{} }
+
+	case "tab":
+		a.rangeIdx = (a.rangeIdx + 1) % len(ArchiveRanges)
+		a.cursor, a.offset = 0, 0
+
+	case "shift+tab":
+		a.rangeIdx = (a.rangeIdx + len(ArchiveRanges) - 1) % len(ArchiveRanges)
+		a.cursor, a.offset = 0, 0
+
+	case "j", "down":
+		if a.cursor < len(a.Visible())-1 {
+			a.cursor++
+		}
+
+	case "k", "up":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+
+	case "enter":
+		visible := a.Visible()
+		if a.cursor < len(visible) {
+			task := visible[a.cursor]
+			return a, func() tea.Msg {
+				return SelectionMsg{Key: "archive_detail", Value: task}
+			}
+		}
+	}
+
+	// Keep the cursor in view
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	} else if a.cursor >= a.offset+archiveRows {
+		a.offset = a.cursor - archiveRows + 1
+	}
+	return a, nil
+}
+
+// Visible returns the tasks completed within the selected range
+func (a *ArchiveOverlay) Visible() []domain.Task {
+	within := ArchiveRanges[a.rangeIdx].Within
+	if within == 0 {
+		return a.tasks
+	}
+
+	cutoff := a.now().Add(-within)
+	var visible []domain.Task
+	for _, task := range a.tasks {
+		if task.CompletedAt().After(cutoff) {
+			visible = append(visible, task)
+		}
+	}
+	return visible
+}
+
+// View renders the archive
+func (a *ArchiveOverlay) View() string {
+	var b strings.Builder
+
+	// Range tabs
+	tabs := make([]string, len(ArchiveRanges))
+	for i, r := range ArchiveRanges {
+		if i == a.rangeIdx {
+			tabs[i] = a.styles.MenuItemActive.Render("[" + r.Label + "]")
+		} else {
+			tabs[i] = a.styles.MenuItem.Render(" " + r.Label + " ")
+		}
+	}
+	b.WriteString(strings.Join(tabs, " "))
+	b.WriteString("\n\n")
+
+	visible := a.Visible()
+	if len(visible) == 0 {
+		b.WriteString(a.styles.MenuItem.Foreground(styles.Overlay0).Render("No completed tasks in this range"))
+		b.WriteString("\n")
+	}
+	end := min(a.offset+archiveRows, len(visible))
+	for i := a.offset; i < end; i++ {
+		b.WriteString(a.renderTask(visible[i], i == a.cursor))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	footer := fmt.Sprintf("%d completed • Tab: range • Enter: details • j/k: navigate • q/Esc: close", len(visible))
+	b.WriteString(a.styles.Footer.Render(footer))
+
+	return b.String()
+}
+
+// renderTask renders one line of the archive: completion date, ID and title
+func (a *ArchiveOverlay) renderTask(task domain.Task, active bool) string {
+	dateStyle := lipgloss.NewStyle().Foreground(styles.Overlay1)
+	idStyle := lipgloss.NewStyle().Foreground(styles.Overlay1).Bold(true)
+	titleStyle := a.styles.MenuItem
+	if active {
+		titleStyle = a.styles.MenuItemActive
+	}
+
+	completed := task.CompletedAt()
+	date := "          "
+	if !completed.IsZero() {
+		date = completed.Local().Format("2006-01-02")
+	}
+
+	title := task.Title
+	if maxTitle := 50; len([]rune(title)) > maxTitle {
+		title = string([]rune(title)[:maxTitle-1]) + "…"
+	}
+
+	return dateStyle.Render(date) + " " + idStyle.Render(task.ID) + " " + titleStyle.Render(title)
+}
+
+// Title returns the overlay title
+func (a *ArchiveOverlay) Title() string {
+	return "Archive"
+}
+
+// Size returns the overlay dimensions
+func (a *ArchiveOverlay) Size() (width, height int) {
+	rows := min(len(a.Visible()), archiveRows)
+	if rows == 0 {
+		rows = 1
+	}
+	// Tabs, blank, rows, blank, footer, padding
+	return 80, rows + 6
+}
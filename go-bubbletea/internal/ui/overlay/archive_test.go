@@ -0,0 +1,91 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func archiveTasks(now time.Time) []domain.Task {
+	closed := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	return []domain.Task{
+		{ID: "az-1", Title: "Open task", Status: domain.StatusOpen},
+		{ID: "az-2", Title: "Last month", Status: domain.StatusDone, ClosedAt: closed(20 * 24 * time.Hour)},
+		{ID: "az-3", Title: "This morning", Status: domain.StatusDone, ClosedAt: closed(2 * time.Hour)},
+		{ID: "az-4", Title: "Last week", Status: domain.StatusDone, UpdatedAt: now.Add(-3 * 24 * time.Hour)},
+	}
+}
+
+func archiveIDs(tasks []domain.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestArchiveOverlay_RangeFilter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	archive := NewArchiveOverlay(archiveTasks(now))
+	archive.now = func() time.Time { return now }
+
+	tests := []struct {
+		label string
+		want  []string
+	}{
+		{"All", []string{"az-3", "az-4", "az-2"}},
+		{"Today", []string{"az-3"}},
+		{"7 days", []string{"az-3", "az-4"}},
+		{"30 days", []string{"az-3", "az-4", "az-2"}},
+	}
+
+	for _, tt := range tests {
+		if got := ArchiveRanges[archive.rangeIdx].Label; got != tt.label {
+			t.Fatalf("Expected range %q, got %q", tt.label, got)
+		}
+		if got := archiveIDs(archive.Visible()); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: expected %v, got %v", tt.label, tt.want, got)
+		}
+		archive.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+
+	if archive.rangeIdx != 0 {
+		t.Errorf("Expected tab to wrap back to All, got range %d", archive.rangeIdx)
+	}
+}
+
+func TestArchiveOverlay_EnterSelectsTask(t *testing.T) {
+	now := time.Now()
+	archive := NewArchiveOverlay(archiveTasks(now))
+
+	archive.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd := archive.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command from enter")
+	}
+
+	msg, ok := cmd().(SelectionMsg)
+	if !ok || msg.Key != "archive_detail" {
+		t.Fatalf("Expected archive_detail selection, got %#v", cmd())
+	}
+	if task, ok := msg.Value.(domain.Task); !ok || task.ID != "az-4" {
+		t.Errorf("Expected az-4 selected, got %#v", msg.Value)
+	}
+}
+
+func TestArchiveOverlay_EmptyRange(t *testing.T) {
+	archive := NewArchiveOverlay(nil)
+
+	if !strings.Contains(archive.View(), "No completed tasks") {
+		t.Error("Expected an empty archive to say so")
+	}
+	if _, cmd := archive.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Expected enter to do nothing in an empty archive")
+	}
+}
@@ -0,0 +1,85 @@
+package overlay
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// beadsInstallCommand installs the beads CLI, as documented by beads
+const beadsInstallCommand = "curl -sSL https://raw.githubusercontent.com/steveyegge/beads/main/scripts/install.sh | bash"
+
+// BeadsMissingOverlay explains that the beads CLI isn't installed. It stays
+// up until the user retries or dismisses it.
+type BeadsMissingOverlay struct {
+	detail string // The error from running bd
+	styles *Styles
+}
+
+// NewBeadsMissingOverlay creates the prompt for a missing bd binary
+func NewBeadsMissingOverlay(err error) *BeadsMissingOverlay {
+	var detail string
+	if err != nil {
+		detail = err.Error()
+	}
+	return &BeadsMissingOverlay{
+		detail: detail,
+		styles: New(),
+	}
+}
+
+// Init initializes the prompt
+func (b *BeadsMissingOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (b *BeadsMissingOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return b, nil
+	}
+
+	switch keyMsg.String() {
+	case "r", "enter":
+		return b, func() tea.Msg { return SelectionMsg{Key: "beads_retry"} }
+	case "esc", "q":
+		return b, func() tea.Msg { return SelectionMsg{Key: "beads_dismiss"} }
+	}
+	return b, nil
+}
+
+// View renders the prompt
+func (b *BeadsMissingOverlay) View() string {
+	var s strings.Builder
+
+	s.WriteString(b.styles.MenuItem.Render("Azedarach keeps its tasks in beads, but the bd command isn't on your PATH."))
+	s.WriteString("\n\n")
+	s.WriteString(b.styles.MenuItem.Render("Install it with:"))
+	s.WriteString("\n\n")
+	s.WriteString(b.styles.MenuKey.Render("  " + beadsInstallCommand))
+	s.WriteString("\n\n")
+	s.WriteString(b.styles.MenuItem.Render("then run bd init in your project. See github.com/steveyegge/beads for other ways to install."))
+	s.WriteString("\n")
+
+	if b.detail != "" {
+		s.WriteString("\n")
+		s.WriteString(b.styles.Footer.Render(b.detail))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(b.styles.Footer.Render("r/Enter: retry • Esc: dismiss (keeps checking in the background)"))
+
+	return s.String()
+}
+
+// Title returns the overlay title
+func (b *BeadsMissingOverlay) Title() string {
+	return "Beads CLI Not Found"
+}
+
+// Size returns the overlay dimensions
+func (b *BeadsMissingOverlay) Size() (width, height int) {
+	return 80, 18
+}
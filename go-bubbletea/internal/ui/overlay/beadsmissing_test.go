@@ -0,0 +1,45 @@
+package overlay
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBeadsMissingOverlay_Keys(t *testing.T) {
+	tests := []struct {
+		key  tea.KeyMsg
+		want string
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}, "beads_retry"},
+		{tea.KeyMsg{Type: tea.KeyEnter}, "beads_retry"},
+		{tea.KeyMsg{Type: tea.KeyEsc}, "beads_dismiss"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key.String(), func(t *testing.T) {
+			b := NewBeadsMissingOverlay(nil)
+			_, cmd := b.Update(tt.key)
+			if cmd == nil {
+				t.Fatal("Expected a command")
+			}
+			msg, ok := cmd().(SelectionMsg)
+			if !ok || msg.Key != tt.want {
+				t.Errorf("Expected SelectionMsg %q, got %#v", tt.want, cmd())
+			}
+		})
+	}
+}
+
+func TestBeadsMissingOverlay_View(t *testing.T) {
+	b := NewBeadsMissingOverlay(errors.New("exec: \"bd\": executable file not found in $PATH"))
+	view := b.View()
+
+	for _, want := range []string{"install.sh", "executable file not found", "retry"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
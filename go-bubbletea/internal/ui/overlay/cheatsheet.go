@@ -0,0 +1,74 @@
+package overlay
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CheatSheetFormat is the output format of an exported keybinding cheat sheet
+type CheatSheetFormat int
+
+const (
+	CheatSheetText CheatSheetFormat = iota
+	CheatSheetMarkdown
+)
+
+// CheatSheetFormatForPath picks the cheat sheet format from a file extension.
+// ".md" and ".markdown" produce markdown, anything else plain text.
+func CheatSheetFormatForPath(path string) CheatSheetFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return CheatSheetMarkdown
+	default:
+		return CheatSheetText
+	}
+}
+
+// RenderCheatSheet renders keybinding categories as a printable cheat sheet
+func RenderCheatSheet(categories []KeyCategory, format CheatSheetFormat) string {
+	if format == CheatSheetMarkdown {
+		return renderMarkdownCheatSheet(categories)
+	}
+	return renderTextCheatSheet(categories)
+}
+
+// renderMarkdownCheatSheet renders one table per category
+func renderMarkdownCheatSheet(categories []KeyCategory) string {
+	var b strings.Builder
+	b.WriteString("# Azedarach Keybindings\n")
+
+	for _, cat := range categories {
+		fmt.Fprintf(&b, "\n## %s\n\n", cat.Name)
+		b.WriteString("| Key | Action |\n")
+		b.WriteString("|-----|--------|\n")
+		for _, binding := range cat.Bindings {
+			key := strings.ReplaceAll(binding.Key, "|", `\|`)
+			fmt.Fprintf(&b, "| `%s` | %s |\n", key, binding.Description)
+		}
+	}
+
+	return b.String()
+}
+
+// renderTextCheatSheet renders categories with keys padded into a column
+func renderTextCheatSheet(categories []KeyCategory) string {
+	keyWidth := 0
+	for _, cat := range categories {
+		for _, binding := range cat.Bindings {
+			keyWidth = max(keyWidth, len(binding.Key))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("AZEDARACH KEYBINDINGS\n")
+
+	for _, cat := range categories {
+		fmt.Fprintf(&b, "\n%s\n", strings.ToUpper(cat.Name))
+		for _, binding := range cat.Bindings {
+			fmt.Fprintf(&b, "  %-*s  %s\n", keyWidth, binding.Key, binding.Description)
+		}
+	}
+
+	return b.String()
+}
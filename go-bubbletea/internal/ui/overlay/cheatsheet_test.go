@@ -0,0 +1,86 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeyCategories = []KeyCategory{
+	{
+		Name: "Navigation",
+		Bindings: []KeyBinding{
+			{Key: "j/k", Description: "Move up/down"},
+			{Key: "gg", Description: "Jump to top"},
+		},
+	},
+	{
+		Name: "Other",
+		Bindings: []KeyBinding{
+			{Key: "q", Description: "Quit"},
+		},
+	},
+}
+
+func TestRenderCheatSheet_Markdown(t *testing.T) {
+	got := RenderCheatSheet(testKeyCategories, CheatSheetMarkdown)
+
+	want := "# Azedarach Keybindings\n" +
+		"\n## Navigation\n\n" +
+		"| Key | Action |\n" +
+		"|-----|--------|\n" +
+		"| `j/k` | Move up/down |\n" +
+		"| `gg` | Jump to top |\n" +
+		"\n## Other\n\n" +
+		"| Key | Action |\n" +
+		"|-----|--------|\n" +
+		"| `q` | Quit |\n"
+
+	assert.Equal(t, want, got)
+}
+
+func TestRenderCheatSheet_Text(t *testing.T) {
+	got := RenderCheatSheet(testKeyCategories, CheatSheetText)
+
+	want := "AZEDARACH KEYBINDINGS\n" +
+		"\nNAVIGATION\n" +
+		"  j/k  Move up/down\n" +
+		"  gg   Jump to top\n" +
+		"\nOTHER\n" +
+		"  q    Quit\n"
+
+	assert.Equal(t, want, got)
+}
+
+func TestRenderCheatSheet_EscapesPipes(t *testing.T) {
+	categories := []KeyCategory{
+		{Name: "Misc", Bindings: []KeyBinding{{Key: "|", Description: "Split"}}},
+	}
+
+	got := RenderCheatSheet(categories, CheatSheetMarkdown)
+
+	assert.Contains(t, got, "| `\\|` | Split |")
+}
+
+func TestCheatSheetFormatForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want CheatSheetFormat
+	}{
+		{path: "keys.md", want: CheatSheetMarkdown},
+		{path: "/tmp/KEYS.MARKDOWN", want: CheatSheetMarkdown},
+		{path: "keys.txt", want: CheatSheetText},
+		{path: "keys", want: CheatSheetText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, CheatSheetFormatForPath(tt.path))
+		})
+	}
+}
+
+func TestKeyCategories_MatchesHelpOverlay(t *testing.T) {
+	help := NewHelpOverlay()
+	assert.Equal(t, KeyCategories(), help.getCategories())
+}
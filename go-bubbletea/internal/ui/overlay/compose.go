@@ -0,0 +1,75 @@
+package overlay
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+// reset ends any styling left open where a line is cut
+const reset = "\x1b[0m"
+
+// Place draws fg over bg with fg's top-left corner at column x, row y. bg is
+// padded or cut to exactly width×height first, and whatever of fg falls
+// outside that area is clipped, so the result always fits the screen. The
+// background stays visible around fg on every line it covers.
+func Place(bg, fg string, x, y, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(bg, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	for i, line := range lines {
+		lines[i] = fitLine(line, width)
+	}
+
+	fgWidth := lipgloss.Width(fg)
+	x = max(x, 0)
+	for i, fgLine := range strings.Split(fg, "\n") {
+		row := y + i
+		if row < 0 || row >= height {
+			continue
+		}
+
+		// Pad fg lines to the same width, so the block has a straight edge
+		if pad := fgWidth - ansi.StringWidth(fgLine); pad > 0 {
+			fgLine += strings.Repeat(" ", pad)
+		}
+
+		line := lines[row]
+		left := ansi.Truncate(line, x, "")
+		right := ansi.TruncateLeft(line, x+fgWidth, "")
+		lines[row] = fitLine(left+reset+fgLine+reset+right, width)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fitLine pads or cuts line to exactly width columns
+func fitLine(line string, width int) string {
+	w := ansi.StringWidth(line)
+	if w > width {
+		return ansi.Truncate(line, width, "") + reset
+	}
+	return line + strings.Repeat(" ", width-w)
+}
+
+// Dim renders s without its colours in a muted foreground, for the screen
+// behind a modal
+func Dim(s string) string {
+	dim := lipgloss.NewStyle().Foreground(styles.Surface2)
+
+	lines := strings.Split(ansi.Strip(s), "\n")
+	for i, line := range lines {
+		lines[i] = dim.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,66 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPlace(t *testing.T) {
+	bg := strings.Join([]string{
+		"aaaaaaaaaa",
+		"bbbbbbbbbb",
+		"cccccccccc",
+		"dddddddddd",
+	}, "\n")
+	fg := "XX\nYYY"
+
+	got := ansi.Strip(Place(bg, fg, 3, 1, 10, 4))
+	want := strings.Join([]string{
+		"aaaaaaaaaa",
+		"bbbXX bbbb",
+		"cccYYYcccc",
+		"dddddddddd",
+	}, "\n")
+	if got != want {
+		t.Errorf("Place() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPlace_ClipsToScreen(t *testing.T) {
+	bg := strings.Repeat(strings.Repeat("b", 30)+"\n", 12)
+	fg := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Width(20).Height(10).Render("modal")
+
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{"centred", (15 - 22) / 2, (8 - 12) / 2},
+		{"past the bottom right", 10, 5},
+		{"past the top left", -5, -5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Place(bg, fg, tt.x, tt.y, 15, 8)
+			if height := lipgloss.Height(got); height != 8 {
+				t.Errorf("Expected 8 rows, got %d", height)
+			}
+			for i, line := range strings.Split(got, "\n") {
+				if width := ansi.StringWidth(line); width != 15 {
+					t.Errorf("Row %d is %d columns wide, want 15", i, width)
+				}
+			}
+		})
+	}
+}
+
+func TestDim(t *testing.T) {
+	styled := lipgloss.NewStyle().Bold(true).Render("board")
+
+	if got := ansi.Strip(Dim(styled + "\nrow")); got != "board\nrow" {
+		t.Errorf("Expected Dim to keep the text, got %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/config"
+)
+
+// SettingsSavedMsg reports the settings overlay writing Config to Path.
+// The app applies Config once Err is nil.
+type SettingsSavedMsg struct {
+	Config *config.Config
+	Path   string
+	Err    error
+}
+
+// toastLevels are the toast durations the settings overlay edits, in the
+// order shown
+var toastLevels = []string{"info", "success", "warning", "error"}
+
+// configEdits are the settings changed in the overlay, keyed by item, each
+// applying its change to a config
+type configEdits map[string]func(*config.Config)
+
+// apply makes every edit to cfg
+func (e configEdits) apply(cfg *config.Config) {
+	for _, edit := range e {
+		edit(cfg)
+	}
+}
+
+// NewConfigSettingsOverlay creates a settings overlay that edits a copy of
+// cfg and, once it validates, saves the edited settings to path
+func NewConfigSettingsOverlay(editor interface {
+	GetShowPhases() bool
+	ToggleShowPhases()
+}, cfg *config.Config, path string) *SettingsOverlay {
+	draft := cloneConfig(cfg)
+	edits := configEdits{}
+	menu := &SettingsOverlay{styles: New()}
+
+	menu.items = []SettingItem{
+		{
+			Key:   "phases",
+			Label: "Show dependency phases",
+			Type:  SettingToggle,
+			Value: editor.GetShowPhases(),
+			OnChange: func(value any) {
+				editor.ToggleShowPhases()
+			},
+		},
+		{
+			Key:   "",
+			Label: "───────────────────",
+			Type:  SettingSeparator,
+		},
+		{
+			Key:   "cliTool",
+			Label: "CLI tool",
+			Type:  SettingText,
+			Value: draft.CLITool,
+			OnEdit: func(value string) error {
+				if value == "" {
+					return errors.New("cliTool must not be empty")
+				}
+				edits["cliTool"] = func(c *config.Config) { c.CLITool = value }
+				edits["cliTool"](draft)
+				return nil
+			},
+		},
+		{
+			Key:   "baseBranch",
+			Label: "Base branch",
+			Type:  SettingText,
+			Value: draft.Git.BaseBranch,
+			OnEdit: func(value string) error {
+				// Empty detects the branch from origin/HEAD
+				edits["baseBranch"] = func(c *config.Config) { c.Git.BaseBranch = value }
+				edits["baseBranch"](draft)
+				return nil
+			},
+		},
+		portSetting("basePort", "Dev server base port", draft.DevServer.BasePort, func(c *config.Config, port int) {
+			c.DevServer.BasePort = port
+		}, edits, draft),
+		portSetting("maxPort", "Dev server max port", draft.DevServer.MaxPort, func(c *config.Config, port int) {
+			c.DevServer.MaxPort = port
+		}, edits, draft),
+	}
+
+	for _, level := range toastLevels {
+		value := ""
+		if ms, ok := draft.UI.ToastDurations[level]; ok {
+			value = strconv.Itoa(ms)
+		}
+		menu.items = append(menu.items, SettingItem{
+			Key:   "toast." + level,
+			Label: "Toast ms, " + level,
+			Type:  SettingText,
+			Value: value,
+			OnEdit: func(value string) error {
+				key := "toast." + level
+				// Empty goes back to the built-in duration
+				if value == "" {
+					edits[key] = func(c *config.Config) { delete(c.UI.ToastDurations, level) }
+					edits[key](draft)
+					return nil
+				}
+				ms, err := strconv.Atoi(value)
+				if err != nil {
+					return fmt.Errorf("ui.toastDurations.%s must be a number of milliseconds, got %q", level, value)
+				}
+				edits[key] = func(c *config.Config) {
+					if c.UI.ToastDurations == nil {
+						c.UI.ToastDurations = make(map[string]int)
+					}
+					c.UI.ToastDurations[level] = ms
+				}
+				edits[key](draft)
+				return nil
+			},
+		})
+	}
+
+	menu.items = append(menu.items,
+		SettingItem{
+			Key:   "",
+			Label: "───────────────────",
+			Type:  SettingSeparator,
+		},
+		SettingItem{
+			Key:   "save",
+			Label: "Save to " + filepath.Base(path),
+			Type:  SettingAction,
+			OnAction: func() tea.Cmd {
+				return saveConfig(draft, edits, path)
+			},
+		},
+		SettingItem{
+			Key:   "editor",
+			Label: "Open config in $EDITOR",
+			Type:  SettingAction,
+			OnAction: func() tea.Cmd {
+				return openConfigInEditor()
+			},
+		},
+		SettingItem{
+			Key:   "projects",
+			Label: "Manage projects",
+			Type:  SettingAction,
+			OnAction: func() tea.Cmd {
+				return func() tea.Msg {
+					return SelectionMsg{Key: "projects"}
+				}
+			},
+		},
+	)
+
+	menu.moveCursorToNextSelectable()
+	return menu
+}
+
+// portSetting is a SettingText item editing a port, recording the edit as
+// set applied to a config
+func portSetting(key, label string, port int, set func(*config.Config, int), edits configEdits, draft *config.Config) SettingItem {
+	return SettingItem{
+		Key:   key,
+		Label: label,
+		Type:  SettingText,
+		Value: strconv.Itoa(port),
+		OnEdit: func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("devServer.%s must be a port number, got %q", key, value)
+			}
+			edits[key] = func(c *config.Config) { set(c, n) }
+			edits[key](draft)
+			return nil
+		},
+	}
+}
+
+// saveConfig validates draft and, if it passes, makes the edits to the
+// project's own config at path, leaving settings it doesn't set to the
+// defaults. Validation checks the disk, so it runs in the command too;
+// problems come back as Err, one per line, and nothing is written.
+func saveConfig(draft *config.Config, edits configEdits, path string) tea.Cmd {
+	saved := cloneConfig(draft)
+	pending := maps.Clone(edits)
+	return func() tea.Msg {
+		if err := saved.Validate(); err != nil {
+			return SettingsSavedMsg{Config: saved, Path: path, Err: err}
+		}
+		return SettingsSavedMsg{Config: saved, Path: path, Err: config.EditConfigFile(path, pending.apply)}
+	}
+}
+
+// cloneConfig copies cfg deeply enough that editing the copy's settings
+// leaves cfg as it was
+func cloneConfig(cfg *config.Config) *config.Config {
+	clone := *cfg
+	clone.UI.ToastDurations = maps.Clone(cfg.UI.ToastDurations)
+	return &clone
+}
@@ -0,0 +1,179 @@
+package overlay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/config"
+)
+
+// phasesEditor is a stand-in for the editor service's phases toggle
+type phasesEditor struct{ show bool }
+
+func (e *phasesEditor) GetShowPhases() bool { return e.show }
+func (e *phasesEditor) ToggleShowPhases()   { e.show = !e.show }
+
+// newTestConfigSettings opens config settings over the defaults, saving
+// into a temp dir
+func newTestConfigSettings(t *testing.T) (*SettingsOverlay, *config.Config, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Session.LogDir = filepath.Join(dir, "logs")
+	path := filepath.Join(dir, ".azedarach.json")
+	return NewConfigSettingsOverlay(&phasesEditor{}, cfg, path), cfg, path
+}
+
+// editSetting selects the item with key and types value into it
+func editSetting(t *testing.T, menu *SettingsOverlay, key, value string) {
+	t.Helper()
+	selectSetting(t, menu, key)
+	menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !menu.editing {
+		t.Fatalf("Expected Enter to edit %s", key)
+	}
+	menu.input.SetValue(value)
+	menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func selectSetting(t *testing.T, menu *SettingsOverlay, key string) {
+	t.Helper()
+	for i, item := range menu.items {
+		if item.Key == key {
+			menu.cursor = i
+			return
+		}
+	}
+	t.Fatalf("No setting %q", key)
+}
+
+func TestConfigSettings_EditValidateSave(t *testing.T) {
+	menu, cfg, path := newTestConfigSettings(t)
+
+	editSetting(t, menu, "cliTool", "opencode")
+	editSetting(t, menu, "baseBranch", "develop")
+	editSetting(t, menu, "basePort", "4000")
+	editSetting(t, menu, "maxPort", "4100")
+	editSetting(t, menu, "toast.error", "9000")
+	if menu.editing || len(menu.Errors()) > 0 {
+		t.Fatalf("Expected every edit to be accepted, got %v", menu.Errors())
+	}
+	if cfg.CLITool != "claude" {
+		t.Error("Expected edits to leave the live config alone until saved")
+	}
+
+	selectSetting(t, menu, "save")
+	_, cmd := menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected saving a valid config to write it")
+	}
+	saved, ok := cmd().(SettingsSavedMsg)
+	if !ok {
+		t.Fatalf("Expected SettingsSavedMsg, got %#v", cmd())
+	}
+	if saved.Err != nil {
+		t.Fatalf("Save failed: %v", saved.Err)
+	}
+	if saved.Path != path {
+		t.Errorf("Expected save to %s, got %s", path, saved.Path)
+	}
+
+	loaded, err := config.LoadConfig(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if loaded.CLITool != "opencode" || loaded.Git.BaseBranch != "develop" {
+		t.Errorf("Expected saved CLI tool and base branch, got %q and %q", loaded.CLITool, loaded.Git.BaseBranch)
+	}
+	if loaded.DevServer.BasePort != 4000 || loaded.DevServer.MaxPort != 4100 {
+		t.Errorf("Expected saved ports 4000-4100, got %d-%d", loaded.DevServer.BasePort, loaded.DevServer.MaxPort)
+	}
+	if loaded.UI.ToastDurations["error"] != 9000 {
+		t.Errorf("Expected saved error toast duration 9000, got %d", loaded.UI.ToastDurations["error"])
+	}
+}
+
+func TestConfigSettings_InvalidConfigIsNotSaved(t *testing.T) {
+	menu, _, path := newTestConfigSettings(t)
+
+	// Each port is a number, but base above max fails validation
+	editSetting(t, menu, "basePort", "5000")
+	editSetting(t, menu, "maxPort", "4000")
+
+	selectSetting(t, menu, "save")
+	_, cmd := menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected saving to validate in a command")
+	}
+	saved, ok := cmd().(SettingsSavedMsg)
+	if !ok || saved.Err == nil {
+		t.Fatalf("Expected the invalid config to be reported, got %#v", saved)
+	}
+	if !strings.Contains(saved.Err.Error(), "devServer.basePort (5000) must not exceed devServer.maxPort (4000)") {
+		t.Errorf("Expected the validation error, got %v", saved.Err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no config file, got %v", err)
+	}
+}
+
+func TestConfigSettings_SavesOnlyEditedSettings(t *testing.T) {
+	menu, _, path := newTestConfigSettings(t)
+	if err := os.WriteFile(path, []byte(`{"session": {"maxConcurrent": 3}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	editSetting(t, menu, "basePort", "3050")
+	selectSetting(t, menu, "save")
+	_, cmd := menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if saved := cmd().(SettingsSavedMsg); saved.Err != nil {
+		t.Fatalf("Save failed: %v", saved.Err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		CLITool   string `json:"cliTool"`
+		Session   struct{ MaxConcurrent int }
+		DevServer struct{ BasePort int }
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.DevServer.BasePort != 3050 {
+		t.Errorf("Expected the edited base port saved, got %d", raw.DevServer.BasePort)
+	}
+	if raw.Session.MaxConcurrent != 3 {
+		t.Errorf("Expected the project's own settings kept, got maxConcurrent %d", raw.Session.MaxConcurrent)
+	}
+	if raw.CLITool != "" {
+		t.Errorf("Expected unedited defaults left out of the file, got cliTool %q", raw.CLITool)
+	}
+}
+
+func TestConfigSettings_RejectsUnparsableEdit(t *testing.T) {
+	menu, _, _ := newTestConfigSettings(t)
+
+	editSetting(t, menu, "basePort", "three thousand")
+	if !menu.editing {
+		t.Error("Expected a rejected edit to stay open for correction")
+	}
+	if errs := menu.Errors(); len(errs) != 1 || !strings.Contains(errs[0], "devServer.basePort must be a port number") {
+		t.Errorf("Expected a port number error, got %v", errs)
+	}
+
+	// Esc abandons the edit and its error
+	menu.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if menu.editing || len(menu.Errors()) > 0 {
+		t.Error("Expected Esc to cancel the edit")
+	}
+	if got := menu.items[menu.cursor].Value; got != "3000" {
+		t.Errorf("Expected the port to stay 3000, got %v", got)
+	}
+}
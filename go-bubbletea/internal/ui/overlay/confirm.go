@@ -6,12 +6,19 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// ConfirmDialog is a confirmation dialog overlay with Yes/No options
+// ConfirmDialog is a confirmation dialog overlay with Yes/No options. It
+// answers with a SelectionMsg, or with the caller's commands when created
+// by NewConfirmDialogWithActions.
 type ConfirmDialog struct {
 	title    string
 	message  string
 	styles   *Styles
 	selected bool // true = Yes, false = No
+
+	hasActions bool
+	onConfirm  tea.Cmd
+	onCancel   tea.Cmd
+	answered   bool
 }
 
 // ConfirmResult represents the result of a confirmation dialog
@@ -29,6 +36,40 @@ func NewConfirmDialog(title, message string) *ConfirmDialog {
 	}
 }
 
+// NewConfirmDialogWithActions creates a confirmation dialog that runs
+// onConfirm when answered yes and onCancel when answered no, instead of
+// sending a SelectionMsg. Either command may be nil. An overlay showing the
+// dialog inside itself closes it once Answered.
+func NewConfirmDialogWithActions(title, message string, onConfirm, onCancel tea.Cmd) *ConfirmDialog {
+	c := NewConfirmDialog(title, message)
+	c.hasActions = true
+	c.onConfirm = onConfirm
+	c.onCancel = onCancel
+	return c
+}
+
+// Answered reports whether the dialog has been answered either way
+func (c *ConfirmDialog) Answered() bool {
+	return c.answered
+}
+
+// answer records the answer and returns the command reporting it
+func (c *ConfirmDialog) answer(confirmed bool) tea.Cmd {
+	c.answered = true
+	if c.hasActions {
+		if confirmed {
+			return c.onConfirm
+		}
+		return c.onCancel
+	}
+	return func() tea.Msg {
+		return SelectionMsg{
+			Key:   map[bool]string{true: "yes", false: "no"}[confirmed],
+			Value: ConfirmResult{Confirmed: confirmed},
+		}
+	}
+}
+
 // Init initializes the dialog
 func (c *ConfirmDialog) Init() tea.Cmd {
 	return nil
@@ -41,30 +82,15 @@ func (c *ConfirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "y", "Y":
 			// Yes - confirm and close
-			return c, func() tea.Msg {
-				return SelectionMsg{
-					Key:   "yes",
-					Value: ConfirmResult{Confirmed: true},
-				}
-			}
+			return c, c.answer(true)
 
 		case "n", "N", "esc":
 			// No or Escape - cancel and close
-			return c, func() tea.Msg {
-				return SelectionMsg{
-					Key:   "no",
-					Value: ConfirmResult{Confirmed: false},
-				}
-			}
+			return c, c.answer(false)
 
 		case "enter":
 			// Confirm current selection
-			return c, func() tea.Msg {
-				return SelectionMsg{
-					Key: map[bool]string{true: "yes", false: "no"}[c.selected],
-					Value: ConfirmResult{Confirmed: c.selected},
-				}
-			}
+			return c, c.answer(c.selected)
 
 		case "left", "h":
 			// Move to No
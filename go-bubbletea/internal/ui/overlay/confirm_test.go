@@ -309,3 +309,59 @@ func TestConfirmDialog_Init(t *testing.T) {
 		t.Error("expected Init to return nil command")
 	}
 }
+
+type confirmedMsg struct{}
+type cancelledMsg struct{}
+
+func TestConfirmDialogWithActions_DispatchesAnswer(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tea.KeyMsg
+		want tea.Msg
+	}{
+		{"y confirms", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'y'}}}, confirmedMsg{}},
+		{"Y confirms", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'Y'}}}, confirmedMsg{}},
+		{"enter on yes confirms", []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyEnter}}, confirmedMsg{}},
+		{"enter defaults to no", []tea.KeyMsg{{Type: tea.KeyEnter}}, cancelledMsg{}},
+		{"n cancels", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'n'}}}, cancelledMsg{}},
+		{"esc cancels", []tea.KeyMsg{{Type: tea.KeyEsc}}, cancelledMsg{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confirm := NewConfirmDialogWithActions("Delete", "Delete az-1?",
+				func() tea.Msg { return confirmedMsg{} },
+				func() tea.Msg { return cancelledMsg{} },
+			)
+
+			var cmd tea.Cmd
+			for _, key := range tt.keys {
+				_, cmd = confirm.Update(key)
+			}
+			if cmd == nil {
+				t.Fatal("expected a command")
+			}
+			if got := cmd(); got != tt.want {
+				t.Errorf("expected %T, got %T", tt.want, got)
+			}
+			if !confirm.Answered() {
+				t.Error("expected the dialog to be answered")
+			}
+		})
+	}
+}
+
+func TestConfirmDialogWithActions_NilAction(t *testing.T) {
+	confirm := NewConfirmDialogWithActions("Delete", "Delete az-1?", func() tea.Msg { return confirmedMsg{} }, nil)
+
+	if _, cmd := confirm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd != nil || confirm.Answered() {
+		t.Error("expected x to leave the dialog unanswered")
+	}
+	// A nil onCancel answers without a command rather than a SelectionMsg
+	if _, cmd := confirm.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil {
+		t.Errorf("expected no command without onCancel, got %T", cmd())
+	}
+	if !confirm.Answered() {
+		t.Error("expected Esc to answer the dialog")
+	}
+}
@@ -0,0 +1,248 @@
+package overlay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// dependencyPickerRows is how many candidate beads the picker shows at once
+const dependencyPickerRows = 10
+
+// DependencyLinkTypes are the links the picker can add, cycled with tab
+var DependencyLinkTypes = []domain.DependencyType{
+	domain.DependencyBlockedBy,
+	domain.DependencyBlocks,
+	domain.DependencyRelatedTo,
+}
+
+// DependencyPickMsg asks to pick a bead to link to TaskID
+type DependencyPickMsg struct {
+	TaskID string
+}
+
+// DependencyAddMsg asks to link TaskID to OtherID. Type is from TaskID's
+// side: blocked_by means OtherID blocks TaskID.
+type DependencyAddMsg struct {
+	TaskID  string
+	OtherID string
+	Type    domain.DependencyType
+}
+
+// DependencyRemoveMsg asks to remove one of TaskID's dependencies
+type DependencyRemoveMsg struct {
+	TaskID     string
+	Dependency domain.Dependency
+}
+
+// DependencyPicker picks another bead to link to a task, filtered by typing
+type DependencyPicker struct {
+	task       domain.Task
+	candidates []domain.Task
+	input      textinput.Model
+	linkType   int
+	cursor     int
+	offset     int
+	styles     *Styles
+}
+
+// NewDependencyPicker creates a picker of tasks to link to task. The task
+// itself and beads it already depends on aren't offered.
+func NewDependencyPicker(task domain.Task, tasks []domain.Task) *DependencyPicker {
+	linked := make(map[string]bool, len(task.Dependencies))
+	for _, dep := range task.Dependencies {
+		linked[dep.ID] = true
+	}
+
+	var candidates []domain.Task
+	for _, t := range tasks {
+		if t.ID != task.ID && !linked[t.ID] {
+			candidates = append(candidates, t)
+		}
+	}
+
+	ti := textinput.New()
+	ti.Prompt = "Filter: "
+	ti.Placeholder = "ID or title"
+	ti.Focus()
+	ti.CharLimit = 100
+	ti.Width = 40
+
+	return &DependencyPicker{
+		task:       task,
+		candidates: candidates,
+		input:      ti,
+		styles:     New(),
+	}
+}
+
+// Init initializes the picker
+func (p *DependencyPicker) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (p *DependencyPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return p, func() tea.Msg { return CloseOverlayMsg{} }
+
+		case "tab":
+			p.linkType = (p.linkType + 1) % len(DependencyLinkTypes)
+			return p, nil
+
+		case "shift+tab":
+			p.linkType = (p.linkType + len(DependencyLinkTypes) - 1) % len(DependencyLinkTypes)
+			return p, nil
+
+		case "down", "ctrl+j", "ctrl+n":
+			if p.cursor < len(p.Matches())-1 {
+				p.cursor++
+			}
+			p.scrollToCursor()
+			return p, nil
+
+		case "up", "ctrl+k", "ctrl+p":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+			p.scrollToCursor()
+			return p, nil
+
+		case "enter":
+			matches := p.Matches()
+			if p.cursor >= len(matches) {
+				return p, nil
+			}
+			add := DependencyAddMsg{
+				TaskID:  p.task.ID,
+				OtherID: matches[p.cursor].ID,
+				Type:    DependencyLinkTypes[p.linkType],
+			}
+			return p, func() tea.Msg { return add }
+		}
+	}
+
+	prev := p.input.Value()
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != prev {
+		p.cursor, p.offset = 0, 0
+	}
+	return p, cmd
+}
+
+// Matches returns the candidates whose ID or title contains the filter
+func (p *DependencyPicker) Matches() []domain.Task {
+	query := strings.ToLower(strings.TrimSpace(p.input.Value()))
+	if query == "" {
+		return p.candidates
+	}
+
+	var matches []domain.Task
+	for _, t := range p.candidates {
+		if strings.Contains(strings.ToLower(t.ID), query) || strings.Contains(strings.ToLower(t.Title), query) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
+
+// scrollToCursor keeps the cursor within the visible rows
+func (p *DependencyPicker) scrollToCursor() {
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+dependencyPickerRows {
+		p.offset = p.cursor - dependencyPickerRows + 1
+	}
+}
+
+// View renders the picker
+func (p *DependencyPicker) View() string {
+	var b strings.Builder
+
+	// Link type tabs
+	for i, linkType := range DependencyLinkTypes {
+		label := dependencyLabel(linkType)
+		if i == p.linkType {
+			b.WriteString(p.styles.MenuItemActive.Render("[" + label + "]"))
+		} else {
+			b.WriteString(p.styles.MenuItem.Render(" " + label + " "))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	matches := p.Matches()
+	if len(matches) == 0 {
+		b.WriteString(p.styles.Footer.Render("No matching beads"))
+		b.WriteString("\n")
+	}
+	end := min(p.offset+dependencyPickerRows, len(matches))
+	for i := p.offset; i < end; i++ {
+		t := matches[i]
+		style := p.styles.MenuItem
+		prefix := "  "
+		if i == p.cursor {
+			style = p.styles.MenuItemActive
+			prefix = "▶ "
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%s %s", prefix, t.ID, t.Title)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if len(matches) > 0 && p.cursor < len(matches) {
+		b.WriteString(p.styles.MenuItem.Render(describeLink(p.task.ID, matches[p.cursor].ID, DependencyLinkTypes[p.linkType])))
+		b.WriteString("\n")
+	}
+	b.WriteString(p.styles.Footer.Render("Tab: link type • ↑/↓: choose • Enter: add • Esc: cancel"))
+
+	return b.String()
+}
+
+// Title returns the overlay title
+func (p *DependencyPicker) Title() string {
+	return "Add Dependency to " + p.task.ID
+}
+
+// Size returns the overlay dimensions
+func (p *DependencyPicker) Size() (width, height int) {
+	// Tabs, input, rows, description and footer, with spacing and padding
+	return 70, dependencyPickerRows + 11
+}
+
+// dependencyLabel names a link type from the task's side
+func dependencyLabel(linkType domain.DependencyType) string {
+	switch linkType {
+	case domain.DependencyBlockedBy:
+		return "Blocked by"
+	case domain.DependencyBlocks:
+		return "Blocks"
+	case domain.DependencyRelatedTo:
+		return "Related to"
+	case domain.DependencyParentChild:
+		return "Child of"
+	default:
+		return string(linkType)
+	}
+}
+
+// describeLink spells out the link that would be added
+func describeLink(taskID, otherID string, linkType domain.DependencyType) string {
+	switch linkType {
+	case domain.DependencyBlockedBy:
+		return fmt.Sprintf("%s can't start until %s is done", taskID, otherID)
+	case domain.DependencyBlocks:
+		return fmt.Sprintf("%s can't start until %s is done", otherID, taskID)
+	default:
+		return fmt.Sprintf("%s and %s are related", taskID, otherID)
+	}
+}
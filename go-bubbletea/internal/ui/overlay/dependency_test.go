@@ -0,0 +1,101 @@
+package overlay
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func dependencyPickerTasks() (domain.Task, []domain.Task) {
+	task := domain.Task{
+		ID:    "az-1",
+		Title: "Login form",
+		Dependencies: []domain.Dependency{
+			{ID: "az-2", Type: domain.DependencyBlocks},
+		},
+	}
+	return task, []domain.Task{
+		task,
+		{ID: "az-2", Title: "Auth API"},
+		{ID: "az-3", Title: "Session cookies"},
+		{ID: "az-4", Title: "Password reset"},
+	}
+}
+
+func TestDependencyPicker_Candidates(t *testing.T) {
+	task, tasks := dependencyPickerTasks()
+	p := NewDependencyPicker(task, tasks)
+
+	// Neither the task nor beads it already depends on are offered
+	matches := p.Matches()
+	if len(matches) != 2 || matches[0].ID != "az-3" || matches[1].ID != "az-4" {
+		t.Errorf("Expected az-3 and az-4, got %v", matches)
+	}
+}
+
+func TestDependencyPicker_Filter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"az-3", "az-4"}},
+		{"cookie", []string{"az-3"}},
+		{"PASSWORD", []string{"az-4"}},
+		{"az-4", []string{"az-4"}},
+		{"nothing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			task, tasks := dependencyPickerTasks()
+			p := NewDependencyPicker(task, tasks)
+			p.input.SetValue(tt.query)
+
+			var got []string
+			for _, match := range p.Matches() {
+				got = append(got, match.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDependencyPicker_Enter(t *testing.T) {
+	task, tasks := dependencyPickerTasks()
+	p := NewDependencyPicker(task, tasks)
+
+	// Second candidate, second link type
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command on enter")
+	}
+
+	msg, ok := cmd().(DependencyAddMsg)
+	if !ok {
+		t.Fatalf("Expected DependencyAddMsg, got %T", cmd())
+	}
+	want := DependencyAddMsg{TaskID: "az-1", OtherID: "az-4", Type: domain.DependencyBlocks}
+	if msg != want {
+		t.Errorf("Expected %+v, got %+v", want, msg)
+	}
+}
+
+func TestDependencyPicker_EnterWithoutMatches(t *testing.T) {
+	task, tasks := dependencyPickerTasks()
+	p := NewDependencyPicker(task, tasks)
+	p.input.SetValue("nothing")
+
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Expected no command with nothing to pick")
+	}
+}
@@ -1,51 +1,162 @@
 package overlay
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
-// DetailPanel displays full task details with scrollable description
+// TaskGetter fetches the current state of a single task
+type TaskGetter interface {
+	Get(ctx context.Context, id string) (*domain.Task, error)
+}
+
+// DetailLoadedMsg carries a task refreshed for the detail panel
+type DetailLoadedMsg struct {
+	TaskID string
+	Task   *domain.Task
+	Err    error
+}
+
+// Detail panel layout: the content is wrapped to the box less its padding,
+// and the lines shown are what's left of the box after the padding, the
+// title and the scroll indicator
+const (
+	detailWidth      = 70
+	detailHeight     = 30
+	detailWrapWidth  = detailWidth - 4
+	detailViewHeight = detailHeight - 6
+)
+
+// DetailPanel displays full task details, scrolling when they don't fit
 type DetailPanel struct {
 	task          domain.Task
 	session       *domain.Session
 	scrollY       int
 	contentHeight int
 	viewHeight    int
+	wrapWidth     int
 	styles        *Styles
+	markdown      *markdownRenderer // Renders the long text sections; nil shows them as written
+
+	// Refresh on open: the task from the board may be stale
+	getter  TaskGetter
+	loading bool
+	loadErr error // Refresh failed; gone when the bead was deleted
+	gone    bool
+	spinner spinner.Model
+
+	depCursor int // Dependency selected for removal, -1 for none
+
+	blockers []domain.Task // Blockers not done yet
 }
 
-// NewDetailPanel creates a new detail panel for the given task and optional session
-func NewDetailPanel(task domain.Task, session *domain.Session) *DetailPanel {
-	// Calculate contentHeight based on description
-	contentHeight := 0
-	if task.Description != "" {
-		contentHeight = len(strings.Split(task.Description, "\n"))
+// NewDetailPanel creates a new detail panel for the given task and optional
+// session. With a getter, the panel shows the task as given while it
+// fetches the current version.
+func NewDetailPanel(task domain.Task, session *domain.Session, getter TaskGetter) *DetailPanel {
+	d := &DetailPanel{
+		task:       task,
+		session:    session,
+		scrollY:    0,
+		viewHeight: detailViewHeight,
+		wrapWidth:  detailWrapWidth,
+		styles:     New(),
+		getter:     getter,
+		loading:    getter != nil,
+		spinner:    spinner.New(spinner.WithSpinner(spinner.Dot)),
+		depCursor:  -1,
 	}
+	d.layout()
+	return d
+}
 
-	return &DetailPanel{
-		task:          task,
-		session:       session,
-		scrollY:       0,
-		contentHeight: contentHeight,
-		viewHeight:    20, // Default, will be updated in Size()
-		styles:        New(),
+// Init starts refreshing the task
+func (d *DetailPanel) Init() tea.Cmd {
+	return d.Refresh()
+}
+
+// Refresh fetches the current version of the task, e.g. after its
+// dependencies changed
+func (d *DetailPanel) Refresh() tea.Cmd {
+	if d.getter == nil {
+		return nil
+	}
+	d.loading = true
+
+	getter, id := d.getter, d.task.ID
+	load := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		task, err := getter.Get(ctx, id)
+		return DetailLoadedMsg{TaskID: id, Task: task, Err: err}
 	}
+	return tea.Batch(load, d.spinner.Tick)
 }
 
-// Init initializes the detail panel
-func (d *DetailPanel) Init() tea.Cmd {
-	return nil
+// SetMarkdown sets whether the long text sections are rendered as markdown
+func (d *DetailPanel) SetMarkdown(enabled bool) {
+	d.markdown = nil
+	if enabled {
+		d.markdown = newMarkdownRenderer(d.wrapWidth)
+	}
+	d.layout()
+}
+
+// TaskID returns the ID of the task shown
+func (d *DetailPanel) TaskID() string {
+	return d.task.ID
+}
+
+// SetBlockers sets the unfinished tasks blocking the task
+func (d *DetailPanel) SetBlockers(blockers []domain.Task) {
+	d.blockers = blockers
+	d.layout()
 }
 
 // Update handles messages
 func (d *DetailPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case DetailLoadedMsg:
+		if msg.TaskID != d.task.ID {
+			return d, nil
+		}
+		d.loading = false
+		d.loadErr = msg.Err
+		d.gone = errors.Is(msg.Err, domain.ErrNotFound)
+		if msg.Err == nil && msg.Task != nil {
+			d.task = *msg.Task
+			d.scrollY = 0
+			if d.depCursor >= len(d.task.Dependencies) {
+				d.depCursor = len(d.task.Dependencies) - 1
+			}
+		}
+		d.layout()
+		return d, nil
+
+	case tea.WindowSizeMsg:
+		// Narrow screens shrink the box, less its border and padding
+		d.wrapWidth = max(min(detailWrapWidth, msg.Width-6), 1)
+		d.SetMarkdown(d.markdown != nil)
+		return d, nil
+
+	case spinner.TickMsg:
+		if !d.loading {
+			return d, nil
+		}
+		var cmd tea.Cmd
+		d.spinner, cmd = d.spinner.Update(msg)
+		return d, cmd
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc", "q":
@@ -63,6 +174,15 @@ func (d *DetailPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return d, nil
 
+		case "ctrl+d":
+			// Scroll by half a page
+			d.scrollY = min(d.scrollY+d.viewHeight/2, d.maxScroll())
+			return d, nil
+
+		case "ctrl+u":
+			d.scrollY = max(d.scrollY-d.viewHeight/2, 0)
+			return d, nil
+
 		case "g":
 			// Jump to top
 			d.scrollY = 0
@@ -72,6 +192,27 @@ func (d *DetailPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Jump to bottom
 			d.scrollY = d.maxScroll()
 			return d, nil
+
+		case "tab":
+			// Select the next dependency, then none
+			if len(d.task.Dependencies) > 0 {
+				d.depCursor++
+				if d.depCursor >= len(d.task.Dependencies) {
+					d.depCursor = -1
+				}
+			}
+			return d, nil
+
+		case "a":
+			id := d.task.ID
+			return d, func() tea.Msg { return DependencyPickMsg{TaskID: id} }
+
+		case "x":
+			if d.depCursor < 0 || d.depCursor >= len(d.task.Dependencies) {
+				return d, nil
+			}
+			remove := DependencyRemoveMsg{TaskID: d.task.ID, Dependency: d.task.Dependencies[d.depCursor]}
+			return d, func() tea.Msg { return remove }
 		}
 	}
 
@@ -80,6 +221,35 @@ func (d *DetailPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the detail panel
 func (d *DetailPanel) View() string {
+	lines := d.layout()
+
+	// Apply scrolling
+	start := min(d.scrollY, d.maxScroll())
+	end := min(start+d.viewHeight, len(lines))
+	result := strings.Join(lines[start:end], "\n")
+
+	// Scroll indicator if needed
+	if d.maxScroll() > 0 {
+		scrollInfo := d.styles.Footer.Render(
+			fmt.Sprintf("[j/k scroll, ^d/^u half page, g/G jump] (line %d/%d)", start+1, d.contentHeight),
+		)
+		result += "\n" + scrollInfo
+	}
+
+	return result
+}
+
+// layout renders the panel's content wrapped to the overlay width, one
+// line per element, and counts the lines for scrolling
+func (d *DetailPanel) layout() []string {
+	content := strings.TrimSuffix(d.renderContent(), "\n")
+	lines := strings.Split(ansi.Wrap(content, d.wrapWidth, ""), "\n")
+	d.contentHeight = len(lines)
+	return lines
+}
+
+// renderContent renders everything the panel shows, before scrolling
+func (d *DetailPanel) renderContent() string {
 	var b strings.Builder
 
 	// Section style for headers
@@ -96,7 +266,33 @@ func (d *DetailPanel) View() string {
 
 	// Task ID and Title
 	b.WriteString(headerStyle.Render(fmt.Sprintf("[%s] %s", d.task.ID, d.task.Title)))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	// Refresh state
+	switch {
+	case d.loading:
+		b.WriteString(d.styles.Footer.Render(d.spinner.View() + " Refreshing..."))
+		b.WriteString("\n")
+	case d.gone:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#f38ba8")).Render("This bead no longer exists; it may have been deleted"))
+		b.WriteString("\n")
+	case d.loadErr != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#f9e2af")).Render(fmt.Sprintf("Couldn't refresh, showing the board's copy: %v", d.loadErr)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Unfinished blockers
+	if len(d.blockers) > 0 {
+		blockedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f38ba8"))
+		b.WriteString(blockedStyle.Render(fmt.Sprintf("⛔ Blocked by %d unfinished:", len(d.blockers))))
+		b.WriteString("\n")
+		for _, blocker := range d.blockers {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("  %s %s", blocker.ID, blocker.Title)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
 
 	// Status, Priority, Type
 	b.WriteString(labelStyle.Render("Status:"))
@@ -133,6 +329,30 @@ func (d *DetailPanel) View() string {
 	b.WriteString(valueStyle.Render(d.formatTime(d.task.UpdatedAt)))
 	b.WriteString("\n")
 
+	// Dependencies
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render("Dependencies"))
+	b.WriteString("\n")
+	if len(d.task.Dependencies) == 0 {
+		b.WriteString(d.styles.Footer.Render("  None"))
+		b.WriteString("\n")
+	}
+	for i, dep := range d.task.Dependencies {
+		line := fmt.Sprintf("  %s %s", storedDependencyLabel(dep.Type), dep.ID)
+		if i == d.depCursor {
+			b.WriteString(d.styles.MenuItemActive.Render("▶" + line[1:]))
+		} else {
+			b.WriteString(valueStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	hint := "a: add dependency"
+	if len(d.task.Dependencies) > 0 {
+		hint += " • Tab: select • x: remove selected"
+	}
+	b.WriteString(d.styles.Footer.Render("  " + hint))
+	b.WriteString("\n")
+
 	// Session info if present
 	if d.session != nil {
 		b.WriteString("\n")
@@ -141,7 +361,14 @@ func (d *DetailPanel) View() string {
 
 		b.WriteString(labelStyle.Render("State:"))
 		b.WriteString("  ")
-		b.WriteString(valueStyle.Render(fmt.Sprintf("%s %s", d.session.State.Icon(), string(d.session.State))))
+		state := fmt.Sprintf("%s %s", d.session.State.Icon(), string(d.session.State))
+		if d.session.State == domain.SessionBusy && d.session.BusyReason != "" {
+			state = fmt.Sprintf("%s %s (%s)", d.session.BusyReason.Icon(), string(d.session.State), string(d.session.BusyReason))
+		}
+		if d.session.State == domain.SessionBusy && d.session.Stalled {
+			state += ", no new output; may be stuck"
+		}
+		b.WriteString(valueStyle.Render(state))
 		b.WriteString("\n")
 
 		if d.session.StartedAt != nil {
@@ -165,6 +392,17 @@ func (d *DetailPanel) View() string {
 			b.WriteString("\n")
 		}
 
+		if d.session.Ahead > 0 || d.session.Behind > 0 {
+			branch := fmt.Sprintf("%d ahead, %d behind the base branch", d.session.Ahead, d.session.Behind)
+			if d.session.Behind > 0 {
+				branch += "; update from main (u in the action menu)"
+			}
+			b.WriteString(labelStyle.Render("Branch:"))
+			b.WriteString("  ")
+			b.WriteString(valueStyle.Render(branch))
+			b.WriteString("\n")
+		}
+
 		if d.session.DevServer != nil && d.session.DevServer.Running {
 			b.WriteString(labelStyle.Render("Dev Server:"))
 			b.WriteString("  ")
@@ -173,31 +411,29 @@ func (d *DetailPanel) View() string {
 		}
 	}
 
-	// Description section with scrolling
-	if d.task.Description != "" {
+	// Long text sections
+	sections := []struct{ header, text string }{
+		{"Description", d.task.Description},
+		{"Design", d.task.Design},
+		{"Acceptance Criteria", d.task.Acceptance},
+	}
+	for _, section := range sections {
+		if section.text == "" {
+			continue
+		}
 		b.WriteString("\n")
-		b.WriteString(headerStyle.Render("Description"))
+		b.WriteString(headerStyle.Render(section.header))
 		b.WriteString("\n")
-
-		// Split description into lines and apply scroll
-		descLines := strings.Split(d.task.Description, "\n")
-		d.contentHeight = len(descLines)
-
-		start := d.scrollY
-		end := min(d.scrollY+d.viewHeight, len(descLines))
-
-		for i := start; i < end; i++ {
-			b.WriteString(valueStyle.Render(descLines[i]))
-			b.WriteString("\n")
+		if d.markdown != nil {
+			if rendered, err := d.markdown.Render(section.text); err == nil {
+				b.WriteString(rendered)
+				b.WriteString("\n")
+				continue
+			}
 		}
-
-		// Scroll indicator if needed
-		if d.maxScroll() > 0 {
-			scrollInfo := d.styles.Footer.Render(
-				fmt.Sprintf("[j/k to scroll, g/G to jump] (line %d/%d)", d.scrollY+1, d.contentHeight),
-			)
+		for _, line := range strings.Split(section.text, "\n") {
+			b.WriteString(valueStyle.Render(line))
 			b.WriteString("\n")
-			b.WriteString(scrollInfo)
 		}
 	}
 
@@ -211,8 +447,22 @@ func (d *DetailPanel) Title() string {
 
 // Size returns the overlay dimensions
 func (d *DetailPanel) Size() (width, height int) {
-	d.viewHeight = 15 // Description viewing area
-	return 70, 30     // Total overlay size
+	return detailWidth, detailHeight
+}
+
+// storedDependencyLabel names a task's dependency from the task's side. bd
+// reports the related and parent types with its own spelling.
+func storedDependencyLabel(depType domain.DependencyType) string {
+	switch depType {
+	case domain.DependencyBlocks:
+		return "Blocked by"
+	case domain.DependencyRelatedTo, "related":
+		return "Related to"
+	case domain.DependencyParentChild, "parent-child":
+		return "Child of"
+	default:
+		return string(depType)
+	}
 }
 
 // formatStatus formats a status for display
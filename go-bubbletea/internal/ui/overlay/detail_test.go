@@ -1,11 +1,14 @@
 package overlay
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,7 +26,7 @@ func TestNewDetailPanel(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 	require.NotNil(t, panel)
 	assert.Equal(t, task.ID, panel.task.ID)
 	assert.Nil(t, panel.session)
@@ -32,14 +35,14 @@ func TestNewDetailPanel(t *testing.T) {
 
 func TestDetailPanelTitle(t *testing.T) {
 	task := domain.Task{ID: "test"}
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	assert.Equal(t, "Task Details", panel.Title())
 }
 
 func TestDetailPanelSize(t *testing.T) {
 	task := domain.Task{ID: "test"}
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	width, height := panel.Size()
 	assert.Equal(t, 70, width)
@@ -58,7 +61,7 @@ func TestDetailPanelView(t *testing.T) {
 		UpdatedAt:   time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC),
 	}
 
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 	view := panel.View()
 
 	// Check that key information is present
@@ -90,7 +93,7 @@ func TestDetailPanelViewWithSession(t *testing.T) {
 		},
 	}
 
-	panel := NewDetailPanel(task, session)
+	panel := NewDetailPanel(task, session, nil)
 	view := panel.View()
 
 	// Check session info is present
@@ -110,13 +113,26 @@ func TestDetailPanelViewWithParent(t *testing.T) {
 		Status:   domain.StatusOpen,
 	}
 
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 	view := panel.View()
 
 	assert.Contains(t, view, "Parent:")
 	assert.Contains(t, view, "az-parent")
 }
 
+func TestDetailPanelViewWithBlockers(t *testing.T) {
+	task := domain.Task{ID: "az-1", Title: "Login form", Status: domain.StatusOpen}
+
+	panel := NewDetailPanel(task, nil, nil)
+	assert.NotContains(t, panel.View(), "Blocked by")
+
+	panel.SetBlockers([]domain.Task{{ID: "az-2", Title: "Auth API"}})
+	view := panel.View()
+
+	assert.Contains(t, view, "Blocked by 1 unfinished")
+	assert.Contains(t, view, "az-2 Auth API")
+}
+
 func TestDetailPanelScrolling(t *testing.T) {
 	// Create a task with a long description
 	lines := make([]string, 50)
@@ -130,7 +146,7 @@ func TestDetailPanelScrolling(t *testing.T) {
 		Description: description,
 	}
 
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	// Initial scroll position should be 0
 	assert.Equal(t, 0, panel.scrollY)
@@ -163,13 +179,70 @@ func TestDetailPanelScrolling(t *testing.T) {
 	assert.Greater(t, panel.scrollY, 0)
 }
 
+func TestDetailPanelScrollsLongContent(t *testing.T) {
+	task := domain.Task{
+		ID:         "az-1",
+		Title:      "Long task",
+		Design:     strings.Repeat("design line\n", 30),
+		Acceptance: "Works",
+	}
+	panel := NewDetailPanel(task, nil, nil)
+
+	view := panel.View()
+	assert.Contains(t, view, "(line 1/")
+	assert.NotContains(t, view, "Acceptance Criteria", "Expected the end to be below the view")
+
+	// Every line past the view is reachable
+	assert.Equal(t, panel.contentHeight-panel.viewHeight, panel.maxScroll())
+	assert.Greater(t, panel.maxScroll(), 0)
+
+	m, _ := panel.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	panel = m.(*DetailPanel)
+	assert.Equal(t, panel.viewHeight/2, panel.scrollY)
+
+	m, _ = panel.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	panel = m.(*DetailPanel)
+	assert.Equal(t, 0, panel.scrollY)
+
+	for i := 0; i < 10; i++ {
+		m, _ = panel.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+		panel = m.(*DetailPanel)
+	}
+	assert.Equal(t, panel.maxScroll(), panel.scrollY)
+
+	view = panel.View()
+	assert.Contains(t, view, "Acceptance Criteria")
+	assert.Contains(t, view, "Works")
+	assert.Equal(t, panel.viewHeight+2, strings.Count(view, "\n")+1, "Expected a full view and the scroll indicator")
+}
+
+func TestDetailPanelWrapsToWidth(t *testing.T) {
+	task := domain.Task{
+		ID:          "az-1",
+		Description: strings.Repeat("word ", 40),
+	}
+	panel := NewDetailPanel(task, nil, nil)
+
+	for _, line := range strings.Split(panel.View(), "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), detailWrapWidth)
+	}
+	assert.Contains(t, panel.View(), "word word")
+}
+
+func TestDetailPanelShortContentDoesNotScroll(t *testing.T) {
+	panel := NewDetailPanel(domain.Task{ID: "az-1", Description: "Short"}, nil, nil)
+
+	assert.Equal(t, 0, panel.maxScroll())
+	assert.NotContains(t, panel.View(), "(line ")
+}
+
 func TestDetailPanelScrollLimits(t *testing.T) {
 	task := domain.Task{
 		ID:          "test",
 		Description: "Short description",
 	}
 
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	// Should not scroll below 0
 	m, _ := panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
@@ -186,7 +259,7 @@ func TestDetailPanelScrollLimits(t *testing.T) {
 
 func TestDetailPanelEscapeCloses(t *testing.T) {
 	task := domain.Task{ID: "test"}
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	// Test Esc key
 	_, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyEsc})
@@ -209,7 +282,7 @@ func TestDetailPanelEscapeCloses(t *testing.T) {
 
 func TestDetailPanelFormatStatus(t *testing.T) {
 	task := domain.Task{ID: "test"}
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	tests := []struct {
 		status   domain.Status
@@ -231,7 +304,7 @@ func TestDetailPanelFormatStatus(t *testing.T) {
 
 func TestDetailPanelFormatDuration(t *testing.T) {
 	task := domain.Task{ID: "test"}
-	panel := NewDetailPanel(task, nil)
+	panel := NewDetailPanel(task, nil, nil)
 
 	tests := []struct {
 		duration time.Duration
@@ -250,3 +323,74 @@ func TestDetailPanelFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+// stubGetter returns a fixed task or error
+type stubGetter struct {
+	task *domain.Task
+	err  error
+}
+
+func (g stubGetter) Get(ctx context.Context, id string) (*domain.Task, error) {
+	return g.task, g.err
+}
+
+// loadDetail runs the panel's refresh and delivers the result
+func loadDetail(t *testing.T, panel *DetailPanel) {
+	t.Helper()
+	require.True(t, panel.loading)
+	assert.Contains(t, panel.View(), "Refreshing")
+
+	var loaded *DetailLoadedMsg
+	for _, cmd := range panel.Init()().(tea.BatchMsg) {
+		if msg, ok := cmd().(DetailLoadedMsg); ok {
+			loaded = &msg
+		}
+	}
+	require.NotNil(t, loaded, "Expected Init to load the task")
+	panel.Update(*loaded)
+	assert.False(t, panel.loading)
+}
+
+func TestDetailPanel_Refresh(t *testing.T) {
+	stale := domain.Task{ID: "az-1", Title: "Old title", Description: "Old"}
+	fresh := domain.Task{ID: "az-1", Title: "New title", Description: "Edited elsewhere"}
+
+	panel := NewDetailPanel(stale, nil, stubGetter{task: &fresh})
+	loadDetail(t, panel)
+
+	view := panel.View()
+	assert.Contains(t, view, "New title")
+	assert.Contains(t, view, "Edited elsewhere")
+	assert.NotContains(t, view, "Refreshing")
+}
+
+func TestDetailPanel_RefreshErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"deleted", &domain.BeadsError{Op: "show", BeadID: "az-1", Message: "bead not found", Err: domain.ErrNotFound}, "no longer exists"},
+		{"failed", fmt.Errorf("bd timed out"), "Couldn't refresh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := domain.Task{ID: "az-1", Title: "Snapshot"}
+			panel := NewDetailPanel(task, nil, stubGetter{err: tt.err})
+			loadDetail(t, panel)
+
+			view := panel.View()
+			assert.Contains(t, view, tt.want)
+			assert.Contains(t, view, "Snapshot", "Expected the board's copy to stay")
+		})
+	}
+}
+
+func TestDetailPanel_IgnoresOtherTasks(t *testing.T) {
+	panel := NewDetailPanel(domain.Task{ID: "az-1", Title: "Mine"}, nil, stubGetter{})
+	panel.Update(DetailLoadedMsg{TaskID: "az-2", Task: &domain.Task{ID: "az-2", Title: "Other"}})
+
+	assert.True(t, panel.loading)
+	assert.NotContains(t, panel.View(), "Other")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,10 +35,27 @@ type DiagnosticsRefreshMsg struct {
 	Diagnostics *diagnostics.SystemDiagnostics
 }
 
+// DiagnosticsTickMsg is an auto-refresh tick. Ticks from a ticker that has
+// since been stopped or restarted carry a stale ID and are dropped.
+type DiagnosticsTickMsg struct {
+	id int64
+}
+
+// diagnosticsTickID hands out ticker IDs, unique across panels so a closed
+// panel's last tick can't drive a newly opened one
+var diagnosticsTickID atomic.Int64
+
+// Bounds of the auto-refresh interval, adjusted with + and -
+const (
+	minRefreshInterval = time.Second
+	maxRefreshInterval = time.Minute
+)
+
 // DiagnosticsPanel displays system diagnostics and health information
 type DiagnosticsPanel struct {
 	diagnosticsService DiagnosticsCollector
 	sessions           map[string]*domain.Session
+	beadsPath          string // Current project's .beads directory, checked when set
 	currentDiagnostics *diagnostics.SystemDiagnostics
 
 	// UI state
@@ -48,21 +66,32 @@ type DiagnosticsPanel struct {
 	styles        *Styles
 
 	// Auto-refresh
-	lastRefresh time.Time
+	lastRefresh     time.Time
+	autoRefresh     bool
+	refreshInterval time.Duration
+	nextRefresh     time.Time
+	tickID          int64 // ID of the running ticker's ticks
+	now             func() time.Time
 }
 
-// NewDiagnosticsPanel creates a new diagnostics panel
+// NewDiagnosticsPanel creates a new diagnostics panel that auto-refreshes,
+// once toggled on with a, every refreshInterval
 func NewDiagnosticsPanel(
 	diagService DiagnosticsCollector,
 	sessions map[string]*domain.Session,
+	beadsPath string,
+	refreshInterval time.Duration,
 ) *DiagnosticsPanel {
 	return &DiagnosticsPanel{
 		diagnosticsService: diagService,
 		sessions:           sessions,
+		beadsPath:          beadsPath,
 		activeSection:      SectionOverview,
 		scrollY:            0,
 		viewHeight:         20,
 		styles:             New(),
+		refreshInterval:    clampRefreshInterval(refreshInterval),
+		now:                time.Now,
 	}
 }
 
@@ -77,12 +106,27 @@ func (d *DiagnosticsPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc", "q":
+			// Stop the ticker so its pending tick is dropped
+			d.stopAutoRefresh()
 			return d, func() tea.Msg { return CloseOverlayMsg{} }
 
 		case "r":
 			// Manual refresh
 			return d, d.refreshCmd()
 
+		case "a":
+			if d.autoRefresh {
+				d.stopAutoRefresh()
+				return d, nil
+			}
+			return d, d.startAutoRefresh()
+
+		case "+", "=":
+			return d, d.setRefreshInterval(d.refreshInterval + time.Second)
+
+		case "-":
+			return d, d.setRefreshInterval(d.refreshInterval - time.Second)
+
 		case "j", "down":
 			if d.scrollY < d.maxScroll() {
 				d.scrollY++
@@ -146,6 +190,13 @@ func (d *DiagnosticsPanel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		d.currentDiagnostics = msg.Diagnostics
 		d.lastRefresh = time.Now()
 		return d, nil
+
+	case DiagnosticsTickMsg:
+		if !d.autoRefresh || msg.id != d.tickID {
+			return d, nil
+		}
+		d.nextRefresh = d.now().Add(d.refreshInterval)
+		return d, tea.Batch(d.refreshCmd(), d.tickCmd())
 	}
 
 	return d, nil
@@ -209,18 +260,70 @@ func (d *DiagnosticsPanel) Title() string {
 // Size returns the overlay dimensions
 func (d *DiagnosticsPanel) Size() (width, height int) {
 	d.viewHeight = 20
-	return 80, 28
+	return 80, 29
 }
 
 // refreshCmd returns a command to refresh diagnostics
 func (d *DiagnosticsPanel) refreshCmd() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		diag := d.diagnosticsService.CollectDiagnostics(ctx, d.sessions, nil)
+		var beadsPath *string
+		if d.beadsPath != "" {
+			beadsPath = &d.beadsPath
+		}
+		diag := d.diagnosticsService.CollectDiagnostics(ctx, d.sessions, beadsPath)
 		return DiagnosticsRefreshMsg{Diagnostics: diag}
 	}
 }
 
+// AutoRefresh reports whether the panel refreshes itself, and how often
+func (d *DiagnosticsPanel) AutoRefresh() (on bool, interval time.Duration) {
+	return d.autoRefresh, d.refreshInterval
+}
+
+// startAutoRefresh starts a new ticker, refreshing once per interval
+func (d *DiagnosticsPanel) startAutoRefresh() tea.Cmd {
+	d.autoRefresh = true
+	d.tickID = diagnosticsTickID.Add(1)
+	d.nextRefresh = d.now().Add(d.refreshInterval)
+	return d.tickCmd()
+}
+
+// stopAutoRefresh stops the ticker; its next tick no longer matches
+func (d *DiagnosticsPanel) stopAutoRefresh() {
+	d.autoRefresh = false
+	d.tickID = 0
+}
+
+// setRefreshInterval changes the interval within its bounds, restarting a
+// running ticker so the new interval applies from now
+func (d *DiagnosticsPanel) setRefreshInterval(interval time.Duration) tea.Cmd {
+	d.refreshInterval = clampRefreshInterval(interval)
+	if !d.autoRefresh {
+		return nil
+	}
+	return d.startAutoRefresh()
+}
+
+// clampRefreshInterval keeps interval within the bounds + and - allow
+func clampRefreshInterval(interval time.Duration) time.Duration {
+	if interval < minRefreshInterval {
+		return minRefreshInterval
+	}
+	if interval > maxRefreshInterval {
+		return maxRefreshInterval
+	}
+	return interval
+}
+
+// tickCmd schedules the running ticker's next tick
+func (d *DiagnosticsPanel) tickCmd() tea.Cmd {
+	id := d.tickID
+	return tea.Tick(d.refreshInterval, func(time.Time) tea.Msg {
+		return DiagnosticsTickMsg{id: id}
+	})
+}
+
 // Rendering helpers
 
 func (d *DiagnosticsPanel) renderOverview(b *strings.Builder) {
@@ -249,6 +352,18 @@ func (d *DiagnosticsPanel) renderOverview(b *strings.Builder) {
 	b.WriteString(statusStyle.Render(strings.ToUpper(string(diag.OverallState))))
 	b.WriteString("\n")
 
+	// Why it isn't healthy, one threshold crossed per line
+	for i, reason := range diag.Reasons {
+		label := ""
+		if i == 0 {
+			label = "Because:"
+		}
+		b.WriteString(labelStyle.Render(label))
+		b.WriteString("  ")
+		b.WriteString(lipgloss.NewStyle().Foreground(statusColor).Render(reason))
+		b.WriteString("\n")
+	}
+
 	b.WriteString(labelStyle.Render("Updated:"))
 	b.WriteString("  ")
 	b.WriteString(d.styles.MenuItem.Render(diag.Timestamp.Format("15:04:05")))
@@ -306,6 +421,13 @@ func (d *DiagnosticsPanel) renderOverview(b *strings.Builder) {
 	b.WriteString(d.styles.MenuItem.Render(networkStatus))
 	b.WriteString("\n")
 
+	if diag.BeadsPath != "" {
+		b.WriteString(labelStyle.Render("Beads:"))
+		b.WriteString("  ")
+		b.WriteString(d.styles.MenuItem.Render(diag.BeadsPath))
+		b.WriteString("\n")
+	}
+
 	// If everything is healthy, show success message
 	if diag.OverallState == diagnostics.HealthHealthy {
 		b.WriteString("\n")
@@ -388,9 +510,24 @@ func (d *DiagnosticsPanel) renderSessions(b *strings.Builder) {
 		Foreground(lipgloss.Color("#94e2d5")).
 		Bold(true)
 
-	b.WriteString(tableHeaderStyle.Render("  BEAD ID          STATE        UPTIME"))
+	// CPU and memory columns only when the process table could be read
+	hasMetrics := false
+	for _, session := range diag.Sessions {
+		if session.Metrics != nil {
+			hasMetrics = true
+			break
+		}
+	}
+
+	header := "  BEAD ID          STATE        UPTIME"
+	rule := "  ─────────────────────────────────────────"
+	if hasMetrics {
+		header = "  BEAD ID          STATE        UPTIME    CPU     MEM"
+		rule = "  ─────────────────────────────────────────────────────────"
+	}
+	b.WriteString(tableHeaderStyle.Render(header))
 	b.WriteString("\n")
-	b.WriteString(d.styles.MenuItem.Render("  ─────────────────────────────────────────"))
+	b.WriteString(d.styles.MenuItem.Render(rule))
 	b.WriteString("\n")
 
 	// Table rows
@@ -407,6 +544,21 @@ func (d *DiagnosticsPanel) renderSessions(b *strings.Builder) {
 			session.State,
 			uptimeStr,
 		)
+		if hasMetrics {
+			cpuStr, memStr := "-", "-"
+			if session.Metrics != nil {
+				cpuStr = fmt.Sprintf("%.1f%%", session.Metrics.CPU)
+				memStr = formatBytes(session.Metrics.RSS)
+			}
+			line = fmt.Sprintf("  %-16s %s %-7s  %-8s  %-6s  %s",
+				truncateDiagString(session.BeadID, 16),
+				stateIcon,
+				session.State,
+				uptimeStr,
+				cpuStr,
+				memStr,
+			)
+		}
 		b.WriteString(d.styles.MenuItem.Render(line))
 		b.WriteString("\n")
 
@@ -518,32 +670,37 @@ func (d *DiagnosticsPanel) renderNetwork(b *strings.Builder) {
 		b.WriteString("\n")
 	}
 
+	// Latency of the latest checks, with failed checks as gaps
+	if len(diag.Network.History) > 0 {
+		b.WriteString(labelStyle.Render("History:"))
+		b.WriteString("  ")
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#94e2d5")).Render(sparkline(diag.Network.History)))
+		b.WriteString("\n")
+
+		if lo, avg, hi, ok := diag.Network.LatencyStats(); ok {
+			b.WriteString(labelStyle.Render("Min/Avg/Max:"))
+			b.WriteString("  ")
+			b.WriteString(d.styles.MenuItem.Render(fmt.Sprintf("%dms / %dms / %dms", lo.Milliseconds(), avg.Milliseconds(), hi.Milliseconds())))
+			b.WriteString("\n")
+		}
+	}
+
 	// Network-dependent features
 	b.WriteString("\n")
 	b.WriteString(headerStyle.Render("NETWORK FEATURES"))
 	b.WriteString("\n\n")
 
-	features := []struct {
-		name     string
-		requires bool
-	}{
-		{"GitHub PR Creation", true},
-		{"Git Push/Pull", true},
-		{"Package Installation", true},
-		{"Claude API", true},
-	}
-
-	for _, feature := range features {
+	for _, feature := range diagnostics.NetworkFeatures {
 		icon := "✓"
 		color := lipgloss.Color("#a6e3a1")
 
-		if feature.requires && !diag.Network.IsOnline {
+		if feature.RequiresNetwork && !diag.Network.IsOnline {
 			icon = "✗"
 			color = lipgloss.Color("#6c7086")
 		}
 
 		featureStyle := lipgloss.NewStyle().Foreground(color)
-		b.WriteString(featureStyle.Render(fmt.Sprintf("  %s %s", icon, feature.name)))
+		b.WriteString(featureStyle.Render(fmt.Sprintf("  %s %s", icon, feature.Name)))
 		b.WriteString("\n")
 	}
 }
@@ -592,10 +749,10 @@ func (d *DiagnosticsPanel) renderSystem(b *strings.Builder) {
 
 func (d *DiagnosticsPanel) renderFooter() string {
 	hints := []string{
-		"[Tab] Switch section",
-		"[1-6] Jump to section",
+		"[Tab/1-6] Section",
 		"[j/k] Scroll",
 		"[r] Refresh",
+		"[a] Auto",
 		"[q/Esc] Close",
 	}
 
@@ -610,7 +767,16 @@ func (d *DiagnosticsPanel) renderFooter() string {
 		parts = append(parts, styledHint)
 	}
 
-	footer := hintStyle.Render(strings.Join(parts, "  "))
+	// Auto-refresh state; the interval is adjusted with + and -
+	auto := fmt.Sprintf("Auto-refresh off • every %s (+/-)", formatDuration(d.refreshInterval))
+	if d.autoRefresh {
+		next := d.nextRefresh.Sub(d.now()).Round(time.Second)
+		if next < 0 {
+			next = 0
+		}
+		auto = fmt.Sprintf("Auto-refresh on • every %s (+/-) • next in %s", formatDuration(d.refreshInterval), formatDuration(next))
+	}
+	footer := hintStyle.Render(auto) + "\n" + hintStyle.Render(strings.Join(parts, "  "))
 
 	// Add scroll indicator if needed
 	if d.maxScroll() > 0 {
@@ -671,6 +837,40 @@ func truncateDiagString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// sparkLevels are the bars a sparkline is drawn with, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws latencies as one bar each, scaled between the lowest and
+// highest of them. Negative latencies, failed checks, are drawn as dotted gaps.
+func sparkline(latencies []time.Duration) string {
+	lo, hi := time.Duration(-1), time.Duration(0)
+	for _, latency := range latencies {
+		if latency < 0 {
+			continue
+		}
+		if lo < 0 || latency < lo {
+			lo = latency
+		}
+		if latency > hi {
+			hi = latency
+		}
+	}
+
+	bars := make([]rune, len(latencies))
+	for i, latency := range latencies {
+		switch {
+		case latency < 0:
+			bars[i] = '·'
+		case hi == lo:
+			bars[i] = sparkLevels[0]
+		default:
+			top := time.Duration(len(sparkLevels) - 1)
+			bars[i] = sparkLevels[((latency-lo)*top+(hi-lo)/2)/(hi-lo)]
+		}
+	}
+	return string(bars)
+}
+
 func formatDuration(dur time.Duration) string {
 	if dur < time.Minute {
 		return fmt.Sprintf("%ds", int(dur.Seconds()))
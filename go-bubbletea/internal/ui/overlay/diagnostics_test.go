@@ -28,7 +28,7 @@ func TestNewDiagnosticsPanel(t *testing.T) {
 	}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 
 	if panel == nil {
 		t.Fatal("NewDiagnosticsPanel returned nil")
@@ -52,7 +52,7 @@ func TestDiagnosticsPanel_Init(t *testing.T) {
 	}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 	cmd := panel.Init()
 
 	if cmd == nil {
@@ -158,7 +158,7 @@ func TestDiagnosticsPanel_Update_KeyHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			panel := NewDiagnosticsPanel(mockService, sessions)
+			panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 			panel.activeSection = tt.initialSection
 			panel.currentDiagnostics = mockService.diagnostics
 
@@ -208,7 +208,7 @@ func TestDiagnosticsPanel_Update_Scrolling(t *testing.T) {
 	}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 	panel.currentDiagnostics = mockService.diagnostics
 	panel.contentHeight = 50
 	panel.scrollY = 0
@@ -292,7 +292,7 @@ func TestDiagnosticsPanel_Title(t *testing.T) {
 			}
 			sessions := make(map[string]*domain.Session)
 
-			panel := NewDiagnosticsPanel(mockService, sessions)
+			panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 			panel.activeSection = tt.section
 			panel.currentDiagnostics = mockService.diagnostics
 
@@ -332,7 +332,7 @@ func TestDiagnosticsPanel_View(t *testing.T) {
 	}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 	panel.currentDiagnostics = mockService.diagnostics
 
 	// Test each section renders without panic
@@ -357,11 +357,140 @@ func TestDiagnosticsPanel_View(t *testing.T) {
 	}
 }
 
+func TestDiagnosticsPanel_AutoRefreshToggle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	panel := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 5*time.Second)
+	panel.now = func() time.Time { return now }
+
+	_, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if cmd == nil {
+		t.Fatal("expected a toggled-on panel to start its ticker")
+	}
+	if on, interval := panel.AutoRefresh(); !on || interval != 5*time.Second {
+		t.Errorf("AutoRefresh() = %v, %v, want on every 5s", on, interval)
+	}
+	if want := now.Add(5 * time.Second); !panel.nextRefresh.Equal(want) {
+		t.Errorf("next refresh at %v, want %v", panel.nextRefresh, want)
+	}
+	if footer := panel.renderFooter(); !strings.Contains(footer, "Auto-refresh on • every 5s (+/-) • next in 5s") {
+		t.Errorf("footer missing auto-refresh state: %q", footer)
+	}
+
+	// The ticker's tick refreshes and schedules the next one
+	tick := DiagnosticsTickMsg{id: panel.tickID}
+	if _, cmd := panel.Update(tick); cmd == nil {
+		t.Error("expected a tick to refresh and re-arm")
+	}
+
+	// + lengthens the interval and restarts the ticker, dropping the old one
+	panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if _, interval := panel.AutoRefresh(); interval != 6*time.Second {
+		t.Errorf("interval after + = %v, want 6s", interval)
+	}
+	if _, cmd := panel.Update(tick); cmd != nil {
+		t.Error("expected the replaced ticker's tick to be dropped")
+	}
+
+	// Toggling off stops the ticker
+	if _, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}}); cmd != nil {
+		t.Error("expected no command when toggling off")
+	}
+	if _, cmd := panel.Update(DiagnosticsTickMsg{id: panel.tickID}); cmd != nil {
+		t.Error("expected ticks to be dropped once auto-refresh is off")
+	}
+	if footer := panel.renderFooter(); !strings.Contains(footer, "Auto-refresh off") {
+		t.Errorf("footer missing auto-refresh state: %q", footer)
+	}
+}
+
+func TestDiagnosticsPanel_AutoRefreshStopsOnClose(t *testing.T) {
+	panel := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 5*time.Second)
+	panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	tick := DiagnosticsTickMsg{id: panel.tickID}
+
+	_, cmd := panel.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(CloseOverlayMsg); !ok {
+		t.Fatal("expected esc to close the panel")
+	}
+	if on, _ := panel.AutoRefresh(); on {
+		t.Error("expected auto-refresh off once closed")
+	}
+	if _, cmd := panel.Update(tick); cmd != nil {
+		t.Error("expected the pending tick to be dropped after closing")
+	}
+
+	// Nor does it drive a panel opened afterwards
+	reopened := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 5*time.Second)
+	reopened.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if reopened.tickID == tick.id {
+		t.Error("expected a new panel's ticker to get a fresh ID")
+	}
+}
+
+func TestDiagnosticsPanel_RefreshIntervalBounds(t *testing.T) {
+	panel := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", time.Second)
+	panel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	if _, interval := panel.AutoRefresh(); interval != time.Second {
+		t.Errorf("interval = %v, want the 1s minimum", interval)
+	}
+
+	panel = NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 0)
+	if _, interval := panel.AutoRefresh(); interval != time.Second {
+		t.Errorf("interval = %v, want an unset interval raised to 1s", interval)
+	}
+}
+
+func TestDiagnosticsPanel_OverviewReasons(t *testing.T) {
+	panel := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 5*time.Second)
+	panel.currentDiagnostics = &diagnostics.SystemDiagnostics{
+		Timestamp:    time.Now(),
+		OverallState: diagnostics.HealthCritical,
+		Reasons:      []string{"Network is offline", "2 port conflicts, more than the 1 allowed"},
+	}
+
+	view := panel.View()
+	for _, want := range []string{"CRITICAL", "Because:", "Network is offline", "2 port conflicts, more than the 1 allowed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestDiagnosticsPanel_SessionMetrics(t *testing.T) {
+	sessionsWith := func(metrics *diagnostics.ProcessMetrics) *diagnostics.SystemDiagnostics {
+		return &diagnostics.SystemDiagnostics{
+			Timestamp:    time.Now(),
+			OverallState: diagnostics.HealthHealthy,
+			Sessions: []diagnostics.SessionInfo{
+				{BeadID: "az-1", State: domain.SessionBusy, Metrics: metrics},
+			},
+		}
+	}
+
+	panel := NewDiagnosticsPanel(&mockDiagnosticsService{}, map[string]*domain.Session{}, "", 5*time.Second)
+	panel.activeSection = SectionSessions
+
+	panel.currentDiagnostics = sessionsWith(&diagnostics.ProcessMetrics{CPU: 97.5, RSS: 420 * 1024 * 1024})
+	view := panel.View()
+	for _, want := range []string{"CPU", "MEM", "97.5%", "420.00 MB"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+
+	// Without metrics the columns are left out entirely
+	panel.currentDiagnostics = sessionsWith(nil)
+	view = panel.View()
+	if strings.Contains(view, "CPU") || strings.Contains(view, "MEM") {
+		t.Errorf("View() shows resource columns without metrics:\n%s", view)
+	}
+}
+
 func TestDiagnosticsPanel_Size(t *testing.T) {
 	mockService := &mockDiagnosticsService{}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 	width, height := panel.Size()
 
 	if width == 0 {
@@ -387,7 +516,7 @@ func TestDiagnosticsPanel_GetSectionName(t *testing.T) {
 
 	mockService := &mockDiagnosticsService{}
 	sessions := make(map[string]*domain.Session)
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -447,7 +576,7 @@ func TestDiagnosticsPanel_RefreshMessage(t *testing.T) {
 	}
 	sessions := make(map[string]*domain.Session)
 
-	panel := NewDiagnosticsPanel(mockService, sessions)
+	panel := NewDiagnosticsPanel(mockService, sessions, "", 5*time.Second)
 
 	// Simulate receiving a refresh message
 	msg := DiagnosticsRefreshMsg{
@@ -506,3 +635,51 @@ func TestFormatDuration_Overlay(t *testing.T) {
 		})
 	}
 }
+
+func TestSparkline(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		want      string
+	}{
+		{"empty", nil, ""},
+		{"steady", []time.Duration{40 * ms, 40 * ms, 40 * ms}, "▁▁▁"},
+		{"scaled between lowest and highest", []time.Duration{10 * ms, 80 * ms, 45 * ms, 20 * ms, 70 * ms}, "▁█▅▂▇"},
+		{"offline gaps", []time.Duration{10 * ms, -1, -1, 20 * ms}, "▁··█"},
+		{"all offline", []time.Duration{-1, -1}, "··"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.latencies); got != tt.want {
+				t.Errorf("sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsPanel_NetworkLatencyHistory(t *testing.T) {
+	ms := time.Millisecond
+	mockService := &mockDiagnosticsService{
+		diagnostics: &diagnostics.SystemDiagnostics{
+			OverallState: diagnostics.HealthHealthy,
+			Network: diagnostics.NetworkInfo{
+				IsOnline: true,
+				Latency:  30 * ms,
+				History:  []time.Duration{10 * ms, -1, 50 * ms, 30 * ms},
+			},
+		},
+	}
+	panel := NewDiagnosticsPanel(mockService, make(map[string]*domain.Session), "", 5*time.Second)
+	panel.currentDiagnostics = mockService.diagnostics
+	panel.activeSection = SectionNetwork
+
+	view := panel.View()
+	if !strings.Contains(view, "▁·█▅") {
+		t.Errorf("view missing latency sparkline:\n%s", view)
+	}
+	if !strings.Contains(view, "10ms / 30ms / 50ms") {
+		t.Errorf("view missing min/avg/max:\n%s", view)
+	}
+}
@@ -0,0 +1,137 @@
+package overlay
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+const (
+	diffSummaryWidth    = 70
+	diffSummaryMaxItems = 8  // Commits or files listed before "… and N more"
+	diffSummaryLineMax  = 62 // Columns for a commit or file line
+)
+
+// DiffSummaryOverlay shows a compact account of a session's work: the
+// commits on its branch and the size of its changes against base
+type DiffSummaryOverlay struct {
+	beadID  string
+	base    string
+	summary git.ChangeSummary
+	styles  *Styles
+}
+
+// NewDiffSummaryOverlay creates the summary of beadID's branch against base
+func NewDiffSummaryOverlay(beadID, base string, summary git.ChangeSummary) *DiffSummaryOverlay {
+	return &DiffSummaryOverlay{
+		beadID:  beadID,
+		base:    base,
+		summary: summary,
+		styles:  New(),
+	}
+}
+
+// Init initializes the overlay
+func (d *DiffSummaryOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (d *DiffSummaryOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc", "q", "enter":
+			return d, func() tea.Msg { return CloseOverlayMsg{} }
+		}
+	}
+	return d, nil
+}
+
+// counts returns the commit and file counts of the headline, e.g.
+// "3 commits ahead of main • 5 files changed"
+func (d *DiffSummaryOverlay) counts() string {
+	return fmt.Sprintf("%s ahead of %s • %s changed",
+		plural(len(d.summary.Commits), "commit"), d.base,
+		plural(d.summary.FilesChanged(), "file"))
+}
+
+// View renders the summary
+func (d *DiffSummaryOverlay) View() string {
+	var b strings.Builder
+
+	if len(d.summary.Commits) == 0 && d.summary.FilesChanged() == 0 {
+		b.WriteString(d.styles.MenuItemDisabled.Render("No changes against " + d.base))
+		b.WriteString("\n")
+		b.WriteString(d.styles.Footer.Render("Esc: close"))
+		return b.String()
+	}
+
+	insertions := lipgloss.NewStyle().Foreground(styles.Green).Render(fmt.Sprintf("+%d", d.summary.Insertions))
+	deletions := lipgloss.NewStyle().Foreground(styles.Red).Render(fmt.Sprintf("−%d", d.summary.Deletions))
+	b.WriteString(d.styles.MenuItem.Render(d.counts() + " • "))
+	b.WriteString(insertions + " " + deletions)
+	b.WriteString("\n")
+
+	if len(d.summary.Commits) > 0 {
+		b.WriteString("\n")
+		b.WriteString(d.styles.MenuHeader.Render("Commits"))
+		b.WriteString("\n")
+		hashStyle := lipgloss.NewStyle().Foreground(styles.Yellow)
+		for i, commit := range d.summary.Commits {
+			if i == diffSummaryMaxItems {
+				b.WriteString(d.styles.Footer.UnsetMarginTop().Render(fmt.Sprintf("  … and %d more", len(d.summary.Commits)-i)))
+				b.WriteString("\n")
+				break
+			}
+			subject := ansi.Truncate(commit.Subject, diffSummaryLineMax-len(commit.Hash)-1, "…")
+			b.WriteString("  " + hashStyle.Render(commit.Hash) + " " + d.styles.MenuItem.Render(subject))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(d.summary.Files) > 0 {
+		b.WriteString("\n")
+		b.WriteString(d.styles.MenuHeader.Render("Files"))
+		b.WriteString("\n")
+		for i, file := range d.summary.Files {
+			if i == diffSummaryMaxItems {
+				b.WriteString(d.styles.Footer.UnsetMarginTop().Render(fmt.Sprintf("  … and %d more", len(d.summary.Files)-i)))
+				b.WriteString("\n")
+				break
+			}
+			changes := fmt.Sprintf("%d", file.Changes)
+			if file.Binary {
+				changes = "bin"
+			}
+			path := ansi.Truncate(file.Path, diffSummaryLineMax-6, "…")
+			b.WriteString(fmt.Sprintf("  %-*s %5s", diffSummaryLineMax-6, path, changes))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(d.styles.Footer.Render("Space f: full diff • Esc: close"))
+	return b.String()
+}
+
+// Title returns the overlay title
+func (d *DiffSummaryOverlay) Title() string {
+	return "Changes in " + d.beadID
+}
+
+// Size returns the overlay dimensions
+func (d *DiffSummaryOverlay) Size() (width, height int) {
+	return diffSummaryWidth, lipgloss.Height(d.View()) + 4
+}
+
+// plural formats a count with its noun, e.g. "1 file" or "3 files"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
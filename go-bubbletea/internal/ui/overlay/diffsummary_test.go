@@ -0,0 +1,63 @@
+package overlay
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/riordanpawley/azedarach/internal/services/git"
+)
+
+func TestDiffSummaryOverlay_View(t *testing.T) {
+	d := NewDiffSummaryOverlay("az-1", "main", git.ChangeSummary{
+		Commits:    []git.Commit{{Hash: "a1b2c3d", Subject: "Add the thing"}},
+		Files:      []git.FileStat{{Path: "thing.go", Changes: 12}, {Path: "logo.png", Binary: true}},
+		Insertions: 10,
+		Deletions:  2,
+	})
+
+	view := ansi.Strip(d.View())
+	for _, want := range []string{"1 commit ahead of main • 2 files changed • +10 −2", "a1b2c3d Add the thing", "thing.go", "12", "bin"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestDiffSummaryOverlay_ElidesLongLists(t *testing.T) {
+	var summary git.ChangeSummary
+	for i := 0; i < diffSummaryMaxItems+3; i++ {
+		summary.Commits = append(summary.Commits, git.Commit{Hash: fmt.Sprintf("c%06d", i), Subject: "Commit"})
+	}
+	d := NewDiffSummaryOverlay("az-1", "main", summary)
+
+	view := ansi.Strip(d.View())
+	if !strings.Contains(view, "… and 3 more") {
+		t.Errorf("View() should elide the commits past %d:\n%s", diffSummaryMaxItems, view)
+	}
+	if strings.Contains(view, fmt.Sprintf("c%06d", diffSummaryMaxItems)) {
+		t.Errorf("View() shows a commit past the limit:\n%s", view)
+	}
+}
+
+func TestDiffSummaryOverlay_NoChanges(t *testing.T) {
+	d := NewDiffSummaryOverlay("az-1", "main", git.ChangeSummary{})
+
+	if view := ansi.Strip(d.View()); !strings.Contains(view, "No changes against main") {
+		t.Errorf("View() = %q, want a no changes note", view)
+	}
+}
+
+func TestDiffSummaryOverlay_EscCloses(t *testing.T) {
+	d := NewDiffSummaryOverlay("az-1", "main", git.ChangeSummary{})
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected a command")
+	}
+	if _, ok := cmd().(CloseOverlayMsg); !ok {
+		t.Errorf("Expected CloseOverlayMsg, got %#v", cmd())
+	}
+}
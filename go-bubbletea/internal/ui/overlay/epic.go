@@ -6,25 +6,41 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/riordanpawley/azedarach/internal/core/phases"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
+// epicWrapWidth is the drill-down's width less its padding
+const epicWrapWidth = 60 - 4
+
 // EpicDrillDown is an overlay that shows epic details with child tasks
 type EpicDrillDown struct {
-	epic     domain.Task
-	children []domain.Task
-	cursor   int
-	styles   *Styles
+	epic      domain.Task
+	children  []domain.Task
+	cursor    int
+	wrapWidth int
+	styles    *Styles
+	markdown  *markdownRenderer // Renders the description; nil shows it as written
 }
 
 // NewEpicDrillDown creates a new epic drill-down overlay
 func NewEpicDrillDown(epic domain.Task, children []domain.Task) *EpicDrillDown {
 	return &EpicDrillDown{
-		epic:     epic,
-		children: children,
-		cursor:   0,
-		styles:   New(),
+		epic:      epic,
+		children:  children,
+		cursor:    0,
+		wrapWidth: epicWrapWidth,
+		styles:    New(),
+	}
+}
+
+// SetMarkdown sets whether the epic's description is rendered as markdown
+func (e *EpicDrillDown) SetMarkdown(enabled bool) {
+	e.markdown = nil
+	if enabled {
+		e.markdown = newMarkdownRenderer(e.wrapWidth)
 	}
 }
 
@@ -36,6 +52,12 @@ func (e *EpicDrillDown) Init() tea.Cmd {
 // Update handles messages
 func (e *EpicDrillDown) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Narrow screens shrink the box, less its border and padding
+		e.wrapWidth = max(min(epicWrapWidth, msg.Width-6), 1)
+		e.SetMarkdown(e.markdown != nil)
+		return e, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "esc":
@@ -82,7 +104,20 @@ func (e *EpicDrillDown) View() string {
 	// Progress bar
 	progressBar := e.renderProgressBar()
 	b.WriteString(progressBar)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	// Per-phase counts of unfinished children
+	if phaseCounts := e.renderPhaseCounts(); phaseCounts != "" {
+		b.WriteString(phaseCounts)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Description
+	if description := e.renderDescription(); description != "" {
+		b.WriteString(description)
+		b.WriteString("\n\n")
+	}
 
 	// Child tasks
 	if len(e.children) == 0 {
@@ -113,14 +148,34 @@ func (e *EpicDrillDown) Title() string {
 // Size returns the overlay dimensions
 func (e *EpicDrillDown) Size() (width, height int) {
 	// Width: wide enough for task cards
-	// Height: header + progress + children + footer + padding
+	// Height: header + progress [+ phases] + children + footer + padding
 	height = 6 + len(e.children)
+	if e.renderPhaseCounts() != "" {
+		height++
+	}
 	if len(e.children) == 0 {
 		height = 8 // Minimum height for "no children" message
 	}
+	if description := e.renderDescription(); description != "" {
+		height += lipgloss.Height(description) + 1
+	}
 	return 60, height
 }
 
+// renderDescription renders the epic's description wrapped to the width,
+// as markdown when enabled
+func (e *EpicDrillDown) renderDescription() string {
+	if e.epic.Description == "" {
+		return ""
+	}
+	if e.markdown != nil {
+		if rendered, err := e.markdown.Render(e.epic.Description); err == nil {
+			return rendered
+		}
+	}
+	return ansi.Wrap(e.styles.MenuItem.Render(e.epic.Description), e.wrapWidth, "")
+}
+
 // renderProgressBar creates a visual progress bar for the epic
 func (e *EpicDrillDown) renderProgressBar() string {
 	total := len(e.children)
@@ -165,6 +220,43 @@ func (e *EpicDrillDown) renderProgressBar() string {
 	return progressStyle.Render(bar.String()) + e.styles.Footer.Render(stats)
 }
 
+// renderPhaseCounts summarizes unfinished children by dependency phase,
+// e.g. "Φ0: 2 • Φ1: 1". Completed children no longer block their siblings
+// and are left out. Returns "" when every child is done.
+func (e *EpicDrillDown) renderPhaseCounts() string {
+	childIDs := make(map[string]bool)
+	tasks := make(map[string]domain.Task)
+	for _, child := range e.children {
+		if child.Status == domain.StatusDone {
+			continue
+		}
+		childIDs[child.ID] = true
+		tasks[child.ID] = child
+	}
+	if len(childIDs) == 0 {
+		return ""
+	}
+
+	result := phases.ComputeDependencyPhases(childIDs, tasks)
+
+	parts := make([]string, 0, result.MaxPhase+1)
+	for phase := 0; phase <= result.MaxPhase; phase++ {
+		count := result.PhaseCounts[phase]
+		if count == 0 {
+			continue
+		}
+		// Phase 0 is ready (green), later phases are blocked (yellow)
+		style := lipgloss.NewStyle().Foreground(styles.Yellow)
+		if phase == 0 {
+			style = style.Foreground(styles.Green)
+		}
+		parts = append(parts, style.Render(fmt.Sprintf("Φ%d: %d", phase, count)))
+	}
+
+	separator := lipgloss.NewStyle().Foreground(styles.Overlay0).Render(" • ")
+	return strings.Join(parts, separator)
+}
+
 // renderChild renders a single child task
 func (e *EpicDrillDown) renderChild(child domain.Task, active bool) string {
 	var b strings.Builder
@@ -318,9 +318,9 @@ func TestEpicDrillDown_Size(t *testing.T) {
 		expectedHeight int
 	}{
 		{"no children", 0, 8},
-		{"one child", 1, 7},
-		{"three children", 3, 9},
-		{"ten children", 10, 16},
+		{"one child", 1, 8}, // open children add a phase summary line
+		{"three children", 3, 10},
+		{"ten children", 10, 17},
 	}
 
 	for _, tt := range tests {
@@ -346,3 +346,56 @@ func TestEpicDrillDown_Size(t *testing.T) {
 		})
 	}
 }
+
+func TestEpicDrillDown_PhaseCounts(t *testing.T) {
+	epic := domain.Task{ID: "az-1", Title: "Test Epic", Type: domain.TypeEpic}
+	blockedBy := func(id string) []domain.Dependency {
+		return []domain.Dependency{{ID: id, Type: domain.DependencyBlocks}}
+	}
+
+	tests := []struct {
+		name     string
+		children []domain.Task
+		want     string
+	}{
+		{
+			name:     "no children",
+			children: nil,
+			want:     "",
+		},
+		{
+			name: "all done",
+			children: []domain.Task{
+				{ID: "az-2", Status: domain.StatusDone},
+			},
+			want: "",
+		},
+		{
+			name: "chain of phases",
+			children: []domain.Task{
+				{ID: "az-2", Status: domain.StatusOpen},
+				{ID: "az-3", Status: domain.StatusOpen},
+				{ID: "az-4", Status: domain.StatusOpen, Dependencies: blockedBy("az-2")},
+			},
+			want: "Φ0: 2 • Φ1: 1",
+		},
+		{
+			name: "done blocker no longer counts",
+			children: []domain.Task{
+				{ID: "az-2", Status: domain.StatusDone},
+				{ID: "az-3", Status: domain.StatusInProgress, Dependencies: blockedBy("az-2")},
+			},
+			want: "Φ0: 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := NewEpicDrillDown(epic, tt.children)
+			got := overlay.renderPhaseCounts()
+			if got != tt.want {
+				t.Errorf("renderPhaseCounts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package overlay
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
@@ -17,22 +19,117 @@ const (
 	filterModePriority filterMode = "priority"
 	filterModeType     filterMode = "type"
 	filterModeSession  filterMode = "session"
+	filterModeAssignee filterMode = "assignee"
+	filterModeLabel    filterMode = "label"
 )
 
+// pickerRows is how many assignees or labels the menu lists at once
+const pickerRows = 8
+
+// builtinStatusKeys are the keys toggling bd's own statuses
+var builtinStatusKeys = map[domain.Status]string{
+	domain.StatusOpen:       "o",
+	domain.StatusInProgress: "i",
+	domain.StatusBlocked:    "b",
+	domain.StatusDone:       "d",
+}
+
+// statusOption is a status the menu filters by, with its key and label
+type statusOption struct {
+	key    string
+	label  string
+	status domain.Status
+}
+
 // FilterMenu is a menu overlay for task filtering
 type FilterMenu struct {
-	filter *domain.Filter
-	styles *Styles
-	mode   filterMode
+	filter   *domain.Filter
+	styles   *Styles
+	mode     filterMode
+	statuses []statusOption
+
+	// Assignees ("" for unassigned) and labels to pick from, and the cursor
+	// in whichever list is being picked
+	assignees []string
+	labels    []string
+	cursor    int
+	offset    int
 }
 
 // NewFilterMenu creates a new filter menu for the given filter
 func NewFilterMenu(filter *domain.Filter) *FilterMenu {
+	return NewFilterMenuWithTasks(filter, nil)
+}
+
+// NewFilterMenuWithTasks creates a filter menu offering the assignees and
+// labels found on tasks, along with any the filter already selects
+func NewFilterMenuWithTasks(filter *domain.Filter, tasks []domain.Task) *FilterMenu {
+	var assignees, labels []string
+	for _, task := range tasks {
+		assignees = append(assignees, task.Assignee)
+		labels = append(labels, task.Labels...)
+	}
+	for assignee := range filter.Assignee {
+		assignees = append(assignees, assignee)
+	}
+	for label := range filter.Labels {
+		labels = append(labels, label)
+	}
+
+	// Sorted, so unassigned ("") comes first
+	slices.Sort(assignees)
+	slices.Sort(labels)
+
 	return &FilterMenu{
-		filter: filter,
-		styles: New(),
-		mode:   filterModeNormal,
+		filter:    filter,
+		styles:    New(),
+		mode:      filterModeNormal,
+		assignees: slices.Compact(assignees),
+		labels:    slices.Compact(labels),
+		statuses:  statusOptions(config.DefaultBoardColumns),
+	}
+}
+
+// SetStatusColumns offers the statuses of the board's columns, custom ones
+// included, instead of bd's four
+func (m *FilterMenu) SetStatusColumns(columns []config.ColumnConfig) {
+	m.statuses = statusOptions(columns)
+}
+
+// statusOptions keys each column's status: bd's own keep their letters and
+// a custom status takes the first free letter of its title, or else a digit
+func statusOptions(columns []config.ColumnConfig) []statusOption {
+	taken := make(map[string]bool)
+	for _, key := range builtinStatusKeys {
+		taken[key] = true
+	}
+
+	options := make([]statusOption, 0, len(columns))
+	for _, col := range columns {
+		status := domain.Status(col.Status)
+		key, ok := builtinStatusKeys[status]
+		if !ok {
+			key = freeStatusKey(col.Title, taken)
+			taken[key] = true
+		}
+		options = append(options, statusOption{key: key, label: col.Title, status: status})
+	}
+	return options
+}
+
+// freeStatusKey returns the first letter of title, or digit, not yet taken
+func freeStatusKey(title string, taken map[string]bool) string {
+	for _, r := range strings.ToLower(title) {
+		if key := string(r); r >= 'a' && r <= 'z' && !taken[key] {
+			return key
+		}
+	}
+	for r := '1'; r <= '9'; r++ {
+		if key := string(r); !taken[key] {
+			return key
+		}
 	}
+	return ""
 }
 
 // Init initializes the menu
@@ -55,6 +152,8 @@ func (m *FilterMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleTypeMode(msg)
 		case filterModeSession:
 			return m.handleSessionMode(msg)
+		case filterModeAssignee, filterModeLabel:
+			return m.handlePickerMode(msg)
 		}
 	}
 
@@ -83,10 +182,28 @@ func (m *FilterMenu) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.mode = filterModeSession
 		return m, nil
 
+	case "u":
+		m.mode = filterModeAssignee
+		m.cursor, m.offset = 0, 0
+		return m, nil
+
+	case "l":
+		m.mode = filterModeLabel
+		m.cursor, m.offset = 0, 0
+		return m, nil
+
+	case "m":
+		m.filter.ToggleMatchMode()
+		return m, nil
+
 	case "e":
 		m.filter.HideEpicChildren = !m.filter.HideEpicChildren
 		return m, nil
 
+	case "a":
+		m.filter.HasAttachments = !m.filter.HasAttachments
+		return m, nil
+
 	case "1":
 		days := 1
 		m.filter.AgeMaxDays = &days
@@ -116,32 +233,18 @@ func (m *FilterMenu) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleStatusMode handles keys in status selection mode
 func (m *FilterMenu) handleStatusMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.mode = filterModeNormal
-		return m, nil
-
-	case "o":
-		m.filter.ToggleStatus(domain.StatusOpen)
-		m.mode = filterModeNormal
-		return m, nil
-
-	case "i":
-		m.filter.ToggleStatus(domain.StatusInProgress)
-		m.mode = filterModeNormal
-		return m, nil
-
-	case "b":
-		m.filter.ToggleStatus(domain.StatusBlocked)
-		m.mode = filterModeNormal
-		return m, nil
-
-	case "d":
-		m.filter.ToggleStatus(domain.StatusDone)
+	if msg.String() == "esc" {
 		m.mode = filterModeNormal
 		return m, nil
 	}
 
+	for _, opt := range m.statuses {
+		if opt.key != "" && msg.String() == opt.key {
+			m.filter.ToggleStatus(opt.status)
+			m.mode = filterModeNormal
+			return m, nil
+		}
+	}
 	return m, nil
 }
 
@@ -258,17 +361,63 @@ func (m *FilterMenu) handleSessionMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handlePickerMode handles keys while picking assignees or labels. Unlike
+// the fixed categories several can be toggled before returning.
+func (m *FilterMenu) handlePickerMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.pickerItems()
+
+	switch msg.String() {
+	case "esc", "enter":
+		m.mode = filterModeNormal
+		return m, nil
+
+	case "j", "down":
+		if m.cursor < len(items)-1 {
+			m.cursor++
+		}
+
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case " ", "x":
+		if m.cursor < len(items) {
+			if m.mode == filterModeAssignee {
+				m.filter.ToggleAssignee(items[m.cursor])
+			} else {
+				m.filter.ToggleLabel(items[m.cursor])
+			}
+		}
+	}
+
+	// Keep the cursor in view
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+pickerRows {
+		m.offset = m.cursor - pickerRows + 1
+	}
+	return m, nil
+}
+
+// pickerItems returns the assignees or labels being picked from
+func (m *FilterMenu) pickerItems() []string {
+	if m.mode == filterModeAssignee {
+		return m.assignees
+	}
+	return m.labels
+}
+
 // View renders the menu
 func (m *FilterMenu) View() string {
 	var b strings.Builder
 
 	// Status filter line
-	b.WriteString(m.renderFilterLine("Status", "s", []filterOption{
-		{key: "o", label: "Open", active: m.filter.Status[domain.StatusOpen]},
-		{key: "i", label: "In Progress", active: m.filter.Status[domain.StatusInProgress]},
-		{key: "b", label: "Blocked", active: m.filter.Status[domain.StatusBlocked]},
-		{key: "d", label: "Done", active: m.filter.Status[domain.StatusDone]},
-	}, m.mode == filterModeStatus))
+	statusOptions := make([]filterOption, 0, len(m.statuses))
+	for _, opt := range m.statuses {
+		statusOptions = append(statusOptions, filterOption{key: opt.key, label: opt.label, active: m.filter.Status[opt.status]})
+	}
+	b.WriteString(m.renderFilterLine("Status", "s", statusOptions, m.mode == filterModeStatus))
 
 	// Priority filter line
 	b.WriteString(m.renderFilterLine("Priority", "p", []filterOption{
@@ -298,6 +447,24 @@ func (m *FilterMenu) View() string {
 		{key: "P", label: "Paused", active: m.filter.SessionState[domain.SessionPaused]},
 	}, m.mode == filterModeSession))
 
+	// Assignee and label lines, with the list being picked from beneath
+	b.WriteString(m.renderPickerLine("Assignee", "u", m.filter.Assignee, m.mode == filterModeAssignee))
+	if m.mode == filterModeAssignee {
+		b.WriteString(m.renderPicker(m.assignees, m.filter.Assignee))
+	}
+	b.WriteString(m.renderPickerLine("Labels", "l", m.filter.Labels, m.mode == filterModeLabel))
+	if m.mode == filterModeLabel {
+		b.WriteString(m.renderPicker(m.labels, m.filter.Labels))
+	}
+
+	// How the categories above combine
+	match := "all categories"
+	if m.filter.MatchMode == domain.MatchAnyOf {
+		match = "any category"
+	}
+	b.WriteString(m.styles.MenuKey.Render("[m]") + " " +
+		m.styles.MenuItem.Render("Match:") + " " + m.styles.MenuItemActive.Render(match) + "\n")
+
 	// Separator
 	b.WriteString(m.styles.Separator.Render("───────────────────────────────────────"))
 	b.WriteString("\n")
@@ -312,6 +479,16 @@ func (m *FilterMenu) View() string {
 	b.WriteString(line)
 	b.WriteString("\n")
 
+	// Has attachments checkbox
+	checkbox = "[ ]"
+	if m.filter.HasAttachments {
+		checkbox = "[●]"
+	}
+	line = m.styles.MenuKey.Render("[a]") + " " +
+		m.styles.MenuItem.Render(checkbox+" Has attachments")
+	b.WriteString(line)
+	b.WriteString("\n")
+
 	// Separator
 	b.WriteString(m.styles.Separator.Render("───────────────────────────────────────"))
 	b.WriteString("\n")
@@ -330,7 +507,11 @@ func (m *FilterMenu) View() string {
 	b.WriteString("\n")
 
 	// Footer hint based on mode
-	if m.mode != filterModeNormal {
+	if m.mode == filterModeAssignee || m.mode == filterModeLabel {
+		hint := m.styles.Footer.Render("j/k: Move • Space: Toggle • Enter/Esc: Done")
+		b.WriteString("\n")
+		b.WriteString(hint)
+	} else if m.mode != filterModeNormal {
 		hint := m.styles.Footer.Render("Press key to toggle filter, Esc to cancel")
 		b.WriteString("\n")
 		b.WriteString(hint)
@@ -381,6 +562,61 @@ func (m *FilterMenu) renderFilterLine(category string, categoryKey string, optio
 	return b.String()
 }
 
+// renderPickerLine renders an assignee or label category line, listing the
+// selected values
+func (m *FilterMenu) renderPickerLine(category string, categoryKey string, selected map[string]bool, selecting bool) string {
+	keyStyle := m.styles.MenuKey
+	if selecting {
+		keyStyle = m.styles.MenuItemActive
+	}
+
+	summary := m.styles.MenuItem.Render("any")
+	if len(selected) > 0 {
+		names := make([]string, 0, len(selected))
+		for name := range selected {
+			names = append(names, pickerLabel(name))
+		}
+		slices.Sort(names)
+		summary = m.styles.MenuItemActive.Render(strings.Join(names, ", "))
+	}
+
+	return keyStyle.Render(fmt.Sprintf("[%s]", categoryKey)) + " " +
+		m.styles.MenuItem.Render(category+":") + " " + summary + "\n"
+}
+
+// renderPicker renders the visible window of items, checked where selected
+func (m *FilterMenu) renderPicker(items []string, selected map[string]bool) string {
+	if len(items) == 0 {
+		return m.styles.Footer.Render("    (none on the board)") + "\n"
+	}
+
+	var b strings.Builder
+	end := min(m.offset+pickerRows, len(items))
+	for i := m.offset; i < end; i++ {
+		checkbox := "[ ]"
+		if selected[items[i]] {
+			checkbox = "[●]"
+		}
+		style := m.styles.MenuItem
+		prefix := "    "
+		if i == m.cursor {
+			style = m.styles.MenuItemActive
+			prefix = "  ▸ "
+		}
+		b.WriteString(style.Render(prefix + checkbox + " " + pickerLabel(items[i])))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// pickerLabel is how an assignee or label is shown; "" is unassigned
+func pickerLabel(name string) string {
+	if name == "" {
+		return "(unassigned)"
+	}
+	return name
+}
+
 // renderAgeFilter renders the age filter line
 func (m *FilterMenu) renderAgeFilter() string {
 	var b strings.Builder
@@ -434,8 +670,13 @@ func (m *FilterMenu) Title() string {
 // Size returns the overlay dimensions
 func (m *FilterMenu) Size() (width, height int) {
 	// Width: enough for filter options
-	// Height: 4 filter lines + 1 checkbox + 1 age + 1 clear + separators + padding
-	return 56, 14
+	// Height: 6 filter lines + match + 2 checkboxes + 1 age + 1 clear + separators + padding
+	height = 18
+	if m.mode == filterModeAssignee || m.mode == filterModeLabel {
+		// The list being picked from and its hint
+		height += max(min(len(m.pickerItems()), pickerRows), 1) + 2
+	}
+	return 56, height
 }
 
 // intPtr returns a pointer to an int
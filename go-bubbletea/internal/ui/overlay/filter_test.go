@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/config"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
@@ -301,6 +302,105 @@ func TestFilterMenu_SessionStateToggle(t *testing.T) {
 	}
 }
 
+// labelledTasks has alice's and bob's labelled tasks and an unassigned one
+var labelledTasks = []domain.Task{
+	{ID: "az-1", Assignee: "bob", Labels: []string{"backend", "urgent"}},
+	{ID: "az-2", Assignee: "alice", Labels: []string{"frontend"}},
+	{ID: "az-3", Labels: []string{"backend"}},
+}
+
+func TestNewFilterMenuWithTasks_Choices(t *testing.T) {
+	filter := domain.NewFilter()
+	filter.ToggleLabel("stale") // Selected before its last task lost it
+
+	menu := NewFilterMenuWithTasks(filter, labelledTasks)
+
+	if got := strings.Join(menu.assignees, ","); got != ",alice,bob" {
+		t.Errorf("assignees = %q, want unassigned, alice, bob", got)
+	}
+	if got := strings.Join(menu.labels, ","); got != "backend,frontend,stale,urgent" {
+		t.Errorf("labels = %q, want each label once, sorted", got)
+	}
+}
+
+func TestFilterMenu_AssigneeToggle(t *testing.T) {
+	filter := domain.NewFilter()
+	menu := NewFilterMenuWithTasks(filter, labelledTasks)
+
+	model, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	menu = model.(*FilterMenu)
+	if menu.mode != filterModeAssignee {
+		t.Fatal("Should enter assignee mode on 'u' key")
+	}
+
+	// Down to alice and toggle her
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if !filter.Assignee["alice"] || len(filter.Assignee) != 1 {
+		t.Errorf("Expected alice selected, got %v", filter.Assignee)
+	}
+	if !strings.Contains(menu.View(), "[●] alice") {
+		t.Error("View should check alice in the picker")
+	}
+
+	menu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if menu.mode != filterModeNormal {
+		t.Error("Should return to normal mode on Enter")
+	}
+	if !strings.Contains(menu.View(), "Assignee: alice") {
+		t.Error("View should list alice as the selected assignee")
+	}
+}
+
+func TestFilterMenu_LabelMultiSelect(t *testing.T) {
+	filter := domain.NewFilter()
+	menu := NewFilterMenuWithTasks(filter, labelledTasks)
+
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if menu.mode != filterModeLabel {
+		t.Fatal("Should enter label mode on 'l' key")
+	}
+
+	// backend, then down twice to urgent; both stay selected
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if menu.mode != filterModeLabel {
+		t.Error("Should stay in label mode while toggling")
+	}
+	if !filter.Labels["backend"] || !filter.Labels["urgent"] || len(filter.Labels) != 2 {
+		t.Errorf("Expected backend and urgent selected, got %v", filter.Labels)
+	}
+
+	// Toggling again deselects
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if filter.Labels["urgent"] {
+		t.Error("Second toggle should deselect urgent")
+	}
+
+	menu.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if menu.mode != filterModeNormal {
+		t.Error("Should return to normal mode on Esc")
+	}
+	if !strings.Contains(menu.View(), "Labels: backend") {
+		t.Error("View should list backend as a selected label")
+	}
+}
+
+func TestFilterMenu_PickerWithoutChoices(t *testing.T) {
+	menu := NewFilterMenu(domain.NewFilter())
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	if !strings.Contains(menu.View(), "(none on the board)") {
+		t.Error("View should say there are no labels to pick")
+	}
+	if menu.filter.IsActive() {
+		t.Error("Toggling with nothing to pick should leave the filter inactive")
+	}
+}
+
 func TestFilterMenu_HideEpicChildrenToggle(t *testing.T) {
 	filter := domain.NewFilter()
 	menu := NewFilterMenu(filter)
@@ -324,6 +424,50 @@ func TestFilterMenu_HideEpicChildrenToggle(t *testing.T) {
 	}
 }
 
+func TestFilterMenu_MatchModeToggle(t *testing.T) {
+	filter := domain.NewFilter()
+	menu := NewFilterMenu(filter)
+
+	if !strings.Contains(menu.View(), "Match: all categories") {
+		t.Error("View should start matching all categories")
+	}
+
+	model, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	menu = model.(*FilterMenu)
+	if filter.MatchMode != domain.MatchAnyOf {
+		t.Errorf("Should toggle MatchMode to any, got %v", filter.MatchMode)
+	}
+	if !strings.Contains(menu.View(), "Match: any category") {
+		t.Error("View should show matching any category")
+	}
+
+	model, _ = menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	menu = model.(*FilterMenu)
+	if filter.MatchMode != domain.MatchAllOf {
+		t.Errorf("Should toggle MatchMode back to all, got %v", filter.MatchMode)
+	}
+}
+
+func TestFilterMenu_HasAttachmentsToggle(t *testing.T) {
+	filter := domain.NewFilter()
+	menu := NewFilterMenu(filter)
+
+	model, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	menu = model.(*FilterMenu)
+	if !filter.HasAttachments {
+		t.Error("Should toggle HasAttachments to true")
+	}
+	if !strings.Contains(menu.View(), "[●] Has attachments") {
+		t.Error("View should show the checked Has attachments box")
+	}
+
+	model, _ = menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	menu = model.(*FilterMenu)
+	if filter.HasAttachments {
+		t.Error("Should toggle HasAttachments to false")
+	}
+}
+
 func TestFilterMenu_AgeFilter(t *testing.T) {
 	filter := domain.NewFilter()
 	menu := NewFilterMenu(filter)
@@ -510,3 +654,27 @@ func TestFilterMenu_ImplementsOverlayInterface(t *testing.T) {
 func TestFilterMenu_ImplementsTeaModel(t *testing.T) {
 	var _ tea.Model = (*FilterMenu)(nil)
 }
+
+func TestFilterMenu_CustomStatuses(t *testing.T) {
+	filter := domain.NewFilter()
+	menu := NewFilterMenu(filter)
+	menu.SetStatusColumns([]config.ColumnConfig{
+		{Title: "Open", Status: "open"},
+		{Title: "Review", Status: "review", Base: "in_progress"},
+		{Title: "Done", Status: "closed"},
+	})
+
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if !filter.Status["review"] {
+		t.Errorf("Expected r to toggle the review status, got %v", filter.Status)
+	}
+
+	view := menu.View()
+	if !strings.Contains(view, "r=Review") {
+		t.Errorf("Expected the custom status offered, got:\n%s", view)
+	}
+	if strings.Contains(view, "Blocked") {
+		t.Errorf("Expected only the board's statuses offered, got:\n%s", view)
+	}
+}
@@ -148,11 +148,18 @@ func (h *HelpOverlay) Size() (width, height int) {
 
 // getCategories returns all keybinding categories
 func (h *HelpOverlay) getCategories() []KeyCategory {
+	return KeyCategories()
+}
+
+// KeyCategories returns the keymap grouped by category. It is the single
+// source for the help overlay and the exported cheat sheet.
+func KeyCategories() []KeyCategory {
 	return []KeyCategory{
 		{
 			Name: "Navigation",
 			Bindings: []KeyBinding{
 				{Key: "h/l", Description: "Move between columns"},
+				{Key: "H/L", Description: "Move to next column with tasks"},
 				{Key: "j/k", Description: "Move up/down in column"},
 				{Key: "gg", Description: "Jump to top of column"},
 				{Key: "ge", Description: "Jump to bottom of column"},
@@ -165,6 +172,16 @@ func (h *HelpOverlay) getCategories() []KeyCategory {
 			Bindings: []KeyBinding{
 				{Key: "Space", Description: "Open action menu"},
 				{Key: "Enter", Description: "Show task details"},
+				{Key: "Ctrl+Z", Description: "Undo last move or delete"},
+				{Key: "z", Description: "Collapse/expand Done column"},
+				{Key: "Z", Description: "Focus mode: one column, full width"},
+				{Key: "A", Description: "Archive of completed tasks"},
+				{Key: "P", Description: "Plan a feature with AI (needs ANTHROPIC_API_KEY)"},
+				{Key: "E", Description: "Export filtered board as markdown"},
+				{Key: "N", Description: "Notification center (recent toasts)"},
+				{Key: "x", Description: "Dismiss the newest toast"},
+				{Key: "Click", Description: "Select card, again for action menu"},
+				{Key: "Wheel", Description: "Scroll column under pointer"},
 			},
 		},
 		{
@@ -172,6 +189,7 @@ func (h *HelpOverlay) getCategories() []KeyCategory {
 			Bindings: []KeyBinding{
 				{Key: "/", Description: "Search"},
 				{Key: "f", Description: "Filter menu"},
+				{Key: "!", Description: "Toggle waiting/errored sessions filter"},
 				{Key: ",", Description: "Sort menu"},
 				{Key: "v", Description: "Select mode"},
 				{Key: "?", Description: "Help (this screen)"},
@@ -180,9 +198,12 @@ func (h *HelpOverlay) getCategories() []KeyCategory {
 		{
 			Name: "Selection",
 			Bindings: []KeyBinding{
-				{Key: "v", Description: "Toggle selection on current task"},
-				{Key: "%", Description: "Select all"},
-				{Key: "A", Description: "Clear selection"},
+				{Key: "Space", Description: "Toggle current task (select mode)"},
+				{Key: "j/k", Description: "Extend selection up/down"},
+				{Key: "a", Description: "Select all in column"},
+				{Key: "A", Description: "Select all visible"},
+				{Key: "x", Description: "Clear selection"},
+				{Key: "Enter", Description: "Bulk actions on selection"},
 			},
 		},
 		{
@@ -191,6 +212,7 @@ func (h *HelpOverlay) getCategories() []KeyCategory {
 				{Key: "Tab", Description: "Toggle compact/kanban view"},
 				{Key: "q", Description: "Quit"},
 				{Key: "Ctrl+L", Description: "Refresh screen"},
+				{Key: "Ctrl+R", Description: "Reload beads now"},
 			},
 		},
 	}
@@ -30,6 +30,7 @@ type ImageAttachOverlay struct {
 	cursor      int
 	pathInput   textinput.Model
 	inputActive bool
+	confirm     *ConfirmDialog // Delete confirmation, while open
 	error       string
 	styles      *Styles
 }
@@ -77,6 +78,13 @@ func (i *ImageAttachOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if i.inputActive {
 			return i.handleInputMode(msg)
 		}
+		if i.confirm != nil {
+			_, cmd := i.confirm.Update(msg)
+			if i.confirm.Answered() {
+				i.confirm = nil
+			}
+			return i, cmd
+		}
 
 		switch msg.String() {
 		case "esc", "q":
@@ -117,9 +125,9 @@ func (i *ImageAttachOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return i, nil
 
 		case "d":
-			// Delete attachment
+			// Delete attachment, once confirmed
 			if i.mode == imageAttachModeList && len(i.files) > 0 {
-				return i, i.deleteAttachment()
+				i.confirm = i.deleteConfirmation()
 			}
 			return i, nil
 
@@ -201,6 +209,9 @@ func (i *ImageAttachOverlay) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd
 
 // View renders the overlay
 func (i *ImageAttachOverlay) View() string {
+	if i.confirm != nil {
+		return i.styles.Title.Render(i.confirm.Title()) + "\n\n" + i.confirm.View()
+	}
 	if i.inputActive {
 		return i.renderFileInput()
 	}
@@ -436,6 +447,13 @@ func (i *ImageAttachOverlay) attachFromFile(path string) tea.Cmd {
 	}
 }
 
+// deleteConfirmation asks before deleting the selected attachment
+func (i *ImageAttachOverlay) deleteConfirmation() *ConfirmDialog {
+	file := i.files[i.cursor]
+	message := fmt.Sprintf("Delete image: %s?\n\nThis action cannot be undone.", file.Filename)
+	return NewConfirmDialogWithActions("⚠ Confirm Delete", message, i.deleteAttachment(), nil)
+}
+
 func (i *ImageAttachOverlay) deleteAttachment() tea.Cmd {
 	if i.cursor >= len(i.files) {
 		return nil
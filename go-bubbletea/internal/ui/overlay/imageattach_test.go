@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -372,3 +373,48 @@ func TestImageAttachOverlay_Integration(t *testing.T) {
 		t.Error("expected input mode to be inactive")
 	}
 }
+
+func TestImageAttachOverlay_DeleteAsksFirst(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := attachment.NewService(tmpDir, logger)
+
+	overlay := NewImageAttachOverlay("az-123", service)
+	overlay.Update(attachmentsLoadedMsg{attachments: []attachment.Attachment{
+		{ID: "img-1", Filename: "screenshot.png"},
+	}})
+
+	// d asks rather than deleting
+	if _, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}}); cmd != nil {
+		t.Fatal("expected d not to delete before confirming")
+	}
+	if overlay.confirm == nil {
+		t.Fatal("expected a delete confirmation")
+	}
+	if view := overlay.View(); !strings.Contains(view, "screenshot.png") {
+		t.Errorf("expected the confirmation to name the image, got:\n%s", view)
+	}
+
+	// n closes it without deleting
+	if _, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}); cmd != nil {
+		t.Error("expected n not to delete")
+	}
+	if overlay.confirm != nil {
+		t.Error("expected n to close the confirmation")
+	}
+
+	// y closes it and deletes
+	overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	_, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if overlay.confirm != nil {
+		t.Error("expected y to close the confirmation")
+	}
+	if cmd == nil {
+		t.Fatal("expected y to delete the attachment")
+	}
+	switch msg := cmd().(type) {
+	case attachmentDeletedMsg, errorMsg:
+	default:
+		t.Errorf("expected a delete result, got %T", msg)
+	}
+}
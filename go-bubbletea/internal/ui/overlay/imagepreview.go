@@ -14,13 +14,13 @@ import (
 
 // ImagePreviewOverlay displays and manages image attachments with navigation
 type ImagePreviewOverlay struct {
-	beadID        string
-	service       *attachment.Service
-	images        []attachment.Attachment
-	currentIndex  int
-	confirmDelete bool
-	error         string
-	styles        *Styles
+	beadID       string
+	service      *attachment.Service
+	images       []attachment.Attachment
+	currentIndex int
+	confirm      *ConfirmDialog // Delete confirmation, while open
+	error        string
+	styles       *Styles
 }
 
 // ImageDeletedMsg is sent when an image is deleted
@@ -32,11 +32,10 @@ type ImageDeletedMsg struct {
 // NewImagePreviewOverlay creates a new image preview overlay
 func NewImagePreviewOverlay(beadID string, service *attachment.Service, initialIndex int) *ImagePreviewOverlay {
 	return &ImagePreviewOverlay{
-		beadID:        beadID,
-		service:       service,
-		currentIndex:  initialIndex,
-		confirmDelete: false,
-		styles:        New(),
+		beadID:       beadID,
+		service:      service,
+		currentIndex: initialIndex,
+		styles:       New(),
 	}
 }
 
@@ -49,8 +48,12 @@ func (i *ImagePreviewOverlay) Init() tea.Cmd {
 func (i *ImagePreviewOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if i.confirmDelete {
-			return i.handleConfirmMode(msg)
+		if i.confirm != nil {
+			_, cmd := i.confirm.Update(msg)
+			if i.confirm.Answered() {
+				i.confirm = nil
+			}
+			return i, cmd
 		}
 		return i.handleNormalMode(msg)
 
@@ -68,7 +71,7 @@ func (i *ImagePreviewOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case imageDeletedMsg:
 		i.error = ""
-		i.confirmDelete = false
+		i.confirm = nil
 		// Reload images after deletion
 		return i, tea.Batch(
 			i.loadImages(),
@@ -82,7 +85,7 @@ func (i *ImagePreviewOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case imagePreviewErrorMsg:
 		i.error = msg.err.Error()
-		i.confirmDelete = false
+		i.confirm = nil
 		return i, nil
 	}
 
@@ -133,7 +136,7 @@ func (i *ImagePreviewOverlay) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.C
 	case "d":
 		// Delete current image (show confirmation)
 		if len(i.images) > 0 {
-			i.confirmDelete = true
+			i.confirm = i.deleteConfirmation()
 		}
 		return i, nil
 
@@ -145,27 +148,17 @@ func (i *ImagePreviewOverlay) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.C
 	return i, nil
 }
 
-// handleConfirmMode handles key presses in delete confirmation mode
-func (i *ImagePreviewOverlay) handleConfirmMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		// Yes - delete image
-		i.confirmDelete = false
-		return i, i.deleteCurrentImage()
-
-	case "n", "N", "esc":
-		// No - cancel
-		i.confirmDelete = false
-		return i, nil
-	}
-
-	return i, nil
+// deleteConfirmation asks before deleting the current image
+func (i *ImagePreviewOverlay) deleteConfirmation() *ConfirmDialog {
+	img := i.images[i.currentIndex]
+	message := fmt.Sprintf("Delete image: %s?\nSize: %s\n\nThis action cannot be undone.", img.Filename, formatFileSize(img.Size))
+	return NewConfirmDialogWithActions("⚠ Confirm Delete", message, i.deleteCurrentImage(), nil)
 }
 
 // View renders the overlay
 func (i *ImagePreviewOverlay) View() string {
-	if i.confirmDelete {
-		return i.renderDeleteConfirmation()
+	if i.confirm != nil {
+		return i.styles.Title.Render(i.confirm.Title()) + "\n\n" + i.confirm.View()
 	}
 	return i.renderPreview()
 }
@@ -275,15 +268,15 @@ func (i *ImagePreviewOverlay) renderPreview() string {
 	hints := []string{}
 	if len(i.images) > 1 {
 		hints = append(hints,
-			i.styles.MenuKey.Render("h/l") + " " + i.styles.Footer.Render("Navigate"),
-			i.styles.MenuKey.Render("g/G") + " " + i.styles.Footer.Render("First/Last"),
+			i.styles.MenuKey.Render("h/l")+" "+i.styles.Footer.Render("Navigate"),
+			i.styles.MenuKey.Render("g/G")+" "+i.styles.Footer.Render("First/Last"),
 		)
 	}
 	hints = append(hints,
-		i.styles.MenuKey.Render("o") + " " + i.styles.Footer.Render("Open"),
-		i.styles.MenuKey.Render("d") + " " + i.styles.Footer.Render("Delete"),
-		i.styles.MenuKey.Render("r") + " " + i.styles.Footer.Render("Refresh"),
-		i.styles.MenuKey.Render("Esc") + " " + i.styles.Footer.Render("Close"),
+		i.styles.MenuKey.Render("o")+" "+i.styles.Footer.Render("Open"),
+		i.styles.MenuKey.Render("d")+" "+i.styles.Footer.Render("Delete"),
+		i.styles.MenuKey.Render("r")+" "+i.styles.Footer.Render("Refresh"),
+		i.styles.MenuKey.Render("Esc")+" "+i.styles.Footer.Render("Close"),
 	)
 
 	b.WriteString(i.styles.Footer.Render(strings.Join(hints, " • ")))
@@ -291,46 +284,6 @@ func (i *ImagePreviewOverlay) renderPreview() string {
 	return b.String()
 }
 
-// renderDeleteConfirmation renders the delete confirmation dialog
-func (i *ImagePreviewOverlay) renderDeleteConfirmation() string {
-	var b strings.Builder
-
-	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#f38ba8")).
-		Bold(true)
-
-	b.WriteString(headerStyle.Render("⚠ Confirm Delete"))
-	b.WriteString("\n\n")
-
-	if i.currentIndex >= 0 && i.currentIndex < len(i.images) {
-		img := i.images[i.currentIndex]
-		b.WriteString(i.styles.MenuItem.Render(fmt.Sprintf("Delete image: %s?", img.Filename)))
-		b.WriteString("\n\n")
-		b.WriteString(i.styles.Footer.Render(fmt.Sprintf("Size: %s", formatFileSize(img.Size))))
-		b.WriteString("\n\n")
-	}
-
-	b.WriteString(i.styles.MenuItem.Render("This action cannot be undone."))
-	b.WriteString("\n\n")
-
-	// Buttons
-	yesStyle := i.styles.MenuItemActive
-	noStyle := i.styles.MenuItem
-
-	yes := yesStyle.Render("[Y] Yes, delete")
-	no := noStyle.Render("[N] No, cancel")
-
-	buttons := yes + "    " + no
-	b.WriteString(buttons)
-	b.WriteString("\n\n")
-
-	// Footer hint
-	footer := i.styles.Footer.Render("Y: Delete • N/Esc: Cancel")
-	b.WriteString(footer)
-
-	return b.String()
-}
-
 // Title returns the overlay title
 func (i *ImagePreviewOverlay) Title() string {
 	return "Image Preview"
@@ -338,8 +291,9 @@ func (i *ImagePreviewOverlay) Title() string {
 
 // Size returns the overlay dimensions
 func (i *ImagePreviewOverlay) Size() (width, height int) {
-	if i.confirmDelete {
-		return 60, 15
+	if i.confirm != nil {
+		width, height := i.confirm.Size()
+		return width, height + 2
 	}
 	return 75, 25
 }
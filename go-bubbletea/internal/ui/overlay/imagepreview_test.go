@@ -170,7 +170,7 @@ func TestImagePreviewOverlay_DeleteConfirmation(t *testing.T) {
 	model, _ = overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
 	preview = model.(*ImagePreviewOverlay)
 
-	if !preview.confirmDelete {
+	if preview.confirm == nil {
 		t.Error("Should enter delete confirmation mode")
 	}
 
@@ -178,7 +178,7 @@ func TestImagePreviewOverlay_DeleteConfirmation(t *testing.T) {
 	model, _ = overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
 	preview = model.(*ImagePreviewOverlay)
 
-	if preview.confirmDelete {
+	if preview.confirm != nil {
 		t.Error("Should exit delete confirmation mode after 'n'")
 	}
 
@@ -192,7 +192,11 @@ func TestImagePreviewOverlay_DeleteConfirmation(t *testing.T) {
 		t.Error("Should return delete command after 'y'")
 	}
 
-	// Execute delete command
+	// Confirming closes the dialog at once and deletes
+	preview = model.(*ImagePreviewOverlay)
+	if preview.confirm != nil {
+		t.Error("Should close the delete confirmation after 'y'")
+	}
 	msg = cmd()
 	if _, ok := msg.(imageDeletedMsg); !ok {
 		if errMsg, ok := msg.(imagePreviewErrorMsg); ok {
@@ -283,7 +287,7 @@ func TestImagePreviewOverlay_View(t *testing.T) {
 
 	// Test delete confirmation view
 	preview := overlay
-	preview.confirmDelete = true
+	preview.confirm = preview.deleteConfirmation()
 
 	confirmView := overlay.View()
 
@@ -319,7 +323,7 @@ func TestImagePreviewOverlay_TitleAndSize(t *testing.T) {
 
 	// Test confirm mode size
 	preview := overlay
-	preview.confirmDelete = true
+	preview.confirm = NewConfirmDialogWithActions("Confirm Delete", "Delete image?", nil, nil)
 
 	confWidth, confHeight := overlay.Size()
 	if confWidth <= 0 || confHeight <= 0 {
@@ -434,11 +438,11 @@ func TestImagePreviewOverlay_ErrorHandling(t *testing.T) {
 	}
 
 	// Confirm mode should be reset on error
-	preview.confirmDelete = true
+	preview.confirm = NewConfirmDialogWithActions("Confirm Delete", "Delete image?", nil, nil)
 	model, _ = overlay.Update(errMsg)
 	preview = model.(*ImagePreviewOverlay)
 
-	if preview.confirmDelete {
+	if preview.confirm != nil {
 		t.Error("Confirm mode should be reset on error")
 	}
 }
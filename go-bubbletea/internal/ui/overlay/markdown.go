@@ -0,0 +1,82 @@
+package overlay
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+// markdownStyle is glamour's dark style in the app's Catppuccin colours,
+// without the margins the overlay's padding already provides
+func markdownStyle() ansi.StyleConfig {
+	color := func(c lipgloss.Color) *string {
+		s := string(c)
+		return &s
+	}
+	noMargin := uint(0)
+
+	style := glamourstyles.DarkStyleConfig
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	style.Document.Color = color(styles.Text)
+	style.Document.Margin = &noMargin
+	style.Heading.Color = color(styles.Blue)
+	style.H1.Color = color(styles.Base)
+	style.H1.BackgroundColor = color(styles.Blue)
+	style.H6.Color = color(styles.Overlay2)
+	style.HorizontalRule.Color = color(styles.Surface2)
+	style.Link.Color = color(styles.Sapphire)
+	style.LinkText.Color = color(styles.Lavender)
+	style.Code.Color = color(styles.Peach)
+	style.Code.BackgroundColor = color(styles.Surface0)
+	style.CodeBlock.Color = color(styles.Subtext0)
+	style.CodeBlock.Margin = &noMargin
+	return style
+}
+
+// markdownRenderer renders bead text written in markdown to a width. It
+// keeps what it rendered, since overlays are redrawn on every frame.
+type markdownRenderer struct {
+	width    int
+	renderer *glamour.TermRenderer
+	rendered map[string]string
+}
+
+// newMarkdownRenderer creates a renderer wrapping to width columns
+func newMarkdownRenderer(width int) *markdownRenderer {
+	return &markdownRenderer{
+		width:    width,
+		rendered: make(map[string]string),
+	}
+}
+
+// Render returns text rendered as markdown. On an error the caller should
+// show the text as written.
+func (r *markdownRenderer) Render(text string) (string, error) {
+	if out, ok := r.rendered[text]; ok {
+		return out, nil
+	}
+
+	if r.renderer == nil {
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithStyles(markdownStyle()),
+			glamour.WithWordWrap(r.width),
+		)
+		if err != nil {
+			return "", err
+		}
+		r.renderer = renderer
+	}
+
+	out, err := r.renderer.Render(text)
+	if err != nil {
+		return "", err
+	}
+	out = strings.Trim(out, "\n")
+	r.rendered[text] = out
+	return out, nil
+}
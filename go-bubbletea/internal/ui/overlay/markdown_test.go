@@ -0,0 +1,68 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/riordanpawley/azedarach/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownRenderer_StylesHeadingsAndCode(t *testing.T) {
+	text := "# Plan\n\nSome *context*.\n\n```go\nfmt.Println(\"hi\")\n```"
+
+	out, err := newMarkdownRenderer(40).Render(text)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "\x1b[", "Expected styled output")
+	plain := ansi.Strip(out)
+	assert.Contains(t, plain, "Plan")
+	assert.NotContains(t, plain, "# Plan", "Expected the heading marker to be rendered away")
+	assert.Contains(t, plain, `fmt.Println("hi")`)
+	assert.NotContains(t, plain, "```", "Expected the code fence to be rendered away")
+}
+
+func TestMarkdownRenderer_WrapsToWidth(t *testing.T) {
+	out, err := newMarkdownRenderer(30).Render(strings.Repeat("word ", 30))
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(out, "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 30)
+	}
+}
+
+func TestDetailPanel_Markdown(t *testing.T) {
+	task := domain.Task{ID: "az-1", Description: "## Steps\n\n- one\n- two"}
+
+	t.Run("raw by default", func(t *testing.T) {
+		panel := NewDetailPanel(task, nil, nil)
+		assert.Contains(t, panel.View(), "## Steps")
+		assert.Contains(t, panel.View(), "- one")
+	})
+
+	t.Run("rendered when enabled", func(t *testing.T) {
+		panel := NewDetailPanel(task, nil, nil)
+		panel.SetMarkdown(true)
+
+		view := ansi.Strip(panel.View())
+		assert.Contains(t, view, "Steps")
+		assert.NotContains(t, view, "- one")
+		assert.Contains(t, view, "• one")
+	})
+}
+
+func TestEpicDrillDown_Markdown(t *testing.T) {
+	epic := domain.Task{ID: "az-1", Title: "Epic", Type: domain.TypeEpic, Description: "Ship **auth**"}
+
+	drillDown := NewEpicDrillDown(epic, nil)
+	assert.Contains(t, drillDown.View(), "Ship **auth**")
+
+	drillDown.SetMarkdown(true)
+	view := ansi.Strip(drillDown.View())
+	assert.Contains(t, view, "Ship auth")
+	_, height := drillDown.Size()
+	assert.Equal(t, 10, height, "Expected room for the description")
+}
@@ -10,19 +10,22 @@ import (
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
+// BaseMergeTarget is the ID of the merge target for the project's base branch
+const BaseMergeTarget = "base"
+
 // MergeTarget represents a target that can be merged into
 type MergeTarget struct {
-	ID          string        // "main" or task ID
-	Label       string        // Display label
-	IsMain      bool          // Whether this is the main branch
-	Status      domain.Status // Task status (if not main)
+	ID          string        // BaseMergeTarget or task ID
+	Label       string        // Display label; the branch name for the base branch
+	IsMain      bool          // Whether this is the base branch
+	Status      domain.Status // Task status (if not the base branch)
 	HasWorktree bool          // Whether this target has a worktree
 }
 
 // MergeSelectOverlay allows selecting a merge target task
 type MergeSelectOverlay struct {
 	source     *domain.Task  // The bead being merged FROM
-	candidates []MergeTarget // Beads that can be merged INTO (including main)
+	candidates []MergeTarget // Beads that can be merged INTO (including the base branch)
 	cursor     int
 	onMerge    func(targetID string) tea.Cmd
 	onCancel   func() tea.Cmd
@@ -125,9 +128,9 @@ func (m *MergeSelectOverlay) renderCandidate(target MergeTarget, isActive bool)
 	}
 	parts = append(parts, cursor)
 
-	// Main branch gets special rendering
+	// Base branch gets special rendering
 	if target.IsMain {
-		label := "main"
+		label := target.Label
 		if isActive {
 			label = m.overlayStyles.MenuItemActive.Render(label)
 		} else {
@@ -137,7 +140,7 @@ func (m *MergeSelectOverlay) renderCandidate(target MergeTarget, isActive bool)
 				Render(label)
 		}
 		parts = append(parts, label)
-		parts = append(parts, m.overlayStyles.MenuItemDisabled.Render("(main branch)"))
+		parts = append(parts, m.overlayStyles.MenuItemDisabled.Render("(base branch)"))
 		return strings.Join(parts, "")
 	}
 
@@ -240,8 +240,8 @@ func TestMergeSelectOverlay_RenderCandidate(t *testing.T) {
 func TestMergeSelectOverlay_RenderMainBranch(t *testing.T) {
 	source := makeTask("az-123", "Source", domain.StatusOpen, domain.TypeTask)
 	mainTarget := MergeTarget{
-		ID:          "main",
-		Label:       "main branch",
+		ID:          BaseMergeTarget,
+		Label:       "develop",
 		IsMain:      true,
 		HasWorktree: false,
 	}
@@ -249,8 +249,9 @@ func TestMergeSelectOverlay_RenderMainBranch(t *testing.T) {
 	overlay := NewMergeSelectOverlay(&source, []MergeTarget{mainTarget}, nil, nil)
 
 	formatted := overlay.renderCandidate(mainTarget, false)
-	assert.Contains(t, formatted, "main")
-	assert.Contains(t, formatted, "(main branch)")
+	assert.Contains(t, formatted, "develop")
+	assert.Contains(t, formatted, "(base branch)")
+	assert.NotContains(t, formatted, "main")
 }
 
 func TestMergeSelectOverlay_Init(t *testing.T) {
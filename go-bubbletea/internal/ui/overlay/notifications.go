@@ -0,0 +1,156 @@
+package overlay
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/types"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
+)
+
+// notificationRows is how many notifications the notification center shows at once
+const notificationRows = 15
+
+// NotificationsOverlay is the notification center: every toast raised
+// recently, newest first, including those that have since expired
+type NotificationsOverlay struct {
+	toasts []types.Toast
+	cursor int
+	offset int
+	styles *Styles
+}
+
+// NewNotificationsOverlay creates a notification center over history, the
+// toasts raised so far, oldest first
+func NewNotificationsOverlay(history []types.Toast) *NotificationsOverlay {
+	toasts := make([]types.Toast, len(history))
+	for i, toast := range history {
+		toasts[len(history)-1-i] = toast
+	}
+	return &NotificationsOverlay{
+		toasts: toasts,
+		styles: New(),
+	}
+}
+
+// Init initializes the notification center
+func (n *NotificationsOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (n *NotificationsOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return n, nil
+	}
+
+	switch keyMsg.String() {
+	case "q", "esc", "N":
+		return n, func() tea.Msg { return CloseOverlayMsg{} }
+
+	case "c":
+		// Clear the history; the overlay stays open, now empty
+		n.toasts = nil
+		n.cursor, n.offset = 0, 0
+		return n, func() tea.Msg { return SelectionMsg{Key: "clear_notifications"} }
+
+	case "j", "down":
+		if n.cursor < len(n.toasts)-1 {
+			n.cursor++
+		}
+
+	case "k", "up":
+		if n.cursor > 0 {
+			n.cursor--
+		}
+	}
+
+	// Keep the cursor in view
+	if n.cursor < n.offset {
+		n.offset = n.cursor
+	} else if n.cursor >= n.offset+notificationRows {
+		n.offset = n.cursor - notificationRows + 1
+	}
+	return n, nil
+}
+
+// Toasts returns the notifications listed, newest first
+func (n *NotificationsOverlay) Toasts() []types.Toast {
+	return n.toasts
+}
+
+// View renders the notification center
+func (n *NotificationsOverlay) View() string {
+	var b strings.Builder
+
+	if len(n.toasts) == 0 {
+		b.WriteString(n.styles.MenuItem.Foreground(styles.Overlay0).Render("No notifications"))
+		b.WriteString("\n")
+	}
+	end := min(n.offset+notificationRows, len(n.toasts))
+	for i := n.offset; i < end; i++ {
+		b.WriteString(n.renderToast(n.toasts[i], i == n.cursor))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	footer := fmt.Sprintf("%d notifications • c: clear • j/k: navigate • q/Esc: close", len(n.toasts))
+	b.WriteString(n.styles.Footer.Render(footer))
+
+	return b.String()
+}
+
+// renderToast renders one line of the notification center: time raised,
+// level and message
+func (n *NotificationsOverlay) renderToast(toast types.Toast, active bool) string {
+	timeStyle := lipgloss.NewStyle().Foreground(styles.Overlay1)
+	messageStyle := n.styles.MenuItem
+	if active {
+		messageStyle = n.styles.MenuItemActive
+	}
+
+	raised := "        "
+	if !toast.Raised.IsZero() {
+		raised = toast.Raised.Local().Format("15:04:05")
+	}
+
+	message := toast.Message
+	if toast.Count > 1 {
+		message = fmt.Sprintf("%s (x%d)", message, toast.Count)
+	}
+	if maxMessage := 56; len([]rune(message)) > maxMessage {
+		message = string([]rune(message)[:maxMessage-1]) + "…"
+	}
+
+	level := lipgloss.NewStyle().Foreground(levelColor(toast.Level)).Bold(true).Width(8).Render(toast.Level.String())
+	return timeStyle.Render(raised) + " " + level + messageStyle.Render(message)
+}
+
+// levelColor returns the color toasts of level are drawn in
+func levelColor(level types.ToastLevel) lipgloss.Color {
+	switch level {
+	case types.ToastSuccess:
+		return styles.Green
+	case types.ToastWarning:
+		return styles.Yellow
+	case types.ToastError:
+		return styles.Red
+	default:
+		return styles.Blue
+	}
+}
+
+// Title returns the overlay title
+func (n *NotificationsOverlay) Title() string {
+	return "Notifications"
+}
+
+// Size returns the overlay dimensions
+func (n *NotificationsOverlay) Size() (width, height int) {
+	rows := max(min(len(n.toasts), notificationRows), 1)
+	// Rows, blank, footer, padding
+	return 80, rows + 4
+}
@@ -0,0 +1,45 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/types"
+)
+
+func TestNotificationsOverlay_NewestFirst(t *testing.T) {
+	raised := time.Date(2025, 6, 1, 9, 30, 0, 0, time.Local)
+	history := []types.Toast{
+		{Level: types.ToastError, Message: "push failed", Raised: raised},
+		{Level: types.ToastInfo, Message: "Board refreshed", Count: 3, Raised: raised.Add(time.Minute)},
+	}
+	center := NewNotificationsOverlay(history)
+
+	if got := center.Toasts(); got[0].Message != "Board refreshed" || got[1].Message != "push failed" {
+		t.Fatalf("Expected newest first, got %+v", got)
+	}
+
+	view := center.View()
+	for _, want := range []string{"09:31:00", "info", "Board refreshed (x3)", "09:30:00", "error", "push failed", "2 notifications"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestNotificationsOverlay_Clear(t *testing.T) {
+	center := NewNotificationsOverlay([]types.Toast{{Level: types.ToastWarning, Message: "offline"}})
+
+	_, cmd := center.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if cmd == nil {
+		t.Fatal("Expected c to report the clear")
+	}
+	if msg, ok := cmd().(SelectionMsg); !ok || msg.Key != "clear_notifications" {
+		t.Errorf("Expected clear_notifications, got %+v", cmd())
+	}
+	if len(center.Toasts()) != 0 || !strings.Contains(center.View(), "No notifications") {
+		t.Errorf("Expected an empty notification center, got:\n%s", center.View())
+	}
+}
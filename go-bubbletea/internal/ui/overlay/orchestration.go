@@ -7,6 +7,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/core/phases"
 	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
@@ -29,6 +30,9 @@ type OrchestrationOverlay struct {
 	height   int
 	styles   *Styles
 
+	// Parallelization metric (nil hides the widget)
+	parallelism *phases.Parallelism
+
 	// Callbacks
 	onAttach  func(beadID string) tea.Cmd
 	onKill    func(beadID string) tea.Cmd
@@ -54,6 +58,11 @@ func NewOrchestrationOverlay(
 	}
 }
 
+// SetParallelism sets the ready vs. active metric shown above the session list
+func (o *OrchestrationOverlay) SetParallelism(p phases.Parallelism) {
+	o.parallelism = &p
+}
+
 // Init initializes the overlay
 func (o *OrchestrationOverlay) Init() tea.Cmd {
 	return nil
@@ -126,6 +135,9 @@ func (o *OrchestrationOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the overlay
 func (o *OrchestrationOverlay) View() string {
 	if len(o.sessions) == 0 {
+		if o.parallelism != nil {
+			return o.renderParallelism() + "\n" + o.renderEmptyState()
+		}
 		return o.renderEmptyState()
 	}
 
@@ -138,7 +150,12 @@ func (o *OrchestrationOverlay) View() string {
 		Padding(0, 1)
 	header := headerStyle.Render(fmt.Sprintf("Active Sessions: %d", len(o.sessions)))
 	b.WriteString(header)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if o.parallelism != nil {
+		b.WriteString(o.renderParallelism())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Render each session
 	for i, session := range o.sessions {
@@ -277,6 +294,36 @@ func (o *OrchestrationOverlay) renderSession(index int, session SessionInfo) str
 	return b.String()
 }
 
+// renderParallelism renders the ready vs. active gauge, colored by how much
+// of the available parallel work is in use
+func (o *OrchestrationOverlay) renderParallelism() string {
+	p := *o.parallelism
+	efficiency := p.Efficiency()
+
+	color := styles.Green
+	switch {
+	case efficiency < 0.5:
+		color = styles.Red
+	case efficiency < 1:
+		color = styles.Yellow
+	}
+
+	barWidth := 10
+	filled := int(efficiency * float64(barWidth))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+	gauge := lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%s %3.0f%%", bar, efficiency*100))
+	detail := fmt.Sprintf("Parallelism: %d active / %d ready", p.Active, p.Ready)
+	if available := p.Available(); available > 0 {
+		detail += fmt.Sprintf(" • %d more could start", available)
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(styles.Subtext0).
+		Padding(0, 1)
+	return detailStyle.Render(detail) + " " + gauge
+}
+
 // renderEmptyState renders the empty state when no sessions are active
 func (o *OrchestrationOverlay) renderEmptyState() string {
 	emptyStyle := lipgloss.NewStyle().
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/core/phases"
 	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
@@ -16,6 +17,17 @@ type PlanningStartMsg struct {
 	Description string
 }
 
+// PlanningConfirmMsg signals that the user approved the reviewed plan. The
+// parent passes Plan, which no longer contains excluded tasks, to
+// CreateBeadsFromPlan.
+type PlanningConfirmMsg struct {
+	Plan *domain.Plan
+}
+
+// PlanningDiscardMsg signals that the user threw the plan under review away,
+// including its saved draft
+type PlanningDiscardMsg struct{}
+
 // PlanningCompleteMsg signals that planning is complete
 type PlanningCompleteMsg struct {
 	Beads []domain.Task
@@ -27,6 +39,7 @@ type planningPhase string
 const (
 	phaseInput    planningPhase = "input"
 	phaseProgress planningPhase = "progress"
+	phaseReview   planningPhase = "review"
 	phaseComplete planningPhase = "complete"
 	phaseError    planningPhase = "error"
 )
@@ -39,8 +52,28 @@ type PlanningOverlay struct {
 	state       domain.PlanningState
 	styles      *Styles
 	focusInput  bool // true for title input, false for description textarea
+
+	// Review phase: an editable copy of the plan awaiting approval
+	review       *domain.Plan
+	reviewSource *domain.Plan // plan the copy was made from
+	excluded     map[string]bool
+	reviewCursor int
+	editing      reviewEdit
+	editInput    textinput.Model
 }
 
+// reviewEdit is the task field being edited in the review phase
+type reviewEdit int
+
+const (
+	editNone reviewEdit = iota
+	editTitle
+	editDeps
+)
+
+// maxReviewRows is how many plan tasks the review list shows at once
+const maxReviewRows = 15
+
 // NewPlanningOverlay creates a new planning overlay
 func NewPlanningOverlay() *PlanningOverlay {
 	// Title input for single-line description
@@ -57,10 +90,15 @@ func NewPlanningOverlay() *PlanningOverlay {
 	ta.SetWidth(70)
 	ta.SetHeight(8)
 
+	ei := textinput.New()
+	ei.CharLimit = 200
+	ei.Width = 60
+
 	return &PlanningOverlay{
 		phase:       phaseInput,
 		input:       ti,
 		description: ta,
+		editInput:   ei,
 		state: domain.PlanningState{
 			Status: domain.PlanningIdle,
 		},
@@ -84,6 +122,11 @@ func (p *PlanningOverlay) UpdateState(state domain.PlanningState) {
 		p.phase = phaseInput
 	case domain.PlanningGenerating, domain.PlanningReviewing, domain.PlanningRefining, domain.PlanningCreatingBeads:
 		p.phase = phaseProgress
+	case domain.PlanningAwaitingApproval:
+		p.phase = phaseReview
+		if state.CurrentPlan != nil && state.CurrentPlan != p.reviewSource {
+			p.startReview(state.CurrentPlan)
+		}
 	case domain.PlanningComplete:
 		p.phase = phaseComplete
 	case domain.PlanningErrorStatus:
@@ -91,6 +134,12 @@ func (p *PlanningOverlay) UpdateState(state domain.PlanningState) {
 	}
 }
 
+// Idle reports whether the overlay still waits for a feature description,
+// with no plan started or shown
+func (p *PlanningOverlay) Idle() bool {
+	return p.phase == phaseInput
+}
+
 // Update handles messages
 func (p *PlanningOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -102,6 +151,8 @@ func (p *PlanningOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return p.handleInputPhase(msg)
 		case phaseProgress:
 			return p.handleProgressPhase(msg)
+		case phaseReview:
+			return p.handleReviewPhase(msg)
 		case phaseComplete:
 			return p.handleCompletePhase(msg)
 		case phaseError:
@@ -185,6 +236,156 @@ func (p *PlanningOverlay) handleProgressPhase(msg tea.KeyMsg) (tea.Model, tea.Cm
 	return p, nil
 }
 
+// startReview makes an editable copy of plan with every task included
+func (p *PlanningOverlay) startReview(plan *domain.Plan) {
+	review := *plan
+	review.Tasks = make([]domain.PlannedTask, len(plan.Tasks))
+	for i, task := range plan.Tasks {
+		task.DependsOn = append([]string(nil), task.DependsOn...)
+		review.Tasks[i] = task
+	}
+
+	p.review = &review
+	p.reviewSource = plan
+	p.excluded = make(map[string]bool)
+	p.reviewCursor = 0
+	p.editing = editNone
+}
+
+// ReviewedPlan returns the plan as edited by the user, without excluded tasks
+func (p *PlanningOverlay) ReviewedPlan() *domain.Plan {
+	if p.review == nil {
+		return nil
+	}
+	return p.review.WithoutTasks(p.excluded)
+}
+
+// handleReviewPhase handles review phase keys
+func (p *PlanningOverlay) handleReviewPhase(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if p.review == nil {
+		if msg.String() == "esc" {
+			return p, func() tea.Msg { return CloseOverlayMsg{} }
+		}
+		return p, nil
+	}
+
+	if p.editing != editNone {
+		switch msg.String() {
+		case "enter":
+			p.applyEdit()
+			return p, nil
+		case "esc":
+			p.editing = editNone
+			p.editInput.Blur()
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.editInput, cmd = p.editInput.Update(msg)
+		return p, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		// The plan stays saved as a draft and can be resumed later
+		return p, func() tea.Msg { return CloseOverlayMsg{} }
+
+	case "j", "down":
+		if p.reviewCursor < len(p.review.Tasks)-1 {
+			p.reviewCursor++
+		}
+		return p, nil
+
+	case "k", "up":
+		if p.reviewCursor > 0 {
+			p.reviewCursor--
+		}
+		return p, nil
+
+	case " ":
+		if task := p.currentReviewTask(); task != nil {
+			p.excluded[task.ID] = !p.excluded[task.ID]
+		}
+		return p, nil
+
+	case "e":
+		if task := p.currentReviewTask(); task != nil {
+			p.startEdit(editTitle, task.Title)
+		}
+		return p, nil
+
+	case "d":
+		if task := p.currentReviewTask(); task != nil {
+			p.startEdit(editDeps, strings.Join(task.DependsOn, ", "))
+		}
+		return p, nil
+
+	case "x":
+		p.UpdateState(domain.PlanningState{Status: domain.PlanningIdle})
+		return p, func() tea.Msg { return PlanningDiscardMsg{} }
+
+	case "enter", "ctrl+s":
+		plan := p.ReviewedPlan()
+		if len(plan.Tasks) == 0 {
+			return p, nil
+		}
+		p.phase = phaseProgress
+		return p, func() tea.Msg { return PlanningConfirmMsg{Plan: plan} }
+	}
+
+	return p, nil
+}
+
+// currentReviewTask returns the task under the review cursor
+func (p *PlanningOverlay) currentReviewTask() *domain.PlannedTask {
+	if p.review == nil || p.reviewCursor < 0 || p.reviewCursor >= len(p.review.Tasks) {
+		return nil
+	}
+	return &p.review.Tasks[p.reviewCursor]
+}
+
+// startEdit opens the inline editor for a field of the current task
+func (p *PlanningOverlay) startEdit(field reviewEdit, value string) {
+	p.editing = field
+	p.editInput.SetValue(value)
+	p.editInput.CursorEnd()
+	p.editInput.Focus()
+}
+
+// applyEdit saves the inline editor's value to the current task. Unknown
+// task IDs and self-references are dropped from dependencies.
+func (p *PlanningOverlay) applyEdit() {
+	field := p.editing
+	p.editing = editNone
+	p.editInput.Blur()
+
+	task := p.currentReviewTask()
+	if task == nil {
+		return
+	}
+	value := strings.TrimSpace(p.editInput.Value())
+
+	switch field {
+	case editTitle:
+		if value != "" {
+			task.Title = value
+		}
+	case editDeps:
+		known := make(map[string]bool, len(p.review.Tasks))
+		for _, t := range p.review.Tasks {
+			known[t.ID] = true
+		}
+		var deps []string
+		seen := make(map[string]bool)
+		for _, dep := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+			if known[dep] && dep != task.ID && !seen[dep] {
+				seen[dep] = true
+				deps = append(deps, dep)
+			}
+		}
+		task.DependsOn = deps
+	}
+}
+
 // handleCompletePhase handles complete phase keys
 func (p *PlanningOverlay) handleCompletePhase(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -228,6 +429,8 @@ func (p *PlanningOverlay) View() string {
 		return p.renderInputPhase()
 	case phaseProgress:
 		return p.renderProgressPhase()
+	case phaseReview:
+		return p.renderReviewPhase()
 	case phaseComplete:
 		return p.renderCompletePhase()
 	case phaseError:
@@ -339,6 +542,98 @@ func (p *PlanningOverlay) renderProgressPhase() string {
 	return b.String()
 }
 
+// renderReviewPhase renders the approved plan for editing before beads are
+// created
+func (p *PlanningOverlay) renderReviewPhase() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#cba6f7")).
+		Bold(true)
+
+	b.WriteString(titleStyle.Render("Review Plan"))
+	b.WriteString("\n\n")
+
+	if p.review == nil {
+		b.WriteString(p.styles.Footer.Render("No plan to review • Esc: close"))
+		return b.String()
+	}
+
+	subtextStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6c7086"))
+	epicStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#cba6f7"))
+	b.WriteString(epicStyle.Render("Epic: " + p.review.EpicTitle))
+	b.WriteString("\n")
+
+	selected := len(p.ReviewedPlan().Tasks)
+	b.WriteString(subtextStyle.Render(fmt.Sprintf("%d of %d tasks selected", selected, len(p.review.Tasks))))
+	b.WriteString("\n\n")
+
+	// Keep the cursor visible in long plans
+	start := 0
+	if p.reviewCursor >= maxReviewRows {
+		start = p.reviewCursor - maxReviewRows + 1
+	}
+	end := min(start+maxReviewRows, len(p.review.Tasks))
+
+	idStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#89b4fa"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#cdd6f4"))
+	for i := start; i < end; i++ {
+		task := p.review.Tasks[i]
+
+		cursor := "  "
+		if i == p.reviewCursor {
+			cursor = p.styles.MenuKey.Render("▸ ")
+		}
+		check := "[x]"
+		style := textStyle
+		if p.excluded[task.ID] {
+			check = "[ ]"
+			style = subtextStyle.Strikethrough(true)
+		}
+
+		b.WriteString(cursor)
+		b.WriteString(check + " ")
+		b.WriteString(idStyle.Render(task.ID) + " ")
+		b.WriteString(style.Render(truncateText(task.Title, 40)))
+		if len(task.DependsOn) > 0 {
+			b.WriteString(subtextStyle.Render(fmt.Sprintf(" (deps: %s)", strings.Join(task.DependsOn, ", "))))
+		}
+		b.WriteString("\n")
+	}
+	if end < len(p.review.Tasks) {
+		b.WriteString(subtextStyle.Render(fmt.Sprintf("  ... and %d more", len(p.review.Tasks)-end)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if p.editing != editNone {
+		label := "Title:"
+		if p.editing == editDeps {
+			label = "Depends on:"
+		}
+		b.WriteString(p.styles.MenuKey.Render(label) + " " + p.editInput.View())
+		b.WriteString("\n\n")
+		hints := []string{
+			p.styles.MenuKey.Render("Enter") + " " + p.styles.Footer.Render("Save"),
+			p.styles.MenuKey.Render("Esc") + " " + p.styles.Footer.Render("Cancel"),
+		}
+		b.WriteString(p.styles.Footer.Render(strings.Join(hints, " • ")))
+		return b.String()
+	}
+
+	hints := []string{
+		p.styles.MenuKey.Render("Space") + " " + p.styles.Footer.Render("Toggle"),
+		p.styles.MenuKey.Render("e") + " " + p.styles.Footer.Render("Title"),
+		p.styles.MenuKey.Render("d") + " " + p.styles.Footer.Render("Deps"),
+		p.styles.MenuKey.Render("Enter") + " " + p.styles.Footer.Render("Create beads"),
+		p.styles.MenuKey.Render("x") + " " + p.styles.Footer.Render("Discard"),
+		p.styles.MenuKey.Render("Esc") + " " + p.styles.Footer.Render("Later"),
+	}
+	b.WriteString(p.styles.Footer.Render(strings.Join(hints, " • ")))
+
+	return b.String()
+}
+
 // renderCompletePhase renders the complete phase
 func (p *PlanningOverlay) renderCompletePhase() string {
 	var b strings.Builder
@@ -427,6 +722,7 @@ func (p *PlanningOverlay) renderStatusIndicator() string {
 		domain.PlanningGenerating:     lipgloss.Color("#f9e2af"),
 		domain.PlanningReviewing:      lipgloss.Color("#89b4fa"),
 		domain.PlanningRefining:       lipgloss.Color("#cba6f7"),
+		domain.PlanningAwaitingApproval: lipgloss.Color("#94e2d5"),
 		domain.PlanningCreatingBeads:  lipgloss.Color("#a6e3a1"),
 		domain.PlanningComplete:       lipgloss.Color("#a6e3a1"),
 		domain.PlanningErrorStatus:    lipgloss.Color("#f38ba8"),
@@ -437,6 +733,7 @@ func (p *PlanningOverlay) renderStatusIndicator() string {
 		domain.PlanningGenerating:     "Generating plan...",
 		domain.PlanningReviewing:      "Reviewing plan...",
 		domain.PlanningRefining:       "Refining plan...",
+		domain.PlanningAwaitingApproval: "Awaiting approval",
 		domain.PlanningCreatingBeads:  "Creating beads...",
 		domain.PlanningComplete:       "Complete!",
 		domain.PlanningErrorStatus:    "Error",
@@ -525,22 +822,28 @@ func (p *PlanningOverlay) renderPlanSummary(plan *domain.Plan) string {
 		b.WriteString(subtextStyle.Render(fmt.Sprintf("  ... and %d more", len(plan.Tasks)-8)))
 	}
 
-	// Parallelization score
-	if plan.ParallelizationScore > 0 {
+	// Parallelization score, computed from the dependency graph; the
+	// model's self-reported score is only shown as a hint
+	if len(plan.Tasks) > 0 {
+		score := phases.PlanParallelizationScore(plan)
+
 		b.WriteString("\n\n")
 		b.WriteString(subtextStyle.Render("Parallelization score: "))
 
 		var scoreColor lipgloss.Color
-		if plan.ParallelizationScore > 70 {
+		if score > 70 {
 			scoreColor = lipgloss.Color("#a6e3a1")
-		} else if plan.ParallelizationScore > 40 {
+		} else if score > 40 {
 			scoreColor = lipgloss.Color("#f9e2af")
 		} else {
 			scoreColor = lipgloss.Color("#f38ba8")
 		}
 
 		scoreStyle := lipgloss.NewStyle().Foreground(scoreColor)
-		b.WriteString(scoreStyle.Render(fmt.Sprintf("%d%%", plan.ParallelizationScore)))
+		b.WriteString(scoreStyle.Render(fmt.Sprintf("%d%%", score)))
+		if plan.ParallelizationScore > 0 && plan.ParallelizationScore != score {
+			b.WriteString(subtextStyle.Render(fmt.Sprintf(" (model estimate: %d%%)", plan.ParallelizationScore)))
+		}
 	}
 
 	return b.String()
@@ -608,6 +911,8 @@ func (p *PlanningOverlay) Size() (width, height int) {
 		return 80, 28
 	case phaseProgress:
 		return 80, 35
+	case phaseReview:
+		return 80, 35
 	case phaseComplete:
 		return 80, 25
 	case phaseError:
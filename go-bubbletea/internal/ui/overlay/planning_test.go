@@ -49,6 +49,11 @@ func TestPlanningOverlay_UpdateState(t *testing.T) {
 			status:    domain.PlanningCreatingBeads,
 			wantPhase: phaseProgress,
 		},
+		{
+			name:      "awaiting approval status",
+			status:    domain.PlanningAwaitingApproval,
+			wantPhase: phaseReview,
+		},
 		{
 			name:      "complete status",
 			status:    domain.PlanningComplete,
@@ -164,6 +169,108 @@ func TestPlanningOverlay_ProgressPhase(t *testing.T) {
 	assert.True(t, ok, "expected CloseOverlayMsg")
 }
 
+// newReviewOverlay returns an overlay reviewing a three-task plan where
+// task-3 depends on task-2
+func newReviewOverlay() *PlanningOverlay {
+	overlay := NewPlanningOverlay()
+	overlay.UpdateState(domain.PlanningState{
+		Status: domain.PlanningAwaitingApproval,
+		CurrentPlan: &domain.Plan{
+			EpicTitle: "Login",
+			Tasks: []domain.PlannedTask{
+				{ID: "task-1", Title: "Add form"},
+				{ID: "task-2", Title: "Add OAuth"},
+				{ID: "task-3", Title: "Add tests", DependsOn: []string{"task-2"}},
+			},
+		},
+	})
+	return overlay
+}
+
+func reviewKey(overlay *PlanningOverlay, key string) tea.Cmd {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	_, cmd := overlay.Update(msg)
+	return cmd
+}
+
+func TestPlanningOverlay_ReviewExcludesTasks(t *testing.T) {
+	overlay := newReviewOverlay()
+	require.Equal(t, phaseReview, overlay.phase)
+
+	// Exclude task-2
+	reviewKey(overlay, "j")
+	reviewKey(overlay, " ")
+	assert.Contains(t, overlay.View(), "2 of 3 tasks selected")
+
+	cmd := reviewKey(overlay, "enter")
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(PlanningConfirmMsg)
+	require.True(t, ok, "expected PlanningConfirmMsg")
+
+	require.Len(t, msg.Plan.Tasks, 2)
+	assert.Equal(t, "task-1", msg.Plan.Tasks[0].ID)
+	assert.Equal(t, "task-3", msg.Plan.Tasks[1].ID)
+	assert.Empty(t, msg.Plan.Tasks[1].DependsOn, "dependency on an excluded task is dropped")
+	assert.Equal(t, phaseProgress, overlay.phase)
+}
+
+func TestPlanningOverlay_ReviewToggleBack(t *testing.T) {
+	overlay := newReviewOverlay()
+
+	reviewKey(overlay, " ")
+	reviewKey(overlay, " ")
+
+	assert.Len(t, overlay.ReviewedPlan().Tasks, 3)
+}
+
+func TestPlanningOverlay_ReviewNothingSelected(t *testing.T) {
+	overlay := newReviewOverlay()
+	for i := 0; i < 3; i++ {
+		reviewKey(overlay, " ")
+		reviewKey(overlay, "j")
+	}
+
+	assert.Nil(t, reviewKey(overlay, "enter"), "an empty plan cannot be confirmed")
+	assert.Equal(t, phaseReview, overlay.phase)
+}
+
+func TestPlanningOverlay_ReviewEdits(t *testing.T) {
+	overlay := newReviewOverlay()
+
+	// Rename task-1
+	reviewKey(overlay, "e")
+	overlay.editInput.SetValue("Add login form")
+	reviewKey(overlay, "enter")
+
+	// task-3 depends on task-1 instead; unknown and self IDs are dropped
+	reviewKey(overlay, "j")
+	reviewKey(overlay, "j")
+	reviewKey(overlay, "d")
+	overlay.editInput.SetValue("task-1, task-9 task-3")
+	reviewKey(overlay, "enter")
+
+	// Cancelled edits leave the task untouched
+	reviewKey(overlay, "e")
+	overlay.editInput.SetValue("Discarded")
+	reviewKey(overlay, "esc")
+
+	plan := overlay.ReviewedPlan()
+	assert.Equal(t, "Add login form", plan.Tasks[0].Title)
+	assert.Equal(t, []string{"task-1"}, plan.Tasks[2].DependsOn)
+	assert.Equal(t, "Add tests", plan.Tasks[2].Title)
+	assert.Equal(t, []string{"task-2"}, overlay.reviewSource.Tasks[2].DependsOn, "the service's plan is not modified")
+}
+
 func TestPlanningOverlay_CompletePhase(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -291,6 +398,24 @@ func TestPlanningOverlay_View(t *testing.T) {
 	}
 }
 
+func TestPlanningOverlay_ParallelizationScoreFromGraph(t *testing.T) {
+	overlay := NewPlanningOverlay()
+	plan := &domain.Plan{
+		EpicTitle: "Test Epic",
+		Tasks: []domain.PlannedTask{
+			{ID: "task-1", Title: "First"},
+			{ID: "task-2", Title: "Second", DependsOn: []string{"task-1"}},
+		},
+		ParallelizationScore: 90,
+	}
+
+	summary := overlay.renderPlanSummary(plan)
+
+	// A two-task chain can't run in parallel, whatever the model says
+	assert.Contains(t, summary, "Parallelization score: 0%")
+	assert.Contains(t, summary, "model estimate: 90%")
+}
+
 func TestPlanningOverlay_Title(t *testing.T) {
 	overlay := NewPlanningOverlay()
 	assert.Equal(t, "AI Planning", overlay.Title())
@@ -0,0 +1,66 @@
+package overlay
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PlanningSetupOverlay explains that planning needs an Anthropic API key,
+// shown in place of the planning overlay when none is set
+type PlanningSetupOverlay struct {
+	styles *Styles
+}
+
+// NewPlanningSetupOverlay creates the prompt for a missing ANTHROPIC_API_KEY
+func NewPlanningSetupOverlay() *PlanningSetupOverlay {
+	return &PlanningSetupOverlay{
+		styles: New(),
+	}
+}
+
+// Init initializes the prompt
+func (p *PlanningSetupOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (p *PlanningSetupOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q", "enter":
+		return p, func() tea.Msg { return CloseOverlayMsg{} }
+	}
+	return p, nil
+}
+
+// View renders the prompt
+func (p *PlanningSetupOverlay) View() string {
+	var s strings.Builder
+
+	s.WriteString(p.styles.MenuItem.Render("Planning breaks a feature into beads with Claude, which needs an Anthropic API key."))
+	s.WriteString("\n\n")
+	s.WriteString(p.styles.MenuItem.Render("Set it in the shell you start azedarach from:"))
+	s.WriteString("\n\n")
+	s.WriteString(p.styles.MenuKey.Render("  export ANTHROPIC_API_KEY=sk-ant-..."))
+	s.WriteString("\n\n")
+	s.WriteString(p.styles.MenuItem.Render("then restart azedarach. Keys are created at console.anthropic.com."))
+	s.WriteString("\n\n")
+	s.WriteString(p.styles.Footer.Render("Esc/Enter: close"))
+
+	return s.String()
+}
+
+// Title returns the overlay title
+func (p *PlanningSetupOverlay) Title() string {
+	return "Planning Unavailable"
+}
+
+// Size returns the overlay dimensions
+func (p *PlanningSetupOverlay) Size() (width, height int) {
+	return 90, 12
+}
@@ -0,0 +1,24 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPlanningSetupOverlay(t *testing.T) {
+	p := NewPlanningSetupOverlay()
+
+	if view := p.View(); !strings.Contains(view, "ANTHROPIC_API_KEY") {
+		t.Errorf("Expected view to name the missing key, got:\n%s", view)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected a command")
+	}
+	if _, ok := cmd().(CloseOverlayMsg); !ok {
+		t.Errorf("Expected CloseOverlayMsg, got %#v", cmd())
+	}
+}
@@ -67,6 +67,13 @@ func NewPRCreateOverlay(branch, baseBranch, beadID string) *PRCreateOverlay {
 	}
 }
 
+// SetDefaults fills the title and description with text to edit, such as
+// what the bead says
+func (p *PRCreateOverlay) SetDefaults(title, body string) {
+	p.title.SetValue(title)
+	p.body.SetValue(body)
+}
+
 // Init initializes the overlay
 func (p *PRCreateOverlay) Init() tea.Cmd {
 	return textinput.Blink
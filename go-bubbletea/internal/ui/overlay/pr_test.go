@@ -157,6 +157,21 @@ func TestPRCreateOverlaySubmitWithCtrlS(t *testing.T) {
 	assert.True(t, prMsg.Draft)
 }
 
+func TestPRCreateOverlaySetDefaults(t *testing.T) {
+	overlay := NewPRCreateOverlay("az/az-42", "main", "az-42")
+	overlay.SetDefaults("[az-42] Login form", "Adds the form.\n\nRefs az-42")
+
+	// The defaults stay editable
+	m, _ := overlay.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	overlay = m.(*PRCreateOverlay)
+
+	_, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	prMsg := cmd().(PRCreatedMsg)
+	assert.Equal(t, "[az-42] Login form!", prMsg.Title)
+	assert.Equal(t, "Adds the form.\n\nRefs az-42", prMsg.Body)
+}
+
 func TestPRCreateOverlaySubmitWithEnter(t *testing.T) {
 	overlay := NewPRCreateOverlay("fix/bug", "main", "az-50")
 
@@ -30,13 +30,24 @@ type ProjectSelector struct {
 	cursor   int
 	mode     projectSelectorMode
 	styles   *Styles
+
+	// Scan mode: repos found under scanRoot and which of them to add, with
+	// the first of them in view
+	scanRoot   string
+	found      []config.Project
+	picked     []bool
+	scanOffset int
 }
 
+// scanRows is how many scanned repos the scan list shows at once
+const scanRows = 12
+
 type projectSelectorMode int
 
 const (
 	projectModeList projectSelectorMode = iota
 	projectModeActions
+	projectModeScan
 )
 
 // NewProjectSelector creates a new project selector overlay
@@ -57,6 +68,10 @@ func (m *ProjectSelector) Init() tea.Cmd {
 
 // Update handles messages
 func (m *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.mode == projectModeScan {
+		return m, m.updateScan(keyMsg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -111,6 +126,12 @@ func (m *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Detect from cwd
 				return m, m.detectAndAdd()
 			}
+
+		case "S":
+			if m.mode == projectModeList {
+				// Scan a directory for repos to add
+				return m, m.startScan()
+			}
 		}
 	}
 
@@ -119,8 +140,11 @@ func (m *ProjectSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the project selector
 func (m *ProjectSelector) View() string {
-	if m.mode == projectModeActions {
+	switch m.mode {
+	case projectModeActions:
 		return m.viewActions()
+	case projectModeScan:
+		return m.viewScan()
 	}
 	return m.viewList()
 }
@@ -132,7 +156,7 @@ func (m *ProjectSelector) viewList() string {
 	if len(m.registry.Projects) == 0 {
 		b.WriteString(m.styles.MenuItem.Render("No projects registered"))
 		b.WriteString("\n\n")
-		b.WriteString(m.styles.Footer.Render("a: add project • D: detect from cwd • esc: close"))
+		b.WriteString(m.styles.Footer.Render("a: add project • D: detect from cwd • S: scan for repos • esc: close"))
 		return b.String()
 	}
 
@@ -155,7 +179,7 @@ func (m *ProjectSelector) viewList() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(m.styles.Footer.Render("enter: switch • d: set default • x: remove • a: add • D: detect • esc: close"))
+	b.WriteString(m.styles.Footer.Render("enter: switch • d: set default • x: remove • a: add • D: detect • S: scan • esc: close"))
 
 	return b.String()
 }
@@ -170,7 +194,8 @@ func (m *ProjectSelector) viewActions() string {
 	}{
 		{"1", "Add project manually"},
 		{"2", "Detect from current directory"},
-		{"3", "Cancel"},
+		{"3", "Scan a directory for repos"},
+		{"4", "Cancel"},
 	}
 
 	for i, action := range actions {
@@ -192,8 +217,11 @@ func (m *ProjectSelector) viewActions() string {
 
 // Title returns the overlay title
 func (m *ProjectSelector) Title() string {
-	if m.mode == projectModeActions {
+	switch m.mode {
+	case projectModeActions:
 		return "Add Project"
+	case projectModeScan:
+		return "Add Projects Found"
 	}
 	return "Projects"
 }
@@ -203,6 +231,11 @@ func (m *ProjectSelector) Size() (width, height int) {
 	if m.mode == projectModeActions {
 		return 50, 10
 	}
+	if m.mode == projectModeScan {
+		// Root, blank, repos in view (at least the empty notice), blank,
+		// footer, padding
+		return 70, max(min(len(m.found), scanRows), 1) + 7
+	}
 
 	// Dynamic height based on number of projects
 	height = len(m.registry.Projects)*2 + 6
@@ -230,7 +263,10 @@ func (m *ProjectSelector) moveCursorUp() {
 // getMaxCursor returns the maximum cursor position
 func (m *ProjectSelector) getMaxCursor() int {
 	if m.mode == projectModeActions {
-		return 2 // 3 actions (0, 1, 2)
+		return 3 // 4 actions (0-3)
+	}
+	if m.mode == projectModeScan {
+		return max(len(m.found)-1, 0)
 	}
 	if len(m.registry.Projects) == 0 {
 		return 0
@@ -371,6 +407,9 @@ func (m *ProjectSelector) executeAction() tea.Cmd {
 		m.mode = projectModeList
 		return m.detectAndAdd()
 	case 2:
+		// Scan a directory for repos
+		return m.startScan()
+	case 3:
 		// Cancel
 		m.mode = projectModeList
 		return nil
@@ -378,6 +417,152 @@ func (m *ProjectSelector) executeAction() tea.Cmd {
 	return nil
 }
 
+// startScan lists the unregistered git repos in the registry's scan
+// directory, all picked, for the user to confirm
+func (m *ProjectSelector) startScan() tea.Cmd {
+	root, err := m.registry.ScanDir()
+	if err == nil {
+		m.found, err = m.registry.Scan(root)
+	}
+	if err != nil {
+		m.mode = projectModeList
+		return func() tea.Msg {
+			return SelectionMsg{Key: "scan-error", Value: err}
+		}
+	}
+
+	m.scanRoot = root
+	m.picked = make([]bool, len(m.found))
+	for i := range m.picked {
+		m.picked[i] = true
+	}
+	m.mode = projectModeScan
+	m.cursor, m.scanOffset = 0, 0
+	return nil
+}
+
+// updateScan handles keys while picking scanned repos to add
+func (m *ProjectSelector) updateScan(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = projectModeList
+		m.cursor = 0
+
+	case "j", "down":
+		m.moveCursorDown()
+
+	case "k", "up":
+		m.moveCursorUp()
+
+	case " ", "space":
+		if m.cursor < len(m.picked) {
+			m.picked[m.cursor] = !m.picked[m.cursor]
+		}
+
+	case "a":
+		// All, or none if all are already picked
+		all := true
+		for _, picked := range m.picked {
+			all = all && picked
+		}
+		for i := range m.picked {
+			m.picked[i] = !all
+		}
+
+	case "enter":
+		m.mode = projectModeList
+		m.cursor = 0
+		return m.addPicked()
+	}
+
+	// Keep the cursor in view
+	if m.cursor < m.scanOffset {
+		m.scanOffset = m.cursor
+	} else if m.cursor >= m.scanOffset+scanRows {
+		m.scanOffset = m.cursor - scanRows + 1
+	}
+	return nil
+}
+
+// addPicked adds the picked scanned repos to the registry and saves it
+func (m *ProjectSelector) addPicked() tea.Cmd {
+	var projects []config.Project
+	for i, project := range m.found {
+		if m.picked[i] {
+			projects = append(projects, project)
+		}
+	}
+	if len(projects) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		names := make([]string, 0, len(projects))
+		for _, project := range projects {
+			if err := m.registry.Add(project.Name, project.Path); err != nil {
+				return SelectionMsg{
+					Key:   "add-error",
+					Value: fmt.Errorf("adding %s: %w", project.Name, err),
+				}
+			}
+			names = append(names, project.Name)
+		}
+
+		if err := config.SaveProjectsRegistry(m.registry); err != nil {
+			return SelectionMsg{
+				Key:   "save-error",
+				Value: err,
+			}
+		}
+
+		return SelectionMsg{
+			Key:   "scan-success",
+			Value: "added " + strings.Join(names, ", "),
+		}
+	}
+}
+
+// viewScan renders the scanned repos with whether each will be added
+func (m *ProjectSelector) viewScan() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Footer.Render("Git repos in " + m.scanRoot))
+	b.WriteString("\n\n")
+
+	if len(m.found) == 0 {
+		b.WriteString(m.styles.MenuItem.Render("No unregistered repos found"))
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.Footer.Render("esc: back"))
+		return b.String()
+	}
+
+	end := min(m.scanOffset+scanRows, len(m.found))
+	for i := m.scanOffset; i < end; i++ {
+		style := m.styles.MenuItem
+		if i == m.cursor {
+			style = m.styles.MenuItemActive
+		}
+		box := "[ ]"
+		if m.picked[i] {
+			box = "[x]"
+		}
+		b.WriteString(m.styles.MenuKey.Render(box) + " " + style.Render(m.found[i].Name))
+		b.WriteString("\n")
+	}
+
+	count := 0
+	for _, picked := range m.picked {
+		if picked {
+			count++
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Footer.Render(fmt.Sprintf("space: toggle • a: all/none • enter: add %d • esc: back", count)))
+
+	return b.String()
+}
+
 // ProjectSelectorOption is a function that configures a ProjectSelector
 type ProjectSelectorOption func(*ProjectSelector)
 
@@ -1,6 +1,9 @@
 package overlay
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -454,7 +457,7 @@ func TestProjectSelector_GetMaxCursor(t *testing.T) {
 		{"empty list mode", 0, projectModeList, 0},
 		{"one project list mode", 1, projectModeList, 0},
 		{"three projects list mode", 3, projectModeList, 2},
-		{"actions mode", 0, projectModeActions, 2},
+		{"actions mode", 0, projectModeActions, 3},
 	}
 
 	for _, tt := range tests {
@@ -481,3 +484,117 @@ func TestProjectSelector_GetMaxCursor(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectSelector_ScanAddsPickedRepos(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	root := t.TempDir()
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Not a git repo, so never offered
+	if err := os.MkdirAll(filepath.Join(root, "notes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := &config.ProjectsRegistry{ScanRoot: root}
+	selector := NewProjectSelector(registry)
+
+	selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if selector.mode != projectModeScan {
+		t.Fatalf("expected scan mode, got %v", selector.mode)
+	}
+	if len(selector.found) != 3 {
+		t.Fatalf("expected 3 repos found, got %v", selector.found)
+	}
+	if strings.Contains(selector.View(), "notes") {
+		t.Error("expected non-git directory not to be offered")
+	}
+
+	// Deselect beta before confirming
+	selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	selector.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if !strings.Contains(selector.View(), "enter: add 2") {
+		t.Errorf("expected 2 repos picked, got:\n%s", selector.View())
+	}
+
+	_, cmd := selector.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected confirming to add the picked repos")
+	}
+	msg, ok := cmd().(SelectionMsg)
+	if !ok || msg.Key != "scan-success" {
+		t.Fatalf("expected scan-success, got %#v", msg)
+	}
+	if msg.Value != "added alpha, gamma" {
+		t.Errorf("expected alpha and gamma added, got %v", msg.Value)
+	}
+
+	if len(registry.Projects) != 2 || registry.Projects[0].Name != "alpha" || registry.Projects[1].Name != "gamma" {
+		t.Errorf("expected alpha and gamma registered, got %v", registry.Projects)
+	}
+	if selector.mode != projectModeList {
+		t.Errorf("expected back in list mode, got %v", selector.mode)
+	}
+
+	// Added repos aren't offered again
+	selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if len(selector.found) != 1 || selector.found[0].Name != "beta" {
+		t.Errorf("expected only beta left to add, got %v", selector.found)
+	}
+}
+
+func TestProjectSelector_ScanEscapeAddsNothing(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "alpha", ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := &config.ProjectsRegistry{ScanRoot: root}
+	selector := NewProjectSelector(registry)
+
+	selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	_, cmd := selector.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		t.Error("expected Esc to leave the scan without adding")
+	}
+	if selector.mode != projectModeList || len(registry.Projects) != 0 {
+		t.Errorf("expected list mode and no projects, got %v and %v", selector.mode, registry.Projects)
+	}
+}
+
+func TestProjectSelector_ScanPagesLongLists(t *testing.T) {
+	root := t.TempDir()
+	for i := range 30 {
+		if err := os.MkdirAll(filepath.Join(root, fmt.Sprintf("repo-%02d", i), ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registry := &config.ProjectsRegistry{ScanRoot: root}
+	selector := NewProjectSelector(registry)
+	selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+
+	if _, height := selector.Size(); height != scanRows+7 {
+		t.Errorf("expected the overlay sized to %d repos, got height %d", scanRows, height)
+	}
+	view := selector.View()
+	if !strings.Contains(view, "repo-00") || strings.Contains(view, fmt.Sprintf("repo-%02d", scanRows)) {
+		t.Errorf("expected only the first %d repos, got:\n%s", scanRows, view)
+	}
+
+	// Moving past the last repo in view scrolls the list
+	for range scanRows {
+		selector.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	view = selector.View()
+	if strings.Contains(view, "repo-00") || !strings.Contains(view, fmt.Sprintf("repo-%02d", scanRows)) {
+		t.Errorf("expected the list scrolled by one, got:\n%s", view)
+	}
+	if !strings.Contains(view, "enter: add 30") {
+		t.Errorf("expected every repo counted as picked, got:\n%s", view)
+	}
+}
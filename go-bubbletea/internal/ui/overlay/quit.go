@@ -0,0 +1,143 @@
+package overlay
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+// quitSessionsShown is how many sessions the quit prompt lists by name
+const quitSessionsShown = 8
+
+// quitChoice is an option of the quit prompt
+type quitChoice struct {
+	key   string // Shortcut key
+	label string
+	msg   string // SelectionMsg key sent when chosen; empty cancels
+}
+
+var quitChoices = []quitChoice{
+	{key: "l", label: "Quit and leave sessions running", msg: "quit_leave"},
+	{key: "s", label: "Quit and stop all sessions", msg: "quit_stop"},
+	{key: "c", label: "Cancel"},
+}
+
+// QuitConfirmOverlay asks what to do with running sessions before quitting
+type QuitConfirmOverlay struct {
+	sessions []*domain.Session
+	cursor   int
+	styles   *Styles
+}
+
+// NewQuitConfirmOverlay creates a quit prompt summarizing sessions
+func NewQuitConfirmOverlay(sessions map[string]*domain.Session) *QuitConfirmOverlay {
+	list := make([]*domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		list = append(list, session)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].BeadID < list[j].BeadID
+	})
+
+	return &QuitConfirmOverlay{
+		sessions: list,
+		styles:   New(),
+	}
+}
+
+// Init initializes the prompt
+func (q *QuitConfirmOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (q *QuitConfirmOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return q, nil
+	}
+
+	switch key := keyMsg.String(); key {
+	case "j", "down", "tab":
+		q.cursor = (q.cursor + 1) % len(quitChoices)
+	case "k", "up", "shift+tab":
+		q.cursor = (q.cursor + len(quitChoices) - 1) % len(quitChoices)
+	case "enter":
+		return q, q.choose(quitChoices[q.cursor])
+	case "esc", "q", "n":
+		return q, q.choose(quitChoices[len(quitChoices)-1])
+	case "ctrl+c":
+		// A second ctrl+c quits without touching the sessions
+		return q, q.choose(quitChoices[0])
+	default:
+		for _, choice := range quitChoices {
+			if key == choice.key {
+				return q, q.choose(choice)
+			}
+		}
+	}
+	return q, nil
+}
+
+// choose returns the message for choice
+func (q *QuitConfirmOverlay) choose(choice quitChoice) tea.Cmd {
+	if choice.msg == "" {
+		return func() tea.Msg { return CloseOverlayMsg{} }
+	}
+	return func() tea.Msg {
+		return SelectionMsg{Key: choice.msg, Value: len(q.sessions)}
+	}
+}
+
+// View renders the prompt
+func (q *QuitConfirmOverlay) View() string {
+	var b strings.Builder
+
+	noun := "sessions are"
+	if len(q.sessions) == 1 {
+		noun = "session is"
+	}
+	b.WriteString(q.styles.MenuItem.Render(fmt.Sprintf("%d %s still running:", len(q.sessions), noun)))
+	b.WriteString("\n\n")
+
+	for i, session := range q.sessions {
+		if i == quitSessionsShown {
+			b.WriteString(q.styles.Footer.Render(fmt.Sprintf("  …and %d more", len(q.sessions)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %s %s", session.State.Icon(), q.styles.MenuItem.Render(session.BeadID)))
+		b.WriteString(q.styles.Footer.Render(" " + session.State.String()))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	for i, choice := range quitChoices {
+		style := q.styles.MenuItem
+		if i == q.cursor {
+			style = q.styles.MenuItemActive
+		}
+		b.WriteString(q.styles.MenuKey.Render("["+strings.ToUpper(choice.key)+"]") + " " + style.Render(choice.label))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(q.styles.Footer.Render("j/k: navigate • Enter: choose • Esc: cancel"))
+
+	return b.String()
+}
+
+// Title returns the overlay title
+func (q *QuitConfirmOverlay) Title() string {
+	return "Quit"
+}
+
+// Size returns the overlay dimensions
+func (q *QuitConfirmOverlay) Size() (width, height int) {
+	listed := min(len(q.sessions), quitSessionsShown+1)
+	// Summary, blank, sessions, blank, choices, blank, footer, title, padding
+	return 60, listed + len(quitChoices) + 9
+}
@@ -0,0 +1,52 @@
+package overlay
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
+)
+
+func TestQuitConfirmOverlay_Choices(t *testing.T) {
+	sessions := map[string]*domain.Session{
+		"az-1": {BeadID: "az-1", State: domain.SessionBusy},
+	}
+
+	tests := []struct {
+		name    string
+		keys    []tea.KeyMsg
+		wantKey string // Empty expects the prompt to close
+	}{
+		{"leave running", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'l'}}}, "quit_leave"},
+		{"stop all", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'s'}}}, "quit_stop"},
+		{"enter on second", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'j'}}, {Type: tea.KeyEnter}}, "quit_stop"},
+		{"second ctrl+c", []tea.KeyMsg{{Type: tea.KeyCtrlC}}, "quit_leave"},
+		{"cancel", []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'c'}}}, ""},
+		{"esc", []tea.KeyMsg{{Type: tea.KeyEsc}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQuitConfirmOverlay(sessions)
+
+			var cmd tea.Cmd
+			for _, key := range tt.keys {
+				_, cmd = q.Update(key)
+			}
+			if cmd == nil {
+				t.Fatal("Expected a command")
+			}
+
+			msg := cmd()
+			if tt.wantKey == "" {
+				if _, ok := msg.(CloseOverlayMsg); !ok {
+					t.Errorf("Expected CloseOverlayMsg, got %#v", msg)
+				}
+				return
+			}
+			if sel, ok := msg.(SelectionMsg); !ok || sel.Key != tt.wantKey {
+				t.Errorf("Expected selection %q, got %#v", tt.wantKey, msg)
+			}
+		})
+	}
+}
@@ -6,16 +6,20 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/domain"
 )
 
-// SearchMsg is emitted on every keystroke for live filtering
+// SearchMsg is emitted on every keystroke, and when the scope changes, for
+// live filtering
 type SearchMsg struct {
 	Query string
+	Scope domain.SearchScope
 }
 
 // SearchOverlay provides a search input overlay
 type SearchOverlay struct {
 	input      textinput.Model
+	scope      domain.SearchScope
 	matchCount int
 }
 
@@ -29,6 +33,12 @@ var matchCountStyle = lipgloss.NewStyle().
 
 // NewSearchOverlay creates a new search overlay
 func NewSearchOverlay() *SearchOverlay {
+	return NewSearchOverlayWithScope(domain.SearchAllFields)
+}
+
+// NewSearchOverlayWithScope creates a search overlay starting in scope,
+// which Tab toggles
+func NewSearchOverlayWithScope(scope domain.SearchScope) *SearchOverlay {
 	ti := textinput.New()
 	ti.Prompt = "/ "
 	ti.Placeholder = "search..."
@@ -38,10 +48,16 @@ func NewSearchOverlay() *SearchOverlay {
 
 	return &SearchOverlay{
 		input:      ti,
+		scope:      scope,
 		matchCount: 0,
 	}
 }
 
+// Scope returns which fields the search matches
+func (s *SearchOverlay) Scope() domain.SearchScope {
+	return s.scope
+}
+
 // SetMatchCount updates the match count display
 func (s *SearchOverlay) SetMatchCount(count int) {
 	s.matchCount = count
@@ -67,9 +83,19 @@ func (s *SearchOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Esc closes and clears filter
 			s.input.SetValue("")
 			return s, tea.Batch(
-				func() tea.Msg { return SearchMsg{Query: ""} },
+				func() tea.Msg { return SearchMsg{Query: "", Scope: s.scope} },
 				func() tea.Msg { return CloseOverlayMsg{} },
 			)
+
+		case tea.KeyTab:
+			// Tab switches between title-only and all fields
+			if s.scope == domain.SearchTitleOnly {
+				s.scope = domain.SearchAllFields
+			} else {
+				s.scope = domain.SearchTitleOnly
+			}
+			query, scope := s.input.Value(), s.scope
+			return s, func() tea.Msg { return SearchMsg{Query: query, Scope: scope} }
 		}
 	}
 
@@ -81,7 +107,7 @@ func (s *SearchOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if s.input.Value() != prevValue {
 		return s, tea.Batch(
 			cmd,
-			func() tea.Msg { return SearchMsg{Query: s.input.Value()} },
+			func() tea.Msg { return SearchMsg{Query: s.input.Value(), Scope: s.scope} },
 		)
 	}
 
@@ -97,6 +123,7 @@ func (s *SearchOverlay) View() string {
 		countText := fmt.Sprintf(" (%d matches)", s.matchCount)
 		inputView += matchCountStyle.Render(countText)
 	}
+	inputView += matchCountStyle.Render(fmt.Sprintf(" [%s • Tab]", s.scope))
 
 	return searchStyle.Render(inputView)
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riordanpawley/azedarach/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -197,6 +198,25 @@ func TestSearchOverlay_View(t *testing.T) {
 	// but we can verify it's not empty
 }
 
+func TestSearchOverlay_TabTogglesScope(t *testing.T) {
+	s := NewSearchOverlay()
+	s.input.SetValue("auth")
+	assert.Equal(t, domain.SearchAllFields, s.Scope())
+	assert.Contains(t, s.View(), "all fields")
+
+	model, cmd := s.Update(tea.KeyMsg{Type: tea.KeyTab})
+	s = model.(*SearchOverlay)
+	require.NotNil(t, cmd)
+	assert.Equal(t, SearchMsg{Query: "auth", Scope: domain.SearchTitleOnly}, cmd())
+	assert.Contains(t, s.View(), "title")
+	assert.Equal(t, "auth", s.input.Value(), "Tab shouldn't edit the query")
+
+	model, cmd = s.Update(tea.KeyMsg{Type: tea.KeyTab})
+	s = model.(*SearchOverlay)
+	assert.Equal(t, SearchMsg{Query: "auth", Scope: domain.SearchAllFields}, cmd())
+	assert.Equal(t, domain.SearchTitleOnly, NewSearchOverlayWithScope(domain.SearchTitleOnly).Scope())
+}
+
 func TestSearchOverlay_NoSearchMsgOnSameValue(t *testing.T) {
 	s := NewSearchOverlay()
 
@@ -0,0 +1,88 @@
+package overlay
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SendTextMsg asks to type Text into a bead's session and press Enter
+type SendTextMsg struct {
+	BeadID string
+	Text   string
+}
+
+// SendTextOverlay reads a line to send to a running session, such as an
+// answer to a question Claude is waiting on
+type SendTextOverlay struct {
+	beadID string
+	input  textinput.Model
+	styles *Styles
+}
+
+// NewSendTextOverlay creates the input for sending a line to beadID's session
+func NewSendTextOverlay(beadID string) *SendTextOverlay {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "text to send..."
+	ti.Focus()
+	ti.CharLimit = 1000
+	ti.Width = 56
+
+	return &SendTextOverlay{
+		beadID: beadID,
+		input:  ti,
+		styles: New(),
+	}
+}
+
+// Init initializes the input
+func (s *SendTextOverlay) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (s *SendTextOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			text := strings.TrimSpace(s.input.Value())
+			if text == "" {
+				return s, nil
+			}
+			beadID := s.beadID
+			return s, func() tea.Msg { return SendTextMsg{BeadID: beadID, Text: text} }
+
+		case tea.KeyEsc:
+			return s, func() tea.Msg { return CloseOverlayMsg{} }
+		}
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return s, cmd
+}
+
+// View renders the input
+func (s *SendTextOverlay) View() string {
+	var b strings.Builder
+
+	b.WriteString(s.styles.MenuItem.Render("Type a line into " + s.beadID + "'s session:"))
+	b.WriteString("\n\n")
+	b.WriteString(s.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(s.styles.Footer.Render("Enter: send • Esc: cancel"))
+
+	return b.String()
+}
+
+// Title returns the overlay title
+func (s *SendTextOverlay) Title() string {
+	return "Send to Session"
+}
+
+// Size returns the overlay dimensions
+func (s *SendTextOverlay) Size() (width, height int) {
+	return 64, 8
+}
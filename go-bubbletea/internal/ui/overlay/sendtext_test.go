@@ -0,0 +1,47 @@
+package overlay
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSendTextOverlay_Enter(t *testing.T) {
+	s := NewSendTextOverlay("az-1")
+	for _, r := range "yes please" {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command")
+	}
+	msg, ok := cmd().(SendTextMsg)
+	if !ok {
+		t.Fatalf("Expected SendTextMsg, got %#v", cmd())
+	}
+	if msg.BeadID != "az-1" || msg.Text != "yes please" {
+		t.Errorf("Expected az-1 / %q, got %s / %q", "yes please", msg.BeadID, msg.Text)
+	}
+}
+
+func TestSendTextOverlay_EmptyEnterDoesNothing(t *testing.T) {
+	s := NewSendTextOverlay("az-1")
+	s.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	if _, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Errorf("Expected no command for blank text, got %#v", cmd())
+	}
+}
+
+func TestSendTextOverlay_Esc(t *testing.T) {
+	s := NewSendTextOverlay("az-1")
+
+	_, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected a command")
+	}
+	if _, ok := cmd().(CloseOverlayMsg); !ok {
+		t.Errorf("Expected CloseOverlayMsg, got %#v", cmd())
+	}
+}
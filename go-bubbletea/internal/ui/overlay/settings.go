@@ -6,7 +6,10 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
 // SettingType represents the type of a setting
@@ -21,6 +24,8 @@ const (
 	SettingAction
 	// SettingSeparator is a visual separator (not selectable)
 	SettingSeparator
+	// SettingText is a free-text value (Enter to edit, Enter again to set)
+	SettingText
 )
 
 // SettingItem represents a single setting in the settings menu
@@ -29,9 +34,10 @@ type SettingItem struct {
 	Label    string
 	Type     SettingType
 	Value    any
-	Choices  []string      // For SettingChoice type
-	OnChange func(any)     // Callback when value changes
-	OnAction func() tea.Cmd // Callback for SettingAction type
+	Choices  []string           // For SettingChoice type
+	OnChange func(any)          // Callback when value changes
+	OnAction func() tea.Cmd     // Callback for SettingAction type
+	OnEdit   func(string) error // For SettingText: applies the edited text, or rejects it
 }
 
 // SettingsOverlay is a settings menu overlay
@@ -39,6 +45,13 @@ type SettingsOverlay struct {
 	items  []SettingItem
 	cursor int
 	styles *Styles
+
+	// Editing a SettingText item in place
+	editing bool
+	input   textinput.Model
+
+	// Problems with the last edit or save, shown under the items
+	errors []string
 }
 
 // NewSettingsOverlay creates a new settings overlay with the given items
@@ -133,6 +146,10 @@ func (m *SettingsOverlay) Init() tea.Cmd {
 
 // Update handles messages
 func (m *SettingsOverlay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.editing {
+		return m.updateEdit(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -212,15 +229,42 @@ func (m *SettingsOverlay) View() string {
 				keyStyle.Render("["+item.Key+"]"),
 				style.Render(item.Label),
 			)
+
+		case SettingText:
+			value, _ := item.Value.(string)
+			if value == "" {
+				value = "(default)"
+			}
+			if m.editing && i == m.cursor {
+				value = m.input.View()
+			}
+			line = fmt.Sprintf("%s %s: %s",
+				keyStyle.Render("["+item.Key+"]"),
+				style.Render(item.Label),
+				value,
+			)
 		}
 
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
 
+	if len(m.errors) > 0 {
+		b.WriteString("\n")
+		errorStyle := lipgloss.NewStyle().Foreground(styles.Red)
+		for _, problem := range m.errors {
+			b.WriteString(errorStyle.Render(problem))
+			b.WriteString("\n")
+		}
+	}
+
 	// Add footer hint
 	b.WriteString("\n")
-	b.WriteString(m.styles.Footer.Render("j/k: navigate • h/l: change choice • space/enter: toggle/activate • esc: close"))
+	if m.editing {
+		b.WriteString(m.styles.Footer.Render("enter: set • esc: cancel"))
+	} else {
+		b.WriteString(m.styles.Footer.Render("j/k: navigate • h/l: change choice • space/enter: toggle/edit/activate • esc: close"))
+	}
 
 	return b.String()
 }
@@ -233,8 +277,64 @@ func (m *SettingsOverlay) Title() string {
 // Size returns the overlay dimensions
 func (m *SettingsOverlay) Size() (width, height int) {
 	// Width: enough for longest setting line
-	// Height: number of items + footer + padding
-	return 60, len(m.items) + 6
+	// Height: number of items + problems + footer + padding
+	height = len(m.items) + 6
+	if len(m.errors) > 0 {
+		height += len(m.errors) + 1
+	}
+	return 72, height
+}
+
+// Errors returns the problems with the last edit or save
+func (m *SettingsOverlay) Errors() []string {
+	return m.errors
+}
+
+// SetErrors shows problems under the items, e.g. a save that failed
+func (m *SettingsOverlay) SetErrors(problems []string) {
+	m.errors = problems
+}
+
+// startEdit edits the current SettingText item in place
+func (m *SettingsOverlay) startEdit() tea.Cmd {
+	item := m.items[m.cursor]
+	value, _ := item.Value.(string)
+
+	m.input = textinput.New()
+	m.input.CharLimit = 200
+	m.input.SetValue(value)
+	m.editing = true
+	return m.input.Focus()
+}
+
+// updateEdit handles messages while a SettingText item is being edited
+func (m *SettingsOverlay) updateEdit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			m.editing = false
+			m.errors = nil
+			return m, nil
+
+		case "enter":
+			item := &m.items[m.cursor]
+			value := strings.TrimSpace(m.input.Value())
+			if item.OnEdit != nil {
+				if err := item.OnEdit(value); err != nil {
+					m.errors = []string{err.Error()}
+					return m, nil
+				}
+			}
+			item.Value = value
+			m.editing = false
+			m.errors = nil
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
 }
 
 // moveCursorDown moves the cursor to the next selectable item
@@ -318,6 +418,9 @@ func (m *SettingsOverlay) activateCurrent() tea.Cmd {
 		}
 		return nil
 
+	case SettingText:
+		return m.startEdit()
+
 	default:
 		return nil
 	}
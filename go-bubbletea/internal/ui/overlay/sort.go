@@ -40,12 +40,24 @@ func NewSortMenu(sort *domain.Sort) *SortMenu {
 				Field:       domain.SortByPriority,
 				Description: "Sort by priority (P0 highest)",
 			},
+			{
+				Key:         "a",
+				Label:       "Age",
+				Field:       domain.SortByAge,
+				Description: "Sort by creation time (oldest first)",
+			},
 			{
 				Key:         "u",
 				Label:       "Updated",
 				Field:       domain.SortByUpdated,
 				Description: "Sort by last updated time",
 			},
+			{
+				Key:         "t",
+				Label:       "Title",
+				Field:       domain.SortByTitle,
+				Description: "Sort alphabetically by title",
+			},
 		},
 	}
 }
@@ -63,7 +75,7 @@ func (m *SortMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "esc", "q":
 			return m, func() tea.Msg { return CloseOverlayMsg{} }
 
-		case "s", "p", "u":
+		case "s", "p", "a", "u", "t":
 			// Find the option for this key
 			for _, opt := range m.options {
 				if opt.Key == msg.String() {
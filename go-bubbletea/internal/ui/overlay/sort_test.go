@@ -24,8 +24,8 @@ func TestNewSortMenu(t *testing.T) {
 		t.Error("expected menu to hold reference to sort state")
 	}
 
-	if len(menu.options) != 3 {
-		t.Errorf("expected 3 sort options, got %d", len(menu.options))
+	if len(menu.options) != 5 {
+		t.Errorf("expected 5 sort options, got %d", len(menu.options))
 	}
 }
 
@@ -48,8 +48,8 @@ func TestSortMenu_Size(t *testing.T) {
 		t.Errorf("expected width 70, got %d", width)
 	}
 
-	// Height should be options + footer + padding (3 + 5 = 8)
-	expectedHeight := 8
+	// Height should be options + footer + padding (5 + 5 = 10)
+	expectedHeight := 10
 	if height != expectedHeight {
 		t.Errorf("expected height %d, got %d", expectedHeight, height)
 	}
@@ -154,6 +154,20 @@ func TestSortMenu_FieldSelection(t *testing.T) {
 			expectField:  domain.SortByUpdated,
 			expectOrder:  domain.SortAsc,
 		},
+		{
+			name:         "Change from Priority to Age",
+			initialField: domain.SortByPriority,
+			pressKey:     "a",
+			expectField:  domain.SortByAge,
+			expectOrder:  domain.SortAsc,
+		},
+		{
+			name:         "Change from Priority to Title",
+			initialField: domain.SortByPriority,
+			pressKey:     "t",
+			expectField:  domain.SortByTitle,
+			expectOrder:  domain.SortAsc,
+		},
 	}
 
 	for _, tt := range tests {
@@ -5,6 +5,7 @@ import tea "github.com/charmbracelet/bubbletea"
 // Stack manages a stack of overlays with push/pop operations
 type Stack struct {
 	overlays []Overlay
+	offsets  []int // Scroll offset of each overlay, for content taller than the screen
 }
 
 // NewStack creates a new empty overlay stack
@@ -17,6 +18,7 @@ func NewStack() *Stack {
 // Push adds an overlay to the top of the stack
 func (s *Stack) Push(o Overlay) tea.Cmd {
 	s.overlays = append(s.overlays, o)
+	s.offsets = append(s.offsets, 0)
 	return o.Init()
 }
 
@@ -29,6 +31,7 @@ func (s *Stack) Pop() Overlay {
 
 	top := s.overlays[len(s.overlays)-1]
 	s.overlays = s.overlays[:len(s.overlays)-1]
+	s.offsets = s.offsets[:len(s.offsets)-1]
 	return top
 }
 
@@ -49,6 +52,25 @@ func (s *Stack) IsEmpty() bool {
 // Clear removes all overlays from the stack
 func (s *Stack) Clear() {
 	s.overlays = make([]Overlay, 0)
+	s.offsets = nil
+}
+
+// ScrollOffset returns how many lines the current overlay is scrolled
+func (s *Stack) ScrollOffset() int {
+	if len(s.offsets) == 0 {
+		return 0
+	}
+	return s.offsets[len(s.offsets)-1]
+}
+
+// Scroll moves the current overlay's scroll offset by delta lines, keeping
+// it between 0 and limit
+func (s *Stack) Scroll(delta, limit int) {
+	if len(s.offsets) == 0 {
+		return
+	}
+	top := len(s.offsets) - 1
+	s.offsets[top] = max(min(s.offsets[top]+delta, limit), 0)
 }
 
 // Update forwards the message to the current overlay and handles CloseOverlayMsg
@@ -274,3 +274,30 @@ func TestStackUpdateForwardsMessages(t *testing.T) {
 		t.Errorf("Expected value 'result', got '%v'", selectionMsg.Value)
 	}
 }
+
+func TestStackScroll(t *testing.T) {
+	stack := NewStack()
+	stack.Scroll(3, 10) // No overlay: nothing to scroll
+
+	stack.Push(mockOverlay{title: "Tall", width: 40, height: 50})
+	stack.Scroll(3, 10)
+	stack.Scroll(20, 10)
+	if got := stack.ScrollOffset(); got != 10 {
+		t.Errorf("Expected offset clamped to 10, got %d", got)
+	}
+
+	// Each overlay keeps its own offset
+	stack.Push(mockOverlay{title: "Short", width: 40, height: 5})
+	if got := stack.ScrollOffset(); got != 0 {
+		t.Errorf("Expected a new overlay to start at 0, got %d", got)
+	}
+	stack.Scroll(-5, 10)
+	if got := stack.ScrollOffset(); got != 0 {
+		t.Errorf("Expected offset clamped to 0, got %d", got)
+	}
+
+	stack.Pop()
+	if got := stack.ScrollOffset(); got != 10 {
+		t.Errorf("Expected the tall overlay's offset back, got %d", got)
+	}
+}
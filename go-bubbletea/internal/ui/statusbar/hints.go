@@ -10,7 +10,7 @@ func GetHints(mode types.Mode) string {
 	case types.ModeGoto:
 		return "g: top  e: end  h: first col  l: last col  Esc: cancel"
 	case types.ModeSelect:
-		return "Space: toggle  a: all  n: none  Esc: cancel"
+		return "Space: toggle  a: all  x: none  Enter: actions  Esc: cancel"
 	case types.ModeSearch:
 		return "Type to search  Enter: confirm  Esc: cancel"
 	case types.ModeAction:
@@ -1,16 +1,27 @@
 package statusbar
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/riordanpawley/azedarach/internal/types"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
 )
 
+// StaleAfter is how old the board can get before its age is shown as
+// stale. Beads refresh every few seconds, so older means refreshing stalled.
+const StaleAfter = 30 * time.Second
+
 // StatusBar represents the status bar at the bottom of the TUI
 type StatusBar struct {
-	mode   types.Mode
-	width  int
-	styles *styles.Styles
+	mode       types.Mode
+	width      int
+	styles     *styles.Styles
+	info       string        // Shown after the mode, e.g. pending offline changes
+	refreshAge time.Duration // Time since the board was refreshed, shown when refreshed
+	refreshed  bool
 }
 
 // New creates a new StatusBar with the given mode, width, and styles
@@ -22,10 +33,55 @@ func New(mode types.Mode, width int, styles *styles.Styles) StatusBar {
 	}
 }
 
+// WithInfo returns the status bar with info shown between the mode and the hints
+func (sb StatusBar) WithInfo(info string) StatusBar {
+	sb.info = info
+	return sb
+}
+
+// WithRefreshAge returns the status bar showing how long ago the board was
+// refreshed, picked out once it's older than StaleAfter
+func (sb StatusBar) WithRefreshAge(age time.Duration) StatusBar {
+	sb.refreshAge = age
+	sb.refreshed = true
+	return sb
+}
+
+// FormatRefreshAge describes how long ago the board was refreshed
+func FormatRefreshAge(age time.Duration) string {
+	switch {
+	case age < time.Second:
+		return "updated just now"
+	case age < time.Minute:
+		return fmt.Sprintf("updated %ds ago", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("updated %dm ago", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("updated %dh ago", int(age.Hours()))
+	}
+}
+
 // Render renders the status bar as a string
 func (sb StatusBar) Render() string {
 	modeBadge := sb.styles.StatusMode.Render(" " + sb.mode.String() + " ")
 
+	// Info goes before the hints so narrow terminals cut the hints instead
+	var info []string
+	if sb.info != "" {
+		info = append(info, sb.styles.StatusInfo.Render(sb.info))
+	}
+	if sb.refreshed {
+		ageStyle := sb.styles.StatusInfo
+		if sb.refreshAge > StaleAfter {
+			ageStyle = sb.styles.StatusStale
+		}
+		info = append(info, ageStyle.Render(FormatRefreshAge(sb.refreshAge)))
+	}
+	for _, part := range info {
+		separator := sb.styles.StatusHint.Render(" │ ")
+		modeBadge = lipgloss.JoinHorizontal(lipgloss.Left, modeBadge, separator, part)
+	}
+
 	// Keybinding hints
 	hints := GetHints(sb.mode)
 	hintsRendered := sb.styles.StatusHint.Render(hints)
@@ -38,6 +94,10 @@ func (sb StatusBar) Render() string {
 	} else {
 		content = modeBadge
 	}
+	if len(info) > 0 {
+		// Keep to one line; the padding takes a cell each side
+		content = ansi.Truncate(content, max(sb.width-2, 0), "…")
+	}
 
 	// Apply status bar style and fill width
 	return sb.styles.StatusBar.Width(sb.width).Render(content)
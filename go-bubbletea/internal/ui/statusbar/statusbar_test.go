@@ -3,6 +3,7 @@ package statusbar
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/riordanpawley/azedarach/internal/types"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
@@ -120,7 +121,7 @@ func TestGetHints_AllModes(t *testing.T) {
 		expected string
 	}{
 		{types.ModeNormal, "h/l: columns  j/k: tasks  Space: action  ?: help  q: quit"},
-		{types.ModeSelect, "Space: toggle  a: all  n: none  Esc: cancel"},
+		{types.ModeSelect, "Space: toggle  a: all  x: none  Enter: actions  Esc: cancel"},
 		{types.ModeSearch, "Type to search  Enter: confirm  Esc: cancel"},
 		{types.ModeGoto, "g: top  e: end  h: first col  l: last col  Esc: cancel"},
 		{types.ModeAction, ""},
@@ -135,3 +136,55 @@ func TestGetHints_AllModes(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusBar_WithInfo(t *testing.T) {
+	style := styles.New()
+	sb := New(types.ModeNormal, 80, style).WithInfo("3 pending changes")
+
+	result := sb.Render()
+
+	if !strings.Contains(result, "3 pending changes") {
+		t.Errorf("Expected status bar to contain the info, got: %s", result)
+	}
+	if strings.Contains(result, "\n") {
+		t.Errorf("Expected status bar to stay on one line, got: %q", result)
+	}
+}
+
+func TestFormatRefreshAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{0, "updated just now"},
+		{500 * time.Millisecond, "updated just now"},
+		{time.Second, "updated 1s ago"},
+		{59 * time.Second, "updated 59s ago"},
+		{time.Minute, "updated 1m ago"},
+		{90 * time.Minute, "updated 1h ago"},
+		{26 * time.Hour, "updated 26h ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age.String(), func(t *testing.T) {
+			if got := FormatRefreshAge(tt.age); got != tt.want {
+				t.Errorf("FormatRefreshAge(%v) = %q, want %q", tt.age, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusBar_WithRefreshAge(t *testing.T) {
+	style := styles.New()
+
+	result := New(types.ModeNormal, 80, style).WithInfo("3 pending changes").WithRefreshAge(45 * time.Second).Render()
+
+	for _, want := range []string{"3 pending changes", "updated 45s ago"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected status bar to contain %q, got: %s", want, result)
+		}
+	}
+	if strings.Contains(result, "\n") {
+		t.Errorf("Expected status bar to stay on one line, got: %q", result)
+	}
+}
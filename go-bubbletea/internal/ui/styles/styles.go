@@ -8,27 +8,39 @@ import (
 // Styles holds all the UI styles
 type Styles struct {
 	// Board
-	Board              lipgloss.Style
-	Column             lipgloss.Style
-	ColumnHeader       lipgloss.Style
-	ColumnHeaderActive lipgloss.Style
+	Board                 lipgloss.Style
+	Column                lipgloss.Style
+	ColumnHeader          lipgloss.Style
+	ColumnHeaderActive    lipgloss.Style
+	ColumnHeaderOverLimit lipgloss.Style // Column holding more tasks than its WIP limit
 
 	// Cards
 	Card         lipgloss.Style
 	CardActive   lipgloss.Style
 	CardSelected lipgloss.Style
+	CardMoved    lipgloss.Style
+	CardBlocked  lipgloss.Style // Open task waiting on unfinished blockers
 	TaskID       lipgloss.Style
 	TaskTitle    lipgloss.Style
+	TaskAge      lipgloss.Style
+	TaskStale    lipgloss.Style
+
+	// Card border colors by task status, from config.Board.StatusColors.
+	// Statuses without one keep the Card style's border.
+	CardStatusColors map[domain.Status]lipgloss.Color
 
 	// Badges
-	PriorityBadge func(priority int) lipgloss.Style
-	TypeBadge     lipgloss.Style
+	PriorityBadge   func(priority int) lipgloss.Style
+	TypeBadge       lipgloss.Style
+	AttachmentBadge lipgloss.Style
+	BlockedBadge    lipgloss.Style
 
 	// Status bar
-	StatusBar  lipgloss.Style
-	StatusMode lipgloss.Style
-	StatusHint lipgloss.Style
-	StatusInfo lipgloss.Style
+	StatusBar   lipgloss.Style
+	StatusMode  lipgloss.Style
+	StatusHint  lipgloss.Style
+	StatusInfo  lipgloss.Style
+	StatusStale lipgloss.Style // Board not refreshed for a while
 
 	// Overlays
 	Overlay          lipgloss.Style
@@ -53,6 +65,17 @@ type Styles struct {
 	SessionPaused  lipgloss.Style
 	SessionIdle    lipgloss.Style
 
+	// Busy session reasons
+	BusyThinking  lipgloss.Style
+	BusyToolUse   lipgloss.Style
+	BusyCompiling lipgloss.Style
+	BusyTesting   lipgloss.Style
+	BusyStalled   lipgloss.Style // No new output for session.stuckTimeoutMs
+
+	// Session branch relative to the base branch
+	BranchAhead  lipgloss.Style
+	BranchBehind lipgloss.Style // Base branch has moved on; update from main
+
 	// Epic progress
 	EpicProgress lipgloss.Style
 }
@@ -80,6 +103,12 @@ func New() *Styles {
 			Padding(0, 1).
 			MarginBottom(1),
 
+		ColumnHeaderOverLimit: lipgloss.NewStyle().
+			Foreground(Red).
+			Bold(true).
+			Padding(0, 1).
+			MarginBottom(1),
+
 		Card: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(Surface1).
@@ -98,6 +127,18 @@ func New() *Styles {
 			Padding(0, 1).
 			MarginBottom(1),
 
+		CardMoved: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(Peach).
+			Padding(0, 1).
+			MarginBottom(1),
+
+		CardBlocked: lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(Maroon).
+			Padding(0, 1).
+			MarginBottom(1),
+
 		TaskID: lipgloss.NewStyle().
 			Foreground(Overlay1).
 			Bold(true),
@@ -105,6 +146,12 @@ func New() *Styles {
 		TaskTitle: lipgloss.NewStyle().
 			Foreground(Text),
 
+		TaskAge: lipgloss.NewStyle().
+			Foreground(Overlay1),
+
+		TaskStale: lipgloss.NewStyle().
+			Foreground(Overlay0),
+
 		PriorityBadge: func(priority int) lipgloss.Style {
 			color := PriorityColors[min(priority, len(PriorityColors)-1)]
 			return lipgloss.NewStyle().
@@ -119,6 +166,12 @@ func New() *Styles {
 			Background(Surface1).
 			Padding(0, 1),
 
+		AttachmentBadge: lipgloss.NewStyle().
+			Foreground(Teal),
+
+		BlockedBadge: lipgloss.NewStyle().
+			Foreground(Red),
+
 		StatusBar: lipgloss.NewStyle().
 			Background(Surface0).
 			Foreground(Subtext0).
@@ -136,6 +189,9 @@ func New() *Styles {
 		StatusInfo: lipgloss.NewStyle().
 			Foreground(Subtext0),
 
+		StatusStale: lipgloss.NewStyle().
+			Foreground(Yellow),
+
 		Overlay: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(Surface2).
@@ -206,11 +262,45 @@ func New() *Styles {
 		SessionIdle: lipgloss.NewStyle().
 			Foreground(Subtext0),
 
+		BusyThinking: lipgloss.NewStyle().
+			Foreground(Mauve),
+
+		BusyToolUse: lipgloss.NewStyle().
+			Foreground(Teal),
+
+		BusyCompiling: lipgloss.NewStyle().
+			Foreground(Peach),
+
+		BusyTesting: lipgloss.NewStyle().
+			Foreground(Sapphire),
+
+		BusyStalled: lipgloss.NewStyle().
+			Foreground(Maroon).
+			Bold(true),
+
+		BranchAhead: lipgloss.NewStyle().
+			Foreground(Subtext0),
+
+		BranchBehind: lipgloss.NewStyle().
+			Foreground(Yellow),
+
 		EpicProgress: lipgloss.NewStyle().
 			Foreground(Subtext0),
 	}
 }
 
+// SetCardStatusColors sets the card border colors by status from config,
+// keyed by status and valued as ParseColor reads them. Colors that don't
+// parse are left out.
+func (s *Styles) SetCardStatusColors(colors map[string]string) {
+	s.CardStatusColors = make(map[domain.Status]lipgloss.Color, len(colors))
+	for status, value := range colors {
+		if color, ok := ParseColor(value); ok {
+			s.CardStatusColors[domain.Status(status)] = color
+		}
+	}
+}
+
 // SessionState returns the appropriate style for a session state
 func (s *Styles) SessionState(state domain.SessionState) lipgloss.Style {
 	switch state {
@@ -230,3 +320,36 @@ func (s *Styles) SessionState(state domain.SessionState) lipgloss.Style {
 		return s.SessionIdle
 	}
 }
+
+// BusyReason returns the style for a busy session doing reason, falling back
+// to the plain busy style
+func (s *Styles) BusyReason(reason domain.BusyReason) lipgloss.Style {
+	switch reason {
+	case domain.BusyThinking:
+		return s.BusyThinking
+	case domain.BusyToolUse:
+		return s.BusyToolUse
+	case domain.BusyCompiling:
+		return s.BusyCompiling
+	case domain.BusyTesting:
+		return s.BusyTesting
+	default:
+		return s.SessionBusy
+	}
+}
+
+// PRState returns the appropriate style for a pull request state
+func (s *Styles) PRState(state domain.PRState) lipgloss.Style {
+	switch state {
+	case domain.PRApproved:
+		return lipgloss.NewStyle().Foreground(Green)
+	case domain.PRChangesRequested:
+		return lipgloss.NewStyle().Foreground(Peach)
+	case domain.PRMerged:
+		return lipgloss.NewStyle().Foreground(Mauve)
+	case domain.PRClosed:
+		return lipgloss.NewStyle().Foreground(Overlay0)
+	default:
+		return lipgloss.NewStyle().Foreground(Blue)
+	}
+}
@@ -2,6 +2,8 @@ package styles
 
 import (
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestNew(t *testing.T) {
@@ -63,3 +65,28 @@ func TestThemeColors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  lipgloss.Color
+		ok    bool
+	}{
+		{value: "red", want: Red, ok: true},
+		{value: "Blue", want: Blue, ok: true},
+		{value: "#ff8800", want: lipgloss.Color("#ff8800"), ok: true},
+		{value: "#f80", want: lipgloss.Color("#f80"), ok: true},
+		{value: "208", want: lipgloss.Color("208"), ok: true},
+		{value: "256", ok: false},
+		{value: "crimson", ok: false},
+		{value: "#ff88", ok: false},
+		{value: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseColor(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseColor(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package styles
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Catppuccin Mocha palette (matching TypeScript version)
 var (
@@ -50,6 +56,45 @@ var PriorityColors = []lipgloss.Color{
 	Overlay0, // P4 - Backlog
 }
 
+// NamedColors maps the palette's names, as written in config, to colors
+var NamedColors = map[string]lipgloss.Color{
+	"text":      Text,
+	"subtext":   Subtext0,
+	"overlay":   Overlay0,
+	"surface":   Surface2,
+	"red":       Red,
+	"green":     Green,
+	"blue":      Blue,
+	"yellow":    Yellow,
+	"peach":     Peach,
+	"mauve":     Mauve,
+	"pink":      Pink,
+	"teal":      Teal,
+	"sky":       Sky,
+	"sapphire":  Sapphire,
+	"lavender":  Lavender,
+	"flamingo":  Flamingo,
+	"rosewater": Rosewater,
+	"maroon":    Maroon,
+}
+
+// ParseColor returns the color a config value names: a palette name such
+// as "red", a hex color such as "#f38ba8", or an ANSI color number
+func ParseColor(value string) (lipgloss.Color, bool) {
+	if color, ok := NamedColors[strings.ToLower(value)]; ok {
+		return color, true
+	}
+	if hexColorPattern.MatchString(value) {
+		return lipgloss.Color(value), true
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(value), true
+	}
+	return "", false
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
 // StatusColors maps status to colors
 var StatusColors = map[string]lipgloss.Color{
 	"open":        Blue,
@@ -1,6 +1,8 @@
 package toast
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/riordanpawley/azedarach/internal/types"
 	"github.com/riordanpawley/azedarach/internal/ui/styles"
@@ -33,7 +35,11 @@ func (r *ToastRenderer) Render(toasts []types.Toast, width int) string {
 
 	for _, t := range toasts {
 		style := r.styleForLevel(t.Level)
-		rendered = append(rendered, style.Width(toastWidth).Render(t.Message))
+		message := t.Message
+		if t.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, t.Count)
+		}
+		rendered = append(rendered, style.Width(toastWidth).Render(message))
 	}
 
 	// Stack toasts vertically, aligned to the right
@@ -119,3 +119,20 @@ func TestToastRenderer_styleForLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestToastRenderer_Render_RepeatCount(t *testing.T) {
+	renderer := New(styles.New())
+
+	toasts := []types.Toast{
+		{
+			Level:   types.ToastError,
+			Message: "Load failed",
+			Expires: time.Now().Add(5 * time.Second),
+			Count:   3,
+		},
+	}
+
+	result := renderer.Render(toasts, 120)
+
+	assert.Contains(t, result, "Load failed (x3)", "Should show repeat counter")
+}